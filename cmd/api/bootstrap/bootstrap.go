@@ -3,19 +3,30 @@ package bootstrap
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"os"
 	"time"
 
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/auditing"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/authenticating"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/authorizing"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/creating"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/deleting"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/getting"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/listing"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/notifying"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/auth"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/bus/inmemory"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/bus/outbox"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/mail"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/server"
-	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/storage/sqldb"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/webhook"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/purging"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/registering"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/restoring"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/searching"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/updating"
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
@@ -37,28 +48,82 @@ type config struct {
 	Dbname     string
 	Dbtimeout  time.Duration
 
-	// Login configuration
-	Jwtkey     auth.JWTKey
-	Jwtexpires time.Duration
+	// Login configuration. Access tokens are short-lived; sessions are kept alive with
+	// refresh tokens
+	Jwtkey         auth.JWTKey
+	Jwtexpires     time.Duration `default:"15m"`
+	Refreshexpires time.Duration `default:"720h"`
+
+	// Access token signing: "HS256" with Jwtkey, or "RS256" or "EdDSA" with rotating keys,
+	// stored encrypted with Jwtkeysecret
+	Jwtalgorithm   string        `default:"HS256"`
+	Jwtkeyrotation time.Duration `default:"720h"`
+	Jwtkeypublish  time.Duration `default:"1h"`
+	Jwtkeyinterval time.Duration `default:"1m"`
+	Jwtkeysecret   auth.JWTKey
 
 	// Frontend configuration
 	Frontendurl string
-}
 
-func Run() error {
+	// Registration: the verification links mailed to the new users point to
+	// Verificationurl, with the token as a query parameter. A user gets at most one new
+	// link every Verificationresend
+	Verificationurl     string        `default:"http://localhost:8080/verify"`
+	Verificationexpires time.Duration `default:"48h"`
+	Verificationresend  time.Duration `default:"10m"`
+
+	// Mail configuration: "smtp", or "log" to write the emails to Mailfile (the standard
+	// output when empty) instead of sending them
+	Mailer       string `default:"log"`
+	Mailfile     string
+	Mailfrom     string `default:"no-reply@localhost"`
+	Smtphost     string
+	Smtpport     int `default:"587"`
+	Smtpusername string
+	Smtppassword string
+
+	// Apply pending migrations at startup
+	Automigrate bool
+
+	// Storage backend: "postgres" (default) or "memory"
+	Storage string
+
+	// Event bus configuration
+	Eventworkers     int           `default:"4"`
+	Eventqueuesize   int           `default:"1024"`
+	Eventmaxattempts int           `default:"5"`
+	Eventbackoff     time.Duration `default:"500ms"`
+
+	// Outbox relay configuration
+	Outboxinterval  time.Duration `default:"1s"`
+	Outboxlease     time.Duration `default:"1m"`
+	Outboxbatchsize int           `default:"100"`
+
+	// Webhook delivery configuration
+	Webhookworkers     int           `default:"4"`
+	Webhookinterval    time.Duration `default:"1s"`
+	Webhooklease       time.Duration `default:"1m"`
+	Webhookmaxattempts int           `default:"5"`
+	Webhookbackoff     time.Duration `default:"1s"`
+	Webhooktimeout     time.Duration `default:"10s"`
+}
 
+func loadConfig() (config, error) {
 	if os.Getenv("MELA_ENV") == "" {
 		if err := godotenv.Load(".env.local"); err != nil {
-			return fmt.Errorf("error loading .env file: %w", err)
+			return config{}, fmt.Errorf("error loading .env file: %w", err)
 		}
 	}
 
 	var cfg config
-	err := envconfig.Process("mela", &cfg)
-	if err != nil {
-		return err
+	if err := envconfig.Process("mela", &cfg); err != nil {
+		return config{}, err
 	}
 
+	return cfg, nil
+}
+
+func openDB(cfg config) (*sql.DB, error) {
 	var postgreURI string
 	if os.Getenv("DATABASE_URL") != "" {
 		postgreURI = os.Getenv("DATABASE_URL")
@@ -67,44 +132,160 @@ func Run() error {
 	}
 	db, err := sql.Open("postgres", postgreURI)
 	if err != nil {
-		return fmt.Errorf("failed to connect to database: %w", err)
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	return db, nil
+}
+
+// signingHMAC is the MELA_JWTALGORITHM that signs the access tokens with MELA_JWTKEY.
+const signingHMAC = "HS256"
+
+// newSigner returns the signer of the access tokens configured in cfg. The keys of RS256
+// and EdDSA are loaded, and the first one generated, before it returns; the rotator that
+// keeps them up to date is nil with HS256.
+func newSigner(cfg config, signingKeys domain.SigningKeyRepository) (auth.Signer, *auth.KeyRotator, error) {
+	if cfg.Jwtalgorithm == signingHMAC {
+		if len(cfg.Jwtkey) == 0 {
+			return nil, nil, errors.New("MELA_JWTKEY is required to sign with HS256")
+		}
+		return auth.NewHMACSigner(cfg.Jwtkey), nil, nil
+	}
+
+	algorithm, err := domain.NewSigningAlgorithm(cfg.Jwtalgorithm)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid MELA_JWTALGORITHM %q: %w", cfg.Jwtalgorithm, err)
+	}
+
+	if len(cfg.Jwtkeysecret) == 0 {
+		return nil, nil, fmt.Errorf("MELA_JWTKEYSECRET is required to sign with %s", algorithm)
+	}
+	signingKeys, err = auth.NewSealedSigningKeyRepository(signingKeys, cfg.Jwtkeysecret)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid MELA_JWTKEYSECRET: %w", err)
+	}
+
+	keys := auth.NewKeySet()
+	rotator := auth.NewKeyRotator(signingKeys, keys, algorithm, cfg.Jwtkeyrotation, cfg.Jwtkeypublish, cfg.Jwtexpires, cfg.Jwtkeyinterval)
+	if err := rotator.Rotate(context.Background()); err != nil {
+		return nil, nil, fmt.Errorf("failed to load the signing keys: %w", err)
+	}
+
+	return keys, rotator, nil
+}
+
+// Mailers of MELA_MAILER.
+const (
+	mailerLog  = "log"
+	mailerSMTP = "smtp"
+)
+
+// newMailer returns the mailer configured in cfg. The file of the log mailer stays open
+// for the life of the process.
+func newMailer(cfg config) (registering.Mailer, error) {
+	switch cfg.Mailer {
+	case mailerSMTP:
+		if cfg.Smtphost == "" {
+			return nil, errors.New("MELA_SMTPHOST is required to send emails over SMTP")
+		}
+		return mail.NewSMTPMailer(cfg.Smtphost, cfg.Smtpport, cfg.Smtpusername, cfg.Smtppassword, cfg.Mailfrom), nil
+	case mailerLog, "":
+		if cfg.Mailfile == "" {
+			return mail.NewLogMailer(os.Stdout, cfg.Mailfrom), nil
+		}
+		f, err := os.OpenFile(cfg.Mailfile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open MELA_MAILFILE: %w", err)
+		}
+		return mail.NewLogMailer(f, cfg.Mailfrom), nil
+	default:
+		return nil, fmt.Errorf("invalid MELA_MAILER %q", cfg.Mailer)
+	}
+}
+
+func Run() error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	repos, err := newRepositories(cfg)
+	if err != nil {
+		return err
 	}
 
 	var (
-		commandBus = inmemory.NewCommandBus()
+		commandBus = auditing.NewCommandBus(inmemory.NewCommandBus(), repos.audit, repos.txManager)
 		queryBus   = inmemory.NewQueryBus()
-		eventBus   = inmemory.NewEventBus()
+
+		// The services publish to the outbox, in the transaction of their changes; the
+		// relay then hands the events to the handlers subscribed to the async bus
+		asyncEventBus = inmemory.NewAsyncEventBus(cfg.Eventworkers, cfg.Eventqueuesize, cfg.Eventmaxattempts, cfg.Eventbackoff, repos.deadLetters)
+		eventBus      = outbox.NewBus(repos.outbox, asyncEventBus)
+		relay         = outbox.NewRelay(repos.outbox, asyncEventBus, cfg.Outboxinterval, cfg.Outboxlease, cfg.Outboxbatchsize)
 	)
 
-	userRepository := sqldb.NewUserRepository(db, cfg.Dbtimeout)
-	movieRepository := sqldb.NewMovieRepository(db, cfg.Dbtimeout)
-	groupRepository := sqldb.NewGroupRepository(db, cfg.Dbtimeout)
-	categoryRepository := sqldb.NewCategoryRepository(db, cfg.Dbtimeout)
-	trackRepository := sqldb.NewTrackRepository(db, cfg.Dbtimeout)
-	themeRepository := sqldb.NewThemeRepository(db, cfg.Dbtimeout)
-	trackThemeRepository := sqldb.NewTrackThemeRepository(db, cfg.Dbtimeout)
+	signer, rotator, err := newSigner(cfg, repos.signingKeys)
+	if err != nil {
+		return err
+	}
 
-	authenticatingService := authenticating.NewLoginService(userRepository, cfg.Jwtkey, cfg.Jwtexpires)
+	authenticatingService := authenticating.NewLoginService(repos.users, repos.sessions, signer, cfg.Jwtexpires, cfg.Refreshexpires)
+	authenticatingSessionService := authenticating.NewSessionService(repos.users, repos.sessions, repos.revocations, signer, cfg.Jwtexpires, cfg.Refreshexpires)
 	queryBus.Register(authenticating.LoginQueryType, authenticating.NewLoginQueryHandler(authenticatingService))
+	queryBus.Register(authenticating.RefreshQueryType, authenticating.NewRefreshQueryHandler(authenticatingSessionService))
+	queryBus.Register(authenticating.RevokedQueryType, authenticating.NewRevokedQueryHandler(authenticatingSessionService))
+	commandBus.Register(authenticating.LogoutCommandType, authenticating.NewLogoutCommandHandler(authenticatingSessionService))
+	commandBus.Register(authenticating.RevokeSessionsCommandType, authenticating.NewRevokeSessionsCommandHandler(authenticatingSessionService))
+
+	mailer, err := newMailer(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Visitors sign up unverified and get a verification link mailed on UserCreatedEvent.
+	// Users created by an admin are verified already, so they get no email. New links asked
+	// for are mailed on VerificationRequestedEvent
+	registeringService := registering.NewRegisterService(repos.users, repos.txManager, eventBus)
+	registeringVerificationService := registering.NewVerificationService(repos.users, repos.verificationTokens, repos.txManager, eventBus, mailer, cfg.Verificationurl, cfg.Verificationexpires, cfg.Verificationresend)
+	commandBus.Register(registering.RegisterCommandType, registering.NewRegisterCommandHandler(registeringService))
+	commandBus.Register(registering.VerifyCommandType, registering.NewVerifyCommandHandler(registeringVerificationService))
+	commandBus.Register(registering.ResendVerificationCommandType, registering.NewResendVerificationCommandHandler(registeringVerificationService))
+	eventBus.Subscribe(domain.UserCreatedEventType, registering.NewSendVerificationOnUserCreated(registeringVerificationService))
+	eventBus.Subscribe(domain.VerificationRequestedEventType, registering.NewSendVerificationOnVerificationRequested(registeringVerificationService))
+
+	authorizingService := authorizing.NewRoleService(repos.users, repos.roles)
+	queryBus.Register(authorizing.PermissionQueryType, authorizing.NewPermissionQueryHandler(authorizingService))
 
-	gettingMovieService := getting.NewMovieService(movieRepository)
-	gettingGroupService := getting.NewGroupService(groupRepository)
-	gettingCategoryService := getting.NewCategoryService(categoryRepository)
-	gettingTrackService := getting.NewTrackService(trackRepository, gettingMovieService)
-	gettingThemeService := getting.NewThemeService(themeRepository, gettingTrackService, gettingGroupService, gettingCategoryService)
+	gettingUserService := getting.NewUserService(repos.users)
+	gettingMovieService := getting.NewMovieService(repos.movies)
+	gettingGroupService := getting.NewGroupService(repos.groups)
+	gettingCategoryService := getting.NewCategoryService(repos.categories)
+	gettingTrackService := getting.NewTrackService(repos.tracks, gettingMovieService)
+	gettingThemeService := getting.NewThemeService(repos.themes, gettingTrackService, gettingGroupService, gettingCategoryService)
+	gettingWebhookService := getting.NewWebhookService(repos.webhooks)
+	queryBus.Register(getting.UsersQueryType, getting.NewUsersQueryHandler(gettingUserService))
 	queryBus.Register(getting.MoviesQueryType, getting.NewMoviesQueryHandler(gettingMovieService))
 	queryBus.Register(getting.GroupsQueryType, getting.NewGroupsQueryHandler(gettingGroupService))
 	queryBus.Register(getting.CategoriesQueryType, getting.NewCategoriesQueryHandler(gettingCategoryService))
 	queryBus.Register(getting.TracksQueryType, getting.NewTracksQueryHandler(gettingTrackService))
 	queryBus.Register(getting.ThemesQueryType, getting.NewThemesQueryHandler(gettingThemeService))
 
-	creatingUserService := creating.NewUserService(userRepository, eventBus)
-	creatingMovieService := creating.NewMovieService(movieRepository)
-	creatingGroupService := creating.NewGroupService(groupRepository)
-	creatingCategoryService := creating.NewCategoryService(categoryRepository)
-	creatingTrackService := creating.NewTrackService(trackRepository)
-	creatingThemeService := creating.NewThemeService(themeRepository)
-	creatingTrackThemeService := creating.NewTrackThemeService(trackThemeRepository)
+	commandBus.RegisterLoader(domain.AuditEntityUser, auditing.NewLoader(gettingUserService.GetUser, domain.ErrUserNotFound))
+	commandBus.RegisterLoader(domain.AuditEntityMovie, auditing.NewLoader(gettingMovieService.GetMovie, domain.ErrMovieNotFound))
+	commandBus.RegisterLoader(domain.AuditEntityGroup, auditing.NewLoader(gettingGroupService.GetGroup, domain.ErrGroupNotFound))
+	commandBus.RegisterLoader(domain.AuditEntityCategory, auditing.NewLoader(gettingCategoryService.GetCategory, domain.ErrCategoryNotFound))
+	commandBus.RegisterLoader(domain.AuditEntityTrack, auditing.NewLoader(gettingTrackService.GetTrack, domain.ErrTrackNotFound))
+	commandBus.RegisterLoader(domain.AuditEntityTheme, auditing.NewLoader(gettingThemeService.GetTheme, domain.ErrThemeNotFound))
+	commandBus.RegisterLoader(domain.AuditEntityWebhook, auditing.NewLoader(gettingWebhookService.GetWebhook, domain.ErrWebhookNotFound))
+
+	creatingUserService := creating.NewUserService(repos.users, repos.txManager, eventBus)
+	creatingMovieService := creating.NewMovieService(repos.movies, repos.txManager, eventBus)
+	creatingGroupService := creating.NewGroupService(repos.groups, repos.txManager, eventBus)
+	creatingCategoryService := creating.NewCategoryService(repos.categories, repos.txManager, eventBus)
+	creatingTrackService := creating.NewTrackService(repos.tracks, repos.txManager, eventBus)
+	creatingThemeService := creating.NewThemeService(repos.themes, repos.trackThemes, repos.tracks, repos.txManager, eventBus)
+	creatingTrackThemeService := creating.NewTrackThemeService(repos.trackThemes, repos.tracks, repos.txManager, eventBus)
 	commandBus.Register(creating.UserCommandType, creating.NewUserCommandHandler(creatingUserService))
 	commandBus.Register(creating.MovieCommandType, creating.NewMovieCommandHandler(creatingMovieService))
 	commandBus.Register(creating.GroupCommandType, creating.NewGroupCommandHandler(creatingGroupService))
@@ -113,13 +294,22 @@ func Run() error {
 	commandBus.Register(creating.ThemeCommandType, creating.NewThemeCommandHandler(creatingThemeService))
 	commandBus.Register(creating.TrackThemeCommandType, creating.NewTrackThemeCommandHandler(creatingTrackThemeService))
 
-	listingUserService := listing.NewUserService(userRepository)
-	listingMovieService := listing.NewMovieService(movieRepository)
-	listingGroupService := listing.NewGroupService(groupRepository)
-	listingCategoryService := listing.NewCategoryService(categoryRepository)
-	listingTrackService := listing.NewTrackService(trackRepository, listingMovieService, gettingMovieService)
-	listingThemeService := listing.NewThemeService(themeRepository, listingTrackService, listingGroupService, listingCategoryService, gettingGroupService, gettingTrackService, gettingCategoryService)
-	listingTrackThemeService := listing.NewTrackThemeService(trackThemeRepository, gettingTrackService, gettingThemeService)
+	creatingWebhookService := creating.NewWebhookService(repos.webhooks)
+	commandBus.Register(creating.WebhookCommandType, creating.NewWebhookCommandHandler(creatingWebhookService))
+
+	updatingWebhookService := updating.NewWebhookService(repos.webhooks)
+	commandBus.Register(updating.WebhookCommandType, updating.NewWebhookCommandHandler(updatingWebhookService))
+
+	deletingWebhookService := deleting.NewWebhookService(repos.webhooks)
+	commandBus.Register(deleting.WebhookCommandType, deleting.NewWebhookCommandHandler(deletingWebhookService))
+
+	listingUserService := listing.NewUserService(repos.users)
+	listingMovieService := listing.NewMovieService(repos.movies)
+	listingGroupService := listing.NewGroupService(repos.groups)
+	listingCategoryService := listing.NewCategoryService(repos.categories)
+	listingTrackService := listing.NewTrackService(repos.trackViews)
+	listingThemeService := listing.NewThemeService(repos.themeViews)
+	listingTrackThemeService := listing.NewTrackThemeService(repos.trackThemeViews, repos.themes)
 	queryBus.Register(listing.UsersQueryType, listing.NewUsersQueryHandler(listingUserService))
 	queryBus.Register(listing.MoviesQueryType, listing.NewMoviesQueryHandler(listingMovieService))
 	queryBus.Register(listing.GroupsQueryType, listing.NewGroupsQueryHandler(listingGroupService))
@@ -129,26 +319,48 @@ func Run() error {
 	queryBus.Register(listing.ThemesQueryType, listing.NewThemesQueryHandler(listingThemeService))
 	queryBus.Register(listing.ThemesByGroupQueryType, listing.NewThemesByGroupQueryHandler(listingThemeService))
 	queryBus.Register(listing.TracksThemesByTrackQueryType, listing.NewTracksThemesByTrackQueryHandler(listingTrackThemeService))
+	queryBus.Register(listing.TracksThemesByThemeQueryType, listing.NewTracksThemesByThemeQueryHandler(listingTrackThemeService))
+
+	listingTrashService := listing.NewTrashService(repos.trash)
+	queryBus.Register(listing.TrashQueryType, listing.NewTrashQueryHandler(listingTrashService))
+
+	listingAuditService := listing.NewAuditService(repos.audit)
+	queryBus.Register(listing.AuditQueryType, listing.NewAuditQueryHandler(listingAuditService))
+
+	listingWebhookService := listing.NewWebhookService(repos.webhooks, repos.webhookDeliveries)
+	queryBus.Register(listing.WebhooksQueryType, listing.NewWebhooksQueryHandler(listingWebhookService))
+	queryBus.Register(listing.WebhookDeliveriesQueryType, listing.NewWebhookDeliveriesQueryHandler(listingWebhookService))
 
-	updatingMovieService := updating.NewMovieService(movieRepository)
-	updatingGroupService := updating.NewGroupService(groupRepository)
-	updatingCategoryService := updating.NewCategoryService(categoryRepository)
-	updatingTrackService := updating.NewTrackService(trackRepository)
-	updatingThemeService := updating.NewThemeService(themeRepository)
-	updatingTrackThemeService := updating.NewTrackThemeService(trackThemeRepository)
+	searchingService := searching.NewSearchService(repos.search)
+	queryBus.Register(searching.SearchQueryType, searching.NewSearchQueryHandler(searchingService))
+
+	updatingUserService := updating.NewUserService(repos.users, repos.sessions, repos.txManager, eventBus)
+	updatingMovieService := updating.NewMovieService(repos.movies, repos.txManager, eventBus)
+	updatingGroupService := updating.NewGroupService(repos.groups, repos.txManager, eventBus)
+	updatingCategoryService := updating.NewCategoryService(repos.categories, repos.txManager, eventBus)
+	updatingTrackService := updating.NewTrackService(repos.tracks, repos.txManager, eventBus)
+	updatingThemeService := updating.NewThemeService(repos.themes, repos.txManager, eventBus)
+	updatingTrackThemeService := updating.NewTrackThemeService(repos.trackThemes, repos.tracks, repos.txManager, eventBus)
+	commandBus.Register(updating.UserCommandType, updating.NewUserCommandHandler(updatingUserService))
+	commandBus.Register(updating.UserPasswordCommandType, updating.NewUserPasswordCommandHandler(updatingUserService))
+	commandBus.Register(updating.UserRoleCommandType, updating.NewUserRoleCommandHandler(updatingUserService))
 	commandBus.Register(updating.MovieCommandType, updating.NewMovieCommandHandler(updatingMovieService))
 	commandBus.Register(updating.GroupCommandType, updating.NewGroupCommandHandler(updatingGroupService))
 	commandBus.Register(updating.CategoryCommandType, updating.NewCategoryCommandHandler(updatingCategoryService))
 	commandBus.Register(updating.TrackCommandType, updating.NewTrackCommandHandler(updatingTrackService))
 	commandBus.Register(updating.ThemeCommandType, updating.NewThemeCommandHandler(updatingThemeService))
 	commandBus.Register(updating.TrackThemeCommandType, updating.NewTrackThemeCommandHandler(updatingTrackThemeService))
+	commandBus.Register(updating.GroupDescriptionCommandType, updating.NewGroupDescriptionCommandHandler(updatingGroupService))
+	commandBus.Register(updating.ThemeDescriptionCommandType, updating.NewThemeDescriptionCommandHandler(updatingThemeService))
 
-	deletingMovieService := deleting.NewMovieService(movieRepository)
-	deletingGroupService := deleting.NewGroupService(groupRepository)
-	deletingCategoryService := deleting.NewCategoryService(categoryRepository)
-	deletingTrackService := deleting.NewTrackService(trackRepository)
-	deletingThemeService := deleting.NewThemeService(themeRepository)
-	deletingTrackThemeService := deleting.NewTrackThemeService(trackThemeRepository)
+	deletingUserService := deleting.NewUserService(repos.users, repos.txManager, eventBus)
+	deletingMovieService := deleting.NewMovieService(repos.movies, repos.tracks, repos.themes, repos.txManager, eventBus)
+	deletingGroupService := deleting.NewGroupService(repos.groups, repos.themes, repos.txManager, eventBus)
+	deletingCategoryService := deleting.NewCategoryService(repos.categories, repos.themes, repos.txManager, eventBus)
+	deletingTrackService := deleting.NewTrackService(repos.tracks, repos.themes, repos.txManager, eventBus)
+	deletingThemeService := deleting.NewThemeService(repos.themes, repos.txManager, eventBus)
+	deletingTrackThemeService := deleting.NewTrackThemeService(repos.trackThemes, repos.txManager, eventBus)
+	commandBus.Register(deleting.UserCommandType, deleting.NewUserCommandHandler(deletingUserService))
 	commandBus.Register(deleting.MovieCommandType, deleting.NewMovieCommandHandler(deletingMovieService))
 	commandBus.Register(deleting.GroupCommandType, deleting.NewGroupCommandHandler(deletingGroupService))
 	commandBus.Register(deleting.CategoryCommandType, deleting.NewCategoryCommandHandler(deletingCategoryService))
@@ -156,6 +368,36 @@ func Run() error {
 	commandBus.Register(deleting.ThemeCommandType, deleting.NewThemeCommandHandler(deletingThemeService))
 	commandBus.Register(deleting.TrackThemeCommandType, deleting.NewTrackThemeCommandHandler(deletingTrackThemeService))
 
+	restoringMovieService := restoring.NewMovieService(repos.movies, repos.txManager, eventBus)
+	restoringGroupService := restoring.NewGroupService(repos.groups, repos.txManager, eventBus)
+	restoringCategoryService := restoring.NewCategoryService(repos.categories, repos.txManager, eventBus)
+	restoringTrackService := restoring.NewTrackService(repos.tracks, repos.movies, repos.txManager, eventBus)
+	restoringThemeService := restoring.NewThemeService(repos.themes, repos.groups, repos.categories, repos.tracks, repos.txManager, eventBus)
+	commandBus.Register(restoring.MovieCommandType, restoring.NewMovieCommandHandler(restoringMovieService))
+	commandBus.Register(restoring.GroupCommandType, restoring.NewGroupCommandHandler(restoringGroupService))
+	commandBus.Register(restoring.CategoryCommandType, restoring.NewCategoryCommandHandler(restoringCategoryService))
+	commandBus.Register(restoring.TrackCommandType, restoring.NewTrackCommandHandler(restoringTrackService))
+	commandBus.Register(restoring.ThemeCommandType, restoring.NewThemeCommandHandler(restoringThemeService))
+
+	purgingMovieService := purging.NewMovieService(repos.movies, repos.txManager, eventBus)
+	purgingGroupService := purging.NewGroupService(repos.groups, repos.txManager, eventBus)
+	purgingCategoryService := purging.NewCategoryService(repos.categories, repos.txManager, eventBus)
+	purgingTrackService := purging.NewTrackService(repos.tracks, repos.trackThemes, repos.txManager, eventBus)
+	purgingThemeService := purging.NewThemeService(repos.themes, repos.trackThemes, repos.txManager, eventBus)
+	commandBus.Register(purging.MovieCommandType, purging.NewMovieCommandHandler(purgingMovieService))
+	commandBus.Register(purging.GroupCommandType, purging.NewGroupCommandHandler(purgingGroupService))
+	commandBus.Register(purging.CategoryCommandType, purging.NewCategoryCommandHandler(purgingCategoryService))
+	commandBus.Register(purging.TrackCommandType, purging.NewTrackCommandHandler(purgingTrackService))
+	commandBus.Register(purging.ThemeCommandType, purging.NewThemeCommandHandler(purgingThemeService))
+
+	// Every catalogue event is offered to the webhooks; the service only posts it to the
+	// ones subscribed to its type
+	notifyingWebhookService := notifying.NewWebhookService(repos.webhooks, repos.webhookDeliveries, repos.txManager, webhook.NewHTTPSender(cfg.Webhooktimeout, domain.EventCodec{}), cfg.Webhookworkers, cfg.Webhookinterval, cfg.Webhooklease, cfg.Webhookmaxattempts, cfg.Webhookbackoff)
+	notifyWebhooks := notifying.NewNotifyWebhooksOnEvent(notifyingWebhookService)
+	for _, eventType := range domain.WebhookEventTypes() {
+		eventBus.Subscribe(eventType, notifyWebhooks)
+	}
+
 	// At the moment, this is not implemented. It shows how an inmemory event bus can be used to handle events.
 	// increasingUserCounterService := increasing.NewUserCounterIncreaserService()
 	// eventBus.Subscribe(
@@ -163,6 +405,42 @@ func Run() error {
 	// 	creating.NewIncreaseUsersCounterOnUserCreated(increasingUserCounterService),
 	// )
 
-	ctx, srv := server.New(context.Background(), cfg.Host, cfg.Port, cfg.Shutdowntimeout, commandBus, queryBus, cfg.Jwtkey, cfg.Frontendurl)
-	return srv.Run(ctx)
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	relayErr := make(chan error, 1)
+	go func() { relayErr <- relay.Run(relayCtx) }()
+
+	webhooksCtx, stopWebhooks := context.WithCancel(context.Background())
+	defer stopWebhooks()
+	webhooksErr := make(chan error, 1)
+	go func() { webhooksErr <- notifyingWebhookService.Run(webhooksCtx) }()
+
+	if rotator != nil {
+		rotatorCtx, stopRotator := context.WithCancel(context.Background())
+		defer stopRotator()
+		go rotator.Run(rotatorCtx)
+	}
+
+	ctx, srv := server.New(context.Background(), cfg.Host, cfg.Port, cfg.Shutdowntimeout, commandBus, queryBus, signer, cfg.Frontendurl)
+	runErr := srv.Run(ctx)
+
+	// The server no longer takes requests, so nothing else is saved to the outbox: relay
+	// what is left of it, then let the handlers finish with the events already queued.
+	// Whatever they do not get to stays in the outbox for the next start.
+	stopRelay()
+	runErr = errors.Join(runErr, <-relayErr)
+
+	drainCtx, cancel := context.WithTimeout(context.Background(), cfg.Shutdowntimeout)
+	defer cancel()
+	runErr = errors.Join(runErr, asyncEventBus.Shutdown(drainCtx))
+
+	// The webhooks are notified last, once the handlers have scheduled their deliveries.
+	// The attempts the workers do not get to stay in the delivery log for the next start.
+	stopWebhooks()
+	select {
+	case err := <-webhooksErr:
+		runErr = errors.Join(runErr, err)
+	case <-drainCtx.Done():
+	}
+
+	return runErr
 }
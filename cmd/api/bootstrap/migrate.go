@@ -0,0 +1,86 @@
+package bootstrap
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strconv"
+
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/db"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/storage/sqldb"
+)
+
+const migrateUsage = "usage: migrate up | migrate down N | migrate status"
+
+var ErrInvalidMigrateCommand = errors.New(migrateUsage)
+
+func newMigrator(conn *sql.DB) (*sqldb.Migrator, error) {
+	migrations, err := fs.Sub(db.Migrations, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	return sqldb.NewMigrator(conn, migrations)
+}
+
+// Migrate runs the migrate subcommand with the arguments that follow it: "up",
+// "down N" or "status".
+func Migrate(args []string) error {
+	if len(args) == 0 {
+		return ErrInvalidMigrateCommand
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	conn, err := openDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	migrator, err := newMigrator(conn)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	switch {
+	case args[0] == "up" && len(args) == 1:
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Applied %d migrations\n", applied)
+	case args[0] == "down" && len(args) == 2:
+		n, err := strconv.Atoi(args[1])
+		if err != nil {
+			return ErrInvalidMigrateCommand
+		}
+		reverted, err := migrator.Down(ctx, n)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Reverted %d migrations\n", reverted)
+	case args[0] == "status" && len(args) == 1:
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			state := "pending"
+			if status.Applied {
+				state = "applied"
+			}
+			fmt.Printf("%06d %-40s %s\n", status.Version, status.Name, state)
+		}
+	default:
+		return ErrInvalidMigrateCommand
+	}
+
+	return nil
+}
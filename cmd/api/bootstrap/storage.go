@@ -0,0 +1,143 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/listing"
+	bus "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/bus/inmemory"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/storage/inmemory"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/storage/sqldb"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/tx"
+)
+
+// Storage backends selected with MELA_STORAGE.
+const (
+	storagePostgres = "postgres"
+	storageMemory   = "memory"
+)
+
+// repositories holds every repository the services are built with, backed by one storage.
+type repositories struct {
+	users       domain.UserRepository
+	movies      domain.MovieRepository
+	groups      domain.GroupRepository
+	categories  domain.CategoryRepository
+	tracks      domain.TrackRepository
+	themes      domain.ThemeRepository
+	trackThemes domain.TrackThemeRepository
+
+	trackViews      listing.TrackViewRepository
+	themeViews      listing.ThemeViewRepository
+	trackThemeViews listing.TrackThemeViewRepository
+	search          domain.SearchRepository
+	trash           listing.TrashRepository
+	audit           domain.AuditRepository
+	deadLetters     event.DeadLetterStore
+	outbox          event.Outbox
+
+	webhooks          domain.WebhookRepository
+	webhookDeliveries domain.WebhookDeliveryRepository
+
+	sessions    domain.SessionRepository
+	revocations domain.RevocationRepository
+	signingKeys domain.SigningKeyRepository
+	roles       domain.RoleRepository
+
+	verificationTokens domain.VerificationTokenRepository
+
+	txManager tx.Manager
+}
+
+// newRepositories builds the repositories of the storage configured in cfg. PostgreSQL
+// gets its pending migrations applied first when automigrate is set.
+func newRepositories(cfg config) (repositories, error) {
+	switch cfg.Storage {
+	case storageMemory:
+		return newMemoryRepositories(), nil
+	case storagePostgres, "":
+		return newSQLRepositories(cfg)
+	default:
+		return repositories{}, fmt.Errorf("unknown storage %q", cfg.Storage)
+	}
+}
+
+func newSQLRepositories(cfg config) (repositories, error) {
+	db, err := openDB(cfg)
+	if err != nil {
+		return repositories{}, err
+	}
+
+	if cfg.Automigrate {
+		migrator, err := newMigrator(db)
+		if err != nil {
+			return repositories{}, err
+		}
+		applied, err := migrator.Up(context.Background())
+		if err != nil {
+			return repositories{}, fmt.Errorf("failed to apply migrations: %w", err)
+		}
+		log.Printf("Applied %d pending migrations", applied)
+	}
+
+	return repositories{
+		users:              sqldb.NewUserRepository(db, cfg.Dbtimeout),
+		movies:             sqldb.NewMovieRepository(db, cfg.Dbtimeout),
+		groups:             sqldb.NewGroupRepository(db, cfg.Dbtimeout),
+		categories:         sqldb.NewCategoryRepository(db, cfg.Dbtimeout),
+		tracks:             sqldb.NewTrackRepository(db, cfg.Dbtimeout),
+		themes:             sqldb.NewThemeRepository(db, cfg.Dbtimeout),
+		trackThemes:        sqldb.NewTrackThemeRepository(db, cfg.Dbtimeout),
+		trackViews:         sqldb.NewTrackViewRepository(db, cfg.Dbtimeout),
+		themeViews:         sqldb.NewThemeViewRepository(db, cfg.Dbtimeout),
+		trackThemeViews:    sqldb.NewTrackThemeViewRepository(db, cfg.Dbtimeout),
+		search:             sqldb.NewSearchRepository(db, cfg.Dbtimeout),
+		trash:              sqldb.NewTrashRepository(db, cfg.Dbtimeout),
+		audit:              sqldb.NewAuditRepository(db, cfg.Dbtimeout),
+		deadLetters:        sqldb.NewDeadLetterRepository(db, cfg.Dbtimeout, domain.EventCodec{}),
+		outbox:             sqldb.NewOutboxRepository(db, cfg.Dbtimeout, domain.EventCodec{}),
+		webhooks:           sqldb.NewWebhookRepository(db, cfg.Dbtimeout),
+		webhookDeliveries:  sqldb.NewWebhookDeliveryRepository(db, cfg.Dbtimeout, domain.EventCodec{}),
+		sessions:           sqldb.NewSessionRepository(db, cfg.Dbtimeout),
+		revocations:        sqldb.NewRevocationRepository(db, cfg.Dbtimeout),
+		signingKeys:        sqldb.NewSigningKeyRepository(db, cfg.Dbtimeout),
+		roles:              sqldb.NewRoleRepository(db, cfg.Dbtimeout),
+		verificationTokens: sqldb.NewVerificationTokenRepository(db, cfg.Dbtimeout),
+		txManager:          sqldb.NewTxManager(db),
+	}, nil
+}
+
+// newMemoryRepositories builds repositories that keep the catalogue in memory. Nothing
+// survives a restart, which suits local runs and end-to-end tests.
+func newMemoryRepositories() repositories {
+	store := inmemory.NewStore()
+
+	return repositories{
+		users:              inmemory.NewUserRepository(store),
+		movies:             inmemory.NewMovieRepository(store),
+		groups:             inmemory.NewGroupRepository(store),
+		categories:         inmemory.NewCategoryRepository(store),
+		tracks:             inmemory.NewTrackRepository(store),
+		themes:             inmemory.NewThemeRepository(store),
+		trackThemes:        inmemory.NewTrackThemeRepository(store),
+		trackViews:         inmemory.NewTrackViewRepository(store),
+		themeViews:         inmemory.NewThemeViewRepository(store),
+		trackThemeViews:    inmemory.NewTrackThemeViewRepository(store),
+		search:             inmemory.NewSearchRepository(store),
+		trash:              inmemory.NewTrashRepository(store),
+		audit:              inmemory.NewAuditRepository(store),
+		deadLetters:        bus.NewDeadLetterStore(),
+		outbox:             inmemory.NewOutboxRepository(store),
+		webhooks:           inmemory.NewWebhookRepository(store),
+		webhookDeliveries:  inmemory.NewWebhookDeliveryRepository(store),
+		sessions:           inmemory.NewSessionRepository(store),
+		revocations:        inmemory.NewRevocationRepository(store),
+		signingKeys:        inmemory.NewSigningKeyRepository(store),
+		roles:              inmemory.NewRoleRepository(store),
+		verificationTokens: inmemory.NewVerificationTokenRepository(store),
+		txManager:          inmemory.NewTxManager(store),
+	}
+}
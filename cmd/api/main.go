@@ -2,11 +2,19 @@ package main
 
 import (
 	"log"
+	"os"
 
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/cmd/api/bootstrap"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := bootstrap.Migrate(os.Args[2:]); err != nil {
+			log.Fatalf("Failed to run migrations: %v", err)
+		}
+		return
+	}
+
 	if err := bootstrap.Run(); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
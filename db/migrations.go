@@ -0,0 +1,10 @@
+// Package db holds the SQL schema of the API.
+package db
+
+import "embed"
+
+// Migrations embeds the files in migrations/, named <version>_<name>.up.sql and
+// <version>_<name>.down.sql.
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS themes_search_vector_idx;
+DROP INDEX IF EXISTS groups_search_vector_idx;
+DROP INDEX IF EXISTS tracks_search_vector_idx;
+DROP INDEX IF EXISTS movies_search_vector_idx;
+
+ALTER TABLE themes DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE groups DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE tracks DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE movies DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,26 @@
+ALTER TABLE themes
+ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(description, '')), 'B')
+) STORED;
+
+ALTER TABLE groups
+ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(description, '')), 'B')
+) STORED;
+
+ALTER TABLE tracks
+ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(name, '')), 'A')
+) STORED;
+
+ALTER TABLE movies
+ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(name, '')), 'A')
+) STORED;
+
+CREATE INDEX themes_search_vector_idx ON themes USING GIN (search_vector);
+CREATE INDEX groups_search_vector_idx ON groups USING GIN (search_vector);
+CREATE INDEX tracks_search_vector_idx ON tracks USING GIN (search_vector);
+CREATE INDEX movies_search_vector_idx ON movies USING GIN (search_vector);
@@ -0,0 +1,8 @@
+ALTER TABLE movies
+DROP CONSTRAINT IF EXISTS movies_runtime_minutes_check,
+DROP CONSTRAINT IF EXISTS movies_series_check,
+DROP CONSTRAINT IF EXISTS movies_sequence_key,
+DROP COLUMN IF EXISTS runtime_minutes,
+DROP COLUMN IF EXISTS series,
+DROP COLUMN IF EXISTS sequence,
+DROP COLUMN IF EXISTS release_year;
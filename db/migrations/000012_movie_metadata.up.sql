@@ -0,0 +1,37 @@
+ALTER TABLE movies
+ADD COLUMN release_year INTEGER NULL,
+ADD COLUMN sequence INTEGER NULL,
+ADD COLUMN series VARCHAR(32) NULL,
+ADD COLUMN runtime_minutes INTEGER NULL;
+
+UPDATE movies SET
+    series = CASE
+        WHEN name ILIKE '%Unexpected Journey%'
+          OR name ILIKE '%Desolation of Smaug%'
+          OR name ILIKE '%Five Armies%' THEN 'hobbit'
+        ELSE 'lotr'
+    END,
+    release_year = CASE
+        WHEN name ILIKE '%Fellowship of the Ring%' THEN 2001
+        WHEN name ILIKE '%Two Towers%' THEN 2002
+        WHEN name ILIKE '%Return of the King%' THEN 2003
+        WHEN name ILIKE '%Unexpected Journey%' THEN 2012
+        WHEN name ILIKE '%Desolation of Smaug%' THEN 2013
+        WHEN name ILIKE '%Five Armies%' THEN 2014
+        ELSE EXTRACT(YEAR FROM created_at)::INTEGER
+    END;
+
+UPDATE movies SET sequence = ordered.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (ORDER BY release_year, created_at, id) AS position
+    FROM movies
+) AS ordered
+WHERE movies.id = ordered.id;
+
+ALTER TABLE movies
+ALTER COLUMN release_year SET NOT NULL,
+ALTER COLUMN sequence SET NOT NULL,
+ALTER COLUMN series SET NOT NULL,
+ADD CONSTRAINT movies_sequence_key UNIQUE (sequence),
+ADD CONSTRAINT movies_series_check CHECK (series IN ('lotr', 'hobbit')),
+ADD CONSTRAINT movies_runtime_minutes_check CHECK (runtime_minutes IS NULL OR runtime_minutes > 0);
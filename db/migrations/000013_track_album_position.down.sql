@@ -0,0 +1,10 @@
+ALTER TABLE tracks
+DROP CONSTRAINT IF EXISTS tracks_duration_seconds_check,
+DROP CONSTRAINT IF EXISTS tracks_edition_check,
+DROP CONSTRAINT IF EXISTS tracks_disc_number_check,
+DROP CONSTRAINT IF EXISTS tracks_track_number_check,
+DROP CONSTRAINT IF EXISTS tracks_position_key,
+DROP COLUMN IF EXISTS duration_seconds,
+DROP COLUMN IF EXISTS edition,
+DROP COLUMN IF EXISTS disc_number,
+DROP COLUMN IF EXISTS track_number;
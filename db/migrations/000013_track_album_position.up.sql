@@ -0,0 +1,29 @@
+ALTER TABLE tracks
+ADD COLUMN track_number INTEGER NULL,
+ADD COLUMN disc_number INTEGER NULL,
+ADD COLUMN edition VARCHAR(32) NULL,
+ADD COLUMN duration_seconds INTEGER NULL;
+
+UPDATE tracks SET track_number = ordered.position, disc_number = 1, edition = 'theatrical'
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY movie_id ORDER BY created_at, id) AS position
+    FROM tracks
+) AS ordered
+WHERE tracks.id = ordered.id;
+
+UPDATE tracks SET duration_seconds = GREATEST(
+    1,
+    COALESCE((SELECT MAX(end_second) FROM tracks_themes WHERE tracks_themes.track_id = tracks.id), 0),
+    COALESCE((SELECT MAX(first_heard_end) FROM themes WHERE themes.first_heard = tracks.id), 0)
+);
+
+ALTER TABLE tracks
+ALTER COLUMN track_number SET NOT NULL,
+ALTER COLUMN disc_number SET NOT NULL,
+ALTER COLUMN edition SET NOT NULL,
+ALTER COLUMN duration_seconds SET NOT NULL,
+ADD CONSTRAINT tracks_position_key UNIQUE (movie_id, edition, disc_number, track_number),
+ADD CONSTRAINT tracks_track_number_check CHECK (track_number > 0),
+ADD CONSTRAINT tracks_disc_number_check CHECK (disc_number > 0),
+ADD CONSTRAINT tracks_edition_check CHECK (edition IN ('theatrical', 'complete_recordings')),
+ADD CONSTRAINT tracks_duration_seconds_check CHECK (duration_seconds > 0);
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS tracks_position_key;
+ALTER TABLE tracks ADD CONSTRAINT tracks_position_key UNIQUE (movie_id, edition, disc_number, track_number);
+
+DROP INDEX IF EXISTS movies_sequence_key;
+ALTER TABLE movies ADD CONSTRAINT movies_sequence_key UNIQUE (sequence);
+
+ALTER TABLE themes DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE tracks DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE categories DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE groups DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE movies DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,13 @@
+ALTER TABLE movies ADD COLUMN deleted_at TIMESTAMP(0) NULL;
+ALTER TABLE groups ADD COLUMN deleted_at TIMESTAMP(0) NULL;
+ALTER TABLE categories ADD COLUMN deleted_at TIMESTAMP(0) NULL;
+ALTER TABLE tracks ADD COLUMN deleted_at TIMESTAMP(0) NULL;
+ALTER TABLE themes ADD COLUMN deleted_at TIMESTAMP(0) NULL;
+
+-- A movie in the trash gives up its sequence, so that a live movie can take it
+ALTER TABLE movies DROP CONSTRAINT movies_sequence_key;
+CREATE UNIQUE INDEX movies_sequence_key ON movies (sequence) WHERE deleted_at IS NULL;
+
+-- Likewise, a track in the trash gives up its album position
+ALTER TABLE tracks DROP CONSTRAINT tracks_position_key;
+CREATE UNIQUE INDEX tracks_position_key ON tracks (movie_id, edition, disc_number, track_number) WHERE deleted_at IS NULL;
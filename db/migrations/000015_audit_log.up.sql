@@ -0,0 +1,14 @@
+CREATE TABLE audit_log (
+    id UUID PRIMARY KEY,
+    command_type VARCHAR(255) NOT NULL,
+    entity VARCHAR(50) NOT NULL,
+    entity_id UUID NULL,
+    user_id UUID NULL,
+    before JSONB NULL,
+    after JSONB NULL,
+    occurred_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX audit_log_occurred_at_idx ON audit_log (occurred_at DESC);
+CREATE INDEX audit_log_entity_idx ON audit_log (entity, occurred_at DESC);
+CREATE INDEX audit_log_user_id_idx ON audit_log (user_id, occurred_at DESC);
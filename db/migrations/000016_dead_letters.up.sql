@@ -0,0 +1,14 @@
+CREATE TABLE dead_letters (
+    id BIGSERIAL PRIMARY KEY,
+    event_id UUID NOT NULL,
+    event_type VARCHAR(255) NOT NULL,
+    aggregate_id VARCHAR(255) NOT NULL,
+    occurred_on TIMESTAMPTZ NOT NULL,
+    payload JSONB NULL,
+    handler VARCHAR(255) NOT NULL,
+    attempts INTEGER NOT NULL,
+    error TEXT NOT NULL,
+    failed_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX dead_letters_failed_at_idx ON dead_letters (failed_at DESC);
@@ -0,0 +1,12 @@
+CREATE TABLE outbox (
+    id UUID PRIMARY KEY,
+    seq BIGSERIAL NOT NULL,
+    event_type VARCHAR(255) NOT NULL,
+    aggregate_id VARCHAR(255) NOT NULL,
+    occurred_on TIMESTAMPTZ NOT NULL,
+    payload JSONB NOT NULL,
+    claimed_at TIMESTAMPTZ,
+    sent_at TIMESTAMPTZ
+);
+
+CREATE INDEX outbox_unsent_idx ON outbox (seq) WHERE sent_at IS NULL;
@@ -0,0 +1,30 @@
+CREATE TABLE webhooks (
+    id UUID PRIMARY KEY,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    event_types TEXT[] NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- A row is an attempt at posting an event to a webhook. It is pending, and due at
+-- next_attempt_at, until delivered_at records when it was made. The event is kept along
+-- with it, so that the pending attempts survive a restart.
+CREATE TABLE webhook_deliveries (
+    id UUID PRIMARY KEY,
+    webhook_id UUID NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL,
+    event_type VARCHAR(255) NOT NULL,
+    aggregate_id VARCHAR(255) NOT NULL,
+    occurred_on TIMESTAMPTZ NOT NULL,
+    payload JSONB NOT NULL,
+    attempt INTEGER NOT NULL,
+    next_attempt_at TIMESTAMPTZ,
+    claimed_at TIMESTAMPTZ,
+    status_code INTEGER,
+    error TEXT,
+    delivered_at TIMESTAMPTZ,
+    CONSTRAINT webhook_deliveries_attempt_key UNIQUE (webhook_id, event_id, attempt)
+);
+
+CREATE INDEX webhook_deliveries_webhook_id_idx ON webhook_deliveries (webhook_id, delivered_at DESC, id DESC);
+CREATE INDEX webhook_deliveries_pending_idx ON webhook_deliveries (next_attempt_at) WHERE delivered_at IS NULL;
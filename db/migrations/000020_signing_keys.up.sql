@@ -0,0 +1,7 @@
+CREATE TABLE signing_keys (
+    id TEXT PRIMARY KEY,
+    algorithm TEXT NOT NULL CHECK (algorithm IN ('RS256', 'EdDSA')),
+    private_key BYTEA NOT NULL,
+    activates_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,16 @@
+CREATE TABLE roles (
+    name TEXT PRIMARY KEY,
+    permissions TEXT[] NOT NULL DEFAULT '{}'
+);
+
+INSERT INTO roles (name, permissions) VALUES
+    ('viewer', '{}'),
+    ('editor', '{tracks_themes:write,descriptions:write}'),
+    ('curator', '{tracks_themes:write,descriptions:write,catalogue:write}'),
+    ('admin', '{tracks_themes:write,descriptions:write,catalogue:write,trash:purge,users:manage,audit:read,webhooks:manage}');
+
+ALTER TABLE users ADD COLUMN role TEXT NOT NULL DEFAULT 'viewer' REFERENCES roles (name);
+
+UPDATE users SET role = 'admin' WHERE is_admin;
+
+ALTER TABLE users DROP COLUMN is_admin;
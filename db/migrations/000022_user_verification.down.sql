@@ -0,0 +1,3 @@
+DROP TABLE verification_tokens;
+
+ALTER TABLE users DROP COLUMN verified;
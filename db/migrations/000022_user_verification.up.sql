@@ -0,0 +1,12 @@
+-- The users created so far were created by admins, who vouch for their emails
+ALTER TABLE users ADD COLUMN verified BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE users SET verified = TRUE;
+
+CREATE TABLE verification_tokens (
+    token_hash CHAR(64) PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX verification_tokens_user_id_idx ON verification_tokens (user_id);
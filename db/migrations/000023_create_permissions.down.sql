@@ -0,0 +1,4 @@
+UPDATE roles SET permissions = permissions || '{catalogue:write}'
+WHERE name = 'curator' AND NOT 'catalogue:write' = ANY (permissions);
+
+UPDATE roles SET permissions = array_remove(array_remove(permissions, 'tracks:create'), 'themes:create');
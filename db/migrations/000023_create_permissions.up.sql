@@ -0,0 +1,7 @@
+-- Creating tracks and themes gets permissions of its own, so that curators can do it
+-- without being able to delete and restore the rest of the catalogue
+UPDATE roles SET permissions = permissions || '{tracks:create,themes:create}'
+WHERE 'catalogue:write' = ANY (permissions);
+
+UPDATE roles SET permissions = array_remove(permissions, 'catalogue:write')
+WHERE name = 'curator';
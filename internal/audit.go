@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidAuditEntry = errors.New("invalid audit entry")
+var ErrInvalidAuditEntity = errors.New("invalid audit entity")
+var ErrInvalidAuditSince = errors.New("invalid audit since, expected an RFC 3339 time")
+
+// The entities an audit entry can be about.
+const (
+	AuditEntityUser       = "user"
+	AuditEntityMovie      = "movie"
+	AuditEntityGroup      = "group"
+	AuditEntityCategory   = "category"
+	AuditEntityTrack      = "track"
+	AuditEntityTheme      = "theme"
+	AuditEntityTrackTheme = "track_theme"
+	AuditEntityWebhook    = "webhook"
+)
+
+func isAuditEntity(entity string) bool {
+	switch entity {
+	case AuditEntityUser, AuditEntityMovie, AuditEntityGroup, AuditEntityCategory,
+		AuditEntityTrack, AuditEntityTheme, AuditEntityTrackTheme, AuditEntityWebhook:
+		return true
+	default:
+		return false
+	}
+}
+
+// AuditEntry records a command that changed the catalogue: who ran it, when, and the
+// state of the entity it changed before and after.
+type AuditEntry struct {
+	id          string
+	commandType string
+	entity      string
+	entityID    string          // Empty when the command created the entity
+	userID      string          // Empty when nobody was authenticated
+	before      json.RawMessage // Nil when the entity did not exist
+	after       json.RawMessage // Nil when the entity no longer exists
+	occurredAt  time.Time
+}
+
+// NewAuditEntry creates a new AuditEntry with a fresh ID.
+func NewAuditEntry(commandType, entity, entityID, userID string, before, after json.RawMessage, occurredAt time.Time) (AuditEntry, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return AuditEntry{}, fmt.Errorf("%w: %w", ErrInvalidAuditEntry, err)
+	}
+
+	return NewAuditEntryWithID(id.String(), commandType, entity, entityID, userID, before, after, occurredAt)
+}
+
+// NewAuditEntryWithID creates an AuditEntry from stored values.
+func NewAuditEntryWithID(id, commandType, entity, entityID, userID string, before, after json.RawMessage, occurredAt time.Time) (AuditEntry, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return AuditEntry{}, fmt.Errorf("%w: %w", ErrInvalidAuditEntry, err)
+	}
+	if commandType == "" || occurredAt.IsZero() {
+		return AuditEntry{}, ErrInvalidAuditEntry
+	}
+	if !isAuditEntity(entity) {
+		return AuditEntry{}, ErrInvalidAuditEntity
+	}
+
+	return AuditEntry{
+		id:          id,
+		commandType: commandType,
+		entity:      entity,
+		entityID:    entityID,
+		userID:      userID,
+		before:      before,
+		after:       after,
+		occurredAt:  occurredAt.UTC(),
+	}, nil
+}
+
+func (e AuditEntry) ID() string {
+	return e.id
+}
+
+func (e AuditEntry) CommandType() string {
+	return e.commandType
+}
+
+func (e AuditEntry) Entity() string {
+	return e.entity
+}
+
+func (e AuditEntry) EntityID() string {
+	return e.entityID
+}
+
+func (e AuditEntry) UserID() string {
+	return e.userID
+}
+
+func (e AuditEntry) Before() json.RawMessage {
+	return e.before
+}
+
+func (e AuditEntry) After() json.RawMessage {
+	return e.after
+}
+
+func (e AuditEntry) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+// AuditFilter narrows the audit log. Every criterion is optional, and the ones that are
+// set must all match.
+type AuditFilter struct {
+	entity string
+	userID *UserID
+	since  *time.Time // Matches the entries that occurred at or after it
+}
+
+// NewAuditFilter creates a new AuditFilter instance. Empty values leave the matching
+// criterion unset.
+func NewAuditFilter(entity, userID, since string) (AuditFilter, error) {
+	var filter AuditFilter
+
+	if entity != "" {
+		if !isAuditEntity(entity) {
+			return AuditFilter{}, ErrInvalidAuditEntity
+		}
+		filter.entity = entity
+	}
+
+	if userID != "" {
+		userIDVO, err := NewUserIDFromString(userID)
+		if err != nil {
+			return AuditFilter{}, err
+		}
+		filter.userID = &userIDVO
+	}
+
+	if since != "" {
+		sinceTime, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return AuditFilter{}, fmt.Errorf("%w: %w", ErrInvalidAuditSince, err)
+		}
+		sinceTime = sinceTime.UTC()
+		filter.since = &sinceTime
+	}
+
+	return filter, nil
+}
+
+func (f AuditFilter) Entity() string {
+	return f.entity
+}
+
+func (f AuditFilter) UserID() *UserID {
+	return f.userID
+}
+
+func (f AuditFilter) Since() *time.Time {
+	return f.since
+}
+
+// AuditRepository is the interface for the audit log. Entries are only ever appended.
+type AuditRepository interface {
+	Save(ctx context.Context, entry AuditEntry) error
+	FindPage(ctx context.Context, filter AuditFilter, page PageRequest) ([]AuditEntry, *Cursor, error) // Newest first
+}
+
+//go:generate mockery --case=snake --outpkg=storagemocks --output=platform/storage/storagemocks --name=AuditRepository
@@ -0,0 +1,150 @@
+package auditing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/command"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/tx"
+)
+
+// actorKey is the key jwt.Middleware stores the ID of the authenticated user under. The
+// gin context the handlers dispatch commands with exposes it through Value.
+const actorKey = "userID"
+
+// Target is the entity a command changes.
+type Target struct {
+	Entity string // One of the domain.AuditEntity constants
+	ID     string // Empty when the command creates the entity
+	// Created returns, once a create command has run, the ID of the entity it stored, so
+	// that the entry names it and records its stored state.
+	Created func() string
+	// Request is recorded as the state of an entity that cannot be loaded by ID, such as
+	// a track theme: as the after state, or as the before state when Removed is set. It
+	// must not hold secrets.
+	Request any
+	Removed bool // The command deletes the entity, which leaves no after state
+}
+
+// Auditable is implemented by the commands the audit log records.
+type Auditable interface {
+	AuditTarget() Target
+}
+
+// Loader returns the current state of an entity, or nil when it does not exist.
+type Loader func(ctx context.Context, id string) (any, error)
+
+// NewLoader adapts a getter that reports a missing entity with notFound into a Loader.
+func NewLoader[T any](get func(ctx context.Context, id string) (T, error), notFound error) Loader {
+	return func(ctx context.Context, id string) (any, error) {
+		state, err := get(ctx, id)
+		if errors.Is(err, notFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return state, nil
+	}
+}
+
+// CommandBus decorates a command.Bus so that every successful Auditable command leaves
+// an entry in the audit log. The command and its entry are written in one transaction:
+// a command whose entry cannot be saved is rolled back.
+type CommandBus struct {
+	next            command.Bus
+	auditRepository domain.AuditRepository
+	txManager       tx.Manager
+	loaders         map[string]Loader
+	now             func() time.Time
+}
+
+// NewCommandBus creates a new CommandBus that dispatches commands to next.
+func NewCommandBus(next command.Bus, auditRepository domain.AuditRepository, txManager tx.Manager) *CommandBus {
+	return &CommandBus{
+		next:            next,
+		auditRepository: auditRepository,
+		txManager:       txManager,
+		loaders:         make(map[string]Loader),
+		now:             time.Now,
+	}
+}
+
+// Register registers a command handler on the decorated bus.
+func (b *CommandBus) Register(cmdType command.Type, handler command.Handler) {
+	b.next.Register(cmdType, handler)
+}
+
+// RegisterLoader sets how the state of an entity is loaded for its before and after JSON.
+func (b *CommandBus) RegisterLoader(entity string, loader Loader) {
+	b.loaders[entity] = loader
+}
+
+// Dispatch dispatches the command and records it in the audit log.
+func (b *CommandBus) Dispatch(ctx context.Context, cmd command.Command) error {
+	auditable, ok := cmd.(Auditable)
+	if !ok {
+		return b.next.Dispatch(ctx, cmd)
+	}
+
+	target := auditable.AuditTarget()
+	userID, _ := ctx.Value(actorKey).(string)
+
+	return b.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var beforeRequest, afterRequest any
+		if target.Removed {
+			beforeRequest = target.Request
+		} else {
+			afterRequest = target.Request
+		}
+
+		before, err := b.snapshot(ctx, target, beforeRequest)
+		if err != nil {
+			return err
+		}
+
+		if err := b.next.Dispatch(ctx, cmd); err != nil {
+			return err
+		}
+
+		if target.ID == "" && target.Created != nil {
+			target.ID = target.Created()
+		}
+		after, err := b.snapshot(ctx, target, afterRequest)
+		if err != nil {
+			return err
+		}
+
+		entry, err := domain.NewAuditEntry(string(cmd.Type()), target.Entity, target.ID, userID, before, after, b.now())
+		if err != nil {
+			return err
+		}
+		return b.auditRepository.Save(ctx, entry)
+	})
+}
+
+// snapshot returns the JSON state of the target, or that of fallback when the target
+// cannot be loaded by ID. It is nil when there is no state to record.
+func (b *CommandBus) snapshot(ctx context.Context, target Target, fallback any) (json.RawMessage, error) {
+	state := fallback
+	if loader, ok := b.loaders[target.Entity]; ok && target.ID != "" {
+		loaded, err := loader(ctx, target.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s for the audit log: %w", target.Entity, err)
+		}
+		state = loaded
+	}
+	if state == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s for the audit log: %w", target.Entity, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,252 @@
+package auditing
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/storage/storagemocks"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/command"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/command/commandmocks"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/tx/txmocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	movieID = "123e4567-e89b-12d3-a456-426614174000"
+	userID  = "28712a55-04dd-4200-9316-4d6a1e399121"
+)
+
+type testCommand struct{}
+
+func (c testCommand) Type() command.Type {
+	return "command.test"
+}
+
+type auditableCommand struct {
+	testCommand
+	target Target
+}
+
+func (c auditableCommand) AuditTarget() Target {
+	return c.target
+}
+
+type movieState struct {
+	Name string `json:"name"`
+}
+
+// movieLoader returns the states of the movie in turn, one per call.
+func movieLoader(states ...*movieState) Loader {
+	return func(ctx context.Context, id string) (any, error) {
+		state := states[0]
+		states = states[1:]
+		if state == nil {
+			return nil, nil
+		}
+		return *state, nil
+	}
+}
+
+func TestCommandBusDispatchUpdate(t *testing.T) {
+	cmd := auditableCommand{target: Target{Entity: domain.AuditEntityMovie, ID: movieID, Request: movieState{Name: "ignored"}}}
+	occurredAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	next := new(commandmocks.Bus)
+	next.On("Dispatch", mock.Anything, cmd).Return(nil).Once()
+
+	auditRepositoryMock := new(storagemocks.AuditRepository)
+	auditRepositoryMock.On("Save", mock.Anything, mock.MatchedBy(func(entry domain.AuditEntry) bool {
+		return entry.CommandType() == "command.test" &&
+			entry.Entity() == domain.AuditEntityMovie &&
+			entry.EntityID() == movieID &&
+			entry.UserID() == userID &&
+			string(entry.Before()) == `{"name":"The Two Towers"}` &&
+			string(entry.After()) == `{"name":"The Return of the King"}` &&
+			entry.OccurredAt().Equal(occurredAt)
+	})).Return(nil).Once()
+
+	bus := NewCommandBus(next, auditRepositoryMock, newTxManagerMock(t))
+	bus.now = func() time.Time { return occurredAt }
+	bus.RegisterLoader(domain.AuditEntityMovie, movieLoader(&movieState{"The Two Towers"}, &movieState{"The Return of the King"}))
+
+	err := bus.Dispatch(newGinContext(userID), cmd)
+	assert.NoError(t, err)
+
+	next.AssertExpectations(t)
+	auditRepositoryMock.AssertExpectations(t)
+}
+
+func TestCommandBusDispatchCreateRecordsRequest(t *testing.T) {
+	cmd := auditableCommand{target: Target{Entity: domain.AuditEntityMovie, Request: movieState{Name: "The Two Towers"}}}
+
+	next := new(commandmocks.Bus)
+	next.On("Dispatch", mock.Anything, cmd).Return(nil).Once()
+
+	auditRepositoryMock := new(storagemocks.AuditRepository)
+	auditRepositoryMock.On("Save", mock.Anything, mock.MatchedBy(func(entry domain.AuditEntry) bool {
+		return entry.EntityID() == "" &&
+			entry.Before() == nil &&
+			string(entry.After()) == `{"name":"The Two Towers"}`
+	})).Return(nil).Once()
+
+	bus := NewCommandBus(next, auditRepositoryMock, newTxManagerMock(t))
+	bus.RegisterLoader(domain.AuditEntityMovie, func(ctx context.Context, id string) (any, error) {
+		t.Fatal("a target without ID must not be loaded")
+		return nil, nil
+	})
+
+	err := bus.Dispatch(newGinContext(userID), cmd)
+	assert.NoError(t, err)
+
+	auditRepositoryMock.AssertExpectations(t)
+}
+
+func TestCommandBusDispatchCreateRecordsCreated(t *testing.T) {
+	createdID := ""
+	cmd := auditableCommand{target: Target{Entity: domain.AuditEntityMovie, Created: func() string { return createdID }}}
+
+	next := new(commandmocks.Bus)
+	next.On("Dispatch", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		createdID = movieID
+	}).Return(nil).Once()
+
+	auditRepositoryMock := new(storagemocks.AuditRepository)
+	auditRepositoryMock.On("Save", mock.Anything, mock.MatchedBy(func(entry domain.AuditEntry) bool {
+		return entry.EntityID() == movieID &&
+			entry.Before() == nil &&
+			string(entry.After()) == `{"name":"The Two Towers"}`
+	})).Return(nil).Once()
+
+	bus := NewCommandBus(next, auditRepositoryMock, newTxManagerMock(t))
+	bus.RegisterLoader(domain.AuditEntityMovie, movieLoader(&movieState{"The Two Towers"}))
+
+	err := bus.Dispatch(newGinContext(userID), cmd)
+	assert.NoError(t, err)
+
+	auditRepositoryMock.AssertExpectations(t)
+}
+
+func TestCommandBusDispatchRemovedRecordsRequestAsBefore(t *testing.T) {
+	cmd := auditableCommand{target: Target{Entity: domain.AuditEntityTrackTheme, Request: movieState{Name: "The Two Towers"}, Removed: true}}
+
+	next := new(commandmocks.Bus)
+	next.On("Dispatch", mock.Anything, cmd).Return(nil).Once()
+
+	auditRepositoryMock := new(storagemocks.AuditRepository)
+	auditRepositoryMock.On("Save", mock.Anything, mock.MatchedBy(func(entry domain.AuditEntry) bool {
+		return string(entry.Before()) == `{"name":"The Two Towers"}` &&
+			entry.After() == nil
+	})).Return(nil).Once()
+
+	bus := NewCommandBus(next, auditRepositoryMock, newTxManagerMock(t))
+
+	err := bus.Dispatch(newGinContext(userID), cmd)
+	assert.NoError(t, err)
+
+	auditRepositoryMock.AssertExpectations(t)
+}
+
+func TestCommandBusDispatchDeleteWithoutUser(t *testing.T) {
+	cmd := auditableCommand{target: Target{Entity: domain.AuditEntityMovie, ID: movieID}}
+
+	next := new(commandmocks.Bus)
+	next.On("Dispatch", mock.Anything, cmd).Return(nil).Once()
+
+	auditRepositoryMock := new(storagemocks.AuditRepository)
+	auditRepositoryMock.On("Save", mock.Anything, mock.MatchedBy(func(entry domain.AuditEntry) bool {
+		return entry.UserID() == "" &&
+			string(entry.Before()) == `{"name":"The Two Towers"}` &&
+			entry.After() == nil
+	})).Return(nil).Once()
+
+	bus := NewCommandBus(next, auditRepositoryMock, newTxManagerMock(t))
+	bus.RegisterLoader(domain.AuditEntityMovie, movieLoader(&movieState{"The Two Towers"}, nil))
+
+	err := bus.Dispatch(context.Background(), cmd)
+	assert.NoError(t, err)
+
+	auditRepositoryMock.AssertExpectations(t)
+}
+
+func TestCommandBusDispatchFailedCommandIsNotRecorded(t *testing.T) {
+	cmd := auditableCommand{target: Target{Entity: domain.AuditEntityMovie, ID: movieID}}
+
+	next := new(commandmocks.Bus)
+	next.On("Dispatch", mock.Anything, cmd).Return(domain.ErrMovieNotFound).Once()
+
+	auditRepositoryMock := new(storagemocks.AuditRepository)
+
+	bus := NewCommandBus(next, auditRepositoryMock, newTxManagerMock(t))
+	bus.RegisterLoader(domain.AuditEntityMovie, movieLoader(nil))
+
+	err := bus.Dispatch(newGinContext(userID), cmd)
+	assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+
+	auditRepositoryMock.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestCommandBusDispatchSaveError(t *testing.T) {
+	cmd := auditableCommand{target: Target{Entity: domain.AuditEntityMovie, ID: movieID}}
+
+	next := new(commandmocks.Bus)
+	next.On("Dispatch", mock.Anything, cmd).Return(nil).Once()
+
+	auditRepositoryMock := new(storagemocks.AuditRepository)
+	auditRepositoryMock.On("Save", mock.Anything, mock.Anything).Return(errors.New("database error")).Once()
+
+	bus := NewCommandBus(next, auditRepositoryMock, newTxManagerMock(t))
+
+	err := bus.Dispatch(newGinContext(userID), cmd)
+	assert.EqualError(t, err, "database error")
+}
+
+func TestCommandBusDispatchNotAuditable(t *testing.T) {
+	cmd := testCommand{}
+
+	next := new(commandmocks.Bus)
+	next.On("Dispatch", mock.Anything, cmd).Return(nil).Once()
+
+	auditRepositoryMock := new(storagemocks.AuditRepository)
+
+	bus := NewCommandBus(next, auditRepositoryMock, new(txmocks.Manager))
+
+	err := bus.Dispatch(context.Background(), cmd)
+	assert.NoError(t, err)
+
+	next.AssertExpectations(t)
+	auditRepositoryMock.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestNewLoaderMapsNotFound(t *testing.T) {
+	loader := NewLoader(func(ctx context.Context, id string) (movieState, error) {
+		return movieState{}, domain.ErrMovieNotFound
+	}, domain.ErrMovieNotFound)
+
+	state, err := loader(context.Background(), movieID)
+	require.NoError(t, err)
+	assert.Nil(t, state)
+}
+
+// newGinContext returns the context jwt.Middleware leaves behind for the user.
+func newGinContext(userID string) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Set("userID", userID)
+	return ctx
+}
+
+func newTxManagerMock(t *testing.T) *txmocks.Manager {
+	txManagerMock := new(txmocks.Manager)
+	txManagerMock.On("WithinTx", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	t.Cleanup(func() { txManagerMock.AssertExpectations(t) })
+	return txManagerMock
+}
@@ -0,0 +1,95 @@
+package authenticating
+
+import (
+	"context"
+	"time"
+
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/command"
+)
+
+const (
+	LogoutCommandType         command.Type = "command.authenticating.logout"
+	RevokeSessionsCommandType command.Type = "command.authenticating.revoke_sessions"
+)
+
+// LogoutCommand ends the session an access token belongs to and revokes the token.
+type LogoutCommand struct {
+	SessionID string
+	JTI       string
+	ExpiresAt time.Time // Expiry of the access token
+}
+
+// NewLogoutCommand creates a new LogoutCommand instance.
+func NewLogoutCommand(sessionID, jti string, expiresAt time.Time) LogoutCommand {
+	return LogoutCommand{
+		SessionID: sessionID,
+		JTI:       jti,
+		ExpiresAt: expiresAt,
+	}
+}
+
+// Type returns the command type.
+func (c LogoutCommand) Type() command.Type {
+	return LogoutCommandType
+}
+
+// LogoutCommandHandler handles the logout command.
+type LogoutCommandHandler struct {
+	service SessionService
+}
+
+// NewLogoutCommandHandler creates a new LogoutCommandHandler instance.
+func NewLogoutCommandHandler(service SessionService) LogoutCommandHandler {
+	return LogoutCommandHandler{
+		service: service,
+	}
+}
+
+// Handle processes the logout command.
+func (h LogoutCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	logoutCmd, ok := cmd.(LogoutCommand)
+	if !ok {
+		return nil
+	}
+
+	return h.service.Logout(ctx, logoutCmd.SessionID, logoutCmd.JTI, logoutCmd.ExpiresAt)
+}
+
+// RevokeSessionsCommand ends every session of a user, signing them out everywhere.
+type RevokeSessionsCommand struct {
+	UserID string
+}
+
+// NewRevokeSessionsCommand creates a new RevokeSessionsCommand instance.
+func NewRevokeSessionsCommand(userID string) RevokeSessionsCommand {
+	return RevokeSessionsCommand{
+		UserID: userID,
+	}
+}
+
+// Type returns the command type.
+func (c RevokeSessionsCommand) Type() command.Type {
+	return RevokeSessionsCommandType
+}
+
+// RevokeSessionsCommandHandler handles the revoke sessions command.
+type RevokeSessionsCommandHandler struct {
+	service SessionService
+}
+
+// NewRevokeSessionsCommandHandler creates a new RevokeSessionsCommandHandler instance.
+func NewRevokeSessionsCommandHandler(service SessionService) RevokeSessionsCommandHandler {
+	return RevokeSessionsCommandHandler{
+		service: service,
+	}
+}
+
+// Handle processes the revoke sessions command.
+func (h RevokeSessionsCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	revokeCmd, ok := cmd.(RevokeSessionsCommand)
+	if !ok {
+		return nil
+	}
+
+	return h.service.RevokeSessions(ctx, revokeCmd.UserID)
+}
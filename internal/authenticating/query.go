@@ -48,3 +48,89 @@ func (h LoginQueryHandler) Handle(ctx context.Context, query query.Query) (any,
 
 	return h.service.LoginUser(ctx, loginQuery.Email, loginQuery.Password)
 }
+
+const (
+	RefreshQueryType = "query.authenticating.refresh"
+	RevokedQueryType = "query.authenticating.revoked"
+)
+
+// RefreshQuery represents a query for a new pair of tokens.
+type RefreshQuery struct {
+	RefreshToken string
+}
+
+// NewRefreshQuery creates a new RefreshQuery instance.
+func NewRefreshQuery(refreshToken string) RefreshQuery {
+	return RefreshQuery{
+		RefreshToken: refreshToken,
+	}
+}
+
+// Type returns the query type.
+func (q RefreshQuery) Type() query.Type {
+	return RefreshQueryType
+}
+
+// RefreshQueryHandler handles the refresh query.
+type RefreshQueryHandler struct {
+	service SessionService
+}
+
+// NewRefreshQueryHandler creates a new RefreshQueryHandler instance.
+func NewRefreshQueryHandler(service SessionService) RefreshQueryHandler {
+	return RefreshQueryHandler{
+		service: service,
+	}
+}
+
+// Handle processes the refresh query.
+func (h RefreshQueryHandler) Handle(ctx context.Context, query query.Query) (any, error) {
+	refreshQuery, ok := query.(RefreshQuery)
+	if !ok {
+		return nil, nil
+	}
+
+	return h.service.Refresh(ctx, refreshQuery.RefreshToken)
+}
+
+// RevokedQuery asks whether an access token was revoked, by its jti and sid claims. It
+// is answered with a bool.
+type RevokedQuery struct {
+	JTI       string
+	SessionID string
+}
+
+// NewRevokedQuery creates a new RevokedQuery instance.
+func NewRevokedQuery(jti, sessionID string) RevokedQuery {
+	return RevokedQuery{
+		JTI:       jti,
+		SessionID: sessionID,
+	}
+}
+
+// Type returns the query type.
+func (q RevokedQuery) Type() query.Type {
+	return RevokedQueryType
+}
+
+// RevokedQueryHandler handles the revoked query.
+type RevokedQueryHandler struct {
+	service SessionService
+}
+
+// NewRevokedQueryHandler creates a new RevokedQueryHandler instance.
+func NewRevokedQueryHandler(service SessionService) RevokedQueryHandler {
+	return RevokedQueryHandler{
+		service: service,
+	}
+}
+
+// Handle processes the revoked query.
+func (h RevokedQueryHandler) Handle(ctx context.Context, query query.Query) (any, error) {
+	revokedQuery, ok := query.(RevokedQuery)
+	if !ok {
+		return nil, nil
+	}
+
+	return h.service.IsRevoked(ctx, revokedQuery.JTI, revokedQuery.SessionID)
+}
@@ -2,53 +2,222 @@ package authenticating
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/auth"
 )
 
+// tokenIssuer issues the access and refresh tokens of the sessions.
+type tokenIssuer struct {
+	signer     auth.Signer
+	exp        time.Duration // Lifetime of the access tokens
+	refreshExp time.Duration // Lifetime of the refresh tokens
+	now        func() time.Time
+}
+
+// refreshToken returns a new refresh token along with the hash and expiry it is stored with.
+func (i tokenIssuer) refreshToken() (token, hash string, expiresAt time.Time, err error) {
+	token, err = auth.GenerateToken()
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	return token, auth.HashToken(token), i.now().Add(i.refreshExp), nil
+}
+
+func (i tokenIssuer) response(user domain.User, sessionID domain.SessionID, refreshToken string) (dto.TokenResponse, error) {
+	token, err := auth.GenerateJWTKey(user, sessionID, i.signer, i.exp)
+	if err != nil {
+		return dto.TokenResponse{}, err
+	}
+
+	return dto.TokenResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(i.exp.Seconds()),
+	}, nil
+}
+
 // LoginService is the default implementation of the LoginService interface
 type LoginService struct {
-	userRepository domain.UserRepository
-	jwtKey         auth.JWTKey
-	exp            time.Duration
+	userRepository    domain.UserRepository
+	sessionRepository domain.SessionRepository
+	issuer            tokenIssuer
 }
 
-// NewLoginService creates a new instance of LoginService.
-func NewLoginService(userRepository domain.UserRepository, jwtKey auth.JWTKey, exp time.Duration) LoginService {
+// NewLoginService creates a new instance of LoginService. Access tokens live for exp and
+// refresh tokens for refreshExp.
+func NewLoginService(userRepository domain.UserRepository, sessionRepository domain.SessionRepository, signer auth.Signer, exp, refreshExp time.Duration) LoginService {
 	return LoginService{
-		userRepository: userRepository,
-		jwtKey:         jwtKey,
-		exp:            exp,
+		userRepository:    userRepository,
+		sessionRepository: sessionRepository,
+		issuer: tokenIssuer{
+			signer:     signer,
+			exp:        exp,
+			refreshExp: refreshExp,
+			now:        time.Now,
+		},
 	}
 }
 
-// LoginUser handles user login.
-func (s LoginService) LoginUser(ctx context.Context, email, password string) (string, error) {
+// LoginUser handles user login, opening a new session.
+func (s LoginService) LoginUser(ctx context.Context, email, password string) (dto.TokenResponse, error) {
 	// Obtain the user by email
 	emailVO, err := domain.NewUserEmail(email)
 	if err != nil {
-		return "", err
+		return dto.TokenResponse{}, err
 	}
 
 	// Find the user in the repository
 	user, err := s.userRepository.FindByEmail(ctx, emailVO)
 	if err != nil {
-		return "", err
+		return dto.TokenResponse{}, err
 	}
 
 	// Check if the provided password matches the stored hashed password
 	if err := auth.CheckPassword(user.Password().String(), password); err != nil {
-		return "", domain.ErrInvalidUserPassword
+		return dto.TokenResponse{}, domain.ErrInvalidUserPassword
+	}
+
+	// Only users who proved they own their email can log in
+	if !user.Verified() {
+		return dto.TokenResponse{}, domain.ErrUserNotVerified
+	}
+
+	// Open a session, which only keeps the hash of its refresh token
+	refreshToken, hash, expiresAt, err := s.issuer.refreshToken()
+	if err != nil {
+		return dto.TokenResponse{}, err
+	}
+	session, err := domain.NewSession(user.ID(), hash, expiresAt)
+	if err != nil {
+		return dto.TokenResponse{}, err
+	}
+	if err := s.sessionRepository.Save(ctx, session); err != nil {
+		return dto.TokenResponse{}, err
 	}
 
 	// Generate a new JWT token for the user
-	token, err := auth.GenerateJWTKey(user, s.jwtKey, s.exp)
+	return s.issuer.response(user, session.ID(), refreshToken)
+}
+
+// SessionService refreshes and ends the sessions opened by LoginService.
+type SessionService struct {
+	userRepository       domain.UserRepository
+	sessionRepository    domain.SessionRepository
+	revocationRepository domain.RevocationRepository
+	issuer               tokenIssuer
+}
+
+// NewSessionService creates a new SessionService, issuing tokens like NewLoginService.
+func NewSessionService(userRepository domain.UserRepository, sessionRepository domain.SessionRepository, revocationRepository domain.RevocationRepository, signer auth.Signer, exp, refreshExp time.Duration) SessionService {
+	return SessionService{
+		userRepository:       userRepository,
+		sessionRepository:    sessionRepository,
+		revocationRepository: revocationRepository,
+		issuer: tokenIssuer{
+			signer:     signer,
+			exp:        exp,
+			refreshExp: refreshExp,
+			now:        time.Now,
+		},
+	}
+}
+
+// Refresh exchanges a refresh token for a new access token and a new refresh token. The
+// refresh token can only be used once: presenting it again after it was rotated means it
+// leaked, so the whole session is revoked.
+func (s SessionService) Refresh(ctx context.Context, refreshToken string) (dto.TokenResponse, error) {
+	hash := auth.HashToken(refreshToken)
+	session, err := s.sessionRepository.FindByTokenHash(ctx, hash)
+	if errors.Is(err, domain.ErrSessionNotFound) {
+		return dto.TokenResponse{}, domain.ErrInvalidRefreshToken
+	}
 	if err != nil {
-		return "", err
+		return dto.TokenResponse{}, err
+	}
+
+	now := s.issuer.now()
+	if session.TokenHash() != hash {
+		if err := s.sessionRepository.Revoke(ctx, session.ID(), now); err != nil {
+			return dto.TokenResponse{}, err
+		}
+		return dto.TokenResponse{}, domain.ErrInvalidRefreshToken
+	}
+	if !session.Active(now) {
+		return dto.TokenResponse{}, domain.ErrInvalidRefreshToken
 	}
 
-	// Return the generated token
-	return token, nil
+	user, err := s.userRepository.Find(ctx, session.UserID())
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return dto.TokenResponse{}, domain.ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return dto.TokenResponse{}, err
+	}
+
+	newRefreshToken, newHash, expiresAt, err := s.issuer.refreshToken()
+	if err != nil {
+		return dto.TokenResponse{}, err
+	}
+	rotated, err := session.Rotate(newHash, expiresAt)
+	if err != nil {
+		return dto.TokenResponse{}, err
+	}
+	if err := s.sessionRepository.Rotate(ctx, rotated); err != nil {
+		return dto.TokenResponse{}, err
+	}
+
+	return s.issuer.response(user, session.ID(), newRefreshToken)
+}
+
+// Logout ends a session: its refresh token can no longer be used, and the access token
+// with the given jti, which expires at expiresAt, is revoked right away. The tokens that
+// expired meanwhile are dropped from the revocation list.
+func (s SessionService) Logout(ctx context.Context, sessionID, jti string, expiresAt time.Time) error {
+	id, err := domain.NewSessionIDFromString(sessionID)
+	if err != nil {
+		return err
+	}
+
+	now := s.issuer.now()
+	if err := s.revocationRepository.Revoke(ctx, jti, expiresAt); err != nil {
+		return err
+	}
+	if err := s.sessionRepository.Revoke(ctx, id, now); err != nil {
+		return err
+	}
+	return s.revocationRepository.Prune(ctx, now)
+}
+
+// RevokeSessions ends every session of a user. The access tokens issued for them are
+// rejected from then on, as their session is revoked.
+func (s SessionService) RevokeSessions(ctx context.Context, userID string) error {
+	id, err := domain.NewUserIDFromString(userID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.userRepository.Find(ctx, id); err != nil {
+		return err
+	}
+	return s.sessionRepository.RevokeByUser(ctx, id, domain.SessionID{}, s.issuer.now())
+}
+
+// IsRevoked tells whether the access token with the given jti, issued for the session
+// with the given ID, was revoked, either itself or along with its session. A token with
+// an invalid session ID counts as revoked.
+func (s SessionService) IsRevoked(ctx context.Context, jti, sessionID string) (bool, error) {
+	id, err := domain.NewSessionIDFromString(sessionID)
+	if err != nil {
+		return true, nil
+	}
+
+	revoked, err := s.revocationRepository.IsRevoked(ctx, jti)
+	if err != nil || revoked {
+		return revoked, err
+	}
+	return s.sessionRepository.IsRevoked(ctx, id)
 }
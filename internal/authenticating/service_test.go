@@ -9,20 +9,27 @@ import (
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/auth"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/storage/storagemocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 const (
 	email  = "user@example.com"
 	jwtKey = "some.jwt.token"
-	exp    = 24 * time.Hour
+	exp    = 15 * time.Minute
+
+	refreshExp = 720 * time.Hour
 )
 
+var signer = auth.NewHMACSigner([]byte(jwtKey))
+
 func TestLoginServiceLoginUserRepositoryEmailError(t *testing.T) {
 	email := "invalid-email"
 	password := "password123"
 
 	userRepositoryMock := new(storagemocks.UserRepository)
-	service := NewLoginService(userRepositoryMock, []byte(jwtKey), exp)
+	sessionRepositoryMock := new(storagemocks.SessionRepository)
+	service := NewLoginService(userRepositoryMock, sessionRepositoryMock, signer, exp, refreshExp)
 
 	_, err := service.LoginUser(context.Background(), email, password)
 	assert.Error(t, err)
@@ -36,10 +43,11 @@ func TestLoginServiceLoginUserRepositoryFindByEmailError(t *testing.T) {
 	assert.NoError(t, err)
 
 	userRepositoryMock := new(storagemocks.UserRepository)
+	sessionRepositoryMock := new(storagemocks.SessionRepository)
 	userRepositoryMock.On("FindByEmail", context.Background(), emailVO).Return(domain.User{}, domain.ErrUserNotFound)
 	defer userRepositoryMock.AssertExpectations(t)
 
-	service := NewLoginService(userRepositoryMock, []byte(jwtKey), exp)
+	service := NewLoginService(userRepositoryMock, sessionRepositoryMock, signer, exp, refreshExp)
 
 	_, err = service.LoginUser(context.Background(), email, password)
 	assert.Error(t, err)
@@ -53,10 +61,11 @@ func TestLoginServiceLoginUserPasswordError(t *testing.T) {
 	assert.NoError(t, err)
 
 	userRepositoryMock := new(storagemocks.UserRepository)
+	sessionRepositoryMock := new(storagemocks.SessionRepository)
 	userRepositoryMock.On("FindByEmail", context.Background(), emailVO).Return(domain.User{}, nil)
 	defer userRepositoryMock.AssertExpectations(t)
 
-	service := NewLoginService(userRepositoryMock, []byte(jwtKey), exp)
+	service := NewLoginService(userRepositoryMock, sessionRepositoryMock, signer, exp, refreshExp)
 
 	_, err = service.LoginUser(context.Background(), email, password)
 	assert.Error(t, err)
@@ -66,17 +75,230 @@ func TestLoginServiceLoginUserPasswordError(t *testing.T) {
 func TestLoginServiceLoginUserSuccess(t *testing.T) {
 	password := "password123"
 	hashedPassword, _ := auth.HashPassword(password)
-	user, _ := domain.NewUser("name", email, hashedPassword, false)
+	user, _ := domain.NewUser("name", email, hashedPassword, domain.RoleViewer)
+	user = user.Verify()
+
+	emailVO, err := domain.NewUserEmail(email)
+	assert.NoError(t, err)
+
+	userRepositoryMock := new(storagemocks.UserRepository)
+	sessionRepositoryMock := new(storagemocks.SessionRepository)
+	userRepositoryMock.On("FindByEmail", context.Background(), emailVO).Return(user, nil)
+	defer userRepositoryMock.AssertExpectations(t)
+
+	sessionRepositoryMock.On("Save", context.Background(), mock.MatchedBy(func(s domain.Session) bool {
+		return s.UserID() == user.ID() && s.PreviousTokenHash() == ""
+	})).Return(nil)
+	defer sessionRepositoryMock.AssertExpectations(t)
+
+	service := NewLoginService(userRepositoryMock, sessionRepositoryMock, signer, exp, refreshExp)
+
+	res, err := service.LoginUser(context.Background(), email, password)
+	require.NoError(t, err)
+	assert.NotEmpty(t, res.Token)
+	assert.NotEmpty(t, res.RefreshToken)
+	assert.Equal(t, int(exp.Seconds()), res.ExpiresIn)
+
+	saved := sessionRepositoryMock.Calls[0].Arguments.Get(1).(domain.Session)
+	assert.Equal(t, auth.HashToken(res.RefreshToken), saved.TokenHash())
+}
+
+func TestLoginServiceLoginUserNotVerified(t *testing.T) {
+	password := "password123"
+	hashedPassword, _ := auth.HashPassword(password)
+	user, _ := domain.NewUser("name", email, hashedPassword, domain.RoleViewer)
 
 	emailVO, err := domain.NewUserEmail(email)
 	assert.NoError(t, err)
 
 	userRepositoryMock := new(storagemocks.UserRepository)
+	sessionRepositoryMock := new(storagemocks.SessionRepository)
 	userRepositoryMock.On("FindByEmail", context.Background(), emailVO).Return(user, nil)
 	defer userRepositoryMock.AssertExpectations(t)
+	defer sessionRepositoryMock.AssertExpectations(t)
 
-	service := NewLoginService(userRepositoryMock, []byte(jwtKey), exp)
+	service := NewLoginService(userRepositoryMock, sessionRepositoryMock, signer, exp, refreshExp)
 
 	_, err = service.LoginUser(context.Background(), email, password)
+	assert.ErrorIs(t, err, domain.ErrUserNotVerified)
+}
+
+func newSessionService(t *testing.T) (SessionService, *storagemocks.UserRepository, *storagemocks.SessionRepository, *storagemocks.RevocationRepository) {
+	t.Helper()
+
+	userRepositoryMock := new(storagemocks.UserRepository)
+	sessionRepositoryMock := new(storagemocks.SessionRepository)
+	revocationRepositoryMock := new(storagemocks.RevocationRepository)
+	t.Cleanup(func() {
+		userRepositoryMock.AssertExpectations(t)
+		sessionRepositoryMock.AssertExpectations(t)
+		revocationRepositoryMock.AssertExpectations(t)
+	})
+
+	service := NewSessionService(userRepositoryMock, sessionRepositoryMock, revocationRepositoryMock, signer, exp, refreshExp)
+	return service, userRepositoryMock, sessionRepositoryMock, revocationRepositoryMock
+}
+
+func newSession(t *testing.T, userID domain.UserID, refreshToken string, expiresAt time.Time) domain.Session {
+	t.Helper()
+
+	session, err := domain.NewSession(userID, auth.HashToken(refreshToken), expiresAt)
+	require.NoError(t, err)
+	return session
+}
+
+func TestSessionServiceRefreshUnknownToken(t *testing.T) {
+	service, _, sessionRepositoryMock, _ := newSessionService(t)
+	sessionRepositoryMock.On("FindByTokenHash", context.Background(), auth.HashToken("unknown")).
+		Return(domain.Session{}, domain.ErrSessionNotFound)
+
+	_, err := service.Refresh(context.Background(), "unknown")
+	assert.ErrorIs(t, err, domain.ErrInvalidRefreshToken)
+}
+
+func TestSessionServiceRefreshExpired(t *testing.T) {
+	user, err := domain.NewUser("name", email, "hashed", domain.RoleViewer)
+	require.NoError(t, err)
+	session := newSession(t, user.ID(), "token", time.Now().Add(-time.Minute))
+
+	service, _, sessionRepositoryMock, _ := newSessionService(t)
+	sessionRepositoryMock.On("FindByTokenHash", context.Background(), session.TokenHash()).Return(session, nil)
+
+	_, err = service.Refresh(context.Background(), "token")
+	assert.ErrorIs(t, err, domain.ErrInvalidRefreshToken)
+}
+
+func TestSessionServiceRefreshReusedTokenRevokesSession(t *testing.T) {
+	user, err := domain.NewUser("name", email, "hashed", domain.RoleViewer)
+	require.NoError(t, err)
+	session := newSession(t, user.ID(), "old", time.Now().Add(time.Hour))
+	session, err = session.Rotate(auth.HashToken("new"), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	service, _, sessionRepositoryMock, _ := newSessionService(t)
+	sessionRepositoryMock.On("FindByTokenHash", context.Background(), auth.HashToken("old")).Return(session, nil)
+	sessionRepositoryMock.On("Revoke", context.Background(), session.ID(), mock.AnythingOfType("time.Time")).Return(nil)
+
+	_, err = service.Refresh(context.Background(), "old")
+	assert.ErrorIs(t, err, domain.ErrInvalidRefreshToken)
+}
+
+func TestSessionServiceRefreshSuccess(t *testing.T) {
+	user, err := domain.NewUser("name", email, "hashed", domain.RoleViewer)
+	require.NoError(t, err)
+	session := newSession(t, user.ID(), "token", time.Now().Add(time.Hour))
+
+	service, userRepositoryMock, sessionRepositoryMock, _ := newSessionService(t)
+	sessionRepositoryMock.On("FindByTokenHash", context.Background(), session.TokenHash()).Return(session, nil)
+	userRepositoryMock.On("Find", context.Background(), user.ID()).Return(user, nil)
+	sessionRepositoryMock.On("Rotate", context.Background(), mock.MatchedBy(func(s domain.Session) bool {
+		return s.ID() == session.ID() && s.PreviousTokenHash() == session.TokenHash()
+	})).Return(nil)
+
+	res, err := service.Refresh(context.Background(), "token")
+	require.NoError(t, err)
+	assert.NotEmpty(t, res.Token)
+	assert.NotEqual(t, "token", res.RefreshToken)
+
+	rotated := sessionRepositoryMock.Calls[1].Arguments.Get(1).(domain.Session)
+	assert.Equal(t, auth.HashToken(res.RefreshToken), rotated.TokenHash())
+
+	claims, err := auth.ValidateToken(res.Token, signer)
+	require.NoError(t, err)
+	assert.Equal(t, session.ID().String(), claims["sid"])
+}
+
+func TestSessionServiceRefreshConcurrentRotation(t *testing.T) {
+	user, err := domain.NewUser("name", email, "hashed", domain.RoleViewer)
+	require.NoError(t, err)
+	session := newSession(t, user.ID(), "token", time.Now().Add(time.Hour))
+
+	service, userRepositoryMock, sessionRepositoryMock, _ := newSessionService(t)
+	sessionRepositoryMock.On("FindByTokenHash", context.Background(), session.TokenHash()).Return(session, nil)
+	userRepositoryMock.On("Find", context.Background(), user.ID()).Return(user, nil)
+	sessionRepositoryMock.On("Rotate", context.Background(), mock.Anything).Return(domain.ErrInvalidRefreshToken)
+
+	_, err = service.Refresh(context.Background(), "token")
+	assert.ErrorIs(t, err, domain.ErrInvalidRefreshToken)
+}
+
+func TestSessionServiceLogout(t *testing.T) {
+	sessionID, err := domain.NewSessionID()
+	require.NoError(t, err)
+	expiresAt := time.Now().Add(exp)
+
+	service, _, sessionRepositoryMock, revocationRepositoryMock := newSessionService(t)
+	revocationRepositoryMock.On("Revoke", context.Background(), "some-jti", expiresAt).Return(nil)
+	sessionRepositoryMock.On("Revoke", context.Background(), sessionID, mock.AnythingOfType("time.Time")).Return(nil)
+	revocationRepositoryMock.On("Prune", context.Background(), mock.AnythingOfType("time.Time")).Return(nil)
+
+	err = service.Logout(context.Background(), sessionID.String(), "some-jti", expiresAt)
 	assert.NoError(t, err)
 }
+
+func TestSessionServiceLogoutInvalidSessionID(t *testing.T) {
+	service, _, _, _ := newSessionService(t)
+
+	err := service.Logout(context.Background(), "invalid", "some-jti", time.Now())
+	assert.ErrorIs(t, err, domain.ErrInvalidSessionID)
+}
+
+func TestSessionServiceRevokeSessions(t *testing.T) {
+	user, err := domain.NewUser("name", email, "hashed", domain.RoleViewer)
+	require.NoError(t, err)
+
+	service, userRepositoryMock, sessionRepositoryMock, _ := newSessionService(t)
+	userRepositoryMock.On("Find", context.Background(), user.ID()).Return(user, nil)
+	sessionRepositoryMock.On("RevokeByUser", context.Background(), user.ID(), domain.SessionID{}, mock.AnythingOfType("time.Time")).Return(nil)
+
+	err = service.RevokeSessions(context.Background(), user.ID().String())
+	assert.NoError(t, err)
+}
+
+func TestSessionServiceRevokeSessionsUserNotFound(t *testing.T) {
+	userID, err := domain.NewUserID()
+	require.NoError(t, err)
+
+	service, userRepositoryMock, _, _ := newSessionService(t)
+	userRepositoryMock.On("Find", context.Background(), userID).Return(domain.User{}, domain.ErrUserNotFound)
+
+	err = service.RevokeSessions(context.Background(), userID.String())
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func TestSessionServiceIsRevoked(t *testing.T) {
+	sessionID, err := domain.NewSessionID()
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		tokenRevoked   bool
+		sessionRevoked bool
+		expected       bool
+	}{
+		"active":          {expected: false},
+		"token revoked":   {tokenRevoked: true, expected: true},
+		"session revoked": {sessionRevoked: true, expected: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			service, _, sessionRepositoryMock, revocationRepositoryMock := newSessionService(t)
+			revocationRepositoryMock.On("IsRevoked", context.Background(), "some-jti").Return(tt.tokenRevoked, nil)
+			if !tt.tokenRevoked {
+				sessionRepositoryMock.On("IsRevoked", context.Background(), sessionID).Return(tt.sessionRevoked, nil)
+			}
+
+			revoked, err := service.IsRevoked(context.Background(), "some-jti", sessionID.String())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, revoked)
+		})
+	}
+}
+
+func TestSessionServiceIsRevokedInvalidSessionID(t *testing.T) {
+	service, _, _, _ := newSessionService(t)
+
+	revoked, err := service.IsRevoked(context.Background(), "some-jti", "invalid")
+	require.NoError(t, err)
+	assert.True(t, revoked)
+}
@@ -0,0 +1,52 @@
+package authorizing
+
+import (
+	"context"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/query"
+)
+
+const PermissionQueryType = "query.authorizing.permission"
+
+// PermissionQuery asks whether the role of a user grants a permission. It is answered
+// with a bool.
+type PermissionQuery struct {
+	UserID     string
+	Permission domain.Permission
+}
+
+// NewPermissionQuery creates a new PermissionQuery instance.
+func NewPermissionQuery(userID string, permission domain.Permission) PermissionQuery {
+	return PermissionQuery{
+		UserID:     userID,
+		Permission: permission,
+	}
+}
+
+// Type returns the query type.
+func (q PermissionQuery) Type() query.Type {
+	return PermissionQueryType
+}
+
+// PermissionQueryHandler handles the permission query.
+type PermissionQueryHandler struct {
+	service RoleService
+}
+
+// NewPermissionQueryHandler creates a new PermissionQueryHandler instance.
+func NewPermissionQueryHandler(service RoleService) PermissionQueryHandler {
+	return PermissionQueryHandler{
+		service: service,
+	}
+}
+
+// Handle processes the permission query.
+func (h PermissionQueryHandler) Handle(ctx context.Context, query query.Query) (any, error) {
+	permissionQuery, ok := query.(PermissionQuery)
+	if !ok {
+		return nil, nil
+	}
+
+	return h.service.HasPermission(ctx, permissionQuery.UserID, permissionQuery.Permission)
+}
@@ -0,0 +1,50 @@
+package authorizing
+
+import (
+	"context"
+	"errors"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+)
+
+// RoleService checks what the roles of the users allow them to do. The user and their
+// role are read on every check, so that changing the role of a user, deleting them or
+// changing a role applies to the tokens already issued.
+type RoleService struct {
+	userRepository domain.UserRepository
+	roleRepository domain.RoleRepository
+}
+
+func NewRoleService(userRepository domain.UserRepository, roleRepository domain.RoleRepository) RoleService {
+	return RoleService{
+		userRepository: userRepository,
+		roleRepository: roleRepository,
+	}
+}
+
+// HasPermission tells whether the current role of the user grants the permission.
+// Unknown users and roles grant nothing.
+func (s RoleService) HasPermission(ctx context.Context, userID string, permission domain.Permission) (bool, error) {
+	id, err := domain.NewUserIDFromString(userID)
+	if err != nil {
+		return false, nil
+	}
+
+	user, err := s.userRepository.Find(ctx, id)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	role, err := s.roleRepository.Find(ctx, user.Role())
+	if errors.Is(err, domain.ErrRoleNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return role.Can(permission), nil
+}
@@ -0,0 +1,91 @@
+package authorizing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/storage/storagemocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const userID = "123e4567-e89b-12d3-a456-426614174000"
+
+func newUser(t *testing.T, role string) domain.User {
+	user, err := domain.NewUserWithID(userID, "Samwise Gamgee", "sam@shire.me", "hashed-password", role, true)
+	require.NoError(t, err)
+	return user
+}
+
+func TestRoleServiceHasPermission(t *testing.T) {
+	user := newUser(t, domain.RoleEditor)
+	editor, err := domain.NewRole(domain.RoleEditor, []string{domain.PermissionAnnotate.String()})
+	require.NoError(t, err)
+
+	userRepositoryMock := new(storagemocks.UserRepository)
+	userRepositoryMock.On("Find", mock.Anything, user.ID()).Return(user, nil)
+	defer userRepositoryMock.AssertExpectations(t)
+
+	roleRepositoryMock := new(storagemocks.RoleRepository)
+	roleRepositoryMock.On("Find", mock.Anything, editor.Name()).Return(editor, nil)
+	defer roleRepositoryMock.AssertExpectations(t)
+
+	service := NewRoleService(userRepositoryMock, roleRepositoryMock)
+
+	allowed, err := service.HasPermission(context.Background(), userID, domain.PermissionAnnotate)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	allowed, err = service.HasPermission(context.Background(), userID, domain.PermissionManageUsers)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+}
+
+func TestRoleServiceHasPermissionUnknownUser(t *testing.T) {
+	userRepositoryMock := new(storagemocks.UserRepository)
+	userRepositoryMock.On("Find", mock.Anything, mock.Anything).Return(domain.User{}, domain.ErrUserNotFound)
+	defer userRepositoryMock.AssertExpectations(t)
+
+	service := NewRoleService(userRepositoryMock, new(storagemocks.RoleRepository))
+
+	allowed, err := service.HasPermission(context.Background(), userID, domain.PermissionAnnotate)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	// Tokens without a user ID grant nothing either, without asking the repository
+	allowed, err = service.HasPermission(context.Background(), "", domain.PermissionAnnotate)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+}
+
+func TestRoleServiceHasPermissionUnknownRole(t *testing.T) {
+	user := newUser(t, "wizard")
+
+	userRepositoryMock := new(storagemocks.UserRepository)
+	userRepositoryMock.On("Find", mock.Anything, user.ID()).Return(user, nil)
+	defer userRepositoryMock.AssertExpectations(t)
+
+	roleRepositoryMock := new(storagemocks.RoleRepository)
+	roleRepositoryMock.On("Find", mock.Anything, user.Role()).Return(domain.Role{}, domain.ErrRoleNotFound)
+	defer roleRepositoryMock.AssertExpectations(t)
+
+	service := NewRoleService(userRepositoryMock, roleRepositoryMock)
+
+	allowed, err := service.HasPermission(context.Background(), userID, domain.PermissionAnnotate)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+}
+
+func TestRoleServiceHasPermissionRepositoryError(t *testing.T) {
+	userRepositoryMock := new(storagemocks.UserRepository)
+	userRepositoryMock.On("Find", mock.Anything, mock.Anything).Return(domain.User{}, errors.New("connection lost"))
+	defer userRepositoryMock.AssertExpectations(t)
+
+	service := NewRoleService(userRepositoryMock, new(storagemocks.RoleRepository))
+
+	_, err := service.HasPermission(context.Background(), userID, domain.PermissionManageUsers)
+	assert.Error(t, err)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+
 	"github.com/google/uuid"
 )
 
@@ -66,8 +68,12 @@ func (n CategoryName) String() string {
 type CategoryRepository interface {
 	Save(ctx context.Context, category Category) error
 	Find(ctx context.Context, id CategoryID) (Category, error)
+	FindInTrash(ctx context.Context, id CategoryID) (Category, error) // Finds the category only if it is in the trash
 	FindAll(ctx context.Context) ([]Category, error)
-	Delete(ctx context.Context, id CategoryID) error
+	FindPage(ctx context.Context, page PageRequest) ([]Category, *Cursor, error)
+	Delete(ctx context.Context, id CategoryID) error  // Moves the category to the trash
+	Restore(ctx context.Context, id CategoryID) error // Takes the category out of the trash
+	Purge(ctx context.Context, id CategoryID) error   // Deletes the category for good, once in the trash
 	Update(ctx context.Context, category Category) error
 }
 
@@ -76,6 +82,8 @@ type CategoryRepository interface {
 type Category struct {
 	id   CategoryID
 	name CategoryName
+
+	events []event.Event
 }
 
 func NewCategory(name string) (Category, error) {
@@ -94,6 +102,8 @@ func NewCategory(name string) (Category, error) {
 		name: nameVO,
 	}
 
+	category.Record(NewCategoryCreatedEvent(category.ID().String(), category.Name().String()))
+
 	return category, nil
 }
 
@@ -123,3 +133,16 @@ func (c Category) ID() CategoryID {
 func (c Category) Name() CategoryName {
 	return c.name
 }
+
+// Record adds an event to the category's event list.
+func (c *Category) Record(event event.Event) {
+	c.events = append(c.events, event)
+}
+
+// PullEvents returns the events recorded for the category and clears the event list.
+func (c *Category) PullEvents() []event.Event {
+	events := c.events
+	c.events = nil
+
+	return events
+}
@@ -3,6 +3,8 @@ package creating
 import (
 	"context"
 
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/auditing"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/command"
 )
@@ -15,15 +17,18 @@ const (
 	TrackCommandType      command.Type = "command.create.track"
 	ThemeCommandType      command.Type = "command.create.theme"
 	TrackThemeCommandType command.Type = "command.create.track_theme"
+	WebhookCommandType    command.Type = "command.create.webhook"
 )
 
 type UserCommand struct {
 	dto dto.UserCreateRequest
+	id  *string // Set to the ID of the new user once the command has run
 }
 
 func NewUserCommand(dto dto.UserCreateRequest) UserCommand {
 	return UserCommand{
 		dto: dto,
+		id:  new(string),
 	}
 }
 
@@ -31,6 +36,10 @@ func (c UserCommand) Type() command.Type {
 	return UserCommandType
 }
 
+func (c UserCommand) AuditTarget() auditing.Target {
+	return auditing.Target{Entity: domain.AuditEntityUser, Created: func() string { return *c.id }}
+}
+
 type UserCommandHandler struct {
 	service UserService
 }
@@ -47,16 +56,24 @@ func (h UserCommandHandler) Handle(ctx context.Context, cmd command.Command) err
 		return nil
 	}
 
-	return h.service.CreateUser(ctx, userCmd.dto)
+	id, err := h.service.CreateUser(ctx, userCmd.dto)
+	if err != nil {
+		return err
+	}
+
+	*userCmd.id = id
+	return nil
 }
 
 type MovieCommand struct {
 	dto dto.MovieCreateRequest
+	id  *string // Set to the ID of the new movie once the command has run
 }
 
 func NewMovieCommand(dto dto.MovieCreateRequest) MovieCommand {
 	return MovieCommand{
 		dto: dto,
+		id:  new(string),
 	}
 }
 
@@ -64,6 +81,10 @@ func (c MovieCommand) Type() command.Type {
 	return MovieCommandType
 }
 
+func (c MovieCommand) AuditTarget() auditing.Target {
+	return auditing.Target{Entity: domain.AuditEntityMovie, Created: func() string { return *c.id }}
+}
+
 type MovieCommandHandler struct {
 	service MovieService
 }
@@ -80,16 +101,24 @@ func (h MovieCommandHandler) Handle(ctx context.Context, cmd command.Command) er
 		return nil
 	}
 
-	return h.service.CreateMovie(ctx, movieCmd.dto)
+	id, err := h.service.CreateMovie(ctx, movieCmd.dto)
+	if err != nil {
+		return err
+	}
+
+	*movieCmd.id = id
+	return nil
 }
 
 type GroupCommand struct {
 	dto dto.GroupCreateRequest
+	id  *string // Set to the ID of the new group once the command has run
 }
 
 func NewGroupCommand(dto dto.GroupCreateRequest) GroupCommand {
 	return GroupCommand{
 		dto: dto,
+		id:  new(string),
 	}
 }
 
@@ -97,6 +126,10 @@ func (c GroupCommand) Type() command.Type {
 	return GroupCommandType
 }
 
+func (c GroupCommand) AuditTarget() auditing.Target {
+	return auditing.Target{Entity: domain.AuditEntityGroup, Created: func() string { return *c.id }}
+}
+
 type GroupCommandHandler struct {
 	service GroupService
 }
@@ -113,16 +146,24 @@ func (h GroupCommandHandler) Handle(ctx context.Context, cmd command.Command) er
 		return nil
 	}
 
-	return h.service.CreateGroup(ctx, groupCmd.dto)
+	id, err := h.service.CreateGroup(ctx, groupCmd.dto)
+	if err != nil {
+		return err
+	}
+
+	*groupCmd.id = id
+	return nil
 }
 
 type CategoryCommand struct {
 	dto dto.CategoryCreateRequest
+	id  *string // Set to the ID of the new category once the command has run
 }
 
 func NewCategoryCommand(dto dto.CategoryCreateRequest) CategoryCommand {
 	return CategoryCommand{
 		dto: dto,
+		id:  new(string),
 	}
 }
 
@@ -130,6 +171,10 @@ func (c CategoryCommand) Type() command.Type {
 	return CategoryCommandType
 }
 
+func (c CategoryCommand) AuditTarget() auditing.Target {
+	return auditing.Target{Entity: domain.AuditEntityCategory, Created: func() string { return *c.id }}
+}
+
 type CategoryCommandHandler struct {
 	service CategoryService
 }
@@ -146,16 +191,24 @@ func (h CategoryCommandHandler) Handle(ctx context.Context, cmd command.Command)
 		return nil
 	}
 
-	return h.service.CreateCategory(ctx, categoryCmd.dto)
+	id, err := h.service.CreateCategory(ctx, categoryCmd.dto)
+	if err != nil {
+		return err
+	}
+
+	*categoryCmd.id = id
+	return nil
 }
 
 type TrackCommand struct {
 	dto dto.TrackCreateRequest
+	id  *string // Set to the ID of the new track once the command has run
 }
 
 func NewTrackCommand(dto dto.TrackCreateRequest) TrackCommand {
 	return TrackCommand{
 		dto: dto,
+		id:  new(string),
 	}
 }
 
@@ -163,6 +216,10 @@ func (c TrackCommand) Type() command.Type {
 	return TrackCommandType
 }
 
+func (c TrackCommand) AuditTarget() auditing.Target {
+	return auditing.Target{Entity: domain.AuditEntityTrack, Created: func() string { return *c.id }}
+}
+
 type TrackCommandHandler struct {
 	service TrackService
 }
@@ -179,16 +236,24 @@ func (h TrackCommandHandler) Handle(ctx context.Context, cmd command.Command) er
 		return nil
 	}
 
-	return h.service.CreateTrack(ctx, trackCmd.dto)
+	id, err := h.service.CreateTrack(ctx, trackCmd.dto)
+	if err != nil {
+		return err
+	}
+
+	*trackCmd.id = id
+	return nil
 }
 
 type ThemeCommand struct {
 	dto dto.ThemeCreateRequest
+	id  *string // Set to the ID of the new theme once the command has run
 }
 
 func NewThemeCommand(dto dto.ThemeCreateRequest) ThemeCommand {
 	return ThemeCommand{
 		dto: dto,
+		id:  new(string),
 	}
 }
 
@@ -196,6 +261,10 @@ func (c ThemeCommand) Type() command.Type {
 	return ThemeCommandType
 }
 
+func (c ThemeCommand) AuditTarget() auditing.Target {
+	return auditing.Target{Entity: domain.AuditEntityTheme, Created: func() string { return *c.id }}
+}
+
 type ThemeCommandHandler struct {
 	service ThemeService
 }
@@ -212,7 +281,13 @@ func (h ThemeCommandHandler) Handle(ctx context.Context, cmd command.Command) er
 		return nil
 	}
 
-	return h.service.CreateTheme(ctx, themeCmd.dto)
+	id, err := h.service.CreateTheme(ctx, themeCmd.dto)
+	if err != nil {
+		return err
+	}
+
+	*themeCmd.id = id
+	return nil
 }
 
 type TrackThemeCommand struct {
@@ -229,6 +304,10 @@ func (c TrackThemeCommand) Type() command.Type {
 	return TrackThemeCommandType
 }
 
+func (c TrackThemeCommand) AuditTarget() auditing.Target {
+	return auditing.Target{Entity: domain.AuditEntityTrackTheme, Request: c.dto}
+}
+
 type TrackThemeCommandHandler struct {
 	service TrackThemeService
 }
@@ -247,3 +326,48 @@ func (h TrackThemeCommandHandler) Handle(ctx context.Context, cmd command.Comman
 
 	return h.service.CreateTrackTheme(ctx, trackThemeCmd.dto)
 }
+
+type WebhookCommand struct {
+	dto dto.WebhookCreateRequest
+	id  *string // Set to the ID of the new webhook once the command has run
+}
+
+func NewWebhookCommand(dto dto.WebhookCreateRequest) WebhookCommand {
+	return WebhookCommand{
+		dto: dto,
+		id:  new(string),
+	}
+}
+
+func (c WebhookCommand) Type() command.Type {
+	return WebhookCommandType
+}
+
+func (c WebhookCommand) AuditTarget() auditing.Target {
+	return auditing.Target{Entity: domain.AuditEntityWebhook, Created: func() string { return *c.id }}
+}
+
+type WebhookCommandHandler struct {
+	service WebhookService
+}
+
+func NewWebhookCommandHandler(service WebhookService) WebhookCommandHandler {
+	return WebhookCommandHandler{
+		service: service,
+	}
+}
+
+func (h WebhookCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	webhookCmd, ok := cmd.(WebhookCommand)
+	if !ok {
+		return nil
+	}
+
+	id, err := h.service.CreateWebhook(ctx, webhookCmd.dto)
+	if err != nil {
+		return err
+	}
+
+	*webhookCmd.id = id
+	return nil
+}
@@ -7,144 +7,261 @@ import (
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/auth"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/tx"
 )
 
 type UserService struct {
 	userRepository domain.UserRepository
+	txManager      tx.Manager
 	eventBus       event.Bus
 }
 
-func NewUserService(userRepository domain.UserRepository, eventBus event.Bus) UserService {
+func NewUserService(userRepository domain.UserRepository, txManager tx.Manager, eventBus event.Bus) UserService {
 	return UserService{
 		userRepository: userRepository,
+		txManager:      txManager,
 		eventBus:       eventBus,
 	}
 }
 
-func (s UserService) CreateUser(ctx context.Context, dto dto.UserCreateRequest) error {
+func (s UserService) CreateUser(ctx context.Context, dto dto.UserCreateRequest) (string, error) {
 	// Hash the user's password
 	hashedPassword, err := auth.HashPassword(dto.Password)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	// Create a new user object. The isAdmin field is always set to false.
-	user, err := domain.NewUser(dto.Name, dto.Email, hashedPassword, false)
-	if err != nil {
-		return err
+	// Users are viewers unless they are given another role
+	role := dto.Role
+	if role == "" {
+		role = domain.RoleViewer
 	}
 
-	// Save the user to the database
-	if err := s.userRepository.Save(ctx, user); err != nil {
-		return err
+	user, err := domain.NewUser(dto.Name, dto.Email, hashedPassword, role)
+	if err != nil {
+		return "", err
+	}
+	// An admin vouches for the email of the users they create
+	user = user.Verify()
+
+	// Save the user and its events together, so that no event is lost if either fails
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.userRepository.Save(ctx, user); err != nil {
+			return err
+		}
+		return s.eventBus.Publish(ctx, user.PullEvents())
+	})
+	if err != nil {
+		return "", err
 	}
 
-	// Publish user events
-	return s.eventBus.Publish(ctx, user.PullEvents())
+	return user.ID().String(), nil
 }
 
 type MovieService struct {
 	movieRepository domain.MovieRepository
+	txManager       tx.Manager
+	eventBus        event.Bus
 }
 
-func NewMovieService(movieRepository domain.MovieRepository) MovieService {
+func NewMovieService(movieRepository domain.MovieRepository, txManager tx.Manager, eventBus event.Bus) MovieService {
 	return MovieService{
 		movieRepository: movieRepository,
+		txManager:       txManager,
+		eventBus:        eventBus,
 	}
 }
 
-func (s MovieService) CreateMovie(ctx context.Context, dto dto.MovieCreateRequest) error {
-	movie, err := domain.NewMovie(dto.Name)
+func (s MovieService) CreateMovie(ctx context.Context, dto dto.MovieCreateRequest) (string, error) {
+	movie, err := domain.NewMovie(dto.Name, dto.ReleaseYear, dto.Sequence, dto.Series, dto.RuntimeMinutes)
 	if err != nil {
-		return err
+		return "", err
+	}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.movieRepository.Save(ctx, movie); err != nil {
+			return err
+		}
+		return s.eventBus.Publish(ctx, movie.PullEvents())
+	})
+	if err != nil {
+		return "", err
 	}
 
-	return s.movieRepository.Save(ctx, movie)
+	return movie.ID().String(), nil
 }
 
 type GroupService struct {
 	groupRepository domain.GroupRepository
+	txManager       tx.Manager
+	eventBus        event.Bus
 }
 
-func NewGroupService(groupRepository domain.GroupRepository) GroupService {
+func NewGroupService(groupRepository domain.GroupRepository, txManager tx.Manager, eventBus event.Bus) GroupService {
 	return GroupService{
 		groupRepository: groupRepository,
+		txManager:       txManager,
+		eventBus:        eventBus,
 	}
 }
 
-func (s GroupService) CreateGroup(ctx context.Context, dto dto.GroupCreateRequest) error {
+func (s GroupService) CreateGroup(ctx context.Context, dto dto.GroupCreateRequest) (string, error) {
 	group, err := domain.NewGroup(dto.Name, dto.Description, dto.ImageURL)
 	if err != nil {
-		return err
+		return "", err
+	}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.groupRepository.Save(ctx, group); err != nil {
+			return err
+		}
+		return s.eventBus.Publish(ctx, group.PullEvents())
+	})
+	if err != nil {
+		return "", err
 	}
 
-	return s.groupRepository.Save(ctx, group)
+	return group.ID().String(), nil
 }
 
 type CategoryService struct {
 	categoryRepository domain.CategoryRepository
+	txManager          tx.Manager
+	eventBus           event.Bus
 }
 
-func NewCategoryService(categoryRepository domain.CategoryRepository) CategoryService {
+func NewCategoryService(categoryRepository domain.CategoryRepository, txManager tx.Manager, eventBus event.Bus) CategoryService {
 	return CategoryService{
 		categoryRepository: categoryRepository,
+		txManager:          txManager,
+		eventBus:           eventBus,
 	}
 }
 
-func (s CategoryService) CreateCategory(ctx context.Context, dto dto.CategoryCreateRequest) error {
+func (s CategoryService) CreateCategory(ctx context.Context, dto dto.CategoryCreateRequest) (string, error) {
 	category, err := domain.NewCategory(dto.Name)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	return s.categoryRepository.Save(ctx, category)
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.categoryRepository.Save(ctx, category); err != nil {
+			return err
+		}
+		return s.eventBus.Publish(ctx, category.PullEvents())
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return category.ID().String(), nil
 }
 
 type TrackService struct {
 	trackRepository domain.TrackRepository
+	txManager       tx.Manager
+	eventBus        event.Bus
 }
 
-func NewTrackService(trackRepository domain.TrackRepository) TrackService {
+func NewTrackService(trackRepository domain.TrackRepository, txManager tx.Manager, eventBus event.Bus) TrackService {
 	return TrackService{
 		trackRepository: trackRepository,
+		txManager:       txManager,
+		eventBus:        eventBus,
 	}
 }
 
-func (s TrackService) CreateTrack(ctx context.Context, dto dto.TrackCreateRequest) error {
-	track, err := domain.NewTrack(dto.Name, dto.MovieID, dto.SpotifyURL)
+func (s TrackService) CreateTrack(ctx context.Context, dto dto.TrackCreateRequest) (string, error) {
+	track, err := domain.NewTrack(dto.Name, dto.MovieID, dto.SpotifyURL, dto.TrackNumber, dto.DiscNumber, dto.Edition, dto.DurationSeconds)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	return s.trackRepository.Save(ctx, track)
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.trackRepository.Save(ctx, track); err != nil {
+			return err
+		}
+		return s.eventBus.Publish(ctx, track.PullEvents())
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return track.ID().String(), nil
 }
 
 type ThemeService struct {
-	themeRepository domain.ThemeRepository
+	themeRepository      domain.ThemeRepository
+	trackThemeRepository domain.TrackThemeRepository
+	trackRepository      domain.TrackRepository
+	txManager            tx.Manager
+	eventBus             event.Bus
 }
 
-func NewThemeService(themeRepository domain.ThemeRepository) ThemeService {
+func NewThemeService(themeRepository domain.ThemeRepository, trackThemeRepository domain.TrackThemeRepository, trackRepository domain.TrackRepository, txManager tx.Manager, eventBus event.Bus) ThemeService {
 	return ThemeService{
-		themeRepository: themeRepository,
+		themeRepository:      themeRepository,
+		trackThemeRepository: trackThemeRepository,
+		trackRepository:      trackRepository,
+		txManager:            txManager,
+		eventBus:             eventBus,
 	}
 }
 
-func (s ThemeService) CreateTheme(ctx context.Context, dto dto.ThemeCreateRequest) error {
+// CreateTheme saves the theme and, when it has a first-heard span, the matching track
+// theme, so that either both exist or neither does. Their events are published in the
+// same transaction. It returns the ID of the new theme.
+func (s ThemeService) CreateTheme(ctx context.Context, dto dto.ThemeCreateRequest) (string, error) {
 	theme, err := domain.NewTheme(dto.Name, dto.FirstHeard, dto.GroupID, dto.Description, dto.FirstHeardStart, dto.FirstHeardEnd, dto.CategoryID)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	return s.themeRepository.Save(ctx, theme)
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.themeRepository.Save(ctx, theme); err != nil {
+			return err
+		}
+
+		trackTheme, ok := theme.FirstHeardOccurrence()
+		if !ok {
+			return s.eventBus.Publish(ctx, theme.PullEvents())
+		}
+
+		// The occurrence must fit inside the track it is heard in
+		track, err := s.trackRepository.Find(ctx, trackTheme.TrackID())
+		if err != nil {
+			return err
+		}
+		if err := trackTheme.FitsIn(track); err != nil {
+			return err
+		}
+
+		trackTheme.Record(addedEvent(trackTheme))
+		if err := s.trackThemeRepository.Save(ctx, trackTheme); err != nil {
+			return err
+		}
+		return s.eventBus.Publish(ctx, append(theme.PullEvents(), trackTheme.PullEvents()...))
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return theme.ID().String(), nil
 }
 
 type TrackThemeService struct {
 	trackThemeRepository domain.TrackThemeRepository
+	trackRepository      domain.TrackRepository
+	txManager            tx.Manager
+	eventBus             event.Bus
 }
 
-func NewTrackThemeService(trackThemeRepository domain.TrackThemeRepository) TrackThemeService {
+func NewTrackThemeService(trackThemeRepository domain.TrackThemeRepository, trackRepository domain.TrackRepository, txManager tx.Manager, eventBus event.Bus) TrackThemeService {
 	return TrackThemeService{
 		trackThemeRepository: trackThemeRepository,
+		trackRepository:      trackRepository,
+		txManager:            txManager,
+		eventBus:             eventBus,
 	}
 }
 
@@ -154,5 +271,60 @@ func (s TrackThemeService) CreateTrackTheme(ctx context.Context, dto dto.TrackTh
 		return err
 	}
 
-	return s.trackThemeRepository.Save(ctx, trackTheme)
+	trackTheme.Record(addedEvent(trackTheme))
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		// The occurrence must fit inside the track it is heard in. Locking the track makes
+		// concurrent writes to its occurrences wait for this one, so they cannot both pass
+		// the overlap check below.
+		track, err := s.trackRepository.FindForUpdate(ctx, trackTheme.TrackID())
+		if err != nil {
+			return err
+		}
+		if err := trackTheme.FitsIn(track); err != nil {
+			return err
+		}
+
+		// Reject duplicates and overlaps with the theme's other occurrences in the track
+		existing, err := s.trackThemeRepository.FindByTrack(ctx, trackTheme.TrackID())
+		if err != nil {
+			return err
+		}
+		for _, other := range existing {
+			if trackTheme.Overlaps(other) {
+				return domain.ErrTrackThemeOverlaps
+			}
+		}
+
+		if err := s.trackThemeRepository.Save(ctx, trackTheme); err != nil {
+			return err
+		}
+		return s.eventBus.Publish(ctx, trackTheme.PullEvents())
+	})
+}
+
+func addedEvent(trackTheme domain.TrackTheme) domain.TrackThemeAddedEvent {
+	return domain.NewTrackThemeAddedEvent(trackTheme.TrackID().String(), trackTheme.ThemeID().String(), trackTheme.StartSecond().Int())
+}
+
+type WebhookService struct {
+	webhookRepository domain.WebhookRepository
+}
+
+func NewWebhookService(webhookRepository domain.WebhookRepository) WebhookService {
+	return WebhookService{
+		webhookRepository: webhookRepository,
+	}
+}
+
+func (s WebhookService) CreateWebhook(ctx context.Context, dto dto.WebhookCreateRequest) (string, error) {
+	webhook, err := domain.NewWebhook(dto.URL, dto.Secret, dto.EventTypes)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.webhookRepository.Save(ctx, webhook); err != nil {
+		return "", err
+	}
+
+	return webhook.ID().String(), nil
 }
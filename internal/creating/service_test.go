@@ -5,9 +5,12 @@ import (
 	"errors"
 	"testing"
 
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/storage/storagemocks"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event/eventmocks"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/tx/txmocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -37,9 +40,9 @@ func TestUserServiceCreateUserRepositoryError(t *testing.T) {
 	eventBusMock := new(eventmocks.Bus)
 	defer eventBusMock.AssertExpectations(t)
 
-	service := NewUserService(userRepositoryMock, eventBusMock)
+	service := NewUserService(userRepositoryMock, newTxManagerMock(t), eventBusMock)
 
-	err := service.CreateUser(context.Background(), dto)
+	_, err := service.CreateUser(context.Background(), dto)
 	assert.Error(t, err)
 }
 
@@ -51,16 +54,18 @@ func TestUserServiceCreateUserSuccess(t *testing.T) {
 	}
 
 	userRepositoryMock := new(storagemocks.UserRepository)
-	userRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType(domainUserType)).Return(nil).Once()
+	userRepositoryMock.On("Save", mock.Anything, mock.MatchedBy(func(user domain.User) bool {
+		return user.Verified()
+	})).Return(nil).Once()
 	defer userRepositoryMock.AssertExpectations(t)
 
 	eventBusMock := new(eventmocks.Bus)
 	eventBusMock.On("Publish", mock.Anything, mock.AnythingOfType("[]event.Event")).Return(nil).Once()
 	defer eventBusMock.AssertExpectations(t)
 
-	service := NewUserService(userRepositoryMock, eventBusMock)
+	service := NewUserService(userRepositoryMock, newTxManagerMock(t), eventBusMock)
 
-	err := service.CreateUser(context.Background(), dto)
+	_, err := service.CreateUser(context.Background(), dto)
 	assert.NoError(t, err)
 }
 
@@ -79,40 +84,69 @@ func TestUserServiceCreateUserEventBusError(t *testing.T) {
 	eventBusMock.On("Publish", mock.Anything, mock.AnythingOfType("[]event.Event")).Return(errors.New("event bus error")).Once()
 	defer eventBusMock.AssertExpectations(t)
 
-	service := NewUserService(userRepositoryMock, eventBusMock)
+	service := NewUserService(userRepositoryMock, newTxManagerMock(t), eventBusMock)
 
-	err := service.CreateUser(context.Background(), dto)
+	_, err := service.CreateUser(context.Background(), dto)
 	assert.Error(t, err)
 }
 
 func TestMovieServiceCreateMovieRepositoryError(t *testing.T) {
 	dto := dto.MovieCreateRequest{
-		Name: "Test Movie",
+		Name:        "Test Movie",
+		ReleaseYear: 2012,
+		Sequence:    4,
+		Series:      domain.MovieSeriesHobbit,
 	}
 
 	movieRepositoryMock := new(storagemocks.MovieRepository)
 	movieRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("domain.Movie")).Return(errors.New(repositoryErrorMsg)).Once()
 	defer movieRepositoryMock.AssertExpectations(t)
 
-	service := NewMovieService(movieRepositoryMock)
+	service := NewMovieService(movieRepositoryMock, newTxManagerMock(t), new(eventmocks.Bus))
 
-	err := service.CreateMovie(context.Background(), dto)
+	_, err := service.CreateMovie(context.Background(), dto)
 	assert.Error(t, err)
 }
 
 func TestMovieServiceCreateMovieSuccess(t *testing.T) {
 	dto := dto.MovieCreateRequest{
-		Name: "Test Movie",
+		Name:        "Test Movie",
+		ReleaseYear: 2012,
+		Sequence:    4,
+		Series:      domain.MovieSeriesHobbit,
 	}
 
+	var saved domain.Movie
 	movieRepositoryMock := new(storagemocks.MovieRepository)
-	movieRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("domain.Movie")).Return(nil).Once()
+	movieRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("domain.Movie")).Run(func(args mock.Arguments) {
+		saved = args.Get(1).(domain.Movie)
+	}).Return(nil).Once()
 	defer movieRepositoryMock.AssertExpectations(t)
 
-	service := NewMovieService(movieRepositoryMock)
+	service := NewMovieService(movieRepositoryMock, newTxManagerMock(t), newEventBusMock(t, domain.MovieCreatedEventType))
 
-	err := service.CreateMovie(context.Background(), dto)
+	id, err := service.CreateMovie(context.Background(), dto)
 	assert.NoError(t, err)
+	assert.Equal(t, saved.ID().String(), id)
+}
+
+func TestMovieServiceCreateMovieInvalidRuntime(t *testing.T) {
+	runtimeMinutes := 0
+	dto := dto.MovieCreateRequest{
+		Name:           "Test Movie",
+		ReleaseYear:    2012,
+		Sequence:       4,
+		Series:         domain.MovieSeriesHobbit,
+		RuntimeMinutes: &runtimeMinutes,
+	}
+
+	movieRepositoryMock := new(storagemocks.MovieRepository)
+	defer movieRepositoryMock.AssertExpectations(t)
+
+	service := NewMovieService(movieRepositoryMock, new(txmocks.Manager), new(eventmocks.Bus))
+
+	_, err := service.CreateMovie(context.Background(), dto)
+	assert.ErrorIs(t, err, domain.ErrInvalidMovieRuntime)
 }
 
 func TestGroupServiceCreateGroupRepositoryError(t *testing.T) {
@@ -126,9 +160,9 @@ func TestGroupServiceCreateGroupRepositoryError(t *testing.T) {
 	groupRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("domain.Group")).Return(errors.New(repositoryErrorMsg)).Once()
 	defer groupRepositoryMock.AssertExpectations(t)
 
-	service := NewGroupService(groupRepositoryMock)
+	service := NewGroupService(groupRepositoryMock, newTxManagerMock(t), new(eventmocks.Bus))
 
-	err := service.CreateGroup(context.Background(), dto)
+	_, err := service.CreateGroup(context.Background(), dto)
 	assert.Error(t, err)
 }
 
@@ -143,9 +177,9 @@ func TestGroupServiceCreateGroupSuccess(t *testing.T) {
 	groupRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("domain.Group")).Return(nil).Once()
 	defer groupRepositoryMock.AssertExpectations(t)
 
-	service := NewGroupService(groupRepositoryMock)
+	service := NewGroupService(groupRepositoryMock, newTxManagerMock(t), newEventBusMock(t, domain.GroupCreatedEventType))
 
-	err := service.CreateGroup(context.Background(), dto)
+	_, err := service.CreateGroup(context.Background(), dto)
 	assert.NoError(t, err)
 }
 
@@ -158,9 +192,9 @@ func TestCategoryServiceCreateCategoryRepositoryError(t *testing.T) {
 	categoryRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("domain.Category")).Return(errors.New(repositoryErrorMsg)).Once()
 	defer categoryRepositoryMock.AssertExpectations(t)
 
-	service := NewCategoryService(categoryRepositoryMock)
+	service := NewCategoryService(categoryRepositoryMock, newTxManagerMock(t), new(eventmocks.Bus))
 
-	err := service.CreateCategory(context.Background(), dto)
+	_, err := service.CreateCategory(context.Background(), dto)
 	assert.Error(t, err)
 }
 
@@ -173,41 +207,49 @@ func TestCategoryServiceCreateCategorySuccess(t *testing.T) {
 	categoryRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("domain.Category")).Return(nil).Once()
 	defer categoryRepositoryMock.AssertExpectations(t)
 
-	service := NewCategoryService(categoryRepositoryMock)
+	service := NewCategoryService(categoryRepositoryMock, newTxManagerMock(t), newEventBusMock(t, domain.CategoryCreatedEventType))
 
-	err := service.CreateCategory(context.Background(), dto)
+	_, err := service.CreateCategory(context.Background(), dto)
 	assert.NoError(t, err)
 }
 
 func TestTrackServiceCreateTrackRepositoryError(t *testing.T) {
 	dto := dto.TrackCreateRequest{
-		Name:    "Test Track",
-		MovieID: "456e7890-e89b-12d3-a456-426614174111",
+		Name:            "Test Track",
+		MovieID:         "456e7890-e89b-12d3-a456-426614174111",
+		TrackNumber:     1,
+		DiscNumber:      1,
+		Edition:         domain.TrackEditionTheatrical,
+		DurationSeconds: 180,
 	}
 
 	trackRepositoryMock := new(storagemocks.TrackRepository)
 	trackRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("domain.Track")).Return(errors.New(repositoryErrorMsg)).Once()
 	defer trackRepositoryMock.AssertExpectations(t)
 
-	service := NewTrackService(trackRepositoryMock)
+	service := NewTrackService(trackRepositoryMock, newTxManagerMock(t), new(eventmocks.Bus))
 
-	err := service.CreateTrack(context.Background(), dto)
+	_, err := service.CreateTrack(context.Background(), dto)
 	assert.Error(t, err)
 }
 
 func TestTrackServiceCreateTrackSuccess(t *testing.T) {
 	dto := dto.TrackCreateRequest{
-		Name:    "Test Track",
-		MovieID: "456e7890-e89b-12d3-a456-426614174111",
+		Name:            "Test Track",
+		MovieID:         "456e7890-e89b-12d3-a456-426614174111",
+		TrackNumber:     1,
+		DiscNumber:      1,
+		Edition:         domain.TrackEditionTheatrical,
+		DurationSeconds: 180,
 	}
 
 	trackRepositoryMock := new(storagemocks.TrackRepository)
 	trackRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("domain.Track")).Return(nil).Once()
 	defer trackRepositoryMock.AssertExpectations(t)
 
-	service := NewTrackService(trackRepositoryMock)
+	service := NewTrackService(trackRepositoryMock, newTxManagerMock(t), newEventBusMock(t, domain.TrackCreatedEventType))
 
-	err := service.CreateTrack(context.Background(), dto)
+	_, err := service.CreateTrack(context.Background(), dto)
 	assert.NoError(t, err)
 }
 
@@ -224,9 +266,9 @@ func TestThemeServiceCreateThemeRepositoryError(t *testing.T) {
 	themeRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("domain.Theme")).Return(errors.New(repositoryErrorMsg)).Once()
 	defer themeRepositoryMock.AssertExpectations(t)
 
-	service := NewThemeService(themeRepositoryMock)
+	service := NewThemeService(themeRepositoryMock, new(storagemocks.TrackThemeRepository), new(storagemocks.TrackRepository), newTxManagerMock(t), new(eventmocks.Bus))
 
-	err := service.CreateTheme(context.Background(), dto)
+	_, err := service.CreateTheme(context.Background(), dto)
 	assert.Error(t, err)
 }
 
@@ -243,12 +285,81 @@ func TestThemeServiceCreateThemeSuccess(t *testing.T) {
 	themeRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("domain.Theme")).Return(nil).Once()
 	defer themeRepositoryMock.AssertExpectations(t)
 
-	service := NewThemeService(themeRepositoryMock)
+	service := NewThemeService(themeRepositoryMock, new(storagemocks.TrackThemeRepository), new(storagemocks.TrackRepository), newTxManagerMock(t), newEventBusMock(t, domain.ThemeCreatedEventType))
 
-	err := service.CreateTheme(context.Background(), dto)
+	_, err := service.CreateTheme(context.Background(), dto)
 	assert.NoError(t, err)
 }
 
+func TestThemeServiceCreateThemeWithFirstHeardOccurrence(t *testing.T) {
+	dto := dto.ThemeCreateRequest{
+		Name:            "Test Theme",
+		FirstHeard:      "456e7890-e89b-12d3-a456-426614174130",
+		GroupID:         "456e7890-e89b-12d3-a456-426614174131",
+		Description:     "Theme Description",
+		FirstHeardStart: 30,
+		FirstHeardEnd:   90,
+	}
+
+	themeRepositoryMock := new(storagemocks.ThemeRepository)
+	themeRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("domain.Theme")).Return(nil).Once()
+	defer themeRepositoryMock.AssertExpectations(t)
+
+	trackRepositoryMock := new(storagemocks.TrackRepository)
+	trackRepositoryMock.On("Find", mock.Anything, mock.AnythingOfType("domain.TrackID")).Return(newTestTrack(t, dto.FirstHeard), nil).Once()
+	defer trackRepositoryMock.AssertExpectations(t)
+
+	trackThemeRepositoryMock := new(storagemocks.TrackThemeRepository)
+	trackThemeRepositoryMock.On("Save", mock.Anything, mock.MatchedBy(func(trackTheme domain.TrackTheme) bool {
+		return trackTheme.TrackID().String() == dto.FirstHeard &&
+			trackTheme.StartSecond().Int() == dto.FirstHeardStart &&
+			trackTheme.EndSecond().Int() == dto.FirstHeardEnd
+	})).Return(nil).Once()
+	defer trackThemeRepositoryMock.AssertExpectations(t)
+
+	service := NewThemeService(themeRepositoryMock, trackThemeRepositoryMock, trackRepositoryMock, newTxManagerMock(t), newEventBusMock(t, domain.ThemeCreatedEventType, domain.TrackThemeAddedEventType))
+
+	_, err := service.CreateTheme(context.Background(), dto)
+	assert.NoError(t, err)
+}
+
+func TestThemeServiceCreateThemeFirstHeardOccurrenceError(t *testing.T) {
+	dto := dto.ThemeCreateRequest{
+		Name:            "Test Theme",
+		FirstHeard:      "456e7890-e89b-12d3-a456-426614174132",
+		GroupID:         "456e7890-e89b-12d3-a456-426614174133",
+		Description:     "Theme Description",
+		FirstHeardStart: 30,
+		FirstHeardEnd:   90,
+	}
+
+	themeRepositoryMock := new(storagemocks.ThemeRepository)
+	themeRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("domain.Theme")).Return(nil).Once()
+	defer themeRepositoryMock.AssertExpectations(t)
+
+	trackRepositoryMock := new(storagemocks.TrackRepository)
+	trackRepositoryMock.On("Find", mock.Anything, mock.AnythingOfType("domain.TrackID")).Return(newTestTrack(t, dto.FirstHeard), nil).Once()
+	defer trackRepositoryMock.AssertExpectations(t)
+
+	trackThemeRepositoryMock := new(storagemocks.TrackThemeRepository)
+	trackThemeRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("domain.TrackTheme")).Return(errors.New(repositoryErrorMsg)).Once()
+	defer trackThemeRepositoryMock.AssertExpectations(t)
+
+	// The error must reach the transaction manager so it rolls the theme back
+	txManagerMock := new(txmocks.Manager)
+	txManagerMock.On("WithinTx", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		err := fn(ctx)
+		assert.EqualError(t, err, repositoryErrorMsg)
+		return err
+	}).Once()
+	defer txManagerMock.AssertExpectations(t)
+
+	service := NewThemeService(themeRepositoryMock, trackThemeRepositoryMock, trackRepositoryMock, txManagerMock, new(eventmocks.Bus))
+
+	_, err := service.CreateTheme(context.Background(), dto)
+	assert.Error(t, err)
+}
+
 func TestTrackThemeServiceCreateTrackThemeRepositoryError(t *testing.T) {
 	dto := dto.TrackThemeCreateRequest{
 		TrackID:     "456e7890-e89b-12d3-a456-426614174117",
@@ -258,11 +369,16 @@ func TestTrackThemeServiceCreateTrackThemeRepositoryError(t *testing.T) {
 		IsVariant:   false,
 	}
 
+	trackRepositoryMock := new(storagemocks.TrackRepository)
+	trackRepositoryMock.On("FindForUpdate", mock.Anything, mock.AnythingOfType("domain.TrackID")).Return(newTestTrack(t, dto.TrackID), nil).Once()
+	defer trackRepositoryMock.AssertExpectations(t)
+
 	trackThemeRepositoryMock := new(storagemocks.TrackThemeRepository)
+	trackThemeRepositoryMock.On("FindByTrack", mock.Anything, mock.AnythingOfType("domain.TrackID")).Return([]domain.TrackTheme{}, nil).Once()
 	trackThemeRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("domain.TrackTheme")).Return(errors.New(repositoryErrorMsg)).Once()
 	defer trackThemeRepositoryMock.AssertExpectations(t)
 
-	service := NewTrackThemeService(trackThemeRepositoryMock)
+	service := NewTrackThemeService(trackThemeRepositoryMock, trackRepositoryMock, newTxManagerMock(t), new(eventmocks.Bus))
 
 	err := service.CreateTrackTheme(context.Background(), dto)
 	assert.Error(t, err)
@@ -277,12 +393,279 @@ func TestTrackThemeServiceCreateTrackThemeSuccess(t *testing.T) {
 		IsVariant:   false,
 	}
 
+	trackRepositoryMock := new(storagemocks.TrackRepository)
+	trackRepositoryMock.On("FindForUpdate", mock.Anything, mock.AnythingOfType("domain.TrackID")).Return(newTestTrack(t, dto.TrackID), nil).Once()
+	defer trackRepositoryMock.AssertExpectations(t)
+
+	trackThemeRepositoryMock := new(storagemocks.TrackThemeRepository)
+	trackThemeRepositoryMock.On("FindByTrack", mock.Anything, mock.AnythingOfType("domain.TrackID")).Return([]domain.TrackTheme{}, nil).Once()
+	trackThemeRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("domain.TrackTheme")).Return(nil).Once()
+	defer trackThemeRepositoryMock.AssertExpectations(t)
+
+	service := NewTrackThemeService(trackThemeRepositoryMock, trackRepositoryMock, newTxManagerMock(t), newEventBusMock(t, domain.TrackThemeAddedEventType))
+
+	err := service.CreateTrackTheme(context.Background(), dto)
+	assert.NoError(t, err)
+}
+
+func TestTrackThemeServiceCreateTrackThemeTrackNotFound(t *testing.T) {
+	dto := dto.TrackThemeCreateRequest{
+		TrackID:     "456e7890-e89b-12d3-a456-426614174121",
+		ThemeID:     "456e7890-e89b-12d3-a456-426614174122",
+		StartSecond: 30,
+		EndSecond:   90,
+		IsVariant:   false,
+	}
+
+	trackRepositoryMock := new(storagemocks.TrackRepository)
+	trackRepositoryMock.On("FindForUpdate", mock.Anything, mock.AnythingOfType("domain.TrackID")).Return(domain.Track{}, domain.ErrTrackNotFound).Once()
+	defer trackRepositoryMock.AssertExpectations(t)
+
+	trackThemeRepositoryMock := new(storagemocks.TrackThemeRepository)
+	defer trackThemeRepositoryMock.AssertExpectations(t)
+
+	service := NewTrackThemeService(trackThemeRepositoryMock, trackRepositoryMock, newTxManagerMock(t), new(eventmocks.Bus))
+
+	err := service.CreateTrackTheme(context.Background(), dto)
+	assert.ErrorIs(t, err, domain.ErrTrackNotFound)
+}
+
+func TestTrackThemeServiceCreateTrackThemeExceedsTrackDuration(t *testing.T) {
+	dto := dto.TrackThemeCreateRequest{
+		TrackID:     "456e7890-e89b-12d3-a456-426614174123",
+		ThemeID:     "456e7890-e89b-12d3-a456-426614174124",
+		StartSecond: 150,
+		EndSecond:   200,
+		IsVariant:   false,
+	}
+
+	trackRepositoryMock := new(storagemocks.TrackRepository)
+	trackRepositoryMock.On("FindForUpdate", mock.Anything, mock.AnythingOfType("domain.TrackID")).Return(newTestTrack(t, dto.TrackID), nil).Once()
+	defer trackRepositoryMock.AssertExpectations(t)
+
 	trackThemeRepositoryMock := new(storagemocks.TrackThemeRepository)
+	defer trackThemeRepositoryMock.AssertExpectations(t)
+
+	service := NewTrackThemeService(trackThemeRepositoryMock, trackRepositoryMock, newTxManagerMock(t), new(eventmocks.Bus))
+
+	err := service.CreateTrackTheme(context.Background(), dto)
+	assert.ErrorIs(t, err, domain.ErrEndSecondExceedsTrackDuration)
+}
+
+func TestTrackThemeServiceCreateTrackThemeOverlaps(t *testing.T) {
+	trackID := "456e7890-e89b-12d3-a456-426614174125"
+	themeID := "456e7890-e89b-12d3-a456-426614174126"
+
+	tests := map[string]struct {
+		existing    []int // start and end second of the occurrence already saved
+		startSecond int
+		endSecond   int
+	}{
+		"duplicate":          {existing: []int{30, 90}, startSecond: 30, endSecond: 90},
+		"shifted duplicate":  {existing: []int{30, 90}, startSecond: 32, endSecond: 91},
+		"starts inside":      {existing: []int{30, 90}, startSecond: 60, endSecond: 120},
+		"contains existing":  {existing: []int{30, 90}, startSecond: 10, endSecond: 100},
+		"contained entirely": {existing: []int{30, 90}, startSecond: 40, endSecond: 50},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dto := dto.TrackThemeCreateRequest{
+				TrackID:     trackID,
+				ThemeID:     themeID,
+				StartSecond: tt.startSecond,
+				EndSecond:   tt.endSecond,
+			}
+
+			existing, err := domain.NewTrackTheme(trackID, themeID, tt.existing[0], tt.existing[1], false)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			trackRepositoryMock := new(storagemocks.TrackRepository)
+			trackRepositoryMock.On("FindForUpdate", mock.Anything, mock.AnythingOfType("domain.TrackID")).Return(newTestTrack(t, trackID), nil).Once()
+			defer trackRepositoryMock.AssertExpectations(t)
+
+			trackThemeRepositoryMock := new(storagemocks.TrackThemeRepository)
+			trackThemeRepositoryMock.On("FindByTrack", mock.Anything, existing.TrackID()).Return([]domain.TrackTheme{existing}, nil).Once()
+			defer trackThemeRepositoryMock.AssertExpectations(t)
+
+			service := NewTrackThemeService(trackThemeRepositoryMock, trackRepositoryMock, newTxManagerMock(t), new(eventmocks.Bus))
+
+			err = service.CreateTrackTheme(context.Background(), dto)
+			assert.ErrorIs(t, err, domain.ErrTrackThemeOverlaps)
+		})
+	}
+}
+
+func TestTrackThemeServiceCreateTrackThemeNoOverlap(t *testing.T) {
+	trackID := "456e7890-e89b-12d3-a456-426614174127"
+	themeID := "456e7890-e89b-12d3-a456-426614174128"
+	otherThemeID := "456e7890-e89b-12d3-a456-426614174129"
+
+	dto := dto.TrackThemeCreateRequest{
+		TrackID:     trackID,
+		ThemeID:     themeID,
+		StartSecond: 90,
+		EndSecond:   120,
+	}
+
+	// An occurrence of the same theme that ends where the new one starts, and another
+	// theme heard at the same time, do not overlap
+	sameThemeBefore, err := domain.NewTrackTheme(trackID, themeID, 30, 90, false)
+	assert.NoError(t, err)
+	otherTheme, err := domain.NewTrackTheme(trackID, otherThemeID, 90, 120, false)
+	assert.NoError(t, err)
+
+	trackRepositoryMock := new(storagemocks.TrackRepository)
+	trackRepositoryMock.On("FindForUpdate", mock.Anything, mock.AnythingOfType("domain.TrackID")).Return(newTestTrack(t, trackID), nil).Once()
+	defer trackRepositoryMock.AssertExpectations(t)
+
+	trackThemeRepositoryMock := new(storagemocks.TrackThemeRepository)
+	trackThemeRepositoryMock.On("FindByTrack", mock.Anything, sameThemeBefore.TrackID()).Return([]domain.TrackTheme{sameThemeBefore, otherTheme}, nil).Once()
 	trackThemeRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("domain.TrackTheme")).Return(nil).Once()
 	defer trackThemeRepositoryMock.AssertExpectations(t)
 
-	service := NewTrackThemeService(trackThemeRepositoryMock)
+	service := NewTrackThemeService(trackThemeRepositoryMock, trackRepositoryMock, newTxManagerMock(t), newEventBusMock(t, domain.TrackThemeAddedEventType))
+
+	err = service.CreateTrackTheme(context.Background(), dto)
+	assert.NoError(t, err)
+}
+
+func TestTrackThemeServiceCreateTrackThemeChecksWithinTx(t *testing.T) {
+	dto := dto.TrackThemeCreateRequest{
+		TrackID:     "456e7890-e89b-12d3-a456-426614174130",
+		ThemeID:     "456e7890-e89b-12d3-a456-426614174131",
+		StartSecond: 30,
+		EndSecond:   90,
+		IsVariant:   false,
+	}
+
+	// The unit of work marks its context, so that the calls made within it can be told
+	// apart
+	type txKey struct{}
+	txManagerMock := new(txmocks.Manager)
+	txManagerMock.On("WithinTx", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(context.WithValue(ctx, txKey{}, true))
+	}).Once()
+	defer txManagerMock.AssertExpectations(t)
+	inTx := mock.MatchedBy(func(ctx context.Context) bool { return ctx.Value(txKey{}) != nil })
+
+	var calls []string
+	trackRepositoryMock := new(storagemocks.TrackRepository)
+	trackRepositoryMock.On("FindForUpdate", inTx, mock.AnythingOfType("domain.TrackID")).Return(newTestTrack(t, dto.TrackID), nil).
+		Run(func(mock.Arguments) { calls = append(calls, "FindForUpdate") }).Once()
+	defer trackRepositoryMock.AssertExpectations(t)
+
+	trackThemeRepositoryMock := new(storagemocks.TrackThemeRepository)
+	trackThemeRepositoryMock.On("FindByTrack", inTx, mock.AnythingOfType("domain.TrackID")).Return([]domain.TrackTheme{}, nil).
+		Run(func(mock.Arguments) { calls = append(calls, "FindByTrack") }).Once()
+	trackThemeRepositoryMock.On("Save", inTx, mock.AnythingOfType("domain.TrackTheme")).Return(nil).Once()
+	defer trackThemeRepositoryMock.AssertExpectations(t)
+
+	service := NewTrackThemeService(trackThemeRepositoryMock, trackRepositoryMock, txManagerMock, newEventBusMock(t, domain.TrackThemeAddedEventType))
 
 	err := service.CreateTrackTheme(context.Background(), dto)
 	assert.NoError(t, err)
+	// The track is locked before its occurrences are read
+	assert.Equal(t, []string{"FindForUpdate", "FindByTrack"}, calls)
+}
+
+// newTxManagerMock returns a transaction manager that runs the unit of work it is given.
+func newTxManagerMock(t *testing.T) *txmocks.Manager {
+	txManagerMock := new(txmocks.Manager)
+	txManagerMock.On("WithinTx", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	t.Cleanup(func() { txManagerMock.AssertExpectations(t) })
+	return txManagerMock
+}
+
+// newTestTrack returns a three-minute track with the given ID.
+func newTestTrack(t *testing.T, id string) domain.Track {
+	track, err := domain.NewTrackWithID(id, "Test Track", "456e7890-e89b-12d3-a456-426614174111", nil, 1, 1, domain.TrackEditionTheatrical, 180)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return track
+}
+
+// newEventBusMock returns an event bus that expects the events of the given types to be
+// published once, in that order.
+func newEventBusMock(t *testing.T, types ...event.Type) *eventmocks.Bus {
+	eventBusMock := new(eventmocks.Bus)
+	eventBusMock.On("Publish", mock.Anything, mock.MatchedBy(func(events []event.Event) bool {
+		if len(events) != len(types) {
+			return false
+		}
+		for i, e := range events {
+			if e.Type() != types[i] {
+				return false
+			}
+		}
+		return true
+	})).Return(nil).Once()
+	t.Cleanup(func() { eventBusMock.AssertExpectations(t) })
+	return eventBusMock
+}
+
+func TestWebhookServiceCreateWebhookInvalid(t *testing.T) {
+	dto := dto.WebhookCreateRequest{
+		URL:        "ftp://hooks.example.com",
+		Secret:     "a-very-secret-signing-key",
+		EventTypes: []string{string(domain.MovieCreatedEventType)},
+	}
+
+	webhookRepositoryMock := new(storagemocks.WebhookRepository)
+
+	service := NewWebhookService(webhookRepositoryMock)
+
+	_, err := service.CreateWebhook(context.Background(), dto)
+	assert.ErrorIs(t, err, domain.ErrInvalidWebhookURL)
+	webhookRepositoryMock.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestWebhookServiceCreateWebhookInternalHost(t *testing.T) {
+	webhookRepositoryMock := new(storagemocks.WebhookRepository)
+
+	service := NewWebhookService(webhookRepositoryMock)
+
+	for _, url := range []string{
+		"http://localhost:8080/hooks",
+		"http://127.0.0.1/hooks",
+		"http://10.0.0.12/hooks",
+		"http://169.254.169.254/latest/meta-data",
+		"http://[::1]/hooks",
+		"http://[::ffff:192.168.1.1]/hooks",
+		"http://0.0.0.0/hooks",
+	} {
+		dto := dto.WebhookCreateRequest{
+			URL:        url,
+			Secret:     "a-very-secret-signing-key",
+			EventTypes: []string{string(domain.MovieCreatedEventType)},
+		}
+
+		_, err := service.CreateWebhook(context.Background(), dto)
+		assert.ErrorIs(t, err, domain.ErrInvalidWebhookURL, url)
+	}
+	webhookRepositoryMock.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestWebhookServiceCreateWebhookSuccess(t *testing.T) {
+	dto := dto.WebhookCreateRequest{
+		URL:        "https://hooks.example.com/middle-earth",
+		Secret:     "a-very-secret-signing-key",
+		EventTypes: []string{string(domain.MovieCreatedEventType), string(domain.MovieCreatedEventType)},
+	}
+
+	webhookRepositoryMock := new(storagemocks.WebhookRepository)
+	webhookRepositoryMock.On("Save", mock.Anything, mock.MatchedBy(func(webhook domain.Webhook) bool {
+		return webhook.URL().String() == dto.URL && len(webhook.EventTypes()) == 1
+	})).Return(nil).Once()
+	defer webhookRepositoryMock.AssertExpectations(t)
+
+	service := NewWebhookService(webhookRepositoryMock)
+
+	_, err := service.CreateWebhook(context.Background(), dto)
+	assert.NoError(t, err)
 }
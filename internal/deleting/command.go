@@ -4,33 +4,83 @@ import (
 	"context"
 
 	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/auditing"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/command"
 )
 
 const (
+	UserCommandType       = "command.delete.user"
 	MovieCommandType      = "command.delete.movie"
 	GroupCommandType      = "command.delete.group"
 	CategoryCommandType   = "command.delete.category"
 	TrackCommandType      = "command.delete.track"
 	ThemeCommandType      = "command.delete.theme"
 	TrackThemeCommandType = "command.delete.track_theme"
+	WebhookCommandType    = "command.delete.webhook"
 )
 
-type MovieCommand struct {
+type UserCommand struct {
 	ID string
 }
 
-func NewMovieCommand(id string) MovieCommand {
-	return MovieCommand{
+func NewUserCommand(id string) UserCommand {
+	return UserCommand{
 		ID: id,
 	}
 }
 
+func (c UserCommand) Type() command.Type {
+	return UserCommandType
+}
+
+func (c UserCommand) AuditTarget() auditing.Target {
+	return auditing.Target{Entity: domain.AuditEntityUser, ID: c.ID}
+}
+
+type UserCommandHandler struct {
+	service UserService
+}
+
+func NewUserCommandHandler(service UserService) UserCommandHandler {
+	return UserCommandHandler{
+		service: service,
+	}
+}
+
+func (h UserCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	userCmd, ok := cmd.(UserCommand)
+	if !ok {
+		return nil
+	}
+
+	userID, err := domain.NewUserIDFromString(userCmd.ID)
+	if err != nil {
+		return err
+	}
+	return h.service.DeleteUser(ctx, userID)
+}
+
+type MovieCommand struct {
+	ID      string
+	Cascade bool
+}
+
+func NewMovieCommand(id string, cascade bool) MovieCommand {
+	return MovieCommand{
+		ID:      id,
+		Cascade: cascade,
+	}
+}
+
 func (c MovieCommand) Type() command.Type {
 	return MovieCommandType
 }
 
+func (c MovieCommand) AuditTarget() auditing.Target {
+	return auditing.Target{Entity: domain.AuditEntityMovie, ID: c.ID}
+}
+
 type MovieCommandHandler struct {
 	service MovieService
 }
@@ -51,16 +101,18 @@ func (h MovieCommandHandler) Handle(ctx context.Context, cmd command.Command) er
 	if err != nil {
 		return err
 	}
-	return h.service.DeleteMovie(ctx, movieID)
+	return h.service.DeleteMovie(ctx, movieID, movieCmd.Cascade)
 }
 
 type GroupCommand struct {
-	ID string
+	ID      string
+	Cascade bool
 }
 
-func NewGroupCommand(id string) GroupCommand {
+func NewGroupCommand(id string, cascade bool) GroupCommand {
 	return GroupCommand{
-		ID: id,
+		ID:      id,
+		Cascade: cascade,
 	}
 }
 
@@ -68,6 +120,10 @@ func (c GroupCommand) Type() command.Type {
 	return GroupCommandType
 }
 
+func (c GroupCommand) AuditTarget() auditing.Target {
+	return auditing.Target{Entity: domain.AuditEntityGroup, ID: c.ID}
+}
+
 type GroupCommandHandler struct {
 	service GroupService
 }
@@ -88,16 +144,18 @@ func (h GroupCommandHandler) Handle(ctx context.Context, cmd command.Command) er
 	if err != nil {
 		return err
 	}
-	return h.service.DeleteGroup(ctx, groupID)
+	return h.service.DeleteGroup(ctx, groupID, groupCmd.Cascade)
 }
 
 type CategoryCommand struct {
-	ID string
+	ID      string
+	Cascade bool
 }
 
-func NewCategoryCommand(id string) CategoryCommand {
+func NewCategoryCommand(id string, cascade bool) CategoryCommand {
 	return CategoryCommand{
-		ID: id,
+		ID:      id,
+		Cascade: cascade,
 	}
 }
 
@@ -105,6 +163,10 @@ func (c CategoryCommand) Type() command.Type {
 	return CategoryCommandType
 }
 
+func (c CategoryCommand) AuditTarget() auditing.Target {
+	return auditing.Target{Entity: domain.AuditEntityCategory, ID: c.ID}
+}
+
 type CategoryCommandHandler struct {
 	service CategoryService
 }
@@ -125,16 +187,18 @@ func (h CategoryCommandHandler) Handle(ctx context.Context, cmd command.Command)
 	if err != nil {
 		return err
 	}
-	return h.service.DeleteCategory(ctx, categoryID)
+	return h.service.DeleteCategory(ctx, categoryID, categoryCmd.Cascade)
 }
 
 type TrackCommand struct {
-	ID string
+	ID      string
+	Cascade bool
 }
 
-func NewTrackCommand(id string) TrackCommand {
+func NewTrackCommand(id string, cascade bool) TrackCommand {
 	return TrackCommand{
-		ID: id,
+		ID:      id,
+		Cascade: cascade,
 	}
 }
 
@@ -142,6 +206,10 @@ func (c TrackCommand) Type() command.Type {
 	return TrackCommandType
 }
 
+func (c TrackCommand) AuditTarget() auditing.Target {
+	return auditing.Target{Entity: domain.AuditEntityTrack, ID: c.ID}
+}
+
 type TrackCommandHandler struct {
 	service TrackService
 }
@@ -162,7 +230,7 @@ func (h TrackCommandHandler) Handle(ctx context.Context, cmd command.Command) er
 	if err != nil {
 		return err
 	}
-	return h.service.DeleteTrack(ctx, trackID)
+	return h.service.DeleteTrack(ctx, trackID, trackCmd.Cascade)
 }
 
 type ThemeCommand struct {
@@ -179,6 +247,10 @@ func (c ThemeCommand) Type() command.Type {
 	return ThemeCommandType
 }
 
+func (c ThemeCommand) AuditTarget() auditing.Target {
+	return auditing.Target{Entity: domain.AuditEntityTheme, ID: c.ID}
+}
+
 type ThemeCommandHandler struct {
 	service ThemeService
 }
@@ -216,6 +288,10 @@ func (c TrackThemeCommand) Type() command.Type {
 	return TrackThemeCommandType
 }
 
+func (c TrackThemeCommand) AuditTarget() auditing.Target {
+	return auditing.Target{Entity: domain.AuditEntityTrackTheme, Request: c.dto, Removed: true}
+}
+
 type TrackThemeCommandHandler struct {
 	service TrackThemeService
 }
@@ -249,3 +325,44 @@ func (h TrackThemeCommandHandler) Handle(ctx context.Context, cmd command.Comman
 
 	return h.service.DeleteTrackTheme(ctx, trackID, themeID, startSecond)
 }
+
+type WebhookCommand struct {
+	ID string
+}
+
+func NewWebhookCommand(id string) WebhookCommand {
+	return WebhookCommand{
+		ID: id,
+	}
+}
+
+func (c WebhookCommand) Type() command.Type {
+	return WebhookCommandType
+}
+
+func (c WebhookCommand) AuditTarget() auditing.Target {
+	return auditing.Target{Entity: domain.AuditEntityWebhook, ID: c.ID}
+}
+
+type WebhookCommandHandler struct {
+	service WebhookService
+}
+
+func NewWebhookCommandHandler(service WebhookService) WebhookCommandHandler {
+	return WebhookCommandHandler{
+		service: service,
+	}
+}
+
+func (h WebhookCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	webhookCmd, ok := cmd.(WebhookCommand)
+	if !ok {
+		return nil
+	}
+
+	webhookID, err := domain.NewWebhookIDFromString(webhookCmd.ID)
+	if err != nil {
+		return err
+	}
+	return h.service.DeleteWebhook(ctx, webhookID)
+}
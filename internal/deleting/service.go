@@ -4,88 +4,364 @@ import (
 	"context"
 
 	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/tx"
 )
 
+type UserService struct {
+	userRepository domain.UserRepository
+	txManager      tx.Manager
+	eventBus       event.Bus
+}
+
+func NewUserService(userRepository domain.UserRepository, txManager tx.Manager, eventBus event.Bus) UserService {
+	return UserService{
+		userRepository: userRepository,
+		txManager:      txManager,
+		eventBus:       eventBus,
+	}
+}
+
+// DeleteUser removes a user for good. Their sessions go with them, so they can no longer
+// refresh their tokens. Deleting the last user allowed to manage users is refused with
+// domain.ErrLastAdmin.
+func (s *UserService) DeleteUser(ctx context.Context, id domain.UserID) error {
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		// Counting first locks the users allowed to manage users, so that concurrent
+		// deletions cannot remove all of them
+		admins, err := s.userRepository.CountByPermission(ctx, domain.PermissionManageUsers)
+		if err != nil {
+			return err
+		}
+
+		user, err := s.userRepository.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.userRepository.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		left, err := s.userRepository.CountByPermission(ctx, domain.PermissionManageUsers)
+		if err != nil {
+			return err
+		}
+		if admins > 0 && left == 0 {
+			return domain.ErrLastAdmin
+		}
+
+		user.Record(domain.NewUserDeletedEvent(user.ID().String(), user.Name().String(), user.Email().String()))
+		return s.eventBus.Publish(ctx, user.PullEvents())
+	})
+}
+
 type MovieService struct {
 	movieRepository domain.MovieRepository
+	trackRepository domain.TrackRepository
+	themeRepository domain.ThemeRepository
+	txManager       tx.Manager
+	eventBus        event.Bus
 }
 
-func NewMovieService(repo domain.MovieRepository) MovieService {
+func NewMovieService(movieRepository domain.MovieRepository, trackRepository domain.TrackRepository, themeRepository domain.ThemeRepository, txManager tx.Manager, eventBus event.Bus) MovieService {
 	return MovieService{
-		movieRepository: repo,
+		movieRepository: movieRepository,
+		trackRepository: trackRepository,
+		themeRepository: themeRepository,
+		txManager:       txManager,
+		eventBus:        eventBus,
 	}
 }
 
-func (s *MovieService) DeleteMovie(ctx context.Context, id domain.MovieID) error {
-	return s.movieRepository.Delete(ctx, id)
+// DeleteMovie deletes the movie. A movie that still has tracks, or themes first heard in
+// them, is refused with a domain.InUseError unless cascade is set, in which case those
+// themes and tracks are deleted along with it.
+func (s *MovieService) DeleteMovie(ctx context.Context, id domain.MovieID, cascade bool) error {
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var events []event.Event
+		movie, err := s.movieRepository.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+		tracks, err := s.trackRepository.FindByMovie(ctx, id)
+		if err != nil {
+			return err
+		}
+		themes, err := s.themeRepository.FindByMovie(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		dependents := domain.Dependents{Tracks: trackIDs(tracks), Themes: themeIDs(themes)}
+		if !dependents.IsEmpty() && !cascade {
+			return domain.InUseError{Dependents: dependents}
+		}
+
+		themeEvents, err := deleteThemes(ctx, s.themeRepository, themes)
+		if err != nil {
+			return err
+		}
+		events = append(events, themeEvents...)
+		for _, track := range tracks {
+			if err := s.trackRepository.Delete(ctx, track.ID()); err != nil {
+				return err
+			}
+			events = append(events, trackDeletedEvent(track))
+		}
+		if err := s.movieRepository.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		movie.Record(domain.NewMovieDeletedEvent(movie.ID().String(), movie.Name().String()))
+		events = append(events, movie.PullEvents()...)
+		return s.eventBus.Publish(ctx, events)
+	})
 }
 
 type GroupService struct {
 	groupRepository domain.GroupRepository
+	themeRepository domain.ThemeRepository
+	txManager       tx.Manager
+	eventBus        event.Bus
 }
 
-func NewGroupService(repo domain.GroupRepository) GroupService {
+func NewGroupService(groupRepository domain.GroupRepository, themeRepository domain.ThemeRepository, txManager tx.Manager, eventBus event.Bus) GroupService {
 	return GroupService{
-		groupRepository: repo,
+		groupRepository: groupRepository,
+		themeRepository: themeRepository,
+		txManager:       txManager,
+		eventBus:        eventBus,
 	}
 }
 
-func (s *GroupService) DeleteGroup(ctx context.Context, id domain.GroupID) error {
-	return s.groupRepository.Delete(ctx, id)
+// DeleteGroup deletes the group. A group that still has themes is refused with a
+// domain.InUseError unless cascade is set, in which case its themes are deleted too.
+func (s *GroupService) DeleteGroup(ctx context.Context, id domain.GroupID, cascade bool) error {
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		group, err := s.groupRepository.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+		themes, err := s.themeRepository.FindByGroup(ctx, id)
+		if err != nil {
+			return err
+		}
+		events, err := deleteDependentThemes(ctx, s.themeRepository, themes, cascade)
+		if err != nil {
+			return err
+		}
+		if err := s.groupRepository.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		group.Record(domain.NewGroupDeletedEvent(group.ID().String(), group.Name().String()))
+		events = append(events, group.PullEvents()...)
+		return s.eventBus.Publish(ctx, events)
+	})
 }
 
 type CategoryService struct {
 	categoryRepository domain.CategoryRepository
+	themeRepository    domain.ThemeRepository
+	txManager          tx.Manager
+	eventBus           event.Bus
 }
 
-func NewCategoryService(repo domain.CategoryRepository) CategoryService {
+func NewCategoryService(categoryRepository domain.CategoryRepository, themeRepository domain.ThemeRepository, txManager tx.Manager, eventBus event.Bus) CategoryService {
 	return CategoryService{
-		categoryRepository: repo,
+		categoryRepository: categoryRepository,
+		themeRepository:    themeRepository,
+		txManager:          txManager,
+		eventBus:           eventBus,
 	}
 }
 
-func (s *CategoryService) DeleteCategory(ctx context.Context, id domain.CategoryID) error {
-	return s.categoryRepository.Delete(ctx, id)
+// DeleteCategory deletes the category. A category that still has themes is refused with a
+// domain.InUseError unless cascade is set, in which case its themes are deleted too.
+func (s *CategoryService) DeleteCategory(ctx context.Context, id domain.CategoryID, cascade bool) error {
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		category, err := s.categoryRepository.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+		themes, err := s.themeRepository.FindByCategory(ctx, id)
+		if err != nil {
+			return err
+		}
+		events, err := deleteDependentThemes(ctx, s.themeRepository, themes, cascade)
+		if err != nil {
+			return err
+		}
+		if err := s.categoryRepository.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		category.Record(domain.NewCategoryDeletedEvent(category.ID().String(), category.Name().String()))
+		events = append(events, category.PullEvents()...)
+		return s.eventBus.Publish(ctx, events)
+	})
 }
 
 type TrackService struct {
 	trackRepository domain.TrackRepository
+	themeRepository domain.ThemeRepository
+	txManager       tx.Manager
+	eventBus        event.Bus
 }
 
-func NewTrackService(repo domain.TrackRepository) TrackService {
+func NewTrackService(trackRepository domain.TrackRepository, themeRepository domain.ThemeRepository, txManager tx.Manager, eventBus event.Bus) TrackService {
 	return TrackService{
-		trackRepository: repo,
+		trackRepository: trackRepository,
+		themeRepository: themeRepository,
+		txManager:       txManager,
+		eventBus:        eventBus,
 	}
 }
 
-func (s *TrackService) DeleteTrack(ctx context.Context, id domain.TrackID) error {
-	return s.trackRepository.Delete(ctx, id)
+// DeleteTrack deletes the track. The theme occurrences in it are kept, left out of the
+// listings, until the track is purged from the trash. A track some theme is first heard in
+// is refused with a domain.InUseError unless cascade is set, in which case those themes
+// are deleted too.
+func (s *TrackService) DeleteTrack(ctx context.Context, id domain.TrackID, cascade bool) error {
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		track, err := s.trackRepository.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+		themes, err := s.themeRepository.FindByFirstHeard(ctx, id)
+		if err != nil {
+			return err
+		}
+		events, err := deleteDependentThemes(ctx, s.themeRepository, themes, cascade)
+		if err != nil {
+			return err
+		}
+		if err := s.trackRepository.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		track.Record(trackDeletedEvent(track))
+		events = append(events, track.PullEvents()...)
+		return s.eventBus.Publish(ctx, events)
+	})
+}
+
+// deleteDependentThemes deletes the themes that reference an entity about to be deleted,
+// or reports them in a domain.InUseError when the delete does not cascade. It returns the
+// events of the deleted themes.
+func deleteDependentThemes(ctx context.Context, themeRepository domain.ThemeRepository, themes []domain.Theme, cascade bool) ([]event.Event, error) {
+	dependents := domain.Dependents{Themes: themeIDs(themes)}
+	if !dependents.IsEmpty() && !cascade {
+		return nil, domain.InUseError{Dependents: dependents}
+	}
+	return deleteThemes(ctx, themeRepository, themes)
+}
+
+func deleteThemes(ctx context.Context, themeRepository domain.ThemeRepository, themes []domain.Theme) ([]event.Event, error) {
+	var events []event.Event
+	for _, theme := range themes {
+		if err := themeRepository.Delete(ctx, theme.ID()); err != nil {
+			return nil, err
+		}
+		events = append(events, themeDeletedEvent(theme))
+	}
+	return events, nil
+}
+
+func trackDeletedEvent(track domain.Track) domain.TrackDeletedEvent {
+	return domain.NewTrackDeletedEvent(track.ID().String(), track.Name().String(), track.MovieID().String())
+}
+
+func themeDeletedEvent(theme domain.Theme) domain.ThemeDeletedEvent {
+	return domain.NewThemeDeletedEvent(theme.ID().String(), theme.Name().String(), theme.GroupID().String())
+}
+
+func trackIDs(tracks []domain.Track) []domain.TrackID {
+	ids := make([]domain.TrackID, 0, len(tracks))
+	for _, track := range tracks {
+		ids = append(ids, track.ID())
+	}
+	return ids
+}
+
+func themeIDs(themes []domain.Theme) []domain.ThemeID {
+	ids := make([]domain.ThemeID, 0, len(themes))
+	for _, theme := range themes {
+		ids = append(ids, theme.ID())
+	}
+	return ids
 }
 
 type ThemeService struct {
 	themeRepository domain.ThemeRepository
+	txManager       tx.Manager
+	eventBus        event.Bus
 }
 
-func NewThemeService(repo domain.ThemeRepository) ThemeService {
+func NewThemeService(repo domain.ThemeRepository, txManager tx.Manager, eventBus event.Bus) ThemeService {
 	return ThemeService{
 		themeRepository: repo,
+		txManager:       txManager,
+		eventBus:        eventBus,
 	}
 }
 
 func (s *ThemeService) DeleteTheme(ctx context.Context, id domain.ThemeID) error {
-	return s.themeRepository.Delete(ctx, id)
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		theme, err := s.themeRepository.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.themeRepository.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		theme.Record(themeDeletedEvent(theme))
+		return s.eventBus.Publish(ctx, theme.PullEvents())
+	})
 }
 
 type TrackThemeService struct {
 	trackThemeRepository domain.TrackThemeRepository
+	txManager            tx.Manager
+	eventBus             event.Bus
 }
 
-func NewTrackThemeService(repo domain.TrackThemeRepository) TrackThemeService {
+func NewTrackThemeService(repo domain.TrackThemeRepository, txManager tx.Manager, eventBus event.Bus) TrackThemeService {
 	return TrackThemeService{
 		trackThemeRepository: repo,
+		txManager:            txManager,
+		eventBus:             eventBus,
 	}
 }
 
 func (s *TrackThemeService) DeleteTrackTheme(ctx context.Context, trackID domain.TrackID, themeID domain.ThemeID, startSecond domain.StartSecond) error {
-	return s.trackThemeRepository.Delete(ctx, trackID, themeID, startSecond)
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		trackTheme, err := s.trackThemeRepository.Find(ctx, trackID, themeID, startSecond)
+		if err != nil {
+			return err
+		}
+		if err := s.trackThemeRepository.Delete(ctx, trackID, themeID, startSecond); err != nil {
+			return err
+		}
+
+		trackTheme.Record(domain.NewTrackThemeRemovedEvent(trackID.String(), themeID.String(), startSecond.Int()))
+		return s.eventBus.Publish(ctx, trackTheme.PullEvents())
+	})
+}
+
+type WebhookService struct {
+	webhookRepository domain.WebhookRepository
+}
+
+func NewWebhookService(webhookRepository domain.WebhookRepository) WebhookService {
+	return WebhookService{
+		webhookRepository: webhookRepository,
+	}
+}
+
+// DeleteWebhook removes a webhook for good, along with its delivery log. No more events
+// are posted to it, though one being delivered when it happens may still go out.
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id domain.WebhookID) error {
+	return s.webhookRepository.Delete(ctx, id)
 }
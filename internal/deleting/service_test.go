@@ -7,6 +7,9 @@ import (
 
 	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/storage/storagemocks"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event/eventmocks"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/tx/txmocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -14,6 +17,8 @@ import (
 
 const (
 	uuidStr          = "123e4567-e89b-12d3-a456-426614174000"
+	trackUUIDStr     = "223e4567-e89b-12d3-a456-426614174001"
+	themeUUIDStr     = "323e4567-e89b-12d3-a456-426614174002"
 	databaseErrorMsg = "database error"
 )
 
@@ -22,11 +27,18 @@ func TestMovieServiceDeleteMovieRepositoryError(t *testing.T) {
 	require.NoError(t, err)
 
 	mockRepo := new(storagemocks.MovieRepository)
+	mockRepo.On("Find", mock.Anything, movieIDObj).Return(newTestMovie(t, movieIDObj.String()), nil)
 	mockRepo.On("Delete", mock.Anything, movieIDObj).Return(fmt.Errorf("%s", databaseErrorMsg))
 
-	service := NewMovieService(mockRepo)
+	trackRepositoryMock := new(storagemocks.TrackRepository)
+	trackRepositoryMock.On("FindByMovie", mock.Anything, movieIDObj).Return(nil, nil)
 
-	err = service.DeleteMovie(context.Background(), movieIDObj)
+	themeRepositoryMock := new(storagemocks.ThemeRepository)
+	themeRepositoryMock.On("FindByMovie", mock.Anything, movieIDObj).Return(nil, nil)
+
+	service := NewMovieService(mockRepo, trackRepositoryMock, themeRepositoryMock, newTxManagerMock(t), new(eventmocks.Bus))
+
+	err = service.DeleteMovie(context.Background(), movieIDObj, false)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), databaseErrorMsg)
 
@@ -38,26 +50,99 @@ func TestMovieServiceDeleteMovieSuccess(t *testing.T) {
 	require.NoError(t, err)
 
 	mockRepo := new(storagemocks.MovieRepository)
+	mockRepo.On("Find", mock.Anything, movieIDObj).Return(newTestMovie(t, movieIDObj.String()), nil)
 	mockRepo.On("Delete", mock.Anything, movieIDObj).Return(nil)
 
-	service := NewMovieService(mockRepo)
+	trackRepositoryMock := new(storagemocks.TrackRepository)
+	trackRepositoryMock.On("FindByMovie", mock.Anything, movieIDObj).Return(nil, nil)
+
+	themeRepositoryMock := new(storagemocks.ThemeRepository)
+	themeRepositoryMock.On("FindByMovie", mock.Anything, movieIDObj).Return(nil, nil)
 
-	err = service.DeleteMovie(context.Background(), movieIDObj)
+	service := NewMovieService(mockRepo, trackRepositoryMock, themeRepositoryMock, newTxManagerMock(t), newEventBusMock(t, domain.MovieDeletedEventType))
+
+	err = service.DeleteMovie(context.Background(), movieIDObj, false)
 	assert.NoError(t, err)
 
 	mockRepo.AssertExpectations(t)
 }
 
+func TestMovieServiceDeleteMovieInUse(t *testing.T) {
+	movieIDObj, err := domain.NewMovieIDFromString(uuidStr)
+	require.NoError(t, err)
+
+	track := newTestTrack(t, trackUUIDStr)
+	theme := newTestTheme(t, themeUUIDStr, trackUUIDStr)
+
+	mockRepo := new(storagemocks.MovieRepository)
+	mockRepo.On("Find", mock.Anything, movieIDObj).Return(newTestMovie(t, movieIDObj.String()), nil)
+
+	trackRepositoryMock := new(storagemocks.TrackRepository)
+	trackRepositoryMock.On("FindByMovie", mock.Anything, movieIDObj).Return([]domain.Track{track}, nil)
+
+	themeRepositoryMock := new(storagemocks.ThemeRepository)
+	themeRepositoryMock.On("FindByMovie", mock.Anything, movieIDObj).Return([]domain.Theme{theme}, nil)
+
+	service := NewMovieService(mockRepo, trackRepositoryMock, themeRepositoryMock, newTxManagerMock(t), new(eventmocks.Bus))
+
+	err = service.DeleteMovie(context.Background(), movieIDObj, false)
+	var inUse domain.InUseError
+	require.ErrorAs(t, err, &inUse)
+	assert.ErrorIs(t, err, domain.ErrInUse)
+	assert.Equal(t, []domain.TrackID{track.ID()}, inUse.Dependents.Tracks)
+	assert.Equal(t, []domain.ThemeID{theme.ID()}, inUse.Dependents.Themes)
+
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	trackRepositoryMock.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	themeRepositoryMock.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestMovieServiceDeleteMovieCascade(t *testing.T) {
+	movieIDObj, err := domain.NewMovieIDFromString(uuidStr)
+	require.NoError(t, err)
+
+	track := newTestTrack(t, trackUUIDStr)
+	theme := newTestTheme(t, themeUUIDStr, trackUUIDStr)
+
+	var deleted []string
+
+	mockRepo := new(storagemocks.MovieRepository)
+	mockRepo.On("Find", mock.Anything, movieIDObj).Return(newTestMovie(t, movieIDObj.String()), nil)
+	mockRepo.On("Delete", mock.Anything, movieIDObj).Return(nil).Run(func(mock.Arguments) { deleted = append(deleted, "movie") })
+
+	trackRepositoryMock := new(storagemocks.TrackRepository)
+	trackRepositoryMock.On("FindByMovie", mock.Anything, movieIDObj).Return([]domain.Track{track}, nil)
+	trackRepositoryMock.On("Delete", mock.Anything, track.ID()).Return(nil).Run(func(mock.Arguments) { deleted = append(deleted, "track") })
+
+	themeRepositoryMock := new(storagemocks.ThemeRepository)
+	themeRepositoryMock.On("FindByMovie", mock.Anything, movieIDObj).Return([]domain.Theme{theme}, nil)
+	themeRepositoryMock.On("Delete", mock.Anything, theme.ID()).Return(nil).Run(func(mock.Arguments) { deleted = append(deleted, "theme") })
+
+	service := NewMovieService(mockRepo, trackRepositoryMock, themeRepositoryMock, newTxManagerMock(t), newEventBusMock(t, domain.ThemeDeletedEventType, domain.TrackDeletedEventType, domain.MovieDeletedEventType))
+
+	err = service.DeleteMovie(context.Background(), movieIDObj, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"theme", "track", "movie"}, deleted)
+
+	mockRepo.AssertExpectations(t)
+	trackRepositoryMock.AssertExpectations(t)
+	themeRepositoryMock.AssertExpectations(t)
+}
+
 func TestGroupServiceDeleteGroupRepositoryError(t *testing.T) {
 	groupIDObj, err := domain.NewGroupIDFromString(uuidStr)
 	require.NoError(t, err)
 
 	mockRepo := new(storagemocks.GroupRepository)
+	mockRepo.On("Find", mock.Anything, groupIDObj).Return(newTestGroup(t, groupIDObj.String()), nil)
 	mockRepo.On("Delete", mock.Anything, groupIDObj).Return(fmt.Errorf("%s", databaseErrorMsg))
 
-	service := NewGroupService(mockRepo)
+	themeRepositoryMock := new(storagemocks.ThemeRepository)
+	themeRepositoryMock.On("FindByGroup", mock.Anything, groupIDObj).Return(nil, nil)
 
-	err = service.DeleteGroup(context.Background(), groupIDObj)
+	service := NewGroupService(mockRepo, themeRepositoryMock, newTxManagerMock(t), new(eventmocks.Bus))
+
+	err = service.DeleteGroup(context.Background(), groupIDObj, false)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "database error")
 
@@ -69,26 +154,80 @@ func TestGroupServiceDeleteGroupSuccess(t *testing.T) {
 	require.NoError(t, err)
 
 	mockRepo := new(storagemocks.GroupRepository)
+	mockRepo.On("Find", mock.Anything, groupIDObj).Return(newTestGroup(t, groupIDObj.String()), nil)
 	mockRepo.On("Delete", mock.Anything, groupIDObj).Return(nil)
 
-	service := NewGroupService(mockRepo)
+	themeRepositoryMock := new(storagemocks.ThemeRepository)
+	themeRepositoryMock.On("FindByGroup", mock.Anything, groupIDObj).Return(nil, nil)
+
+	service := NewGroupService(mockRepo, themeRepositoryMock, newTxManagerMock(t), newEventBusMock(t, domain.GroupDeletedEventType))
 
-	err = service.DeleteGroup(context.Background(), groupIDObj)
+	err = service.DeleteGroup(context.Background(), groupIDObj, false)
 	assert.NoError(t, err)
 
 	mockRepo.AssertExpectations(t)
 }
 
+func TestGroupServiceDeleteGroupInUse(t *testing.T) {
+	groupIDObj, err := domain.NewGroupIDFromString(uuidStr)
+	require.NoError(t, err)
+
+	theme := newTestTheme(t, themeUUIDStr, trackUUIDStr)
+
+	mockRepo := new(storagemocks.GroupRepository)
+	mockRepo.On("Find", mock.Anything, groupIDObj).Return(newTestGroup(t, groupIDObj.String()), nil)
+
+	themeRepositoryMock := new(storagemocks.ThemeRepository)
+	themeRepositoryMock.On("FindByGroup", mock.Anything, groupIDObj).Return([]domain.Theme{theme}, nil)
+
+	service := NewGroupService(mockRepo, themeRepositoryMock, newTxManagerMock(t), new(eventmocks.Bus))
+
+	err = service.DeleteGroup(context.Background(), groupIDObj, false)
+	var inUse domain.InUseError
+	require.ErrorAs(t, err, &inUse)
+	assert.Empty(t, inUse.Dependents.Tracks)
+	assert.Equal(t, []domain.ThemeID{theme.ID()}, inUse.Dependents.Themes)
+
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestGroupServiceDeleteGroupCascade(t *testing.T) {
+	groupIDObj, err := domain.NewGroupIDFromString(uuidStr)
+	require.NoError(t, err)
+
+	theme := newTestTheme(t, themeUUIDStr, trackUUIDStr)
+
+	mockRepo := new(storagemocks.GroupRepository)
+	mockRepo.On("Find", mock.Anything, groupIDObj).Return(newTestGroup(t, groupIDObj.String()), nil)
+	mockRepo.On("Delete", mock.Anything, groupIDObj).Return(nil)
+
+	themeRepositoryMock := new(storagemocks.ThemeRepository)
+	themeRepositoryMock.On("FindByGroup", mock.Anything, groupIDObj).Return([]domain.Theme{theme}, nil)
+	themeRepositoryMock.On("Delete", mock.Anything, theme.ID()).Return(nil)
+
+	service := NewGroupService(mockRepo, themeRepositoryMock, newTxManagerMock(t), newEventBusMock(t, domain.ThemeDeletedEventType, domain.GroupDeletedEventType))
+
+	err = service.DeleteGroup(context.Background(), groupIDObj, true)
+	assert.NoError(t, err)
+
+	mockRepo.AssertExpectations(t)
+	themeRepositoryMock.AssertExpectations(t)
+}
+
 func TestCategoryServiceDeleteCategoryRepositoryError(t *testing.T) {
 	categoryIDObj, err := domain.NewCategoryIDFromString(uuidStr)
 	require.NoError(t, err)
 
 	mockRepo := new(storagemocks.CategoryRepository)
+	mockRepo.On("Find", mock.Anything, categoryIDObj).Return(newTestCategory(t, categoryIDObj.String()), nil)
 	mockRepo.On("Delete", mock.Anything, categoryIDObj).Return(fmt.Errorf("%s", databaseErrorMsg))
 
-	service := NewCategoryService(mockRepo)
+	themeRepositoryMock := new(storagemocks.ThemeRepository)
+	themeRepositoryMock.On("FindByCategory", mock.Anything, categoryIDObj).Return(nil, nil)
 
-	err = service.DeleteCategory(context.Background(), categoryIDObj)
+	service := NewCategoryService(mockRepo, themeRepositoryMock, newTxManagerMock(t), new(eventmocks.Bus))
+
+	err = service.DeleteCategory(context.Background(), categoryIDObj, false)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), databaseErrorMsg)
 
@@ -100,26 +239,56 @@ func TestCategoryServiceDeleteCategorySuccess(t *testing.T) {
 	require.NoError(t, err)
 
 	mockRepo := new(storagemocks.CategoryRepository)
+	mockRepo.On("Find", mock.Anything, categoryIDObj).Return(newTestCategory(t, categoryIDObj.String()), nil)
 	mockRepo.On("Delete", mock.Anything, categoryIDObj).Return(nil)
 
-	service := NewCategoryService(mockRepo)
+	themeRepositoryMock := new(storagemocks.ThemeRepository)
+	themeRepositoryMock.On("FindByCategory", mock.Anything, categoryIDObj).Return(nil, nil)
+
+	service := NewCategoryService(mockRepo, themeRepositoryMock, newTxManagerMock(t), newEventBusMock(t, domain.CategoryDeletedEventType))
 
-	err = service.DeleteCategory(context.Background(), categoryIDObj)
+	err = service.DeleteCategory(context.Background(), categoryIDObj, false)
 	assert.NoError(t, err)
 
 	mockRepo.AssertExpectations(t)
 }
 
+func TestCategoryServiceDeleteCategoryInUse(t *testing.T) {
+	categoryIDObj, err := domain.NewCategoryIDFromString(uuidStr)
+	require.NoError(t, err)
+
+	theme := newTestTheme(t, themeUUIDStr, trackUUIDStr)
+
+	mockRepo := new(storagemocks.CategoryRepository)
+	mockRepo.On("Find", mock.Anything, categoryIDObj).Return(newTestCategory(t, categoryIDObj.String()), nil)
+
+	themeRepositoryMock := new(storagemocks.ThemeRepository)
+	themeRepositoryMock.On("FindByCategory", mock.Anything, categoryIDObj).Return([]domain.Theme{theme}, nil)
+
+	service := NewCategoryService(mockRepo, themeRepositoryMock, newTxManagerMock(t), new(eventmocks.Bus))
+
+	err = service.DeleteCategory(context.Background(), categoryIDObj, false)
+	var inUse domain.InUseError
+	require.ErrorAs(t, err, &inUse)
+	assert.Equal(t, []domain.ThemeID{theme.ID()}, inUse.Dependents.Themes)
+
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
 func TestTrackServiceDeleteTrackRepositoryError(t *testing.T) {
 	trackIDObj, err := domain.NewTrackIDFromString(uuidStr)
 	require.NoError(t, err)
 
 	mockRepo := new(storagemocks.TrackRepository)
+	mockRepo.On("Find", mock.Anything, trackIDObj).Return(newTestTrack(t, trackIDObj.String()), nil)
 	mockRepo.On("Delete", mock.Anything, trackIDObj).Return(fmt.Errorf("%s", databaseErrorMsg))
 
-	service := NewTrackService(mockRepo)
+	themeRepositoryMock := new(storagemocks.ThemeRepository)
+	themeRepositoryMock.On("FindByFirstHeard", mock.Anything, trackIDObj).Return(nil, nil)
 
-	err = service.DeleteTrack(context.Background(), trackIDObj)
+	service := NewTrackService(mockRepo, themeRepositoryMock, newTxManagerMock(t), new(eventmocks.Bus))
+
+	err = service.DeleteTrack(context.Background(), trackIDObj, false)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), databaseErrorMsg)
 
@@ -131,24 +300,52 @@ func TestTrackServiceDeleteTrackSuccess(t *testing.T) {
 	require.NoError(t, err)
 
 	mockRepo := new(storagemocks.TrackRepository)
+	mockRepo.On("Find", mock.Anything, trackIDObj).Return(newTestTrack(t, trackIDObj.String()), nil)
 	mockRepo.On("Delete", mock.Anything, trackIDObj).Return(nil)
 
-	service := NewTrackService(mockRepo)
+	themeRepositoryMock := new(storagemocks.ThemeRepository)
+	themeRepositoryMock.On("FindByFirstHeard", mock.Anything, trackIDObj).Return(nil, nil)
+
+	service := NewTrackService(mockRepo, themeRepositoryMock, newTxManagerMock(t), newEventBusMock(t, domain.TrackDeletedEventType))
 
-	err = service.DeleteTrack(context.Background(), trackIDObj)
+	err = service.DeleteTrack(context.Background(), trackIDObj, false)
 	assert.NoError(t, err)
 
 	mockRepo.AssertExpectations(t)
 }
 
+func TestTrackServiceDeleteTrackCascadeError(t *testing.T) {
+	trackIDObj, err := domain.NewTrackIDFromString(trackUUIDStr)
+	require.NoError(t, err)
+
+	theme := newTestTheme(t, themeUUIDStr, trackUUIDStr)
+
+	mockRepo := new(storagemocks.TrackRepository)
+	mockRepo.On("Find", mock.Anything, trackIDObj).Return(newTestTrack(t, trackIDObj.String()), nil)
+
+	themeRepositoryMock := new(storagemocks.ThemeRepository)
+	themeRepositoryMock.On("FindByFirstHeard", mock.Anything, trackIDObj).Return([]domain.Theme{theme}, nil)
+	themeRepositoryMock.On("Delete", mock.Anything, theme.ID()).Return(fmt.Errorf("%s", databaseErrorMsg))
+
+	service := NewTrackService(mockRepo, themeRepositoryMock, newTxManagerMock(t), new(eventmocks.Bus))
+
+	err = service.DeleteTrack(context.Background(), trackIDObj, true)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), databaseErrorMsg)
+
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	themeRepositoryMock.AssertExpectations(t)
+}
+
 func TestThemeServiceDeleteThemeRepositoryError(t *testing.T) {
 	themeIDObj, err := domain.NewThemeIDFromString(uuidStr)
 	require.NoError(t, err)
 
 	mockRepo := new(storagemocks.ThemeRepository)
+	mockRepo.On("Find", mock.Anything, themeIDObj).Return(newTestTheme(t, themeIDObj.String(), trackUUIDStr), nil)
 	mockRepo.On("Delete", mock.Anything, themeIDObj).Return(fmt.Errorf("%s", databaseErrorMsg))
 
-	service := NewThemeService(mockRepo)
+	service := NewThemeService(mockRepo, newTxManagerMock(t), new(eventmocks.Bus))
 
 	err = service.DeleteTheme(context.Background(), themeIDObj)
 	assert.Error(t, err)
@@ -162,9 +359,10 @@ func TestThemeServiceDeleteThemeSuccess(t *testing.T) {
 	require.NoError(t, err)
 
 	mockRepo := new(storagemocks.ThemeRepository)
+	mockRepo.On("Find", mock.Anything, themeIDObj).Return(newTestTheme(t, themeIDObj.String(), trackUUIDStr), nil)
 	mockRepo.On("Delete", mock.Anything, themeIDObj).Return(nil)
 
-	service := NewThemeService(mockRepo)
+	service := NewThemeService(mockRepo, newTxManagerMock(t), newEventBusMock(t, domain.ThemeDeletedEventType))
 
 	err = service.DeleteTheme(context.Background(), themeIDObj)
 	assert.NoError(t, err)
@@ -172,6 +370,21 @@ func TestThemeServiceDeleteThemeSuccess(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestThemeServiceDeleteThemeNotFound(t *testing.T) {
+	themeIDObj, err := domain.NewThemeIDFromString(uuidStr)
+	require.NoError(t, err)
+
+	mockRepo := new(storagemocks.ThemeRepository)
+	mockRepo.On("Find", mock.Anything, themeIDObj).Return(domain.Theme{}, domain.ErrThemeNotFound)
+
+	service := NewThemeService(mockRepo, newTxManagerMock(t), new(eventmocks.Bus))
+
+	err = service.DeleteTheme(context.Background(), themeIDObj)
+	assert.ErrorIs(t, err, domain.ErrThemeNotFound)
+
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
 func TestTrackThemeServiceDeleteTrackThemeRepositoryError(t *testing.T) {
 	trackIDObj, err := domain.NewTrackIDFromString(uuidStr)
 	require.NoError(t, err)
@@ -183,9 +396,10 @@ func TestTrackThemeServiceDeleteTrackThemeRepositoryError(t *testing.T) {
 	require.NoError(t, err)
 
 	mockRepo := new(storagemocks.TrackThemeRepository)
+	mockRepo.On("Find", mock.Anything, trackIDObj, themeIDObj, startSecondObj).Return(newTestTrackTheme(t, trackIDObj.String(), themeIDObj.String()), nil)
 	mockRepo.On("Delete", mock.Anything, trackIDObj, themeIDObj, startSecondObj).Return(fmt.Errorf("%s", databaseErrorMsg))
 
-	service := NewTrackThemeService(mockRepo)
+	service := NewTrackThemeService(mockRepo, newTxManagerMock(t), new(eventmocks.Bus))
 
 	err = service.DeleteTrackTheme(context.Background(), trackIDObj, themeIDObj, startSecondObj)
 	assert.Error(t, err)
@@ -205,12 +419,156 @@ func TestTrackThemeServiceDeleteTrackThemeSuccess(t *testing.T) {
 	require.NoError(t, err)
 
 	mockRepo := new(storagemocks.TrackThemeRepository)
+	mockRepo.On("Find", mock.Anything, trackIDObj, themeIDObj, startSecondObj).Return(newTestTrackTheme(t, trackIDObj.String(), themeIDObj.String()), nil)
 	mockRepo.On("Delete", mock.Anything, trackIDObj, themeIDObj, startSecondObj).Return(nil)
 
-	service := NewTrackThemeService(mockRepo)
+	service := NewTrackThemeService(mockRepo, newTxManagerMock(t), newEventBusMock(t, domain.TrackThemeRemovedEventType))
 
 	err = service.DeleteTrackTheme(context.Background(), trackIDObj, themeIDObj, startSecondObj)
 	assert.NoError(t, err)
 
 	mockRepo.AssertExpectations(t)
 }
+
+// newTxManagerMock returns a transaction manager that runs the unit of work it is given.
+func newTxManagerMock(t *testing.T) *txmocks.Manager {
+	txManagerMock := new(txmocks.Manager)
+	txManagerMock.On("WithinTx", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	t.Cleanup(func() { txManagerMock.AssertExpectations(t) })
+	return txManagerMock
+}
+
+// newTestMovie returns a movie with the given ID.
+func newTestMovie(t *testing.T, id string) domain.Movie {
+	movie, err := domain.NewMovieWithID(id, "Test Movie", 2001, 1, domain.MovieSeriesLordOfTheRings, nil)
+	require.NoError(t, err)
+	return movie
+}
+
+// newTestGroup returns a group with the given ID.
+func newTestGroup(t *testing.T, id string) domain.Group {
+	group, err := domain.NewGroupWithID(id, "Test Group", "Test description", "http://example.com/image.jpg")
+	require.NoError(t, err)
+	return group
+}
+
+// newTestCategory returns a category with the given ID.
+func newTestCategory(t *testing.T, id string) domain.Category {
+	category, err := domain.NewCategoryWithID(id, "Test Category")
+	require.NoError(t, err)
+	return category
+}
+
+// newTestTrackTheme returns the occurrence of the theme in the track from second 0 to 30.
+func newTestTrackTheme(t *testing.T, trackID, themeID string) domain.TrackTheme {
+	trackTheme, err := domain.NewTrackTheme(trackID, themeID, 0, 30, false)
+	require.NoError(t, err)
+	return trackTheme
+}
+
+// newTestTrack returns a three-minute track with the given ID.
+func newTestTrack(t *testing.T, id string) domain.Track {
+	track, err := domain.NewTrackWithID(id, "Test Track", uuidStr, nil, 1, 1, domain.TrackEditionTheatrical, 180)
+	require.NoError(t, err)
+	return track
+}
+
+// newTestTheme returns a theme with the given ID, first heard in the given track.
+func newTestTheme(t *testing.T, id, firstHeard string) domain.Theme {
+	theme, err := domain.NewThemeWithID(id, "Test Theme", firstHeard, uuidStr, "Test description", 0, 30, nil)
+	require.NoError(t, err)
+	return theme
+}
+
+// newEventBusMock returns an event bus that expects the events of the given types to be
+// published once, in that order.
+func newEventBusMock(t *testing.T, types ...event.Type) *eventmocks.Bus {
+	eventBusMock := new(eventmocks.Bus)
+	eventBusMock.On("Publish", mock.Anything, mock.MatchedBy(func(events []event.Event) bool {
+		if len(events) != len(types) {
+			return false
+		}
+		for i, e := range events {
+			if e.Type() != types[i] {
+				return false
+			}
+		}
+		return true
+	})).Return(nil).Once()
+	t.Cleanup(func() { eventBusMock.AssertExpectations(t) })
+	return eventBusMock
+}
+
+// newTestUser returns a viewer identified by uuidStr.
+func newTestUser(t *testing.T) domain.User {
+	user, err := domain.NewUserWithID(uuidStr, "Samwise Gamgee", "sam@shire.me", "hashed-password", domain.RoleViewer, true)
+	require.NoError(t, err)
+	return user
+}
+
+func TestUserServiceDeleteUser(t *testing.T) {
+	userID, err := domain.NewUserIDFromString(uuidStr)
+	require.NoError(t, err)
+
+	userRepositoryMock := new(storagemocks.UserRepository)
+	userRepositoryMock.On("CountByPermission", mock.Anything, domain.PermissionManageUsers).Return(2, nil).Once()
+	userRepositoryMock.On("Find", mock.Anything, userID).Return(newTestUser(t), nil).Once()
+	userRepositoryMock.On("Delete", mock.Anything, userID).Return(nil).Once()
+	userRepositoryMock.On("CountByPermission", mock.Anything, domain.PermissionManageUsers).Return(1, nil).Once()
+	defer userRepositoryMock.AssertExpectations(t)
+
+	service := NewUserService(userRepositoryMock, newTxManagerMock(t), newEventBusMock(t, domain.UserDeletedEventType))
+
+	err = service.DeleteUser(context.Background(), userID)
+	assert.NoError(t, err)
+}
+
+func TestUserServiceDeleteUserLastAdmin(t *testing.T) {
+	userID, err := domain.NewUserIDFromString(uuidStr)
+	require.NoError(t, err)
+
+	userRepositoryMock := new(storagemocks.UserRepository)
+	userRepositoryMock.On("CountByPermission", mock.Anything, domain.PermissionManageUsers).Return(1, nil).Once()
+	userRepositoryMock.On("Find", mock.Anything, userID).Return(newTestUser(t), nil).Once()
+	userRepositoryMock.On("Delete", mock.Anything, userID).Return(nil).Once()
+	userRepositoryMock.On("CountByPermission", mock.Anything, domain.PermissionManageUsers).Return(0, nil).Once()
+	defer userRepositoryMock.AssertExpectations(t)
+
+	eventBusMock := new(eventmocks.Bus)
+	service := NewUserService(userRepositoryMock, newTxManagerMock(t), eventBusMock)
+
+	err = service.DeleteUser(context.Background(), userID)
+	assert.ErrorIs(t, err, domain.ErrLastAdmin)
+	eventBusMock.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestUserServiceDeleteUserNotFound(t *testing.T) {
+	userID, err := domain.NewUserIDFromString(uuidStr)
+	require.NoError(t, err)
+
+	userRepositoryMock := new(storagemocks.UserRepository)
+	userRepositoryMock.On("CountByPermission", mock.Anything, domain.PermissionManageUsers).Return(1, nil).Once()
+	userRepositoryMock.On("Find", mock.Anything, userID).Return(domain.User{}, domain.ErrUserNotFound).Once()
+	defer userRepositoryMock.AssertExpectations(t)
+
+	service := NewUserService(userRepositoryMock, newTxManagerMock(t), new(eventmocks.Bus))
+
+	err = service.DeleteUser(context.Background(), userID)
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func TestWebhookServiceDeleteWebhookNotFound(t *testing.T) {
+	webhookID, err := domain.NewWebhookIDFromString(uuidStr)
+	require.NoError(t, err)
+
+	webhookRepositoryMock := new(storagemocks.WebhookRepository)
+	webhookRepositoryMock.On("Delete", mock.Anything, webhookID).Return(domain.ErrWebhookNotFound).Once()
+	defer webhookRepositoryMock.AssertExpectations(t)
+
+	service := NewWebhookService(webhookRepositoryMock)
+
+	err = service.DeleteWebhook(context.Background(), webhookID)
+	assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+}
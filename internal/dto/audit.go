@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+)
+
+type AuditQuery struct {
+	PageQuery
+	Entity string `form:"entity"`
+	User   string `form:"user"`
+	Since  string `form:"since"` // RFC 3339 time
+}
+
+// AuditEntryResponse is a command recorded in the audit log. Before and After are null
+// when the entity did not exist before the command or no longer exists after it.
+type AuditEntryResponse struct {
+	ID          string          `json:"id"`
+	CommandType string          `json:"command_type"`
+	Entity      string          `json:"entity"`
+	EntityID    *string         `json:"entity_id"`
+	UserID      *string         `json:"user_id"`
+	Before      json.RawMessage `json:"before"`
+	After       json.RawMessage `json:"after"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+}
+
+func NewAuditEntryResponse(entry domain.AuditEntry) AuditEntryResponse {
+	return AuditEntryResponse{
+		ID:          entry.ID(),
+		CommandType: entry.CommandType(),
+		Entity:      entry.Entity(),
+		EntityID:    optional(entry.EntityID()),
+		UserID:      optional(entry.UserID()),
+		Before:      entry.Before(),
+		After:       entry.After(),
+		OccurredAt:  entry.OccurredAt(),
+	}
+}
+
+// optional maps the empty string to null.
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,41 @@
+package dto
+
+import domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+
+type DeleteQuery struct {
+	Cascade bool `form:"cascade"`
+}
+
+type DependentList struct {
+	Count int      `json:"count"`
+	IDs   []string `json:"ids"`
+}
+
+type DependentsResponse struct {
+	Tracks DependentList `json:"tracks"`
+	Themes DependentList `json:"themes"`
+}
+
+type InUseResponse struct {
+	Error      string             `json:"error"`
+	Dependents DependentsResponse `json:"dependents"`
+}
+
+func NewInUseResponse(err domain.InUseError) InUseResponse {
+	tracks := make([]string, 0, len(err.Dependents.Tracks))
+	for _, id := range err.Dependents.Tracks {
+		tracks = append(tracks, id.String())
+	}
+	themes := make([]string, 0, len(err.Dependents.Themes))
+	for _, id := range err.Dependents.Themes {
+		themes = append(themes, id.String())
+	}
+
+	return InUseResponse{
+		Error: err.Error(),
+		Dependents: DependentsResponse{
+			Tracks: DependentList{Count: len(tracks), IDs: tracks},
+			Themes: DependentList{Count: len(themes), IDs: themes},
+		},
+	}
+}
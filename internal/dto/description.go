@@ -0,0 +1,6 @@
+package dto
+
+// DescriptionUpdateRequest replaces the description of a group or a theme.
+type DescriptionUpdateRequest struct {
+	Description string `json:"description" binding:"required"`
+}
@@ -3,21 +3,48 @@ package dto
 import domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
 
 type MovieCreateRequest struct {
-	Name string `json:"name" binding:"required"`
+	Name           string `json:"name" binding:"required"`
+	ReleaseYear    int    `json:"release_year" binding:"required"`
+	Sequence       int    `json:"sequence" binding:"required"`
+	Series         string `json:"series" binding:"required"`
+	RuntimeMinutes *int   `json:"runtime_minutes"`
 }
 
 type MovieUpdateRequest struct {
-	Name string `json:"name" binding:"required"`
+	Name           string `json:"name" binding:"required"`
+	ReleaseYear    int    `json:"release_year" binding:"required"`
+	Sequence       int    `json:"sequence" binding:"required"`
+	Series         string `json:"series" binding:"required"`
+	RuntimeMinutes *int   `json:"runtime_minutes"`
 }
 
 type MovieResponse struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	ReleaseYear    int    `json:"release_year"`
+	Sequence       int    `json:"sequence"`
+	Series         string `json:"series"`
+	RuntimeMinutes *int   `json:"runtime_minutes"`
+}
+
+type MoviesQuery struct {
+	PageQuery
+	Series string `form:"series"`
 }
 
 func NewMovieResponse(movie domain.Movie) MovieResponse {
+	var runtimeMinutes *int
+	if movie.Runtime() != nil {
+		minutes := movie.Runtime().Minutes()
+		runtimeMinutes = &minutes
+	}
+
 	return MovieResponse{
-		ID:   movie.ID().String(),
-		Name: movie.Name().String(),
+		ID:             movie.ID().String(),
+		Name:           movie.Name().String(),
+		ReleaseYear:    movie.ReleaseYear().Int(),
+		Sequence:       movie.Sequence().Int(),
+		Series:         movie.Series().String(),
+		RuntimeMinutes: runtimeMinutes,
 	}
 }
@@ -0,0 +1,20 @@
+package dto
+
+import domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+
+type PageResponse[T any] struct {
+	Items      []T     `json:"items"`
+	NextCursor *string `json:"next_cursor"`
+}
+
+func NewPageResponse[T any](items []T, nextCursor *domain.Cursor) PageResponse[T] {
+	return PageResponse[T]{
+		Items:      items,
+		NextCursor: nextCursor.AsStringPtr(),
+	}
+}
+
+type PageQuery struct {
+	Limit  int    `form:"limit" binding:"gte=0"`
+	Cursor string `form:"cursor"`
+}
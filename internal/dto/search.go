@@ -0,0 +1,34 @@
+package dto
+
+import domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+
+type SearchQuery struct {
+	Q     string `form:"q" binding:"required"`
+	Limit int    `form:"limit" binding:"gte=0"`
+}
+
+// SearchResult is a catalogue entry matching a search. Type is one of "theme", "track",
+// "group" or "movie", and Snippet highlights the matching words with <mark> tags. The rest
+// of the snippet is HTML escaped.
+type SearchResult struct {
+	Type    string  `json:"type"`
+	ID      string  `json:"id"`
+	Name    string  `json:"name"`
+	Snippet string  `json:"snippet"`
+	Rank    float64 `json:"rank"`
+}
+
+func NewSearchResult(result domain.SearchResult) SearchResult {
+	return SearchResult{
+		Type:    result.Type(),
+		ID:      result.ID(),
+		Name:    result.Name(),
+		Snippet: result.Snippet(),
+		Rank:    result.Rank(),
+	}
+}
+
+type SearchResponse struct {
+	Query   string         `json:"query"`
+	Results []SearchResult `json:"results"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+type TokenRefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// TokenResponse holds the tokens of a session. The access token expires after ExpiresIn
+// seconds; the refresh token gets a new pair of tokens and is good for a single use.
+type TokenResponse struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+}
@@ -45,3 +45,12 @@ func NewThemeResponse(theme domain.Theme, firstHeard TrackResponse, group GroupR
 		Category:        category,
 	}
 }
+
+type ThemesQuery struct {
+	PageQuery
+	GroupID    string `form:"group_id"`
+	CategoryID string `form:"category_id"`
+	MovieID    string `form:"movie_id"`
+	Name       string `form:"name"`
+	Sort       string `form:"sort"`
+}
@@ -3,29 +3,45 @@ package dto
 import domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
 
 type TrackCreateRequest struct {
-	Name       string  `json:"name" binding:"required"`
-	MovieID    string  `json:"movie_id" binding:"required"`
-	SpotifyURL *string `json:"spotify_url" binding:"required,url"`
+	Name            string  `json:"name" binding:"required"`
+	MovieID         string  `json:"movie_id" binding:"required"`
+	SpotifyURL      *string `json:"spotify_url" binding:"required,url"`
+	TrackNumber     int     `json:"track_number" binding:"required"`
+	DiscNumber      int     `json:"disc_number" binding:"required"`
+	Edition         string  `json:"edition" binding:"required"`
+	DurationSeconds int     `json:"duration_seconds" binding:"required"`
 }
 
 type TrackUpdateRequest struct {
-	Name       string  `json:"name" binding:"required"`
-	MovieID    string  `json:"movie_id" binding:"required"`
-	SpotifyURL *string `json:"spotify_url" binding:"required,url"`
+	Name            string  `json:"name" binding:"required"`
+	MovieID         string  `json:"movie_id" binding:"required"`
+	SpotifyURL      *string `json:"spotify_url" binding:"required,url"`
+	TrackNumber     int     `json:"track_number" binding:"required"`
+	DiscNumber      int     `json:"disc_number" binding:"required"`
+	Edition         string  `json:"edition" binding:"required"`
+	DurationSeconds int     `json:"duration_seconds" binding:"required"`
 }
 
 type TrackResponse struct {
-	ID         string        `json:"id"`
-	Name       string        `json:"name"`
-	Movie      MovieResponse `json:"movie"`
-	SpotifyURL *string       `json:"spotify_url"`
+	ID              string        `json:"id"`
+	Name            string        `json:"name"`
+	Movie           MovieResponse `json:"movie"`
+	SpotifyURL      *string       `json:"spotify_url"`
+	TrackNumber     int           `json:"track_number"`
+	DiscNumber      int           `json:"disc_number"`
+	Edition         string        `json:"edition"`
+	DurationSeconds int           `json:"duration_seconds"`
 }
 
 func NewTrackResponse(track domain.Track, movie MovieResponse) TrackResponse {
 	return TrackResponse{
-		ID:         track.ID().String(),
-		Name:       track.Name().String(),
-		Movie:      movie,
-		SpotifyURL: track.SpotifyURL().AsStringPtr(),
+		ID:              track.ID().String(),
+		Name:            track.Name().String(),
+		Movie:           movie,
+		SpotifyURL:      track.SpotifyURL().AsStringPtr(),
+		TrackNumber:     track.TrackNumber().Int(),
+		DiscNumber:      track.DiscNumber().Int(),
+		Edition:         track.Edition().String(),
+		DurationSeconds: track.Duration().Seconds(),
 	}
 }
@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+// TrashItem is a deleted catalogue entry that can still be restored. Type is one of
+// "movie", "group", "category", "track" or "theme".
+type TrashItem struct {
+	Type      string    `json:"type"`
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
@@ -6,12 +6,41 @@ type UserCreateRequest struct {
 	Name     string `json:"name" binding:"required"`
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required"`
+	Role     string `json:"role"` // Defaults to viewer
+}
+
+// RegisterRequest is the request of a visitor signing up. Unlike UserCreateRequest, it
+// cannot choose a role.
+type RegisterRequest struct {
+	Name     string `json:"name" binding:"required"`
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+// ResendVerificationRequest asks for a new verification link for the email.
+type ResendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type UserUpdateRequest struct {
+	Name  string `json:"name" binding:"required"`
+	Email string `json:"email" binding:"required,email"`
+}
+
+type UserPasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+type UserRoleRequest struct {
+	Role string `json:"role" binding:"required"`
 }
 
 type UserResponse struct {
 	ID    string `json:"id"`
 	Name  string `json:"name"`
 	Email string `json:"email"`
+	Role  string `json:"role"`
 }
 
 func NewUserResponse(user domain.User) UserResponse {
@@ -19,5 +48,6 @@ func NewUserResponse(user domain.User) UserResponse {
 		ID:    user.ID().String(),
 		Name:  user.Name().String(),
 		Email: user.Email().String(),
+		Role:  user.Role().String(),
 	}
 }
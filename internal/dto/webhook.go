@@ -0,0 +1,70 @@
+package dto
+
+import (
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+)
+
+type WebhookCreateRequest struct {
+	URL        string   `json:"url" binding:"required"`
+	Secret     string   `json:"secret" binding:"required"`
+	EventTypes []string `json:"event_types" binding:"required"`
+}
+
+// WebhookUpdateRequest replaces the URL, the secret and the event types of a webhook. It
+// is how a secret is rotated.
+type WebhookUpdateRequest = WebhookCreateRequest
+
+// WebhookResponse is a webhook as listed to admins. The secret is never sent back.
+type WebhookResponse struct {
+	ID         string   `json:"id"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+}
+
+type WebhooksResponse struct {
+	Webhooks []WebhookResponse `json:"webhooks"`
+}
+
+// WebhookDeliveryResponse is an attempt at posting an event to a webhook. StatusCode is
+// null when no response was received, and Error is null when the webhook accepted the event.
+type WebhookDeliveryResponse struct {
+	ID          string    `json:"id"`
+	EventID     string    `json:"event_id"`
+	EventType   string    `json:"event_type"`
+	Attempt     int       `json:"attempt"`
+	StatusCode  *int      `json:"status_code"`
+	Error       *string   `json:"error"`
+	DeliveredAt time.Time `json:"delivered_at"`
+}
+
+func NewWebhookResponse(webhook domain.Webhook) WebhookResponse {
+	eventTypes := make([]string, 0, len(webhook.EventTypes()))
+	for _, eventType := range webhook.EventTypes() {
+		eventTypes = append(eventTypes, string(eventType))
+	}
+
+	return WebhookResponse{
+		ID:         webhook.ID().String(),
+		URL:        webhook.URL().String(),
+		EventTypes: eventTypes,
+	}
+}
+
+func NewWebhookDeliveryResponse(delivery domain.WebhookDelivery) WebhookDeliveryResponse {
+	var statusCode *int
+	if code := delivery.StatusCode(); code != 0 {
+		statusCode = &code
+	}
+
+	return WebhookDeliveryResponse{
+		ID:          delivery.ID(),
+		EventID:     delivery.EventID(),
+		EventType:   string(delivery.EventType()),
+		Attempt:     delivery.Attempt(),
+		StatusCode:  statusCode,
+		Error:       optional(delivery.Err()),
+		DeliveredAt: delivery.DeliveredAt(),
+	}
+}
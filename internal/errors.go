@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInUse is returned when deleting an entity that other entities still reference.
+var ErrInUse = errors.New("in use")
+
+// ErrDeletedReference is returned when restoring an entity that references another one
+// still in the trash.
+var ErrDeletedReference = errors.New("references a deleted entity")
+
+// Dependents are the tracks and themes that still reference an entity being deleted.
+type Dependents struct {
+	Tracks []TrackID
+	Themes []ThemeID
+}
+
+// IsEmpty tells whether nothing references the entity.
+func (d Dependents) IsEmpty() bool {
+	return len(d.Tracks) == 0 && len(d.Themes) == 0
+}
+
+// InUseError is an ErrInUse that lists the dependents keeping the entity from being deleted.
+type InUseError struct {
+	Dependents Dependents
+}
+
+func (e InUseError) Error() string {
+	return fmt.Sprintf("%s: referenced by %d tracks and %d themes", ErrInUse, len(e.Dependents.Tracks), len(e.Dependents.Themes))
+}
+
+func (e InUseError) Is(target error) bool {
+	return target == ErrInUse
+}
@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+)
+
+var ErrUnknownEvent = errors.New("unknown event")
+
+// eventPayload is the JSON form of what an event carries besides its ID, aggregate ID and
+// time. Each event fills the fields it has.
+type eventPayload struct {
+	Name        string `json:"name,omitempty"`
+	Email       string `json:"email,omitempty"`
+	Role        string `json:"role,omitempty"`
+	MovieID     string `json:"movie_id,omitempty"`
+	GroupID     string `json:"group_id,omitempty"`
+	TrackID     string `json:"track_id,omitempty"`
+	StartSecond *int   `json:"start_second,omitempty"`
+}
+
+// payloader is implemented by every event of the domain.
+type payloader interface {
+	payload() eventPayload
+}
+
+func (e UserCreatedEvent) payload() eventPayload {
+	return eventPayload{Name: e.name, Email: e.email}
+}
+
+func (e VerificationRequestedEvent) payload() eventPayload {
+	return eventPayload{Email: e.email}
+}
+
+func (e userEvent) payload() eventPayload {
+	return eventPayload{Name: e.name, Email: e.email}
+}
+
+func (e UserRoleChangedEvent) payload() eventPayload {
+	return eventPayload{Name: e.name, Email: e.email, Role: e.role}
+}
+
+func (e catalogueEvent) payload() eventPayload {
+	return eventPayload{Name: e.name}
+}
+
+func (e trackEvent) payload() eventPayload {
+	return eventPayload{Name: e.name, MovieID: e.movieID}
+}
+
+func (e themeEvent) payload() eventPayload {
+	return eventPayload{Name: e.name, GroupID: e.groupID}
+}
+
+func (e trackThemeEvent) payload() eventPayload {
+	startSecond := e.startSecond
+	return eventPayload{TrackID: e.trackID, StartSecond: &startSecond}
+}
+
+func (p eventPayload) user(b event.BaseEvent) userEvent {
+	return userEvent{BaseEvent: b, name: p.Name, email: p.Email}
+}
+
+func (p eventPayload) catalogue(b event.BaseEvent) catalogueEvent {
+	return catalogueEvent{BaseEvent: b, name: p.Name}
+}
+
+func (p eventPayload) movie(b event.BaseEvent) movieEvent {
+	return movieEvent{p.catalogue(b)}
+}
+
+func (p eventPayload) group(b event.BaseEvent) groupEvent {
+	return groupEvent{p.catalogue(b)}
+}
+
+func (p eventPayload) category(b event.BaseEvent) categoryEvent {
+	return categoryEvent{p.catalogue(b)}
+}
+
+func (p eventPayload) track(b event.BaseEvent) trackEvent {
+	return trackEvent{catalogueEvent: p.catalogue(b), movieID: p.MovieID}
+}
+
+func (p eventPayload) theme(b event.BaseEvent) themeEvent {
+	return themeEvent{catalogueEvent: p.catalogue(b), groupID: p.GroupID}
+}
+
+func (p eventPayload) trackTheme(b event.BaseEvent) trackThemeEvent {
+	var startSecond int
+	if p.StartSecond != nil {
+		startSecond = *p.StartSecond
+	}
+	return trackThemeEvent{BaseEvent: b, trackID: p.TrackID, startSecond: startSecond}
+}
+
+// eventDecoders rebuilds every event of the domain from its base and payload.
+var eventDecoders = map[event.Type]func(b event.BaseEvent, p eventPayload) event.Event{
+	UserCreatedEventType: func(b event.BaseEvent, p eventPayload) event.Event {
+		return UserCreatedEvent{BaseEvent: b, id: b.AggregateID(), name: p.Name, email: p.Email}
+	},
+	VerificationRequestedEventType: func(b event.BaseEvent, p eventPayload) event.Event {
+		return VerificationRequestedEvent{BaseEvent: b, email: p.Email}
+	},
+	UserUpdatedEventType: func(b event.BaseEvent, p eventPayload) event.Event { return UserUpdatedEvent{p.user(b)} },
+	UserRoleChangedEventType: func(b event.BaseEvent, p eventPayload) event.Event {
+		return UserRoleChangedEvent{userEvent: p.user(b), role: p.Role}
+	},
+	UserDeletedEventType:       func(b event.BaseEvent, p eventPayload) event.Event { return UserDeletedEvent{p.user(b)} },
+	MovieCreatedEventType:      func(b event.BaseEvent, p eventPayload) event.Event { return MovieCreatedEvent{p.movie(b)} },
+	MovieUpdatedEventType:      func(b event.BaseEvent, p eventPayload) event.Event { return MovieUpdatedEvent{p.movie(b)} },
+	MovieDeletedEventType:      func(b event.BaseEvent, p eventPayload) event.Event { return MovieDeletedEvent{p.movie(b)} },
+	MovieRestoredEventType:     func(b event.BaseEvent, p eventPayload) event.Event { return MovieRestoredEvent{p.movie(b)} },
+	MoviePurgedEventType:       func(b event.BaseEvent, p eventPayload) event.Event { return MoviePurgedEvent{p.movie(b)} },
+	GroupCreatedEventType:      func(b event.BaseEvent, p eventPayload) event.Event { return GroupCreatedEvent{p.group(b)} },
+	GroupUpdatedEventType:      func(b event.BaseEvent, p eventPayload) event.Event { return GroupUpdatedEvent{p.group(b)} },
+	GroupDeletedEventType:      func(b event.BaseEvent, p eventPayload) event.Event { return GroupDeletedEvent{p.group(b)} },
+	GroupRestoredEventType:     func(b event.BaseEvent, p eventPayload) event.Event { return GroupRestoredEvent{p.group(b)} },
+	GroupPurgedEventType:       func(b event.BaseEvent, p eventPayload) event.Event { return GroupPurgedEvent{p.group(b)} },
+	CategoryCreatedEventType:   func(b event.BaseEvent, p eventPayload) event.Event { return CategoryCreatedEvent{p.category(b)} },
+	CategoryUpdatedEventType:   func(b event.BaseEvent, p eventPayload) event.Event { return CategoryUpdatedEvent{p.category(b)} },
+	CategoryDeletedEventType:   func(b event.BaseEvent, p eventPayload) event.Event { return CategoryDeletedEvent{p.category(b)} },
+	CategoryRestoredEventType:  func(b event.BaseEvent, p eventPayload) event.Event { return CategoryRestoredEvent{p.category(b)} },
+	CategoryPurgedEventType:    func(b event.BaseEvent, p eventPayload) event.Event { return CategoryPurgedEvent{p.category(b)} },
+	TrackCreatedEventType:      func(b event.BaseEvent, p eventPayload) event.Event { return TrackCreatedEvent{p.track(b)} },
+	TrackUpdatedEventType:      func(b event.BaseEvent, p eventPayload) event.Event { return TrackUpdatedEvent{p.track(b)} },
+	TrackDeletedEventType:      func(b event.BaseEvent, p eventPayload) event.Event { return TrackDeletedEvent{p.track(b)} },
+	TrackRestoredEventType:     func(b event.BaseEvent, p eventPayload) event.Event { return TrackRestoredEvent{p.track(b)} },
+	TrackPurgedEventType:       func(b event.BaseEvent, p eventPayload) event.Event { return TrackPurgedEvent{p.track(b)} },
+	ThemeCreatedEventType:      func(b event.BaseEvent, p eventPayload) event.Event { return ThemeCreatedEvent{p.theme(b)} },
+	ThemeUpdatedEventType:      func(b event.BaseEvent, p eventPayload) event.Event { return ThemeUpdatedEvent{p.theme(b)} },
+	ThemeDeletedEventType:      func(b event.BaseEvent, p eventPayload) event.Event { return ThemeDeletedEvent{p.theme(b)} },
+	ThemeRestoredEventType:     func(b event.BaseEvent, p eventPayload) event.Event { return ThemeRestoredEvent{p.theme(b)} },
+	ThemePurgedEventType:       func(b event.BaseEvent, p eventPayload) event.Event { return ThemePurgedEvent{p.theme(b)} },
+	TrackThemeAddedEventType:   func(b event.BaseEvent, p eventPayload) event.Event { return TrackThemeAddedEvent{p.trackTheme(b)} },
+	TrackThemeUpdatedEventType: func(b event.BaseEvent, p eventPayload) event.Event { return TrackThemeUpdatedEvent{p.trackTheme(b)} },
+	TrackThemeRemovedEventType: func(b event.BaseEvent, p eventPayload) event.Event { return TrackThemeRemovedEvent{p.trackTheme(b)} },
+}
+
+// EventCodec implements event.Codec for the events of the domain.
+type EventCodec struct{}
+
+func (EventCodec) Encode(e event.Event) (json.RawMessage, error) {
+	p, ok := e.(payloader)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEvent, e.Type())
+	}
+
+	data, err := json.Marshal(p.payload())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event %s: %w", e.ID(), err)
+	}
+	return data, nil
+}
+
+func (EventCodec) Decode(base event.BaseEvent, eventType event.Type, payload json.RawMessage) (event.Event, error) {
+	decode, ok := eventDecoders[eventType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEvent, eventType)
+	}
+
+	var p eventPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, fmt.Errorf("failed to decode event %s: %w", base.ID(), err)
+	}
+	return decode(base, p), nil
+}
@@ -35,3 +35,573 @@ func (e UserCreatedEvent) UserName() string {
 func (e UserCreatedEvent) UserEmail() string {
 	return e.email
 }
+
+const VerificationRequestedEventType = "events.user.verification_requested"
+
+// VerificationRequestedEvent is a request for a new verification link, sent to the email
+// whether or not it belongs to a user. It is published before the email is looked up, so
+// its aggregate is the email itself.
+type VerificationRequestedEvent struct {
+	event.BaseEvent
+	email string
+}
+
+func NewVerificationRequestedEvent(email string) VerificationRequestedEvent {
+	return VerificationRequestedEvent{
+		email:     email,
+		BaseEvent: event.NewBaseEvent(email),
+	}
+}
+
+func (e VerificationRequestedEvent) Type() event.Type {
+	return VerificationRequestedEventType
+}
+
+func (e VerificationRequestedEvent) Email() string {
+	return e.email
+}
+
+const (
+	UserUpdatedEventType     = "events.user.updated"
+	UserRoleChangedEventType = "events.user.role_changed"
+	UserDeletedEventType     = "events.user.deleted"
+)
+
+// userEvent is the base of the events of users changed by an admin or by themselves. Its
+// aggregate ID is the ID of the user; it carries their name and email when the event
+// occurred, never their password.
+type userEvent struct {
+	event.BaseEvent
+	name  string
+	email string
+}
+
+func newUserEvent(id, name, email string) userEvent {
+	return userEvent{
+		BaseEvent: event.NewBaseEvent(id),
+		name:      name,
+		email:     email,
+	}
+}
+
+func (e userEvent) UserID() string {
+	return e.AggregateID()
+}
+
+func (e userEvent) UserName() string {
+	return e.name
+}
+
+func (e userEvent) UserEmail() string {
+	return e.email
+}
+
+// UserUpdatedEvent is published when the profile or the password of a user changes.
+type UserUpdatedEvent struct {
+	userEvent
+}
+
+func NewUserUpdatedEvent(id, name, email string) UserUpdatedEvent {
+	return UserUpdatedEvent{userEvent: newUserEvent(id, name, email)}
+}
+
+func (e UserUpdatedEvent) Type() event.Type {
+	return UserUpdatedEventType
+}
+
+type UserRoleChangedEvent struct {
+	userEvent
+	role string
+}
+
+func NewUserRoleChangedEvent(id, name, email, role string) UserRoleChangedEvent {
+	return UserRoleChangedEvent{userEvent: newUserEvent(id, name, email), role: role}
+}
+
+func (e UserRoleChangedEvent) Type() event.Type {
+	return UserRoleChangedEventType
+}
+
+// Role returns the name of the role the user was given.
+func (e UserRoleChangedEvent) Role() string {
+	return e.role
+}
+
+type UserDeletedEvent struct {
+	userEvent
+}
+
+func NewUserDeletedEvent(id, name, email string) UserDeletedEvent {
+	return UserDeletedEvent{userEvent: newUserEvent(id, name, email)}
+}
+
+func (e UserDeletedEvent) Type() event.Type {
+	return UserDeletedEventType
+}
+
+const (
+	MovieCreatedEventType      = "events.movie.created"
+	MovieUpdatedEventType      = "events.movie.updated"
+	MovieDeletedEventType      = "events.movie.deleted"
+	MovieRestoredEventType     = "events.movie.restored"
+	MoviePurgedEventType       = "events.movie.purged"
+	GroupCreatedEventType      = "events.group.created"
+	GroupUpdatedEventType      = "events.group.updated"
+	GroupDeletedEventType      = "events.group.deleted"
+	GroupRestoredEventType     = "events.group.restored"
+	GroupPurgedEventType       = "events.group.purged"
+	CategoryCreatedEventType   = "events.category.created"
+	CategoryUpdatedEventType   = "events.category.updated"
+	CategoryDeletedEventType   = "events.category.deleted"
+	CategoryRestoredEventType  = "events.category.restored"
+	CategoryPurgedEventType    = "events.category.purged"
+	TrackCreatedEventType      = "events.track.created"
+	TrackUpdatedEventType      = "events.track.updated"
+	TrackDeletedEventType      = "events.track.deleted"
+	TrackRestoredEventType     = "events.track.restored"
+	TrackPurgedEventType       = "events.track.purged"
+	ThemeCreatedEventType      = "events.theme.created"
+	ThemeUpdatedEventType      = "events.theme.updated"
+	ThemeDeletedEventType      = "events.theme.deleted"
+	ThemeRestoredEventType     = "events.theme.restored"
+	ThemePurgedEventType       = "events.theme.purged"
+	TrackThemeAddedEventType   = "events.track_theme.added"
+	TrackThemeUpdatedEventType = "events.track_theme.updated"
+	TrackThemeRemovedEventType = "events.track_theme.removed"
+)
+
+// The events of the catalogue carry the ID of the entity they are about as their
+// aggregate ID, along with its name. Subscribers that need more load the entity.
+
+// catalogueEvent is the base of the events of movies, groups, categories, tracks and
+// themes.
+type catalogueEvent struct {
+	event.BaseEvent
+	name string
+}
+
+func newCatalogueEvent(id, name string) catalogueEvent {
+	return catalogueEvent{
+		BaseEvent: event.NewBaseEvent(id),
+		name:      name,
+	}
+}
+
+// Name returns the name of the entity when the event occurred.
+func (e catalogueEvent) Name() string {
+	return e.name
+}
+
+type movieEvent struct {
+	catalogueEvent
+}
+
+func (e movieEvent) MovieID() string {
+	return e.AggregateID()
+}
+
+type groupEvent struct {
+	catalogueEvent
+}
+
+func (e groupEvent) GroupID() string {
+	return e.AggregateID()
+}
+
+type categoryEvent struct {
+	catalogueEvent
+}
+
+func (e categoryEvent) CategoryID() string {
+	return e.AggregateID()
+}
+
+type trackEvent struct {
+	catalogueEvent
+	movieID string
+}
+
+func (e trackEvent) TrackID() string {
+	return e.AggregateID()
+}
+
+func (e trackEvent) MovieID() string {
+	return e.movieID
+}
+
+type themeEvent struct {
+	catalogueEvent
+	groupID string
+}
+
+func (e themeEvent) ThemeID() string {
+	return e.AggregateID()
+}
+
+func (e themeEvent) GroupID() string {
+	return e.groupID
+}
+
+// trackThemeEvent is the base of the events of theme occurrences in tracks. Its aggregate
+// ID is the ID of the theme.
+type trackThemeEvent struct {
+	event.BaseEvent
+	trackID     string
+	startSecond int
+}
+
+func newTrackThemeEvent(trackID, themeID string, startSecond int) trackThemeEvent {
+	return trackThemeEvent{
+		BaseEvent:   event.NewBaseEvent(themeID),
+		trackID:     trackID,
+		startSecond: startSecond,
+	}
+}
+
+func (e trackThemeEvent) TrackID() string {
+	return e.trackID
+}
+
+func (e trackThemeEvent) ThemeID() string {
+	return e.AggregateID()
+}
+
+func (e trackThemeEvent) StartSecond() int {
+	return e.startSecond
+}
+
+type MovieCreatedEvent struct {
+	movieEvent
+}
+
+func NewMovieCreatedEvent(id, name string) MovieCreatedEvent {
+	return MovieCreatedEvent{movieEvent: movieEvent{catalogueEvent: newCatalogueEvent(id, name)}}
+}
+
+func (e MovieCreatedEvent) Type() event.Type {
+	return MovieCreatedEventType
+}
+
+type MovieUpdatedEvent struct {
+	movieEvent
+}
+
+func NewMovieUpdatedEvent(id, name string) MovieUpdatedEvent {
+	return MovieUpdatedEvent{movieEvent: movieEvent{catalogueEvent: newCatalogueEvent(id, name)}}
+}
+
+func (e MovieUpdatedEvent) Type() event.Type {
+	return MovieUpdatedEventType
+}
+
+type MovieDeletedEvent struct {
+	movieEvent
+}
+
+func NewMovieDeletedEvent(id, name string) MovieDeletedEvent {
+	return MovieDeletedEvent{movieEvent: movieEvent{catalogueEvent: newCatalogueEvent(id, name)}}
+}
+
+func (e MovieDeletedEvent) Type() event.Type {
+	return MovieDeletedEventType
+}
+
+type MovieRestoredEvent struct {
+	movieEvent
+}
+
+func NewMovieRestoredEvent(id, name string) MovieRestoredEvent {
+	return MovieRestoredEvent{movieEvent: movieEvent{catalogueEvent: newCatalogueEvent(id, name)}}
+}
+
+func (e MovieRestoredEvent) Type() event.Type {
+	return MovieRestoredEventType
+}
+
+type MoviePurgedEvent struct {
+	movieEvent
+}
+
+func NewMoviePurgedEvent(id, name string) MoviePurgedEvent {
+	return MoviePurgedEvent{movieEvent: movieEvent{catalogueEvent: newCatalogueEvent(id, name)}}
+}
+
+func (e MoviePurgedEvent) Type() event.Type {
+	return MoviePurgedEventType
+}
+
+type GroupCreatedEvent struct {
+	groupEvent
+}
+
+func NewGroupCreatedEvent(id, name string) GroupCreatedEvent {
+	return GroupCreatedEvent{groupEvent: groupEvent{catalogueEvent: newCatalogueEvent(id, name)}}
+}
+
+func (e GroupCreatedEvent) Type() event.Type {
+	return GroupCreatedEventType
+}
+
+type GroupUpdatedEvent struct {
+	groupEvent
+}
+
+func NewGroupUpdatedEvent(id, name string) GroupUpdatedEvent {
+	return GroupUpdatedEvent{groupEvent: groupEvent{catalogueEvent: newCatalogueEvent(id, name)}}
+}
+
+func (e GroupUpdatedEvent) Type() event.Type {
+	return GroupUpdatedEventType
+}
+
+type GroupDeletedEvent struct {
+	groupEvent
+}
+
+func NewGroupDeletedEvent(id, name string) GroupDeletedEvent {
+	return GroupDeletedEvent{groupEvent: groupEvent{catalogueEvent: newCatalogueEvent(id, name)}}
+}
+
+func (e GroupDeletedEvent) Type() event.Type {
+	return GroupDeletedEventType
+}
+
+type GroupRestoredEvent struct {
+	groupEvent
+}
+
+func NewGroupRestoredEvent(id, name string) GroupRestoredEvent {
+	return GroupRestoredEvent{groupEvent: groupEvent{catalogueEvent: newCatalogueEvent(id, name)}}
+}
+
+func (e GroupRestoredEvent) Type() event.Type {
+	return GroupRestoredEventType
+}
+
+type GroupPurgedEvent struct {
+	groupEvent
+}
+
+func NewGroupPurgedEvent(id, name string) GroupPurgedEvent {
+	return GroupPurgedEvent{groupEvent: groupEvent{catalogueEvent: newCatalogueEvent(id, name)}}
+}
+
+func (e GroupPurgedEvent) Type() event.Type {
+	return GroupPurgedEventType
+}
+
+type CategoryCreatedEvent struct {
+	categoryEvent
+}
+
+func NewCategoryCreatedEvent(id, name string) CategoryCreatedEvent {
+	return CategoryCreatedEvent{categoryEvent: categoryEvent{catalogueEvent: newCatalogueEvent(id, name)}}
+}
+
+func (e CategoryCreatedEvent) Type() event.Type {
+	return CategoryCreatedEventType
+}
+
+type CategoryUpdatedEvent struct {
+	categoryEvent
+}
+
+func NewCategoryUpdatedEvent(id, name string) CategoryUpdatedEvent {
+	return CategoryUpdatedEvent{categoryEvent: categoryEvent{catalogueEvent: newCatalogueEvent(id, name)}}
+}
+
+func (e CategoryUpdatedEvent) Type() event.Type {
+	return CategoryUpdatedEventType
+}
+
+type CategoryDeletedEvent struct {
+	categoryEvent
+}
+
+func NewCategoryDeletedEvent(id, name string) CategoryDeletedEvent {
+	return CategoryDeletedEvent{categoryEvent: categoryEvent{catalogueEvent: newCatalogueEvent(id, name)}}
+}
+
+func (e CategoryDeletedEvent) Type() event.Type {
+	return CategoryDeletedEventType
+}
+
+type CategoryRestoredEvent struct {
+	categoryEvent
+}
+
+func NewCategoryRestoredEvent(id, name string) CategoryRestoredEvent {
+	return CategoryRestoredEvent{categoryEvent: categoryEvent{catalogueEvent: newCatalogueEvent(id, name)}}
+}
+
+func (e CategoryRestoredEvent) Type() event.Type {
+	return CategoryRestoredEventType
+}
+
+type CategoryPurgedEvent struct {
+	categoryEvent
+}
+
+func NewCategoryPurgedEvent(id, name string) CategoryPurgedEvent {
+	return CategoryPurgedEvent{categoryEvent: categoryEvent{catalogueEvent: newCatalogueEvent(id, name)}}
+}
+
+func (e CategoryPurgedEvent) Type() event.Type {
+	return CategoryPurgedEventType
+}
+
+type TrackCreatedEvent struct {
+	trackEvent
+}
+
+func NewTrackCreatedEvent(id, name, movieID string) TrackCreatedEvent {
+	return TrackCreatedEvent{trackEvent: trackEvent{catalogueEvent: newCatalogueEvent(id, name), movieID: movieID}}
+}
+
+func (e TrackCreatedEvent) Type() event.Type {
+	return TrackCreatedEventType
+}
+
+type TrackUpdatedEvent struct {
+	trackEvent
+}
+
+func NewTrackUpdatedEvent(id, name, movieID string) TrackUpdatedEvent {
+	return TrackUpdatedEvent{trackEvent: trackEvent{catalogueEvent: newCatalogueEvent(id, name), movieID: movieID}}
+}
+
+func (e TrackUpdatedEvent) Type() event.Type {
+	return TrackUpdatedEventType
+}
+
+type TrackDeletedEvent struct {
+	trackEvent
+}
+
+func NewTrackDeletedEvent(id, name, movieID string) TrackDeletedEvent {
+	return TrackDeletedEvent{trackEvent: trackEvent{catalogueEvent: newCatalogueEvent(id, name), movieID: movieID}}
+}
+
+func (e TrackDeletedEvent) Type() event.Type {
+	return TrackDeletedEventType
+}
+
+type TrackRestoredEvent struct {
+	trackEvent
+}
+
+func NewTrackRestoredEvent(id, name, movieID string) TrackRestoredEvent {
+	return TrackRestoredEvent{trackEvent: trackEvent{catalogueEvent: newCatalogueEvent(id, name), movieID: movieID}}
+}
+
+func (e TrackRestoredEvent) Type() event.Type {
+	return TrackRestoredEventType
+}
+
+type TrackPurgedEvent struct {
+	trackEvent
+}
+
+func NewTrackPurgedEvent(id, name, movieID string) TrackPurgedEvent {
+	return TrackPurgedEvent{trackEvent: trackEvent{catalogueEvent: newCatalogueEvent(id, name), movieID: movieID}}
+}
+
+func (e TrackPurgedEvent) Type() event.Type {
+	return TrackPurgedEventType
+}
+
+type ThemeCreatedEvent struct {
+	themeEvent
+}
+
+func NewThemeCreatedEvent(id, name, groupID string) ThemeCreatedEvent {
+	return ThemeCreatedEvent{themeEvent: themeEvent{catalogueEvent: newCatalogueEvent(id, name), groupID: groupID}}
+}
+
+func (e ThemeCreatedEvent) Type() event.Type {
+	return ThemeCreatedEventType
+}
+
+type ThemeUpdatedEvent struct {
+	themeEvent
+}
+
+func NewThemeUpdatedEvent(id, name, groupID string) ThemeUpdatedEvent {
+	return ThemeUpdatedEvent{themeEvent: themeEvent{catalogueEvent: newCatalogueEvent(id, name), groupID: groupID}}
+}
+
+func (e ThemeUpdatedEvent) Type() event.Type {
+	return ThemeUpdatedEventType
+}
+
+type ThemeDeletedEvent struct {
+	themeEvent
+}
+
+func NewThemeDeletedEvent(id, name, groupID string) ThemeDeletedEvent {
+	return ThemeDeletedEvent{themeEvent: themeEvent{catalogueEvent: newCatalogueEvent(id, name), groupID: groupID}}
+}
+
+func (e ThemeDeletedEvent) Type() event.Type {
+	return ThemeDeletedEventType
+}
+
+type ThemeRestoredEvent struct {
+	themeEvent
+}
+
+func NewThemeRestoredEvent(id, name, groupID string) ThemeRestoredEvent {
+	return ThemeRestoredEvent{themeEvent: themeEvent{catalogueEvent: newCatalogueEvent(id, name), groupID: groupID}}
+}
+
+func (e ThemeRestoredEvent) Type() event.Type {
+	return ThemeRestoredEventType
+}
+
+type ThemePurgedEvent struct {
+	themeEvent
+}
+
+func NewThemePurgedEvent(id, name, groupID string) ThemePurgedEvent {
+	return ThemePurgedEvent{themeEvent: themeEvent{catalogueEvent: newCatalogueEvent(id, name), groupID: groupID}}
+}
+
+func (e ThemePurgedEvent) Type() event.Type {
+	return ThemePurgedEventType
+}
+
+type TrackThemeAddedEvent struct {
+	trackThemeEvent
+}
+
+func NewTrackThemeAddedEvent(trackID, themeID string, startSecond int) TrackThemeAddedEvent {
+	return TrackThemeAddedEvent{trackThemeEvent: newTrackThemeEvent(trackID, themeID, startSecond)}
+}
+
+func (e TrackThemeAddedEvent) Type() event.Type {
+	return TrackThemeAddedEventType
+}
+
+type TrackThemeUpdatedEvent struct {
+	trackThemeEvent
+}
+
+func NewTrackThemeUpdatedEvent(trackID, themeID string, startSecond int) TrackThemeUpdatedEvent {
+	return TrackThemeUpdatedEvent{trackThemeEvent: newTrackThemeEvent(trackID, themeID, startSecond)}
+}
+
+func (e TrackThemeUpdatedEvent) Type() event.Type {
+	return TrackThemeUpdatedEventType
+}
+
+type TrackThemeRemovedEvent struct {
+	trackThemeEvent
+}
+
+func NewTrackThemeRemovedEvent(trackID, themeID string, startSecond int) TrackThemeRemovedEvent {
+	return TrackThemeRemovedEvent{trackThemeEvent: newTrackThemeEvent(trackID, themeID, startSecond)}
+}
+
+func (e TrackThemeRemovedEvent) Type() event.Type {
+	return TrackThemeRemovedEventType
+}
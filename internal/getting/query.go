@@ -7,6 +7,7 @@ import (
 )
 
 const (
+	UsersQueryType      = "query.getting.users"
 	MoviesQueryType     = "query.getting.movies"
 	GroupsQueryType     = "query.getting.groups"
 	CategoriesQueryType = "query.getting.categories"
@@ -14,6 +15,39 @@ const (
 	ThemesQueryType     = "query.getting.themes"
 )
 
+type UsersQuery struct {
+	ID string
+}
+
+func NewUsersQuery(id string) UsersQuery {
+	return UsersQuery{
+		ID: id,
+	}
+}
+
+func (q UsersQuery) Type() query.Type {
+	return UsersQueryType
+}
+
+type UsersQueryHandler struct {
+	userService UserService
+}
+
+func NewUsersQueryHandler(userService UserService) UsersQueryHandler {
+	return UsersQueryHandler{
+		userService: userService,
+	}
+}
+
+func (h UsersQueryHandler) Handle(ctx context.Context, query query.Query) (any, error) {
+	userQuery, ok := query.(UsersQuery)
+	if !ok {
+		return nil, nil
+	}
+
+	return h.userService.GetUser(ctx, userQuery.ID)
+}
+
 type MoviesQuery struct {
 	ID string
 }
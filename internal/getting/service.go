@@ -7,6 +7,30 @@ import (
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
 )
 
+type UserService struct {
+	userRepository domain.UserRepository
+}
+
+func NewUserService(userRepository domain.UserRepository) UserService {
+	return UserService{
+		userRepository: userRepository,
+	}
+}
+
+func (s UserService) GetUser(ctx context.Context, id string) (dto.UserResponse, error) {
+	userID, err := domain.NewUserIDFromString(id)
+	if err != nil {
+		return dto.UserResponse{}, err
+	}
+
+	user, err := s.userRepository.Find(ctx, userID)
+	if err != nil {
+		return dto.UserResponse{}, err
+	}
+
+	return dto.NewUserResponse(user), nil
+}
+
 type MovieService struct {
 	movieRepository domain.MovieRepository
 }
@@ -160,3 +184,28 @@ func (s ThemeService) GetTheme(ctx context.Context, id string) (dto.ThemeRespons
 
 	return dto.NewThemeResponse(theme, trackDTO, groupDTO, categoryDTO), nil
 }
+
+type WebhookService struct {
+	webhookRepository domain.WebhookRepository
+}
+
+func NewWebhookService(webhookRepository domain.WebhookRepository) WebhookService {
+	return WebhookService{
+		webhookRepository: webhookRepository,
+	}
+}
+
+// GetWebhook returns a webhook without its secret.
+func (s WebhookService) GetWebhook(ctx context.Context, id string) (dto.WebhookResponse, error) {
+	webhookID, err := domain.NewWebhookIDFromString(id)
+	if err != nil {
+		return dto.WebhookResponse{}, err
+	}
+
+	webhook, err := s.webhookRepository.Find(ctx, webhookID)
+	if err != nil {
+		return dto.WebhookResponse{}, err
+	}
+
+	return dto.NewWebhookResponse(webhook), nil
+}
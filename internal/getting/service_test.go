@@ -52,7 +52,7 @@ func TestMovieServiceGetMovieInvalidID(t *testing.T) {
 
 func TestMovieServiceGetMovieSuccess(t *testing.T) {
 	movieRepositoryMock := new(storagemocks.MovieRepository)
-	movie, err := domain.NewMovie(movieName)
+	movie, err := domain.NewMovie(movieName, 2001, 1, domain.MovieSeriesLordOfTheRings, nil)
 	assert.NoError(t, err)
 
 	movieRepositoryMock.On("Find", mock.Anything, movie.ID()).Return(movie, nil).Once()
@@ -206,7 +206,7 @@ func TestTrackServiceGetTrackInvalidID(t *testing.T) {
 
 func TestTrackServiceGetTrackMovieRepositoryError(t *testing.T) {
 	trackRepositoryMock := new(storagemocks.TrackRepository)
-	track, err := domain.NewTrack(trackName, exampleUUID, nil)
+	track, err := domain.NewTrack(trackName, exampleUUID, nil, 1, 1, domain.TrackEditionTheatrical, 180)
 	assert.NoError(t, err)
 
 	trackRepositoryMock.On("Find", mock.Anything, track.ID()).Return(track, nil).Once()
@@ -226,14 +226,14 @@ func TestTrackServiceGetTrackMovieRepositoryError(t *testing.T) {
 
 func TestTrackServiceGetTrackSuccess(t *testing.T) {
 	trackRepositoryMock := new(storagemocks.TrackRepository)
-	track, err := domain.NewTrack(trackName, exampleUUID, nil)
+	track, err := domain.NewTrack(trackName, exampleUUID, nil, 1, 1, domain.TrackEditionTheatrical, 180)
 	assert.NoError(t, err)
 
 	trackRepositoryMock.On("Find", mock.Anything, track.ID()).Return(track, nil).Once()
 	defer trackRepositoryMock.AssertExpectations(t)
 
 	movieRepositoryMock := new(storagemocks.MovieRepository)
-	movie, err := domain.NewMovieWithID(exampleUUID, movieName)
+	movie, err := domain.NewMovieWithID(exampleUUID, movieName, 2001, 1, domain.MovieSeriesLordOfTheRings, nil)
 	assert.NoError(t, err)
 	movieRepositoryMock.On("Find", mock.Anything, movie.ID()).Return(movie, nil).Once()
 	defer movieRepositoryMock.AssertExpectations(t)
@@ -302,7 +302,7 @@ func TestThemeServiceGetThemeSuccess(t *testing.T) {
 	movieRepositoryMock := new(storagemocks.MovieRepository)
 	movieService := NewMovieService(movieRepositoryMock)
 
-	track, err := domain.NewTrack(trackName, exampleUUID, nil)
+	track, err := domain.NewTrack(trackName, exampleUUID, nil, 1, 1, domain.TrackEditionTheatrical, 180)
 	assert.NoError(t, err)
 
 	trackRepositoryMock := new(storagemocks.TrackRepository)
@@ -330,3 +330,46 @@ func TestThemeServiceGetThemeSuccess(t *testing.T) {
 	assert.Equal(t, "The Bridge of Khazad-dûm", result.Name)
 	assert.Equal(t, trackName, result.FirstHeard.Name)
 }
+
+func TestUserServiceGetUserNotFound(t *testing.T) {
+	userRepositoryMock := new(storagemocks.UserRepository)
+	userRepositoryMock.On("Find", mock.Anything, mock.Anything).Return(domain.User{}, domain.ErrUserNotFound)
+	defer userRepositoryMock.AssertExpectations(t)
+
+	userService := NewUserService(userRepositoryMock)
+
+	_, err := userService.GetUser(context.Background(), exampleUUID)
+	assert.Equal(t, domain.ErrUserNotFound, err)
+}
+
+func TestUserServiceGetUserSuccess(t *testing.T) {
+	user, err := domain.NewUser("Peregrin Took", "pippin@shire.me", "hashed-password", domain.RoleEditor)
+	assert.NoError(t, err)
+
+	userRepositoryMock := new(storagemocks.UserRepository)
+	userRepositoryMock.On("Find", mock.Anything, user.ID()).Return(user, nil).Once()
+	defer userRepositoryMock.AssertExpectations(t)
+
+	userService := NewUserService(userRepositoryMock)
+
+	result, err := userService.GetUser(context.Background(), user.ID().String())
+	assert.NoError(t, err)
+	assert.Equal(t, "pippin@shire.me", result.Email)
+	assert.Equal(t, domain.RoleEditor, result.Role)
+}
+
+func TestWebhookServiceGetWebhookSuccess(t *testing.T) {
+	webhook, err := domain.NewWebhook("https://example.com/hooks", "a-secret-of-16-chars", []string{string(domain.MovieCreatedEventType)})
+	assert.NoError(t, err)
+
+	webhookRepositoryMock := new(storagemocks.WebhookRepository)
+	webhookRepositoryMock.On("Find", mock.Anything, webhook.ID()).Return(webhook, nil).Once()
+	defer webhookRepositoryMock.AssertExpectations(t)
+
+	webhookService := NewWebhookService(webhookRepositoryMock)
+
+	result, err := webhookService.GetWebhook(context.Background(), webhook.ID().String())
+	assert.NoError(t, err)
+	assert.Equal(t, webhook.ID().String(), result.ID)
+	assert.Equal(t, "https://example.com/hooks", result.URL)
+}
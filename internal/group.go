@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+
 	"github.com/google/uuid"
 )
 
@@ -90,8 +92,12 @@ func (u ImageURL) String() string {
 type GroupRepository interface {
 	Save(ctx context.Context, group Group) error
 	Find(ctx context.Context, id GroupID) (Group, error)
+	FindInTrash(ctx context.Context, id GroupID) (Group, error) // Finds the group only if it is in the trash
 	FindAll(ctx context.Context) ([]Group, error)
-	Delete(ctx context.Context, id GroupID) error
+	FindPage(ctx context.Context, page PageRequest) ([]Group, *Cursor, error)
+	Delete(ctx context.Context, id GroupID) error  // Moves the group to the trash
+	Restore(ctx context.Context, id GroupID) error // Takes the group out of the trash
+	Purge(ctx context.Context, id GroupID) error   // Deletes the group for good, once in the trash
 	Update(ctx context.Context, group Group) error
 }
 
@@ -102,6 +108,8 @@ type Group struct {
 	name        GroupName
 	description GroupDescription
 	imageURL    ImageURL
+
+	events []event.Event
 }
 
 func NewGroup(name, description, imageURL string) (Group, error) {
@@ -132,6 +140,8 @@ func NewGroup(name, description, imageURL string) (Group, error) {
 		imageURL:    imageURLVO,
 	}
 
+	group.Record(NewGroupCreatedEvent(group.ID().String(), group.Name().String()))
+
 	return group, nil
 }
 
@@ -178,6 +188,30 @@ func (g Group) Description() GroupDescription {
 	return g.description
 }
 
+// WithDescription returns a copy of the group with another description.
+func (g Group) WithDescription(description string) (Group, error) {
+	descriptionVO, err := NewGroupDescription(description)
+	if err != nil {
+		return Group{}, err
+	}
+
+	g.description = descriptionVO
+	return g, nil
+}
+
 func (g Group) ImageURL() ImageURL {
 	return g.imageURL
 }
+
+// Record adds an event to the group's event list.
+func (g *Group) Record(event event.Event) {
+	g.events = append(g.events, event)
+}
+
+// PullEvents returns the events recorded for the group and clears the event list.
+func (g *Group) PullEvents() []event.Event {
+	events := g.events
+	g.events = nil
+
+	return events
+}
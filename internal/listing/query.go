@@ -2,6 +2,8 @@ package listing
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/query"
 )
@@ -16,12 +18,23 @@ const (
 	ThemesQueryType              = "query.listing.themes"
 	ThemesByGroupQueryType       = "query.listing.themes.by_group"
 	TracksThemesByTrackQueryType = "query.listing.track_themes.by_track"
+	TracksThemesByThemeQueryType = "query.listing.track_themes.by_theme"
+	TrashQueryType               = "query.listing.trash"
+	AuditQueryType               = "query.listing.audit"
+	WebhooksQueryType            = "query.listing.webhooks"
+	WebhookDeliveriesQueryType   = "query.listing.webhook_deliveries"
 )
 
-type UsersQuery struct{}
+type UsersQuery struct {
+	Limit  int
+	Cursor string
+}
 
-func NewUsersQuery() UsersQuery {
-	return UsersQuery{}
+func NewUsersQuery(limit int, cursor string) UsersQuery {
+	return UsersQuery{
+		Limit:  limit,
+		Cursor: cursor,
+	}
 }
 
 func (q UsersQuery) Type() query.Type {
@@ -39,18 +52,26 @@ func NewUsersQueryHandler(userService UserService) UsersQueryHandler {
 }
 
 func (h UsersQueryHandler) Handle(ctx context.Context, query query.Query) (any, error) {
-	_, ok := query.(UsersQuery)
+	q, ok := query.(UsersQuery)
 	if !ok {
 		return nil, nil
 	}
 
-	return h.userService.ListUsers(ctx)
+	return h.userService.ListUsers(ctx, q.Limit, q.Cursor)
 }
 
-type MoviesQuery struct{}
+type MoviesQuery struct {
+	Limit  int
+	Cursor string
+	Series string
+}
 
-func NewMoviesQuery() MoviesQuery {
-	return MoviesQuery{}
+func NewMoviesQuery(limit int, cursor string, series string) MoviesQuery {
+	return MoviesQuery{
+		Limit:  limit,
+		Cursor: cursor,
+		Series: series,
+	}
 }
 
 func (q MoviesQuery) Type() query.Type {
@@ -68,18 +89,24 @@ func NewMoviesQueryHandler(movieService MovieService) MoviesQueryHandler {
 }
 
 func (h MoviesQueryHandler) Handle(ctx context.Context, query query.Query) (any, error) {
-	_, ok := query.(MoviesQuery)
+	q, ok := query.(MoviesQuery)
 	if !ok {
 		return nil, nil
 	}
 
-	return h.movieService.ListMovies(ctx)
+	return h.movieService.ListMovies(ctx, q.Limit, q.Cursor, q.Series)
 }
 
-type GroupsQuery struct{}
+type GroupsQuery struct {
+	Limit  int
+	Cursor string
+}
 
-func NewGroupsQuery() GroupsQuery {
-	return GroupsQuery{}
+func NewGroupsQuery(limit int, cursor string) GroupsQuery {
+	return GroupsQuery{
+		Limit:  limit,
+		Cursor: cursor,
+	}
 }
 
 func (q GroupsQuery) Type() query.Type {
@@ -97,18 +124,24 @@ func NewGroupsQueryHandler(groupService GroupService) GroupsQueryHandler {
 }
 
 func (h GroupsQueryHandler) Handle(ctx context.Context, query query.Query) (any, error) {
-	_, ok := query.(GroupsQuery)
+	q, ok := query.(GroupsQuery)
 	if !ok {
 		return nil, nil
 	}
 
-	return h.groupService.ListGroups(ctx)
+	return h.groupService.ListGroups(ctx, q.Limit, q.Cursor)
 }
 
-type CategoriesQuery struct{}
+type CategoriesQuery struct {
+	Limit  int
+	Cursor string
+}
 
-func NewCategoriesQuery() CategoriesQuery {
-	return CategoriesQuery{}
+func NewCategoriesQuery(limit int, cursor string) CategoriesQuery {
+	return CategoriesQuery{
+		Limit:  limit,
+		Cursor: cursor,
+	}
 }
 
 func (q CategoriesQuery) Type() query.Type {
@@ -126,18 +159,24 @@ func NewCategoriesQueryHandler(categoryService CategoryService) CategoriesQueryH
 }
 
 func (h CategoriesQueryHandler) Handle(ctx context.Context, query query.Query) (any, error) {
-	_, ok := query.(CategoriesQuery)
+	q, ok := query.(CategoriesQuery)
 	if !ok {
 		return nil, nil
 	}
 
-	return h.categoryService.ListCategories(ctx)
+	return h.categoryService.ListCategories(ctx, q.Limit, q.Cursor)
 }
 
-type TracksQuery struct{}
+type TracksQuery struct {
+	Limit  int
+	Cursor string
+}
 
-func NewTracksQuery() TracksQuery {
-	return TracksQuery{}
+func NewTracksQuery(limit int, cursor string) TracksQuery {
+	return TracksQuery{
+		Limit:  limit,
+		Cursor: cursor,
+	}
 }
 
 func (q TracksQuery) Type() query.Type {
@@ -155,12 +194,12 @@ func NewTracksQueryHandler(trackService TrackService) TracksQueryHandler {
 }
 
 func (h TracksQueryHandler) Handle(ctx context.Context, query query.Query) (any, error) {
-	_, ok := query.(TracksQuery)
+	q, ok := query.(TracksQuery)
 	if !ok {
 		return nil, nil
 	}
 
-	return h.trackService.ListTracks(ctx)
+	return h.trackService.ListTracks(ctx, q.Limit, q.Cursor)
 }
 
 type TracksByMovieQuery struct {
@@ -196,10 +235,54 @@ func (h TracksByMovieQueryHandler) Handle(ctx context.Context, query query.Query
 	return h.trackService.ListTracksByMovie(ctx, q.MovieID)
 }
 
-type ThemesQuery struct{}
+var ErrUnknownQueryField = errors.New("unknown query field")
+
+// themesQueryFields are the query string fields a ThemesQuery can be built from.
+var themesQueryFields = map[string]struct{}{
+	"limit":       {},
+	"cursor":      {},
+	"group_id":    {},
+	"category_id": {},
+	"movie_id":    {},
+	"name":        {},
+	"sort":        {},
+}
+
+// ThemesFilter holds the optional criteria of a ThemesQuery, as sent by the client.
+type ThemesFilter struct {
+	GroupID    string
+	CategoryID string
+	MovieID    string
+	Name       string
+	Sort       string
+}
+
+type ThemesQuery struct {
+	Limit  int
+	Cursor string
+	Filter ThemesFilter
+	// Fields are the names of the query string fields sent by the client.
+	Fields []string
+}
 
-func NewThemesQuery() ThemesQuery {
-	return ThemesQuery{}
+func NewThemesQuery(limit int, cursor string, filter ThemesFilter, fields []string) ThemesQuery {
+	return ThemesQuery{
+		Limit:  limit,
+		Cursor: cursor,
+		Filter: filter,
+		Fields: fields,
+	}
+}
+
+// Validate rejects the query when the client sent a field it does not understand, so a
+// misspelled filter is not silently ignored.
+func (q ThemesQuery) Validate() error {
+	for _, field := range q.Fields {
+		if _, ok := themesQueryFields[field]; !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownQueryField, field)
+		}
+	}
+	return nil
 }
 
 func (q ThemesQuery) Type() query.Type {
@@ -217,12 +300,16 @@ func NewThemesQueryHandler(themeService ThemeService) ThemesQueryHandler {
 }
 
 func (h ThemesQueryHandler) Handle(ctx context.Context, query query.Query) (any, error) {
-	_, ok := query.(ThemesQuery)
+	q, ok := query.(ThemesQuery)
 	if !ok {
 		return nil, nil
 	}
 
-	return h.themeService.ListThemes(ctx)
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+
+	return h.themeService.ListThemes(ctx, q.Limit, q.Cursor, q.Filter)
 }
 
 type ThemesByGroupQuery struct {
@@ -290,3 +377,177 @@ func (h TracksThemesByTrackQueryHandler) Handle(ctx context.Context, query query
 
 	return h.trackThemeService.ListTracksThemesByTrack(ctx, q.TrackID)
 }
+
+type TracksThemesByThemeQuery struct {
+	ThemeID string
+}
+
+func NewTracksThemesByThemeQuery(themeID string) TracksThemesByThemeQuery {
+	return TracksThemesByThemeQuery{
+		ThemeID: themeID,
+	}
+}
+
+func (q TracksThemesByThemeQuery) Type() query.Type {
+	return TracksThemesByThemeQueryType
+}
+
+type TracksThemesByThemeQueryHandler struct {
+	trackThemeService TrackThemeService
+}
+
+func NewTracksThemesByThemeQueryHandler(trackThemeService TrackThemeService) TracksThemesByThemeQueryHandler {
+	return TracksThemesByThemeQueryHandler{
+		trackThemeService: trackThemeService,
+	}
+}
+
+func (h TracksThemesByThemeQueryHandler) Handle(ctx context.Context, query query.Query) (any, error) {
+	q, ok := query.(TracksThemesByThemeQuery)
+	if !ok {
+		return nil, nil
+	}
+
+	return h.trackThemeService.ListTracksThemesByTheme(ctx, q.ThemeID)
+}
+
+type TrashQuery struct {
+	Limit  int
+	Cursor string
+}
+
+func NewTrashQuery(limit int, cursor string) TrashQuery {
+	return TrashQuery{
+		Limit:  limit,
+		Cursor: cursor,
+	}
+}
+
+func (q TrashQuery) Type() query.Type {
+	return TrashQueryType
+}
+
+type TrashQueryHandler struct {
+	trashService TrashService
+}
+
+func NewTrashQueryHandler(trashService TrashService) TrashQueryHandler {
+	return TrashQueryHandler{
+		trashService: trashService,
+	}
+}
+
+func (h TrashQueryHandler) Handle(ctx context.Context, query query.Query) (any, error) {
+	q, ok := query.(TrashQuery)
+	if !ok {
+		return nil, nil
+	}
+
+	return h.trashService.ListTrash(ctx, q.Limit, q.Cursor)
+}
+
+type AuditQuery struct {
+	Limit  int
+	Cursor string
+	Entity string
+	UserID string
+	Since  string
+}
+
+func NewAuditQuery(limit int, cursor string, entity, userID, since string) AuditQuery {
+	return AuditQuery{
+		Limit:  limit,
+		Cursor: cursor,
+		Entity: entity,
+		UserID: userID,
+		Since:  since,
+	}
+}
+
+func (q AuditQuery) Type() query.Type {
+	return AuditQueryType
+}
+
+type AuditQueryHandler struct {
+	auditService AuditService
+}
+
+func NewAuditQueryHandler(auditService AuditService) AuditQueryHandler {
+	return AuditQueryHandler{
+		auditService: auditService,
+	}
+}
+
+func (h AuditQueryHandler) Handle(ctx context.Context, query query.Query) (any, error) {
+	q, ok := query.(AuditQuery)
+	if !ok {
+		return nil, nil
+	}
+
+	return h.auditService.ListAudit(ctx, q.Limit, q.Cursor, q.Entity, q.UserID, q.Since)
+}
+
+type WebhooksQuery struct{}
+
+func NewWebhooksQuery() WebhooksQuery {
+	return WebhooksQuery{}
+}
+
+func (q WebhooksQuery) Type() query.Type {
+	return WebhooksQueryType
+}
+
+type WebhooksQueryHandler struct {
+	webhookService WebhookService
+}
+
+func NewWebhooksQueryHandler(webhookService WebhookService) WebhooksQueryHandler {
+	return WebhooksQueryHandler{
+		webhookService: webhookService,
+	}
+}
+
+func (h WebhooksQueryHandler) Handle(ctx context.Context, query query.Query) (any, error) {
+	if _, ok := query.(WebhooksQuery); !ok {
+		return nil, nil
+	}
+
+	return h.webhookService.ListWebhooks(ctx)
+}
+
+type WebhookDeliveriesQuery struct {
+	WebhookID string
+	Limit     int
+	Cursor    string
+}
+
+func NewWebhookDeliveriesQuery(webhookID string, limit int, cursor string) WebhookDeliveriesQuery {
+	return WebhookDeliveriesQuery{
+		WebhookID: webhookID,
+		Limit:     limit,
+		Cursor:    cursor,
+	}
+}
+
+func (q WebhookDeliveriesQuery) Type() query.Type {
+	return WebhookDeliveriesQueryType
+}
+
+type WebhookDeliveriesQueryHandler struct {
+	webhookService WebhookService
+}
+
+func NewWebhookDeliveriesQueryHandler(webhookService WebhookService) WebhookDeliveriesQueryHandler {
+	return WebhookDeliveriesQueryHandler{
+		webhookService: webhookService,
+	}
+}
+
+func (h WebhookDeliveriesQueryHandler) Handle(ctx context.Context, query query.Query) (any, error) {
+	q, ok := query.(WebhookDeliveriesQuery)
+	if !ok {
+		return nil, nil
+	}
+
+	return h.webhookService.ListDeliveries(ctx, q.WebhookID, q.Limit, q.Cursor)
+}
@@ -0,0 +1,41 @@
+package listing
+
+import (
+	"context"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
+)
+
+// TrackViewRepository reads tracks already joined with their movie.
+type TrackViewRepository interface {
+	FindPage(ctx context.Context, page domain.PageRequest) ([]dto.TrackResponse, *domain.Cursor, error)
+	FindByMovie(ctx context.Context, movieID domain.MovieID) ([]dto.TrackResponse, error)
+}
+
+//go:generate mockery --case=snake --outpkg=storagemocks --output=../platform/storage/storagemocks --name=TrackViewRepository
+
+// ThemeViewRepository reads themes already joined with their group, category,
+// first heard track and the movie of that track.
+type ThemeViewRepository interface {
+	FindPage(ctx context.Context, filter domain.ThemeFilter, page domain.PageRequest) ([]dto.ThemeResponse, *domain.Cursor, error)
+	FindByGroup(ctx context.Context, groupID domain.GroupID) ([]dto.ThemeResponse, error)
+}
+
+//go:generate mockery --case=snake --outpkg=storagemocks --output=../platform/storage/storagemocks --name=ThemeViewRepository
+
+// TrackThemeViewRepository reads track themes already joined with their track and theme.
+type TrackThemeViewRepository interface {
+	FindByTrack(ctx context.Context, trackID domain.TrackID) ([]dto.TrackThemeResponse, error)
+	FindByTheme(ctx context.Context, themeID domain.ThemeID) ([]dto.TrackThemeResponse, error)
+}
+
+//go:generate mockery --case=snake --outpkg=storagemocks --output=../platform/storage/storagemocks --name=TrackThemeViewRepository
+
+// TrashRepository reads the movies, groups, categories, tracks and themes in the trash,
+// most recently deleted first.
+type TrashRepository interface {
+	FindPage(ctx context.Context, page domain.PageRequest) ([]dto.TrashItem, *domain.Cursor, error)
+}
+
+//go:generate mockery --case=snake --outpkg=storagemocks --output=../platform/storage/storagemocks --name=TrashRepository
@@ -5,7 +5,6 @@ import (
 
 	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
-	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/getting"
 )
 
 type UserService struct {
@@ -18,10 +17,15 @@ func NewUserService(userRepository domain.UserRepository) UserService {
 	}
 }
 
-func (s UserService) ListUsers(ctx context.Context) ([]dto.UserResponse, error) {
-	users, err := s.userRepository.FindAll(ctx)
+func (s UserService) ListUsers(ctx context.Context, limit int, cursor string) (dto.PageResponse[dto.UserResponse], error) {
+	page, err := domain.NewPageRequest(limit, cursor)
 	if err != nil {
-		return []dto.UserResponse{}, err
+		return dto.PageResponse[dto.UserResponse]{}, err
+	}
+
+	users, next, err := s.userRepository.FindPage(ctx, page)
+	if err != nil {
+		return dto.PageResponse[dto.UserResponse]{}, err
 	}
 
 	userResponses := make([]dto.UserResponse, 0, len(users))
@@ -29,7 +33,7 @@ func (s UserService) ListUsers(ctx context.Context) ([]dto.UserResponse, error)
 		userResponses = append(userResponses, dto.NewUserResponse(user))
 	}
 
-	return userResponses, nil
+	return dto.NewPageResponse(userResponses, next), nil
 }
 
 type MovieService struct {
@@ -42,10 +46,20 @@ func NewMovieService(movieRepository domain.MovieRepository) MovieService {
 	}
 }
 
-func (s MovieService) ListMovies(ctx context.Context) ([]dto.MovieResponse, error) {
-	movies, err := s.movieRepository.FindAll(ctx)
+func (s MovieService) ListMovies(ctx context.Context, limit int, cursor string, series string) (dto.PageResponse[dto.MovieResponse], error) {
+	page, err := domain.NewPageRequest(limit, cursor)
 	if err != nil {
-		return []dto.MovieResponse{}, err
+		return dto.PageResponse[dto.MovieResponse]{}, err
+	}
+
+	filter, err := domain.NewMovieFilter(series)
+	if err != nil {
+		return dto.PageResponse[dto.MovieResponse]{}, err
+	}
+
+	movies, next, err := s.movieRepository.FindPage(ctx, filter, page)
+	if err != nil {
+		return dto.PageResponse[dto.MovieResponse]{}, err
 	}
 
 	movieResponses := make([]dto.MovieResponse, 0, len(movies))
@@ -53,7 +67,7 @@ func (s MovieService) ListMovies(ctx context.Context) ([]dto.MovieResponse, erro
 		movieResponses = append(movieResponses, dto.NewMovieResponse(movie))
 	}
 
-	return movieResponses, nil
+	return dto.NewPageResponse(movieResponses, next), nil
 }
 
 type GroupService struct {
@@ -66,10 +80,15 @@ func NewGroupService(groupRepository domain.GroupRepository) GroupService {
 	}
 }
 
-func (s GroupService) ListGroups(ctx context.Context) ([]dto.GroupResponse, error) {
-	groups, err := s.groupRepository.FindAll(ctx)
+func (s GroupService) ListGroups(ctx context.Context, limit int, cursor string) (dto.PageResponse[dto.GroupResponse], error) {
+	page, err := domain.NewPageRequest(limit, cursor)
+	if err != nil {
+		return dto.PageResponse[dto.GroupResponse]{}, err
+	}
+
+	groups, next, err := s.groupRepository.FindPage(ctx, page)
 	if err != nil {
-		return []dto.GroupResponse{}, err
+		return dto.PageResponse[dto.GroupResponse]{}, err
 	}
 
 	groupResponses := make([]dto.GroupResponse, 0, len(groups))
@@ -77,7 +96,7 @@ func (s GroupService) ListGroups(ctx context.Context) ([]dto.GroupResponse, erro
 		groupResponses = append(groupResponses, dto.NewGroupResponse(group))
 	}
 
-	return groupResponses, nil
+	return dto.NewPageResponse(groupResponses, next), nil
 }
 
 type CategoryService struct {
@@ -90,10 +109,15 @@ func NewCategoryService(categoryRepository domain.CategoryRepository) CategorySe
 	}
 }
 
-func (s CategoryService) ListCategories(ctx context.Context) ([]dto.CategoryResponse, error) {
-	categories, err := s.categoryRepository.FindAll(ctx)
+func (s CategoryService) ListCategories(ctx context.Context, limit int, cursor string) (dto.PageResponse[dto.CategoryResponse], error) {
+	page, err := domain.NewPageRequest(limit, cursor)
 	if err != nil {
-		return []dto.CategoryResponse{}, err
+		return dto.PageResponse[dto.CategoryResponse]{}, err
+	}
+
+	categories, next, err := s.categoryRepository.FindPage(ctx, page)
+	if err != nil {
+		return dto.PageResponse[dto.CategoryResponse]{}, err
 	}
 
 	categoryResponses := make([]dto.CategoryResponse, 0, len(categories))
@@ -101,41 +125,31 @@ func (s CategoryService) ListCategories(ctx context.Context) ([]dto.CategoryResp
 		categoryResponses = append(categoryResponses, dto.NewCategoryResponse(category))
 	}
 
-	return categoryResponses, nil
+	return dto.NewPageResponse(categoryResponses, next), nil
 }
 
 type TrackService struct {
-	trackRepository     domain.TrackRepository
-	MovieService        MovieService
-	GettingMovieService getting.MovieService
+	trackViewRepository TrackViewRepository
 }
 
-func NewTrackService(trackRepository domain.TrackRepository, movieService MovieService, gettingMovieService getting.MovieService) TrackService {
+func NewTrackService(trackViewRepository TrackViewRepository) TrackService {
 	return TrackService{
-		trackRepository:     trackRepository,
-		MovieService:        movieService,
-		GettingMovieService: gettingMovieService,
+		trackViewRepository: trackViewRepository,
 	}
 }
 
-func (s TrackService) ListTracks(ctx context.Context) ([]dto.TrackResponse, error) {
-	tracks, err := s.trackRepository.FindAll(ctx)
+func (s TrackService) ListTracks(ctx context.Context, limit int, cursor string) (dto.PageResponse[dto.TrackResponse], error) {
+	page, err := domain.NewPageRequest(limit, cursor)
 	if err != nil {
-		return []dto.TrackResponse{}, err
+		return dto.PageResponse[dto.TrackResponse]{}, err
 	}
 
-	trackResponses := make([]dto.TrackResponse, 0, len(tracks))
-	for _, track := range tracks {
-		// Obtain the movie related to the track.
-		movieDTO, err := s.GettingMovieService.GetMovie(ctx, track.MovieID().String())
-		if err != nil {
-			return []dto.TrackResponse{}, err
-		}
-
-		trackResponses = append(trackResponses, dto.NewTrackResponse(track, movieDTO))
+	tracks, next, err := s.trackViewRepository.FindPage(ctx, page)
+	if err != nil {
+		return dto.PageResponse[dto.TrackResponse]{}, err
 	}
 
-	return trackResponses, nil
+	return dto.NewPageResponse(nonNil(tracks), next), nil
 }
 
 func (s TrackService) ListTracksByMovie(ctx context.Context, movieID string) ([]dto.TrackResponse, error) {
@@ -144,77 +158,41 @@ func (s TrackService) ListTracksByMovie(ctx context.Context, movieID string) ([]
 		return nil, err
 	}
 
-	tracks, err := s.trackRepository.FindByMovie(ctx, movieIDObj)
+	tracks, err := s.trackViewRepository.FindByMovie(ctx, movieIDObj)
 	if err != nil {
 		return []dto.TrackResponse{}, err
 	}
 
-	trackResponses := make([]dto.TrackResponse, 0, len(tracks))
-	for _, track := range tracks {
-		movieDTO, err := s.GettingMovieService.GetMovie(ctx, track.MovieID().String())
-		if err != nil {
-			return []dto.TrackResponse{}, err
-		}
-
-		trackResponses = append(trackResponses, dto.NewTrackResponse(track, movieDTO))
-	}
-
-	return trackResponses, nil
+	return nonNil(tracks), nil
 }
 
 type ThemeService struct {
-	themeRepository        domain.ThemeRepository
-	trackService           TrackService
-	groupService           GroupService
-	categoryService        CategoryService
-	GettingGroupService    getting.GroupService
-	GettingTrackService    getting.TrackService
-	GettingCategoryService getting.CategoryService
+	themeViewRepository ThemeViewRepository
 }
 
-func NewThemeService(themeRepository domain.ThemeRepository, trackService TrackService, groupService GroupService, categoryService CategoryService, gettingGroupService getting.GroupService, gettingTrackService getting.TrackService, gettingCategoryService getting.CategoryService) ThemeService {
+func NewThemeService(themeViewRepository ThemeViewRepository) ThemeService {
 	return ThemeService{
-		themeRepository:        themeRepository,
-		trackService:           trackService,
-		groupService:           groupService,
-		categoryService:        categoryService,
-		GettingGroupService:    gettingGroupService,
-		GettingTrackService:    gettingTrackService,
-		GettingCategoryService: gettingCategoryService,
+		themeViewRepository: themeViewRepository,
 	}
 }
 
-func (s ThemeService) ListThemes(ctx context.Context) ([]dto.ThemeResponse, error) {
-	themes, err := s.themeRepository.FindAll(ctx)
+func (s ThemeService) ListThemes(ctx context.Context, limit int, cursor string, filter ThemesFilter) (dto.PageResponse[dto.ThemeResponse], error) {
+	page, err := domain.NewPageRequest(limit, cursor)
 	if err != nil {
-		return []dto.ThemeResponse{}, err
+		return dto.PageResponse[dto.ThemeResponse]{}, err
 	}
 
-	themeResponses := make([]dto.ThemeResponse, 0, len(themes))
-	for _, theme := range themes {
-		groupDTO, err := s.GettingGroupService.GetGroup(ctx, theme.GroupID().String())
-		if err != nil {
-			return nil, err
-		}
-
-		trackDTO, err := s.GettingTrackService.GetTrack(ctx, theme.FirstHeard().String())
-		if err != nil {
-			return nil, err
-		}
-
-		var categoryDTO *dto.CategoryResponse
-		if theme.CategoryID() != nil {
-			categoryDTORes, err := s.GettingCategoryService.GetCategory(ctx, theme.CategoryID().String())
-			if err != nil {
-				return nil, err
-			}
-			categoryDTO = &categoryDTORes
-		}
+	themeFilter, err := domain.NewThemeFilter(filter.GroupID, filter.CategoryID, filter.MovieID, filter.Name, filter.Sort)
+	if err != nil {
+		return dto.PageResponse[dto.ThemeResponse]{}, err
+	}
 
-		themeResponses = append(themeResponses, dto.NewThemeResponse(theme, trackDTO, groupDTO, categoryDTO))
+	themes, next, err := s.themeViewRepository.FindPage(ctx, themeFilter, page)
+	if err != nil {
+		return dto.PageResponse[dto.ThemeResponse]{}, err
 	}
 
-	return themeResponses, nil
+	return dto.NewPageResponse(nonNil(themes), next), nil
 }
 
 func (s ThemeService) ListThemesByGroup(ctx context.Context, groupID string) ([]dto.ThemeResponse, error) {
@@ -223,49 +201,23 @@ func (s ThemeService) ListThemesByGroup(ctx context.Context, groupID string) ([]
 		return nil, err
 	}
 
-	themes, err := s.themeRepository.FindByGroup(ctx, groupIDObj)
+	themes, err := s.themeViewRepository.FindByGroup(ctx, groupIDObj)
 	if err != nil {
 		return []dto.ThemeResponse{}, err
 	}
 
-	themeResponses := make([]dto.ThemeResponse, 0, len(themes))
-	for _, theme := range themes {
-		groupDTO, err := s.GettingGroupService.GetGroup(ctx, theme.GroupID().String())
-		if err != nil {
-			return nil, err
-		}
-
-		trackDTO, err := s.GettingTrackService.GetTrack(ctx, theme.FirstHeard().String())
-		if err != nil {
-			return nil, err
-		}
-
-		var categoryDTO *dto.CategoryResponse
-		if theme.CategoryID() != nil {
-			categoryDTORes, err := s.GettingCategoryService.GetCategory(ctx, theme.CategoryID().String())
-			if err != nil {
-				return nil, err
-			}
-			categoryDTO = &categoryDTORes
-		}
-
-		themeResponses = append(themeResponses, dto.NewThemeResponse(theme, trackDTO, groupDTO, categoryDTO))
-	}
-
-	return themeResponses, nil
+	return nonNil(themes), nil
 }
 
 type TrackThemeService struct {
-	trackThemeRepository domain.TrackThemeRepository
-	GettingTrackService  getting.TrackService
-	GettingThemeService  getting.ThemeService
+	trackThemeViewRepository TrackThemeViewRepository
+	themeRepository          domain.ThemeRepository
 }
 
-func NewTrackThemeService(trackThemeRepository domain.TrackThemeRepository, gettingTrackService getting.TrackService, gettingThemeService getting.ThemeService) TrackThemeService {
+func NewTrackThemeService(trackThemeViewRepository TrackThemeViewRepository, themeRepository domain.ThemeRepository) TrackThemeService {
 	return TrackThemeService{
-		trackThemeRepository: trackThemeRepository,
-		GettingTrackService:  gettingTrackService,
-		GettingThemeService:  gettingThemeService,
+		trackThemeViewRepository: trackThemeViewRepository,
+		themeRepository:          themeRepository,
 	}
 }
 
@@ -275,24 +227,150 @@ func (s TrackThemeService) ListTracksThemesByTrack(ctx context.Context, trackID
 		return nil, err
 	}
 
-	trackThemes, err := s.trackThemeRepository.FindByTrack(ctx, trackIDObj)
+	trackThemes, err := s.trackThemeViewRepository.FindByTrack(ctx, trackIDObj)
 	if err != nil {
 		return []dto.TrackThemeResponse{}, err
 	}
 
-	trackThemeResponses := make([]dto.TrackThemeResponse, 0, len(trackThemes))
-	for _, trackTheme := range trackThemes {
-		trackDTO, err := s.GettingTrackService.GetTrack(ctx, trackTheme.TrackID().String())
-		if err != nil {
-			return nil, err
-		}
+	return nonNil(trackThemes), nil
+}
+
+func (s TrackThemeService) ListTracksThemesByTheme(ctx context.Context, themeID string) ([]dto.TrackThemeResponse, error) {
+	themeIDObj, err := domain.NewThemeIDFromString(themeID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A theme with no occurrences lists none, but a missing or trashed one is not found
+	if _, err := s.themeRepository.Find(ctx, themeIDObj); err != nil {
+		return nil, err
+	}
+
+	trackThemes, err := s.trackThemeViewRepository.FindByTheme(ctx, themeIDObj)
+	if err != nil {
+		return []dto.TrackThemeResponse{}, err
+	}
+
+	return nonNil(trackThemes), nil
+}
+
+type TrashService struct {
+	trashRepository TrashRepository
+}
+
+func NewTrashService(trashRepository TrashRepository) TrashService {
+	return TrashService{
+		trashRepository: trashRepository,
+	}
+}
+
+func (s TrashService) ListTrash(ctx context.Context, limit int, cursor string) (dto.PageResponse[dto.TrashItem], error) {
+	page, err := domain.NewPageRequest(limit, cursor)
+	if err != nil {
+		return dto.PageResponse[dto.TrashItem]{}, err
+	}
+
+	items, next, err := s.trashRepository.FindPage(ctx, page)
+	if err != nil {
+		return dto.PageResponse[dto.TrashItem]{}, err
+	}
+
+	return dto.NewPageResponse(nonNil(items), next), nil
+}
+
+type AuditService struct {
+	auditRepository domain.AuditRepository
+}
+
+func NewAuditService(auditRepository domain.AuditRepository) AuditService {
+	return AuditService{
+		auditRepository: auditRepository,
+	}
+}
+
+// ListAudit returns a page of the audit log entries that match the filter, newest first.
+// Empty values leave the matching criterion unset.
+func (s AuditService) ListAudit(ctx context.Context, limit int, cursor string, entity, userID, since string) (dto.PageResponse[dto.AuditEntryResponse], error) {
+	page, err := domain.NewPageRequest(limit, cursor)
+	if err != nil {
+		return dto.PageResponse[dto.AuditEntryResponse]{}, err
+	}
+
+	filter, err := domain.NewAuditFilter(entity, userID, since)
+	if err != nil {
+		return dto.PageResponse[dto.AuditEntryResponse]{}, err
+	}
+
+	entries, next, err := s.auditRepository.FindPage(ctx, filter, page)
+	if err != nil {
+		return dto.PageResponse[dto.AuditEntryResponse]{}, err
+	}
+
+	responses := make([]dto.AuditEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		responses = append(responses, dto.NewAuditEntryResponse(entry))
+	}
+	return dto.NewPageResponse(responses, next), nil
+}
+
+type WebhookService struct {
+	webhookRepository  domain.WebhookRepository
+	deliveryRepository domain.WebhookDeliveryRepository
+}
 
-		themeDTO, err := s.GettingThemeService.GetTheme(ctx, trackTheme.ThemeID().String())
-		if err != nil {
-			return nil, err
-		}
+func NewWebhookService(webhookRepository domain.WebhookRepository, deliveryRepository domain.WebhookDeliveryRepository) WebhookService {
+	return WebhookService{
+		webhookRepository:  webhookRepository,
+		deliveryRepository: deliveryRepository,
+	}
+}
+
+func (s WebhookService) ListWebhooks(ctx context.Context) (dto.WebhooksResponse, error) {
+	webhooks, err := s.webhookRepository.FindAll(ctx)
+	if err != nil {
+		return dto.WebhooksResponse{}, err
+	}
+
+	responses := make([]dto.WebhookResponse, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		responses = append(responses, dto.NewWebhookResponse(webhook))
+	}
+	return dto.WebhooksResponse{Webhooks: responses}, nil
+}
+
+// ListDeliveries returns a page of the delivery log of the webhook, newest first.
+func (s WebhookService) ListDeliveries(ctx context.Context, webhookID string, limit int, cursor string) (dto.PageResponse[dto.WebhookDeliveryResponse], error) {
+	id, err := domain.NewWebhookIDFromString(webhookID)
+	if err != nil {
+		return dto.PageResponse[dto.WebhookDeliveryResponse]{}, err
+	}
+
+	page, err := domain.NewPageRequest(limit, cursor)
+	if err != nil {
+		return dto.PageResponse[dto.WebhookDeliveryResponse]{}, err
+	}
+
+	// Tell a missing webhook apart from one that was never delivered to
+	if _, err := s.webhookRepository.Find(ctx, id); err != nil {
+		return dto.PageResponse[dto.WebhookDeliveryResponse]{}, err
+	}
+
+	deliveries, next, err := s.deliveryRepository.FindPage(ctx, id, page)
+	if err != nil {
+		return dto.PageResponse[dto.WebhookDeliveryResponse]{}, err
+	}
+
+	responses := make([]dto.WebhookDeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		responses = append(responses, dto.NewWebhookDeliveryResponse(delivery))
+	}
+	return dto.NewPageResponse(responses, next), nil
+}
 
-		trackThemeResponses = append(trackThemeResponses, dto.NewTrackThemeResponse(trackTheme, trackDTO, themeDTO))
+// nonNil makes sure empty results are encoded as [] instead of null.
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
 	}
-	return trackThemeResponses, nil
+	return items
 }
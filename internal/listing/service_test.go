@@ -4,119 +4,151 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
-	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/getting"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/storage/storagemocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 const repositoryErrorMsg = "repository error"
 
-func getGettingMovieServiceMock(movieRepositoryMock *storagemocks.MovieRepository) getting.MovieService {
-	return getting.NewMovieService(movieRepositoryMock)
-}
-
-func getGettingTrackServiceMock(trackRepositoryMock *storagemocks.TrackRepository, movieRepositoryMock *storagemocks.MovieRepository) getting.TrackService {
-	gettingMovieService := getGettingMovieServiceMock(movieRepositoryMock)
-	return getting.NewTrackService(trackRepositoryMock, gettingMovieService)
-}
-
-func getGettingGroupServiceMock(groupRepositoryMock *storagemocks.GroupRepository) getting.GroupService {
-	return getting.NewGroupService(groupRepositoryMock)
-}
-
-func getGettingCategoryServiceMock(categoryRepositoryMock *storagemocks.CategoryRepository) getting.CategoryService {
-	return getting.NewCategoryService(categoryRepositoryMock)
-}
-
-func getGettingThemeServiceMock(themeRepositoryMock *storagemocks.ThemeRepository, trackRepositoryMock *storagemocks.TrackRepository, groupRepositoryMock *storagemocks.GroupRepository, movieRepositoryMock *storagemocks.MovieRepository, categoryRepositoryMock *storagemocks.CategoryRepository) getting.ThemeService {
-	return getting.NewThemeService(
-		themeRepositoryMock,
-		getGettingTrackServiceMock(trackRepositoryMock, movieRepositoryMock),
-		getGettingGroupServiceMock(groupRepositoryMock),
-		getGettingCategoryServiceMock(categoryRepositoryMock),
-	)
-}
-
 func TestUserServiceListUsersRepositoryError(t *testing.T) {
 	userRepositoryMock := new(storagemocks.UserRepository)
-	userRepositoryMock.On("FindAll", mock.Anything).Return(nil, errors.New(repositoryErrorMsg)).Once()
+	userRepositoryMock.On("FindPage", mock.Anything, mock.Anything).Return(nil, nil, errors.New(repositoryErrorMsg)).Once()
 	defer userRepositoryMock.AssertExpectations(t)
 
 	userService := NewUserService(userRepositoryMock)
 
 	ctx := context.Background()
-	_, err := userService.ListUsers(ctx)
+	_, err := userService.ListUsers(ctx, 0, "")
 	assert.Error(t, err)
 }
 
 func TestUserServiceListUsersSuccess(t *testing.T) {
 	userRepositoryMock := new(storagemocks.UserRepository)
 	users := []domain.User{}
-	user1, err := domain.NewUser("John Doe", "john@example.com", "password123", false)
+	user1, err := domain.NewUser("John Doe", "john@example.com", "password123", domain.RoleViewer)
 	assert.NoError(t, err)
 	users = append(users, user1)
-	user2, err := domain.NewUser("Jane Doe", "jane@example.com", "password456", false)
+	user2, err := domain.NewUser("Jane Doe", "jane@example.com", "password456", domain.RoleViewer)
 	assert.NoError(t, err)
 	users = append(users, user2)
-	userRepositoryMock.On("FindAll", mock.Anything).Return(users, nil).Once()
+	userRepositoryMock.On("FindPage", mock.Anything, mock.Anything).Return(users, nil, nil).Once()
 	defer userRepositoryMock.AssertExpectations(t)
 
 	userService := NewUserService(userRepositoryMock)
 
-	usersDTO, err := userService.ListUsers(context.Background())
+	usersDTO, err := userService.ListUsers(context.Background(), 0, "")
 	assert.NoError(t, err)
-	assert.Len(t, usersDTO, 2)
-	assert.Equal(t, "John Doe", usersDTO[0].Name)
-	assert.Equal(t, "john@example.com", usersDTO[0].Email)
-	assert.Equal(t, "Jane Doe", usersDTO[1].Name)
-	assert.Equal(t, "jane@example.com", usersDTO[1].Email)
+	assert.Len(t, usersDTO.Items, 2)
+	assert.Equal(t, "John Doe", usersDTO.Items[0].Name)
+	assert.Equal(t, "john@example.com", usersDTO.Items[0].Email)
+	assert.Equal(t, "Jane Doe", usersDTO.Items[1].Name)
+	assert.Equal(t, "jane@example.com", usersDTO.Items[1].Email)
 }
 
 func TestMovieServiceListMoviesRepositoryError(t *testing.T) {
 	movieRepositoryMock := new(storagemocks.MovieRepository)
-	movieRepositoryMock.On("FindAll", mock.Anything).Return(nil, errors.New(repositoryErrorMsg)).Once()
+	movieRepositoryMock.On("FindPage", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil, errors.New(repositoryErrorMsg)).Once()
 	defer movieRepositoryMock.AssertExpectations(t)
 
 	movieService := NewMovieService(movieRepositoryMock)
 
 	ctx := context.Background()
-	_, err := movieService.ListMovies(ctx)
+	_, err := movieService.ListMovies(ctx, 0, "", "")
 	assert.Error(t, err)
 }
 
 func TestMovieServiceListMoviesSuccess(t *testing.T) {
 	movieRepositoryMock := new(storagemocks.MovieRepository)
 	movies := []domain.Movie{}
-	movie1, err := domain.NewMovie("The Fellowship of the Ring")
+	movie1, err := domain.NewMovie("The Fellowship of the Ring", 2001, 1, domain.MovieSeriesLordOfTheRings, nil)
 	assert.NoError(t, err)
 	movies = append(movies, movie1)
-	movie2, err := domain.NewMovie("The Two Towers")
+	movie2, err := domain.NewMovie("The Two Towers", 2002, 2, domain.MovieSeriesLordOfTheRings, nil)
 	assert.NoError(t, err)
 	movies = append(movies, movie2)
-	movieRepositoryMock.On("FindAll", mock.Anything).Return(movies, nil).Once()
+	movieRepositoryMock.On("FindPage", mock.Anything, mock.Anything, mock.Anything).Return(movies, nil, nil).Once()
 	defer movieRepositoryMock.AssertExpectations(t)
 
 	movieService := NewMovieService(movieRepositoryMock)
 
-	moviesDTO, err := movieService.ListMovies(context.Background())
+	moviesDTO, err := movieService.ListMovies(context.Background(), 0, "", "")
 	assert.NoError(t, err)
-	assert.Len(t, moviesDTO, 2)
-	assert.Equal(t, "The Fellowship of the Ring", moviesDTO[0].Name)
-	assert.Equal(t, "The Two Towers", moviesDTO[1].Name)
+	assert.Len(t, moviesDTO.Items, 2)
+	assert.Equal(t, "The Fellowship of the Ring", moviesDTO.Items[0].Name)
+	assert.Equal(t, "The Two Towers", moviesDTO.Items[1].Name)
+}
+
+func TestMovieServiceListMoviesInvalidLimit(t *testing.T) {
+	movieRepositoryMock := new(storagemocks.MovieRepository)
+	defer movieRepositoryMock.AssertExpectations(t)
+
+	movieService := NewMovieService(movieRepositoryMock)
+
+	_, err := movieService.ListMovies(context.Background(), domain.MaxPageLimit+1, "", "")
+	assert.ErrorIs(t, err, domain.ErrInvalidPageLimit)
+}
+
+func TestMovieServiceListMoviesNextCursor(t *testing.T) {
+	movieRepositoryMock := new(storagemocks.MovieRepository)
+	movie, err := domain.NewMovie("The Return of the King", 2003, 3, domain.MovieSeriesLordOfTheRings, nil)
+	assert.NoError(t, err)
+	next := domain.NewCursor("3", movie.ID().String())
+	movieRepositoryMock.On("FindPage", mock.Anything, mock.Anything, mock.MatchedBy(func(page domain.PageRequest) bool {
+		return page.Limit() == 1 && page.After() == nil
+	})).Return([]domain.Movie{movie}, &next, nil).Once()
+	defer movieRepositoryMock.AssertExpectations(t)
+
+	movieService := NewMovieService(movieRepositoryMock)
+
+	moviesDTO, err := movieService.ListMovies(context.Background(), 1, "", "")
+	assert.NoError(t, err)
+	assert.Len(t, moviesDTO.Items, 1)
+	if assert.NotNil(t, moviesDTO.NextCursor) {
+		cursor, err := domain.NewCursorFromString(*moviesDTO.NextCursor)
+		assert.NoError(t, err)
+		assert.Equal(t, next, cursor)
+	}
+}
+
+func TestMovieServiceListMoviesBySeries(t *testing.T) {
+	movieRepositoryMock := new(storagemocks.MovieRepository)
+	movieRepositoryMock.On("FindPage", mock.Anything, mock.MatchedBy(func(filter domain.MovieFilter) bool {
+		return filter.Series() != nil && filter.Series().String() == domain.MovieSeriesHobbit
+	}), mock.Anything).Return(nil, nil, nil).Once()
+	defer movieRepositoryMock.AssertExpectations(t)
+
+	movieService := NewMovieService(movieRepositoryMock)
+
+	moviesDTO, err := movieService.ListMovies(context.Background(), 0, "", domain.MovieSeriesHobbit)
+	assert.NoError(t, err)
+	assert.Empty(t, moviesDTO.Items)
+}
+
+func TestMovieServiceListMoviesInvalidSeries(t *testing.T) {
+	movieRepositoryMock := new(storagemocks.MovieRepository)
+	defer movieRepositoryMock.AssertExpectations(t)
+
+	movieService := NewMovieService(movieRepositoryMock)
+
+	_, err := movieService.ListMovies(context.Background(), 0, "", "silmarillion")
+	assert.ErrorIs(t, err, domain.ErrInvalidMovieSeries)
 }
 
 func TestGroupServiceListGroupsRepositoryError(t *testing.T) {
 	groupRepositoryMock := new(storagemocks.GroupRepository)
-	groupRepositoryMock.On("FindAll", mock.Anything).Return(nil, errors.New(repositoryErrorMsg)).Once()
+	groupRepositoryMock.On("FindPage", mock.Anything, mock.Anything).Return(nil, nil, errors.New(repositoryErrorMsg)).Once()
 	defer groupRepositoryMock.AssertExpectations(t)
 
 	groupService := NewGroupService(groupRepositoryMock)
 
 	ctx := context.Background()
-	_, err := groupService.ListGroups(ctx)
+	_, err := groupService.ListGroups(ctx, 0, "")
 	assert.Error(t, err)
 }
 
@@ -129,27 +161,27 @@ func TestGroupServiceListGroupsSuccess(t *testing.T) {
 	group2, err := domain.NewGroup("Rohan", "Description of Rohan", "http://example.com/rohan.jpg")
 	assert.NoError(t, err)
 	groups = append(groups, group2)
-	groupRepositoryMock.On("FindAll", mock.Anything).Return(groups, nil).Once()
+	groupRepositoryMock.On("FindPage", mock.Anything, mock.Anything).Return(groups, nil, nil).Once()
 	defer groupRepositoryMock.AssertExpectations(t)
 
 	groupService := NewGroupService(groupRepositoryMock)
 
-	groupsDTO, err := groupService.ListGroups(context.Background())
+	groupsDTO, err := groupService.ListGroups(context.Background(), 0, "")
 	assert.NoError(t, err)
-	assert.Len(t, groupsDTO, 2)
-	assert.Equal(t, "The Elves", groupsDTO[0].Name)
-	assert.Equal(t, "Rohan", groupsDTO[1].Name)
+	assert.Len(t, groupsDTO.Items, 2)
+	assert.Equal(t, "The Elves", groupsDTO.Items[0].Name)
+	assert.Equal(t, "Rohan", groupsDTO.Items[1].Name)
 }
 
 func TestCategoryServiceListCategoriesRepositoryError(t *testing.T) {
 	categoryRepositoryMock := new(storagemocks.CategoryRepository)
-	categoryRepositoryMock.On("FindAll", mock.Anything).Return(nil, errors.New("repository error")).Once()
+	categoryRepositoryMock.On("FindPage", mock.Anything, mock.Anything).Return(nil, nil, errors.New("repository error")).Once()
 	defer categoryRepositoryMock.AssertExpectations(t)
 
 	categoryService := NewCategoryService(categoryRepositoryMock)
 
 	ctx := context.Background()
-	_, err := categoryService.ListCategories(ctx)
+	_, err := categoryService.ListCategories(ctx, 0, "")
 	assert.Error(t, err)
 }
 
@@ -162,96 +194,89 @@ func TestCategoryServiceListCategoriesSuccess(t *testing.T) {
 	category2, err := domain.NewCategory("The Hobbit Accompaniments")
 	assert.NoError(t, err)
 	categories = append(categories, category2)
-	categoryRepositoryMock.On("FindAll", mock.Anything).Return(categories, nil).Once()
+	categoryRepositoryMock.On("FindPage", mock.Anything, mock.Anything).Return(categories, nil, nil).Once()
 	defer categoryRepositoryMock.AssertExpectations(t)
 
 	categoryService := NewCategoryService(categoryRepositoryMock)
 
-	categoriesDTO, err := categoryService.ListCategories(context.Background())
+	categoriesDTO, err := categoryService.ListCategories(context.Background(), 0, "")
 	assert.NoError(t, err)
-	assert.Len(t, categoriesDTO, 2)
-	assert.Equal(t, "The Mordor Accompaniments", categoriesDTO[0].Name)
-	assert.Equal(t, "The Hobbit Accompaniments", categoriesDTO[1].Name)
+	assert.Len(t, categoriesDTO.Items, 2)
+	assert.Equal(t, "The Mordor Accompaniments", categoriesDTO.Items[0].Name)
+	assert.Equal(t, "The Hobbit Accompaniments", categoriesDTO.Items[1].Name)
 }
 
 func TestTrackServiceListTracksRepositoryError(t *testing.T) {
-	trackRepositoryMock := new(storagemocks.TrackRepository)
-	trackRepositoryMock.On("FindAll", mock.Anything).Return(nil, errors.New(repositoryErrorMsg)).Once()
-	defer trackRepositoryMock.AssertExpectations(t)
-
-	movieRepositoryMock := new(storagemocks.MovieRepository)
-	movieService := NewMovieService(movieRepositoryMock)
-	gettingMovieService := getting.NewMovieService(movieRepositoryMock)
+	trackViewRepositoryMock := new(storagemocks.TrackViewRepository)
+	trackViewRepositoryMock.On("FindPage", mock.Anything, mock.Anything).Return(nil, nil, errors.New(repositoryErrorMsg)).Once()
+	defer trackViewRepositoryMock.AssertExpectations(t)
 
-	trackService := NewTrackService(trackRepositoryMock, movieService, gettingMovieService)
+	trackService := NewTrackService(trackViewRepositoryMock)
 
 	ctx := context.Background()
-	_, err := trackService.ListTracks(ctx)
+	_, err := trackService.ListTracks(ctx, 0, "")
 	assert.Error(t, err)
 }
 
 func TestTrackServiceListTracksSuccess(t *testing.T) {
-	trackRepositoryMock := new(storagemocks.TrackRepository)
-	tracks := []domain.Track{}
-	track1, err := domain.NewTrack("The Three Hunters", "b6c9d5ae-bf3b-419e-ba8f-09c8ce39d9bc", nil)
-	assert.NoError(t, err)
-	tracks = append(tracks, track1)
-	track2, err := domain.NewTrack("The Shire", "28712a55-04dd-4200-9316-4d6a1e399128", nil)
+	movie := dto.MovieResponse{ID: "b6c9d5ae-bf3b-419e-ba8f-09c8ce39d9bc", Name: "The Two Towers"}
+	tracks := []dto.TrackResponse{
+		{ID: "28712a55-04dd-4200-9316-4d6a1e399128", Name: "The Three Hunters", Movie: movie},
+		{ID: "28712a55-04dd-4200-9316-4d6a1e399129", Name: "The Riders of Rohan", Movie: movie},
+	}
+	trackViewRepositoryMock := new(storagemocks.TrackViewRepository)
+	trackViewRepositoryMock.On("FindPage", mock.Anything, mock.Anything).Return(tracks, nil, nil).Once()
+	defer trackViewRepositoryMock.AssertExpectations(t)
+
+	trackService := NewTrackService(trackViewRepositoryMock)
+
+	tracksDTO, err := trackService.ListTracks(context.Background(), 0, "")
 	assert.NoError(t, err)
-	tracks = append(tracks, track2)
-	trackRepositoryMock.On("FindAll", mock.Anything).Return(tracks, nil).Once()
-	defer trackRepositoryMock.AssertExpectations(t)
-
-	movieRepositoryMock := new(storagemocks.MovieRepository)
-	movieRepositoryMock.On("Find", mock.Anything, mock.Anything).Return(domain.Movie{}, nil).Twice()
-	movieService := NewMovieService(movieRepositoryMock)
-	gettingMovieService := getting.NewMovieService(movieRepositoryMock)
-
-	trackService := NewTrackService(trackRepositoryMock, movieService, gettingMovieService)
-
-	tracksDTO, err := trackService.ListTracks(context.Background())
-	assert.NoError(t, err)
-	assert.Len(t, tracksDTO, 2)
-	assert.Equal(t, "The Three Hunters", tracksDTO[0].Name)
-	assert.Equal(t, "The Shire", tracksDTO[1].Name)
+	assert.Len(t, tracksDTO.Items, 2)
+	assert.Equal(t, "The Three Hunters", tracksDTO.Items[0].Name)
+	assert.Equal(t, "The Two Towers", tracksDTO.Items[0].Movie.Name)
+	assert.Equal(t, "The Riders of Rohan", tracksDTO.Items[1].Name)
+	assert.Nil(t, tracksDTO.NextCursor)
 }
 
 func TestTrackServiceListTracksByMovieRepositoryError(t *testing.T) {
-	trackRepositoryMock := new(storagemocks.TrackRepository)
-	trackRepositoryMock.On("FindByMovie", mock.Anything, mock.Anything).Return(nil, errors.New(repositoryErrorMsg)).Once()
-	defer trackRepositoryMock.AssertExpectations(t)
-
-	movieRepositoryMock := new(storagemocks.MovieRepository)
-	movieService := NewMovieService(movieRepositoryMock)
-	gettingMovieService := getting.NewMovieService(movieRepositoryMock)
+	trackViewRepositoryMock := new(storagemocks.TrackViewRepository)
+	trackViewRepositoryMock.On("FindByMovie", mock.Anything, mock.Anything).Return(nil, errors.New(repositoryErrorMsg)).Once()
+	defer trackViewRepositoryMock.AssertExpectations(t)
 
-	trackService := NewTrackService(trackRepositoryMock, movieService, gettingMovieService)
+	trackService := NewTrackService(trackViewRepositoryMock)
 
 	ctx := context.Background()
 	_, err := trackService.ListTracksByMovie(ctx, "12345678-1234-1234-1234-123456789012")
 	assert.Error(t, err)
 }
 
-func TestTrackServiceListTracksByMovieSuccess(t *testing.T) {
-	trackRepositoryMock := new(storagemocks.TrackRepository)
-	tracks := []domain.Track{}
-	track1, err := domain.NewTrack("Track name", "b6c1d5ae-bf3b-419e-ba8f-09c8ce39d9bc", nil)
-	assert.NoError(t, err)
-	tracks = append(tracks, track1)
-	track2, err := domain.NewTrack("Track 2 name", "22712a55-04dd-4200-9316-4d6a1e399128", nil)
-	assert.NoError(t, err)
-	tracks = append(tracks, track2)
-	trackRepositoryMock.On("FindByMovie", mock.Anything, mock.Anything).Return(tracks, nil).Once()
-	defer trackRepositoryMock.AssertExpectations(t)
+func TestTrackServiceListTracksByMovieInvalidID(t *testing.T) {
+	trackViewRepositoryMock := new(storagemocks.TrackViewRepository)
+	defer trackViewRepositoryMock.AssertExpectations(t)
 
-	movieRepositoryMock := new(storagemocks.MovieRepository)
-	movieRepositoryMock.On("Find", mock.Anything, mock.Anything).Return(domain.Movie{}, nil).Twice()
-	movieService := NewMovieService(movieRepositoryMock)
-	gettingMovieService := getting.NewMovieService(movieRepositoryMock)
+	trackService := NewTrackService(trackViewRepositoryMock)
 
-	trackService := NewTrackService(trackRepositoryMock, movieService, gettingMovieService)
+	_, err := trackService.ListTracksByMovie(context.Background(), "invalid")
+	assert.ErrorIs(t, err, domain.ErrInvalidMovieID)
+}
 
-	tracksDTO, err := trackService.ListTracksByMovie(context.Background(), "12345678-1234-1234-1234-123456789012")
+func TestTrackServiceListTracksByMovieSuccess(t *testing.T) {
+	movieID := "12345678-1234-1234-1234-123456789012"
+	movie := dto.MovieResponse{ID: movieID, Name: "The Fellowship of the Ring"}
+	tracks := []dto.TrackResponse{
+		{ID: "b6c1d5ae-bf3b-419e-ba8f-09c8ce39d9bc", Name: "Track name", Movie: movie},
+		{ID: "22712a55-04dd-4200-9316-4d6a1e399128", Name: "Track 2 name", Movie: movie},
+	}
+	trackViewRepositoryMock := new(storagemocks.TrackViewRepository)
+	trackViewRepositoryMock.On("FindByMovie", mock.Anything, mock.MatchedBy(func(id domain.MovieID) bool {
+		return id.String() == movieID
+	})).Return(tracks, nil).Once()
+	defer trackViewRepositoryMock.AssertExpectations(t)
+
+	trackService := NewTrackService(trackViewRepositoryMock)
+
+	tracksDTO, err := trackService.ListTracksByMovie(context.Background(), movieID)
 	assert.NoError(t, err)
 	assert.Len(t, tracksDTO, 2)
 	assert.Equal(t, "Track name", tracksDTO[0].Name)
@@ -259,99 +284,108 @@ func TestTrackServiceListTracksByMovieSuccess(t *testing.T) {
 }
 
 func TestThemeServiceListThemesRepositoryError(t *testing.T) {
-	themeRepositoryMock := new(storagemocks.ThemeRepository)
-	themeRepositoryMock.On("FindAll", mock.Anything).Return(nil, errors.New(repositoryErrorMsg)).Once()
-	defer themeRepositoryMock.AssertExpectations(t)
+	themeViewRepositoryMock := new(storagemocks.ThemeViewRepository)
+	themeViewRepositoryMock.On("FindPage", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil, errors.New(repositoryErrorMsg)).Once()
+	defer themeViewRepositoryMock.AssertExpectations(t)
 
-	movieRepositoryMock := new(storagemocks.MovieRepository)
-	movieService := NewMovieService(movieRepositoryMock)
-	gettingMovieService := getting.NewMovieService(movieRepositoryMock)
-
-	trackRepositoryMock := new(storagemocks.TrackRepository)
-	trackService := NewTrackService(trackRepositoryMock, movieService, gettingMovieService)
-	gettingTrackService := getting.NewTrackService(trackRepositoryMock, gettingMovieService)
-
-	groupRepositoryMock := new(storagemocks.GroupRepository)
-	groupService := NewGroupService(groupRepositoryMock)
-	gettingGroupService := getting.NewGroupService(groupRepositoryMock)
-
-	categoryRepositoryMock := new(storagemocks.CategoryRepository)
-	categoryService := NewCategoryService(categoryRepositoryMock)
-	gettingCategoryService := getting.NewCategoryService(categoryRepositoryMock)
-
-	themeService := NewThemeService(themeRepositoryMock, trackService, groupService, categoryService, gettingGroupService, gettingTrackService, gettingCategoryService)
+	themeService := NewThemeService(themeViewRepositoryMock)
 
 	ctx := context.Background()
-	_, err := themeService.ListThemes(ctx)
+	_, err := themeService.ListThemes(ctx, 0, "", ThemesFilter{})
 	assert.Error(t, err)
 }
 
 func TestThemeServiceListThemesSuccess(t *testing.T) {
-	categoryID1 := "40929ca6-ed89-4548-a1d9-54b604ea50b5"
-	categoryID2 := "40929ca6-ed89-4548-a1d9-54b604ea50b6"
-	trackID := "6a4f86e4-4fef-4151-9c60-e467007dd213"
-	groupID := "40929ca6-ed89-4548-a1d9-54b604ea50b2"
-	themeRepositoryMock := new(storagemocks.ThemeRepository)
-	themes := []domain.Theme{}
-	theme1, err := domain.NewTheme("The History of the Ring", trackID, groupID, "Description", 0, 1, &categoryID1)
+	themes := []dto.ThemeResponse{
+		{ID: "40929ca6-ed89-4548-a1d9-54b604ea50b5", Name: "The History of the Ring", Category: &dto.CategoryResponse{Name: "Main"}},
+		{ID: "40929ca6-ed89-4548-a1d9-54b604ea50b6", Name: "The Rohan Fanfare"},
+	}
+	themeViewRepositoryMock := new(storagemocks.ThemeViewRepository)
+	themeViewRepositoryMock.On("FindPage", mock.Anything, mock.Anything, mock.Anything).Return(themes, nil, nil).Once()
+	defer themeViewRepositoryMock.AssertExpectations(t)
+
+	themeService := NewThemeService(themeViewRepositoryMock)
+
+	themesDTO, err := themeService.ListThemes(context.Background(), 0, "", ThemesFilter{})
 	assert.NoError(t, err)
-	themes = append(themes, theme1)
-	theme2, err := domain.NewTheme("The Rohan Fanfare", trackID, groupID, "Description", 0, 1, &categoryID2)
+	assert.Len(t, themesDTO.Items, 2)
+	assert.Equal(t, "The History of the Ring", themesDTO.Items[0].Name)
+	assert.Equal(t, "The Rohan Fanfare", themesDTO.Items[1].Name)
+}
+
+func TestThemeServiceListThemesEmpty(t *testing.T) {
+	themeViewRepositoryMock := new(storagemocks.ThemeViewRepository)
+	themeViewRepositoryMock.On("FindPage", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil, nil).Once()
+	defer themeViewRepositoryMock.AssertExpectations(t)
+
+	themeService := NewThemeService(themeViewRepositoryMock)
+
+	themesDTO, err := themeService.ListThemes(context.Background(), 0, "", ThemesFilter{})
 	assert.NoError(t, err)
-	themes = append(themes, theme2)
-	themeRepositoryMock.On("FindAll", mock.Anything).Return(themes, nil).Once()
-	defer themeRepositoryMock.AssertExpectations(t)
+	assert.NotNil(t, themesDTO.Items)
+	assert.Empty(t, themesDTO.Items)
+}
 
-	movieRepositoryMock := new(storagemocks.MovieRepository)
-	movieService := NewMovieService(movieRepositoryMock)
-	gettingMovieService := getting.NewMovieService(movieRepositoryMock)
+func TestThemeServiceListThemesFiltered(t *testing.T) {
+	movieID := "223e4567-e89b-12d3-a456-426614174001"
+	themeViewRepositoryMock := new(storagemocks.ThemeViewRepository)
+	themeViewRepositoryMock.On("FindPage", mock.Anything, mock.MatchedBy(func(filter domain.ThemeFilter) bool {
+		return filter.MovieID() != nil && filter.MovieID().String() == movieID &&
+			filter.Name() == "ring" && filter.Sort() == domain.ThemeSortName
+	}), mock.Anything).Return(nil, nil, nil).Once()
+	defer themeViewRepositoryMock.AssertExpectations(t)
 
-	track, err := domain.NewTrack("Track", "28712a55-04dd-4200-9316-4d6a1e399122", nil)
+	themeService := NewThemeService(themeViewRepositoryMock)
+
+	_, err := themeService.ListThemes(context.Background(), 0, "", ThemesFilter{MovieID: movieID, Name: "ring", Sort: "name"})
 	assert.NoError(t, err)
+}
 
-	trackRepositoryMock := new(storagemocks.TrackRepository)
-	trackRepositoryMock.On("Find", mock.Anything, mock.Anything).Return(track, nil).Twice()
-	trackService := NewTrackService(trackRepositoryMock, movieService, gettingMovieService)
-	defer trackRepositoryMock.AssertExpectations(t)
+func TestThemeServiceListThemesInvalidSort(t *testing.T) {
+	themeViewRepositoryMock := new(storagemocks.ThemeViewRepository)
+	defer themeViewRepositoryMock.AssertExpectations(t)
 
-	movieRepositoryMock.On("Find", mock.Anything, mock.Anything).Return(domain.Movie{}, nil).Twice()
-	gettingTrackService := getting.NewTrackService(trackRepositoryMock, gettingMovieService)
-	defer movieRepositoryMock.AssertExpectations(t)
+	themeService := NewThemeService(themeViewRepositoryMock)
 
-	groupRepositoryMock := new(storagemocks.GroupRepository)
-	groupRepositoryMock.On("Find", mock.Anything, mock.Anything).Return(domain.Group{}, nil).Twice()
-	groupService := NewGroupService(groupRepositoryMock)
-	gettingGroupService := getting.NewGroupService(groupRepositoryMock)
-	defer groupRepositoryMock.AssertExpectations(t)
+	_, err := themeService.ListThemes(context.Background(), 0, "", ThemesFilter{Sort: "popularity"})
+	assert.ErrorIs(t, err, domain.ErrInvalidThemeSort)
+}
 
-	categoryRepositoryMock := new(storagemocks.CategoryRepository)
-	categoryRepositoryMock.On("Find", mock.Anything, mock.Anything).Return(domain.Category{}, nil).Twice()
-	categoryService := NewCategoryService(categoryRepositoryMock)
-	gettingCategoryService := getting.NewCategoryService(categoryRepositoryMock)
-	defer categoryRepositoryMock.AssertExpectations(t)
+func TestThemesQueryHandlerUnknownField(t *testing.T) {
+	themeViewRepositoryMock := new(storagemocks.ThemeViewRepository)
+	defer themeViewRepositoryMock.AssertExpectations(t)
+
+	handler := NewThemesQueryHandler(NewThemeService(themeViewRepositoryMock))
 
-	themeService := NewThemeService(themeRepositoryMock, trackService, groupService, categoryService, gettingGroupService, gettingTrackService, gettingCategoryService)
+	_, err := handler.Handle(context.Background(), NewThemesQuery(0, "", ThemesFilter{}, []string{"name", "colour"}))
+	assert.ErrorIs(t, err, ErrUnknownQueryField)
+}
 
-	themesDTO, err := themeService.ListThemes(context.Background())
+func TestThemeServiceListThemesByGroupSuccess(t *testing.T) {
+	groupID := "40929ca6-ed89-4548-a1d9-54b604ea50b2"
+	themes := []dto.ThemeResponse{
+		{ID: "40929ca6-ed89-4548-a1d9-54b604ea50b5", Name: "The Shire", Group: dto.GroupResponse{ID: groupID}},
+	}
+	themeViewRepositoryMock := new(storagemocks.ThemeViewRepository)
+	themeViewRepositoryMock.On("FindByGroup", mock.Anything, mock.MatchedBy(func(id domain.GroupID) bool {
+		return id.String() == groupID
+	})).Return(themes, nil).Once()
+	defer themeViewRepositoryMock.AssertExpectations(t)
+
+	themeService := NewThemeService(themeViewRepositoryMock)
+
+	themesDTO, err := themeService.ListThemesByGroup(context.Background(), groupID)
 	assert.NoError(t, err)
-	assert.Len(t, themesDTO, 2)
-	assert.Equal(t, "The History of the Ring", themesDTO[0].Name)
-	assert.Equal(t, "The Rohan Fanfare", themesDTO[1].Name)
+	assert.Len(t, themesDTO, 1)
+	assert.Equal(t, "The Shire", themesDTO[0].Name)
 }
 
 func TestTrackThemeServiceListTrackThemesRepositoryError(t *testing.T) {
-	trackThemeRepositoryMock := new(storagemocks.TrackThemeRepository)
-	trackThemeRepositoryMock.On("FindByTrack", mock.Anything, mock.Anything).Return(nil, errors.New(repositoryErrorMsg)).Once()
-	defer trackThemeRepositoryMock.AssertExpectations(t)
+	trackThemeViewRepositoryMock := new(storagemocks.TrackThemeViewRepository)
+	trackThemeViewRepositoryMock.On("FindByTrack", mock.Anything, mock.Anything).Return(nil, errors.New(repositoryErrorMsg)).Once()
+	defer trackThemeViewRepositoryMock.AssertExpectations(t)
 
-	trackRepositoryMock := new(storagemocks.TrackRepository)
-
-	themeRepositoryMock := new(storagemocks.ThemeRepository)
-	groupRepositoryMock := new(storagemocks.GroupRepository)
-	movieRepositoryMock := new(storagemocks.MovieRepository)
-	categoryRepositoryMock := new(storagemocks.CategoryRepository)
-
-	trackThemeService := NewTrackThemeService(trackThemeRepositoryMock, getGettingTrackServiceMock(trackRepositoryMock, movieRepositoryMock), getGettingThemeServiceMock(themeRepositoryMock, trackRepositoryMock, groupRepositoryMock, movieRepositoryMock, categoryRepositoryMock))
+	trackThemeService := NewTrackThemeService(trackThemeViewRepositoryMock, new(storagemocks.ThemeRepository))
 
 	ctx := context.Background()
 	_, err := trackThemeService.ListTracksThemesByTrack(ctx, "28712a55-04dd-4200-9316-4d6a1e399128")
@@ -361,50 +395,251 @@ func TestTrackThemeServiceListTrackThemesRepositoryError(t *testing.T) {
 
 func TestTrackThemeServiceListTrackThemesSuccess(t *testing.T) {
 	trackID := "28712a55-04dd-4200-9316-4d6a1e399121"
+	trackThemes := []dto.TrackThemeResponse{
+		{
+			Track:       dto.TrackResponse{ID: trackID, Name: "Track"},
+			Theme:       dto.ThemeResponse{ID: "6a4f86e4-4fef-4151-9c60-e467007dd213", Name: "Theme 1"},
+			StartSecond: 0,
+			EndSecond:   10,
+		},
+	}
+	trackThemeViewRepositoryMock := new(storagemocks.TrackThemeViewRepository)
+	trackThemeViewRepositoryMock.On("FindByTrack", mock.Anything, mock.Anything).Return(trackThemes, nil).Once()
+	defer trackThemeViewRepositoryMock.AssertExpectations(t)
+
+	trackThemeService := NewTrackThemeService(trackThemeViewRepositoryMock, new(storagemocks.ThemeRepository))
+
+	themesDTO, err := trackThemeService.ListTracksThemesByTrack(context.Background(), trackID)
+	assert.NoError(t, err)
+	assert.Len(t, themesDTO, 1)
+	assert.Equal(t, "Theme 1", themesDTO[0].Theme.Name)
+}
+
+func TestTrackThemeServiceListTrackThemesByThemeInvalidID(t *testing.T) {
+	trackThemeViewRepositoryMock := new(storagemocks.TrackThemeViewRepository)
+	defer trackThemeViewRepositoryMock.AssertExpectations(t)
+
+	trackThemeService := NewTrackThemeService(trackThemeViewRepositoryMock, new(storagemocks.ThemeRepository))
+
+	_, err := trackThemeService.ListTracksThemesByTheme(context.Background(), "not-a-uuid")
+	assert.ErrorIs(t, err, domain.ErrInvalidThemeID)
+}
+
+func TestTrackThemeServiceListTrackThemesByThemeNotFound(t *testing.T) {
+	trackThemeViewRepositoryMock := new(storagemocks.TrackThemeViewRepository)
+	defer trackThemeViewRepositoryMock.AssertExpectations(t)
+
+	themeRepositoryMock := new(storagemocks.ThemeRepository)
+	themeRepositoryMock.On("Find", mock.Anything, mock.AnythingOfType("domain.ThemeID")).Return(domain.Theme{}, domain.ErrThemeNotFound).Once()
+	defer themeRepositoryMock.AssertExpectations(t)
+
+	trackThemeService := NewTrackThemeService(trackThemeViewRepositoryMock, themeRepositoryMock)
+
+	_, err := trackThemeService.ListTracksThemesByTheme(context.Background(), "6a4f86e4-4fef-4151-9c60-e467007dd213")
+	assert.ErrorIs(t, err, domain.ErrThemeNotFound)
+}
+
+func TestTrackThemeServiceListTrackThemesByThemeSuccess(t *testing.T) {
 	themeID := "6a4f86e4-4fef-4151-9c60-e467007dd213"
-	trackTheme1, err := domain.NewTrackTheme(trackID, themeID, 0, 10, false)
+	trackThemes := []dto.TrackThemeResponse{
+		{
+			Track:       dto.TrackResponse{ID: "28712a55-04dd-4200-9316-4d6a1e399121", Name: "Concerning Hobbits"},
+			Theme:       dto.ThemeResponse{ID: themeID, Name: "The Shire"},
+			StartSecond: 0,
+			EndSecond:   10,
+		},
+		{
+			Track:       dto.TrackResponse{ID: "28712a55-04dd-4200-9316-4d6a1e399122", Name: "The Grey Havens"},
+			Theme:       dto.ThemeResponse{ID: themeID, Name: "The Shire"},
+			StartSecond: 30,
+			EndSecond:   50,
+			IsVariant:   true,
+		},
+	}
+	trackThemeViewRepositoryMock := new(storagemocks.TrackThemeViewRepository)
+	trackThemeViewRepositoryMock.On("FindByTheme", mock.Anything, mock.MatchedBy(func(id domain.ThemeID) bool {
+		return id.String() == themeID
+	})).Return(trackThemes, nil).Once()
+	defer trackThemeViewRepositoryMock.AssertExpectations(t)
+
+	themeRepositoryMock := new(storagemocks.ThemeRepository)
+	themeRepositoryMock.On("Find", mock.Anything, mock.AnythingOfType("domain.ThemeID")).Return(domain.Theme{}, nil).Once()
+	defer themeRepositoryMock.AssertExpectations(t)
+
+	trackThemeService := NewTrackThemeService(trackThemeViewRepositoryMock, themeRepositoryMock)
+
+	trackThemesDTO, err := trackThemeService.ListTracksThemesByTheme(context.Background(), themeID)
 	assert.NoError(t, err)
+	assert.Len(t, trackThemesDTO, 2)
+	assert.Equal(t, "Concerning Hobbits", trackThemesDTO[0].Track.Name)
+	assert.True(t, trackThemesDTO[1].IsVariant)
+}
 
-	trackThemes := []domain.TrackTheme{}
-	trackThemes = append(trackThemes, trackTheme1)
+func TestTrackThemeServiceListTrackThemesByThemeEmpty(t *testing.T) {
+	trackThemeViewRepositoryMock := new(storagemocks.TrackThemeViewRepository)
+	trackThemeViewRepositoryMock.On("FindByTheme", mock.Anything, mock.Anything).Return(nil, nil).Once()
+	defer trackThemeViewRepositoryMock.AssertExpectations(t)
 
-	movie, err := domain.NewMovie("The Return of the King")
+	themeRepositoryMock := new(storagemocks.ThemeRepository)
+	themeRepositoryMock.On("Find", mock.Anything, mock.AnythingOfType("domain.ThemeID")).Return(domain.Theme{}, nil).Once()
+	defer themeRepositoryMock.AssertExpectations(t)
+
+	trackThemeService := NewTrackThemeService(trackThemeViewRepositoryMock, themeRepositoryMock)
+
+	trackThemesDTO, err := trackThemeService.ListTracksThemesByTheme(context.Background(), "6a4f86e4-4fef-4151-9c60-e467007dd213")
 	assert.NoError(t, err)
+	assert.NotNil(t, trackThemesDTO)
+	assert.Empty(t, trackThemesDTO)
+}
+
+func TestTrashServiceListTrashRepositoryError(t *testing.T) {
+	trashRepositoryMock := new(storagemocks.TrashRepository)
+	trashRepositoryMock.On("FindPage", mock.Anything, mock.Anything).Return(nil, nil, errors.New(repositoryErrorMsg)).Once()
+	defer trashRepositoryMock.AssertExpectations(t)
+
+	trashService := NewTrashService(trashRepositoryMock)
+
+	_, err := trashService.ListTrash(context.Background(), 0, "")
+	assert.EqualError(t, err, repositoryErrorMsg)
+}
+
+func TestTrashServiceListTrashInvalidCursor(t *testing.T) {
+	trashRepositoryMock := new(storagemocks.TrashRepository)
+
+	trashService := NewTrashService(trashRepositoryMock)
 
-	group, err := domain.NewGroup("Gondor", "Description of Gondor", "http://example.com/gondor.jpg")
+	_, err := trashService.ListTrash(context.Background(), 0, "not-a-cursor")
+	assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+	trashRepositoryMock.AssertNotCalled(t, "FindPage", mock.Anything, mock.Anything)
+}
+
+func TestTrashServiceListTrashEmpty(t *testing.T) {
+	trashRepositoryMock := new(storagemocks.TrashRepository)
+	trashRepositoryMock.On("FindPage", mock.Anything, mock.Anything).Return(nil, nil, nil).Once()
+	defer trashRepositoryMock.AssertExpectations(t)
+
+	trashService := NewTrashService(trashRepositoryMock)
+
+	trash, err := trashService.ListTrash(context.Background(), 0, "")
 	assert.NoError(t, err)
+	assert.NotNil(t, trash.Items)
+	assert.Empty(t, trash.Items)
+	assert.Nil(t, trash.NextCursor)
+}
+
+func TestAuditServiceListAuditInvalidFilter(t *testing.T) {
+	auditRepositoryMock := new(storagemocks.AuditRepository)
+
+	auditService := NewAuditService(auditRepositoryMock)
+
+	_, err := auditService.ListAudit(context.Background(), 0, "", "", "", "yesterday")
+	assert.ErrorIs(t, err, domain.ErrInvalidAuditSince)
+	auditRepositoryMock.AssertNotCalled(t, "FindPage", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuditServiceListAuditInvalidLimit(t *testing.T) {
+	auditRepositoryMock := new(storagemocks.AuditRepository)
+
+	auditService := NewAuditService(auditRepositoryMock)
 
-	track, err := domain.NewTrack("Track", trackID, nil)
+	_, err := auditService.ListAudit(context.Background(), domain.MaxPageLimit+1, "", "", "", "")
+	assert.ErrorIs(t, err, domain.ErrInvalidPageLimit)
+	auditRepositoryMock.AssertNotCalled(t, "FindPage", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuditServiceListAuditSuccess(t *testing.T) {
+	entry, err := domain.NewAuditEntry("command.delete.movie", domain.AuditEntityMovie, "6a4f86e4-4fef-4151-9c60-e467007dd213", "", []byte(`{"name":"The Two Towers"}`), nil, time.Now())
 	assert.NoError(t, err)
+	next := domain.NewCursor("2024-03-01T12:00:00Z", entry.ID())
+
+	auditRepositoryMock := new(storagemocks.AuditRepository)
+	auditRepositoryMock.On("FindPage", mock.Anything, mock.MatchedBy(func(filter domain.AuditFilter) bool {
+		return filter.Entity() == domain.AuditEntityMovie && filter.UserID() == nil && filter.Since() == nil
+	}), mock.MatchedBy(func(page domain.PageRequest) bool {
+		return page.Limit() == 1 && page.After() == nil
+	})).Return([]domain.AuditEntry{entry}, &next, nil).Once()
+	defer auditRepositoryMock.AssertExpectations(t)
 
-	theme, err := domain.NewTheme("Theme 1", themeID, "40929ca6-ed89-4548-a1d9-54b604ea50b2", "Description", 0, 1, nil)
+	auditService := NewAuditService(auditRepositoryMock)
+
+	audit, err := auditService.ListAudit(context.Background(), 1, "", "movie", "", "")
 	assert.NoError(t, err)
+	assert.Len(t, audit.Items, 1)
+	assert.Equal(t, "6a4f86e4-4fef-4151-9c60-e467007dd213", *audit.Items[0].EntityID)
+	assert.Nil(t, audit.Items[0].UserID)
+	require.NotNil(t, audit.NextCursor)
+	assert.Equal(t, next.String(), *audit.NextCursor)
+}
 
-	trackThemeRepositoryMock := new(storagemocks.TrackThemeRepository)
-	trackThemeRepositoryMock.On("FindByTrack", mock.Anything, mock.Anything).Return(trackThemes, nil).Once()
-	defer trackThemeRepositoryMock.AssertExpectations(t)
+func TestWebhookServiceListWebhooksHidesSecret(t *testing.T) {
+	webhook, err := domain.NewWebhook("https://hooks.example.com/middle-earth", "a-very-secret-signing-key", []string{string(domain.MovieCreatedEventType)})
+	require.NoError(t, err)
 
-	trackRepositoryMock := new(storagemocks.TrackRepository)
-	trackRepositoryMock.On("Find", mock.Anything, mock.Anything).Return(track, nil).Twice()
-	defer trackRepositoryMock.AssertExpectations(t)
+	webhookRepositoryMock := new(storagemocks.WebhookRepository)
+	webhookRepositoryMock.On("FindAll", mock.Anything).Return([]domain.Webhook{webhook}, nil).Once()
+	defer webhookRepositoryMock.AssertExpectations(t)
 
-	movieRepositoryMock := new(storagemocks.MovieRepository)
-	movieRepositoryMock.On("Find", mock.Anything, mock.Anything).Return(movie, nil).Twice()
-	defer movieRepositoryMock.AssertExpectations(t)
+	webhookService := NewWebhookService(webhookRepositoryMock, new(storagemocks.WebhookDeliveryRepository))
 
-	themeRepositoryMock := new(storagemocks.ThemeRepository)
-	themeRepositoryMock.On("Find", mock.Anything, mock.Anything).Return(theme, nil).Once()
-	defer themeRepositoryMock.AssertExpectations(t)
+	webhooks, err := webhookService.ListWebhooks(context.Background())
+	require.NoError(t, err)
+	require.Len(t, webhooks.Webhooks, 1)
+	assert.Equal(t, dto.WebhookResponse{
+		ID:         webhook.ID().String(),
+		URL:        "https://hooks.example.com/middle-earth",
+		EventTypes: []string{string(domain.MovieCreatedEventType)},
+	}, webhooks.Webhooks[0])
+}
 
-	groupRepositoryMock := new(storagemocks.GroupRepository)
-	groupRepositoryMock.On("Find", mock.Anything, mock.Anything).Return(group, nil).Once()
-	defer groupRepositoryMock.AssertExpectations(t)
+func TestWebhookServiceListDeliveriesNotFound(t *testing.T) {
+	webhookRepositoryMock := new(storagemocks.WebhookRepository)
+	webhookRepositoryMock.On("Find", mock.Anything, mock.AnythingOfType("domain.WebhookID")).Return(domain.Webhook{}, domain.ErrWebhookNotFound).Once()
+	defer webhookRepositoryMock.AssertExpectations(t)
+	deliveryRepositoryMock := new(storagemocks.WebhookDeliveryRepository)
 
-	categoryRepositoryMock := new(storagemocks.CategoryRepository)
+	webhookService := NewWebhookService(webhookRepositoryMock, deliveryRepositoryMock)
 
-	trackThemeService := NewTrackThemeService(trackThemeRepositoryMock, getGettingTrackServiceMock(trackRepositoryMock, movieRepositoryMock), getGettingThemeServiceMock(themeRepositoryMock, trackRepositoryMock, groupRepositoryMock, movieRepositoryMock, categoryRepositoryMock))
+	_, err := webhookService.ListDeliveries(context.Background(), "0c1d7b4e-2f1a-4a5e-9a77-3c9b4f0e6a11", 0, "")
+	assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+	deliveryRepositoryMock.AssertNotCalled(t, "FindPage", mock.Anything, mock.Anything, mock.Anything)
+}
 
-	themesDTO, err := trackThemeService.ListTracksThemesByTrack(context.Background(), trackID)
-	assert.NoError(t, err)
-	assert.Len(t, themesDTO, 1)
+func TestWebhookServiceListDeliveriesInvalidCursor(t *testing.T) {
+	webhookRepositoryMock := new(storagemocks.WebhookRepository)
+	deliveryRepositoryMock := new(storagemocks.WebhookDeliveryRepository)
+
+	webhookService := NewWebhookService(webhookRepositoryMock, deliveryRepositoryMock)
+
+	_, err := webhookService.ListDeliveries(context.Background(), "0c1d7b4e-2f1a-4a5e-9a77-3c9b4f0e6a11", 0, "not-a-cursor")
+	assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+	webhookRepositoryMock.AssertNotCalled(t, "Find", mock.Anything, mock.Anything)
+}
+
+func TestWebhookServiceListDeliveriesSuccess(t *testing.T) {
+	webhook, err := domain.NewWebhook("https://hooks.example.com/middle-earth", "a-very-secret-signing-key", []string{string(domain.MovieCreatedEventType)})
+	require.NoError(t, err)
+	pending, err := domain.NewPendingWebhookDelivery(webhook.ID(), domain.NewMovieCreatedEvent("6a4f86e4-4fef-4151-9c60-e467007dd213", "The Two Towers"), 1, time.Now())
+	require.NoError(t, err)
+	delivery, err := pending.Made(0, "connection refused", time.Now())
+	require.NoError(t, err)
+	next := domain.NewCursor("2024-03-01T12:00:00Z", delivery.ID())
+
+	webhookRepositoryMock := new(storagemocks.WebhookRepository)
+	webhookRepositoryMock.On("Find", mock.Anything, webhook.ID()).Return(webhook, nil).Once()
+	defer webhookRepositoryMock.AssertExpectations(t)
+	deliveryRepositoryMock := new(storagemocks.WebhookDeliveryRepository)
+	deliveryRepositoryMock.On("FindPage", mock.Anything, webhook.ID(), mock.MatchedBy(func(page domain.PageRequest) bool {
+		return page.Limit() == 1 && page.After() == nil
+	})).Return([]domain.WebhookDelivery{delivery}, &next, nil).Once()
+	defer deliveryRepositoryMock.AssertExpectations(t)
+
+	webhookService := NewWebhookService(webhookRepositoryMock, deliveryRepositoryMock)
+
+	deliveries, err := webhookService.ListDeliveries(context.Background(), webhook.ID().String(), 1, "")
+	require.NoError(t, err)
+	require.Len(t, deliveries.Items, 1)
+	assert.Nil(t, deliveries.Items[0].StatusCode)
+	assert.Equal(t, "connection refused", *deliveries.Items[0].Error)
+	require.NotNil(t, deliveries.NextCursor)
+	assert.Equal(t, next.String(), *deliveries.NextCursor)
 }
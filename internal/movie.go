@@ -4,12 +4,28 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+
 	"github.com/google/uuid"
 )
 
 var ErrInvalidMovieID = fmt.Errorf("invalid movie ID")
 var ErrInvalidMovieName = fmt.Errorf("invalid movie name")
 var ErrMovieNotFound = fmt.Errorf("movie not found")
+var ErrInvalidReleaseYear = fmt.Errorf("invalid release year")
+var ErrInvalidMovieSequence = fmt.Errorf("invalid movie sequence")
+var ErrInvalidMovieSeries = fmt.Errorf("invalid movie series")
+var ErrInvalidMovieRuntime = fmt.Errorf("invalid movie runtime")
+var ErrMovieSequenceAlreadyExists = fmt.Errorf("movie sequence already exists")
+
+// Series a movie belongs to.
+const (
+	MovieSeriesLordOfTheRings = "lotr"
+	MovieSeriesHobbit         = "hobbit"
+)
+
+// firstReleaseYear is the year the first motion picture was shot.
+const firstReleaseYear = 1888
 
 type MovieID struct {
 	value string
@@ -63,54 +79,195 @@ func (n MovieName) String() string {
 	return n.value
 }
 
+// ReleaseYear is the year a movie was released in theatres.
+type ReleaseYear struct {
+	value int
+}
+
+func NewReleaseYear(value int) (ReleaseYear, error) {
+	if value < firstReleaseYear {
+		return ReleaseYear{}, ErrInvalidReleaseYear
+	}
+
+	return ReleaseYear{
+		value: value,
+	}, nil
+}
+
+func (y ReleaseYear) Int() int {
+	return y.value
+}
+
+// MovieSequence is the position of a movie when the films are displayed in order.
+type MovieSequence struct {
+	value int
+}
+
+func NewMovieSequence(value int) (MovieSequence, error) {
+	if value < 1 {
+		return MovieSequence{}, ErrInvalidMovieSequence
+	}
+
+	return MovieSequence{
+		value: value,
+	}, nil
+}
+
+func (s MovieSequence) Int() int {
+	return s.value
+}
+
+// MovieSeries is the trilogy a movie belongs to.
+type MovieSeries struct {
+	value string
+}
+
+func NewMovieSeries(value string) (MovieSeries, error) {
+	switch value {
+	case MovieSeriesLordOfTheRings, MovieSeriesHobbit:
+		return MovieSeries{
+			value: value,
+		}, nil
+	default:
+		return MovieSeries{}, ErrInvalidMovieSeries
+	}
+}
+
+func (s MovieSeries) String() string {
+	return s.value
+}
+
+// MovieRuntime is the length of a movie in minutes.
+type MovieRuntime struct {
+	minutes int
+}
+
+func NewMovieRuntime(minutes int) (MovieRuntime, error) {
+	if minutes < 1 {
+		return MovieRuntime{}, ErrInvalidMovieRuntime
+	}
+
+	return MovieRuntime{
+		minutes: minutes,
+	}, nil
+}
+
+func (r MovieRuntime) Minutes() int {
+	return r.minutes
+}
+
+// MovieFilter narrows a list of movies. Every criterion is optional.
+type MovieFilter struct {
+	series *MovieSeries
+}
+
+// NewMovieFilter creates a new MovieFilter instance. An empty series matches every movie.
+func NewMovieFilter(series string) (MovieFilter, error) {
+	if series == "" {
+		return MovieFilter{}, nil
+	}
+
+	seriesVO, err := NewMovieSeries(series)
+	if err != nil {
+		return MovieFilter{}, err
+	}
+
+	return MovieFilter{
+		series: &seriesVO,
+	}, nil
+}
+
+func (f MovieFilter) Series() *MovieSeries {
+	return f.series
+}
+
 type MovieRepository interface {
 	Save(ctx context.Context, movie Movie) error
 	Find(ctx context.Context, id MovieID) (Movie, error)
+	FindInTrash(ctx context.Context, id MovieID) (Movie, error) // Finds the movie only if it is in the trash
 	FindAll(ctx context.Context) ([]Movie, error)
-	Delete(ctx context.Context, id MovieID) error
+	FindPage(ctx context.Context, filter MovieFilter, page PageRequest) ([]Movie, *Cursor, error)
+	Delete(ctx context.Context, id MovieID) error  // Moves the movie to the trash
+	Restore(ctx context.Context, id MovieID) error // Takes the movie out of the trash
+	Purge(ctx context.Context, id MovieID) error   // Deletes the movie for good, once in the trash
 	Update(ctx context.Context, movie Movie) error
 }
 
 //go:generate mockery --case=snake --outpkg=storagemocks --output=platform/storage/storagemocks --name=MovieRepository
 
 type Movie struct {
-	id   MovieID
-	name MovieName
+	id          MovieID
+	name        MovieName
+	releaseYear ReleaseYear
+	sequence    MovieSequence
+	series      MovieSeries
+	runtime     *MovieRuntime // Optional
+
+	events []event.Event
 }
 
-func NewMovie(name string) (Movie, error) {
+func NewMovie(name string, releaseYear, sequence int, series string, runtimeMinutes *int) (Movie, error) {
 	idVO, err := NewMovieID()
 	if err != nil {
 		return Movie{}, err
 	}
 
-	nameVO, err := NewMovieName(name)
+	movie, err := newMovie(idVO, name, releaseYear, sequence, series, runtimeMinutes)
 	if err != nil {
 		return Movie{}, err
 	}
 
-	movie := Movie{
-		id:   idVO,
-		name: nameVO,
-	}
+	movie.Record(NewMovieCreatedEvent(movie.ID().String(), movie.Name().String()))
 
 	return movie, nil
 }
 
-func NewMovieWithID(id, name string) (Movie, error) {
+func NewMovieWithID(id, name string, releaseYear, sequence int, series string, runtimeMinutes *int) (Movie, error) {
 	idVO, err := NewMovieIDFromString(id)
 	if err != nil {
 		return Movie{}, err
 	}
 
+	return newMovie(idVO, name, releaseYear, sequence, series, runtimeMinutes)
+}
+
+func newMovie(id MovieID, name string, releaseYear, sequence int, series string, runtimeMinutes *int) (Movie, error) {
 	nameVO, err := NewMovieName(name)
 	if err != nil {
 		return Movie{}, err
 	}
 
+	releaseYearVO, err := NewReleaseYear(releaseYear)
+	if err != nil {
+		return Movie{}, err
+	}
+
+	sequenceVO, err := NewMovieSequence(sequence)
+	if err != nil {
+		return Movie{}, err
+	}
+
+	seriesVO, err := NewMovieSeries(series)
+	if err != nil {
+		return Movie{}, err
+	}
+
+	var runtimeVO *MovieRuntime
+	if runtimeMinutes != nil {
+		runtimeValue, err := NewMovieRuntime(*runtimeMinutes)
+		if err != nil {
+			return Movie{}, err
+		}
+		runtimeVO = &runtimeValue
+	}
+
 	movie := Movie{
-		id:   idVO,
-		name: nameVO,
+		id:          id,
+		name:        nameVO,
+		releaseYear: releaseYearVO,
+		sequence:    sequenceVO,
+		series:      seriesVO,
+		runtime:     runtimeVO,
 	}
 
 	return movie, nil
@@ -123,3 +280,32 @@ func (m Movie) ID() MovieID {
 func (m Movie) Name() MovieName {
 	return m.name
 }
+
+func (m Movie) ReleaseYear() ReleaseYear {
+	return m.releaseYear
+}
+
+func (m Movie) Sequence() MovieSequence {
+	return m.sequence
+}
+
+func (m Movie) Series() MovieSeries {
+	return m.series
+}
+
+func (m Movie) Runtime() *MovieRuntime {
+	return m.runtime
+}
+
+// Record adds an event to the movie's event list.
+func (m *Movie) Record(event event.Event) {
+	m.events = append(m.events, event)
+}
+
+// PullEvents returns the events recorded for the movie and clears the event list.
+func (m *Movie) PullEvents() []event.Event {
+	events := m.events
+	m.events = nil
+
+	return events
+}
@@ -0,0 +1,22 @@
+package notifying
+
+import (
+	"context"
+
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+)
+
+// NotifyWebhooksOnEvent posts the events it handles to the webhooks subscribed to them.
+type NotifyWebhooksOnEvent struct {
+	webhookService WebhookService
+}
+
+func NewNotifyWebhooksOnEvent(webhookService WebhookService) NotifyWebhooksOnEvent {
+	return NotifyWebhooksOnEvent{
+		webhookService: webhookService,
+	}
+}
+
+func (h NotifyWebhooksOnEvent) Handle(ctx context.Context, e event.Event) error {
+	return h.webhookService.Notify(ctx, e)
+}
@@ -0,0 +1,189 @@
+package notifying
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/tx"
+)
+
+// Sender posts an event to a webhook. It returns the status code of the response, zero
+// when none was received, and an error unless the webhook accepted the event.
+type Sender interface {
+	Send(ctx context.Context, webhook domain.Webhook, e event.Event) (statusCode int, err error)
+}
+
+// WebhookService posts the domain events to the webhooks subscribed to them, recording
+// every attempt in the delivery log.
+//
+// Notify only schedules the first attempt of every delivery, in the delivery log, which
+// keeps it until it is made: an event is not lost once Notify returns, even if the API
+// stops right after. A pool of workers, started with Run, claims the attempts as they
+// fall due and makes them. A failed attempt schedules the next one after a backoff
+// rather than waiting for it, so that an unreachable webhook holds up a worker for no
+// longer than one request.
+type WebhookService struct {
+	webhookRepository  domain.WebhookRepository
+	deliveryRepository domain.WebhookDeliveryRepository
+	txManager          tx.Manager
+	sender             Sender
+	workers            int
+	interval           time.Duration // Wait between two polls of the delivery log
+	lease              time.Duration // Time a claimed attempt has to be made before it can be claimed again
+	maxAttempts        int
+	backoff            time.Duration // Wait before the second attempt, doubled before each further one
+	now                func() time.Time
+}
+
+func NewWebhookService(webhookRepository domain.WebhookRepository, deliveryRepository domain.WebhookDeliveryRepository, txManager tx.Manager, sender Sender, workers int, interval, lease time.Duration, maxAttempts int, backoff time.Duration) WebhookService {
+	return WebhookService{
+		webhookRepository:  webhookRepository,
+		deliveryRepository: deliveryRepository,
+		txManager:          txManager,
+		sender:             sender,
+		workers:            max(workers, 1),
+		interval:           interval,
+		lease:              lease,
+		maxAttempts:        max(maxAttempts, 1),
+		backoff:            backoff,
+		now:                time.Now,
+	}
+}
+
+// Notify schedules the event for every webhook subscribed to it. A handler that is run
+// again for the same event does not post it twice: the first attempt is only scheduled
+// once. The error returned is about the webhooks that could not be loaded or scheduled;
+// how the deliveries went only shows in the delivery log.
+func (s WebhookService) Notify(ctx context.Context, e event.Event) error {
+	webhooks, err := s.webhookRepository.FindByEventType(ctx, e.Type())
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+	for _, webhook := range webhooks {
+		delivery, err := domain.NewPendingWebhookDelivery(webhook.ID(), e, 1, now)
+		if err != nil {
+			return err
+		}
+		// The webhook may have been removed since it was loaded
+		if err := s.deliveryRepository.Schedule(ctx, delivery); err != nil && !errors.Is(err, domain.ErrWebhookNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run makes the attempts as they fall due until ctx is done, polling the delivery log
+// every interval. It then lets the attempts in progress finish and makes the ones due by
+// then, so that the events notified during the shutdown are not left waiting for the
+// next start, and returns the error of that last pass. The attempts due later stay in
+// the delivery log.
+func (s WebhookService) Run(ctx context.Context) error {
+	s.spawn(func() error {
+		s.poll(ctx)
+		return nil
+	})
+	return s.spawn(func() error {
+		return s.drain(context.WithoutCancel(ctx))
+	})
+}
+
+// spawn runs fn on every worker and waits for them all to return.
+func (s WebhookService) spawn(fn func() error) error {
+	errs := make([]error, s.workers)
+	var wg sync.WaitGroup
+	wg.Add(s.workers)
+	for i := range s.workers {
+		go func() {
+			defer wg.Done()
+			errs[i] = fn()
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// poll drains the due attempts every interval until ctx is done.
+func (s WebhookService) poll(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.drain(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("failed to deliver the webhook events: %v", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// drain makes the due attempts, one at a time, until there are no more to claim or ctx
+// is done.
+func (s WebhookService) drain(ctx context.Context) error {
+	for ctx.Err() == nil {
+		deliveries, err := s.deliveryRepository.Claim(ctx, s.now(), s.lease, 1)
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+		// An attempt that is started is seen through, even if ctx is done meanwhile
+		if err := s.deliver(context.WithoutCancel(ctx), deliveries[0]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deliver makes the attempt at posting the event to the webhook and records it, along
+// with the next attempt after its backoff unless the webhook accepted the event or
+// maxAttempts is reached.
+func (s WebhookService) deliver(ctx context.Context, d domain.PendingWebhookDelivery) error {
+	webhook, err := s.webhookRepository.Find(ctx, d.WebhookID())
+	if err != nil {
+		// The webhook was removed, and its deliveries along with it
+		if errors.Is(err, domain.ErrWebhookNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	statusCode, sendErr := s.sender.Send(ctx, webhook, d.Event())
+
+	var message string
+	if sendErr != nil {
+		message = sendErr.Error()
+	}
+	record, err := d.Made(statusCode, message, s.now())
+	if err != nil {
+		return err
+	}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.deliveryRepository.Record(ctx, record); err != nil {
+			return err
+		}
+		if sendErr == nil || d.Attempt() >= s.maxAttempts {
+			return nil
+		}
+
+		next, err := d.Retry(record.DeliveredAt().Add(s.backoff << (d.Attempt() - 1)))
+		if err != nil {
+			return err
+		}
+		return s.deliveryRepository.Schedule(ctx, next)
+	})
+	// The webhook was removed while the event was being delivered
+	if errors.Is(err, domain.ErrWebhookNotFound) {
+		return nil
+	}
+	return err
+}
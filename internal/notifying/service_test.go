@@ -0,0 +1,263 @@
+package notifying
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/storage/inmemory"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/storage/storagemocks"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/tx/txmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// senderFunc adapts a function to the Sender interface.
+type senderFunc func(ctx context.Context, webhook domain.Webhook, e event.Event) (int, error)
+
+func (f senderFunc) Send(ctx context.Context, webhook domain.Webhook, e event.Event) (int, error) {
+	return f(ctx, webhook, e)
+}
+
+// fixture holds the repositories of a service backed by an in-memory store.
+type fixture struct {
+	webhooks   *inmemory.WebhookRepository
+	deliveries *inmemory.WebhookDeliveryRepository
+	txManager  *inmemory.TxManager
+}
+
+func newFixture() fixture {
+	store := inmemory.NewStore()
+	return fixture{
+		webhooks:   inmemory.NewWebhookRepository(store),
+		deliveries: inmemory.NewWebhookDeliveryRepository(store),
+		txManager:  inmemory.NewTxManager(store),
+	}
+}
+
+// service returns a service with a single worker that polls every millisecond.
+func (f fixture) service(sender Sender, maxAttempts int, backoff time.Duration) WebhookService {
+	return NewWebhookService(f.webhooks, f.deliveries, f.txManager, sender, 1, time.Millisecond, time.Minute, maxAttempts, backoff)
+}
+
+// webhook saves a webhook subscribed to the movies created.
+func (f fixture) webhook(t *testing.T) domain.Webhook {
+	t.Helper()
+
+	webhook, err := domain.NewWebhook("https://hooks.example.com/middle-earth", "a-very-secret-signing-key", []string{string(domain.MovieCreatedEventType)})
+	require.NoError(t, err)
+	require.NoError(t, f.webhooks.Save(context.Background(), webhook))
+	return webhook
+}
+
+// log returns the first page of the attempts made at delivering to the webhook, newest
+// first.
+func (f fixture) log(webhook domain.Webhook) ([]domain.WebhookDelivery, error) {
+	page, err := domain.NewPageRequest(0, "")
+	if err != nil {
+		return nil, err
+	}
+	deliveries, _, err := f.deliveries.FindPage(context.Background(), webhook.ID(), page)
+	return deliveries, err
+}
+
+// made waits for n attempts to be made at delivering to the webhook and returns them,
+// newest first.
+func (f fixture) made(t *testing.T, webhook domain.Webhook, n int) []domain.WebhookDelivery {
+	t.Helper()
+
+	var deliveries []domain.WebhookDelivery
+	require.Eventually(t, func() bool {
+		var err error
+		deliveries, err = f.log(webhook)
+		return err == nil && len(deliveries) >= n
+	}, time.Second, time.Millisecond)
+	require.Len(t, deliveries, n)
+	return deliveries
+}
+
+// run starts the workers of the service until the test ends.
+func run(t *testing.T, service WebhookService) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- service.Run(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		assert.NoError(t, <-done)
+	})
+}
+
+func TestWebhookServiceNotifyRetriesUntilAccepted(t *testing.T) {
+	f := newFixture()
+	webhook := f.webhook(t)
+	e := domain.NewMovieCreatedEvent("6a4f86e4-4fef-4151-9c60-e467007dd213", "The Two Towers")
+
+	var attempts atomic.Int32
+	sender := senderFunc(func(context.Context, domain.Webhook, event.Event) (int, error) {
+		if attempts.Add(1) < 3 {
+			return 503, errors.New("unexpected status 503")
+		}
+		return 204, nil
+	})
+
+	service := f.service(sender, 5, time.Millisecond)
+	run(t, service)
+
+	err := service.Notify(context.Background(), e)
+	require.NoError(t, err)
+
+	deliveries := f.made(t, webhook, 3)
+	for i, delivery := range deliveries {
+		assert.Equal(t, 3-i, delivery.Attempt())
+		assert.Equal(t, e.ID(), delivery.EventID())
+		assert.Equal(t, webhook.ID(), delivery.WebhookID())
+	}
+	assert.True(t, deliveries[0].Succeeded())
+	assert.Equal(t, 204, deliveries[0].StatusCode())
+	assert.False(t, deliveries[1].Succeeded())
+	assert.Equal(t, 503, deliveries[1].StatusCode())
+}
+
+func TestWebhookServiceNotifyGivesUpAfterMaxAttempts(t *testing.T) {
+	f := newFixture()
+	failing := f.webhook(t)
+	accepting := f.webhook(t)
+	e := domain.NewMovieCreatedEvent("6a4f86e4-4fef-4151-9c60-e467007dd213", "The Two Towers")
+
+	sender := senderFunc(func(_ context.Context, webhook domain.Webhook, _ event.Event) (int, error) {
+		if webhook.ID() == failing.ID() {
+			return 0, errors.New("connection refused")
+		}
+		return 200, nil
+	})
+
+	// A single worker, which the failing webhook does not hold while it backs off
+	service := f.service(sender, 2, 20*time.Millisecond)
+	run(t, service)
+
+	err := service.Notify(context.Background(), e)
+	require.NoError(t, err)
+
+	accepted := f.made(t, accepting, 1)
+	assert.True(t, accepted[0].Succeeded())
+
+	failed := f.made(t, failing, 2)
+	assert.Equal(t, 2, failed[0].Attempt())
+	assert.Equal(t, "connection refused", failed[0].Err())
+	assert.True(t, accepted[0].DeliveredAt().Before(failed[0].DeliveredAt()))
+
+	// No attempt is left to be made
+	pending, err := f.deliveries.Claim(context.Background(), time.Now().Add(time.Hour), time.Minute, 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestWebhookServiceNotifyIgnoresScheduledEvents(t *testing.T) {
+	f := newFixture()
+	webhook := f.webhook(t)
+	e := domain.NewMovieCreatedEvent("6a4f86e4-4fef-4151-9c60-e467007dd213", "The Two Towers")
+
+	var sent atomic.Int32
+	sender := senderFunc(func(context.Context, domain.Webhook, event.Event) (int, error) {
+		sent.Add(1)
+		return 204, nil
+	})
+
+	service := f.service(sender, 5, time.Millisecond)
+
+	// A handler run again for the same event
+	require.NoError(t, service.Notify(context.Background(), e))
+	require.NoError(t, service.Notify(context.Background(), e))
+	run(t, service)
+
+	f.made(t, webhook, 1)
+	require.NoError(t, service.Notify(context.Background(), e))
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, int32(1), sent.Load())
+}
+
+func TestWebhookServiceNotifyStopsOnRemovedWebhook(t *testing.T) {
+	f := newFixture()
+	f.webhook(t)
+	e := domain.NewMovieCreatedEvent("6a4f86e4-4fef-4151-9c60-e467007dd213", "The Two Towers")
+
+	sent := make(chan struct{}, 4)
+	sender := senderFunc(func(ctx context.Context, webhook domain.Webhook, _ event.Event) (int, error) {
+		// The webhook is removed while the event is being delivered
+		assert.NoError(t, f.webhooks.Delete(ctx, webhook.ID()))
+		sent <- struct{}{}
+		return 500, errors.New("unexpected status 500")
+	})
+
+	service := f.service(sender, 5, time.Millisecond)
+	run(t, service)
+
+	err := service.Notify(context.Background(), e)
+	require.NoError(t, err)
+
+	<-sent
+	select {
+	case <-sent:
+		assert.Fail(t, "a removed webhook was retried")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestWebhookServiceNotifyScheduleError(t *testing.T) {
+	webhook, err := domain.NewWebhook("https://hooks.example.com/middle-earth", "a-very-secret-signing-key", []string{string(domain.MovieCreatedEventType)})
+	require.NoError(t, err)
+	e := domain.NewMovieCreatedEvent("6a4f86e4-4fef-4151-9c60-e467007dd213", "The Two Towers")
+
+	webhookRepositoryMock := new(storagemocks.WebhookRepository)
+	webhookRepositoryMock.On("FindByEventType", mock.Anything, event.Type(domain.MovieCreatedEventType)).Return([]domain.Webhook{webhook}, nil).Once()
+	defer webhookRepositoryMock.AssertExpectations(t)
+
+	deliveryRepositoryMock := new(storagemocks.WebhookDeliveryRepository)
+	deliveryRepositoryMock.On("Schedule", mock.Anything, mock.AnythingOfType("domain.PendingWebhookDelivery")).Return(errors.New("insert error")).Once()
+	defer deliveryRepositoryMock.AssertExpectations(t)
+
+	sender := senderFunc(func(context.Context, domain.Webhook, event.Event) (int, error) {
+		return 200, nil
+	})
+
+	service := NewWebhookService(webhookRepositoryMock, deliveryRepositoryMock, new(txmocks.Manager), sender, 1, time.Millisecond, time.Minute, 5, time.Millisecond)
+
+	err = service.Notify(context.Background(), e)
+	assert.Error(t, err)
+}
+
+func TestWebhookServiceRunDrainsOnCancel(t *testing.T) {
+	f := newFixture()
+	webhook := f.webhook(t)
+	e := domain.NewMovieCreatedEvent("6a4f86e4-4fef-4151-9c60-e467007dd213", "The Two Towers")
+
+	sender := senderFunc(func(context.Context, domain.Webhook, event.Event) (int, error) {
+		return 0, errors.New("connection refused")
+	})
+
+	service := f.service(sender, 5, time.Hour)
+	require.NoError(t, service.Notify(context.Background(), e))
+
+	// The attempt due is made even though the service is stopped already
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, service.Run(ctx))
+
+	deliveries, err := f.log(webhook)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+
+	// The retry, due later, is left for the next start
+	pending, err := f.deliveries.Claim(context.Background(), time.Now().Add(2*time.Hour), time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, 2, pending[0].Attempt())
+	assert.Equal(t, e.ID(), pending[0].Event().ID())
+}
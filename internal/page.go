@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidPageLimit = errors.New("invalid page limit")
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+const (
+	// DefaultPageLimit is the page size used when the client does not ask for one.
+	DefaultPageLimit = 50
+	// MaxPageLimit is the largest page size a client can ask for.
+	MaxPageLimit = 200
+)
+
+// Cursor identifies the last element of a page, so the next page can start right after it.
+// The key holds the value of the column the page is sorted by.
+type Cursor struct {
+	key string
+	id  string
+}
+
+type cursorPayload struct {
+	Key string `json:"k"`
+	ID  string `json:"i"`
+}
+
+// NewCursor creates a new Cursor instance.
+func NewCursor(key, id string) Cursor {
+	return Cursor{
+		key: key,
+		id:  id,
+	}
+}
+
+// NewCursorFromString decodes a cursor previously returned to a client.
+func NewCursorFromString(value string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	if _, err := uuid.Parse(payload.ID); err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return Cursor{
+		key: payload.Key,
+		id:  payload.ID,
+	}, nil
+}
+
+// Key returns the sort key of the element the cursor points at.
+func (c Cursor) Key() string {
+	return c.key
+}
+
+// ID returns the ID of the element the cursor points at.
+func (c Cursor) ID() string {
+	return c.id
+}
+
+// String returns the opaque representation of the cursor handed to clients.
+func (c Cursor) String() string {
+	raw, _ := json.Marshal(cursorPayload{Key: c.key, ID: c.id})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// AsStringPtr returns the opaque representation of the cursor, or nil when there is no cursor.
+func (c *Cursor) AsStringPtr() *string {
+	if c == nil {
+		return nil
+	}
+	value := c.String()
+	return &value
+}
+
+// PageRequest describes which slice of a collection a client wants.
+type PageRequest struct {
+	limit int
+	after *Cursor
+}
+
+// NewPageRequest creates a new PageRequest instance. A zero limit means DefaultPageLimit
+// and an empty cursor means the first page.
+func NewPageRequest(limit int, cursor string) (PageRequest, error) {
+	if limit == 0 {
+		limit = DefaultPageLimit
+	}
+	if limit < 0 || limit > MaxPageLimit {
+		return PageRequest{}, ErrInvalidPageLimit
+	}
+
+	var afterVO *Cursor
+	if cursor != "" {
+		cursorValue, err := NewCursorFromString(cursor)
+		if err != nil {
+			return PageRequest{}, err
+		}
+		afterVO = &cursorValue
+	}
+
+	return PageRequest{
+		limit: limit,
+		after: afterVO,
+	}, nil
+}
+
+// Limit returns the maximum number of elements in the page.
+func (p PageRequest) Limit() int {
+	return p.limit
+}
+
+// After returns the cursor the page starts after, or nil for the first page.
+func (p PageRequest) After() *Cursor {
+	return p.after
+}
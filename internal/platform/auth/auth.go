@@ -1,11 +1,16 @@
 package auth
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"time"
 
 	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -13,9 +18,12 @@ type JWTKey = []byte
 
 const passwordMinLength = 8
 
+// tokenBytes is the number of random bytes of an opaque token.
+const tokenBytes = 32
+
 func HashPassword(password string) (string, error) {
 	if len(password) < passwordMinLength {
-		return "", fmt.Errorf("password must be at least %d characters long", passwordMinLength)
+		return "", fmt.Errorf("%w: must be at least %d characters long", domain.ErrInvalidUserPassword, passwordMinLength)
 	}
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -30,34 +38,45 @@ func CheckPassword(hash, password string) error {
 	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 }
 
-func GenerateJWTKey(user domain.User, key JWTKey, exp time.Duration) (string, error) {
+// GenerateJWTKey issues an access token of the user for the session, signed by signer.
+// Its jti claim is unique, so that the token can be revoked on its own.
+func GenerateJWTKey(user domain.User, sessionID domain.SessionID, signer Signer, exp time.Duration) (string, error) {
+	jti, err := uuid.NewRandom()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate token ID: %w", err)
+	}
+
 	claims := jwt.MapClaims{
-		"id":       user.ID().String(),
-		"email":    user.Email().String(),
-		"name":     user.Name().String(),
-		"is_admin": user.IsAdmin().Bool(),
-		"exp":      time.Now().Add(exp).Unix(),
+		"id":    user.ID().String(),
+		"email": user.Email().String(),
+		"name":  user.Name().String(),
+		"role":  user.Role().String(),
+		"jti":   jti.String(),
+		"sid":   sessionID.String(),
+		"exp":   time.Now().Add(exp).Unix(),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(key)
+	return signer.Sign(claims)
 }
 
-func ValidateToken(tokenString string, key JWTKey) (jwt.MapClaims, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return key, nil
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse token: %w", err)
-	}
+// ValidateToken checks the signature and expiry of an access token and returns its claims.
+func ValidateToken(tokenString string, signer Signer) (jwt.MapClaims, error) {
+	return signer.Verify(tokenString)
+}
 
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok || !token.Valid {
-		return nil, fmt.Errorf("invalid token")
+// GenerateToken returns a new random opaque token, like the refresh tokens of the
+// sessions and the tokens of the verification links.
+func GenerateToken() (string, error) {
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
 	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
 
-	return claims, nil
+// HashToken returns the hex encoded SHA-256 hash an opaque token is stored as. The
+// tokens are random, so unlike passwords they need no salt nor slow hash.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
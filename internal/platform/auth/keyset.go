@@ -0,0 +1,196 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrNoSigningKey is returned when signing before any key has activated.
+var ErrNoSigningKey = errors.New("no active signing key")
+
+// rsaKeyBits is the size of the RSA keys generated for RS256.
+const rsaKeyBits = 2048
+
+// GenerateSigningKey returns a new private key for the algorithm, in the PKCS #8 form
+// domain.SigningKey holds.
+func GenerateSigningKey(algorithm domain.SigningAlgorithm) ([]byte, error) {
+	var key any
+	var err error
+	switch algorithm {
+	case domain.SigningAlgorithmRS256:
+		key, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case domain.SigningAlgorithmEdDSA:
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, domain.ErrInvalidSigningAlgorithm
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+
+	return x509.MarshalPKCS8PrivateKey(key)
+}
+
+// keySetEntry is a parsed domain.SigningKey.
+type keySetEntry struct {
+	id          string
+	method      jwt.SigningMethod
+	private     crypto.Signer
+	activatesAt time.Time
+}
+
+// KeySet signs the access tokens with the newest of its keys that has activated, and
+// verifies them with the key their kid header names. Its keys are replaced as they are
+// rotated, see KeyRotator. It is safe for concurrent use.
+type KeySet struct {
+	mu      sync.RWMutex
+	entries []keySetEntry // In activation order
+	now     func() time.Time
+}
+
+// NewKeySet creates an empty KeySet.
+func NewKeySet() *KeySet {
+	return &KeySet{
+		now: time.Now,
+	}
+}
+
+// Replace replaces the keys of the set with keys, which come in activation order as
+// domain.SigningKeyRepository.FindAll returns them. The set is left as it was if any of
+// them cannot be parsed.
+func (s *KeySet) Replace(keys []domain.SigningKey) error {
+	entries := make([]keySetEntry, 0, len(keys))
+	for _, key := range keys {
+		entry, err := parseSigningKey(key)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = entries
+	return nil
+}
+
+func parseSigningKey(key domain.SigningKey) (keySetEntry, error) {
+	private, err := x509.ParsePKCS8PrivateKey(key.PrivateKey())
+	if err != nil {
+		return keySetEntry{}, fmt.Errorf("failed to parse signing key %s: %w", key.ID(), err)
+	}
+
+	entry := keySetEntry{
+		id:          key.ID(),
+		activatesAt: key.ActivatesAt(),
+	}
+	switch private := private.(type) {
+	case *rsa.PrivateKey:
+		entry.method, entry.private = jwt.SigningMethodRS256, private
+	case ed25519.PrivateKey:
+		entry.method, entry.private = jwt.SigningMethodEdDSA, private
+	}
+	if entry.private == nil || entry.method.Alg() != key.Algorithm().String() {
+		return keySetEntry{}, fmt.Errorf("signing key %s is not an %s key", key.ID(), key.Algorithm())
+	}
+
+	return entry, nil
+}
+
+func (s *KeySet) Sign(claims jwt.MapClaims) (string, error) {
+	entry, ok := s.signingEntry()
+	if !ok {
+		return "", ErrNoSigningKey
+	}
+
+	token := jwt.NewWithClaims(entry.method, claims)
+	token.Header["kid"] = entry.id
+	return token.SignedString(entry.private)
+}
+
+// signingEntry returns the newest entry that has activated.
+func (s *KeySet) signingEntry() (keySetEntry, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := s.now()
+	for i := len(s.entries) - 1; i >= 0; i-- {
+		if !s.entries[i].activatesAt.After(now) {
+			return s.entries[i], true
+		}
+	}
+	return keySetEntry{}, false
+}
+
+// Verify checks a token against the key its kid header names. Keys that have not
+// activated yet are accepted too, as other instances may already sign with them.
+func (s *KeySet) Verify(tokenString string) (jwt.MapClaims, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		entry, ok := s.entry(kid)
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key: %q", kid)
+		}
+		if token.Method.Alg() != entry.method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return entry.private.Public(), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token: %w", err)
+	}
+
+	return claimsOf(token)
+}
+
+func (s *KeySet) entry(id string) (keySetEntry, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, entry := range s.entries {
+		if entry.id == id {
+			return entry, true
+		}
+	}
+	return keySetEntry{}, false
+}
+
+// JWKS returns the public part of every key, including the ones that have not activated
+// yet, so that verifiers know them before the first token signed with them.
+func (s *KeySet) JWKS() JWKS {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	jwks := JWKS{Keys: make([]JWK, 0, len(s.entries))}
+	for _, entry := range s.entries {
+		jwk := JWK{
+			ID:        entry.id,
+			Use:       "sig",
+			Algorithm: entry.method.Alg(),
+		}
+		switch public := entry.private.Public().(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	return jwks
+}
@@ -0,0 +1,159 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var keysNow = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func newSigningKey(t *testing.T, id string, algorithm domain.SigningAlgorithm, activatesAt time.Time) domain.SigningKey {
+	t.Helper()
+
+	privateKey, err := GenerateSigningKey(algorithm)
+	require.NoError(t, err)
+	key, err := domain.NewSigningKey(id, algorithm, privateKey, activatesAt)
+	require.NoError(t, err)
+	return key
+}
+
+func newTestKeySet(t *testing.T, keys ...domain.SigningKey) *KeySet {
+	t.Helper()
+
+	set := NewKeySet()
+	set.now = func() time.Time { return keysNow }
+	require.NoError(t, set.Replace(keys))
+	return set
+}
+
+func testClaims() jwt.MapClaims {
+	return jwt.MapClaims{"id": "user", "exp": time.Now().Add(time.Hour).Unix()}
+}
+
+func TestKeySetSignsAndVerifies(t *testing.T) {
+	for _, algorithm := range []domain.SigningAlgorithm{domain.SigningAlgorithmRS256, domain.SigningAlgorithmEdDSA} {
+		t.Run(algorithm.String(), func(t *testing.T) {
+			set := newTestKeySet(t, newSigningKey(t, "key", algorithm, keysNow))
+
+			token, err := set.Sign(testClaims())
+			require.NoError(t, err)
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+			require.NoError(t, err)
+			assert.Equal(t, "key", parsed.Header["kid"])
+			assert.Equal(t, algorithm.String(), parsed.Header["alg"])
+
+			claims, err := set.Verify(token)
+			require.NoError(t, err)
+			assert.Equal(t, "user", claims["id"])
+		})
+	}
+}
+
+func TestKeySetSignsWithTheNewestActiveKey(t *testing.T) {
+	set := newTestKeySet(t,
+		newSigningKey(t, "old", domain.SigningAlgorithmEdDSA, keysNow.Add(-48*time.Hour)),
+		newSigningKey(t, "current", domain.SigningAlgorithmEdDSA, keysNow.Add(-time.Hour)),
+		newSigningKey(t, "next", domain.SigningAlgorithmRS256, keysNow.Add(time.Hour)),
+	)
+
+	token, err := set.Sign(testClaims())
+	require.NoError(t, err)
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+	require.NoError(t, err)
+	assert.Equal(t, "current", parsed.Header["kid"])
+}
+
+func TestKeySetVerifiesTokensOfEveryKey(t *testing.T) {
+	old := newSigningKey(t, "old", domain.SigningAlgorithmEdDSA, keysNow.Add(-time.Hour))
+	oldToken, err := newTestKeySet(t, old).Sign(testClaims())
+	require.NoError(t, err)
+
+	set := newTestKeySet(t, old, newSigningKey(t, "current", domain.SigningAlgorithmRS256, keysNow))
+	_, err = set.Verify(oldToken)
+	assert.NoError(t, err)
+}
+
+func TestKeySetRejectsUnknownKeys(t *testing.T) {
+	other := newTestKeySet(t, newSigningKey(t, "other", domain.SigningAlgorithmEdDSA, keysNow))
+	token, err := other.Sign(testClaims())
+	require.NoError(t, err)
+
+	set := newTestKeySet(t, newSigningKey(t, "key", domain.SigningAlgorithmEdDSA, keysNow))
+	_, err = set.Verify(token)
+	assert.Error(t, err)
+}
+
+func TestKeySetRejectsHMACTokens(t *testing.T) {
+	set := newTestKeySet(t, newSigningKey(t, "key", domain.SigningAlgorithmRS256, keysNow))
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, testClaims())
+	token.Header["kid"] = "key"
+	signed, err := token.SignedString([]byte("secret"))
+	require.NoError(t, err)
+
+	_, err = set.Verify(signed)
+	assert.Error(t, err)
+}
+
+func TestKeySetWithoutActiveKey(t *testing.T) {
+	set := newTestKeySet(t, newSigningKey(t, "next", domain.SigningAlgorithmEdDSA, keysNow.Add(time.Minute)))
+
+	_, err := set.Sign(testClaims())
+	assert.ErrorIs(t, err, ErrNoSigningKey)
+}
+
+func TestKeySetReplaceRejectsMismatchedAlgorithm(t *testing.T) {
+	privateKey, err := GenerateSigningKey(domain.SigningAlgorithmEdDSA)
+	require.NoError(t, err)
+	key, err := domain.NewSigningKey("key", domain.SigningAlgorithmRS256, privateKey, keysNow)
+	require.NoError(t, err)
+
+	set := newTestKeySet(t)
+	assert.Error(t, set.Replace([]domain.SigningKey{key}))
+}
+
+func TestKeySetJWKS(t *testing.T) {
+	set := newTestKeySet(t,
+		newSigningKey(t, "rsa", domain.SigningAlgorithmRS256, keysNow),
+		newSigningKey(t, "ed", domain.SigningAlgorithmEdDSA, keysNow.Add(time.Hour)),
+	)
+
+	jwks := set.JWKS()
+	require.Len(t, jwks.Keys, 2)
+
+	rsaKey := jwks.Keys[0]
+	assert.Equal(t, "RSA", rsaKey.KeyType)
+	assert.Equal(t, "rsa", rsaKey.ID)
+	assert.Equal(t, "RS256", rsaKey.Algorithm)
+	assert.Equal(t, "sig", rsaKey.Use)
+	assert.Equal(t, "AQAB", rsaKey.E)
+	assert.NotEmpty(t, rsaKey.N)
+
+	edKey := jwks.Keys[1]
+	assert.Equal(t, "OKP", edKey.KeyType)
+	assert.Equal(t, "Ed25519", edKey.Curve)
+	assert.Equal(t, "EdDSA", edKey.Algorithm)
+	assert.Len(t, edKey.X, 43)
+}
+
+func TestHMACSigner(t *testing.T) {
+	signer := NewHMACSigner([]byte("secret"))
+
+	token, err := signer.Sign(testClaims())
+	require.NoError(t, err)
+
+	claims, err := signer.Verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, "user", claims["id"])
+
+	_, err = NewHMACSigner([]byte("other")).Verify(token)
+	assert.Error(t, err)
+	assert.Empty(t, signer.JWKS().Keys)
+}
@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/google/uuid"
+)
+
+// KeyRotator keeps the signing keys of a KeySet up to date with the repository, and
+// rotates them:
+//
+//   - A new key is generated when the signing key is due to be replaced within
+//     publishDelay, or signs with another algorithm than the configured one. It only
+//     activates publishDelay later, so that every instance, and every verifier that
+//     caches the JWKS, knows it before the first token is signed with it.
+//   - A key that has been replaced keeps verifying for tokenLifetime, so that the tokens
+//     it signed last can still be used, and is then deleted.
+//
+// Every instance runs its own rotator on the same repository. Two instances may then
+// generate a key at the same time; the one that activates last signs, and the other one
+// is deleted like any replaced key.
+type KeyRotator struct {
+	repository    domain.SigningKeyRepository
+	keys          *KeySet
+	algorithm     domain.SigningAlgorithm
+	period        time.Duration // Time a key signs before it is replaced
+	publishDelay  time.Duration // Time a key is published before it signs
+	tokenLifetime time.Duration // Lifetime of the access tokens
+	interval      time.Duration // Wait between two rotations
+	now           func() time.Time
+}
+
+// NewKeyRotator creates a new KeyRotator that generates algorithm keys for keys.
+func NewKeyRotator(repository domain.SigningKeyRepository, keys *KeySet, algorithm domain.SigningAlgorithm, period, publishDelay, tokenLifetime, interval time.Duration) *KeyRotator {
+	return &KeyRotator{
+		repository:    repository,
+		keys:          keys,
+		algorithm:     algorithm,
+		period:        period,
+		publishDelay:  publishDelay,
+		tokenLifetime: tokenLifetime,
+		interval:      interval,
+		now:           time.Now,
+	}
+}
+
+// Run rotates the keys every interval until ctx is done.
+func (r *KeyRotator) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		if err := r.Rotate(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("failed to rotate the signing keys: %v", err)
+		}
+	}
+}
+
+// Rotate generates the next key when it is due, deletes the keys that no longer verify
+// any token, and loads the remaining ones into the KeySet. Without any key, the first
+// one activates right away, as no token can be waiting for it.
+func (r *KeyRotator) Rotate(ctx context.Context) error {
+	now := r.now()
+	keys, err := r.repository.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(keys) == 0 {
+		if err := r.generate(ctx, now); err != nil {
+			return err
+		}
+	} else if r.due(keys[len(keys)-1], now) {
+		if err := r.generate(ctx, now.Add(r.publishDelay)); err != nil {
+			return err
+		}
+	}
+
+	// A key is replaced when the next one activates
+	for i := 0; i < len(keys)-1; i++ {
+		if !keys[i+1].ActivatesAt().Add(r.tokenLifetime).After(now) {
+			if err := r.repository.Delete(ctx, keys[i].ID()); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Reload the keys, along with the ones other instances may have generated
+	keys, err = r.repository.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+	return r.keys.Replace(keys)
+}
+
+// due tells whether the last key has to be followed by a new one. A key that has not
+// activated yet is still to be used.
+func (r *KeyRotator) due(last domain.SigningKey, now time.Time) bool {
+	if last.ActivatesAt().After(now) {
+		return false
+	}
+	if last.Algorithm() != r.algorithm {
+		return true
+	}
+	return !now.Before(last.ActivatesAt().Add(r.period - r.publishDelay))
+}
+
+func (r *KeyRotator) generate(ctx context.Context, activatesAt time.Time) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return fmt.Errorf("failed to generate signing key ID: %w", err)
+	}
+
+	privateKey, err := GenerateSigningKey(r.algorithm)
+	if err != nil {
+		return err
+	}
+
+	key, err := domain.NewSigningKey(id.String(), r.algorithm, privateKey, activatesAt)
+	if err != nil {
+		return err
+	}
+	return r.repository.Save(ctx, key)
+}
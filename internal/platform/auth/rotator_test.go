@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/storage/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	rotationPeriod = 24 * time.Hour
+	publishDelay   = time.Hour
+	tokenLifetime  = 15 * time.Minute
+)
+
+// testRotator is a KeyRotator on an in-memory repository, with a clock the test moves.
+type testRotator struct {
+	*KeyRotator
+	repository *inmemory.SigningKeyRepository
+	now        time.Time
+}
+
+func newTestRotator(algorithm domain.SigningAlgorithm) *testRotator {
+	repository := inmemory.NewSigningKeyRepository(inmemory.NewStore())
+	r := &testRotator{repository: repository, now: keysNow}
+	r.KeyRotator = NewKeyRotator(repository, NewKeySet(), algorithm, rotationPeriod, publishDelay, tokenLifetime, time.Minute)
+	r.KeyRotator.now = func() time.Time { return r.now }
+	r.keys.now = func() time.Time { return r.now }
+	return r
+}
+
+func (r *testRotator) rotateAt(t *testing.T, now time.Time) []domain.SigningKey {
+	t.Helper()
+
+	r.now = now
+	require.NoError(t, r.Rotate(context.Background()))
+	keys, err := r.repository.FindAll(context.Background())
+	require.NoError(t, err)
+	return keys
+}
+
+func TestKeyRotatorGeneratesTheFirstKeyRightAway(t *testing.T) {
+	r := newTestRotator(domain.SigningAlgorithmEdDSA)
+
+	keys := r.rotateAt(t, keysNow)
+	require.Len(t, keys, 1)
+	assert.Equal(t, keysNow, keys[0].ActivatesAt())
+	assert.Equal(t, domain.SigningAlgorithmEdDSA, keys[0].Algorithm())
+
+	_, err := r.keys.Sign(testClaims())
+	assert.NoError(t, err)
+
+	// Nothing is due yet
+	keys = r.rotateAt(t, keysNow.Add(time.Hour))
+	assert.Len(t, keys, 1)
+}
+
+func TestKeyRotatorPublishesTheNextKeyAhead(t *testing.T) {
+	r := newTestRotator(domain.SigningAlgorithmEdDSA)
+	first := r.rotateAt(t, keysNow)[0]
+
+	due := keysNow.Add(rotationPeriod - publishDelay)
+	keys := r.rotateAt(t, due)
+	require.Len(t, keys, 2)
+	assert.Equal(t, first, keys[0])
+	assert.Equal(t, due.Add(publishDelay), keys[1].ActivatesAt())
+	assert.Len(t, r.keys.JWKS().Keys, 2)
+
+	// The first key signs until the next one activates
+	tokenOfFirst, err := r.keys.Sign(testClaims())
+	require.NoError(t, err)
+
+	r.rotateAt(t, keys[1].ActivatesAt())
+	claims, err := r.keys.Verify(tokenOfFirst)
+	require.NoError(t, err)
+	assert.Equal(t, "user", claims["id"])
+
+	// The first key is deleted once its last tokens have expired
+	keys = r.rotateAt(t, keys[1].ActivatesAt().Add(tokenLifetime))
+	require.Len(t, keys, 1)
+	assert.NotEqual(t, first.ID(), keys[0].ID())
+	_, err = r.keys.Verify(tokenOfFirst)
+	assert.Error(t, err)
+}
+
+func TestKeyRotatorReplacesKeysOfAnotherAlgorithm(t *testing.T) {
+	r := newTestRotator(domain.SigningAlgorithmRS256)
+	key := newSigningKey(t, "eddsa", domain.SigningAlgorithmEdDSA, keysNow.Add(-time.Hour))
+	require.NoError(t, r.repository.Save(context.Background(), key))
+
+	keys := r.rotateAt(t, keysNow)
+	require.Len(t, keys, 2)
+	assert.Equal(t, domain.SigningAlgorithmRS256, keys[1].Algorithm())
+	assert.Equal(t, keysNow.Add(publishDelay), keys[1].ActivatesAt())
+}
@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+)
+
+// minKeySecretLength is the shortest secret the signing keys can be encrypted with.
+const minKeySecretLength = 32
+
+// sealedKeyVersion starts the private keys encrypted by SealedSigningKeyRepository. A
+// PKCS #8 key in DER form always starts with 0x30, the tag of an ASN.1 SEQUENCE, so the
+// keys stored before they were encrypted can still be told apart.
+const sealedKeyVersion byte = 0x01
+
+var ErrInvalidKeySecret = fmt.Errorf("the secret of the signing keys must be at least %d bytes long", minKeySecretLength)
+
+// SealedSigningKeyRepository encrypts the private keys with AES-256-GCM before another
+// repository stores them, and decrypts them when it loads them, so that the storage and
+// its backups never hold them in the clear. The ID and algorithm of a key are bound to
+// its ciphertext, which cannot be moved to another row.
+//
+// Keys stored in the clear before, which a rotation replaces in time, are read as they
+// are.
+type SealedSigningKeyRepository struct {
+	repository domain.SigningKeyRepository
+	aead       cipher.AEAD
+}
+
+// NewSealedSigningKeyRepository creates a SealedSigningKeyRepository that encrypts the
+// keys with a key derived from secret.
+func NewSealedSigningKeyRepository(repository domain.SigningKeyRepository, secret []byte) (*SealedSigningKeyRepository, error) {
+	if len(secret) < minKeySecretLength {
+		return nil, ErrInvalidKeySecret
+	}
+
+	key := sha256.Sum256(secret)
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SealedSigningKeyRepository{
+		repository: repository,
+		aead:       aead,
+	}, nil
+}
+
+func (r *SealedSigningKeyRepository) Save(ctx context.Context, key domain.SigningKey) error {
+	nonce := make([]byte, r.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := append([]byte{sealedKeyVersion}, nonce...)
+	sealed = r.aead.Seal(sealed, nonce, key.PrivateKey(), additionalData(key))
+
+	sealedKey, err := domain.NewSigningKey(key.ID(), key.Algorithm(), sealed, key.ActivatesAt())
+	if err != nil {
+		return err
+	}
+	return r.repository.Save(ctx, sealedKey)
+}
+
+func (r *SealedSigningKeyRepository) FindAll(ctx context.Context) ([]domain.SigningKey, error) {
+	keys, err := r.repository.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, key := range keys {
+		if !bytes.HasPrefix(key.PrivateKey(), []byte{sealedKeyVersion}) {
+			continue
+		}
+
+		privateKey, err := r.open(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt signing key %s: %w", key.ID(), err)
+		}
+
+		keys[i], err = domain.NewSigningKey(key.ID(), key.Algorithm(), privateKey, key.ActivatesAt())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+func (r *SealedSigningKeyRepository) Delete(ctx context.Context, id string) error {
+	return r.repository.Delete(ctx, id)
+}
+
+func (r *SealedSigningKeyRepository) open(key domain.SigningKey) ([]byte, error) {
+	sealed := key.PrivateKey()[1:]
+	if len(sealed) < r.aead.NonceSize() {
+		return nil, domain.ErrInvalidSigningKey
+	}
+
+	nonce, ciphertext := sealed[:r.aead.NonceSize()], sealed[r.aead.NonceSize():]
+	privateKey, err := r.aead.Open(nil, nonce, ciphertext, additionalData(key))
+	if err != nil {
+		// A wrong secret, or a key that was tampered with
+		return nil, errors.Join(domain.ErrInvalidSigningKey, err)
+	}
+	return privateKey, nil
+}
+
+// additionalData binds the ciphertext of a key to its ID and algorithm.
+func additionalData(key domain.SigningKey) []byte {
+	return []byte(key.ID() + "." + key.Algorithm().String())
+}
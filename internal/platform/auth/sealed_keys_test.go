@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/storage/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const keySecret = "a-secret-long-enough-for-the-signing-keys"
+
+func newSealedRepository(t *testing.T, repository domain.SigningKeyRepository, secret string) *SealedSigningKeyRepository {
+	t.Helper()
+
+	sealed, err := NewSealedSigningKeyRepository(repository, []byte(secret))
+	require.NoError(t, err)
+	return sealed
+}
+
+func TestSealedSigningKeyRepositoryEncryptsThePrivateKeys(t *testing.T) {
+	stored := inmemory.NewSigningKeyRepository(inmemory.NewStore())
+	repository := newSealedRepository(t, stored, keySecret)
+	key := newSigningKey(t, "key", domain.SigningAlgorithmEdDSA, keysNow)
+
+	require.NoError(t, repository.Save(context.Background(), key))
+
+	raw, err := stored.FindAll(context.Background())
+	require.NoError(t, err)
+	require.Len(t, raw, 1)
+	assert.False(t, bytes.Contains(raw[0].PrivateKey(), key.PrivateKey()))
+
+	keys, err := repository.FindAll(context.Background())
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, key.PrivateKey(), keys[0].PrivateKey())
+
+	// The keys it loads sign and verify
+	set := newTestKeySet(t, keys...)
+	token, err := set.Sign(testClaims())
+	require.NoError(t, err)
+	_, err = set.Verify(token)
+	assert.NoError(t, err)
+}
+
+func TestSealedSigningKeyRepositoryRejectsAnotherSecret(t *testing.T) {
+	stored := inmemory.NewSigningKeyRepository(inmemory.NewStore())
+	require.NoError(t, newSealedRepository(t, stored, keySecret).Save(context.Background(), newSigningKey(t, "key", domain.SigningAlgorithmRS256, keysNow)))
+
+	_, err := newSealedRepository(t, stored, keySecret+"-rotated").FindAll(context.Background())
+	assert.ErrorIs(t, err, domain.ErrInvalidSigningKey)
+}
+
+func TestSealedSigningKeyRepositoryReadsKeysStoredInTheClear(t *testing.T) {
+	stored := inmemory.NewSigningKeyRepository(inmemory.NewStore())
+	key := newSigningKey(t, "key", domain.SigningAlgorithmEdDSA, keysNow)
+	require.NoError(t, stored.Save(context.Background(), key))
+
+	keys, err := newSealedRepository(t, stored, keySecret).FindAll(context.Background())
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, key.PrivateKey(), keys[0].PrivateKey())
+}
+
+func TestNewSealedSigningKeyRepositoryShortSecret(t *testing.T) {
+	_, err := NewSealedSigningKeyRepository(inmemory.NewSigningKeyRepository(inmemory.NewStore()), []byte("too-short"))
+	assert.ErrorIs(t, err, ErrInvalidKeySecret)
+}
@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Signer signs the access tokens and verifies them. It is implemented by HMACSigner,
+// which shares a single secret with the verifiers, and by KeySet, which signs with
+// rotating private keys and publishes the public ones.
+type Signer interface {
+	Sign(claims jwt.MapClaims) (string, error)
+	Verify(tokenString string) (jwt.MapClaims, error)
+	// JWKS returns the public keys the tokens can be verified with.
+	JWKS() JWKS
+}
+
+// JWK is a public key in the JSON Web Key format (RFC 7517). RSA keys set N and E, and
+// Ed25519 keys Curve and X.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	ID        string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set, as served at /.well-known/jwks.json.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// HMACSigner signs the access tokens with HS256 and a static secret. Every verifier needs
+// the secret, and changing it invalidates every token, so it is only kept for the
+// deployments that rely on it.
+type HMACSigner struct {
+	key JWTKey
+}
+
+// NewHMACSigner creates a new HMACSigner with the given secret.
+func NewHMACSigner(key JWTKey) HMACSigner {
+	return HMACSigner{
+		key: key,
+	}
+}
+
+func (s HMACSigner) Sign(claims jwt.MapClaims) (string, error) {
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.key)
+}
+
+func (s HMACSigner) Verify(tokenString string) (jwt.MapClaims, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		return s.key, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token: %w", err)
+	}
+
+	return claimsOf(token)
+}
+
+// JWKS returns an empty set: the secret must not be published.
+func (s HMACSigner) JWKS() JWKS {
+	return JWKS{Keys: []JWK{}}
+}
+
+func claimsOf(token *jwt.Token) (jwt.MapClaims, error) {
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, fmt.Errorf("invalid token")
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,272 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+)
+
+// ErrEventBusClosed is returned when publishing on an AsyncEventBus that has been shut
+// down.
+var ErrEventBusClosed = errors.New("event bus closed")
+
+// errShutdown is recorded in the dead letters of the events a shutdown left unhandled.
+var errShutdown = errors.New("event bus shut down before the event was handled")
+
+// delivery is an event to be handled by one of its handlers.
+type delivery struct {
+	event   event.Event
+	handler event.Handler
+	ack     *ack // Only set for the events published with PublishWithAck
+}
+
+// ack calls fn once every handler of an event is done with it, unless a shutdown left one
+// of them without handling it.
+type ack struct {
+	pending   atomic.Int32
+	abandoned atomic.Bool
+	fn        func()
+}
+
+// done records that a handler is done with the event: handled, or dead-lettered after
+// failing on every attempt.
+func (a *ack) done() {
+	if a.pending.Add(-1) == 0 && !a.abandoned.Load() {
+		a.fn()
+	}
+}
+
+// abandon records that a handler never got to handle the event, so fn is never called.
+func (a *ack) abandon() {
+	a.abandoned.Store(true)
+	a.pending.Add(-1)
+}
+
+// AsyncEventBus is an event.Bus that hands events to its handlers in the background. A
+// bounded pool of workers takes the deliveries, one per event and handler, off a bounded
+// queue, so a slow handler never holds up the publisher and a failing one never keeps
+// the event from the others.
+//
+// A handler that fails is retried with an exponential backoff. When it has failed on
+// every attempt the event goes to the dead-letter store.
+//
+// Handlers run outside the request that published the event: their context carries none
+// of the values of the publisher's, in particular none of its transaction.
+type AsyncEventBus struct {
+	handlers map[event.Type][]event.Handler
+	mu       sync.RWMutex // Guards handlers and closed
+
+	queue       chan delivery
+	closed      bool
+	deadLetters event.DeadLetterStore
+	maxAttempts int
+	backoff     time.Duration // Wait before the first retry; it doubles on every other
+
+	// ctx is cancelled when a shutdown runs out of time, to stop the handlers in flight
+	ctx     context.Context
+	cancel  context.CancelFunc
+	workers sync.WaitGroup
+	now     func() time.Time
+}
+
+// NewAsyncEventBus creates a new AsyncEventBus and starts its workers. Publish blocks
+// while queueSize deliveries are waiting. A handler gets maxAttempts attempts at every
+// event.
+func NewAsyncEventBus(workers, queueSize, maxAttempts int, backoff time.Duration, deadLetters event.DeadLetterStore) *AsyncEventBus {
+	ctx, cancel := context.WithCancel(context.Background())
+	b := &AsyncEventBus{
+		handlers:    make(map[event.Type][]event.Handler),
+		queue:       make(chan delivery, queueSize),
+		deadLetters: deadLetters,
+		maxAttempts: max(maxAttempts, 1),
+		backoff:     backoff,
+		ctx:         ctx,
+		cancel:      cancel,
+		now:         time.Now,
+	}
+
+	for range max(workers, 1) {
+		b.workers.Add(1)
+		go b.work()
+	}
+
+	return b
+}
+
+// Publish queues the events for their handlers and returns without waiting for them to
+// be handled. It only fails when the bus is shut down or ctx is done before there is
+// room in the queue.
+func (b *AsyncEventBus) Publish(ctx context.Context, events []event.Event) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return ErrEventBusClosed
+	}
+
+	for _, e := range events {
+		for _, h := range b.handlers[e.Type()] {
+			select {
+			case b.queue <- delivery{event: e, handler: h}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+
+	return nil
+}
+
+// PublishWithAck queues the event like Publish, and calls ack in the background once
+// every handler is done with it, either because it handled the event or because the event
+// went to the dead-letter store. The events a shutdown leaves unhandled are neither
+// acknowledged nor dead-lettered, so that whoever published them can publish them again.
+func (b *AsyncEventBus) PublishWithAck(ctx context.Context, e event.Event, fn func()) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return ErrEventBusClosed
+	}
+
+	handlers := b.handlers[e.Type()]
+	if len(handlers) == 0 {
+		fn()
+		return nil
+	}
+
+	a := &ack{fn: fn}
+	a.pending.Store(int32(len(handlers)))
+	for i, h := range handlers {
+		select {
+		case b.queue <- delivery{event: e, handler: h, ack: a}:
+		case <-ctx.Done():
+			// The queued deliveries still run, but the event must not be acknowledged
+			for range len(handlers) - i {
+				a.abandon()
+			}
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+func (b *AsyncEventBus) Subscribe(eventType event.Type, handler event.Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+// Shutdown stops taking events and waits for the queued ones to be handled. When ctx is
+// done first, the handlers in flight are cancelled and every event left unhandled goes
+// to the dead-letter store, but for those published with PublishWithAck.
+func (b *AsyncEventBus) Shutdown(ctx context.Context) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	close(b.queue)
+	b.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		b.workers.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		b.cancel()
+		return nil
+	case <-ctx.Done():
+		b.cancel()
+		<-drained
+		return fmt.Errorf("failed to drain the event bus: %w", ctx.Err())
+	}
+}
+
+func (b *AsyncEventBus) work() {
+	defer b.workers.Done()
+
+	for d := range b.queue {
+		b.deliver(d)
+	}
+}
+
+// deliver hands the event to the handler until it succeeds or runs out of attempts, and
+// sends it to the dead-letter store if it never succeeds.
+func (b *AsyncEventBus) deliver(d delivery) {
+	var (
+		err      error
+		attempts int
+	)
+	for attempts < b.maxAttempts {
+		if b.ctx.Err() != nil {
+			err = errShutdown
+			break
+		}
+
+		attempts++
+		if err = d.handler.Handle(b.ctx, d.event); err == nil {
+			if d.ack != nil {
+				d.ack.done()
+			}
+			return
+		}
+		if attempts < b.maxAttempts && !b.wait(b.backoff<<(attempts-1)) {
+			err = fmt.Errorf("%w: %w", errShutdown, err)
+			break
+		}
+	}
+
+	if d.ack == nil {
+		b.deadLetter(d, attempts, err)
+		return
+	}
+	if b.ctx.Err() != nil {
+		// The handler was stopped by the shutdown; the event is published again, so it is
+		// not lost
+		d.ack.abandon()
+		return
+	}
+	b.deadLetter(d, attempts, err)
+	d.ack.done()
+}
+
+// wait sleeps for d, and reports false if the bus is stopped in the meantime.
+func (b *AsyncEventBus) wait(d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-b.ctx.Done():
+		return false
+	}
+}
+
+func (b *AsyncEventBus) deadLetter(d delivery, attempts int, err error) {
+	letter := event.DeadLetter{
+		Event:    d.event,
+		Handler:  fmt.Sprintf("%T", d.handler),
+		Attempts: attempts,
+		Err:      err.Error(),
+		FailedAt: b.now(),
+	}
+
+	// The bus context may be cancelled already, and the letter must still be saved
+	if err := b.deadLetters.Save(context.Background(), letter); err != nil {
+		log.Printf("failed to save the dead letter of event %s (%s) for %s: %v", d.event.ID(), d.event.Type(), letter.Handler, err)
+	}
+}
@@ -0,0 +1,255 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testEventType event.Type = "events.test"
+
+type testEvent struct {
+	event.BaseEvent
+}
+
+func (e testEvent) Type() event.Type {
+	return testEventType
+}
+
+func newTestEvent() testEvent {
+	return testEvent{BaseEvent: event.NewBaseEvent("123e4567-e89b-12d3-a456-426614174000")}
+}
+
+// handlerFunc adapts a function into an event.Handler.
+type handlerFunc func(ctx context.Context, e event.Event) error
+
+func (f handlerFunc) Handle(ctx context.Context, e event.Event) error {
+	return f(ctx, e)
+}
+
+// shutdown drains the bus, failing the test if it takes longer than a second.
+func shutdown(t *testing.T, bus *AsyncEventBus) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, bus.Shutdown(ctx))
+}
+
+func TestAsyncEventBusPublishDoesNotWaitForHandlers(t *testing.T) {
+	release := make(chan struct{})
+	var handled atomic.Int32
+
+	bus := NewAsyncEventBus(2, 10, 1, time.Millisecond, NewDeadLetterStore())
+	bus.Subscribe(testEventType, handlerFunc(func(ctx context.Context, e event.Event) error {
+		<-release
+		handled.Add(1)
+		return nil
+	}))
+
+	require.NoError(t, bus.Publish(context.Background(), []event.Event{newTestEvent(), newTestEvent()}))
+	assert.Zero(t, handled.Load())
+
+	close(release)
+	shutdown(t, bus)
+	assert.EqualValues(t, 2, handled.Load())
+}
+
+func TestAsyncEventBusFailingHandlerDoesNotStopTheOthers(t *testing.T) {
+	var handled atomic.Int32
+	deadLetters := NewDeadLetterStore()
+
+	bus := NewAsyncEventBus(1, 10, 1, time.Millisecond, deadLetters)
+	bus.Subscribe(testEventType, handlerFunc(func(ctx context.Context, e event.Event) error {
+		return errors.New("handler error")
+	}))
+	bus.Subscribe(testEventType, handlerFunc(func(ctx context.Context, e event.Event) error {
+		handled.Add(1)
+		return nil
+	}))
+
+	require.NoError(t, bus.Publish(context.Background(), []event.Event{newTestEvent()}))
+	shutdown(t, bus)
+
+	assert.EqualValues(t, 1, handled.Load())
+	assert.Len(t, deadLetters.Letters(), 1)
+}
+
+func TestAsyncEventBusRetriesWithBackoff(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		attempts []time.Time
+	)
+
+	bus := NewAsyncEventBus(1, 10, 3, 10*time.Millisecond, NewDeadLetterStore())
+	bus.Subscribe(testEventType, handlerFunc(func(ctx context.Context, e event.Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts = append(attempts, time.Now())
+		if len(attempts) < 3 {
+			return errors.New("temporary error")
+		}
+		return nil
+	}))
+
+	require.NoError(t, bus.Publish(context.Background(), []event.Event{newTestEvent()}))
+	shutdown(t, bus)
+
+	require.Len(t, attempts, 3)
+	assert.GreaterOrEqual(t, attempts[1].Sub(attempts[0]), 10*time.Millisecond)
+	assert.GreaterOrEqual(t, attempts[2].Sub(attempts[1]), 20*time.Millisecond)
+}
+
+func TestAsyncEventBusDeadLettersAfterLastAttempt(t *testing.T) {
+	e := newTestEvent()
+	failedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	var attempts atomic.Int32
+	deadLetters := NewDeadLetterStore()
+
+	bus := NewAsyncEventBus(1, 10, 3, time.Millisecond, deadLetters)
+	bus.now = func() time.Time { return failedAt }
+	bus.Subscribe(testEventType, handlerFunc(func(ctx context.Context, e event.Event) error {
+		attempts.Add(1)
+		return errors.New("permanent error")
+	}))
+
+	require.NoError(t, bus.Publish(context.Background(), []event.Event{e}))
+	shutdown(t, bus)
+
+	assert.EqualValues(t, 3, attempts.Load())
+	require.Len(t, deadLetters.Letters(), 1)
+	letter := deadLetters.Letters()[0]
+	assert.Equal(t, e.ID(), letter.Event.ID())
+	assert.Equal(t, "inmemory.handlerFunc", letter.Handler)
+	assert.Equal(t, 3, letter.Attempts)
+	assert.Equal(t, "permanent error", letter.Err)
+	assert.Equal(t, failedAt, letter.FailedAt)
+}
+
+func TestAsyncEventBusHandlersDoNotGetThePublisherContext(t *testing.T) {
+	type key struct{}
+	values := make(chan any, 1)
+
+	bus := NewAsyncEventBus(1, 10, 1, time.Millisecond, NewDeadLetterStore())
+	bus.Subscribe(testEventType, handlerFunc(func(ctx context.Context, e event.Event) error {
+		values <- ctx.Value(key{})
+		return ctx.Err()
+	}))
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), key{}, "tx"))
+	require.NoError(t, bus.Publish(ctx, []event.Event{newTestEvent()}))
+	cancel()
+	shutdown(t, bus)
+
+	assert.Nil(t, <-values)
+}
+
+func TestAsyncEventBusShutdownTimeoutDeadLettersUnhandledEvents(t *testing.T) {
+	deadLetters := NewDeadLetterStore()
+	started := make(chan struct{})
+	var calls atomic.Int32
+
+	// The first event blocks the only worker until the shutdown gives up; the second is
+	// still queued by then and must not be handled
+	bus := NewAsyncEventBus(1, 10, 1, time.Millisecond, deadLetters)
+	bus.Subscribe(testEventType, handlerFunc(func(ctx context.Context, e event.Event) error {
+		if calls.Add(1) > 1 {
+			t.Error("a queued event must not be handled after the shutdown timed out")
+			return nil
+		}
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+
+	require.NoError(t, bus.Publish(context.Background(), []event.Event{newTestEvent(), newTestEvent()}))
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := bus.Shutdown(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	letters := deadLetters.Letters()
+	require.Len(t, letters, 2)
+	assert.Equal(t, context.Canceled.Error(), letters[0].Err)
+	assert.Equal(t, errShutdown.Error(), letters[1].Err)
+	assert.Zero(t, letters[1].Attempts)
+}
+
+func TestAsyncEventBusPublishAfterShutdown(t *testing.T) {
+	bus := NewAsyncEventBus(1, 10, 1, time.Millisecond, NewDeadLetterStore())
+	shutdown(t, bus)
+
+	err := bus.Publish(context.Background(), []event.Event{newTestEvent()})
+	assert.ErrorIs(t, err, ErrEventBusClosed)
+}
+
+func TestAsyncEventBusPublishWithAckWaitsForEveryHandler(t *testing.T) {
+	deadLetters := NewDeadLetterStore()
+	acked := make(chan struct{})
+	var handled atomic.Int32
+
+	bus := NewAsyncEventBus(2, 10, 1, time.Millisecond, deadLetters)
+	bus.Subscribe(testEventType, handlerFunc(func(ctx context.Context, e event.Event) error {
+		handled.Add(1)
+		return nil
+	}))
+	bus.Subscribe(testEventType, handlerFunc(func(ctx context.Context, e event.Event) error {
+		handled.Add(1)
+		return errors.New("permanent error")
+	}))
+
+	require.NoError(t, bus.PublishWithAck(context.Background(), newTestEvent(), func() {
+		assert.EqualValues(t, 2, handled.Load())
+		close(acked)
+	}))
+	shutdown(t, bus)
+
+	<-acked
+	assert.Len(t, deadLetters.Letters(), 1)
+}
+
+func TestAsyncEventBusPublishWithAckWithoutHandlers(t *testing.T) {
+	var acked bool
+
+	bus := NewAsyncEventBus(1, 10, 1, time.Millisecond, NewDeadLetterStore())
+	require.NoError(t, bus.PublishWithAck(context.Background(), newTestEvent(), func() { acked = true }))
+	shutdown(t, bus)
+
+	assert.True(t, acked)
+}
+
+func TestAsyncEventBusShutdownTimeoutDoesNotAckUnhandledEvents(t *testing.T) {
+	deadLetters := NewDeadLetterStore()
+	started := make(chan struct{})
+	var acked atomic.Int32
+
+	bus := NewAsyncEventBus(1, 10, 1, time.Millisecond, deadLetters)
+	bus.Subscribe(testEventType, handlerFunc(func(ctx context.Context, e event.Event) error {
+		select {
+		case <-started:
+		default:
+			close(started)
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+
+	ack := func() { acked.Add(1) }
+	require.NoError(t, bus.PublishWithAck(context.Background(), newTestEvent(), ack))
+	require.NoError(t, bus.PublishWithAck(context.Background(), newTestEvent(), ack))
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, bus.Shutdown(ctx), context.DeadlineExceeded)
+
+	assert.Zero(t, acked.Load())
+	assert.Empty(t, deadLetters.Letters())
+}
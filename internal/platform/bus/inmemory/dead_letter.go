@@ -0,0 +1,37 @@
+package inmemory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+)
+
+// DeadLetterStore implements event.DeadLetterStore in memory. Its letters are lost on
+// restart, which suits local runs and tests.
+type DeadLetterStore struct {
+	letters []event.DeadLetter
+	mu      sync.Mutex
+}
+
+// NewDeadLetterStore creates a new, empty DeadLetterStore.
+func NewDeadLetterStore() *DeadLetterStore {
+	return &DeadLetterStore{}
+}
+
+func (s *DeadLetterStore) Save(ctx context.Context, letter event.DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.letters = append(s.letters, letter)
+	return nil
+}
+
+// Letters returns the letters saved so far, oldest first.
+func (s *DeadLetterStore) Letters() []event.DeadLetter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.letters)
+}
@@ -0,0 +1,34 @@
+package outbox
+
+import (
+	"context"
+
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+)
+
+// Bus is an event.Bus that saves the events to the outbox instead of handing them to
+// their handlers. Published within a transaction, the events are only kept if it commits;
+// the Relay publishes them to the handlers afterwards.
+type Bus struct {
+	outbox event.Outbox
+	bus    event.Bus // Where the handlers subscribe, and where the Relay publishes
+}
+
+// NewBus creates a new Bus that saves to outbox the events for the handlers of bus.
+func NewBus(outbox event.Outbox, bus event.Bus) *Bus {
+	return &Bus{
+		outbox: outbox,
+		bus:    bus,
+	}
+}
+
+func (b *Bus) Publish(ctx context.Context, events []event.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return b.outbox.Save(ctx, events)
+}
+
+func (b *Bus) Subscribe(eventType event.Type, handler event.Handler) {
+	b.bus.Subscribe(eventType, handler)
+}
@@ -0,0 +1,56 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event/eventmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBusPublishSavesToTheOutbox(t *testing.T) {
+	events := []event.Event{newTestEvent(), newTestEvent()}
+	ctx := context.Background()
+
+	outboxMock := new(eventmocks.Outbox)
+	outboxMock.On("Save", ctx, events).Return(nil).Once()
+	busMock := new(eventmocks.Bus)
+
+	bus := NewBus(outboxMock, busMock)
+	assert.NoError(t, bus.Publish(ctx, events))
+
+	outboxMock.AssertExpectations(t)
+	busMock.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestBusPublishWithoutEvents(t *testing.T) {
+	outboxMock := new(eventmocks.Outbox)
+
+	bus := NewBus(outboxMock, new(eventmocks.Bus))
+	assert.NoError(t, bus.Publish(context.Background(), nil))
+
+	outboxMock.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestBusPublishOutboxError(t *testing.T) {
+	outboxMock := new(eventmocks.Outbox)
+	outboxMock.On("Save", mock.Anything, mock.Anything).Return(errors.New("insert error")).Once()
+
+	bus := NewBus(outboxMock, new(eventmocks.Bus))
+	assert.Error(t, bus.Publish(context.Background(), []event.Event{newTestEvent()}))
+}
+
+func TestBusSubscribeToTheRelayedBus(t *testing.T) {
+	handler := handlerFunc(func(ctx context.Context, e event.Event) error { return nil })
+
+	busMock := new(eventmocks.Bus)
+	busMock.On("Subscribe", testEventType, mock.Anything).Return().Once()
+
+	bus := NewBus(new(eventmocks.Outbox), busMock)
+	bus.Subscribe(testEventType, handler)
+
+	busMock.AssertExpectations(t)
+}
@@ -0,0 +1,92 @@
+package outbox
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+)
+
+// Publisher hands an event to its handlers and calls ack once they are all done with it.
+// It is implemented by inmemory.AsyncEventBus.
+type Publisher interface {
+	PublishWithAck(ctx context.Context, e event.Event, ack func()) error
+}
+
+// Relay publishes the events of the outbox. An event is only marked sent once its
+// handlers are done with it, so the events of a relay that stops halfway are relayed
+// again when their lease runs out: every event is delivered at least once.
+type Relay struct {
+	outbox    event.Outbox
+	publisher Publisher
+	interval  time.Duration // Wait between two polls of the outbox
+	lease     time.Duration // Time a claimed event has to be sent before it can be claimed again
+	batchSize int
+	now       func() time.Time
+}
+
+// NewRelay creates a new Relay that publishes the events of outbox to publisher.
+func NewRelay(outbox event.Outbox, publisher Publisher, interval, lease time.Duration, batchSize int) *Relay {
+	return &Relay{
+		outbox:    outbox,
+		publisher: publisher,
+		interval:  interval,
+		lease:     lease,
+		batchSize: max(batchSize, 1),
+		now:       time.Now,
+	}
+}
+
+// Run relays the events of the outbox every interval until ctx is done. It then relays
+// once more, so that the events saved right before the shutdown are not left waiting
+// for the next start, and returns the error of that last pass.
+func (r *Relay) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.Relay(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("failed to relay the outbox: %v", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return r.Relay(context.WithoutCancel(ctx))
+		}
+	}
+}
+
+// Relay publishes the unsent events of the outbox, a batch at a time, until there are no
+// more to claim.
+func (r *Relay) Relay(ctx context.Context) error {
+	for {
+		events, err := r.outbox.Claim(ctx, r.now(), r.lease, r.batchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, e := range events {
+			// The events left unpublished stay claimed until their lease runs out
+			if err := r.publisher.PublishWithAck(ctx, e, r.ack(e)); err != nil {
+				return err
+			}
+		}
+
+		if len(events) < r.batchSize {
+			return nil
+		}
+	}
+}
+
+// ack returns the function that marks e sent. If that fails the event is relayed again,
+// which at-least-once delivery allows for.
+func (r *Relay) ack(e event.Event) func() {
+	return func() {
+		// It runs after the relay may have stopped, and the event must still be marked
+		if err := r.outbox.MarkSent(context.Background(), e.ID(), r.now()); err != nil {
+			log.Printf("failed to mark event %s (%s) sent: %v", e.ID(), e.Type(), err)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event/eventmocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testEventType event.Type = "events.test"
+
+type testEvent struct {
+	event.BaseEvent
+}
+
+func (e testEvent) Type() event.Type {
+	return testEventType
+}
+
+func newTestEvent() testEvent {
+	return testEvent{BaseEvent: event.NewBaseEvent(uuid.NewString())}
+}
+
+// handlerFunc adapts a function into an event.Handler.
+type handlerFunc func(ctx context.Context, e event.Event) error
+
+func (f handlerFunc) Handle(ctx context.Context, e event.Event) error {
+	return f(ctx, e)
+}
+
+// publisherFunc adapts a function into a Publisher.
+type publisherFunc func(ctx context.Context, e event.Event, ack func()) error
+
+func (f publisherFunc) PublishWithAck(ctx context.Context, e event.Event, ack func()) error {
+	return f(ctx, e, ack)
+}
+
+// ackingPublisher acknowledges every event it is given right away, and records them.
+func ackingPublisher(published *[]event.Event) Publisher {
+	return publisherFunc(func(ctx context.Context, e event.Event, ack func()) error {
+		*published = append(*published, e)
+		ack()
+		return nil
+	})
+}
+
+var relayNow = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestRelay(outbox event.Outbox, publisher Publisher, batchSize int) *Relay {
+	relay := NewRelay(outbox, publisher, time.Hour, time.Minute, batchSize)
+	relay.now = func() time.Time { return relayNow }
+	return relay
+}
+
+func TestRelayPublishesAndMarksSent(t *testing.T) {
+	first, second := newTestEvent(), newTestEvent()
+	var published []event.Event
+
+	outboxMock := new(eventmocks.Outbox)
+	outboxMock.On("Claim", mock.Anything, relayNow, time.Minute, 10).Return([]event.Event{first, second}, nil).Once()
+	outboxMock.On("MarkSent", mock.Anything, first.ID(), relayNow).Return(nil).Once()
+	outboxMock.On("MarkSent", mock.Anything, second.ID(), relayNow).Return(nil).Once()
+
+	relay := newTestRelay(outboxMock, ackingPublisher(&published), 10)
+	assert.NoError(t, relay.Relay(context.Background()))
+
+	outboxMock.AssertExpectations(t)
+	assert.Equal(t, []event.Event{first, second}, published)
+}
+
+func TestRelayClaimsUntilABatchIsNotFull(t *testing.T) {
+	first, second, third := newTestEvent(), newTestEvent(), newTestEvent()
+	var published []event.Event
+
+	outboxMock := new(eventmocks.Outbox)
+	outboxMock.On("Claim", mock.Anything, relayNow, time.Minute, 2).Return([]event.Event{first, second}, nil).Once()
+	outboxMock.On("Claim", mock.Anything, relayNow, time.Minute, 2).Return([]event.Event{third}, nil).Once()
+	outboxMock.On("MarkSent", mock.Anything, mock.Anything, relayNow).Return(nil).Times(3)
+
+	relay := newTestRelay(outboxMock, ackingPublisher(&published), 2)
+	assert.NoError(t, relay.Relay(context.Background()))
+
+	outboxMock.AssertExpectations(t)
+	assert.Equal(t, []event.Event{first, second, third}, published)
+}
+
+func TestRelayDoesNotMarkUnacknowledgedEventsSent(t *testing.T) {
+	outboxMock := new(eventmocks.Outbox)
+	outboxMock.On("Claim", mock.Anything, relayNow, time.Minute, 10).Return([]event.Event{newTestEvent()}, nil).Once()
+
+	publisher := publisherFunc(func(ctx context.Context, e event.Event, ack func()) error { return nil })
+
+	relay := newTestRelay(outboxMock, publisher, 10)
+	assert.NoError(t, relay.Relay(context.Background()))
+
+	outboxMock.AssertExpectations(t)
+	outboxMock.AssertNotCalled(t, "MarkSent", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRelayClaimError(t *testing.T) {
+	outboxMock := new(eventmocks.Outbox)
+	outboxMock.On("Claim", mock.Anything, relayNow, time.Minute, 10).Return(nil, errors.New("select error")).Once()
+
+	relay := newTestRelay(outboxMock, ackingPublisher(new([]event.Event)), 10)
+	assert.Error(t, relay.Relay(context.Background()))
+}
+
+func TestRelayPublishError(t *testing.T) {
+	outboxMock := new(eventmocks.Outbox)
+	outboxMock.On("Claim", mock.Anything, relayNow, time.Minute, 10).Return([]event.Event{newTestEvent(), newTestEvent()}, nil).Once()
+
+	var calls int
+	publisher := publisherFunc(func(ctx context.Context, e event.Event, ack func()) error {
+		calls++
+		return errors.New("event bus closed")
+	})
+
+	relay := newTestRelay(outboxMock, publisher, 10)
+	assert.Error(t, relay.Relay(context.Background()))
+	assert.Equal(t, 1, calls)
+}
+
+func TestRelayRunRelaysOnceMoreWhenStopped(t *testing.T) {
+	e := newTestEvent()
+	var published []event.Event
+	ctx, cancel := context.WithCancel(context.Background())
+
+	outboxMock := new(eventmocks.Outbox)
+	// The first pass finds nothing and stops the relay; the event is saved right after
+	outboxMock.On("Claim", mock.Anything, relayNow, time.Minute, 10).Return(nil, nil).Run(func(mock.Arguments) { cancel() }).Once()
+	outboxMock.On("Claim", mock.Anything, relayNow, time.Minute, 10).Return([]event.Event{e}, nil).Once()
+	outboxMock.On("MarkSent", mock.Anything, e.ID(), relayNow).Return(nil).Once()
+
+	relay := newTestRelay(outboxMock, ackingPublisher(&published), 10)
+	assert.NoError(t, relay.Run(ctx))
+
+	outboxMock.AssertExpectations(t)
+	assert.Equal(t, []event.Event{e}, published)
+}
@@ -0,0 +1,37 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessage(t *testing.T) {
+	date := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	msg := message("no-reply@middle-earth.me", "pippin@shire.me", "Verify your email", "Hello,\n\nOpen the link.\n", date)
+
+	assert.Equal(t, "From: no-reply@middle-earth.me\r\n"+
+		"To: pippin@shire.me\r\n"+
+		"Subject: Verify your email\r\n"+
+		"Date: Mon, 01 Apr 2024 12:00:00 +0000\r\n"+
+		"MIME-Version: 1.0\r\n"+
+		"Content-Type: text/plain; charset=UTF-8\r\n"+
+		"\r\n"+
+		"Hello,\r\n\r\nOpen the link.\r\n", msg)
+}
+
+func TestLogMailerSend(t *testing.T) {
+	var buf bytes.Buffer
+	mailer := NewLogMailer(&buf, "no-reply@middle-earth.me")
+
+	require.NoError(t, mailer.Send(context.Background(), "pippin@shire.me", "Verify your email", "Open the link."))
+
+	assert.Contains(t, buf.String(), "To: pippin@shire.me\n")
+	assert.Contains(t, buf.String(), "Subject: Verify your email\n")
+	assert.Contains(t, buf.String(), "\n\nOpen the link.\n---\n")
+}
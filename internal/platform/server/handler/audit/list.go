@@ -0,0 +1,41 @@
+package audit
+
+import (
+	"errors"
+	"net/http"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/listing"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/query"
+	"github.com/gin-gonic/gin"
+)
+
+// ListHandler handles the listing of the audit log, newest entries first.
+func ListHandler(queryBus query.Bus) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var params dto.AuditQuery
+		if err := ctx.ShouldBindQuery(&params); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		entries, err := queryBus.Ask(ctx, listing.NewAuditQuery(params.Limit, params.Cursor, params.Entity, params.User, params.Since))
+		if err != nil {
+			switch {
+			case errors.Is(err, domain.ErrInvalidPageLimit),
+				errors.Is(err, domain.ErrInvalidCursor),
+				errors.Is(err, domain.ErrInvalidAuditEntity),
+				errors.Is(err, domain.ErrInvalidUserID),
+				errors.Is(err, domain.ErrInvalidAuditSince):
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			default:
+				ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+				return
+			}
+		}
+
+		ctx.JSON(http.StatusOK, entries)
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/deleting"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/command"
 	"github.com/gin-gonic/gin"
 )
@@ -18,15 +19,28 @@ func DeleteHandler(commandBus command.Bus) gin.HandlerFunc {
 			return
 		}
 
-		err := commandBus.Dispatch(ctx, deleting.NewCategoryCommand(categoryIDParam))
+		var params dto.DeleteQuery
+		if err := ctx.ShouldBindQuery(&params); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		err := commandBus.Dispatch(ctx, deleting.NewCategoryCommand(categoryIDParam, params.Cascade))
 		if err != nil {
+			var inUse domain.InUseError
 			switch {
+			case errors.As(err, &inUse):
+				ctx.JSON(http.StatusConflict, dto.NewInUseResponse(inUse))
+				return
 			case errors.Is(err, domain.ErrInvalidCategoryID):
 				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 				return
 			case errors.Is(err, domain.ErrCategoryNotFound):
 				ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 				return
+			case errors.Is(err, domain.ErrInUse):
+				ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+				return
 			default:
 				ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 				return
@@ -1,8 +1,11 @@
 package groups
 
 import (
+	"errors"
 	"net/http"
 
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/listing"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/query"
 	"github.com/gin-gonic/gin"
@@ -10,12 +13,25 @@ import (
 
 func ListHandler(queryBus query.Bus) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		groups, err := queryBus.Ask(ctx, listing.NewGroupsQuery())
-		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		var page dto.PageQuery
+		if err := ctx.ShouldBindQuery(&page); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
 
+		groups, err := queryBus.Ask(ctx, listing.NewGroupsQuery(page.Limit, page.Cursor))
+		if err != nil {
+			switch {
+			case errors.Is(err, domain.ErrInvalidPageLimit),
+				errors.Is(err, domain.ErrInvalidCursor):
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			default:
+				ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+		}
+
 		ctx.JSON(http.StatusOK, groups)
 	}
 }
@@ -0,0 +1,47 @@
+package groups
+
+import (
+	"errors"
+	"net/http"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/updating"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/command"
+	"github.com/gin-gonic/gin"
+)
+
+// UpdateDescriptionHandler returns a handler function that replaces the description of a group.
+func UpdateDescriptionHandler(commandBus command.Bus) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		groupIDParam := ctx.Param("id")
+		if groupIDParam == "" {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "group ID is required"})
+			return
+		}
+
+		var req dto.DescriptionUpdateRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+
+		cmd := updating.NewGroupDescriptionCommand(groupIDParam, req)
+		if err := commandBus.Dispatch(ctx, cmd); err != nil {
+			switch {
+			case errors.Is(err, domain.ErrGroupNotFound):
+				ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
+			case errors.Is(err, domain.ErrInvalidGroupID),
+				errors.Is(err, domain.ErrInvalidGroupDescription):
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			default:
+				ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+				return
+			}
+		}
+
+		ctx.Status(http.StatusNoContent)
+	}
+}
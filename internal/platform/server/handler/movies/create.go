@@ -22,8 +22,15 @@ func CreateHandler(commandBus command.Bus) gin.HandlerFunc {
 		err := commandBus.Dispatch(ctx, creating.NewMovieCommand(dto))
 		if err != nil {
 			switch {
+			case errors.Is(err, domain.ErrMovieSequenceAlreadyExists):
+				ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+				return
 			case errors.Is(err, domain.ErrInvalidMovieID),
-				errors.Is(err, domain.ErrInvalidMovieName):
+				errors.Is(err, domain.ErrInvalidMovieName),
+				errors.Is(err, domain.ErrInvalidReleaseYear),
+				errors.Is(err, domain.ErrInvalidMovieSequence),
+				errors.Is(err, domain.ErrInvalidMovieSeries),
+				errors.Is(err, domain.ErrInvalidMovieRuntime):
 				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 				return
 			default:
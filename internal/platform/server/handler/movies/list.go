@@ -1,8 +1,11 @@
 package movies
 
 import (
+	"errors"
 	"net/http"
 
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/listing"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/query"
 	"github.com/gin-gonic/gin"
@@ -10,12 +13,26 @@ import (
 
 func ListHandler(queryBus query.Bus) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		movies, err := queryBus.Ask(ctx, listing.NewMoviesQuery())
-		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		var params dto.MoviesQuery
+		if err := ctx.ShouldBindQuery(&params); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
 
+		movies, err := queryBus.Ask(ctx, listing.NewMoviesQuery(params.Limit, params.Cursor, params.Series))
+		if err != nil {
+			switch {
+			case errors.Is(err, domain.ErrInvalidPageLimit),
+				errors.Is(err, domain.ErrInvalidCursor),
+				errors.Is(err, domain.ErrInvalidMovieSeries):
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			default:
+				ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+				return
+			}
+		}
+
 		ctx.JSON(http.StatusOK, movies)
 	}
 }
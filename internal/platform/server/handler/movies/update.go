@@ -28,11 +28,18 @@ func UpdateHandler(commandBus command.Bus) gin.HandlerFunc {
 		cmd := updating.NewMovieCommand(movieIDParam, req)
 		if err := commandBus.Dispatch(ctx, cmd); err != nil {
 			switch {
+			case errors.Is(err, domain.ErrMovieSequenceAlreadyExists):
+				ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+				return
 			case errors.Is(err, domain.ErrMovieNotFound):
 				ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 				return
 			case errors.Is(err, domain.ErrInvalidMovieID),
-				errors.Is(err, domain.ErrInvalidMovieName):
+				errors.Is(err, domain.ErrInvalidMovieName),
+				errors.Is(err, domain.ErrInvalidReleaseYear),
+				errors.Is(err, domain.ErrInvalidMovieSequence),
+				errors.Is(err, domain.ErrInvalidMovieSeries),
+				errors.Is(err, domain.ErrInvalidMovieRuntime):
 				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 				return
 			default:
@@ -0,0 +1,43 @@
+package registration
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/registering"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/command"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterHandler returns a handler function that signs up visitors. They can log in once
+// they open the verification link mailed to them. An email already registered gets the
+// same answer as a new one.
+func RegisterHandler(commandBus command.Bus) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var dto dto.RegisterRequest
+		if err := ctx.ShouldBindJSON(&dto); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		err := commandBus.Dispatch(ctx, registering.NewRegisterCommand(dto))
+		if err != nil {
+			switch {
+			case errors.Is(err, domain.ErrInvalidUserName),
+				errors.Is(err, domain.ErrInvalidUserEmail),
+				errors.Is(err, domain.ErrInvalidUserPassword):
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			default:
+				log.Printf("[REGISTER ERROR] %v", err)
+				ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+				return
+			}
+		}
+
+		ctx.JSON(http.StatusCreated, gin.H{"message": "Check your email to verify your account"})
+	}
+}
@@ -0,0 +1,138 @@
+package registration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/auth"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/command/commandmocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	registerRoute = "/register"
+	verifyRoute   = "/verify"
+	resendRoute   = "/verify/resend"
+)
+
+func TestRegisterHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	register := func(t *testing.T, commandBus *commandmocks.Bus, req dto.RegisterRequest) int {
+		r := gin.New()
+		r.POST(registerRoute, RegisterHandler(commandBus))
+
+		b, err := json.Marshal(req)
+		require.NoError(t, err)
+
+		httpReq, err := http.NewRequest(http.MethodPost, registerRoute, bytes.NewBuffer(b))
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httpReq)
+		return rec.Code
+	}
+
+	t.Run("Given invalid request, should return 400", func(t *testing.T) {
+		commandBus := new(commandmocks.Bus)
+		defer commandBus.AssertExpectations(t)
+
+		code := register(t, commandBus, dto.RegisterRequest{Email: "pippin@shire.me", Password: "second-breakfast"})
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("Given a short password, should return 400", func(t *testing.T) {
+		_, hashErr := auth.HashPassword("short")
+		require.Error(t, hashErr)
+
+		commandBus := new(commandmocks.Bus)
+		commandBus.On("Dispatch", mock.Anything, mock.AnythingOfType("registering.RegisterCommand")).Return(hashErr)
+		defer commandBus.AssertExpectations(t)
+
+		code := register(t, commandBus, dto.RegisterRequest{Name: "Pippin Took", Email: "pippin@shire.me", Password: "short"})
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("Given valid request, should return 201", func(t *testing.T) {
+		commandBus := new(commandmocks.Bus)
+		commandBus.On("Dispatch", mock.Anything, mock.AnythingOfType("registering.RegisterCommand")).Return(nil)
+		defer commandBus.AssertExpectations(t)
+
+		code := register(t, commandBus, dto.RegisterRequest{Name: "Pippin Took", Email: "pippin@shire.me", Password: "second-breakfast"})
+		assert.Equal(t, http.StatusCreated, code)
+	})
+}
+
+func TestVerifyHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	verify := func(t *testing.T, commandBus *commandmocks.Bus) int {
+		r := gin.New()
+		r.GET(verifyRoute, VerifyHandler(commandBus))
+
+		req, err := http.NewRequest(http.MethodGet, verifyRoute+"?token=some-token", nil)
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	t.Run("Given an invalid token, should return 400", func(t *testing.T) {
+		commandBus := new(commandmocks.Bus)
+		commandBus.On("Dispatch", mock.Anything, mock.AnythingOfType("registering.VerifyCommand")).Return(domain.ErrInvalidVerificationToken)
+		defer commandBus.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusBadRequest, verify(t, commandBus))
+	})
+
+	t.Run("Given a valid token, should return 200", func(t *testing.T) {
+		commandBus := new(commandmocks.Bus)
+		commandBus.On("Dispatch", mock.Anything, mock.AnythingOfType("registering.VerifyCommand")).Return(nil)
+		defer commandBus.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusOK, verify(t, commandBus))
+	})
+}
+
+func TestResendVerificationHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	resend := func(t *testing.T, commandBus *commandmocks.Bus, req dto.ResendVerificationRequest) int {
+		r := gin.New()
+		r.POST(resendRoute, ResendVerificationHandler(commandBus))
+
+		b, err := json.Marshal(req)
+		require.NoError(t, err)
+
+		httpReq, err := http.NewRequest(http.MethodPost, resendRoute, bytes.NewBuffer(b))
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httpReq)
+		return rec.Code
+	}
+
+	t.Run("Given an invalid email, should return 400", func(t *testing.T) {
+		commandBus := new(commandmocks.Bus)
+		defer commandBus.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusBadRequest, resend(t, commandBus, dto.ResendVerificationRequest{Email: "pippin"}))
+	})
+
+	t.Run("Given an email, should return 202", func(t *testing.T) {
+		commandBus := new(commandmocks.Bus)
+		commandBus.On("Dispatch", mock.Anything, mock.AnythingOfType("registering.ResendVerificationCommand")).Return(nil)
+		defer commandBus.AssertExpectations(t)
+
+		assert.Equal(t, http.StatusAccepted, resend(t, commandBus, dto.ResendVerificationRequest{Email: "pippin@shire.me"}))
+	})
+}
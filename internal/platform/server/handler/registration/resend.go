@@ -0,0 +1,32 @@
+package registration
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/registering"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/command"
+	"github.com/gin-gonic/gin"
+)
+
+// ResendVerificationHandler returns a handler function that mails a new verification link.
+// It answers the same whether or not the email is registered or verified already.
+func ResendVerificationHandler(commandBus command.Bus) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var dto dto.ResendVerificationRequest
+		if err := ctx.ShouldBindJSON(&dto); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		err := commandBus.Dispatch(ctx, registering.NewResendVerificationCommand(dto))
+		if err != nil {
+			log.Printf("[RESEND VERIFICATION ERROR] %v", err)
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+
+		ctx.JSON(http.StatusAccepted, gin.H{"message": "If the email is registered and not verified yet, a new verification link has been sent to it"})
+	}
+}
@@ -0,0 +1,34 @@
+package registration
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/registering"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/command"
+	"github.com/gin-gonic/gin"
+)
+
+// VerifyHandler returns a handler function that verifies the email of a user with the
+// token of the link mailed to them.
+func VerifyHandler(commandBus command.Bus) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		err := commandBus.Dispatch(ctx, registering.NewVerifyCommand(ctx.Query("token")))
+		if err != nil {
+			switch {
+			case errors.Is(err, domain.ErrInvalidVerificationToken),
+				errors.Is(err, domain.ErrUserNotFound):
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": domain.ErrInvalidVerificationToken.Error()})
+				return
+			default:
+				log.Printf("[VERIFY ERROR] %v", err)
+				ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+				return
+			}
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"message": "Email verified, you can now log in"})
+	}
+}
@@ -0,0 +1,36 @@
+package search
+
+import (
+	"errors"
+	"net/http"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/searching"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/query"
+	"github.com/gin-gonic/gin"
+)
+
+func SearchHandler(queryBus query.Bus) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var params dto.SearchQuery
+		if err := ctx.ShouldBindQuery(&params); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		results, err := queryBus.Ask(ctx, searching.NewSearchQuery(params.Q, params.Limit))
+		if err != nil {
+			switch {
+			case errors.Is(err, domain.ErrInvalidSearchTerm),
+				errors.Is(err, domain.ErrInvalidPageLimit):
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			default:
+				ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+				return
+			}
+		}
+		ctx.JSON(http.StatusOK, results)
+	}
+}
@@ -0,0 +1,22 @@
+package session
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// jwksMaxAge is how long, in seconds, verifiers may cache the key set. New keys are
+// published well ahead of their first use, see auth.KeyRotator.
+const jwksMaxAge = 300
+
+// JWKSHandler returns a handler function that serves the public keys the access tokens
+// can be verified with, as a JSON Web Key Set.
+func JWKSHandler(signer auth.Signer) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", jwksMaxAge))
+		ctx.JSON(http.StatusOK, signer.JWKS())
+	}
+}
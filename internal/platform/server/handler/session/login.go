@@ -24,7 +24,7 @@ func LoginHandler(queryBus query.Bus) gin.HandlerFunc {
 			return
 		}
 
-		token, err := queryBus.Ask(ctx, authenticating.NewLoginQuery(req.Email, req.Password))
+		tokens, err := queryBus.Ask(ctx, authenticating.NewLoginQuery(req.Email, req.Password))
 		if err != nil {
 			switch {
 			case errors.Is(err, domain.ErrUserNotFound):
@@ -33,6 +33,9 @@ func LoginHandler(queryBus query.Bus) gin.HandlerFunc {
 			case errors.Is(err, domain.ErrInvalidUserPassword):
 				ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 				return
+			case errors.Is(err, domain.ErrUserNotVerified):
+				ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+				return
 			case errors.Is(err, domain.ErrInvalidUserEmail):
 				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 				return
@@ -43,6 +46,6 @@ func LoginHandler(queryBus query.Bus) gin.HandlerFunc {
 			}
 		}
 
-		ctx.JSON(http.StatusOK, gin.H{"token": token})
+		ctx.JSON(http.StatusOK, tokens)
 	}
 }
@@ -0,0 +1,39 @@
+package session
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/authenticating"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/command"
+	"github.com/gin-gonic/gin"
+)
+
+// LogoutHandler returns a handler function that ends the session of the access token
+// the request was authenticated with. It must run after the jwt middleware.
+func LogoutHandler(commandBus command.Bus) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		sessionID := ctx.GetString("sid")
+		jti := ctx.GetString("jti")
+		// The exp claim is decoded from JSON as a float64 number of seconds
+		exp := ctx.GetFloat64("exp")
+
+		err := commandBus.Dispatch(ctx, authenticating.NewLogoutCommand(sessionID, jti, time.Unix(int64(exp), 0)))
+		if err != nil {
+			switch {
+			case errors.Is(err, domain.ErrInvalidSessionID):
+				ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+				return
+			default:
+				log.Printf("[LOGOUT ERROR] %v", err)
+				ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+				return
+			}
+		}
+
+		ctx.Status(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,40 @@
+package session
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/authenticating"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/query"
+	"github.com/gin-gonic/gin"
+)
+
+// RefreshHandler returns a handler function that exchanges a refresh token for a new
+// pair of tokens.
+func RefreshHandler(queryBus query.Bus) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req dto.TokenRefreshRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		tokens, err := queryBus.Ask(ctx, authenticating.NewRefreshQuery(req.RefreshToken))
+		if err != nil {
+			switch {
+			case errors.Is(err, domain.ErrInvalidRefreshToken):
+				ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+				return
+			default:
+				log.Printf("[REFRESH ERROR] %v", err)
+				ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+				return
+			}
+		}
+
+		ctx.JSON(http.StatusOK, tokens)
+	}
+}
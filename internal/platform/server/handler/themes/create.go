@@ -26,7 +26,8 @@ func CreateHandler(commandBus command.Bus) gin.HandlerFunc {
 				errors.Is(err, domain.ErrInvalidThemeName),
 				errors.Is(err, domain.ErrInvalidGroupID),
 				errors.Is(err, domain.ErrInvalidCategoryID),
-				errors.Is(err, domain.ErrInvalidTrackID):
+				errors.Is(err, domain.ErrInvalidTrackID),
+				errors.Is(err, domain.ErrEndSecondExceedsTrackDuration):
 				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 				return
 			case errors.Is(err, domain.ErrGroupNotFound),
@@ -1,8 +1,11 @@
 package themes
 
 import (
+	"errors"
 	"net/http"
 
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/listing"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/query"
 	"github.com/gin-gonic/gin"
@@ -10,11 +13,43 @@ import (
 
 func ListHandler(queryBus query.Bus) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		themes, err := queryBus.Ask(ctx, listing.NewThemesQuery())
-		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		var params dto.ThemesQuery
+		if err := ctx.ShouldBindQuery(&params); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+
+		var fields []string
+		for field := range ctx.Request.URL.Query() {
+			fields = append(fields, field)
+		}
+
+		filter := listing.ThemesFilter{
+			GroupID:    params.GroupID,
+			CategoryID: params.CategoryID,
+			MovieID:    params.MovieID,
+			Name:       params.Name,
+			Sort:       params.Sort,
+		}
+
+		themes, err := queryBus.Ask(ctx, listing.NewThemesQuery(params.Limit, params.Cursor, filter, fields))
+		if err != nil {
+			switch {
+			case errors.Is(err, domain.ErrInvalidPageLimit),
+				errors.Is(err, domain.ErrInvalidCursor),
+				errors.Is(err, domain.ErrInvalidGroupID),
+				errors.Is(err, domain.ErrInvalidCategoryID),
+				errors.Is(err, domain.ErrInvalidMovieID),
+				errors.Is(err, domain.ErrInvalidThemeSort),
+				errors.Is(err, listing.ErrUnknownQueryField):
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			default:
+				ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+				return
+			}
+		}
+
 		ctx.JSON(http.StatusOK, themes)
 	}
 }
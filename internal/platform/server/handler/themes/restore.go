@@ -0,0 +1,42 @@
+package themes
+
+import (
+	"errors"
+	"net/http"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/restoring"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/command"
+	"github.com/gin-gonic/gin"
+)
+
+// RestoreHandler handles taking a theme out of the trash.
+func RestoreHandler(commandBus command.Bus) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.Param("id")
+		if id == "" {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "theme ID is required"})
+			return
+		}
+
+		err := commandBus.Dispatch(ctx, restoring.NewThemeCommand(id))
+		if err != nil {
+			switch {
+			case errors.Is(err, domain.ErrInvalidThemeID):
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			case errors.Is(err, domain.ErrDeletedReference):
+				ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+				return
+			case errors.Is(err, domain.ErrThemeNotFound):
+				ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
+			default:
+				ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+				return
+			}
+		}
+
+		ctx.Status(http.StatusNoContent)
+	}
+}
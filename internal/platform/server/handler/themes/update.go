@@ -28,11 +28,17 @@ func UpdateHandler(commandBus command.Bus) gin.HandlerFunc {
 		cmd := updating.NewThemeCommand(ctx.Param("id"), req)
 		if err := commandBus.Dispatch(ctx, cmd); err != nil {
 			switch {
-			case errors.Is(err, domain.ErrThemeNotFound):
+			case errors.Is(err, domain.ErrThemeNotFound),
+				errors.Is(err, domain.ErrGroupNotFound),
+				errors.Is(err, domain.ErrCategoryNotFound),
+				errors.Is(err, domain.ErrTrackNotFound):
 				ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 				return
 			case errors.Is(err, domain.ErrInvalidThemeID),
-				errors.Is(err, domain.ErrInvalidThemeName):
+				errors.Is(err, domain.ErrInvalidThemeName),
+				errors.Is(err, domain.ErrInvalidGroupID),
+				errors.Is(err, domain.ErrInvalidCategoryID),
+				errors.Is(err, domain.ErrInvalidTrackID):
 				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 				return
 			default:
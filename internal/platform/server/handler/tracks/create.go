@@ -24,12 +24,19 @@ func CreateHandler(commandBus command.Bus) gin.HandlerFunc {
 			switch {
 			case errors.Is(err, domain.ErrInvalidTrackID),
 				errors.Is(err, domain.ErrInvalidTrackName),
+				errors.Is(err, domain.ErrInvalidTrackNumber),
+				errors.Is(err, domain.ErrInvalidDiscNumber),
+				errors.Is(err, domain.ErrInvalidTrackEdition),
+				errors.Is(err, domain.ErrInvalidTrackDuration),
 				errors.Is(err, domain.ErrInvalidMovieID):
 				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 				return
 			case errors.Is(err, domain.ErrMovieNotFound):
 				ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 				return
+			case errors.Is(err, domain.ErrTrackPositionAlreadyExists):
+				ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+				return
 			default:
 				ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 				return
@@ -28,13 +28,22 @@ func UpdateHandler(commandBus command.Bus) gin.HandlerFunc {
 		cmd := updating.NewTrackCommand(ctx.Param("id"), req)
 		if err := commandBus.Dispatch(ctx, cmd); err != nil {
 			switch {
-			case errors.Is(err, domain.ErrTrackNotFound):
+			case errors.Is(err, domain.ErrTrackNotFound),
+				errors.Is(err, domain.ErrMovieNotFound):
 				ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 				return
 			case errors.Is(err, domain.ErrInvalidTrackID),
-				errors.Is(err, domain.ErrInvalidTrackName):
+				errors.Is(err, domain.ErrInvalidTrackName),
+				errors.Is(err, domain.ErrInvalidTrackNumber),
+				errors.Is(err, domain.ErrInvalidDiscNumber),
+				errors.Is(err, domain.ErrInvalidTrackEdition),
+				errors.Is(err, domain.ErrInvalidTrackDuration),
+				errors.Is(err, domain.ErrInvalidMovieID):
 				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 				return
+			case errors.Is(err, domain.ErrTrackPositionAlreadyExists):
+				ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+				return
 			default:
 				ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 				return
@@ -25,13 +25,17 @@ func CreateHandler(commandBus command.Bus) gin.HandlerFunc {
 			case errors.Is(err, domain.ErrInvalidTrackID),
 				errors.Is(err, domain.ErrInvalidThemeID),
 				errors.Is(err, domain.ErrInvalidStartSecond),
-				errors.Is(err, domain.ErrInvalidEndSecond):
+				errors.Is(err, domain.ErrInvalidEndSecond),
+				errors.Is(err, domain.ErrEndSecondExceedsTrackDuration):
 				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 				return
 			case errors.Is(err, domain.ErrTrackNotFound),
 				errors.Is(err, domain.ErrThemeNotFound):
 				ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 				return
+			case errors.Is(err, domain.ErrTrackThemeOverlaps):
+				ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+				return
 			default:
 				ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 				return
@@ -1,8 +1,10 @@
 package tracks_themes
 
 import (
+	"errors"
 	"net/http"
 
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/listing"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/query"
 	"github.com/gin-gonic/gin"
@@ -20,3 +22,25 @@ func ListByTrackHandler(queryBus query.Bus) gin.HandlerFunc {
 		ctx.JSON(http.StatusOK, tracksThemes)
 	}
 }
+
+func ListByThemeHandler(queryBus query.Bus) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		themeID := ctx.Param("id")
+		tracksThemes, err := queryBus.Ask(ctx, listing.NewTracksThemesByThemeQuery(themeID))
+		if err != nil {
+			switch {
+			case errors.Is(err, domain.ErrInvalidThemeID):
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			case errors.Is(err, domain.ErrThemeNotFound):
+				ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
+			default:
+				ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+				return
+			}
+		}
+
+		ctx.JSON(http.StatusOK, tracksThemes)
+	}
+}
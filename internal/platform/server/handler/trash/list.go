@@ -0,0 +1,39 @@
+package trash
+
+import (
+	"errors"
+	"net/http"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/listing"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/query"
+	"github.com/gin-gonic/gin"
+)
+
+// ListHandler handles the listing of the movies, groups, categories, tracks and themes in
+// the trash, most recently deleted first.
+func ListHandler(queryBus query.Bus) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var page dto.PageQuery
+		if err := ctx.ShouldBindQuery(&page); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		items, err := queryBus.Ask(ctx, listing.NewTrashQuery(page.Limit, page.Cursor))
+		if err != nil {
+			switch {
+			case errors.Is(err, domain.ErrInvalidPageLimit),
+				errors.Is(err, domain.ErrInvalidCursor):
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			default:
+				ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+				return
+			}
+		}
+
+		ctx.JSON(http.StatusOK, items)
+	}
+}
@@ -26,7 +26,10 @@ func CreateHandler(commandBus command.Bus) gin.HandlerFunc {
 			switch {
 			case errors.Is(err, domain.ErrInvalidUserID),
 				errors.Is(err, domain.ErrInvalidUserName),
-				errors.Is(err, domain.ErrInvalidUserEmail):
+				errors.Is(err, domain.ErrInvalidUserEmail),
+				errors.Is(err, domain.ErrInvalidUserPassword),
+				errors.Is(err, domain.ErrInvalidRoleName),
+				errors.Is(err, domain.ErrRoleNotFound):
 				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 				return
 			case errors.Is(err, domain.ErrUserAlreadyExists):
@@ -0,0 +1,41 @@
+package users
+
+import (
+	"errors"
+	"net/http"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/deleting"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/command"
+	"github.com/gin-gonic/gin"
+)
+
+// DeleteHandler returns a handler function that removes a user for good.
+func DeleteHandler(commandBus command.Bus) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userIDParam := ctx.Param("id")
+		if userIDParam == "" {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "user ID is required"})
+			return
+		}
+
+		if err := commandBus.Dispatch(ctx, deleting.NewUserCommand(userIDParam)); err != nil {
+			switch {
+			case errors.Is(err, domain.ErrInvalidUserID):
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			case errors.Is(err, domain.ErrUserNotFound):
+				ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
+			case errors.Is(err, domain.ErrLastAdmin):
+				ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+				return
+			default:
+				ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+				return
+			}
+		}
+
+		ctx.Status(http.StatusNoContent)
+	}
+}
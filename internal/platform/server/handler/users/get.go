@@ -0,0 +1,35 @@
+package users
+
+import (
+	"net/http"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/getting"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/query"
+	"github.com/gin-gonic/gin"
+)
+
+func GetHandler(queryBus query.Bus) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userIDParam := ctx.Param("id")
+		if userIDParam == "" {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "user ID is required"})
+			return
+		}
+		user, err := queryBus.Ask(ctx, getting.NewUsersQuery(userIDParam))
+		if err != nil {
+			switch err {
+			case domain.ErrUserNotFound:
+				ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
+			case domain.ErrInvalidUserID:
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+
+		ctx.JSON(http.StatusOK, user)
+	}
+}
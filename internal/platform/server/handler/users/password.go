@@ -0,0 +1,54 @@
+package users
+
+import (
+	"errors"
+	"net/http"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/updating"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/command"
+	"github.com/gin-gonic/gin"
+)
+
+// PasswordHandler returns a handler function that changes the password of the
+// authenticated user, who must give the current one. Users can only change their own.
+func PasswordHandler(commandBus command.Bus) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userIDParam := ctx.Param("id")
+		if userIDParam == "" {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "user ID is required"})
+			return
+		}
+		if userIDParam != ctx.GetString("userID") {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": "Forbidden"})
+			return
+		}
+
+		var req dto.UserPasswordRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := commandBus.Dispatch(ctx, updating.NewUserPasswordCommand(userIDParam, ctx.GetString("sid"), req)); err != nil {
+			switch {
+			case errors.Is(err, domain.ErrUserNotFound):
+				ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
+			case errors.Is(err, domain.ErrInvalidUserID),
+				errors.Is(err, domain.ErrInvalidUserPassword):
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			case errors.Is(err, domain.ErrIncorrectPassword):
+				ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+				return
+			default:
+				ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+				return
+			}
+		}
+
+		ctx.Status(http.StatusNoContent)
+	}
+}
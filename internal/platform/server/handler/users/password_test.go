@@ -0,0 +1,68 @@
+package users
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/auth"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/command/commandmocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const userID = "123e4567-e89b-12d3-a456-426614174000"
+
+func TestPasswordHandler(t *testing.T) {
+	commandBus := new(commandmocks.Bus)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/users/:id/password", func(ctx *gin.Context) {
+		// Stands for the JWT middleware
+		ctx.Set("userID", userID)
+	}, PasswordHandler(commandBus))
+
+	post := func(t *testing.T, id string, body dto.UserPasswordRequest) int {
+		b, err := json.Marshal(body)
+		require.NoError(t, err)
+
+		req, err := http.NewRequest(http.MethodPost, "/users/"+id+"/password", bytes.NewBuffer(b))
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	body := dto.UserPasswordRequest{CurrentPassword: "old-password", NewPassword: "new-password"}
+
+	t.Run("Given another user, should return 403", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, post(t, "9b2f0c4e-1a1b-4c1d-8e9f-0a1b2c3d4e5f", body))
+	})
+
+	t.Run("Given an incorrect current password, should return 401", func(t *testing.T) {
+		commandBus.On("Dispatch", mock.Anything, mock.AnythingOfType("updating.UserPasswordCommand")).Return(domain.ErrIncorrectPassword).Once()
+		assert.Equal(t, http.StatusUnauthorized, post(t, userID, body))
+	})
+
+	t.Run("Given a short new password, should return 400", func(t *testing.T) {
+		_, hashErr := auth.HashPassword("short")
+		require.Error(t, hashErr)
+
+		commandBus.On("Dispatch", mock.Anything, mock.AnythingOfType("updating.UserPasswordCommand")).Return(hashErr).Once()
+		assert.Equal(t, http.StatusBadRequest, post(t, userID, dto.UserPasswordRequest{CurrentPassword: "old-password", NewPassword: "short"}))
+	})
+
+	t.Run("Given the current password, should return 204", func(t *testing.T) {
+		commandBus.On("Dispatch", mock.Anything, mock.AnythingOfType("updating.UserPasswordCommand")).Return(nil).Once()
+		assert.Equal(t, http.StatusNoContent, post(t, userID, body))
+	})
+
+	commandBus.AssertExpectations(t)
+}
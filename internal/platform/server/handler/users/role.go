@@ -0,0 +1,50 @@
+package users
+
+import (
+	"errors"
+	"net/http"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/updating"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/command"
+	"github.com/gin-gonic/gin"
+)
+
+// RoleHandler returns a handler function that gives a user another role.
+func RoleHandler(commandBus command.Bus) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userIDParam := ctx.Param("id")
+		if userIDParam == "" {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "user ID is required"})
+			return
+		}
+
+		var req dto.UserRoleRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := commandBus.Dispatch(ctx, updating.NewUserRoleCommand(userIDParam, req)); err != nil {
+			switch {
+			case errors.Is(err, domain.ErrUserNotFound):
+				ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
+			case errors.Is(err, domain.ErrLastAdmin):
+				ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+				return
+			case errors.Is(err, domain.ErrInvalidUserID),
+				errors.Is(err, domain.ErrInvalidRoleName),
+				errors.Is(err, domain.ErrRoleNotFound):
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			default:
+				ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+				return
+			}
+		}
+
+		ctx.Status(http.StatusNoContent)
+	}
+}
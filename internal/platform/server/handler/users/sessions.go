@@ -0,0 +1,39 @@
+package users
+
+import (
+	"errors"
+	"net/http"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/authenticating"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/command"
+	"github.com/gin-gonic/gin"
+)
+
+// RevokeSessionsHandler returns a handler function that signs a user out everywhere:
+// every session of the user is revoked, along with the access tokens issued for them.
+func RevokeSessionsHandler(commandBus command.Bus) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userIDParam := ctx.Param("id")
+		if userIDParam == "" {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "user ID is required"})
+			return
+		}
+
+		if err := commandBus.Dispatch(ctx, authenticating.NewRevokeSessionsCommand(userIDParam)); err != nil {
+			switch {
+			case errors.Is(err, domain.ErrInvalidUserID):
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			case errors.Is(err, domain.ErrUserNotFound):
+				ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
+			default:
+				ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+				return
+			}
+		}
+
+		ctx.Status(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,43 @@
+package webhooks
+
+import (
+	"errors"
+	"net/http"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/updating"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/command"
+	"github.com/gin-gonic/gin"
+)
+
+// UpdateHandler returns a handler function that replaces the URL, the secret and the
+// event types of a webhook.
+func UpdateHandler(commandBus command.Bus) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req dto.WebhookUpdateRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := commandBus.Dispatch(ctx, updating.NewWebhookCommand(ctx.Param("id"), req)); err != nil {
+			switch {
+			case errors.Is(err, domain.ErrWebhookNotFound):
+				ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
+			case errors.Is(err, domain.ErrInvalidWebhookID),
+				errors.Is(err, domain.ErrInvalidWebhookURL),
+				errors.Is(err, domain.ErrInvalidWebhookSecret),
+				errors.Is(err, domain.ErrInvalidWebhookEventType):
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			default:
+				ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+				return
+			}
+		}
+
+		ctx.Status(http.StatusNoContent)
+	}
+}
@@ -1,23 +0,0 @@
-package admin
-
-import (
-	"net/http"
-
-	"github.com/gin-gonic/gin"
-)
-
-func Middleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		isAdmin, ok := c.Get("is_admin")
-		if !ok {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
-		}
-
-		isAdminBool, ok := isAdmin.(bool)
-		if !ok || !isAdminBool {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Forbidden"})
-			return
-		}
-		c.Next()
-	}
-}
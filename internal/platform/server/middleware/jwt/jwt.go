@@ -1,15 +1,20 @@
 package jwt
 
 import (
+	"log"
 	"net/http"
 	"strings"
 
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/authenticating"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/auth"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/query"
 	"github.com/gin-gonic/gin"
 )
 
 // Middleware is a gin.HandlerFunc that middleware for handling JWT authentication.
-func Middleware(jwtKey auth.JWTKey) gin.HandlerFunc {
+// Tokens revoked on logout, or whose session was revoked, are rejected, checking their
+// jti and sid claims through the query bus.
+func Middleware(signer auth.Signer, queryBus query.Bus) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Validate and parse the JWT token
 		tokenString := c.Request.Header.Get("Authorization")
@@ -26,17 +31,42 @@ func Middleware(jwtKey auth.JWTKey) gin.HandlerFunc {
 		}
 		tokenString = parts[1]
 
-		claims, err := auth.ValidateToken(tokenString, jwtKey)
+		claims, err := auth.ValidateToken(tokenString, signer)
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
 			return
 		}
 
+		jti, ok := claims["jti"].(string)
+		if !ok || jti == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			return
+		}
+		sid, ok := claims["sid"].(string)
+		if !ok || sid == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			return
+		}
+
+		revoked, err := queryBus.Ask(c, authenticating.NewRevokedQuery(jti, sid))
+		if err != nil {
+			log.Printf("[JWT ERROR] %v", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+		if isRevoked, _ := revoked.(bool); isRevoked {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token has been revoked"})
+			return
+		}
+
 		// Store the claims in the context
 		c.Set("userID", claims["id"])
 		c.Set("email", claims["email"])
 		c.Set("name", claims["name"])
-		c.Set("is_admin", claims["is_admin"])
+		c.Set("role", claims["role"])
+		c.Set("jti", jti)
+		c.Set("sid", sid)
+		c.Set("exp", claims["exp"])
 		c.Next()
 	}
 }
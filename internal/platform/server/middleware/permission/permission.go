@@ -0,0 +1,30 @@
+package permission
+
+import (
+	"log"
+	"net/http"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/authorizing"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/query"
+	"github.com/gin-gonic/gin"
+)
+
+// Middleware is a gin.HandlerFunc that lets through the users whose current role grants
+// the permission, asking through the query bus. It runs after the JWT middleware, which
+// sets the ID of the user.
+func Middleware(queryBus query.Bus, permission domain.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		allowed, err := queryBus.Ask(c, authorizing.NewPermissionQuery(c.GetString("userID"), permission))
+		if err != nil {
+			log.Printf("[PERMISSION ERROR] %v", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+		if isAllowed, _ := allowed.(bool); !isAllowed {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Forbidden"})
+			return
+		}
+		c.Next()
+	}
+}
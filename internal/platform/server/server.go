@@ -9,19 +9,25 @@ import (
 	"os/signal"
 	"time"
 
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/auth"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/server/handler/audit"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/server/handler/categories"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/server/handler/groups"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/server/handler/health"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/server/handler/movies"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/server/handler/registration"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/server/handler/search"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/server/handler/session"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/server/handler/themes"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/server/handler/tracks"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/server/handler/tracks_themes"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/server/handler/trash"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/server/handler/users"
-	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/server/middleware/admin"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/server/handler/webhooks"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/server/middleware/jwt"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/server/middleware/log_server"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/server/middleware/permission"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/command"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/query"
 	"github.com/gin-contrib/cors"
@@ -35,8 +41,8 @@ type Server struct {
 	// shutdownTimeout is the duration to wait for graceful shutdown.
 	shutdownTimeout time.Duration
 
-	// jwtKey is the key used to sign the JWT tokens.
-	jwtKey auth.JWTKey
+	// signer signs and verifies the JWT tokens.
+	signer auth.Signer
 
 	// deps
 	commandBus command.Bus
@@ -47,13 +53,13 @@ type Server struct {
 	frontendURL string
 }
 
-func New(ctx context.Context, host string, port uint, shutdownTimeout time.Duration, commandBus command.Bus, queryBus query.Bus, jwtKey auth.JWTKey, frontendURL string) (context.Context, Server) {
+func New(ctx context.Context, host string, port uint, shutdownTimeout time.Duration, commandBus command.Bus, queryBus query.Bus, signer auth.Signer, frontendURL string) (context.Context, Server) {
 	srv := Server{
 		httpAddr: fmt.Sprintf("%s:%d", host, port),
 		engine:   gin.New(),
 
 		shutdownTimeout: shutdownTimeout,
-		jwtKey:          jwtKey,
+		signer:          signer,
 
 		commandBus: commandBus,
 		queryBus:   queryBus,
@@ -96,6 +102,11 @@ func (s *Server) registerRoutes() {
 	const categoryIDRoute = "/categories/:id"
 	const trackIDRoute = "/tracks/:id"
 	const themeIDRoute = "/themes/:id"
+	const restoreRoute = "/restore"
+	const trashRoute = "/trash"
+	const userIDRoute = "/users/:id"
+	const webhooksRoute = "/admin/webhooks"
+	const descriptionRoute = "/description"
 
 	s.engine.Use(
 		log_server.Middleware(),
@@ -111,9 +122,14 @@ func (s *Server) registerRoutes() {
 		}),
 	)
 	s.engine.GET("/health", health.CheckHandler())
+	s.engine.GET("/.well-known/jwks.json", session.JWKSHandler(s.signer))
 
 	// Public routes
 	s.engine.POST("/login", session.LoginHandler(s.queryBus))
+	s.engine.POST("/token/refresh", session.RefreshHandler(s.queryBus))
+	s.engine.POST("/register", registration.RegisterHandler(s.commandBus))
+	s.engine.GET("/verify", registration.VerifyHandler(s.commandBus))
+	s.engine.POST("/verify/resend", registration.ResendVerificationHandler(s.commandBus))
 
 	s.engine.GET("/movies", movies.ListHandler(s.queryBus))
 	s.engine.GET(movieIDRoute, movies.GetHandler(s.queryBus))
@@ -132,41 +148,88 @@ func (s *Server) registerRoutes() {
 
 	s.engine.GET(themesRoute, themes.ListHandler(s.queryBus))
 	s.engine.GET(themeIDRoute, themes.GetHandler(s.queryBus))
+	s.engine.GET(themeIDRoute+tracksRoute, tracks_themes.ListByThemeHandler(s.queryBus))
 
-	// Protected routes
+	s.engine.GET("/search", search.SearchHandler(s.queryBus))
+
+	// Authenticated routes. Each one requires a permission of the role of the user, but
+	// logging out and changing one's own password
 	auth := s.engine.Group("")
-	auth.Use(jwt.Middleware(s.jwtKey), admin.Middleware())
+	auth.Use(jwt.Middleware(s.signer, s.queryBus))
 	{
-		auth.POST("/users", users.CreateHandler(s.commandBus))
-		auth.GET("/users", users.ListHandler(s.queryBus))
-
-		auth.POST("/movies", movies.CreateHandler(s.commandBus))
-		auth.PUT(movieIDRoute, movies.UpdateHandler(s.commandBus))
-		auth.DELETE(movieIDRoute, movies.DeleteHandler(s.commandBus))
-
-		auth.POST("/groups", groups.CreateHandler(s.commandBus))
-		auth.PUT(groupIDRoute, groups.UpdateHandler(s.commandBus))
-		auth.DELETE(groupIDRoute, groups.DeleteHandler(s.commandBus))
-
-		auth.POST("/categories", categories.CreateHandler(s.commandBus))
-		auth.PUT(categoryIDRoute, categories.UpdateHandler(s.commandBus))
-		auth.DELETE(categoryIDRoute, categories.DeleteHandler(s.commandBus))
-
-		auth.POST(tracksRoute, tracks.CreateHandler(s.commandBus))
-		auth.PUT(trackIDRoute, tracks.UpdateHandler(s.commandBus))
-		auth.DELETE(trackIDRoute, tracks.DeleteHandler(s.commandBus))
-
-		auth.POST(themesRoute, themes.CreateHandler(s.commandBus))
-		auth.PUT(themeIDRoute, themes.UpdateHandler(s.commandBus))
-		auth.DELETE(themeIDRoute, themes.DeleteHandler(s.commandBus))
-
-		auth.POST(tracksThemesRoute, tracks_themes.CreateHandler(s.commandBus))
-		auth.PUT(tracksThemesRoute, tracks_themes.UpdateHandler(s.commandBus))
-		auth.DELETE(tracksThemesRoute, tracks_themes.DeleteHandler(s.commandBus))
-
+		auth.POST("/logout", session.LogoutHandler(s.commandBus))
+		auth.POST(userIDRoute+"/password", users.PasswordHandler(s.commandBus))
+
+		manageUsers := auth.Group("", s.require(domain.PermissionManageUsers))
+		manageUsers.POST("/users", users.CreateHandler(s.commandBus))
+		manageUsers.GET("/users", users.ListHandler(s.queryBus))
+		manageUsers.GET(userIDRoute, users.GetHandler(s.queryBus))
+		manageUsers.PUT(userIDRoute, users.UpdateHandler(s.commandBus))
+		manageUsers.DELETE(userIDRoute, users.DeleteHandler(s.commandBus))
+		manageUsers.PUT(userIDRoute+"/role", users.RoleHandler(s.commandBus))
+		manageUsers.DELETE(userIDRoute+"/sessions", users.RevokeSessionsHandler(s.commandBus))
+
+		catalogue := auth.Group("", s.require(domain.PermissionManageCatalogue))
+		catalogue.POST("/movies", movies.CreateHandler(s.commandBus))
+		catalogue.PUT(movieIDRoute, movies.UpdateHandler(s.commandBus))
+		catalogue.DELETE(movieIDRoute, movies.DeleteHandler(s.commandBus))
+		catalogue.POST(movieIDRoute+restoreRoute, movies.RestoreHandler(s.commandBus))
+
+		catalogue.POST("/groups", groups.CreateHandler(s.commandBus))
+		catalogue.PUT(groupIDRoute, groups.UpdateHandler(s.commandBus))
+		catalogue.DELETE(groupIDRoute, groups.DeleteHandler(s.commandBus))
+		catalogue.POST(groupIDRoute+restoreRoute, groups.RestoreHandler(s.commandBus))
+
+		catalogue.POST("/categories", categories.CreateHandler(s.commandBus))
+		catalogue.PUT(categoryIDRoute, categories.UpdateHandler(s.commandBus))
+		catalogue.DELETE(categoryIDRoute, categories.DeleteHandler(s.commandBus))
+		catalogue.POST(categoryIDRoute+restoreRoute, categories.RestoreHandler(s.commandBus))
+
+		catalogue.PUT(trackIDRoute, tracks.UpdateHandler(s.commandBus))
+		catalogue.DELETE(trackIDRoute, tracks.DeleteHandler(s.commandBus))
+		catalogue.POST(trackIDRoute+restoreRoute, tracks.RestoreHandler(s.commandBus))
+
+		catalogue.PUT(themeIDRoute, themes.UpdateHandler(s.commandBus))
+		catalogue.DELETE(themeIDRoute, themes.DeleteHandler(s.commandBus))
+		catalogue.POST(themeIDRoute+restoreRoute, themes.RestoreHandler(s.commandBus))
+
+		catalogue.GET(trashRoute, trash.ListHandler(s.queryBus))
+
+		auth.POST(tracksRoute, s.require(domain.PermissionCreateTracks), tracks.CreateHandler(s.commandBus))
+		auth.POST(themesRoute, s.require(domain.PermissionCreateThemes), themes.CreateHandler(s.commandBus))
+
+		purge := auth.Group("", s.require(domain.PermissionPurge))
+		purge.DELETE(trashRoute+movieIDRoute, movies.PurgeHandler(s.commandBus))
+		purge.DELETE(trashRoute+groupIDRoute, groups.PurgeHandler(s.commandBus))
+		purge.DELETE(trashRoute+categoryIDRoute, categories.PurgeHandler(s.commandBus))
+		purge.DELETE(trashRoute+trackIDRoute, tracks.PurgeHandler(s.commandBus))
+		purge.DELETE(trashRoute+themeIDRoute, themes.PurgeHandler(s.commandBus))
+
+		descriptions := auth.Group("", s.require(domain.PermissionEditDescriptions))
+		descriptions.PUT(groupIDRoute+descriptionRoute, groups.UpdateDescriptionHandler(s.commandBus))
+		descriptions.PUT(themeIDRoute+descriptionRoute, themes.UpdateDescriptionHandler(s.commandBus))
+
+		annotate := auth.Group("", s.require(domain.PermissionAnnotate))
+		annotate.POST(tracksThemesRoute, tracks_themes.CreateHandler(s.commandBus))
+		annotate.PUT(tracksThemesRoute, tracks_themes.UpdateHandler(s.commandBus))
+		annotate.DELETE(tracksThemesRoute, tracks_themes.DeleteHandler(s.commandBus))
+
+		auth.GET("/admin/audit", s.require(domain.PermissionReadAudit), audit.ListHandler(s.queryBus))
+
+		manageWebhooks := auth.Group("", s.require(domain.PermissionManageWebhooks))
+		manageWebhooks.POST(webhooksRoute, webhooks.CreateHandler(s.commandBus))
+		manageWebhooks.GET(webhooksRoute, webhooks.ListHandler(s.queryBus))
+		manageWebhooks.PUT(webhooksRoute+"/:id", webhooks.UpdateHandler(s.commandBus))
+		manageWebhooks.DELETE(webhooksRoute+"/:id", webhooks.DeleteHandler(s.commandBus))
+		manageWebhooks.GET(webhooksRoute+"/:id/deliveries", webhooks.ListDeliveriesHandler(s.queryBus))
 	}
 }
 
+// require returns the middleware that lets through the users allowed to take an action.
+func (s *Server) require(p domain.Permission) gin.HandlerFunc {
+	return permission.Middleware(s.queryBus, p)
+}
+
 func serverContext(ctx context.Context) context.Context {
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt)
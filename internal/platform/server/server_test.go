@@ -0,0 +1,107 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/authorizing"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/auth"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/command/commandmocks"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/query"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/query/querymocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const entityID = "123e4567-e89b-12d3-a456-426614174000"
+
+// newTestServer returns a server whose query bus grants the users the permissions of the
+// default roles, and whose command bus accepts every command, along with a function that
+// issues an access token for a user with the role.
+func newTestServer(t *testing.T) (Server, func(role string) string) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	roles := make(map[string]domain.Role)
+	for _, role := range domain.DefaultRoles() {
+		roles[role.Name().String()] = role
+	}
+	userRoles := make(map[string]string)
+
+	queryBusMock := new(querymocks.Bus)
+	queryBusMock.On("Ask", mock.Anything, mock.AnythingOfType("authenticating.RevokedQuery")).Return(false, nil)
+	queryBusMock.On("Ask", mock.Anything, mock.AnythingOfType("authorizing.PermissionQuery")).Return(func(_ context.Context, q query.Query) (any, error) {
+		permissionQuery := q.(authorizing.PermissionQuery)
+		return roles[userRoles[permissionQuery.UserID]].Can(permissionQuery.Permission), nil
+	})
+
+	commandBusMock := new(commandmocks.Bus)
+	commandBusMock.On("Dispatch", mock.Anything, mock.Anything).Return(nil)
+
+	signer := auth.NewHMACSigner([]byte("a-very-secret-signing-key"))
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	_, srv := New(ctx, "localhost", 0, time.Second, commandBusMock, queryBusMock, signer, "http://localhost:3000")
+
+	token := func(role string) string {
+		user, err := domain.NewUser("Gandalf", "gandalf@istari.me", "hashed-password", role)
+		require.NoError(t, err)
+		userRoles[user.ID().String()] = role
+
+		sessionID, err := domain.NewSessionID()
+		require.NoError(t, err)
+		token, err := auth.GenerateJWTKey(user, sessionID, signer, time.Minute)
+		require.NoError(t, err)
+		return token
+	}
+	return srv, token
+}
+
+func TestServerRoutesRequirePermissions(t *testing.T) {
+	srv, token := newTestServer(t)
+
+	tests := []struct {
+		role    string
+		method  string
+		path    string
+		allowed bool
+	}{
+		{domain.RoleCurator, http.MethodPost, "/themes", true},
+		{domain.RoleCurator, http.MethodPost, "/tracks", true},
+		{domain.RoleCurator, http.MethodPost, "/tracks-themes", true},
+		{domain.RoleCurator, http.MethodPost, "/movies", false},
+		{domain.RoleCurator, http.MethodPut, "/tracks/" + entityID, false},
+		{domain.RoleCurator, http.MethodDelete, "/movies/" + entityID, false},
+		{domain.RoleCurator, http.MethodDelete, "/groups/" + entityID, false},
+		{domain.RoleCurator, http.MethodPost, "/categories/" + entityID + "/restore", false},
+		{domain.RoleCurator, http.MethodGet, "/trash", false},
+		{domain.RoleEditor, http.MethodPost, "/themes", false},
+		{domain.RoleEditor, http.MethodPut, "/themes/" + entityID + "/description", true},
+		{domain.RoleViewer, http.MethodPost, "/tracks-themes", false},
+		{domain.RoleAdmin, http.MethodPost, "/themes", true},
+		{domain.RoleAdmin, http.MethodDelete, "/movies/" + entityID, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.role+" "+tt.method+" "+tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Authorization", "Bearer "+token(tt.role))
+			rec := httptest.NewRecorder()
+
+			srv.engine.ServeHTTP(rec, req)
+
+			// The requests let through get the answer of the handler, such as a 400 for the empty body
+			if tt.allowed {
+				assert.NotEqual(t, http.StatusForbidden, rec.Code)
+				assert.NotEqual(t, http.StatusUnauthorized, rec.Code)
+			} else {
+				assert.Equal(t, http.StatusForbidden, rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package inmemory
+
+import (
+	"context"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+)
+
+// AuditRepository implements the AuditRepository interface in memory.
+type AuditRepository struct {
+	store *Store
+}
+
+// NewAuditRepository creates a new AuditRepository.
+func NewAuditRepository(store *Store) *AuditRepository {
+	return &AuditRepository{
+		store: store,
+	}
+}
+
+// matchesAuditEntry tells whether an entry meets every criterion of the filter.
+func matchesAuditEntry(entry domain.AuditEntry, filter domain.AuditFilter) bool {
+	if entity := filter.Entity(); entity != "" && entry.Entity() != entity {
+		return false
+	}
+	if userID := filter.UserID(); userID != nil && entry.UserID() != userID.String() {
+		return false
+	}
+	if since := filter.Since(); since != nil && entry.OccurredAt().Before(*since) {
+		return false
+	}
+	return true
+}
+
+func (r *AuditRepository) Save(ctx context.Context, entry domain.AuditEntry) error {
+	return r.store.write(ctx, func(t *tables) error {
+		for _, saved := range t.auditLog {
+			if saved.ID() == entry.ID() {
+				return ErrDuplicateKey
+			}
+		}
+
+		t.auditLog = append(t.auditLog, entry)
+		return nil
+	})
+}
+
+// auditKeyset sorts entries newest first.
+var auditKeyset = keyset[domain.AuditEntry]{
+	key:        func(e domain.AuditEntry) string { return createdAtKey(e.OccurredAt()) },
+	id:         func(e domain.AuditEntry) string { return e.ID() },
+	compare:    compareTimeKeys,
+	descending: true,
+}
+
+// FindPage returns a page of the entries that match the filter, newest first.
+func (r *AuditRepository) FindPage(ctx context.Context, filter domain.AuditFilter, page domain.PageRequest) ([]domain.AuditEntry, *domain.Cursor, error) {
+	var entries []domain.AuditEntry
+	err := r.store.read(ctx, func(t *tables) error {
+		for _, entry := range t.auditLog {
+			if matchesAuditEntry(entry, filter) {
+				entries = append(entries, entry)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return auditKeyset.page(entries, page)
+}
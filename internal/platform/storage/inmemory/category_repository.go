@@ -0,0 +1,132 @@
+package inmemory
+
+import (
+	"context"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+)
+
+// CategoryRepository implements the CategoryRepository interface in memory.
+type CategoryRepository struct {
+	store *Store
+}
+
+// NewCategoryRepository creates a new CategoryRepository.
+func NewCategoryRepository(store *Store) *CategoryRepository {
+	return &CategoryRepository{
+		store: store,
+	}
+}
+
+func (r *CategoryRepository) Save(ctx context.Context, category domain.Category) error {
+	category.PullEvents()
+
+	return r.store.write(ctx, func(t *tables) error {
+		if _, ok := t.categories[category.ID().String()]; ok {
+			return ErrDuplicateKey
+		}
+
+		t.categories[category.ID().String()] = row[domain.Category]{value: category, createdAt: t.nextCreatedAt()}
+		return nil
+	})
+}
+
+func (r *CategoryRepository) Find(ctx context.Context, id domain.CategoryID) (domain.Category, error) {
+	var category domain.Category
+	err := r.store.read(ctx, func(t *tables) error {
+		found, ok := liveRow(t.categories, id.String())
+		if !ok {
+			return domain.ErrCategoryNotFound
+		}
+		category = found.value
+		return nil
+	})
+	return category, err
+}
+
+// FindInTrash finds a category only if it is in the trash.
+func (r *CategoryRepository) FindInTrash(ctx context.Context, id domain.CategoryID) (domain.Category, error) {
+	var category domain.Category
+	err := r.store.read(ctx, func(t *tables) error {
+		found, ok := trashedRow(t.categories, id.String())
+		if !ok {
+			return domain.ErrCategoryNotFound
+		}
+		category = found.value
+		return nil
+	})
+	return category, err
+}
+
+func (r *CategoryRepository) FindAll(ctx context.Context) ([]domain.Category, error) {
+	var categories []domain.Category
+	err := r.store.read(ctx, func(t *tables) error {
+		categories = valuesOf(rowsOf(t.categories))
+		return nil
+	})
+	return categories, err
+}
+
+func (r *CategoryRepository) FindPage(ctx context.Context, page domain.PageRequest) ([]domain.Category, *domain.Cursor, error) {
+	var rows []row[domain.Category]
+	var next *domain.Cursor
+	err := r.store.read(ctx, func(t *tables) error {
+		var err error
+		rows, next, err = createdAtKeyset(categoryID).page(rowsOf(t.categories), page)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return valuesOf(rows), next, nil
+}
+
+// Delete moves a category to the trash.
+func (r *CategoryRepository) Delete(ctx context.Context, id domain.CategoryID) error {
+	return r.store.write(ctx, func(t *tables) error {
+		return moveToTrash(t.categories, id.String(), domain.ErrCategoryNotFound)
+	})
+}
+
+// Restore takes a category out of the trash.
+func (r *CategoryRepository) Restore(ctx context.Context, id domain.CategoryID) error {
+	return r.store.write(ctx, func(t *tables) error {
+		return restoreFromTrash(t.categories, id.String(), domain.ErrCategoryNotFound)
+	})
+}
+
+// Purge removes a category from the trash for good.
+func (r *CategoryRepository) Purge(ctx context.Context, id domain.CategoryID) error {
+	return r.store.write(ctx, func(t *tables) error {
+		if _, ok := trashedRow(t.categories, id.String()); !ok {
+			return domain.ErrCategoryNotFound
+		}
+		for _, theme := range t.themes {
+			if themeCategory := theme.value.CategoryID(); themeCategory != nil && *themeCategory == id {
+				return inUse("themes")
+			}
+		}
+
+		delete(t.categories, id.String())
+		return nil
+	})
+}
+
+func (r *CategoryRepository) Update(ctx context.Context, category domain.Category) error {
+	category.PullEvents()
+
+	return r.store.write(ctx, func(t *tables) error {
+		existing, ok := liveRow(t.categories, category.ID().String())
+		if !ok {
+			return domain.ErrCategoryNotFound
+		}
+
+		existing.value = category
+		t.categories[category.ID().String()] = existing
+		return nil
+	})
+}
+
+func categoryID(category domain.Category) string {
+	return category.ID().String()
+}
@@ -0,0 +1,33 @@
+package inmemory
+
+import (
+	"testing"
+
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/storage/storagetest"
+)
+
+func TestRepositoryContracts(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storagetest.Repositories {
+		store := NewStore()
+		return storagetest.Repositories{
+			Users:       NewUserRepository(store),
+			Movies:      NewMovieRepository(store),
+			Groups:      NewGroupRepository(store),
+			Categories:  NewCategoryRepository(store),
+			Tracks:      NewTrackRepository(store),
+			Themes:      NewThemeRepository(store),
+			TrackThemes: NewTrackThemeRepository(store),
+			Trash:       NewTrashRepository(store),
+			Audit:       NewAuditRepository(store),
+			Outbox:      NewOutboxRepository(store),
+			Webhooks:    NewWebhookRepository(store),
+			Deliveries:  NewWebhookDeliveryRepository(store),
+			Sessions:    NewSessionRepository(store),
+			Revocations: NewRevocationRepository(store),
+			SigningKeys: NewSigningKeyRepository(store),
+			Roles:       NewRoleRepository(store),
+
+			VerificationTokens: NewVerificationTokenRepository(store),
+		}
+	})
+}
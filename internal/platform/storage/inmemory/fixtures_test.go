@@ -0,0 +1,53 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/stretchr/testify/require"
+)
+
+// seedMovie saves a movie of the given sequence.
+func seedMovie(t *testing.T, store *Store, name string, sequence int) domain.Movie {
+	t.Helper()
+
+	movie, err := domain.NewMovie(name, 2000+sequence, sequence, "lotr", nil)
+	require.NoError(t, err)
+	require.NoError(t, NewMovieRepository(store).Save(context.Background(), movie))
+
+	return movie
+}
+
+// seedTrack saves a theatrical track of the movie at the given position.
+func seedTrack(t *testing.T, store *Store, name string, movie domain.Movie, trackNumber int) domain.Track {
+	t.Helper()
+
+	track, err := domain.NewTrack(name, movie.ID().String(), nil, trackNumber, 1, domain.TrackEditionTheatrical, 300)
+	require.NoError(t, err)
+	require.NoError(t, NewTrackRepository(store).Save(context.Background(), track))
+
+	return track
+}
+
+// seedGroup saves a group.
+func seedGroup(t *testing.T, store *Store, name, description string) domain.Group {
+	t.Helper()
+
+	group, err := domain.NewGroup(name, description, "http://example.com/"+name+".jpg")
+	require.NoError(t, err)
+	require.NoError(t, NewGroupRepository(store).Save(context.Background(), group))
+
+	return group
+}
+
+// seedTheme saves a theme of the group, first heard in the track.
+func seedTheme(t *testing.T, store *Store, name string, group domain.Group, firstHeard domain.Track, firstHeardStart int) domain.Theme {
+	t.Helper()
+
+	theme, err := domain.NewTheme(name, firstHeard.ID().String(), group.ID().String(), "Description of "+name, firstHeardStart, firstHeardStart+30, nil)
+	require.NoError(t, err)
+	require.NoError(t, NewThemeRepository(store).Save(context.Background(), theme))
+
+	return theme
+}
@@ -0,0 +1,132 @@
+package inmemory
+
+import (
+	"context"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+)
+
+// GroupRepository implements the GroupRepository interface in memory.
+type GroupRepository struct {
+	store *Store
+}
+
+// NewGroupRepository creates a new GroupRepository.
+func NewGroupRepository(store *Store) *GroupRepository {
+	return &GroupRepository{
+		store: store,
+	}
+}
+
+func (r *GroupRepository) Save(ctx context.Context, group domain.Group) error {
+	group.PullEvents()
+
+	return r.store.write(ctx, func(t *tables) error {
+		if _, ok := t.groups[group.ID().String()]; ok {
+			return ErrDuplicateKey
+		}
+
+		t.groups[group.ID().String()] = row[domain.Group]{value: group, createdAt: t.nextCreatedAt()}
+		return nil
+	})
+}
+
+func (r *GroupRepository) Find(ctx context.Context, id domain.GroupID) (domain.Group, error) {
+	var group domain.Group
+	err := r.store.read(ctx, func(t *tables) error {
+		found, ok := liveRow(t.groups, id.String())
+		if !ok {
+			return domain.ErrGroupNotFound
+		}
+		group = found.value
+		return nil
+	})
+	return group, err
+}
+
+// FindInTrash finds a group only if it is in the trash.
+func (r *GroupRepository) FindInTrash(ctx context.Context, id domain.GroupID) (domain.Group, error) {
+	var group domain.Group
+	err := r.store.read(ctx, func(t *tables) error {
+		found, ok := trashedRow(t.groups, id.String())
+		if !ok {
+			return domain.ErrGroupNotFound
+		}
+		group = found.value
+		return nil
+	})
+	return group, err
+}
+
+func (r *GroupRepository) FindAll(ctx context.Context) ([]domain.Group, error) {
+	var groups []domain.Group
+	err := r.store.read(ctx, func(t *tables) error {
+		groups = valuesOf(rowsOf(t.groups))
+		return nil
+	})
+	return groups, err
+}
+
+func (r *GroupRepository) FindPage(ctx context.Context, page domain.PageRequest) ([]domain.Group, *domain.Cursor, error) {
+	var rows []row[domain.Group]
+	var next *domain.Cursor
+	err := r.store.read(ctx, func(t *tables) error {
+		var err error
+		rows, next, err = createdAtKeyset(groupID).page(rowsOf(t.groups), page)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return valuesOf(rows), next, nil
+}
+
+// Delete moves a group to the trash.
+func (r *GroupRepository) Delete(ctx context.Context, id domain.GroupID) error {
+	return r.store.write(ctx, func(t *tables) error {
+		return moveToTrash(t.groups, id.String(), domain.ErrGroupNotFound)
+	})
+}
+
+// Restore takes a group out of the trash.
+func (r *GroupRepository) Restore(ctx context.Context, id domain.GroupID) error {
+	return r.store.write(ctx, func(t *tables) error {
+		return restoreFromTrash(t.groups, id.String(), domain.ErrGroupNotFound)
+	})
+}
+
+// Purge removes a group from the trash for good.
+func (r *GroupRepository) Purge(ctx context.Context, id domain.GroupID) error {
+	return r.store.write(ctx, func(t *tables) error {
+		if _, ok := trashedRow(t.groups, id.String()); !ok {
+			return domain.ErrGroupNotFound
+		}
+		for _, theme := range t.themes {
+			if theme.value.GroupID() == id {
+				return inUse("themes")
+			}
+		}
+
+		delete(t.groups, id.String())
+		return nil
+	})
+}
+
+func (r *GroupRepository) Update(ctx context.Context, group domain.Group) error {
+	group.PullEvents()
+
+	return r.store.write(ctx, func(t *tables) error {
+		existing, ok := liveRow(t.groups, group.ID().String())
+		if !ok {
+			return domain.ErrGroupNotFound
+		}
+
+		existing.value = group
+		t.groups[group.ID().String()] = existing
+		return nil
+	})
+}
+
+func groupID(group domain.Group) string {
+	return group.ID().String()
+}
@@ -0,0 +1,167 @@
+package inmemory
+
+import (
+	"context"
+	"strconv"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+)
+
+// MovieRepository implements the MovieRepository interface in memory.
+type MovieRepository struct {
+	store *Store
+}
+
+// NewMovieRepository creates a new MovieRepository.
+func NewMovieRepository(store *Store) *MovieRepository {
+	return &MovieRepository{
+		store: store,
+	}
+}
+
+// movieKeyset sorts movies in display order.
+var movieKeyset = keyset[row[domain.Movie]]{
+	key:     func(r row[domain.Movie]) string { return strconv.Itoa(r.value.Sequence().Int()) },
+	id:      func(r row[domain.Movie]) string { return r.value.ID().String() },
+	compare: compareIntKeys,
+}
+
+// checkMovie enforces the unique sequence of a movie against the other live movies.
+func checkMovie(t *tables, movie domain.Movie) error {
+	for id, existing := range t.movies {
+		if id != movie.ID().String() && existing.deletedAt.IsZero() && existing.value.Sequence() == movie.Sequence() {
+			return domain.ErrMovieSequenceAlreadyExists
+		}
+	}
+	return nil
+}
+
+func (r *MovieRepository) Save(ctx context.Context, movie domain.Movie) error {
+	movie.PullEvents()
+
+	return r.store.write(ctx, func(t *tables) error {
+		if _, ok := t.movies[movie.ID().String()]; ok {
+			return ErrDuplicateKey
+		}
+		if err := checkMovie(t, movie); err != nil {
+			return err
+		}
+
+		t.movies[movie.ID().String()] = row[domain.Movie]{value: movie, createdAt: t.nextCreatedAt()}
+		return nil
+	})
+}
+
+func (r *MovieRepository) Find(ctx context.Context, id domain.MovieID) (domain.Movie, error) {
+	var movie domain.Movie
+	err := r.store.read(ctx, func(t *tables) error {
+		found, ok := liveRow(t.movies, id.String())
+		if !ok {
+			return domain.ErrMovieNotFound
+		}
+		movie = found.value
+		return nil
+	})
+	return movie, err
+}
+
+// FindInTrash finds a movie only if it is in the trash.
+func (r *MovieRepository) FindInTrash(ctx context.Context, id domain.MovieID) (domain.Movie, error) {
+	var movie domain.Movie
+	err := r.store.read(ctx, func(t *tables) error {
+		found, ok := trashedRow(t.movies, id.String())
+		if !ok {
+			return domain.ErrMovieNotFound
+		}
+		movie = found.value
+		return nil
+	})
+	return movie, err
+}
+
+func (r *MovieRepository) FindAll(ctx context.Context) ([]domain.Movie, error) {
+	var movies []domain.Movie
+	err := r.store.read(ctx, func(t *tables) error {
+		rows := rowsOf(t.movies)
+		movieKeyset.sort(rows)
+		movies = valuesOf(rows)
+		return nil
+	})
+	return movies, err
+}
+
+// FindPage returns a page of movies in display order, restricted to the series of the filter.
+func (r *MovieRepository) FindPage(ctx context.Context, filter domain.MovieFilter, page domain.PageRequest) ([]domain.Movie, *domain.Cursor, error) {
+	var rows []row[domain.Movie]
+	var next *domain.Cursor
+	err := r.store.read(ctx, func(t *tables) error {
+		var matching []row[domain.Movie]
+		for _, r := range rowsOf(t.movies) {
+			if series := filter.Series(); series == nil || r.value.Series() == *series {
+				matching = append(matching, r)
+			}
+		}
+
+		var err error
+		rows, next, err = movieKeyset.page(matching, page)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return valuesOf(rows), next, nil
+}
+
+// Delete moves a movie to the trash.
+func (r *MovieRepository) Delete(ctx context.Context, id domain.MovieID) error {
+	return r.store.write(ctx, func(t *tables) error {
+		return moveToTrash(t.movies, id.String(), domain.ErrMovieNotFound)
+	})
+}
+
+// Restore takes a movie out of the trash, unless a live movie took its sequence.
+func (r *MovieRepository) Restore(ctx context.Context, id domain.MovieID) error {
+	return r.store.write(ctx, func(t *tables) error {
+		if trashed, ok := trashedRow(t.movies, id.String()); ok {
+			if err := checkMovie(t, trashed.value); err != nil {
+				return err
+			}
+		}
+		return restoreFromTrash(t.movies, id.String(), domain.ErrMovieNotFound)
+	})
+}
+
+// Purge removes a movie from the trash for good.
+func (r *MovieRepository) Purge(ctx context.Context, id domain.MovieID) error {
+	return r.store.write(ctx, func(t *tables) error {
+		if _, ok := trashedRow(t.movies, id.String()); !ok {
+			return domain.ErrMovieNotFound
+		}
+		for _, track := range t.tracks {
+			if track.value.MovieID() == id {
+				return inUse("tracks")
+			}
+		}
+
+		delete(t.movies, id.String())
+		return nil
+	})
+}
+
+func (r *MovieRepository) Update(ctx context.Context, movie domain.Movie) error {
+	movie.PullEvents()
+
+	return r.store.write(ctx, func(t *tables) error {
+		existing, ok := liveRow(t.movies, movie.ID().String())
+		if !ok {
+			return domain.ErrMovieNotFound
+		}
+		if err := checkMovie(t, movie); err != nil {
+			return err
+		}
+
+		existing.value = movie
+		t.movies[movie.ID().String()] = existing
+		return nil
+	})
+}
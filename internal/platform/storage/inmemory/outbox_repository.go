@@ -0,0 +1,70 @@
+package inmemory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+)
+
+// OutboxRepository implements the event.Outbox interface in memory. Its events are kept
+// in the store, so they are saved or discarded along with the unit of work they are
+// published in.
+type OutboxRepository struct {
+	store *Store
+}
+
+// NewOutboxRepository creates a new OutboxRepository.
+func NewOutboxRepository(store *Store) *OutboxRepository {
+	return &OutboxRepository{
+		store: store,
+	}
+}
+
+// Save adds the events, skipping those already in the outbox.
+func (r *OutboxRepository) Save(ctx context.Context, events []event.Event) error {
+	return r.store.write(ctx, func(t *tables) error {
+		for _, e := range events {
+			saved := slices.ContainsFunc(t.outbox, func(row outboxRow) bool {
+				return row.event.ID() == e.ID()
+			})
+			if !saved {
+				t.outbox = append(t.outbox, outboxRow{event: e})
+			}
+		}
+		return nil
+	})
+}
+
+func (r *OutboxRepository) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]event.Event, error) {
+	var events []event.Event
+	err := r.store.write(ctx, func(t *tables) error {
+		expired := now.Add(-lease)
+		for i := range t.outbox {
+			if len(events) == limit {
+				break
+			}
+
+			row := &t.outbox[i]
+			if !row.sentAt.IsZero() || (!row.claimedAt.IsZero() && !row.claimedAt.Before(expired)) {
+				continue
+			}
+			row.claimedAt = now
+			events = append(events, row.event)
+		}
+		return nil
+	})
+	return events, err
+}
+
+func (r *OutboxRepository) MarkSent(ctx context.Context, eventID string, sentAt time.Time) error {
+	return r.store.write(ctx, func(t *tables) error {
+		for i := range t.outbox {
+			if t.outbox[i].event.ID() == eventID {
+				t.outbox[i].sentAt = sentAt
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,115 @@
+package inmemory
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+)
+
+// keyCompare orders two cursor keys, failing when one of them cannot be parsed.
+type keyCompare func(a, b string) (int, error)
+
+func compareTimeKeys(a, b string) (int, error) {
+	ta, err := time.Parse(time.RFC3339Nano, a)
+	if err != nil {
+		return 0, err
+	}
+	tb, err := time.Parse(time.RFC3339Nano, b)
+	if err != nil {
+		return 0, err
+	}
+	return ta.Compare(tb), nil
+}
+
+func compareIntKeys(a, b string) (int, error) {
+	ia, err := strconv.Atoi(a)
+	if err != nil {
+		return 0, err
+	}
+	ib, err := strconv.Atoi(b)
+	if err != nil {
+		return 0, err
+	}
+	return cmp.Compare(ia, ib), nil
+}
+
+func compareStringKeys(a, b string) (int, error) {
+	return strings.Compare(a, b), nil
+}
+
+// createdAtKey formats a creation time as a cursor key, the same way sqldb does.
+func createdAtKey(createdAt time.Time) string {
+	return createdAt.UTC().Format(time.RFC3339Nano)
+}
+
+// keyset describes how a list is sorted and paged: on the key of each item, then on its ID.
+type keyset[T any] struct {
+	key        func(T) string
+	id         func(T) string
+	compare    keyCompare
+	descending bool // Latest key first
+}
+
+// createdAtKeyset sorts rows by the time they were saved.
+func createdAtKeyset[T any](id func(T) string) keyset[row[T]] {
+	return keyset[row[T]]{
+		key:     func(r row[T]) string { return createdAtKey(r.createdAt) },
+		id:      func(r row[T]) string { return id(r.value) },
+		compare: compareTimeKeys,
+	}
+}
+
+// compareTo orders an item against the position of a cursor.
+func (k keyset[T]) compareTo(item T, key, id string) int {
+	// Keys built by the keyset always parse, so only a cursor key can fail, and
+	// page checks it beforehand.
+	c, _ := k.compare(k.key(item), key)
+	if c == 0 {
+		c = strings.Compare(k.id(item), id)
+	}
+	if k.descending {
+		return -c
+	}
+	return c
+}
+
+// sort orders the items on their key, then on their ID.
+func (k keyset[T]) sort(items []T) {
+	slices.SortFunc(items, func(a, b T) int {
+		return k.compareTo(a, k.key(b), k.id(b))
+	})
+}
+
+// page sorts the items and returns the ones of the requested page, along with the cursor
+// of the last of them when there are more items to read.
+func (k keyset[T]) page(items []T, page domain.PageRequest) ([]T, *domain.Cursor, error) {
+	k.sort(items)
+
+	if after := page.After(); after != nil {
+		if _, err := k.compare(after.Key(), after.Key()); err != nil {
+			return nil, nil, domain.ErrInvalidCursor
+		}
+		start := len(items)
+		for i, item := range items {
+			if k.compareTo(item, after.Key(), after.ID()) > 0 {
+				start = i
+				break
+			}
+		}
+		items = items[start:]
+	}
+
+	if len(items) <= page.Limit() {
+		return items, nil, nil
+	}
+
+	items = items[:page.Limit()]
+	last := items[len(items)-1]
+	cursor := domain.NewCursor(k.key(last), k.id(last))
+
+	return items, &cursor, nil
+}
@@ -0,0 +1,48 @@
+package inmemory
+
+import (
+	"context"
+	"time"
+)
+
+// RevocationRepository implements the RevocationRepository interface in memory.
+type RevocationRepository struct {
+	store *Store
+}
+
+// NewRevocationRepository creates a new RevocationRepository.
+func NewRevocationRepository(store *Store) *RevocationRepository {
+	return &RevocationRepository{
+		store: store,
+	}
+}
+
+// Revoke adds the token to the revocation list. Revoking a token twice is a no-op.
+func (r *RevocationRepository) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	return r.store.write(ctx, func(t *tables) error {
+		if _, ok := t.revokedTokens[jti]; !ok {
+			t.revokedTokens[jti] = expiresAt
+		}
+		return nil
+	})
+}
+
+func (r *RevocationRepository) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	var revoked bool
+	err := r.store.read(ctx, func(t *tables) error {
+		_, revoked = t.revokedTokens[jti]
+		return nil
+	})
+	return revoked, err
+}
+
+func (r *RevocationRepository) Prune(ctx context.Context, now time.Time) error {
+	return r.store.write(ctx, func(t *tables) error {
+		for jti, expiresAt := range t.revokedTokens {
+			if !expiresAt.After(now) {
+				delete(t.revokedTokens, jti)
+			}
+		}
+		return nil
+	})
+}
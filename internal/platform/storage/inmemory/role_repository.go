@@ -0,0 +1,33 @@
+package inmemory
+
+import (
+	"context"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+)
+
+// RoleRepository implements the RoleRepository interface in memory. The store starts
+// with the default roles, just as the database is migrated with them.
+type RoleRepository struct {
+	store *Store
+}
+
+// NewRoleRepository creates a new RoleRepository.
+func NewRoleRepository(store *Store) *RoleRepository {
+	return &RoleRepository{
+		store: store,
+	}
+}
+
+func (r *RoleRepository) Find(ctx context.Context, name domain.RoleName) (domain.Role, error) {
+	var role domain.Role
+	err := r.store.read(ctx, func(t *tables) error {
+		found, ok := t.roles[name.String()]
+		if !ok {
+			return domain.ErrRoleNotFound
+		}
+		role = found
+		return nil
+	})
+	return role, err
+}
@@ -0,0 +1,135 @@
+package inmemory
+
+import (
+	"cmp"
+	"context"
+	"html"
+	"regexp"
+	"slices"
+	"strings"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+)
+
+// Ranks of a search result, depending on where its words were found.
+const (
+	nameRank        = 1.0
+	descriptionRank = 0.5
+)
+
+// SearchRepository searches the catalogue in memory. It has no stemming: an entry matches
+// when every word of the term appears in its name or description, ignoring case. The
+// entries whose parents are in the trash are left out, as in the views.
+type SearchRepository struct {
+	store *Store
+}
+
+// NewSearchRepository creates a new SearchRepository.
+func NewSearchRepository(store *Store) *SearchRepository {
+	return &SearchRepository{
+		store: store,
+	}
+}
+
+// searcher matches the words of a search term against catalogue entries.
+type searcher struct {
+	words []string
+	mark  *regexp.Regexp
+}
+
+func newSearcher(term domain.SearchTerm) searcher {
+	words := strings.Fields(strings.ToLower(term.String()))
+
+	quoted := make([]string, len(words))
+	for i, word := range words {
+		quoted[i] = regexp.QuoteMeta(word)
+	}
+
+	return searcher{
+		words: words,
+		mark:  regexp.MustCompile("(?i)" + strings.Join(quoted, "|")),
+	}
+}
+
+func (s searcher) containsAll(text string) bool {
+	text = strings.ToLower(text)
+	for _, word := range s.words {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// match returns the result for an entry, and false when the entry does not match.
+func (s searcher) match(resultType, id, name, description string) (domain.SearchResult, bool) {
+	text := strings.TrimSpace(name + " " + description)
+
+	var rank float64
+	switch {
+	case s.containsAll(name):
+		rank = nameRank
+	case s.containsAll(text):
+		rank = descriptionRank
+	default:
+		return domain.SearchResult{}, false
+	}
+
+	return domain.NewSearchResult(resultType, id, name, s.highlight(text), rank), true
+}
+
+// highlight HTML escapes the text and wraps the words of the term in <mark> tags, so that
+// the stored text cannot inject markup into the results.
+func (s searcher) highlight(text string) string {
+	var b strings.Builder
+	last := 0
+	for _, match := range s.mark.FindAllStringIndex(text, -1) {
+		b.WriteString(html.EscapeString(text[last:match[0]]))
+		b.WriteString("<mark>" + html.EscapeString(text[match[0]:match[1]]) + "</mark>")
+		last = match[1]
+	}
+	b.WriteString(html.EscapeString(text[last:]))
+	return b.String()
+}
+
+func (r *SearchRepository) Search(ctx context.Context, term domain.SearchTerm, limit int) ([]domain.SearchResult, error) {
+	s := newSearcher(term)
+
+	var results []domain.SearchResult
+	add := func(result domain.SearchResult, ok bool) {
+		if ok {
+			results = append(results, result)
+		}
+	}
+
+	err := r.store.read(ctx, func(t *tables) error {
+		for _, theme := range liveThemeRows(t) {
+			add(s.match("theme", themeID(theme.value), theme.value.Name().String(), theme.value.Description().String()))
+		}
+		for _, track := range liveTrackRows(t) {
+			add(s.match("track", trackID(track.value), track.value.Name().String(), ""))
+		}
+		for _, group := range rowsOf(t.groups) {
+			add(s.match("group", groupID(group.value), group.value.Name().String(), group.value.Description().String()))
+		}
+		for _, movie := range rowsOf(t.movies) {
+			add(s.match("movie", movie.value.ID().String(), movie.value.Name().String(), ""))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(results, func(a, b domain.SearchResult) int {
+		if c := cmp.Compare(b.Rank(), a.Rank()); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name(), b.Name())
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results, nil
+}
@@ -0,0 +1,60 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchRepositorySearch(t *testing.T) {
+	store := NewStore()
+	movie := seedMovie(t, store, "The Fellowship of the Ring", 1)
+	track := seedTrack(t, store, "Concerning Hobbits", movie, 2)
+	group := seedGroup(t, store, "Hobbits", "Themes of the Shire and its folk")
+	seedTheme(t, store, "The Shire", group, track, 0)
+
+	term, err := domain.NewSearchTerm("shire")
+	require.NoError(t, err)
+
+	results, err := NewSearchRepository(store).Search(context.Background(), term, 10)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	assert.Equal(t, "theme", results[0].Type())
+	assert.Equal(t, "The <mark>Shire</mark> Description of The <mark>Shire</mark>", results[0].Snippet())
+	assert.Equal(t, "group", results[1].Type())
+	assert.Greater(t, results[0].Rank(), results[1].Rank())
+}
+
+func TestSearchRepositorySearchEscapesSnippet(t *testing.T) {
+	store := NewStore()
+	seedGroup(t, store, "Hobbits", "<script>alert('Shire')</script> & more")
+
+	term, err := domain.NewSearchTerm("shire")
+	require.NoError(t, err)
+
+	results, err := NewSearchRepository(store).Search(context.Background(), term, 10)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	assert.Equal(t, "Hobbits &lt;script&gt;alert(&#39;<mark>Shire</mark>&#39;)&lt;/script&gt; &amp; more", results[0].Snippet())
+}
+
+func TestSearchRepositorySearchLeavesOutTrashedParents(t *testing.T) {
+	store := NewStore()
+	movie := seedMovie(t, store, "The Fellowship of the Ring", 1)
+	track := seedTrack(t, store, "The Shire", movie, 2)
+	group := seedGroup(t, store, "Hobbits", "Themes of the hobbits")
+	seedTheme(t, store, "The Shire", group, track, 0)
+	require.NoError(t, NewMovieRepository(store).Delete(context.Background(), movie.ID()))
+
+	term, err := domain.NewSearchTerm("shire")
+	require.NoError(t, err)
+
+	results, err := NewSearchRepository(store).Search(context.Background(), term, 10)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
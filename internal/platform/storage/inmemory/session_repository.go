@@ -0,0 +1,121 @@
+package inmemory
+
+import (
+	"context"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+)
+
+// SessionRepository implements the SessionRepository interface in memory.
+type SessionRepository struct {
+	store *Store
+}
+
+// NewSessionRepository creates a new SessionRepository.
+func NewSessionRepository(store *Store) *SessionRepository {
+	return &SessionRepository{
+		store: store,
+	}
+}
+
+func (r *SessionRepository) Save(ctx context.Context, session domain.Session) error {
+	return r.store.write(ctx, func(t *tables) error {
+		if _, ok := t.sessions[session.ID().String()]; ok {
+			return ErrDuplicateKey
+		}
+		if _, ok := t.users[session.UserID().String()]; !ok {
+			return domain.ErrUserNotFound
+		}
+		for _, existing := range t.sessions {
+			if existing.value.TokenHash() == session.TokenHash() {
+				return ErrDuplicateKey
+			}
+		}
+
+		t.sessions[session.ID().String()] = row[domain.Session]{value: session, createdAt: t.nextCreatedAt()}
+		return nil
+	})
+}
+
+func (r *SessionRepository) FindByTokenHash(ctx context.Context, tokenHash string) (domain.Session, error) {
+	var session domain.Session
+	err := r.store.read(ctx, func(t *tables) error {
+		for _, found := range t.sessions {
+			if found.value.TokenHash() == tokenHash || found.value.PreviousTokenHash() == tokenHash {
+				session = found.value
+				return nil
+			}
+		}
+		return domain.ErrSessionNotFound
+	})
+	return session, err
+}
+
+func (r *SessionRepository) Rotate(ctx context.Context, session domain.Session) error {
+	return r.store.write(ctx, func(t *tables) error {
+		stored, ok := t.sessions[session.ID().String()]
+		if !ok || stored.value.TokenHash() != session.PreviousTokenHash() || !stored.value.RevokedAt().IsZero() {
+			return domain.ErrInvalidRefreshToken
+		}
+
+		rotated, err := domain.NewSessionWithID(session.ID().String(), stored.value.UserID().String(), session.TokenHash(), session.PreviousTokenHash(), session.ExpiresAt(), time.Time{})
+		if err != nil {
+			return err
+		}
+		stored.value = rotated
+		t.sessions[session.ID().String()] = stored
+		return nil
+	})
+}
+
+func (r *SessionRepository) Revoke(ctx context.Context, id domain.SessionID, revokedAt time.Time) error {
+	return r.store.write(ctx, func(t *tables) error {
+		stored, ok := t.sessions[id.String()]
+		if !ok {
+			return nil
+		}
+		return revokeSession(t, stored, revokedAt)
+	})
+}
+
+func (r *SessionRepository) RevokeByUser(ctx context.Context, userID domain.UserID, except domain.SessionID, revokedAt time.Time) error {
+	return r.store.write(ctx, func(t *tables) error {
+		for _, stored := range t.sessions {
+			if stored.value.UserID() != userID || stored.value.ID() == except {
+				continue
+			}
+			if err := revokeSession(t, stored, revokedAt); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *SessionRepository) IsRevoked(ctx context.Context, id domain.SessionID) (bool, error) {
+	revoked := true
+	err := r.store.read(ctx, func(t *tables) error {
+		if stored, ok := t.sessions[id.String()]; ok {
+			revoked = !stored.value.RevokedAt().IsZero()
+		}
+		return nil
+	})
+	return revoked, err
+}
+
+// revokeSession stores the session revoked at revokedAt, unless it already was.
+func revokeSession(t *tables, stored row[domain.Session], revokedAt time.Time) error {
+	s := stored.value
+	if !s.RevokedAt().IsZero() {
+		return nil
+	}
+
+	revoked, err := domain.NewSessionWithID(s.ID().String(), s.UserID().String(), s.TokenHash(), s.PreviousTokenHash(), s.ExpiresAt(), revokedAt)
+	if err != nil {
+		return err
+	}
+	stored.value = revoked
+	t.sessions[s.ID().String()] = stored
+	return nil
+}
@@ -0,0 +1,56 @@
+package inmemory
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+)
+
+// SigningKeyRepository implements the SigningKeyRepository interface in memory.
+type SigningKeyRepository struct {
+	store *Store
+}
+
+// NewSigningKeyRepository creates a new SigningKeyRepository.
+func NewSigningKeyRepository(store *Store) *SigningKeyRepository {
+	return &SigningKeyRepository{
+		store: store,
+	}
+}
+
+func (r *SigningKeyRepository) Save(ctx context.Context, key domain.SigningKey) error {
+	return r.store.write(ctx, func(t *tables) error {
+		if _, ok := t.signingKeys[key.ID()]; ok {
+			return ErrDuplicateKey
+		}
+
+		t.signingKeys[key.ID()] = key
+		return nil
+	})
+}
+
+// FindAll returns every key, the one that activates first first.
+func (r *SigningKeyRepository) FindAll(ctx context.Context) ([]domain.SigningKey, error) {
+	var keys []domain.SigningKey
+	err := r.store.read(ctx, func(t *tables) error {
+		keys = slices.SortedFunc(maps.Values(t.signingKeys), func(a, b domain.SigningKey) int {
+			return cmp.Or(a.ActivatesAt().Compare(b.ActivatesAt()), cmp.Compare(a.ID(), b.ID()))
+		})
+		return nil
+	})
+	if len(keys) == 0 {
+		return nil, err
+	}
+	return keys, err
+}
+
+// Delete deletes the key with the given ID. Deleting a key that does not exist is a no-op.
+func (r *SigningKeyRepository) Delete(ctx context.Context, id string) error {
+	return r.store.write(ctx, func(t *tables) error {
+		delete(t.signingKeys, id)
+		return nil
+	})
+}
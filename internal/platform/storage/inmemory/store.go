@@ -0,0 +1,260 @@
+// Package inmemory implements the repositories on top of maps held in memory. It
+// enforces the same uniqueness, foreign-key and cascade rules as the PostgreSQL schema,
+// so it can stand in for sqldb when running the API locally or in end-to-end tests.
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+)
+
+// ErrDuplicateKey is returned when saving a row whose primary key is already stored.
+var ErrDuplicateKey = errors.New("duplicate key")
+
+// inUse returns the error of a delete restricted by rows of the given table, worded as
+// the sqldb repositories word it.
+func inUse(referencedBy string) error {
+	return fmt.Errorf("%w: referenced by %s", domain.ErrInUse, referencedBy)
+}
+
+// row is a stored value along with the time it was first saved, which sorts rows the
+// way created_at does in the SQL tables, and the time it was moved to the trash, if any.
+type row[T any] struct {
+	value     T
+	createdAt time.Time
+	deletedAt time.Time
+}
+
+type trackThemeKey struct {
+	trackID     string
+	themeID     string
+	startSecond int
+}
+
+func keyOfTrackTheme(trackTheme domain.TrackTheme) trackThemeKey {
+	return trackThemeKey{
+		trackID:     trackTheme.TrackID().String(),
+		themeID:     trackTheme.ThemeID().String(),
+		startSecond: trackTheme.StartSecond().Int(),
+	}
+}
+
+// outboxRow is an event of the outbox along with the times it was last claimed and sent,
+// zero until then.
+type outboxRow struct {
+	event     event.Event
+	claimedAt time.Time
+	sentAt    time.Time
+}
+
+// webhookDeliveryRow is an attempt at posting an event to a webhook along with the time it
+// was last claimed, zero until then, and its record once it is made.
+type webhookDeliveryRow struct {
+	pending   domain.PendingWebhookDelivery
+	claimedAt time.Time
+	made      domain.WebhookDelivery // Zero while the attempt is pending
+}
+
+func (r webhookDeliveryRow) isPending() bool {
+	return r.made.ID() == ""
+}
+
+// tables holds every row of the store.
+type tables struct {
+	users       map[string]row[domain.User]
+	movies      map[string]row[domain.Movie]
+	groups      map[string]row[domain.Group]
+	categories  map[string]row[domain.Category]
+	tracks      map[string]row[domain.Track]
+	themes      map[string]row[domain.Theme]
+	trackThemes map[trackThemeKey]row[domain.TrackTheme]
+	auditLog    []domain.AuditEntry // In the order the entries were saved
+	outbox      []outboxRow         // In the order the events were saved
+
+	webhooks          map[string]row[domain.Webhook]
+	webhookDeliveries []webhookDeliveryRow // In the order the attempts were scheduled
+
+	sessions           map[string]row[domain.Session]
+	verificationTokens map[string]domain.VerificationToken
+	revokedTokens      map[string]time.Time // Expiry of the revoked tokens, by jti
+	signingKeys        map[string]domain.SigningKey
+	roles              map[string]domain.Role
+
+	lastCreatedAt time.Time
+}
+
+func newTables() *tables {
+	roles := make(map[string]domain.Role)
+	for _, role := range domain.DefaultRoles() {
+		roles[role.Name().String()] = role
+	}
+
+	return &tables{
+		users:              make(map[string]row[domain.User]),
+		movies:             make(map[string]row[domain.Movie]),
+		groups:             make(map[string]row[domain.Group]),
+		categories:         make(map[string]row[domain.Category]),
+		tracks:             make(map[string]row[domain.Track]),
+		themes:             make(map[string]row[domain.Theme]),
+		trackThemes:        make(map[trackThemeKey]row[domain.TrackTheme]),
+		webhooks:           make(map[string]row[domain.Webhook]),
+		sessions:           make(map[string]row[domain.Session]),
+		verificationTokens: make(map[string]domain.VerificationToken),
+		revokedTokens:      make(map[string]time.Time),
+		signingKeys:        make(map[string]domain.SigningKey),
+		roles:              roles,
+	}
+}
+
+// clone copies the maps of the tables. Domain values are immutable, so the rows
+// themselves can be shared.
+func (t *tables) clone() *tables {
+	return &tables{
+		users:              maps.Clone(t.users),
+		movies:             maps.Clone(t.movies),
+		groups:             maps.Clone(t.groups),
+		categories:         maps.Clone(t.categories),
+		tracks:             maps.Clone(t.tracks),
+		themes:             maps.Clone(t.themes),
+		trackThemes:        maps.Clone(t.trackThemes),
+		auditLog:           slices.Clone(t.auditLog),
+		outbox:             slices.Clone(t.outbox),
+		webhooks:           maps.Clone(t.webhooks),
+		webhookDeliveries:  slices.Clone(t.webhookDeliveries),
+		sessions:           maps.Clone(t.sessions),
+		verificationTokens: maps.Clone(t.verificationTokens),
+		revokedTokens:      maps.Clone(t.revokedTokens),
+		signingKeys:        maps.Clone(t.signingKeys),
+		roles:              maps.Clone(t.roles),
+		lastCreatedAt:      t.lastCreatedAt,
+	}
+}
+
+// nextCreatedAt returns the creation time of a new row. Times strictly increase, so
+// rows sorted by creation time keep the order they were saved in.
+func (t *tables) nextCreatedAt() time.Time {
+	now := time.Now().UTC()
+	if !now.After(t.lastCreatedAt) {
+		now = t.lastCreatedAt.Add(time.Nanosecond)
+	}
+	t.lastCreatedAt = now
+	return now
+}
+
+// Store is the shared state of the in-memory repositories. Reads run concurrently, while
+// writes, single or grouped in a unit of work, run one at a time.
+type Store struct {
+	writeMu sync.Mutex   // Held by a write for its whole duration, including units of work
+	mu      sync.RWMutex // Guards data
+	data    *tables
+}
+
+// NewStore creates a new, empty Store.
+func NewStore() *Store {
+	return &Store{
+		data: newTables(),
+	}
+}
+
+type txKey struct {
+	store *Store
+}
+
+// txTables returns the working copy of an ongoing unit of work on the store, if any.
+func (s *Store) txTables(ctx context.Context) (*tables, bool) {
+	t, ok := ctx.Value(txKey{store: s}).(*tables)
+	return t, ok
+}
+
+func (s *Store) read(ctx context.Context, fn func(t *tables) error) error {
+	if t, ok := s.txTables(ctx); ok {
+		return fn(t)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return fn(s.data)
+}
+
+// write runs fn with exclusive access to the tables. fn must check every rule before it
+// changes anything, so a failed write leaves the tables untouched.
+func (s *Store) write(ctx context.Context, fn func(t *tables) error) error {
+	if t, ok := s.txTables(ctx); ok {
+		return fn(t)
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return fn(s.data)
+}
+
+// TxManager implements tx.Manager for the in-memory repositories. A unit of work runs on
+// a copy of the tables that replaces them only when it succeeds.
+type TxManager struct {
+	store *Store
+}
+
+// NewTxManager creates a new TxManager.
+func NewTxManager(store *Store) *TxManager {
+	return &TxManager{
+		store: store,
+	}
+}
+
+func (m *TxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := m.store.txTables(ctx); ok {
+		return fn(ctx)
+	}
+
+	m.store.writeMu.Lock()
+	defer m.store.writeMu.Unlock()
+
+	m.store.mu.RLock()
+	work := m.store.data.clone()
+	m.store.mu.RUnlock()
+
+	if err := fn(context.WithValue(ctx, txKey{store: m.store}, work)); err != nil {
+		return err
+	}
+
+	m.store.mu.Lock()
+	m.store.data = work
+	m.store.mu.Unlock()
+
+	return nil
+}
+
+// rowsOf returns the rows of a table that are not in the trash, in the order they were
+// saved.
+func rowsOf[K comparable, T any](table map[K]row[T]) []row[T] {
+	rows := make([]row[T], 0, len(table))
+	for _, r := range table {
+		if !r.inTrash() {
+			rows = append(rows, r)
+		}
+	}
+	slices.SortFunc(rows, func(a, b row[T]) int {
+		return a.createdAt.Compare(b.createdAt)
+	})
+	return rows
+}
+
+// valuesOf returns the values held in rows. Like a query without results, it returns nil
+// when there are none.
+func valuesOf[T any](rows []row[T]) []T {
+	var values []T
+	for _, r := range rows {
+		values = append(values, r.value)
+	}
+	return values
+}
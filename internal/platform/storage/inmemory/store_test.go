@@ -0,0 +1,74 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxManagerWithinTxCommit(t *testing.T) {
+	store := NewStore()
+	movie := seedMovie(t, store, "The Fellowship of the Ring", 1)
+	trackRepo := NewTrackRepository(store)
+
+	track, err := domain.NewTrack("Concerning Hobbits", movie.ID().String(), nil, 2, 1, domain.TrackEditionTheatrical, 180)
+	require.NoError(t, err)
+
+	err = NewTxManager(store).WithinTx(context.Background(), func(ctx context.Context) error {
+		if err := trackRepo.Save(ctx, track); err != nil {
+			return err
+		}
+		// Reads inside the unit of work see its own writes.
+		_, err := trackRepo.Find(ctx, track.ID())
+		return err
+	})
+	require.NoError(t, err)
+
+	_, err = trackRepo.Find(context.Background(), track.ID())
+	assert.NoError(t, err)
+}
+
+func TestTxManagerWithinTxRollback(t *testing.T) {
+	store := NewStore()
+	movie := seedMovie(t, store, "The Fellowship of the Ring", 1)
+	trackRepo := NewTrackRepository(store)
+
+	track, err := domain.NewTrack("Concerning Hobbits", movie.ID().String(), nil, 2, 1, domain.TrackEditionTheatrical, 180)
+	require.NoError(t, err)
+
+	failure := errors.New("failure")
+	err = NewTxManager(store).WithinTx(context.Background(), func(ctx context.Context) error {
+		require.NoError(t, trackRepo.Save(ctx, track))
+		return failure
+	})
+	assert.ErrorIs(t, err, failure)
+
+	_, err = trackRepo.Find(context.Background(), track.ID())
+	assert.ErrorIs(t, err, domain.ErrTrackNotFound)
+}
+
+func TestTxManagerWithinTxNested(t *testing.T) {
+	store := NewStore()
+	txManager := NewTxManager(store)
+	movieRepo := NewMovieRepository(store)
+
+	movie, err := domain.NewMovie("The Two Towers", 2002, 2, "lotr", nil)
+	require.NoError(t, err)
+
+	failure := errors.New("failure")
+	err = txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+		err := txManager.WithinTx(ctx, func(ctx context.Context) error {
+			return movieRepo.Save(ctx, movie)
+		})
+		require.NoError(t, err)
+		return failure
+	})
+	assert.ErrorIs(t, err, failure)
+
+	_, err = movieRepo.Find(context.Background(), movie.ID())
+	assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+}
@@ -0,0 +1,275 @@
+package inmemory
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+)
+
+// ThemeRepository implements the ThemeRepository interface in memory.
+type ThemeRepository struct {
+	store *Store
+}
+
+// NewThemeRepository creates a new ThemeRepository.
+func NewThemeRepository(store *Store) *ThemeRepository {
+	return &ThemeRepository{
+		store: store,
+	}
+}
+
+// checkTheme enforces the foreign keys of a theme.
+func checkTheme(t *tables, theme domain.Theme) error {
+	if _, ok := liveRow(t.groups, theme.GroupID().String()); !ok {
+		return domain.ErrGroupNotFound
+	}
+	if categoryID := theme.CategoryID(); categoryID != nil {
+		if _, ok := liveRow(t.categories, categoryID.String()); !ok {
+			return domain.ErrCategoryNotFound
+		}
+	}
+	if _, ok := liveRow(t.tracks, theme.FirstHeard().String()); !ok {
+		return domain.ErrTrackNotFound
+	}
+	return nil
+}
+
+// matchesTheme tells whether a theme meets every criterion of the filter.
+func matchesTheme(t *tables, theme domain.Theme, filter domain.ThemeFilter) bool {
+	if groupID := filter.GroupID(); groupID != nil && theme.GroupID() != *groupID {
+		return false
+	}
+	if categoryID := filter.CategoryID(); categoryID != nil {
+		if theme.CategoryID() == nil || *theme.CategoryID() != *categoryID {
+			return false
+		}
+	}
+	if movieID := filter.MovieID(); movieID != nil {
+		firstHeard, ok := t.tracks[theme.FirstHeard().String()]
+		if !ok || firstHeard.value.MovieID() != *movieID {
+			return false
+		}
+	}
+	if name := filter.Name(); name != "" && !containsFold(theme.Name().String(), name) {
+		return false
+	}
+	return true
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// themeKeyset returns the keyset a list of themes is paginated by.
+func themeKeyset(sort domain.ThemeSort) keyset[row[domain.Theme]] {
+	switch sort {
+	case domain.ThemeSortName:
+		return keyset[row[domain.Theme]]{
+			key:     func(r row[domain.Theme]) string { return r.value.Name().String() },
+			id:      func(r row[domain.Theme]) string { return themeID(r.value) },
+			compare: compareStringKeys,
+		}
+	case domain.ThemeSortFirstHeardStart:
+		return keyset[row[domain.Theme]]{
+			key:     func(r row[domain.Theme]) string { return strconv.Itoa(r.value.FirstHeardStart().Int()) },
+			id:      func(r row[domain.Theme]) string { return themeID(r.value) },
+			compare: compareIntKeys,
+		}
+	default:
+		return createdAtKeyset(themeID)
+	}
+}
+
+// pageThemes filters the given theme rows and returns the requested page of them, sorted
+// as the filter asks.
+func pageThemes(t *tables, themes []row[domain.Theme], filter domain.ThemeFilter, page domain.PageRequest) ([]row[domain.Theme], *domain.Cursor, error) {
+	var matching []row[domain.Theme]
+	for _, r := range themes {
+		if matchesTheme(t, r.value, filter) {
+			matching = append(matching, r)
+		}
+	}
+	return themeKeyset(filter.Sort()).page(matching, page)
+}
+
+// themesWhere returns the themes that match, in the order they were saved.
+func themesWhere(t *tables, match func(theme domain.Theme) bool) []domain.Theme {
+	var themes []domain.Theme
+	for _, r := range rowsOf(t.themes) {
+		if match(r.value) {
+			themes = append(themes, r.value)
+		}
+	}
+	return themes
+}
+
+func themesOfGroup(t *tables, groupID domain.GroupID) []domain.Theme {
+	return themesWhere(t, func(theme domain.Theme) bool {
+		return theme.GroupID() == groupID
+	})
+}
+
+func (r *ThemeRepository) Save(ctx context.Context, theme domain.Theme) error {
+	theme.PullEvents()
+
+	return r.store.write(ctx, func(t *tables) error {
+		if _, ok := t.themes[theme.ID().String()]; ok {
+			return ErrDuplicateKey
+		}
+		if err := checkTheme(t, theme); err != nil {
+			return err
+		}
+
+		t.themes[theme.ID().String()] = row[domain.Theme]{value: theme, createdAt: t.nextCreatedAt()}
+		return nil
+	})
+}
+
+func (r *ThemeRepository) Find(ctx context.Context, id domain.ThemeID) (domain.Theme, error) {
+	var theme domain.Theme
+	err := r.store.read(ctx, func(t *tables) error {
+		found, ok := liveRow(t.themes, id.String())
+		if !ok {
+			return domain.ErrThemeNotFound
+		}
+		theme = found.value
+		return nil
+	})
+	return theme, err
+}
+
+// FindInTrash finds a theme only if it is in the trash.
+func (r *ThemeRepository) FindInTrash(ctx context.Context, id domain.ThemeID) (domain.Theme, error) {
+	var theme domain.Theme
+	err := r.store.read(ctx, func(t *tables) error {
+		found, ok := trashedRow(t.themes, id.String())
+		if !ok {
+			return domain.ErrThemeNotFound
+		}
+		theme = found.value
+		return nil
+	})
+	return theme, err
+}
+
+func (r *ThemeRepository) FindAll(ctx context.Context) ([]domain.Theme, error) {
+	var themes []domain.Theme
+	err := r.store.read(ctx, func(t *tables) error {
+		themes = valuesOf(rowsOf(t.themes))
+		return nil
+	})
+	return themes, err
+}
+
+func (r *ThemeRepository) FindPage(ctx context.Context, filter domain.ThemeFilter, page domain.PageRequest) ([]domain.Theme, *domain.Cursor, error) {
+	var rows []row[domain.Theme]
+	var next *domain.Cursor
+	err := r.store.read(ctx, func(t *tables) error {
+		var err error
+		rows, next, err = pageThemes(t, rowsOf(t.themes), filter, page)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return valuesOf(rows), next, nil
+}
+
+func (r *ThemeRepository) FindByGroup(ctx context.Context, groupID domain.GroupID) ([]domain.Theme, error) {
+	var themes []domain.Theme
+	err := r.store.read(ctx, func(t *tables) error {
+		themes = themesOfGroup(t, groupID)
+		return nil
+	})
+	return themes, err
+}
+
+func (r *ThemeRepository) FindByCategory(ctx context.Context, categoryID domain.CategoryID) ([]domain.Theme, error) {
+	var themes []domain.Theme
+	err := r.store.read(ctx, func(t *tables) error {
+		themes = themesWhere(t, func(theme domain.Theme) bool {
+			return theme.CategoryID() != nil && *theme.CategoryID() == categoryID
+		})
+		return nil
+	})
+	return themes, err
+}
+
+func (r *ThemeRepository) FindByFirstHeard(ctx context.Context, trackID domain.TrackID) ([]domain.Theme, error) {
+	var themes []domain.Theme
+	err := r.store.read(ctx, func(t *tables) error {
+		themes = themesWhere(t, func(theme domain.Theme) bool {
+			return theme.FirstHeard() == trackID
+		})
+		return nil
+	})
+	return themes, err
+}
+
+// FindByMovie returns the themes first heard in a track of the movie.
+func (r *ThemeRepository) FindByMovie(ctx context.Context, movieID domain.MovieID) ([]domain.Theme, error) {
+	var themes []domain.Theme
+	err := r.store.read(ctx, func(t *tables) error {
+		themes = themesWhere(t, func(theme domain.Theme) bool {
+			firstHeard, ok := t.tracks[theme.FirstHeard().String()]
+			return ok && firstHeard.value.MovieID() == movieID
+		})
+		return nil
+	})
+	return themes, err
+}
+
+// Delete moves a theme to the trash.
+func (r *ThemeRepository) Delete(ctx context.Context, id domain.ThemeID) error {
+	return r.store.write(ctx, func(t *tables) error {
+		return moveToTrash(t.themes, id.String(), domain.ErrThemeNotFound)
+	})
+}
+
+// Restore takes a theme out of the trash.
+func (r *ThemeRepository) Restore(ctx context.Context, id domain.ThemeID) error {
+	return r.store.write(ctx, func(t *tables) error {
+		return restoreFromTrash(t.themes, id.String(), domain.ErrThemeNotFound)
+	})
+}
+
+// Purge removes a theme from the trash for good along with its occurrences in tracks.
+func (r *ThemeRepository) Purge(ctx context.Context, id domain.ThemeID) error {
+	return r.store.write(ctx, func(t *tables) error {
+		if _, ok := trashedRow(t.themes, id.String()); !ok {
+			return domain.ErrThemeNotFound
+		}
+
+		delete(t.themes, id.String())
+		for key := range t.trackThemes {
+			if key.themeID == id.String() {
+				delete(t.trackThemes, key)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *ThemeRepository) Update(ctx context.Context, theme domain.Theme) error {
+	theme.PullEvents()
+
+	return r.store.write(ctx, func(t *tables) error {
+		existing, ok := liveRow(t.themes, theme.ID().String())
+		if !ok {
+			return domain.ErrThemeNotFound
+		}
+		if err := checkTheme(t, theme); err != nil {
+			return err
+		}
+
+		existing.value = theme
+		t.themes[theme.ID().String()] = existing
+		return nil
+	})
+}
+
+func themeID(theme domain.Theme) string {
+	return theme.ID().String()
+}
@@ -0,0 +1,92 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThemeRepositorySaveForeignKeys(t *testing.T) {
+	store := NewStore()
+	movie := seedMovie(t, store, "The Fellowship of the Ring", 1)
+	track := seedTrack(t, store, "Concerning Hobbits", movie, 2)
+	group := seedGroup(t, store, "Hobbits", "The hobbits")
+
+	missingID, err := domain.NewTrackID()
+	require.NoError(t, err)
+	missing := missingID.String()
+
+	tests := map[string]struct {
+		firstHeard, groupID string
+		categoryID          *string
+		expected            error
+	}{
+		"missing group":       {firstHeard: track.ID().String(), groupID: missing, expected: domain.ErrGroupNotFound},
+		"missing category":    {firstHeard: track.ID().String(), groupID: group.ID().String(), categoryID: &missing, expected: domain.ErrCategoryNotFound},
+		"missing first heard": {firstHeard: missing, groupID: group.ID().String(), expected: domain.ErrTrackNotFound},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			theme, err := domain.NewTheme("The Shire", tt.firstHeard, tt.groupID, "Description", 0, 30, tt.categoryID)
+			require.NoError(t, err)
+
+			err = NewThemeRepository(store).Save(context.Background(), theme)
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestThemeRepositoryFindPageByName(t *testing.T) {
+	store := NewStore()
+	movie := seedMovie(t, store, "The Fellowship of the Ring", 1)
+	track := seedTrack(t, store, "Concerning Hobbits", movie, 2)
+	group := seedGroup(t, store, "Hobbits", "The hobbits")
+	seedTheme(t, store, "The Shire", group, track, 0)
+	seedTheme(t, store, "A Hobbit's Understanding", group, track, 30)
+	seedTheme(t, store, "Hobbit Outing", group, track, 60)
+
+	repo := NewThemeRepository(store)
+	filter, err := domain.NewThemeFilter("", "", "", "hobbit", "name")
+	require.NoError(t, err)
+	page, err := domain.NewPageRequest(1, "")
+	require.NoError(t, err)
+
+	themes, next, err := repo.FindPage(context.Background(), filter, page)
+	require.NoError(t, err)
+	require.Len(t, themes, 1)
+	require.NotNil(t, next)
+	assert.Equal(t, "A Hobbit's Understanding", themes[0].Name().String())
+
+	page, err = domain.NewPageRequest(1, *next.AsStringPtr())
+	require.NoError(t, err)
+
+	themes, next, err = repo.FindPage(context.Background(), filter, page)
+	require.NoError(t, err)
+	require.Len(t, themes, 1)
+	assert.Nil(t, next)
+	assert.Equal(t, "Hobbit Outing", themes[0].Name().String())
+}
+
+func TestThemeRepositoryPurgeCascadesTrackThemes(t *testing.T) {
+	store := NewStore()
+	movie := seedMovie(t, store, "The Fellowship of the Ring", 1)
+	track := seedTrack(t, store, "Concerning Hobbits", movie, 2)
+	theme := seedTheme(t, store, "The Shire", seedGroup(t, store, "Hobbits", "The hobbits"), track, 0)
+
+	trackTheme, err := domain.NewTrackTheme(track.ID().String(), theme.ID().String(), 10, 40, false)
+	require.NoError(t, err)
+	trackThemeRepo := NewTrackThemeRepository(store)
+	require.NoError(t, trackThemeRepo.Save(context.Background(), trackTheme))
+
+	themeRepo := NewThemeRepository(store)
+	require.NoError(t, themeRepo.Delete(context.Background(), theme.ID()))
+	require.NoError(t, themeRepo.Purge(context.Background(), theme.ID()))
+
+	trackThemes, err := trackThemeRepo.FindByTrack(context.Background(), track.ID())
+	require.NoError(t, err)
+	assert.Empty(t, trackThemes)
+}
@@ -0,0 +1,99 @@
+package inmemory
+
+import (
+	"context"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
+)
+
+// ThemeViewRepository reads themes joined with their group, category, first heard track
+// and the movie of that track.
+type ThemeViewRepository struct {
+	store *Store
+}
+
+// NewThemeViewRepository creates a new ThemeViewRepository.
+func NewThemeViewRepository(store *Store) *ThemeViewRepository {
+	return &ThemeViewRepository{
+		store: store,
+	}
+}
+
+func themeResponse(t *tables, theme domain.Theme) dto.ThemeResponse {
+	firstHeard := trackResponse(t, t.tracks[theme.FirstHeard().String()].value)
+	group := dto.NewGroupResponse(t.groups[theme.GroupID().String()].value)
+
+	var category *dto.CategoryResponse
+	if categoryID := theme.CategoryID(); categoryID != nil {
+		response := dto.NewCategoryResponse(t.categories[categoryID.String()].value)
+		category = &response
+	}
+
+	return dto.NewThemeResponse(theme, firstHeard, group, category)
+}
+
+// liveParents tells whether the group, category and first heard track of the theme, and
+// the movie of that track, are all outside the trash. The view leaves the other themes
+// out, as it does the tracks of a movie in the trash.
+func liveParents(t *tables, theme domain.Theme) bool {
+	if _, ok := liveRow(t.groups, theme.GroupID().String()); !ok {
+		return false
+	}
+	if categoryID := theme.CategoryID(); categoryID != nil {
+		if _, ok := liveRow(t.categories, categoryID.String()); !ok {
+			return false
+		}
+	}
+	firstHeard, ok := liveRow(t.tracks, theme.FirstHeard().String())
+	if !ok {
+		return false
+	}
+	_, ok = liveRow(t.movies, firstHeard.value.MovieID().String())
+	return ok
+}
+
+// liveThemeRows returns the themes outside the trash whose parents are not in the trash
+// either.
+func liveThemeRows(t *tables) []row[domain.Theme] {
+	var rows []row[domain.Theme]
+	for _, r := range rowsOf(t.themes) {
+		if liveParents(t, r.value) {
+			rows = append(rows, r)
+		}
+	}
+	return rows
+}
+
+func (r *ThemeViewRepository) FindPage(ctx context.Context, filter domain.ThemeFilter, page domain.PageRequest) ([]dto.ThemeResponse, *domain.Cursor, error) {
+	var themes []dto.ThemeResponse
+	var next *domain.Cursor
+	err := r.store.read(ctx, func(t *tables) error {
+		rows, cursor, err := pageThemes(t, liveThemeRows(t), filter, page)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			themes = append(themes, themeResponse(t, row.value))
+		}
+		next = cursor
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return themes, next, nil
+}
+
+func (r *ThemeViewRepository) FindByGroup(ctx context.Context, groupID domain.GroupID) ([]dto.ThemeResponse, error) {
+	var themes []dto.ThemeResponse
+	err := r.store.read(ctx, func(t *tables) error {
+		for _, theme := range themesOfGroup(t, groupID) {
+			if liveParents(t, theme) {
+				themes = append(themes, themeResponse(t, theme))
+			}
+		}
+		return nil
+	})
+	return themes, err
+}
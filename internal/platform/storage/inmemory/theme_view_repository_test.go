@@ -0,0 +1,46 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThemeViewRepositoryLeavesOutTrashedParents(t *testing.T) {
+	store := NewStore()
+	movie := seedMovie(t, store, "The Fellowship of the Ring", 1)
+	track := seedTrack(t, store, "Concerning Hobbits", movie, 2)
+	trashedTrack := seedTrack(t, store, "The Black Rider", movie, 3)
+	group := seedGroup(t, store, "Hobbits", "The hobbits")
+	trashedGroup := seedGroup(t, store, "Mordor", "The enemy")
+
+	live := seedTheme(t, store, "The Shire", group, track, 0)
+	seedTheme(t, store, "The Ringwraiths", trashedGroup, track, 30)
+	seedTheme(t, store, "The Nazgûl", group, trashedTrack, 0)
+
+	require.NoError(t, NewGroupRepository(store).Delete(context.Background(), trashedGroup.ID()))
+	require.NoError(t, NewTrackRepository(store).Delete(context.Background(), trashedTrack.ID()))
+
+	repo := NewThemeViewRepository(store)
+	filter, err := domain.NewThemeFilter("", "", "", "", "")
+	require.NoError(t, err)
+	page, err := domain.NewPageRequest(10, "")
+	require.NoError(t, err)
+
+	themes, _, err := repo.FindPage(context.Background(), filter, page)
+	require.NoError(t, err)
+	require.Len(t, themes, 1)
+	assert.Equal(t, live.ID().String(), themes[0].ID)
+
+	themes, err = repo.FindByGroup(context.Background(), trashedGroup.ID())
+	require.NoError(t, err)
+	assert.Empty(t, themes)
+
+	themes, err = repo.FindByGroup(context.Background(), group.ID())
+	require.NoError(t, err)
+	require.Len(t, themes, 1)
+	assert.Equal(t, live.ID().String(), themes[0].ID)
+}
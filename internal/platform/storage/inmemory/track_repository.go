@@ -0,0 +1,213 @@
+package inmemory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+)
+
+// TrackRepository implements the TrackRepository interface in memory.
+type TrackRepository struct {
+	store *Store
+}
+
+// NewTrackRepository creates a new TrackRepository.
+func NewTrackRepository(store *Store) *TrackRepository {
+	return &TrackRepository{
+		store: store,
+	}
+}
+
+// compareAlbumOrder orders the tracks of a movie as they appear on its albums: the
+// theatrical edition before the Complete Recordings, then by disc and track number.
+func compareAlbumOrder(a, b domain.Track) int {
+	if c := cmp.Compare(a.Edition().Rank(), b.Edition().Rank()); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.DiscNumber().Int(), b.DiscNumber().Int()); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.TrackNumber().Int(), b.TrackNumber().Int())
+}
+
+// checkTrack enforces the foreign key and the unique album position of a track.
+func checkTrack(t *tables, track domain.Track) error {
+	if _, ok := liveRow(t.movies, track.MovieID().String()); !ok {
+		return domain.ErrMovieNotFound
+	}
+	return checkTrackPosition(t, track)
+}
+
+// checkTrackPosition enforces the unique album position of a track against the other
+// live tracks.
+func checkTrackPosition(t *tables, track domain.Track) error {
+	for id, existing := range t.tracks {
+		if id != track.ID().String() &&
+			existing.deletedAt.IsZero() &&
+			existing.value.MovieID() == track.MovieID() &&
+			existing.value.Edition() == track.Edition() &&
+			existing.value.DiscNumber() == track.DiscNumber() &&
+			existing.value.TrackNumber() == track.TrackNumber() {
+			return domain.ErrTrackPositionAlreadyExists
+		}
+	}
+	return nil
+}
+
+func (r *TrackRepository) Save(ctx context.Context, track domain.Track) error {
+	track.PullEvents()
+
+	return r.store.write(ctx, func(t *tables) error {
+		if _, ok := t.tracks[track.ID().String()]; ok {
+			return ErrDuplicateKey
+		}
+		if err := checkTrack(t, track); err != nil {
+			return err
+		}
+
+		t.tracks[track.ID().String()] = row[domain.Track]{value: track, createdAt: t.nextCreatedAt()}
+		return nil
+	})
+}
+
+func (r *TrackRepository) Find(ctx context.Context, id domain.TrackID) (domain.Track, error) {
+	var track domain.Track
+	err := r.store.read(ctx, func(t *tables) error {
+		found, ok := liveRow(t.tracks, id.String())
+		if !ok {
+			return domain.ErrTrackNotFound
+		}
+		track = found.value
+		return nil
+	})
+	return track, err
+}
+
+// FindInTrash finds a track only if it is in the trash.
+func (r *TrackRepository) FindInTrash(ctx context.Context, id domain.TrackID) (domain.Track, error) {
+	var track domain.Track
+	err := r.store.read(ctx, func(t *tables) error {
+		found, ok := trashedRow(t.tracks, id.String())
+		if !ok {
+			return domain.ErrTrackNotFound
+		}
+		track = found.value
+		return nil
+	})
+	return track, err
+}
+
+// FindForUpdate finds a track like Find. A unit of work holds the store for its whole
+// duration, so there is nothing more to lock.
+func (r *TrackRepository) FindForUpdate(ctx context.Context, id domain.TrackID) (domain.Track, error) {
+	return r.Find(ctx, id)
+}
+
+func (r *TrackRepository) FindAll(ctx context.Context) ([]domain.Track, error) {
+	var tracks []domain.Track
+	err := r.store.read(ctx, func(t *tables) error {
+		tracks = valuesOf(rowsOf(t.tracks))
+		return nil
+	})
+	return tracks, err
+}
+
+func (r *TrackRepository) FindPage(ctx context.Context, page domain.PageRequest) ([]domain.Track, *domain.Cursor, error) {
+	var rows []row[domain.Track]
+	var next *domain.Cursor
+	err := r.store.read(ctx, func(t *tables) error {
+		var err error
+		rows, next, err = createdAtKeyset(trackID).page(rowsOf(t.tracks), page)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return valuesOf(rows), next, nil
+}
+
+// FindByMovie returns the tracks of a movie in album order.
+func (r *TrackRepository) FindByMovie(ctx context.Context, movieID domain.MovieID) ([]domain.Track, error) {
+	var tracks []domain.Track
+	err := r.store.read(ctx, func(t *tables) error {
+		tracks = tracksOfMovie(t, movieID)
+		return nil
+	})
+	return tracks, err
+}
+
+func tracksOfMovie(t *tables, movieID domain.MovieID) []domain.Track {
+	var tracks []domain.Track
+	for _, r := range rowsOf(t.tracks) {
+		if r.value.MovieID() == movieID {
+			tracks = append(tracks, r.value)
+		}
+	}
+	slices.SortFunc(tracks, compareAlbumOrder)
+	return tracks
+}
+
+// Delete moves a track to the trash.
+func (r *TrackRepository) Delete(ctx context.Context, id domain.TrackID) error {
+	return r.store.write(ctx, func(t *tables) error {
+		return moveToTrash(t.tracks, id.String(), domain.ErrTrackNotFound)
+	})
+}
+
+// Restore takes a track out of the trash, unless a live track took its album position.
+func (r *TrackRepository) Restore(ctx context.Context, id domain.TrackID) error {
+	return r.store.write(ctx, func(t *tables) error {
+		if trashed, ok := trashedRow(t.tracks, id.String()); ok {
+			if err := checkTrackPosition(t, trashed.value); err != nil {
+				return err
+			}
+		}
+		return restoreFromTrash(t.tracks, id.String(), domain.ErrTrackNotFound)
+	})
+}
+
+// Purge removes a track from the trash for good along with the occurrences of themes in it.
+func (r *TrackRepository) Purge(ctx context.Context, id domain.TrackID) error {
+	return r.store.write(ctx, func(t *tables) error {
+		if _, ok := trashedRow(t.tracks, id.String()); !ok {
+			return domain.ErrTrackNotFound
+		}
+		for _, theme := range t.themes {
+			if theme.value.FirstHeard() == id {
+				return inUse("themes")
+			}
+		}
+
+		delete(t.tracks, id.String())
+		for key := range t.trackThemes {
+			if key.trackID == id.String() {
+				delete(t.trackThemes, key)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *TrackRepository) Update(ctx context.Context, track domain.Track) error {
+	track.PullEvents()
+
+	return r.store.write(ctx, func(t *tables) error {
+		existing, ok := liveRow(t.tracks, track.ID().String())
+		if !ok {
+			return domain.ErrTrackNotFound
+		}
+		if err := checkTrack(t, track); err != nil {
+			return err
+		}
+
+		existing.value = track
+		t.tracks[track.ID().String()] = existing
+		return nil
+	})
+}
+
+func trackID(track domain.Track) string {
+	return track.ID().String()
+}
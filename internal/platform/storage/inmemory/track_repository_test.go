@@ -0,0 +1,87 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrackRepositorySaveMovieNotFound(t *testing.T) {
+	store := NewStore()
+
+	movieID, err := domain.NewMovieID()
+	require.NoError(t, err)
+	track, err := domain.NewTrack("Concerning Hobbits", movieID.String(), nil, 2, 1, domain.TrackEditionTheatrical, 180)
+	require.NoError(t, err)
+
+	err = NewTrackRepository(store).Save(context.Background(), track)
+	assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+}
+
+func TestTrackRepositorySavePositionAlreadyExists(t *testing.T) {
+	store := NewStore()
+	movie := seedMovie(t, store, "The Fellowship of the Ring", 1)
+	seedTrack(t, store, "Concerning Hobbits", movie, 2)
+
+	track, err := domain.NewTrack("The Shire", movie.ID().String(), nil, 2, 1, domain.TrackEditionTheatrical, 180)
+	require.NoError(t, err)
+
+	err = NewTrackRepository(store).Save(context.Background(), track)
+	assert.ErrorIs(t, err, domain.ErrTrackPositionAlreadyExists)
+}
+
+func TestTrackRepositoryFindByMovieAlbumOrder(t *testing.T) {
+	store := NewStore()
+	movie := seedMovie(t, store, "The Fellowship of the Ring", 1)
+	repo := NewTrackRepository(store)
+
+	complete, err := domain.NewTrack("The Prophecy", movie.ID().String(), nil, 1, 1, domain.TrackEditionCompleteRecordings, 200)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(context.Background(), complete))
+	seedTrack(t, store, "Concerning Hobbits", movie, 2)
+	seedTrack(t, store, "The Prophecy", movie, 1)
+
+	tracks, err := repo.FindByMovie(context.Background(), movie.ID())
+	require.NoError(t, err)
+	require.Len(t, tracks, 3)
+	assert.Equal(t, 1, tracks[0].TrackNumber().Int())
+	assert.Equal(t, 2, tracks[1].TrackNumber().Int())
+	assert.Equal(t, complete.ID(), tracks[2].ID())
+}
+
+func TestTrackRepositoryPurgeCascadesTrackThemes(t *testing.T) {
+	store := NewStore()
+	movie := seedMovie(t, store, "The Fellowship of the Ring", 1)
+	firstHeard := seedTrack(t, store, "The Prophecy", movie, 1)
+	track := seedTrack(t, store, "Concerning Hobbits", movie, 2)
+	theme := seedTheme(t, store, "The Shire", seedGroup(t, store, "Hobbits", "The hobbits"), firstHeard, 0)
+
+	trackTheme, err := domain.NewTrackTheme(track.ID().String(), theme.ID().String(), 10, 40, false)
+	require.NoError(t, err)
+	trackThemeRepo := NewTrackThemeRepository(store)
+	require.NoError(t, trackThemeRepo.Save(context.Background(), trackTheme))
+
+	trackRepo := NewTrackRepository(store)
+	require.NoError(t, trackRepo.Delete(context.Background(), track.ID()))
+	require.NoError(t, trackRepo.Purge(context.Background(), track.ID()))
+
+	trackThemes, err := trackThemeRepo.FindByTheme(context.Background(), theme.ID())
+	require.NoError(t, err)
+	assert.Empty(t, trackThemes)
+}
+
+func TestTrackRepositoryPurgeFirstHeardInUse(t *testing.T) {
+	store := NewStore()
+	movie := seedMovie(t, store, "The Fellowship of the Ring", 1)
+	track := seedTrack(t, store, "Concerning Hobbits", movie, 2)
+	seedTheme(t, store, "The Shire", seedGroup(t, store, "Hobbits", "The hobbits"), track, 0)
+
+	trackRepo := NewTrackRepository(store)
+	require.NoError(t, trackRepo.Delete(context.Background(), track.ID()))
+
+	err := trackRepo.Purge(context.Background(), track.ID())
+	assert.ErrorIs(t, err, domain.ErrInUse)
+}
@@ -0,0 +1,149 @@
+package inmemory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+)
+
+// TrackThemeRepository implements the TrackThemeRepository interface in memory.
+type TrackThemeRepository struct {
+	store *Store
+}
+
+// NewTrackThemeRepository creates a new TrackThemeRepository.
+func NewTrackThemeRepository(store *Store) *TrackThemeRepository {
+	return &TrackThemeRepository{
+		store: store,
+	}
+}
+
+// trackThemesOfTrack returns the occurrences of themes in a track, by start second.
+func trackThemesOfTrack(t *tables, trackID domain.TrackID) []domain.TrackTheme {
+	var trackThemes []domain.TrackTheme
+	for key, r := range t.trackThemes {
+		if key.trackID == trackID.String() {
+			trackThemes = append(trackThemes, r.value)
+		}
+	}
+	slices.SortFunc(trackThemes, func(a, b domain.TrackTheme) int {
+		return cmp.Compare(a.StartSecond().Int(), b.StartSecond().Int())
+	})
+	return trackThemes
+}
+
+// trackThemesOfTheme returns the occurrences of a theme ordered by movie, then by track
+// in album order and by start second.
+func trackThemesOfTheme(t *tables, themeID domain.ThemeID) []domain.TrackTheme {
+	var trackThemes []domain.TrackTheme
+	for key, r := range t.trackThemes {
+		if key.themeID == themeID.String() {
+			trackThemes = append(trackThemes, r.value)
+		}
+	}
+	slices.SortFunc(trackThemes, func(a, b domain.TrackTheme) int {
+		trackA := t.tracks[a.TrackID().String()].value
+		trackB := t.tracks[b.TrackID().String()].value
+		movieA := t.movies[trackA.MovieID().String()].value
+		movieB := t.movies[trackB.MovieID().String()].value
+
+		if c := cmp.Compare(movieA.Sequence().Int(), movieB.Sequence().Int()); c != 0 {
+			return c
+		}
+		if c := compareAlbumOrder(trackA, trackB); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.StartSecond().Int(), b.StartSecond().Int())
+	})
+	return trackThemes
+}
+
+func (r *TrackThemeRepository) Save(ctx context.Context, trackTheme domain.TrackTheme) error {
+	trackTheme.PullEvents()
+
+	return r.store.write(ctx, func(t *tables) error {
+		if _, ok := liveRow(t.tracks, trackTheme.TrackID().String()); !ok {
+			return domain.ErrTrackNotFound
+		}
+		if _, ok := liveRow(t.themes, trackTheme.ThemeID().String()); !ok {
+			return domain.ErrThemeNotFound
+		}
+		key := keyOfTrackTheme(trackTheme)
+		if _, ok := t.trackThemes[key]; ok {
+			return domain.ErrTrackThemeOverlaps
+		}
+
+		t.trackThemes[key] = row[domain.TrackTheme]{value: trackTheme, createdAt: t.nextCreatedAt()}
+		return nil
+	})
+}
+
+func (r *TrackThemeRepository) Find(ctx context.Context, trackID domain.TrackID, themeID domain.ThemeID, startSecond domain.StartSecond) (domain.TrackTheme, error) {
+	var trackTheme domain.TrackTheme
+	err := r.store.read(ctx, func(t *tables) error {
+		found, ok := t.trackThemes[trackThemeKey{
+			trackID:     trackID.String(),
+			themeID:     themeID.String(),
+			startSecond: startSecond.Int(),
+		}]
+		if !ok {
+			return domain.ErrTrackThemeNotFound
+		}
+		trackTheme = found.value
+		return nil
+	})
+	return trackTheme, err
+}
+
+func (r *TrackThemeRepository) FindByTrack(ctx context.Context, trackID domain.TrackID) ([]domain.TrackTheme, error) {
+	var trackThemes []domain.TrackTheme
+	err := r.store.read(ctx, func(t *tables) error {
+		trackThemes = trackThemesOfTrack(t, trackID)
+		return nil
+	})
+	return trackThemes, err
+}
+
+// FindByTheme returns the occurrences of a theme ordered by movie and then by track.
+func (r *TrackThemeRepository) FindByTheme(ctx context.Context, themeID domain.ThemeID) ([]domain.TrackTheme, error) {
+	var trackThemes []domain.TrackTheme
+	err := r.store.read(ctx, func(t *tables) error {
+		trackThemes = trackThemesOfTheme(t, themeID)
+		return nil
+	})
+	return trackThemes, err
+}
+
+func (r *TrackThemeRepository) Delete(ctx context.Context, trackID domain.TrackID, themeID domain.ThemeID, startSecond domain.StartSecond) error {
+	return r.store.write(ctx, func(t *tables) error {
+		key := trackThemeKey{
+			trackID:     trackID.String(),
+			themeID:     themeID.String(),
+			startSecond: startSecond.Int(),
+		}
+		if _, ok := t.trackThemes[key]; !ok {
+			return domain.ErrTrackThemeNotFound
+		}
+
+		delete(t.trackThemes, key)
+		return nil
+	})
+}
+
+func (r *TrackThemeRepository) Update(ctx context.Context, trackTheme domain.TrackTheme) error {
+	trackTheme.PullEvents()
+
+	return r.store.write(ctx, func(t *tables) error {
+		key := keyOfTrackTheme(trackTheme)
+		existing, ok := t.trackThemes[key]
+		if !ok {
+			return domain.ErrTrackThemeNotFound
+		}
+
+		existing.value = trackTheme
+		t.trackThemes[key] = existing
+		return nil
+	})
+}
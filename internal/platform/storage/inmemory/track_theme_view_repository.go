@@ -0,0 +1,60 @@
+package inmemory
+
+import (
+	"context"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
+)
+
+// TrackThemeViewRepository reads track themes joined with their track and theme.
+type TrackThemeViewRepository struct {
+	store *Store
+}
+
+// NewTrackThemeViewRepository creates a new TrackThemeViewRepository.
+func NewTrackThemeViewRepository(store *Store) *TrackThemeViewRepository {
+	return &TrackThemeViewRepository{
+		store: store,
+	}
+}
+
+// trackThemeResponses joins the track themes with their track and theme, leaving out
+// those whose track or theme is in the trash, or left out of the track and theme views.
+func trackThemeResponses(t *tables, trackThemes []domain.TrackTheme) []dto.TrackThemeResponse {
+	var responses []dto.TrackThemeResponse
+	for _, trackTheme := range trackThemes {
+		trackRow, trackOK := liveRow(t.tracks, trackTheme.TrackID().String())
+		themeRow, themeOK := liveRow(t.themes, trackTheme.ThemeID().String())
+		if !trackOK || !themeOK {
+			continue
+		}
+		if _, ok := liveRow(t.movies, trackRow.value.MovieID().String()); !ok || !liveParents(t, themeRow.value) {
+			continue
+		}
+
+		track := trackResponse(t, trackRow.value)
+		theme := themeResponse(t, themeRow.value)
+		responses = append(responses, dto.NewTrackThemeResponse(trackTheme, track, theme))
+	}
+	return responses
+}
+
+func (r *TrackThemeViewRepository) FindByTrack(ctx context.Context, trackID domain.TrackID) ([]dto.TrackThemeResponse, error) {
+	var trackThemes []dto.TrackThemeResponse
+	err := r.store.read(ctx, func(t *tables) error {
+		trackThemes = trackThemeResponses(t, trackThemesOfTrack(t, trackID))
+		return nil
+	})
+	return trackThemes, err
+}
+
+// FindByTheme returns the occurrences of a theme ordered by movie and then by track.
+func (r *TrackThemeViewRepository) FindByTheme(ctx context.Context, themeID domain.ThemeID) ([]dto.TrackThemeResponse, error) {
+	var trackThemes []dto.TrackThemeResponse
+	err := r.store.read(ctx, func(t *tables) error {
+		trackThemes = trackThemeResponses(t, trackThemesOfTheme(t, themeID))
+		return nil
+	})
+	return trackThemes, err
+}
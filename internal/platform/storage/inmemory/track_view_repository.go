@@ -0,0 +1,71 @@
+package inmemory
+
+import (
+	"context"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
+)
+
+// TrackViewRepository reads tracks joined with their movie.
+type TrackViewRepository struct {
+	store *Store
+}
+
+// NewTrackViewRepository creates a new TrackViewRepository.
+func NewTrackViewRepository(store *Store) *TrackViewRepository {
+	return &TrackViewRepository{
+		store: store,
+	}
+}
+
+func trackResponse(t *tables, track domain.Track) dto.TrackResponse {
+	movie := t.movies[track.MovieID().String()].value
+	return dto.NewTrackResponse(track, dto.NewMovieResponse(movie))
+}
+
+// liveTrackRows returns the tracks outside the trash whose movie is not in the trash either.
+func liveTrackRows(t *tables) []row[domain.Track] {
+	var rows []row[domain.Track]
+	for _, r := range rowsOf(t.tracks) {
+		if _, ok := liveRow(t.movies, r.value.MovieID().String()); ok {
+			rows = append(rows, r)
+		}
+	}
+	return rows
+}
+
+func (r *TrackViewRepository) FindPage(ctx context.Context, page domain.PageRequest) ([]dto.TrackResponse, *domain.Cursor, error) {
+	var tracks []dto.TrackResponse
+	var next *domain.Cursor
+	err := r.store.read(ctx, func(t *tables) error {
+		rows, cursor, err := createdAtKeyset(trackID).page(liveTrackRows(t), page)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			tracks = append(tracks, trackResponse(t, row.value))
+		}
+		next = cursor
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return tracks, next, nil
+}
+
+// FindByMovie returns the tracks of a movie in album order.
+func (r *TrackViewRepository) FindByMovie(ctx context.Context, movieID domain.MovieID) ([]dto.TrackResponse, error) {
+	var tracks []dto.TrackResponse
+	err := r.store.read(ctx, func(t *tables) error {
+		if _, ok := liveRow(t.movies, movieID.String()); !ok {
+			return nil
+		}
+		for _, track := range tracksOfMovie(t, movieID) {
+			tracks = append(tracks, trackResponse(t, track))
+		}
+		return nil
+	})
+	return tracks, err
+}
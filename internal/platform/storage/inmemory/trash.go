@@ -0,0 +1,51 @@
+package inmemory
+
+import "time"
+
+// Movies, groups, categories, tracks and themes are soft deleted like in the SQL tables:
+// Delete stamps their row with the time it was moved to the trash, and every read but the
+// trash listing leaves it out. The row and the track themes referencing it stay until
+// Purge removes them.
+
+// inTrash tells whether the row has been soft deleted.
+func (r row[T]) inTrash() bool {
+	return !r.deletedAt.IsZero()
+}
+
+// liveRow returns the row stored under id, unless there is none or it is in the trash.
+func liveRow[T any](table map[string]row[T], id string) (row[T], bool) {
+	r, ok := table[id]
+	return r, ok && !r.inTrash()
+}
+
+// trashedRow returns the row stored under id, provided it is in the trash.
+func trashedRow[T any](table map[string]row[T], id string) (row[T], bool) {
+	r, ok := table[id]
+	return r, ok && r.inTrash()
+}
+
+// moveToTrash soft deletes the row stored under id, or returns notFound when there is no
+// such row outside the trash.
+func moveToTrash[T any](table map[string]row[T], id string, notFound error) error {
+	r, ok := liveRow(table, id)
+	if !ok {
+		return notFound
+	}
+
+	r.deletedAt = time.Now().UTC()
+	table[id] = r
+	return nil
+}
+
+// restoreFromTrash takes the row stored under id out of the trash, or returns notFound
+// when it is not in the trash.
+func restoreFromTrash[T any](table map[string]row[T], id string, notFound error) error {
+	r, ok := trashedRow(table, id)
+	if !ok {
+		return notFound
+	}
+
+	r.deletedAt = time.Time{}
+	table[id] = r
+	return nil
+}
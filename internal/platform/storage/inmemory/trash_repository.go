@@ -0,0 +1,60 @@
+package inmemory
+
+import (
+	"context"
+	"slices"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
+)
+
+// TrashRepository lists the catalogue entries in the trash.
+type TrashRepository struct {
+	store *Store
+}
+
+// NewTrashRepository creates a new TrashRepository.
+func NewTrashRepository(store *Store) *TrashRepository {
+	return &TrashRepository{
+		store: store,
+	}
+}
+
+// trashItems returns the trash items of the rows of a table that are in the trash.
+func trashItems[T any](table map[string]row[T], itemType string, name func(T) string) []dto.TrashItem {
+	var items []dto.TrashItem
+	for id, r := range table {
+		if r.inTrash() {
+			items = append(items, dto.TrashItem{Type: itemType, ID: id, Name: name(r.value), DeletedAt: r.deletedAt})
+		}
+	}
+	return items
+}
+
+// trashKeyset sorts the entries most recently deleted first.
+var trashKeyset = keyset[dto.TrashItem]{
+	key:        func(item dto.TrashItem) string { return createdAtKey(item.DeletedAt) },
+	id:         func(item dto.TrashItem) string { return item.ID },
+	compare:    compareTimeKeys,
+	descending: true,
+}
+
+// FindPage returns a page of the entries in the trash, most recently deleted first.
+func (r *TrashRepository) FindPage(ctx context.Context, page domain.PageRequest) ([]dto.TrashItem, *domain.Cursor, error) {
+	var items []dto.TrashItem
+	err := r.store.read(ctx, func(t *tables) error {
+		items = slices.Concat(
+			trashItems(t.movies, "movie", func(m domain.Movie) string { return m.Name().String() }),
+			trashItems(t.groups, "group", func(g domain.Group) string { return g.Name().String() }),
+			trashItems(t.categories, "category", func(c domain.Category) string { return c.Name().String() }),
+			trashItems(t.tracks, "track", func(tr domain.Track) string { return tr.Name().String() }),
+			trashItems(t.themes, "theme", func(th domain.Theme) string { return th.Name().String() }),
+		)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return trashKeyset.page(items, page)
+}
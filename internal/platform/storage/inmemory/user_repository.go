@@ -0,0 +1,155 @@
+package inmemory
+
+import (
+	"context"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+)
+
+// UserRepository implements the UserRepository interface in memory.
+type UserRepository struct {
+	store *Store
+}
+
+// NewUserRepository creates a new UserRepository.
+func NewUserRepository(store *Store) *UserRepository {
+	return &UserRepository{
+		store: store,
+	}
+}
+
+// Save stores a user. Its pending events are not stored, just as they are not written to
+// the database.
+func (r *UserRepository) Save(ctx context.Context, user domain.User) error {
+	user.PullEvents()
+
+	return r.store.write(ctx, func(t *tables) error {
+		if _, ok := t.users[user.ID().String()]; ok {
+			return domain.ErrUserAlreadyExists
+		}
+		for _, existing := range t.users {
+			if existing.value.Email() == user.Email() {
+				return domain.ErrUserAlreadyExists
+			}
+		}
+		if _, ok := t.roles[user.Role().String()]; !ok {
+			return domain.ErrRoleNotFound
+		}
+
+		t.users[user.ID().String()] = row[domain.User]{value: user, createdAt: t.nextCreatedAt()}
+		return nil
+	})
+}
+
+func (r *UserRepository) Find(ctx context.Context, id domain.UserID) (domain.User, error) {
+	var user domain.User
+	err := r.store.read(ctx, func(t *tables) error {
+		found, ok := t.users[id.String()]
+		if !ok {
+			return domain.ErrUserNotFound
+		}
+		user = found.value
+		return nil
+	})
+	return user, err
+}
+
+func (r *UserRepository) FindByEmail(ctx context.Context, email domain.UserEmail) (domain.User, error) {
+	var user domain.User
+	err := r.store.read(ctx, func(t *tables) error {
+		for _, found := range t.users {
+			if found.value.Email() == email {
+				user = found.value
+				return nil
+			}
+		}
+		return domain.ErrUserNotFound
+	})
+	return user, err
+}
+
+func (r *UserRepository) FindAll(ctx context.Context) ([]domain.User, error) {
+	var users []domain.User
+	err := r.store.read(ctx, func(t *tables) error {
+		users = valuesOf(rowsOf(t.users))
+		return nil
+	})
+	return users, err
+}
+
+func (r *UserRepository) FindPage(ctx context.Context, page domain.PageRequest) ([]domain.User, *domain.Cursor, error) {
+	var rows []row[domain.User]
+	var next *domain.Cursor
+	err := r.store.read(ctx, func(t *tables) error {
+		var err error
+		rows, next, err = createdAtKeyset(userID).page(rowsOf(t.users), page)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return valuesOf(rows), next, nil
+}
+
+func (r *UserRepository) Update(ctx context.Context, user domain.User) error {
+	user.PullEvents()
+
+	return r.store.write(ctx, func(t *tables) error {
+		existing, ok := t.users[user.ID().String()]
+		if !ok {
+			return domain.ErrUserNotFound
+		}
+		for id, other := range t.users {
+			if id != user.ID().String() && other.value.Email() == user.Email() {
+				return domain.ErrUserAlreadyExists
+			}
+		}
+		if _, ok := t.roles[user.Role().String()]; !ok {
+			return domain.ErrRoleNotFound
+		}
+
+		existing.value = user
+		t.users[user.ID().String()] = existing
+		return nil
+	})
+}
+
+// Delete removes a user and, like the ON DELETE CASCADE of the schema, their sessions
+// and verification tokens.
+func (r *UserRepository) Delete(ctx context.Context, id domain.UserID) error {
+	return r.store.write(ctx, func(t *tables) error {
+		if _, ok := t.users[id.String()]; !ok {
+			return domain.ErrUserNotFound
+		}
+
+		for sessionID, session := range t.sessions {
+			if session.value.UserID() == id {
+				delete(t.sessions, sessionID)
+			}
+		}
+		for tokenHash, token := range t.verificationTokens {
+			if token.UserID() == id {
+				delete(t.verificationTokens, tokenHash)
+			}
+		}
+		delete(t.users, id.String())
+		return nil
+	})
+}
+
+func userID(user domain.User) string {
+	return user.ID().String()
+}
+
+func (r *UserRepository) CountByPermission(ctx context.Context, permission domain.Permission) (int, error) {
+	var count int
+	err := r.store.read(ctx, func(t *tables) error {
+		for _, user := range t.users {
+			if t.roles[user.value.Role().String()].Can(permission) {
+				count++
+			}
+		}
+		return nil
+	})
+	return count, err
+}
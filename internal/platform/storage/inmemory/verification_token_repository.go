@@ -0,0 +1,69 @@
+package inmemory
+
+import (
+	"context"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+)
+
+// VerificationTokenRepository implements the VerificationTokenRepository interface in memory.
+type VerificationTokenRepository struct {
+	store *Store
+}
+
+// NewVerificationTokenRepository creates a new VerificationTokenRepository.
+func NewVerificationTokenRepository(store *Store) *VerificationTokenRepository {
+	return &VerificationTokenRepository{
+		store: store,
+	}
+}
+
+func (r *VerificationTokenRepository) Save(ctx context.Context, token domain.VerificationToken) error {
+	return r.store.write(ctx, func(t *tables) error {
+		if _, ok := t.verificationTokens[token.TokenHash()]; ok {
+			return ErrDuplicateKey
+		}
+		if _, ok := t.users[token.UserID().String()]; !ok {
+			return domain.ErrUserNotFound
+		}
+
+		t.verificationTokens[token.TokenHash()] = token
+		return nil
+	})
+}
+
+func (r *VerificationTokenRepository) FindByTokenHash(ctx context.Context, tokenHash string) (domain.VerificationToken, error) {
+	var token domain.VerificationToken
+	err := r.store.read(ctx, func(t *tables) error {
+		found, ok := t.verificationTokens[tokenHash]
+		if !ok {
+			return domain.ErrInvalidVerificationToken
+		}
+		token = found
+		return nil
+	})
+	return token, err
+}
+
+// Delete deletes the token with the given hash. Deleting a token that does not exist is a no-op.
+func (r *VerificationTokenRepository) Delete(ctx context.Context, tokenHash string) error {
+	return r.store.write(ctx, func(t *tables) error {
+		delete(t.verificationTokens, tokenHash)
+		return nil
+	})
+}
+
+func (r *VerificationTokenRepository) ValidAt(ctx context.Context, userID domain.UserID, at time.Time) (bool, error) {
+	var valid bool
+	err := r.store.read(ctx, func(t *tables) error {
+		for _, token := range t.verificationTokens {
+			if token.UserID() == userID && !token.Expired(at) {
+				valid = true
+				return nil
+			}
+		}
+		return nil
+	})
+	return valid, err
+}
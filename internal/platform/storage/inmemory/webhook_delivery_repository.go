@@ -0,0 +1,109 @@
+package inmemory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+)
+
+// WebhookDeliveryRepository implements the WebhookDeliveryRepository interface in memory.
+type WebhookDeliveryRepository struct {
+	store *Store
+}
+
+// NewWebhookDeliveryRepository creates a new WebhookDeliveryRepository.
+func NewWebhookDeliveryRepository(store *Store) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{
+		store: store,
+	}
+}
+
+// Schedule adds the attempt, skipping it when the same attempt at posting the event to the
+// webhook is already there.
+func (r *WebhookDeliveryRepository) Schedule(ctx context.Context, delivery domain.PendingWebhookDelivery) error {
+	return r.store.write(ctx, func(t *tables) error {
+		if _, ok := t.webhooks[delivery.WebhookID().String()]; !ok {
+			return domain.ErrWebhookNotFound
+		}
+		for _, saved := range t.webhookDeliveries {
+			if saved.pending.ID() == delivery.ID() {
+				return ErrDuplicateKey
+			}
+			if saved.pending.WebhookID() == delivery.WebhookID() && saved.pending.Event().ID() == delivery.Event().ID() && saved.pending.Attempt() == delivery.Attempt() {
+				return nil
+			}
+		}
+
+		t.webhookDeliveries = append(t.webhookDeliveries, webhookDeliveryRow{pending: delivery})
+		return nil
+	})
+}
+
+// Claim returns the due attempts, soonest first.
+func (r *WebhookDeliveryRepository) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.PendingWebhookDelivery, error) {
+	var deliveries []domain.PendingWebhookDelivery
+	err := r.store.write(ctx, func(t *tables) error {
+		expired := now.Add(-lease)
+		var due []*webhookDeliveryRow
+		for i := range t.webhookDeliveries {
+			row := &t.webhookDeliveries[i]
+			if !row.isPending() || row.pending.DueAt().After(now) || (!row.claimedAt.IsZero() && !row.claimedAt.Before(expired)) {
+				continue
+			}
+			due = append(due, row)
+		}
+		slices.SortStableFunc(due, func(a, b *webhookDeliveryRow) int {
+			return a.pending.DueAt().Compare(b.pending.DueAt())
+		})
+
+		for _, row := range due[:min(len(due), limit)] {
+			row.claimedAt = now
+			deliveries = append(deliveries, row.pending)
+		}
+		return nil
+	})
+	return deliveries, err
+}
+
+// Record fills in the outcome of the attempt. An attempt that is no longer there went
+// with its webhook, hence domain.ErrWebhookNotFound.
+func (r *WebhookDeliveryRepository) Record(ctx context.Context, delivery domain.WebhookDelivery) error {
+	return r.store.write(ctx, func(t *tables) error {
+		for i := range t.webhookDeliveries {
+			if t.webhookDeliveries[i].pending.ID() == delivery.ID() {
+				t.webhookDeliveries[i].made = delivery
+				return nil
+			}
+		}
+		return domain.ErrWebhookNotFound
+	})
+}
+
+// webhookDeliveryKeyset sorts the attempts made newest first.
+var webhookDeliveryKeyset = keyset[domain.WebhookDelivery]{
+	key:        func(d domain.WebhookDelivery) string { return createdAtKey(d.DeliveredAt()) },
+	id:         func(d domain.WebhookDelivery) string { return d.ID() },
+	compare:    compareTimeKeys,
+	descending: true,
+}
+
+// FindPage returns a page of the attempts made at posting events to the webhook, newest
+// first.
+func (r *WebhookDeliveryRepository) FindPage(ctx context.Context, webhookID domain.WebhookID, page domain.PageRequest) ([]domain.WebhookDelivery, *domain.Cursor, error) {
+	var deliveries []domain.WebhookDelivery
+	err := r.store.read(ctx, func(t *tables) error {
+		for _, row := range t.webhookDeliveries {
+			if !row.isPending() && row.made.WebhookID() == webhookID {
+				deliveries = append(deliveries, row.made)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return webhookDeliveryKeyset.page(deliveries, page)
+}
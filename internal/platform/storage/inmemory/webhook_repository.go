@@ -0,0 +1,95 @@
+package inmemory
+
+import (
+	"context"
+	"slices"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+)
+
+// WebhookRepository implements the WebhookRepository interface in memory.
+type WebhookRepository struct {
+	store *Store
+}
+
+// NewWebhookRepository creates a new WebhookRepository.
+func NewWebhookRepository(store *Store) *WebhookRepository {
+	return &WebhookRepository{
+		store: store,
+	}
+}
+
+func (r *WebhookRepository) Save(ctx context.Context, webhook domain.Webhook) error {
+	return r.store.write(ctx, func(t *tables) error {
+		if _, ok := t.webhooks[webhook.ID().String()]; ok {
+			return ErrDuplicateKey
+		}
+
+		t.webhooks[webhook.ID().String()] = row[domain.Webhook]{value: webhook, createdAt: t.nextCreatedAt()}
+		return nil
+	})
+}
+
+func (r *WebhookRepository) Find(ctx context.Context, id domain.WebhookID) (domain.Webhook, error) {
+	var webhook domain.Webhook
+	err := r.store.read(ctx, func(t *tables) error {
+		found, ok := t.webhooks[id.String()]
+		if !ok {
+			return domain.ErrWebhookNotFound
+		}
+		webhook = found.value
+		return nil
+	})
+	return webhook, err
+}
+
+func (r *WebhookRepository) FindAll(ctx context.Context) ([]domain.Webhook, error) {
+	var webhooks []domain.Webhook
+	err := r.store.read(ctx, func(t *tables) error {
+		webhooks = valuesOf(rowsOf(t.webhooks))
+		return nil
+	})
+	return webhooks, err
+}
+
+func (r *WebhookRepository) FindByEventType(ctx context.Context, eventType event.Type) ([]domain.Webhook, error) {
+	var webhooks []domain.Webhook
+	err := r.store.read(ctx, func(t *tables) error {
+		for _, webhook := range valuesOf(rowsOf(t.webhooks)) {
+			if webhook.Subscribes(eventType) {
+				webhooks = append(webhooks, webhook)
+			}
+		}
+		return nil
+	})
+	return webhooks, err
+}
+
+func (r *WebhookRepository) Update(ctx context.Context, webhook domain.Webhook) error {
+	return r.store.write(ctx, func(t *tables) error {
+		saved, ok := t.webhooks[webhook.ID().String()]
+		if !ok {
+			return domain.ErrWebhookNotFound
+		}
+
+		saved.value = webhook
+		t.webhooks[webhook.ID().String()] = saved
+		return nil
+	})
+}
+
+// Delete removes the webhook for good, along with its deliveries.
+func (r *WebhookRepository) Delete(ctx context.Context, id domain.WebhookID) error {
+	return r.store.write(ctx, func(t *tables) error {
+		if _, ok := t.webhooks[id.String()]; !ok {
+			return domain.ErrWebhookNotFound
+		}
+
+		t.webhookDeliveries = slices.DeleteFunc(t.webhookDeliveries, func(row webhookDeliveryRow) bool {
+			return row.pending.WebhookID() == id
+		})
+		delete(t.webhooks, id.String())
+		return nil
+	})
+}
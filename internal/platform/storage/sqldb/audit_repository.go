@@ -0,0 +1,153 @@
+package sqldb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+// AuditDB is a row of the audit log. The JSON columns are read and written as text, which
+// PostgreSQL casts to and from JSONB.
+type AuditDB struct {
+	ID          string    `db:"id"`
+	CommandType string    `db:"command_type"`
+	Entity      string    `db:"entity"`
+	EntityID    *string   `db:"entity_id"`
+	UserID      *string   `db:"user_id"`
+	Before      *string   `db:"before"`
+	After       *string   `db:"after"`
+	OccurredAt  time.Time `db:"occurred_at"`
+}
+
+var sqlAuditTable = "audit_log"
+var auditSQLStruct = sqlbuilder.NewStruct(new(AuditDB)).For(defaultFlavor)
+
+// AuditRepository implements the AuditRepository interface for SQL.
+type AuditRepository struct {
+	db        Executor
+	dbTimeout time.Duration
+}
+
+// NewAuditRepository creates a new AuditRepository.
+func NewAuditRepository(db Executor, dbTimeout time.Duration) *AuditRepository {
+	return &AuditRepository{
+		db:        db,
+		dbTimeout: dbTimeout,
+	}
+}
+
+// nullString maps the empty string to NULL.
+func nullString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func nullJSON(data json.RawMessage) *string {
+	if data == nil {
+		return nil
+	}
+	s := string(data)
+	return &s
+}
+
+func auditToDTO(entry domain.AuditEntry) AuditDB {
+	return AuditDB{
+		ID:          entry.ID(),
+		CommandType: entry.CommandType(),
+		Entity:      entry.Entity(),
+		EntityID:    nullString(entry.EntityID()),
+		UserID:      nullString(entry.UserID()),
+		Before:      nullJSON(entry.Before()),
+		After:       nullJSON(entry.After()),
+		OccurredAt:  entry.OccurredAt(),
+	}
+}
+
+func auditToDomain(dto AuditDB) (domain.AuditEntry, error) {
+	var entityID, userID string
+	if dto.EntityID != nil {
+		entityID = *dto.EntityID
+	}
+	if dto.UserID != nil {
+		userID = *dto.UserID
+	}
+
+	var before, after json.RawMessage
+	if dto.Before != nil {
+		before = json.RawMessage(*dto.Before)
+	}
+	if dto.After != nil {
+		after = json.RawMessage(*dto.After)
+	}
+
+	return domain.NewAuditEntryWithID(dto.ID, dto.CommandType, dto.Entity, entityID, userID, before, after, dto.OccurredAt)
+}
+
+func (r *AuditRepository) Save(ctx context.Context, entry domain.AuditEntry) error {
+	query, args := auditSQLStruct.InsertInto(sqlAuditTable, auditToDTO(entry)).Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	_, err := executorFrom(ctxTimeout, r.db).ExecContext(ctxTimeout, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to save audit entry: %v", err)
+	}
+
+	return nil
+}
+
+// FindPage returns a page of the entries that match the filter, newest first.
+func (r *AuditRepository) FindPage(ctx context.Context, filter domain.AuditFilter, page domain.PageRequest) ([]domain.AuditEntry, *domain.Cursor, error) {
+	sb := auditSQLStruct.SelectFrom(sqlAuditTable)
+	if entity := filter.Entity(); entity != "" {
+		sb.Where(sb.Equal("entity", entity))
+	}
+	if userID := filter.UserID(); userID != nil {
+		sb.Where(sb.Equal("user_id", userID.String()))
+	}
+	if since := filter.Since(); since != nil {
+		sb.Where(sb.GreaterEqualThan("occurred_at", *since))
+	}
+	if err := paginateByDesc(sb, sqlAuditTable, timeColumn(sqlAuditTable+".occurred_at"), page); err != nil {
+		return nil, nil, err
+	}
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	rows, err := executorFrom(ctxTimeout, r.db).QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find audit entries: %v", err)
+	}
+	defer rows.Close()
+
+	var entries []domain.AuditEntry
+	var keys []string
+	for rows.Next() {
+		var auditDTO AuditDB
+		var occurredAt time.Time
+		if err := rows.Scan(append(auditSQLStruct.Addr(&auditDTO), &occurredAt)...); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan audit entry: %v", err)
+		}
+		entry, err := auditToDomain(auditDTO)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to convert audit entry: %v", err)
+		}
+		entries = append(entries, entry)
+		keys = append(keys, createdAtKey(occurredAt))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to find audit entries: %v", err)
+	}
+
+	entries, next := pageOf(entries, keys, page, func(e domain.AuditEntry) string { return e.ID() })
+	return entries, next, nil
+}
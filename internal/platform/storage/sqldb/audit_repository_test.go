@@ -0,0 +1,130 @@
+package sqldb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	auditEntryID = "123e4567-e89b-12d3-a456-426614174000"
+	auditMovieID = "6a4f86e4-4fef-4151-9c60-e467007dd213"
+	auditUserID  = "28712a55-04dd-4200-9316-4d6a1e399121"
+)
+
+var auditColumns = []string{"id", "command_type", "entity", "entity_id", "user_id", "before", "after", "occurred_at"}
+
+func TestAuditRepositorySaveSuccess(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	occurredAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	entry, err := domain.NewAuditEntryWithID(auditEntryID, "command.update.movie", domain.AuditEntityMovie, auditMovieID, auditUserID, nil, json.RawMessage(`{"name":"The Two Towers"}`), occurredAt)
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec("INSERT INTO audit_log (id, command_type, entity, entity_id, user_id, before, after, occurred_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)").
+		WithArgs(auditEntryID, "command.update.movie", "movie", auditMovieID, auditUserID, nil, `{"name":"The Two Towers"}`, occurredAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := NewAuditRepository(db, 1*time.Second)
+
+	err = repo.Save(context.Background(), entry)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
+
+func TestAuditRepositorySaveError(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	entry, err := domain.NewAuditEntry("command.create.category", domain.AuditEntityCategory, "", "", nil, nil, time.Now())
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec("INSERT INTO audit_log (id, command_type, entity, entity_id, user_id, before, after, occurred_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)").
+		WillReturnError(errors.New("insert error"))
+
+	repo := NewAuditRepository(db, 1*time.Second)
+
+	err = repo.Save(context.Background(), entry)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
+
+func TestAuditRepositoryFindPageFiltered(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	filter, err := domain.NewAuditFilter("movie", auditUserID, "2024-03-01T00:00:00Z")
+	require.NoError(t, err)
+	page, err := domain.NewPageRequest(1, "")
+	require.NoError(t, err)
+
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	occurredAt := since.Add(12 * time.Hour)
+	before := `{"name":"The Two Towers"}`
+	sqlMock.ExpectQuery("SELECT audit_log.id, audit_log.command_type, audit_log.entity, audit_log.entity_id, audit_log.user_id, audit_log.before, audit_log.after, audit_log.occurred_at, audit_log.occurred_at FROM audit_log WHERE entity = $1 AND user_id = $2 AND occurred_at >= $3 ORDER BY audit_log.occurred_at DESC, audit_log.id DESC LIMIT $4").
+		WithArgs("movie", auditUserID, since, 2).
+		WillReturnRows(sqlmock.NewRows(append(auditColumns, "occurred_at")).
+			AddRow(auditEntryID, "command.delete.movie", "movie", auditMovieID, auditUserID, before, nil, occurredAt, occurredAt).
+			AddRow(auditMovieID, "command.update.movie", "movie", auditMovieID, auditUserID, nil, before, since, since))
+
+	repo := NewAuditRepository(db, 1*time.Second)
+
+	entries, next, err := repo.FindPage(context.Background(), filter, page)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "command.delete.movie", entries[0].CommandType())
+	assert.Equal(t, auditMovieID, entries[0].EntityID())
+	assert.JSONEq(t, before, string(entries[0].Before()))
+	assert.Nil(t, entries[0].After())
+	assert.Equal(t, occurredAt, entries[0].OccurredAt())
+	require.NotNil(t, next)
+	assert.Equal(t, auditEntryID, next.ID())
+	assert.Equal(t, "2024-03-01T12:00:00Z", next.Key())
+}
+
+func TestAuditRepositoryFindPageAfterCursor(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	occurredAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	page, err := domain.NewPageRequest(10, domain.NewCursor("2024-03-01T12:00:00Z", auditEntryID).String())
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery("SELECT audit_log.id, audit_log.command_type, audit_log.entity, audit_log.entity_id, audit_log.user_id, audit_log.before, audit_log.after, audit_log.occurred_at, audit_log.occurred_at FROM audit_log WHERE (audit_log.occurred_at, audit_log.id) < ($1, $2) ORDER BY audit_log.occurred_at DESC, audit_log.id DESC LIMIT $3").
+		WithArgs(occurredAt, auditEntryID, 11).
+		WillReturnRows(sqlmock.NewRows(append(auditColumns, "occurred_at")))
+
+	repo := NewAuditRepository(db, 1*time.Second)
+
+	entries, next, err := repo.FindPage(context.Background(), domain.AuditFilter{}, page)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+	assert.Nil(t, next)
+}
+
+func TestAuditRepositoryFindPageError(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	page, err := domain.NewPageRequest(0, "")
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery("SELECT audit_log.id, audit_log.command_type, audit_log.entity, audit_log.entity_id, audit_log.user_id, audit_log.before, audit_log.after, audit_log.occurred_at, audit_log.occurred_at FROM audit_log ORDER BY audit_log.occurred_at DESC, audit_log.id DESC LIMIT $1").
+		WillReturnError(errors.New("query error"))
+
+	repo := NewAuditRepository(db, 1*time.Second)
+
+	_, _, err = repo.FindPage(context.Background(), domain.AuditFilter{}, page)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
@@ -21,12 +21,12 @@ var categorySQLStruct = sqlbuilder.NewStruct(new(CategoryDB)).For(defaultFlavor)
 
 // CategoryRepository implements the CategoryRepository interface for SQL.
 type CategoryRepository struct {
-	db        *sql.DB
+	db        Executor
 	dbTimeout time.Duration
 }
 
 // NewCategoryRepository creates a new CategoryRepository.
-func NewCategoryRepository(db *sql.DB, dbTimeout time.Duration) *CategoryRepository {
+func NewCategoryRepository(db Executor, dbTimeout time.Duration) *CategoryRepository {
 	return &CategoryRepository{
 		db:        db,
 		dbTimeout: dbTimeout,
@@ -53,8 +53,11 @@ func (r *CategoryRepository) Save(ctx context.Context, category domain.Category)
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	_, err := r.db.ExecContext(ctxTimeout, query, args...)
+	_, err := executorFrom(ctxTimeout, r.db).ExecContext(ctxTimeout, query, args...)
 	if err != nil {
+		if constraintErr := writeError(err); constraintErr != nil {
+			return constraintErr
+		}
 		return fmt.Errorf("failed to save category: %v", err)
 	}
 
@@ -62,14 +65,25 @@ func (r *CategoryRepository) Save(ctx context.Context, category domain.Category)
 }
 
 func (r *CategoryRepository) Find(ctx context.Context, id domain.CategoryID) (domain.Category, error) {
+	return r.find(ctx, id, notDeleted)
+}
+
+// FindInTrash finds the category only if it is in the trash.
+func (r *CategoryRepository) FindInTrash(ctx context.Context, id domain.CategoryID) (domain.Category, error) {
+	return r.find(ctx, id, inTrash)
+}
+
+// find finds the category, provided it passes the trash filter.
+func (r *CategoryRepository) find(ctx context.Context, id domain.CategoryID, filter func(*sqlbuilder.SelectBuilder, string)) (domain.Category, error) {
 	sb := categorySQLStruct.SelectFrom(sqlCategoryTable)
+	filter(sb, sqlCategoryTable)
 	sb.Where(sb.Equal("id", id.String()))
 	query, args := sb.Build()
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
 	var categoryDTO CategoryDB
-	err := r.db.QueryRowContext(ctxTimeout, query, args...).Scan(categorySQLStruct.Addr(&categoryDTO)...)
+	err := executorFrom(ctxTimeout, r.db).QueryRowContext(ctxTimeout, query, args...).Scan(categorySQLStruct.Addr(&categoryDTO)...)
 	if errors.Is(err, sql.ErrNoRows) {
 		return domain.Category{}, domain.ErrCategoryNotFound
 	}
@@ -82,12 +96,13 @@ func (r *CategoryRepository) Find(ctx context.Context, id domain.CategoryID) (do
 
 func (r *CategoryRepository) FindAll(ctx context.Context) ([]domain.Category, error) {
 	sb := categorySQLStruct.SelectFrom(sqlCategoryTable)
+	notDeleted(sb, sqlCategoryTable)
 	query, args := sb.Build()
 
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	rows, err := r.db.QueryContext(ctxTimeout, query, args...)
+	rows, err := executorFrom(ctxTimeout, r.db).QueryContext(ctxTimeout, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find categories: %v", err)
 	}
@@ -109,24 +124,97 @@ func (r *CategoryRepository) FindAll(ctx context.Context) ([]domain.Category, er
 	return categories, nil
 }
 
-func (r *CategoryRepository) Delete(ctx context.Context, id domain.CategoryID) error {
-	sb := categorySQLStruct.DeleteFrom(sqlCategoryTable)
-	sb.Where(sb.Equal("id", id.String()))
+func (r *CategoryRepository) FindPage(ctx context.Context, page domain.PageRequest) ([]domain.Category, *domain.Cursor, error) {
+	sb := categorySQLStruct.SelectFrom(sqlCategoryTable)
+	notDeleted(sb, sqlCategoryTable)
+	if err := paginateByCreatedAt(sb, sqlCategoryTable, page); err != nil {
+		return nil, nil, err
+	}
 	query, args := sb.Build()
 
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	result, err := r.db.ExecContext(ctxTimeout, query, args...)
+	rows, err := executorFrom(ctxTimeout, r.db).QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find categories page: %v", err)
+	}
+	defer rows.Close()
+
+	var categories []domain.Category
+	var keys []string
+	for rows.Next() {
+		var categoryDTO CategoryDB
+		var createdAt time.Time
+		if err := rows.Scan(append(categorySQLStruct.Addr(&categoryDTO), &createdAt)...); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan category: %v", err)
+		}
+		category, err := categoryToDomain(categoryDTO)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to convert category: %v", err)
+		}
+		categories = append(categories, category)
+		keys = append(keys, createdAtKey(createdAt))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to find categories page: %v", err)
+	}
+
+	categories, next := pageOf(categories, keys, page, func(c domain.Category) string { return c.ID().String() })
+	return categories, next, nil
+}
+
+// Delete moves the category to the trash.
+func (r *CategoryRepository) Delete(ctx context.Context, id domain.CategoryID) error {
+	query, args := moveToTrashQuery(sqlCategoryTable, id.String())
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	found, err := execOnRow(ctxTimeout, r.db, query, args)
 	if err != nil {
 		return fmt.Errorf("failed to delete category: %v", err)
 	}
+	if !found {
+		return domain.ErrCategoryNotFound
+	}
 
-	rowsAffected, err := result.RowsAffected()
+	return nil
+}
+
+// Restore takes the category out of the trash.
+func (r *CategoryRepository) Restore(ctx context.Context, id domain.CategoryID) error {
+	query, args := restoreQuery(sqlCategoryTable, id.String())
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	found, err := execOnRow(ctxTimeout, r.db, query, args)
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %v", err)
+		return fmt.Errorf("failed to restore category: %v", err)
 	}
-	if rowsAffected == 0 {
+	if !found {
+		return domain.ErrCategoryNotFound
+	}
+
+	return nil
+}
+
+// Purge deletes the category for good, provided it is in the trash.
+func (r *CategoryRepository) Purge(ctx context.Context, id domain.CategoryID) error {
+	query, args := purgeQuery(sqlCategoryTable, id.String())
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	found, err := execOnRow(ctxTimeout, r.db, query, args)
+	if err != nil {
+		if constraintErr := deleteError(err); constraintErr != nil {
+			return constraintErr
+		}
+		return fmt.Errorf("failed to purge category: %v", err)
+	}
+	if !found {
 		return domain.ErrCategoryNotFound
 	}
 
@@ -136,14 +224,17 @@ func (r *CategoryRepository) Delete(ctx context.Context, id domain.CategoryID) e
 func (r *CategoryRepository) Update(ctx context.Context, category domain.Category) error {
 	row := categoryToDTO(category)
 	sb := categorySQLStruct.Update(sqlCategoryTable, row)
-	sb.Where(sb.Equal("id", row.ID))
+	sb.Where(sb.Equal("id", row.ID), sb.IsNull("deleted_at"))
 	query, args := sb.Build()
 
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	result, err := r.db.ExecContext(ctxTimeout, query, args...)
+	result, err := executorFrom(ctxTimeout, r.db).ExecContext(ctxTimeout, query, args...)
 	if err != nil {
+		if constraintErr := writeError(err); constraintErr != nil {
+			return constraintErr
+		}
 		return fmt.Errorf("failed to update category: %v", err)
 	}
 
@@ -15,7 +15,7 @@ import (
 
 const categoryID = "123e4567-e89b-12d3-a456-426614174000"
 const categoryName = "Fantasy"
-const querySelectAllCategories = "SELECT categories.id, categories.name FROM categories"
+const querySelectAllCategories = "SELECT categories.id, categories.name FROM categories WHERE categories.deleted_at IS NULL"
 
 func TestCategoryRepositorySaveRepositoryError(t *testing.T) {
 	category, err := domain.NewCategoryWithID(categoryID, categoryName)
@@ -59,7 +59,7 @@ func TestCategoryRepositoryFindNotFound(t *testing.T) {
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
 
-	sqlMock.ExpectQuery("SELECT categories.id, categories.name FROM categories WHERE id = $1").
+	sqlMock.ExpectQuery("SELECT categories.id, categories.name FROM categories WHERE categories.deleted_at IS NULL AND id = $1").
 		WithArgs(categoryID).
 		WillReturnError(sql.ErrNoRows)
 
@@ -77,7 +77,7 @@ func TestCategoryRepositoryFindSuccess(t *testing.T) {
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
 
-	sqlMock.ExpectQuery("SELECT categories.id, categories.name FROM categories WHERE id = $1").
+	sqlMock.ExpectQuery("SELECT categories.id, categories.name FROM categories WHERE categories.deleted_at IS NULL AND id = $1").
 		WithArgs(categoryID).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(categoryID, categoryName))
 
@@ -148,7 +148,7 @@ func TestCategoryRepositoryDeleteError(t *testing.T) {
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
 
-	sqlMock.ExpectExec("DELETE FROM categories WHERE id = $1").
+	sqlMock.ExpectExec("UPDATE categories SET deleted_at = CURRENT_TIMESTAMP(0) WHERE id = $1 AND deleted_at IS NULL").
 		WithArgs(categoryID).
 		WillReturnError(errors.New("delete error"))
 
@@ -162,13 +162,32 @@ func TestCategoryRepositoryDeleteError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestCategoryRepositoryDeleteNotFound(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	// No row is affected when the category does not exist or is in the trash already
+	sqlMock.ExpectExec("UPDATE categories SET deleted_at = CURRENT_TIMESTAMP(0) WHERE id = $1 AND deleted_at IS NULL").
+		WithArgs(categoryID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := NewCategoryRepository(db, 1*time.Second)
+
+	categoryIDObj, err := domain.NewCategoryIDFromString(categoryID)
+	require.NoError(t, err)
+
+	err = repo.Delete(context.Background(), categoryIDObj)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.ErrorIs(t, err, domain.ErrCategoryNotFound)
+}
+
 func TestCategoryRepositoryDeleteSuccess(t *testing.T) {
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
 
-	sqlMock.ExpectExec("DELETE FROM categories WHERE id = $1").
+	sqlMock.ExpectExec("UPDATE categories SET deleted_at = CURRENT_TIMESTAMP(0) WHERE id = $1 AND deleted_at IS NULL").
 		WithArgs(categoryID).
-		WillReturnResult(sqlmock.NewResult(1, 1))
+		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	repo := NewCategoryRepository(db, 1*time.Second)
 
@@ -180,6 +199,42 @@ func TestCategoryRepositoryDeleteSuccess(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestCategoryRepositoryPurgeError(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec("DELETE FROM categories WHERE id = $1 AND deleted_at IS NOT NULL").
+		WithArgs(categoryID).
+		WillReturnError(errors.New("delete error"))
+
+	repo := NewCategoryRepository(db, 1*time.Second)
+
+	categoryIDObj, err := domain.NewCategoryIDFromString(categoryID)
+	require.NoError(t, err)
+
+	err = repo.Purge(context.Background(), categoryIDObj)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
+
+func TestCategoryRepositoryPurgeSuccess(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec("DELETE FROM categories WHERE id = $1 AND deleted_at IS NOT NULL").
+		WithArgs(categoryID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	repo := NewCategoryRepository(db, 1*time.Second)
+
+	categoryIDObj, err := domain.NewCategoryIDFromString(categoryID)
+	require.NoError(t, err)
+
+	err = repo.Purge(context.Background(), categoryIDObj)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
+
 func TestCategoryRepositoryUpdateError(t *testing.T) {
 	category, err := domain.NewCategoryWithID(categoryID, categoryName)
 	require.NoError(t, err)
@@ -187,7 +242,7 @@ func TestCategoryRepositoryUpdateError(t *testing.T) {
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
 
-	sqlMock.ExpectExec("UPDATE categories SET id = $1, name = $2 WHERE id = $3").
+	sqlMock.ExpectExec("UPDATE categories SET id = $1, name = $2 WHERE id = $3 AND deleted_at IS NULL").
 		WithArgs(categoryID, categoryName, categoryID).
 		WillReturnError(errors.New("update error"))
 
@@ -205,7 +260,7 @@ func TestCategoryRepositoryUpdateSuccess(t *testing.T) {
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
 
-	sqlMock.ExpectExec("UPDATE categories SET id = $1, name = $2 WHERE id = $3").
+	sqlMock.ExpectExec("UPDATE categories SET id = $1, name = $2 WHERE id = $3 AND deleted_at IS NULL").
 		WithArgs(categoryID, categoryName, categoryID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
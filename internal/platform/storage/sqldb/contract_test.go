@@ -0,0 +1,65 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"io/fs"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/db"
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/storage/storagetest"
+	"github.com/stretchr/testify/require"
+)
+
+// contractDSNVariable names the environment variable holding the DSN of the PostgreSQL
+// database the contract suites run against. Every table of that database is emptied
+// before each test, so it must not hold data worth keeping.
+const contractDSNVariable = "MELA_TEST_DSN"
+
+func TestRepositoryContracts(t *testing.T) {
+	dsn := os.Getenv(contractDSNVariable)
+	if dsn == "" {
+		t.Skipf("%s is not set", contractDSNVariable)
+	}
+
+	conn, err := sql.Open("postgres", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	migrations, err := fs.Sub(db.Migrations, "migrations")
+	require.NoError(t, err)
+	migrator, err := NewMigrator(conn, migrations)
+	require.NoError(t, err)
+	_, err = migrator.Up(context.Background())
+	require.NoError(t, err)
+
+	storagetest.Run(t, func(t *testing.T) storagetest.Repositories {
+		_, err := conn.Exec("TRUNCATE users, movies, groups, categories, tracks, themes, tracks_themes, audit_log, outbox, webhooks, webhook_deliveries, sessions, revoked_tokens, signing_keys, verification_tokens, dead_letters")
+		require.NoError(t, err)
+
+		timeout := 5 * time.Second
+		return storagetest.Repositories{
+			Users:       NewUserRepository(conn, timeout),
+			Movies:      NewMovieRepository(conn, timeout),
+			Groups:      NewGroupRepository(conn, timeout),
+			Categories:  NewCategoryRepository(conn, timeout),
+			Tracks:      NewTrackRepository(conn, timeout),
+			Themes:      NewThemeRepository(conn, timeout),
+			TrackThemes: NewTrackThemeRepository(conn, timeout),
+			Trash:       NewTrashRepository(conn, timeout),
+			Audit:       NewAuditRepository(conn, timeout),
+			Outbox:      NewOutboxRepository(conn, timeout, domain.EventCodec{}),
+			Webhooks:    NewWebhookRepository(conn, timeout),
+			Deliveries:  NewWebhookDeliveryRepository(conn, timeout, domain.EventCodec{}),
+			Sessions:    NewSessionRepository(conn, timeout),
+			Revocations: NewRevocationRepository(conn, timeout),
+			SigningKeys: NewSigningKeyRepository(conn, timeout),
+			Roles:       NewRoleRepository(conn, timeout),
+
+			VerificationTokens: NewVerificationTokenRepository(conn, timeout),
+		}
+	})
+}
@@ -0,0 +1,77 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+// DeadLetterDB is a row of the dead letters. The row ID is generated by the database. The
+// payload is written as text, which PostgreSQL casts to JSONB, like that of the outbox.
+type DeadLetterDB struct {
+	EventID     string    `db:"event_id"`
+	EventType   string    `db:"event_type"`
+	AggregateID string    `db:"aggregate_id"`
+	OccurredOn  time.Time `db:"occurred_on"`
+	Payload     *string   `db:"payload"` // Nil when the event could not be encoded
+	Handler     string    `db:"handler"`
+	Attempts    int       `db:"attempts"`
+	Error       string    `db:"error"`
+	FailedAt    time.Time `db:"failed_at"`
+}
+
+var sqlDeadLetterTable = "dead_letters"
+var deadLetterSQLStruct = sqlbuilder.NewStruct(new(DeadLetterDB)).For(defaultFlavor)
+
+// DeadLetterRepository implements the event.DeadLetterStore interface for SQL. It keeps
+// what identifies the event and its payload, encoded with codec as in the outbox, so
+// that the event can be decoded and replayed.
+type DeadLetterRepository struct {
+	db        Executor
+	dbTimeout time.Duration
+	codec     event.Codec
+}
+
+// NewDeadLetterRepository creates a new DeadLetterRepository.
+func NewDeadLetterRepository(db Executor, dbTimeout time.Duration, codec event.Codec) *DeadLetterRepository {
+	return &DeadLetterRepository{
+		db:        db,
+		dbTimeout: dbTimeout,
+		codec:     codec,
+	}
+}
+
+// Save keeps the letter. An event the codec cannot encode is kept without its payload
+// rather than lost.
+func (r *DeadLetterRepository) Save(ctx context.Context, letter event.DeadLetter) error {
+	var payload *string
+	if data, err := r.codec.Encode(letter.Event); err == nil {
+		encoded := string(data)
+		payload = &encoded
+	}
+
+	query, args := deadLetterSQLStruct.InsertInto(sqlDeadLetterTable, DeadLetterDB{
+		EventID:     letter.Event.ID(),
+		EventType:   string(letter.Event.Type()),
+		AggregateID: letter.Event.AggregateID(),
+		OccurredOn:  letter.Event.OccurredOn(),
+		Payload:     payload,
+		Handler:     letter.Handler,
+		Attempts:    letter.Attempts,
+		Error:       letter.Err,
+		FailedAt:    letter.FailedAt,
+	}).Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	_, err := executorFrom(ctxTimeout, r.db).ExecContext(ctxTimeout, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to save dead letter: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package sqldb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const deadLetterInsert = "INSERT INTO dead_letters (event_id, event_type, aggregate_id, occurred_on, payload, handler, attempts, error, failed_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)"
+
+func TestDeadLetterRepositorySaveSuccess(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	e := domain.NewMovieCreatedEvent(auditMovieID, "The Two Towers")
+	failedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	sqlMock.ExpectExec(deadLetterInsert).
+		WithArgs(e.ID(), "events.movie.created", auditMovieID, e.OccurredOn(), `{"name":"The Two Towers"}`, "*search.Indexer", 3, "timeout", failedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	repo := NewDeadLetterRepository(db, 1*time.Second, domain.EventCodec{})
+
+	err = repo.Save(context.Background(), event.DeadLetter{Event: e, Handler: "*search.Indexer", Attempts: 3, Err: "timeout", FailedAt: failedAt})
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
+
+func TestDeadLetterRepositorySaveUnknownEvent(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	e := unknownEvent{event.NewBaseEvent(auditMovieID)}
+	failedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	sqlMock.ExpectExec(deadLetterInsert).
+		WithArgs(e.ID(), "events.unknown", auditMovieID, e.OccurredOn(), nil, "*search.Indexer", 3, "timeout", failedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	repo := NewDeadLetterRepository(db, 1*time.Second, domain.EventCodec{})
+
+	err = repo.Save(context.Background(), event.DeadLetter{Event: e, Handler: "*search.Indexer", Attempts: 3, Err: "timeout", FailedAt: failedAt})
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
+
+func TestDeadLetterRepositorySaveError(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec(deadLetterInsert).
+		WillReturnError(errors.New("insert error"))
+
+	repo := NewDeadLetterRepository(db, 1*time.Second, domain.EventCodec{})
+
+	err = repo.Save(context.Background(), event.DeadLetter{Event: domain.NewMovieCreatedEvent(auditMovieID, "The Two Towers"), FailedAt: time.Now()})
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
+
+// unknownEvent is an event domain.EventCodec does not know.
+type unknownEvent struct {
+	event.BaseEvent
+}
+
+func (unknownEvent) Type() event.Type {
+	return "events.unknown"
+}
@@ -2,7 +2,9 @@ package sqldb
 
 import (
 	"errors"
+	"fmt"
 
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
 	"github.com/huandu/go-sqlbuilder"
 	"github.com/lib/pq"
 )
@@ -49,3 +51,54 @@ func extractConstraintName(err error) string {
 	}
 	return ""
 }
+
+// constraint is a unique or foreign key constraint of the schema.
+type constraint struct {
+	err          error  // Reported when an insert or update breaks the constraint
+	referencedBy string // Table of a restricting foreign key, reported when a delete breaks it
+}
+
+// constraints registers the constraints of the schema that every repository maps to domain
+// errors. Foreign keys with ON DELETE CASCADE have no referencedBy, as deletes never break them.
+var constraints = map[string]constraint{
+	// Unique constraints
+	"users_email_key":     {err: domain.ErrUserAlreadyExists},
+	"movies_sequence_key": {err: domain.ErrMovieSequenceAlreadyExists},
+	"tracks_position_key": {err: domain.ErrTrackPositionAlreadyExists},
+	"tracks_themes_pkey":  {err: domain.ErrTrackThemeOverlaps}, // An occurrence starting at the same second
+
+	// Foreign keys
+	"tracks_movie_id_fkey":               {err: domain.ErrMovieNotFound, referencedBy: "tracks"},
+	"themes_group_id_fkey":               {err: domain.ErrGroupNotFound, referencedBy: "themes"},
+	"themes_category_id_fkey":            {err: domain.ErrCategoryNotFound, referencedBy: "themes"},
+	"themes_first_heard_fkey":            {err: domain.ErrTrackNotFound, referencedBy: "themes"},
+	"tracks_themes_track_id_fkey":        {err: domain.ErrTrackNotFound},
+	"tracks_themes_theme_id_fkey":        {err: domain.ErrThemeNotFound},
+	"webhook_deliveries_webhook_id_fkey": {err: domain.ErrWebhookNotFound},
+	"sessions_user_id_fkey":              {err: domain.ErrUserNotFound},
+	"users_role_fkey":                    {err: domain.ErrRoleNotFound, referencedBy: "users"},
+	"verification_tokens_user_id_fkey":   {err: domain.ErrUserNotFound},
+}
+
+// writeError returns the domain error for the registered constraint an insert or update
+// breaks, or nil when err is not such a violation.
+func writeError(err error) error {
+	switch mapSQLError(extractSQLErrorCode(err)) {
+	case ErrUniqueViolation, ErrForeignKeyViolation:
+		return constraints[extractConstraintName(err)].err
+	}
+	return nil
+}
+
+// deleteError returns domain.ErrInUse when a delete is restricted by a registered foreign
+// key, or nil when err is not such a violation.
+func deleteError(err error) error {
+	if !errors.Is(mapSQLError(extractSQLErrorCode(err)), ErrForeignKeyViolation) {
+		return nil
+	}
+	c, ok := constraints[extractConstraintName(err)]
+	if !ok || c.referencedBy == "" {
+		return nil
+	}
+	return fmt.Errorf("%w: referenced by %s", domain.ErrInUse, c.referencedBy)
+}
@@ -0,0 +1,62 @@
+package sqldb
+
+import (
+	"errors"
+	"testing"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteError(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		expected error
+	}{
+		"unique sequence":         {err: &pq.Error{Code: "23505", Constraint: "movies_sequence_key"}, expected: domain.ErrMovieSequenceAlreadyExists},
+		"unique email":            {err: &pq.Error{Code: "23505", Constraint: "users_email_key"}, expected: domain.ErrUserAlreadyExists},
+		"theme group":             {err: &pq.Error{Code: "23503", Constraint: "themes_group_id_fkey"}, expected: domain.ErrGroupNotFound},
+		"theme category":          {err: &pq.Error{Code: "23503", Constraint: "themes_category_id_fkey"}, expected: domain.ErrCategoryNotFound},
+		"theme first heard":       {err: &pq.Error{Code: "23503", Constraint: "themes_first_heard_fkey"}, expected: domain.ErrTrackNotFound},
+		"track movie":             {err: &pq.Error{Code: "23503", Constraint: "tracks_movie_id_fkey"}, expected: domain.ErrMovieNotFound},
+		"track theme track":       {err: &pq.Error{Code: "23503", Constraint: "tracks_themes_track_id_fkey"}, expected: domain.ErrTrackNotFound},
+		"track theme theme":       {err: &pq.Error{Code: "23503", Constraint: "tracks_themes_theme_id_fkey"}, expected: domain.ErrThemeNotFound},
+		"unique track theme":      {err: &pq.Error{Code: "23505", Constraint: "tracks_themes_pkey"}, expected: domain.ErrTrackThemeOverlaps},
+		"unregistered constraint": {err: &pq.Error{Code: "23505", Constraint: "webhooks_pkey"}},
+		"other database error":    {err: &pq.Error{Code: "23514", Constraint: "movies_series_check"}},
+		"not a database error":    {err: errors.New("connection refused")},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, writeError(tt.err))
+		})
+	}
+}
+
+func TestDeleteError(t *testing.T) {
+	tests := map[string]struct {
+		err   error
+		inUse bool
+	}{
+		"movie with tracks":     {err: &pq.Error{Code: "23503", Constraint: "tracks_movie_id_fkey"}, inUse: true},
+		"group with themes":     {err: &pq.Error{Code: "23503", Constraint: "themes_group_id_fkey"}, inUse: true},
+		"category with themes":  {err: &pq.Error{Code: "23503", Constraint: "themes_category_id_fkey"}, inUse: true},
+		"track first heard":     {err: &pq.Error{Code: "23503", Constraint: "themes_first_heard_fkey"}, inUse: true},
+		"cascading foreign key": {err: &pq.Error{Code: "23503", Constraint: "tracks_themes_track_id_fkey"}},
+		"unique violation":      {err: &pq.Error{Code: "23505", Constraint: "movies_sequence_key"}},
+		"not a database error":  {err: errors.New("connection refused")},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := deleteError(tt.err)
+			if tt.inUse {
+				assert.ErrorIs(t, err, domain.ErrInUse)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
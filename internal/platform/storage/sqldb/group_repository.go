@@ -23,12 +23,12 @@ var groupSQLStruct = sqlbuilder.NewStruct(new(GroupDB)).For(defaultFlavor)
 
 // GroupRepository implements the GroupRepository interface for SQL.
 type GroupRepository struct {
-	db        *sql.DB
+	db        Executor
 	dbTimeout time.Duration
 }
 
 // NewGroupRepository creates a new GroupRepository.
-func NewGroupRepository(db *sql.DB, dbTimeout time.Duration) *GroupRepository {
+func NewGroupRepository(db Executor, dbTimeout time.Duration) *GroupRepository {
 	return &GroupRepository{
 		db:        db,
 		dbTimeout: dbTimeout,
@@ -59,8 +59,11 @@ func (r *GroupRepository) Save(ctx context.Context, group domain.Group) error {
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	_, err := r.db.ExecContext(ctxTimeout, query, args...)
+	_, err := executorFrom(ctxTimeout, r.db).ExecContext(ctxTimeout, query, args...)
 	if err != nil {
+		if constraintErr := writeError(err); constraintErr != nil {
+			return constraintErr
+		}
 		return fmt.Errorf("failed to save group: %v", err)
 	}
 
@@ -68,14 +71,25 @@ func (r *GroupRepository) Save(ctx context.Context, group domain.Group) error {
 }
 
 func (r *GroupRepository) Find(ctx context.Context, id domain.GroupID) (domain.Group, error) {
+	return r.find(ctx, id, notDeleted)
+}
+
+// FindInTrash finds the group only if it is in the trash.
+func (r *GroupRepository) FindInTrash(ctx context.Context, id domain.GroupID) (domain.Group, error) {
+	return r.find(ctx, id, inTrash)
+}
+
+// find finds the group, provided it passes the trash filter.
+func (r *GroupRepository) find(ctx context.Context, id domain.GroupID, filter func(*sqlbuilder.SelectBuilder, string)) (domain.Group, error) {
 	sb := groupSQLStruct.SelectFrom(sqlGroupTable)
+	filter(sb, sqlGroupTable)
 	sb.Where(sb.Equal("id", id.String()))
 	query, args := sb.Build()
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
 	var groupDTO GroupDB
-	err := r.db.QueryRowContext(ctxTimeout, query, args...).Scan(groupSQLStruct.Addr(&groupDTO)...)
+	err := executorFrom(ctxTimeout, r.db).QueryRowContext(ctxTimeout, query, args...).Scan(groupSQLStruct.Addr(&groupDTO)...)
 	if errors.Is(err, sql.ErrNoRows) {
 		return domain.Group{}, domain.ErrGroupNotFound
 	}
@@ -88,13 +102,14 @@ func (r *GroupRepository) Find(ctx context.Context, id domain.GroupID) (domain.G
 
 func (r *GroupRepository) FindAll(ctx context.Context) ([]domain.Group, error) {
 	sb := groupSQLStruct.SelectFrom(sqlGroupTable)
+	notDeleted(sb, sqlGroupTable)
 	sb.OrderBy("created_at ASC")
 	query, args := sb.Build()
 
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	rows, err := r.db.QueryContext(ctxTimeout, query, args...)
+	rows, err := executorFrom(ctxTimeout, r.db).QueryContext(ctxTimeout, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find groups: %v", err)
 	}
@@ -116,24 +131,97 @@ func (r *GroupRepository) FindAll(ctx context.Context) ([]domain.Group, error) {
 	return groups, nil
 }
 
-func (r *GroupRepository) Delete(ctx context.Context, id domain.GroupID) error {
-	sb := groupSQLStruct.DeleteFrom(sqlGroupTable)
-	sb.Where(sb.Equal("id", id.String()))
+func (r *GroupRepository) FindPage(ctx context.Context, page domain.PageRequest) ([]domain.Group, *domain.Cursor, error) {
+	sb := groupSQLStruct.SelectFrom(sqlGroupTable)
+	notDeleted(sb, sqlGroupTable)
+	if err := paginateByCreatedAt(sb, sqlGroupTable, page); err != nil {
+		return nil, nil, err
+	}
 	query, args := sb.Build()
 
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	result, err := r.db.ExecContext(ctxTimeout, query, args...)
+	rows, err := executorFrom(ctxTimeout, r.db).QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find groups page: %v", err)
+	}
+	defer rows.Close()
+
+	var groups []domain.Group
+	var keys []string
+	for rows.Next() {
+		var groupDTO GroupDB
+		var createdAt time.Time
+		if err := rows.Scan(append(groupSQLStruct.Addr(&groupDTO), &createdAt)...); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan group: %v", err)
+		}
+		group, err := groupToDomain(groupDTO)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to convert group: %v", err)
+		}
+		groups = append(groups, group)
+		keys = append(keys, createdAtKey(createdAt))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to find groups page: %v", err)
+	}
+
+	groups, next := pageOf(groups, keys, page, func(g domain.Group) string { return g.ID().String() })
+	return groups, next, nil
+}
+
+// Delete moves the group to the trash.
+func (r *GroupRepository) Delete(ctx context.Context, id domain.GroupID) error {
+	query, args := moveToTrashQuery(sqlGroupTable, id.String())
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	found, err := execOnRow(ctxTimeout, r.db, query, args)
 	if err != nil {
 		return fmt.Errorf("failed to delete group: %v", err)
 	}
+	if !found {
+		return domain.ErrGroupNotFound
+	}
 
-	rowsAffected, err := result.RowsAffected()
+	return nil
+}
+
+// Restore takes the group out of the trash.
+func (r *GroupRepository) Restore(ctx context.Context, id domain.GroupID) error {
+	query, args := restoreQuery(sqlGroupTable, id.String())
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	found, err := execOnRow(ctxTimeout, r.db, query, args)
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %v", err)
+		return fmt.Errorf("failed to restore group: %v", err)
 	}
-	if rowsAffected == 0 {
+	if !found {
+		return domain.ErrGroupNotFound
+	}
+
+	return nil
+}
+
+// Purge deletes the group for good, provided it is in the trash.
+func (r *GroupRepository) Purge(ctx context.Context, id domain.GroupID) error {
+	query, args := purgeQuery(sqlGroupTable, id.String())
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	found, err := execOnRow(ctxTimeout, r.db, query, args)
+	if err != nil {
+		if constraintErr := deleteError(err); constraintErr != nil {
+			return constraintErr
+		}
+		return fmt.Errorf("failed to purge group: %v", err)
+	}
+	if !found {
 		return domain.ErrGroupNotFound
 	}
 
@@ -143,14 +231,17 @@ func (r *GroupRepository) Delete(ctx context.Context, id domain.GroupID) error {
 func (r *GroupRepository) Update(ctx context.Context, group domain.Group) error {
 	row := groupToDTO(group)
 	sb := groupSQLStruct.Update(sqlGroupTable, row)
-	sb.Where(sb.Equal("id", row.ID))
+	sb.Where(sb.Equal("id", row.ID), sb.IsNull("deleted_at"))
 	query, args := sb.Build()
 
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	result, err := r.db.ExecContext(ctxTimeout, query, args...)
+	result, err := executorFrom(ctxTimeout, r.db).ExecContext(ctxTimeout, query, args...)
 	if err != nil {
+		if constraintErr := writeError(err); constraintErr != nil {
+			return constraintErr
+		}
 		return fmt.Errorf("failed to update group: %v", err)
 	}
 
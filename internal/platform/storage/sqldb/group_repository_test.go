@@ -17,7 +17,7 @@ const groupID = "123e4567-e89b-12d3-a456-426614174000"
 const groupName = "Fellowship of the Ring"
 const groupDescription = "A group formed to destroy the One Ring"
 const groupImageURL = "http://example.com/image.jpg"
-const querySelectAllGroups = "SELECT groups.id, groups.name, groups.description, groups.image_url FROM groups ORDER BY created_at ASC"
+const querySelectAllGroups = "SELECT groups.id, groups.name, groups.description, groups.image_url FROM groups WHERE groups.deleted_at IS NULL ORDER BY created_at ASC"
 
 func TestGroupRepositorySaveRepositoryError(t *testing.T) {
 	group, err := domain.NewGroupWithID(groupID, groupName, groupDescription, groupImageURL)
@@ -61,7 +61,7 @@ func TestGroupRepositoryFindNotFound(t *testing.T) {
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
 
-	sqlMock.ExpectQuery("SELECT groups.id, groups.name, groups.description, groups.image_url FROM groups WHERE id = $1").
+	sqlMock.ExpectQuery("SELECT groups.id, groups.name, groups.description, groups.image_url FROM groups WHERE groups.deleted_at IS NULL AND id = $1").
 		WithArgs(groupID).
 		WillReturnError(sql.ErrNoRows)
 
@@ -79,7 +79,7 @@ func TestGroupRepositoryFindSuccess(t *testing.T) {
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
 
-	sqlMock.ExpectQuery("SELECT groups.id, groups.name, groups.description, groups.image_url FROM groups WHERE id = $1").
+	sqlMock.ExpectQuery("SELECT groups.id, groups.name, groups.description, groups.image_url FROM groups WHERE groups.deleted_at IS NULL AND id = $1").
 		WithArgs(groupID).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "image_url"}).AddRow(groupID, groupName, groupDescription, groupImageURL))
 
@@ -150,7 +150,7 @@ func TestGroupRepositoryDeleteError(t *testing.T) {
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
 
-	sqlMock.ExpectExec("DELETE FROM groups WHERE id = $1").
+	sqlMock.ExpectExec("UPDATE groups SET deleted_at = CURRENT_TIMESTAMP(0) WHERE id = $1 AND deleted_at IS NULL").
 		WithArgs(groupID).
 		WillReturnError(errors.New("delete error"))
 
@@ -164,13 +164,32 @@ func TestGroupRepositoryDeleteError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGroupRepositoryDeleteNotFound(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	// No row is affected when the group does not exist or is in the trash already
+	sqlMock.ExpectExec("UPDATE groups SET deleted_at = CURRENT_TIMESTAMP(0) WHERE id = $1 AND deleted_at IS NULL").
+		WithArgs(groupID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := NewGroupRepository(db, 1*time.Second)
+
+	groupIDObj, err := domain.NewGroupIDFromString(groupID)
+	require.NoError(t, err)
+
+	err = repo.Delete(context.Background(), groupIDObj)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.ErrorIs(t, err, domain.ErrGroupNotFound)
+}
+
 func TestGroupRepositoryDeleteSuccess(t *testing.T) {
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
 
-	sqlMock.ExpectExec("DELETE FROM groups WHERE id = $1").
+	sqlMock.ExpectExec("UPDATE groups SET deleted_at = CURRENT_TIMESTAMP(0) WHERE id = $1 AND deleted_at IS NULL").
 		WithArgs(groupID).
-		WillReturnResult(sqlmock.NewResult(1, 1))
+		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	repo := NewGroupRepository(db, 1*time.Second)
 
@@ -182,6 +201,42 @@ func TestGroupRepositoryDeleteSuccess(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestGroupRepositoryPurgeError(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec("DELETE FROM groups WHERE id = $1 AND deleted_at IS NOT NULL").
+		WithArgs(groupID).
+		WillReturnError(errors.New("delete error"))
+
+	repo := NewGroupRepository(db, 1*time.Second)
+
+	groupIDObj, err := domain.NewGroupIDFromString(groupID)
+	require.NoError(t, err)
+
+	err = repo.Purge(context.Background(), groupIDObj)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
+
+func TestGroupRepositoryPurgeSuccess(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec("DELETE FROM groups WHERE id = $1 AND deleted_at IS NOT NULL").
+		WithArgs(groupID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	repo := NewGroupRepository(db, 1*time.Second)
+
+	groupIDObj, err := domain.NewGroupIDFromString(groupID)
+	require.NoError(t, err)
+
+	err = repo.Purge(context.Background(), groupIDObj)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
+
 func TestGroupRepositoryUpdateError(t *testing.T) {
 	group, err := domain.NewGroupWithID(groupID, groupName, groupDescription, groupImageURL)
 	require.NoError(t, err)
@@ -189,7 +244,7 @@ func TestGroupRepositoryUpdateError(t *testing.T) {
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
 
-	sqlMock.ExpectExec("UPDATE groups SET id = $1, name = $2, description = $3, image_url = $4 WHERE id = $5").
+	sqlMock.ExpectExec("UPDATE groups SET id = $1, name = $2, description = $3, image_url = $4 WHERE id = $5 AND deleted_at IS NULL").
 		WithArgs(groupID, groupName, groupDescription, groupImageURL, groupID).
 		WillReturnError(errors.New("update error"))
 
@@ -207,7 +262,7 @@ func TestGroupRepositoryUpdateSuccess(t *testing.T) {
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
 
-	sqlMock.ExpectExec("UPDATE groups SET id = $1, name = $2, description = $3, image_url = $4 WHERE id = $5").
+	sqlMock.ExpectExec("UPDATE groups SET id = $1, name = $2, description = $3, image_url = $4 WHERE id = $5 AND deleted_at IS NULL").
 		WithArgs(groupID, groupName, groupDescription, groupImageURL, groupID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
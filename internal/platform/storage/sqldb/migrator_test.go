@@ -0,0 +1,201 @@
+package sqldb
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/db"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	queryLockMigrations   = "SELECT pg_advisory_lock($1)"
+	queryUnlockMigrations = "SELECT pg_advisory_unlock($1)"
+	queryCreateVersions   = "CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)"
+	querySelectVersion    = "SELECT version, dirty FROM schema_migrations LIMIT 1"
+	queryClearVersion     = "DELETE FROM schema_migrations"
+	queryInsertVersion    = "INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)"
+)
+
+var testMigrations = fstest.MapFS{
+	"000001_create_movies.up.sql":   {Data: []byte("CREATE TABLE movies (id UUID PRIMARY KEY)")},
+	"000001_create_movies.down.sql": {Data: []byte("DROP TABLE movies")},
+	"000002_create_tracks.up.sql":   {Data: []byte("CREATE TABLE tracks (id UUID PRIMARY KEY)")},
+	"000002_create_tracks.down.sql": {Data: []byte("DROP TABLE tracks")},
+	"README.md":                     {Data: []byte("not a migration")},
+}
+
+func expectMigrationsLock(sqlMock sqlmock.Sqlmock, version int, dirty bool) {
+	sqlMock.ExpectExec(queryLockMigrations).WithArgs(migrationsLockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectExec(queryCreateVersions).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	rows := sqlmock.NewRows([]string{"version", "dirty"})
+	if version > 0 {
+		rows.AddRow(version, dirty)
+	}
+	sqlMock.ExpectQuery(querySelectVersion).WillReturnRows(rows)
+}
+
+func TestNewMigratorReadsEmbeddedMigrations(t *testing.T) {
+	migrations, err := fs.Sub(db.Migrations, "migrations")
+	require.NoError(t, err)
+
+	migrator, err := NewMigrator(nil, migrations)
+	require.NoError(t, err)
+
+	// Every embedded migration must be revertible and versions must not repeat
+	require.NotEmpty(t, migrator.Migrations())
+	for i, migration := range migrator.Migrations() {
+		assert.NotEmpty(t, migration.down, "migration %d_%s has no down file", migration.Version, migration.Name)
+		if i > 0 {
+			assert.Greater(t, migration.Version, migrator.Migrations()[i-1].Version)
+		}
+	}
+}
+
+func TestNewMigratorMissingUpFile(t *testing.T) {
+	_, err := NewMigrator(nil, fstest.MapFS{
+		"000001_create_movies.down.sql": {Data: []byte("DROP TABLE movies")},
+	})
+	assert.Error(t, err)
+}
+
+func TestNewMigratorVersionConflict(t *testing.T) {
+	_, err := NewMigrator(nil, fstest.MapFS{
+		"000001_create_movies.up.sql": {Data: []byte("CREATE TABLE movies (id UUID PRIMARY KEY)")},
+		"000001_create_tracks.up.sql": {Data: []byte("CREATE TABLE tracks (id UUID PRIMARY KEY)")},
+	})
+	assert.Error(t, err)
+}
+
+func TestMigratorUpAppliesPending(t *testing.T) {
+	conn, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	expectMigrationsLock(sqlMock, 1, false)
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec("CREATE TABLE tracks (id UUID PRIMARY KEY)").WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectExec(queryClearVersion).WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectExec(queryInsertVersion).WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectCommit()
+	sqlMock.ExpectExec(queryUnlockMigrations).WithArgs(migrationsLockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	migrator, err := NewMigrator(conn, testMigrations)
+	require.NoError(t, err)
+
+	applied, err := migrator.Up(context.Background())
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, applied)
+}
+
+func TestMigratorUpRollsBackFailedMigration(t *testing.T) {
+	conn, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	expectMigrationsLock(sqlMock, 0, false)
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec("CREATE TABLE movies (id UUID PRIMARY KEY)").WillReturnError(assert.AnError)
+	sqlMock.ExpectRollback()
+	sqlMock.ExpectExec(queryUnlockMigrations).WithArgs(migrationsLockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	migrator, err := NewMigrator(conn, testMigrations)
+	require.NoError(t, err)
+
+	applied, err := migrator.Up(context.Background())
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, 0, applied)
+}
+
+func TestMigratorUpDirtyDatabase(t *testing.T) {
+	conn, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	expectMigrationsLock(sqlMock, 1, true)
+	sqlMock.ExpectExec(queryUnlockMigrations).WithArgs(migrationsLockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	migrator, err := NewMigrator(conn, testMigrations)
+	require.NoError(t, err)
+
+	_, err = migrator.Up(context.Background())
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.ErrorIs(t, err, ErrDirtyDatabase)
+}
+
+func TestMigratorDownRevertsLatest(t *testing.T) {
+	conn, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	expectMigrationsLock(sqlMock, 2, false)
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec("DROP TABLE tracks").WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectExec(queryClearVersion).WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectExec(queryInsertVersion).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectCommit()
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec("DROP TABLE movies").WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectExec(queryClearVersion).WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectCommit()
+	sqlMock.ExpectExec(queryUnlockMigrations).WithArgs(migrationsLockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	migrator, err := NewMigrator(conn, testMigrations)
+	require.NoError(t, err)
+
+	reverted, err := migrator.Down(context.Background(), 5)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, reverted)
+}
+
+func TestMigratorDownInvalidStep(t *testing.T) {
+	migrator, err := NewMigrator(nil, testMigrations)
+	require.NoError(t, err)
+
+	_, err = migrator.Down(context.Background(), 0)
+	assert.ErrorIs(t, err, ErrInvalidMigrationStep)
+}
+
+func TestMigratorDownUnknownVersion(t *testing.T) {
+	conn, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	expectMigrationsLock(sqlMock, 7, false)
+	sqlMock.ExpectExec(queryUnlockMigrations).WithArgs(migrationsLockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	migrator, err := NewMigrator(conn, testMigrations)
+	require.NoError(t, err)
+
+	_, err = migrator.Down(context.Background(), 1)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.ErrorIs(t, err, ErrUnknownSchemaVersion)
+}
+
+func TestMigratorStatus(t *testing.T) {
+	conn, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec(queryCreateVersions).WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectQuery(querySelectVersion).WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(1, false))
+
+	migrator, err := NewMigrator(conn, testMigrations)
+	require.NoError(t, err)
+
+	statuses, err := migrator.Status(context.Background())
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+	assert.Equal(t, []MigrationStatus{
+		{Version: 1, Name: "create_movies", Applied: true},
+		{Version: 2, Name: "create_tracks", Applied: false},
+	}, statuses)
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
@@ -12,8 +13,12 @@ import (
 )
 
 type MovieDB struct {
-	ID   string `db:"id"`
-	Name string `db:"name"`
+	ID             string `db:"id"`
+	Name           string `db:"name"`
+	ReleaseYear    int    `db:"release_year"`
+	Sequence       int    `db:"sequence"`
+	Series         string `db:"series"`
+	RuntimeMinutes *int   `db:"runtime_minutes"`
 }
 
 var sqlMovieTable = "movies"
@@ -21,12 +26,12 @@ var movieSQLStruct = sqlbuilder.NewStruct(new(MovieDB)).For(defaultFlavor)
 
 // MovieRepository implements the MovieRepository interface for SQL.
 type MovieRepository struct {
-	db        *sql.DB
+	db        Executor
 	dbTimeout time.Duration
 }
 
 // NewMovieRepository creates a new MovieRepository.
-func NewMovieRepository(db *sql.DB, dbTimeout time.Duration) *MovieRepository {
+func NewMovieRepository(db Executor, dbTimeout time.Duration) *MovieRepository {
 	return &MovieRepository{
 		db:        db,
 		dbTimeout: dbTimeout,
@@ -34,15 +39,29 @@ func NewMovieRepository(db *sql.DB, dbTimeout time.Duration) *MovieRepository {
 }
 
 func movieToDTO(movie domain.Movie) MovieDB {
+	var runtimeMinutes *int
+	if movie.Runtime() != nil {
+		minutes := movie.Runtime().Minutes()
+		runtimeMinutes = &minutes
+	}
+
 	return MovieDB{
-		ID:   movie.ID().String(),
-		Name: movie.Name().String(),
+		ID:             movie.ID().String(),
+		Name:           movie.Name().String(),
+		ReleaseYear:    movie.ReleaseYear().Int(),
+		Sequence:       movie.Sequence().Int(),
+		Series:         movie.Series().String(),
+		RuntimeMinutes: runtimeMinutes,
 	}
 }
 func movieToDomain(dto MovieDB) (domain.Movie, error) {
 	return domain.NewMovieWithID(
 		dto.ID,
 		dto.Name,
+		dto.ReleaseYear,
+		dto.Sequence,
+		dto.Series,
+		dto.RuntimeMinutes,
 	)
 }
 
@@ -53,8 +72,11 @@ func (r *MovieRepository) Save(ctx context.Context, movie domain.Movie) error {
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	_, err := r.db.ExecContext(ctxTimeout, query, args...)
+	_, err := executorFrom(ctxTimeout, r.db).ExecContext(ctxTimeout, query, args...)
 	if err != nil {
+		if constraintErr := writeError(err); constraintErr != nil {
+			return constraintErr
+		}
 		return fmt.Errorf("failed to save movie: %v", err)
 	}
 
@@ -62,14 +84,25 @@ func (r *MovieRepository) Save(ctx context.Context, movie domain.Movie) error {
 }
 
 func (r *MovieRepository) Find(ctx context.Context, id domain.MovieID) (domain.Movie, error) {
+	return r.find(ctx, id, notDeleted)
+}
+
+// FindInTrash finds the movie only if it is in the trash.
+func (r *MovieRepository) FindInTrash(ctx context.Context, id domain.MovieID) (domain.Movie, error) {
+	return r.find(ctx, id, inTrash)
+}
+
+// find finds the movie, provided it passes the trash filter.
+func (r *MovieRepository) find(ctx context.Context, id domain.MovieID, filter func(*sqlbuilder.SelectBuilder, string)) (domain.Movie, error) {
 	sb := movieSQLStruct.SelectFrom(sqlMovieTable)
+	filter(sb, sqlMovieTable)
 	sb.Where(sb.Equal("id", id.String()))
 	query, args := sb.Build()
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
 	var movieDTO MovieDB
-	err := r.db.QueryRowContext(ctxTimeout, query, args...).Scan(movieSQLStruct.Addr(&movieDTO)...)
+	err := executorFrom(ctxTimeout, r.db).QueryRowContext(ctxTimeout, query, args...).Scan(movieSQLStruct.Addr(&movieDTO)...)
 	if errors.Is(err, sql.ErrNoRows) {
 		return domain.Movie{}, domain.ErrMovieNotFound
 	}
@@ -82,13 +115,14 @@ func (r *MovieRepository) Find(ctx context.Context, id domain.MovieID) (domain.M
 
 func (r *MovieRepository) FindAll(ctx context.Context) ([]domain.Movie, error) {
 	sb := movieSQLStruct.SelectFrom(sqlMovieTable)
-	sb.OrderBy("created_at ASC")
+	notDeleted(sb, sqlMovieTable)
+	sb.OrderBy("sequence ASC")
 	query, args := sb.Build()
 
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	rows, err := r.db.QueryContext(ctxTimeout, query, args...)
+	rows, err := executorFrom(ctxTimeout, r.db).QueryContext(ctxTimeout, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find movies: %v", err)
 	}
@@ -110,24 +144,105 @@ func (r *MovieRepository) FindAll(ctx context.Context) ([]domain.Movie, error) {
 	return movies, nil
 }
 
-func (r *MovieRepository) Delete(ctx context.Context, id domain.MovieID) error {
-	sb := movieSQLStruct.DeleteFrom(sqlMovieTable)
-	sb.Where(sb.Equal("id", id.String()))
+// FindPage returns a page of movies in display order, restricted to the series of the filter.
+func (r *MovieRepository) FindPage(ctx context.Context, filter domain.MovieFilter, page domain.PageRequest) ([]domain.Movie, *domain.Cursor, error) {
+	sb := movieSQLStruct.SelectFrom(sqlMovieTable)
+	notDeleted(sb, sqlMovieTable)
+	if series := filter.Series(); series != nil {
+		sb.Where(sb.Equal("movies.series", series.String()))
+	}
+	if err := paginateBy(sb, sqlMovieTable, intColumn("movies.sequence"), page); err != nil {
+		return nil, nil, err
+	}
 	query, args := sb.Build()
 
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	result, err := r.db.ExecContext(ctxTimeout, query, args...)
+	rows, err := executorFrom(ctxTimeout, r.db).QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find movies page: %v", err)
+	}
+	defer rows.Close()
+
+	var movies []domain.Movie
+	var keys []string
+	for rows.Next() {
+		var movieDTO MovieDB
+		var sequence int
+		if err := rows.Scan(append(movieSQLStruct.Addr(&movieDTO), &sequence)...); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan movie: %v", err)
+		}
+		movie, err := movieToDomain(movieDTO)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to convert movie: %v", err)
+		}
+		movies = append(movies, movie)
+		keys = append(keys, strconv.Itoa(sequence))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to find movies page: %v", err)
+	}
+
+	movies, next := pageOf(movies, keys, page, func(m domain.Movie) string { return m.ID().String() })
+	return movies, next, nil
+}
+
+// Delete moves the movie to the trash.
+func (r *MovieRepository) Delete(ctx context.Context, id domain.MovieID) error {
+	query, args := moveToTrashQuery(sqlMovieTable, id.String())
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	found, err := execOnRow(ctxTimeout, r.db, query, args)
 	if err != nil {
 		return fmt.Errorf("failed to delete movie: %v", err)
 	}
+	if !found {
+		return domain.ErrMovieNotFound
+	}
 
-	rowsAffected, err := result.RowsAffected()
+	return nil
+}
+
+// Restore takes the movie out of the trash, unless a live movie took its sequence.
+func (r *MovieRepository) Restore(ctx context.Context, id domain.MovieID) error {
+	query, args := restoreQuery(sqlMovieTable, id.String())
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	found, err := execOnRow(ctxTimeout, r.db, query, args)
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %v", err)
+		// A live movie took its sequence while it was in the trash
+		if constraintErr := writeError(err); constraintErr != nil {
+			return constraintErr
+		}
+		return fmt.Errorf("failed to restore movie: %v", err)
 	}
-	if rowsAffected == 0 {
+	if !found {
+		return domain.ErrMovieNotFound
+	}
+
+	return nil
+}
+
+// Purge deletes the movie for good, provided it is in the trash.
+func (r *MovieRepository) Purge(ctx context.Context, id domain.MovieID) error {
+	query, args := purgeQuery(sqlMovieTable, id.String())
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	found, err := execOnRow(ctxTimeout, r.db, query, args)
+	if err != nil {
+		if constraintErr := deleteError(err); constraintErr != nil {
+			return constraintErr
+		}
+		return fmt.Errorf("failed to purge movie: %v", err)
+	}
+	if !found {
 		return domain.ErrMovieNotFound
 	}
 
@@ -137,14 +252,17 @@ func (r *MovieRepository) Delete(ctx context.Context, id domain.MovieID) error {
 func (r *MovieRepository) Update(ctx context.Context, movie domain.Movie) error {
 	row := movieToDTO(movie)
 	sb := movieSQLStruct.Update(sqlMovieTable, row)
-	sb.Where(sb.Equal("id", row.ID))
+	sb.Where(sb.Equal("id", row.ID), sb.IsNull("deleted_at"))
 	query, args := sb.Build()
 
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	result, err := r.db.ExecContext(ctxTimeout, query, args...)
+	result, err := executorFrom(ctxTimeout, r.db).ExecContext(ctxTimeout, query, args...)
 	if err != nil {
+		if constraintErr := writeError(err); constraintErr != nil {
+			return constraintErr
+		}
 		return fmt.Errorf("failed to update movie: %v", err)
 	}
 
@@ -9,24 +9,27 @@ import (
 
 	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 const movieID = "123e4567-e89b-12d3-a456-426614174000"
 const movieName = "The Lord of the Rings"
-const querySelectAllMovies = "SELECT movies.id, movies.name FROM movies"
+const querySelectAllMovies = "SELECT movies.id, movies.name, movies.release_year, movies.sequence, movies.series, movies.runtime_minutes FROM movies WHERE movies.deleted_at IS NULL ORDER BY sequence ASC"
+
+var movieColumns = []string{"id", "name", "release_year", "sequence", "series", "runtime_minutes"}
 
 func TestMovieRepositorySaveRepositoryError(t *testing.T) {
-	movie, err := domain.NewMovieWithID(movieID, movieName)
+	movie, err := domain.NewMovieWithID(movieID, movieName, 2001, 1, domain.MovieSeriesLordOfTheRings, nil)
 	require.NoError(t, err)
 
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
 
 	sqlMock.ExpectExec(
-		"INSERT INTO movies (id, name) VALUES ($1, $2)").
-		WithArgs(movieID, movieName).
+		"INSERT INTO movies (id, name, release_year, sequence, series, runtime_minutes) VALUES ($1, $2, $3, $4, $5, $6)").
+		WithArgs(movieID, movieName, 2001, 1, domain.MovieSeriesLordOfTheRings, nil).
 		WillReturnError(errors.New("database error"))
 
 	repo := NewMovieRepository(db, 1*time.Second)
@@ -37,15 +40,15 @@ func TestMovieRepositorySaveRepositoryError(t *testing.T) {
 }
 
 func TestMovieRepositorySaveSuccess(t *testing.T) {
-	movie, err := domain.NewMovieWithID(movieID, movieName)
+	movie, err := domain.NewMovieWithID(movieID, movieName, 2001, 1, domain.MovieSeriesLordOfTheRings, nil)
 	require.NoError(t, err)
 
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
 
 	sqlMock.ExpectExec(
-		"INSERT INTO movies (id, name) VALUES ($1, $2)").
-		WithArgs(movieID, movieName).
+		"INSERT INTO movies (id, name, release_year, sequence, series, runtime_minutes) VALUES ($1, $2, $3, $4, $5, $6)").
+		WithArgs(movieID, movieName, 2001, 1, domain.MovieSeriesLordOfTheRings, nil).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	repo := NewMovieRepository(db, 1*time.Second)
@@ -59,7 +62,7 @@ func TestMovieRepositoryFindNotFound(t *testing.T) {
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
 
-	sqlMock.ExpectQuery("SELECT movies.id, movies.name FROM movies WHERE id = $1").
+	sqlMock.ExpectQuery("SELECT movies.id, movies.name, movies.release_year, movies.sequence, movies.series, movies.runtime_minutes FROM movies WHERE movies.deleted_at IS NULL AND id = $1").
 		WithArgs(movieID).
 		WillReturnError(sql.ErrNoRows)
 
@@ -77,9 +80,9 @@ func TestMovieRepositoryFindSuccess(t *testing.T) {
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
 
-	sqlMock.ExpectQuery("SELECT movies.id, movies.name FROM movies WHERE id = $1").
+	sqlMock.ExpectQuery("SELECT movies.id, movies.name, movies.release_year, movies.sequence, movies.series, movies.runtime_minutes FROM movies WHERE movies.deleted_at IS NULL AND id = $1").
 		WithArgs(movieID).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(movieID, movieName))
+		WillReturnRows(sqlmock.NewRows(movieColumns).AddRow(movieID, movieName, 2001, 1, "lotr", 178))
 
 	repo := NewMovieRepository(db, 1*time.Second)
 
@@ -91,6 +94,11 @@ func TestMovieRepositoryFindSuccess(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, movieIDObj, movie.ID())
 	assert.Equal(t, movieName, movie.Name().String())
+	assert.Equal(t, 2001, movie.ReleaseYear().Int())
+	assert.Equal(t, 1, movie.Sequence().Int())
+	assert.Equal(t, domain.MovieSeriesLordOfTheRings, movie.Series().String())
+	require.NotNil(t, movie.Runtime())
+	assert.Equal(t, 178, movie.Runtime().Minutes())
 }
 
 func TestMovieRepositoryFindAllSuccess(t *testing.T) {
@@ -98,9 +106,9 @@ func TestMovieRepositoryFindAllSuccess(t *testing.T) {
 	require.NoError(t, err)
 
 	sqlMock.ExpectQuery(querySelectAllMovies).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
-			AddRow(movieID, movieName).
-			AddRow("223e4567-e89b-12d3-a456-426614174001", "The Hobbit"))
+		WillReturnRows(sqlmock.NewRows(movieColumns).
+			AddRow(movieID, movieName, 2001, 1, "lotr", nil).
+			AddRow("223e4567-e89b-12d3-a456-426614174001", "The Hobbit", 2012, 4, "hobbit", nil))
 
 	repo := NewMovieRepository(db, 1*time.Second)
 
@@ -119,7 +127,7 @@ func TestMovieRepositoryFindAllEmpty(t *testing.T) {
 	require.NoError(t, err)
 
 	sqlMock.ExpectQuery(querySelectAllMovies).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+		WillReturnRows(sqlmock.NewRows(movieColumns))
 
 	repo := NewMovieRepository(db, 1*time.Second)
 
@@ -148,7 +156,7 @@ func TestMovieRepositoryDeleteError(t *testing.T) {
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
 
-	sqlMock.ExpectExec("DELETE FROM movies WHERE id = $1").
+	sqlMock.ExpectExec("UPDATE movies SET deleted_at = CURRENT_TIMESTAMP(0) WHERE id = $1 AND deleted_at IS NULL").
 		WithArgs(movieID).
 		WillReturnError(errors.New("delete error"))
 
@@ -162,13 +170,32 @@ func TestMovieRepositoryDeleteError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestMovieRepositoryDeleteNotFound(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	// No row is affected when the movie does not exist or is in the trash already
+	sqlMock.ExpectExec("UPDATE movies SET deleted_at = CURRENT_TIMESTAMP(0) WHERE id = $1 AND deleted_at IS NULL").
+		WithArgs(movieID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := NewMovieRepository(db, 1*time.Second)
+
+	movieIDObj, err := domain.NewMovieIDFromString(movieID)
+	require.NoError(t, err)
+
+	err = repo.Delete(context.Background(), movieIDObj)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+}
+
 func TestMovieRepositoryDeleteSuccess(t *testing.T) {
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
 
-	sqlMock.ExpectExec("DELETE FROM movies WHERE id = $1").
+	sqlMock.ExpectExec("UPDATE movies SET deleted_at = CURRENT_TIMESTAMP(0) WHERE id = $1 AND deleted_at IS NULL").
 		WithArgs(movieID).
-		WillReturnResult(sqlmock.NewResult(1, 1))
+		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	repo := NewMovieRepository(db, 1*time.Second)
 
@@ -180,15 +207,69 @@ func TestMovieRepositoryDeleteSuccess(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestMovieRepositoryPurgeError(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec("DELETE FROM movies WHERE id = $1 AND deleted_at IS NOT NULL").
+		WithArgs(movieID).
+		WillReturnError(errors.New("delete error"))
+
+	repo := NewMovieRepository(db, 1*time.Second)
+
+	movieIDObj, err := domain.NewMovieIDFromString(movieID)
+	require.NoError(t, err)
+
+	err = repo.Purge(context.Background(), movieIDObj)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
+
+func TestMovieRepositoryPurgeInUse(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec("DELETE FROM movies WHERE id = $1 AND deleted_at IS NOT NULL").
+		WithArgs(movieID).
+		WillReturnError(&pq.Error{Code: "23503", Constraint: "tracks_movie_id_fkey"})
+
+	repo := NewMovieRepository(db, 1*time.Second)
+
+	movieIDObj, err := domain.NewMovieIDFromString(movieID)
+	require.NoError(t, err)
+
+	err = repo.Purge(context.Background(), movieIDObj)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.ErrorIs(t, err, domain.ErrInUse)
+}
+
+func TestMovieRepositoryPurgeSuccess(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec("DELETE FROM movies WHERE id = $1 AND deleted_at IS NOT NULL").
+		WithArgs(movieID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	repo := NewMovieRepository(db, 1*time.Second)
+
+	movieIDObj, err := domain.NewMovieIDFromString(movieID)
+	require.NoError(t, err)
+
+	err = repo.Purge(context.Background(), movieIDObj)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
+
 func TestMovieRepositoryUpdateError(t *testing.T) {
-	movie, err := domain.NewMovieWithID(movieID, movieName)
+	movie, err := domain.NewMovieWithID(movieID, movieName, 2001, 1, domain.MovieSeriesLordOfTheRings, nil)
 	require.NoError(t, err)
 
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
 
-	sqlMock.ExpectExec("UPDATE movies SET id = $1, name = $2 WHERE id = $3").
-		WithArgs(movieID, movieName, movieID).
+	sqlMock.ExpectExec("UPDATE movies SET id = $1, name = $2, release_year = $3, sequence = $4, series = $5, runtime_minutes = $6 WHERE id = $7 AND deleted_at IS NULL").
+		WithArgs(movieID, movieName, 2001, 1, domain.MovieSeriesLordOfTheRings, nil, movieID).
 		WillReturnError(errors.New("update error"))
 
 	repo := NewMovieRepository(db, 1*time.Second)
@@ -199,14 +280,14 @@ func TestMovieRepositoryUpdateError(t *testing.T) {
 }
 
 func TestMovieRepositoryUpdateSuccess(t *testing.T) {
-	movie, err := domain.NewMovieWithID(movieID, movieName)
+	movie, err := domain.NewMovieWithID(movieID, movieName, 2001, 1, domain.MovieSeriesLordOfTheRings, nil)
 	require.NoError(t, err)
 
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
 
-	sqlMock.ExpectExec("UPDATE movies SET id = $1, name = $2 WHERE id = $3").
-		WithArgs(movieID, movieName, movieID).
+	sqlMock.ExpectExec("UPDATE movies SET id = $1, name = $2, release_year = $3, sequence = $4, series = $5, runtime_minutes = $6 WHERE id = $7 AND deleted_at IS NULL").
+		WithArgs(movieID, movieName, 2001, 1, domain.MovieSeriesLordOfTheRings, nil, movieID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	repo := NewMovieRepository(db, 1*time.Second)
@@ -215,3 +296,125 @@ func TestMovieRepositoryUpdateSuccess(t *testing.T) {
 	assert.NoError(t, sqlMock.ExpectationsWereMet())
 	assert.NoError(t, err)
 }
+
+func TestMovieRepositorySaveSequenceAlreadyExists(t *testing.T) {
+	movie, err := domain.NewMovieWithID(movieID, movieName, 2001, 1, domain.MovieSeriesLordOfTheRings, nil)
+	require.NoError(t, err)
+
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec(
+		"INSERT INTO movies (id, name, release_year, sequence, series, runtime_minutes) VALUES ($1, $2, $3, $4, $5, $6)").
+		WithArgs(movieID, movieName, 2001, 1, domain.MovieSeriesLordOfTheRings, nil).
+		WillReturnError(&pq.Error{Code: "23505", Constraint: "movies_sequence_key"})
+
+	repo := NewMovieRepository(db, 1*time.Second)
+
+	err = repo.Save(context.Background(), movie)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.ErrorIs(t, err, domain.ErrMovieSequenceAlreadyExists)
+}
+
+func TestMovieRepositoryFindPageFirstPage(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery("SELECT movies.id, movies.name, movies.release_year, movies.sequence, movies.series, movies.runtime_minutes, movies.sequence FROM movies WHERE movies.deleted_at IS NULL ORDER BY movies.sequence ASC, movies.id ASC LIMIT $1").
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows(append(movieColumns, "sequence")).
+			AddRow(movieID, movieName, 2001, 1, "lotr", nil, 1).
+			AddRow("223e4567-e89b-12d3-a456-426614174001", "The Two Towers", 2002, 2, "lotr", nil, 2))
+
+	repo := NewMovieRepository(db, 1*time.Second)
+
+	page, err := domain.NewPageRequest(1, "")
+	require.NoError(t, err)
+
+	movies, next, err := repo.FindPage(context.Background(), domain.MovieFilter{}, page)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+	assert.Len(t, movies, 1)
+	assert.Equal(t, movieID, movies[0].ID().String())
+	require.NotNil(t, next)
+	assert.Equal(t, movieID, next.ID())
+	assert.Equal(t, "1", next.Key())
+}
+
+func TestMovieRepositoryFindPageAfterCursor(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery("SELECT movies.id, movies.name, movies.release_year, movies.sequence, movies.series, movies.runtime_minutes, movies.sequence FROM movies WHERE movies.deleted_at IS NULL AND movies.series = $1 AND (movies.sequence, movies.id) > ($2, $3) ORDER BY movies.sequence ASC, movies.id ASC LIMIT $4").
+		WithArgs(domain.MovieSeriesHobbit, 4, movieID, 2).
+		WillReturnRows(sqlmock.NewRows(append(movieColumns, "sequence")).
+			AddRow("223e4567-e89b-12d3-a456-426614174001", "The Desolation of Smaug", 2013, 5, "hobbit", nil, 5))
+
+	repo := NewMovieRepository(db, 1*time.Second)
+
+	filter, err := domain.NewMovieFilter(domain.MovieSeriesHobbit)
+	require.NoError(t, err)
+	page, err := domain.NewPageRequest(1, domain.NewCursor("4", movieID).String())
+	require.NoError(t, err)
+
+	movies, next, err := repo.FindPage(context.Background(), filter, page)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+	assert.Len(t, movies, 1)
+	assert.Equal(t, domain.MovieSeriesHobbit, movies[0].Series().String())
+	assert.Nil(t, next)
+}
+
+func TestMovieRepositoryFindPageRowsError(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery("SELECT movies.id, movies.name, movies.release_year, movies.sequence, movies.series, movies.runtime_minutes, movies.sequence FROM movies WHERE movies.deleted_at IS NULL ORDER BY movies.sequence ASC, movies.id ASC LIMIT $1").
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows(append(movieColumns, "sequence")).
+			AddRow(movieID, movieName, 2001, 1, "lotr", nil, 1).
+			AddRow("223e4567-e89b-12d3-a456-426614174001", "The Two Towers", 2002, 2, "lotr", nil, 2).
+			RowError(1, errors.New("connection reset")))
+
+	repo := NewMovieRepository(db, 1*time.Second)
+
+	page, err := domain.NewPageRequest(1, "")
+	require.NoError(t, err)
+
+	_, next, err := repo.FindPage(context.Background(), domain.MovieFilter{}, page)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+	assert.Nil(t, next)
+}
+
+func TestMovieRepositoryFindPageInvalidCursor(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	repo := NewMovieRepository(db, 1*time.Second)
+
+	page, err := domain.NewPageRequest(1, domain.NewCursor("first", movieID).String())
+	require.NoError(t, err)
+
+	_, _, err = repo.FindPage(context.Background(), domain.MovieFilter{}, page)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+}
+
+func TestMovieRepositoryRestoreSequenceTaken(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec("UPDATE movies SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL").
+		WithArgs(movieID).
+		WillReturnError(&pq.Error{Code: "23505", Constraint: "movies_sequence_key"})
+
+	repo := NewMovieRepository(db, 1*time.Second)
+
+	movieIDVO, err := domain.NewMovieIDFromString(movieID)
+	require.NoError(t, err)
+	err = repo.Restore(context.Background(), movieIDVO)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.ErrorIs(t, err, domain.ErrMovieSequenceAlreadyExists)
+}
@@ -0,0 +1,175 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+// OutboxDB is a row of the outbox. The sequence number, which orders the rows, and the
+// claimed_at and sent_at columns are left to the database and the relay. The payload is
+// written as text, which PostgreSQL casts to JSONB.
+type OutboxDB struct {
+	ID          string    `db:"id"`
+	EventType   string    `db:"event_type"`
+	AggregateID string    `db:"aggregate_id"`
+	OccurredOn  time.Time `db:"occurred_on"`
+	Payload     string    `db:"payload"`
+}
+
+var sqlOutboxTable = "outbox"
+var outboxSQLStruct = sqlbuilder.NewStruct(new(OutboxDB)).For(defaultFlavor)
+
+// claimOutboxQuery claims the oldest unsent rows whose lease, if any, has run out. The
+// rows other relays hold locks on are skipped rather than waited for. The builder has no
+// SKIP LOCKED, hence the raw SQL.
+const claimOutboxQuery = `WITH claimed AS (
+	UPDATE outbox SET claimed_at = $1
+	WHERE id IN (
+		SELECT id FROM outbox
+		WHERE sent_at IS NULL AND (claimed_at IS NULL OR claimed_at < $2)
+		ORDER BY seq
+		LIMIT $3
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING seq, id, event_type, aggregate_id, occurred_on, payload
+)
+SELECT id, event_type, aggregate_id, occurred_on, payload FROM claimed ORDER BY seq`
+
+// deadLetterOutboxQuery moves an outbox row that cannot be decoded to the dead letters,
+// with its payload as it was written, and marks it sent so that it is not claimed again.
+const deadLetterOutboxQuery = `WITH moved AS (
+	UPDATE outbox SET sent_at = $1 WHERE id = $2
+	RETURNING id, event_type, aggregate_id, occurred_on, payload
+)
+INSERT INTO dead_letters (event_id, event_type, aggregate_id, occurred_on, payload, handler, attempts, error, failed_at)
+SELECT id, event_type, aggregate_id, occurred_on, payload, $3, 1, $4, $1 FROM moved`
+
+// outboxHandler is the handler the dead letters of the outbox rows that cannot be decoded
+// are recorded with.
+const outboxHandler = "*sqldb.OutboxRepository"
+
+// undecodableRow is a claimed outbox row the codec failed to decode.
+type undecodableRow struct {
+	id  string
+	err error
+}
+
+// OutboxRepository implements the event.Outbox interface for SQL. The events are encoded
+// with codec, which has to know every event that is published.
+type OutboxRepository struct {
+	db        Executor
+	dbTimeout time.Duration
+	codec     event.Codec
+}
+
+// NewOutboxRepository creates a new OutboxRepository.
+func NewOutboxRepository(db Executor, dbTimeout time.Duration, codec event.Codec) *OutboxRepository {
+	return &OutboxRepository{
+		db:        db,
+		dbTimeout: dbTimeout,
+		codec:     codec,
+	}
+}
+
+// Save inserts the events, skipping those already in the outbox.
+func (r *OutboxRepository) Save(ctx context.Context, events []event.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	rows := make([]any, 0, len(events))
+	for _, e := range events {
+		payload, err := r.codec.Encode(e)
+		if err != nil {
+			return fmt.Errorf("failed to save outbox events: %v", err)
+		}
+		rows = append(rows, OutboxDB{
+			ID:          e.ID(),
+			EventType:   string(e.Type()),
+			AggregateID: e.AggregateID(),
+			OccurredOn:  e.OccurredOn(),
+			Payload:     string(payload),
+		})
+	}
+
+	ib := outboxSQLStruct.InsertInto(sqlOutboxTable, rows...)
+	ib.SQL("ON CONFLICT (id) DO NOTHING")
+	query, args := ib.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	_, err := executorFrom(ctxTimeout, r.db).ExecContext(ctxTimeout, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to save outbox events: %v", err)
+	}
+
+	return nil
+}
+
+// Claim returns the claimed events. The rows the codec cannot decode, say of an event
+// type that no longer exists, are moved to the dead letters instead, so that they do not
+// hold up the events claimed with them, nor those after them.
+func (r *OutboxRepository) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]event.Event, error) {
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	rows, err := executorFrom(ctxTimeout, r.db).QueryContext(ctxTimeout, claimOutboxQuery, now, now.Add(-lease), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %v", err)
+	}
+	defer rows.Close()
+
+	var events []event.Event
+	var undecodable []undecodableRow
+	for rows.Next() {
+		var outboxDTO OutboxDB
+		if err := rows.Scan(outboxSQLStruct.Addr(&outboxDTO)...); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox event: %v", err)
+		}
+
+		base := event.RestoreBaseEvent(outboxDTO.ID, outboxDTO.AggregateID, outboxDTO.OccurredOn)
+		e, err := r.codec.Decode(base, event.Type(outboxDTO.EventType), []byte(outboxDTO.Payload))
+		if err != nil {
+			undecodable = append(undecodable, undecodableRow{id: outboxDTO.ID, err: err})
+			continue
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %v", err)
+	}
+	// A transaction runs one statement at a time, so the rows are done with first
+	rows.Close()
+
+	for _, row := range undecodable {
+		_, err := executorFrom(ctxTimeout, r.db).ExecContext(ctxTimeout, deadLetterOutboxQuery, now, row.id, outboxHandler, row.err.Error())
+		if err != nil {
+			return nil, fmt.Errorf("failed to dead-letter outbox event %s: %v", row.id, err)
+		}
+	}
+
+	return events, nil
+}
+
+func (r *OutboxRepository) MarkSent(ctx context.Context, eventID string, sentAt time.Time) error {
+	ub := defaultFlavor.NewUpdateBuilder()
+	ub.Update(sqlOutboxTable)
+	ub.Set(ub.Assign("sent_at", sentAt))
+	ub.Where(ub.Equal("id", eventID))
+	query, args := ub.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	_, err := executorFrom(ctxTimeout, r.db).ExecContext(ctxTimeout, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox event %s sent: %v", eventID, err)
+	}
+
+	return nil
+}
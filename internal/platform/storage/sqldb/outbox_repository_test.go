@@ -0,0 +1,177 @@
+package sqldb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	outboxInsert   = "INSERT INTO outbox (id, event_type, aggregate_id, occurred_on, payload) VALUES ($1, $2, $3, $4, $5), ($6, $7, $8, $9, $10) ON CONFLICT (id) DO NOTHING"
+	outboxMarkSent = "UPDATE outbox SET sent_at = $1 WHERE id = $2"
+)
+
+var outboxColumns = []string{"id", "event_type", "aggregate_id", "occurred_on", "payload"}
+
+func TestOutboxRepositorySaveSuccess(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	movieEvent := domain.NewMovieCreatedEvent(auditMovieID, "The Two Towers")
+	trackThemeEvent := domain.NewTrackThemeAddedEvent(auditMovieID, auditMovieID, 0)
+
+	sqlMock.ExpectExec(outboxInsert).
+		WithArgs(
+			movieEvent.ID(), "events.movie.created", auditMovieID, movieEvent.OccurredOn(), `{"name":"The Two Towers"}`,
+			trackThemeEvent.ID(), "events.track_theme.added", auditMovieID, trackThemeEvent.OccurredOn(), `{"track_id":"`+auditMovieID+`","start_second":0}`,
+		).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	repo := NewOutboxRepository(db, 1*time.Second, domain.EventCodec{})
+
+	err = repo.Save(context.Background(), []event.Event{movieEvent, trackThemeEvent})
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
+
+func TestOutboxRepositorySaveError(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec(outboxInsert).
+		WillReturnError(errors.New("insert error"))
+
+	repo := NewOutboxRepository(db, 1*time.Second, domain.EventCodec{})
+
+	err = repo.Save(context.Background(), []event.Event{
+		domain.NewMovieCreatedEvent(auditMovieID, "The Two Towers"),
+		domain.NewMovieUpdatedEvent(auditMovieID, "The Two Towers"),
+	})
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
+
+func TestOutboxRepositoryClaimSuccess(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	occurredOn := now.Add(-time.Hour)
+	eventID := "8f0e7d3c-2b1a-4c5d-9e8f-7a6b5c4d3e2f"
+
+	sqlMock.ExpectQuery(claimOutboxQuery).
+		WithArgs(now, now.Add(-time.Minute), 10).
+		WillReturnRows(sqlmock.NewRows(outboxColumns).
+			AddRow(eventID, "events.theme.deleted", auditMovieID, occurredOn, `{"name":"The Shire","group_id":"`+auditMovieID+`"}`))
+
+	repo := NewOutboxRepository(db, 1*time.Second, domain.EventCodec{})
+
+	events, err := repo.Claim(context.Background(), now, time.Minute, 10)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	e, ok := events[0].(domain.ThemeDeletedEvent)
+	require.True(t, ok)
+	assert.Equal(t, eventID, e.ID())
+	assert.Equal(t, auditMovieID, e.ThemeID())
+	assert.Equal(t, occurredOn, e.OccurredOn())
+	assert.Equal(t, "The Shire", e.Name())
+	assert.Equal(t, auditMovieID, e.GroupID())
+}
+
+func TestOutboxRepositoryClaimUnknownEvent(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	unknownID := "8f0e7d3c-2b1a-4c5d-9e8f-7a6b5c4d3e2f"
+	knownID := "1c2d3e4f-5a6b-4c7d-8e9f-0a1b2c3d4e5f"
+
+	sqlMock.ExpectQuery(claimOutboxQuery).
+		WithArgs(now, now.Add(-time.Minute), 10).
+		WillReturnRows(sqlmock.NewRows(outboxColumns).
+			AddRow(unknownID, "events.unknown", auditMovieID, now, `{}`).
+			AddRow(knownID, "events.movie.created", auditMovieID, now, `{"name":"The Two Towers"}`))
+	sqlMock.ExpectExec(deadLetterOutboxQuery).
+		WithArgs(now, unknownID, outboxHandler, domain.ErrUnknownEvent.Error()+": events.unknown").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	repo := NewOutboxRepository(db, 1*time.Second, domain.EventCodec{})
+
+	events, err := repo.Claim(context.Background(), now, time.Minute, 10)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, knownID, events[0].ID())
+}
+
+func TestOutboxRepositoryClaimDeadLetterError(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery(claimOutboxQuery).
+		WillReturnRows(sqlmock.NewRows(outboxColumns).
+			AddRow("8f0e7d3c-2b1a-4c5d-9e8f-7a6b5c4d3e2f", "events.unknown", auditMovieID, time.Now(), `{}`))
+	sqlMock.ExpectExec(deadLetterOutboxQuery).
+		WillReturnError(errors.New("insert error"))
+
+	repo := NewOutboxRepository(db, 1*time.Second, domain.EventCodec{})
+
+	_, err = repo.Claim(context.Background(), time.Now(), time.Minute, 10)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
+
+func TestOutboxRepositoryClaimError(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery(claimOutboxQuery).
+		WillReturnError(errors.New("select error"))
+
+	repo := NewOutboxRepository(db, 1*time.Second, domain.EventCodec{})
+
+	_, err = repo.Claim(context.Background(), time.Now(), time.Minute, 10)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
+
+func TestOutboxRepositoryMarkSentSuccess(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sentAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	eventID := "8f0e7d3c-2b1a-4c5d-9e8f-7a6b5c4d3e2f"
+
+	sqlMock.ExpectExec(outboxMarkSent).
+		WithArgs(sentAt, eventID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := NewOutboxRepository(db, 1*time.Second, domain.EventCodec{})
+
+	err = repo.MarkSent(context.Background(), eventID, sentAt)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
+
+func TestOutboxRepositoryMarkSentError(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec(outboxMarkSent).
+		WillReturnError(errors.New("update error"))
+
+	repo := NewOutboxRepository(db, 1*time.Second, domain.EventCodec{})
+
+	err = repo.MarkSent(context.Background(), "8f0e7d3c-2b1a-4c5d-9e8f-7a6b5c4d3e2f", time.Now())
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
@@ -0,0 +1,113 @@
+package sqldb
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+// keysetColumn is a column a page can be sorted by, along with the way a cursor key is
+// turned back into a value of that column.
+type keysetColumn struct {
+	name     string
+	parseKey func(key string) (any, error)
+}
+
+// createdAtColumn returns the created_at column of the given table.
+func createdAtColumn(table string) keysetColumn {
+	return timeColumn(table + ".created_at")
+}
+
+// timeColumn returns a timestamp column whose values are written to cursor keys as by
+// createdAtKey.
+func timeColumn(name string) keysetColumn {
+	return keysetColumn{
+		name: name,
+		parseKey: func(key string) (any, error) {
+			return time.Parse(time.RFC3339Nano, key)
+		},
+	}
+}
+
+// intColumn returns an integer column whose values are written to cursor keys in base 10.
+func intColumn(name string) keysetColumn {
+	return keysetColumn{
+		name: name,
+		parseKey: func(key string) (any, error) {
+			return strconv.Atoi(key)
+		},
+	}
+}
+
+// paginateByCreatedAt restricts the select to the requested page using keyset pagination
+// on (created_at, id). The created_at column is appended to the selected columns, and one
+// extra row is requested so pageOf can tell whether there is a next page.
+func paginateByCreatedAt(sb *sqlbuilder.SelectBuilder, table string, page domain.PageRequest) error {
+	return paginateBy(sb, table, createdAtColumn(table), page)
+}
+
+// paginateBy works like paginateByCreatedAt, sorting on (column, id) instead.
+func paginateBy(sb *sqlbuilder.SelectBuilder, table string, column keysetColumn, page domain.PageRequest) error {
+	return paginate(sb, table, column, page, false)
+}
+
+// paginateByDesc works like paginateBy, latest (column, id) first.
+func paginateByDesc(sb *sqlbuilder.SelectBuilder, table string, column keysetColumn, page domain.PageRequest) error {
+	return paginate(sb, table, column, page, true)
+}
+
+func paginate(sb *sqlbuilder.SelectBuilder, table string, column keysetColumn, page domain.PageRequest, descending bool) error {
+	idCol := table + ".id"
+	comparison, direction := ">", " ASC"
+	if descending {
+		comparison, direction = "<", " DESC"
+	}
+
+	if after := page.After(); after != nil {
+		value, err := column.parseKey(after.Key())
+		if err != nil {
+			return domain.ErrInvalidCursor
+		}
+		sb.Where(fmt.Sprintf("(%s, %s) %s (%s, %s)", column.name, idCol, comparison, sb.Var(value), sb.Var(after.ID())))
+	}
+
+	sb.SelectMore(column.name)
+	sb.OrderBy(column.name+direction, idCol+direction)
+	sb.Limit(page.Limit() + 1)
+
+	return nil
+}
+
+// createdAtKey formats a created_at value as a cursor key.
+func createdAtKey(createdAt time.Time) string {
+	return createdAt.UTC().Format(time.RFC3339Nano)
+}
+
+// keyOf formats a value scanned from a keyset column as a cursor key.
+func keyOf(value any) string {
+	switch v := value.(type) {
+	case time.Time:
+		return createdAtKey(v)
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// pageOf drops the look-ahead row fetched by the paginate helpers and returns the cursor
+// of the last element when there are more rows to read.
+func pageOf[T any](items []T, keys []string, page domain.PageRequest, id func(T) string) ([]T, *domain.Cursor) {
+	if len(items) <= page.Limit() {
+		return items, nil
+	}
+
+	items = items[:page.Limit()]
+	last := len(items) - 1
+	cursor := domain.NewCursor(keys[last], id(items[last]))
+
+	return items, &cursor
+}
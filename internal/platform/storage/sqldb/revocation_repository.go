@@ -0,0 +1,82 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var sqlRevokedTokenTable = "revoked_tokens"
+
+// RevocationRepository implements the RevocationRepository interface for SQL.
+type RevocationRepository struct {
+	db        Executor
+	dbTimeout time.Duration
+}
+
+// NewRevocationRepository creates a new RevocationRepository.
+func NewRevocationRepository(db Executor, dbTimeout time.Duration) *RevocationRepository {
+	return &RevocationRepository{
+		db:        db,
+		dbTimeout: dbTimeout,
+	}
+}
+
+// Revoke adds the token to the revocation list. Revoking a token twice is a no-op.
+func (r *RevocationRepository) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	ib := defaultFlavor.NewInsertBuilder()
+	ib.InsertInto(sqlRevokedTokenTable)
+	ib.Cols("jti", "expires_at")
+	ib.Values(jti, expiresAt)
+	ib.SQL("ON CONFLICT (jti) DO NOTHING")
+	query, args := ib.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	_, err := executorFrom(ctxTimeout, r.db).ExecContext(ctxTimeout, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to revoke token: %v", err)
+	}
+
+	return nil
+}
+
+func (r *RevocationRepository) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	sb := defaultFlavor.NewSelectBuilder()
+	sb.Select("1").From(sqlRevokedTokenTable).Where(sb.Equal("jti", jti))
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	var found int
+	err := executorFrom(ctxTimeout, r.db).QueryRowContext(ctxTimeout, query, args...).Scan(&found)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check token revocation: %v", err)
+	}
+
+	return true, nil
+}
+
+// Prune drops the tokens that expired by now, which no longer need to be listed.
+func (r *RevocationRepository) Prune(ctx context.Context, now time.Time) error {
+	db := defaultFlavor.NewDeleteBuilder()
+	db.DeleteFrom(sqlRevokedTokenTable).Where(db.LessEqualThan("expires_at", now))
+	query, args := db.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	_, err := executorFrom(ctxTimeout, r.db).ExecContext(ctxTimeout, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to prune revoked tokens: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const revokedJTI = "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+
+func TestRevocationRepositoryRevoke(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	expiresAt := time.Date(2024, 3, 1, 12, 15, 0, 0, time.UTC)
+	sqlMock.ExpectExec("INSERT INTO revoked_tokens (jti, expires_at) VALUES ($1, $2) ON CONFLICT (jti) DO NOTHING").
+		WithArgs(revokedJTI, expiresAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := NewRevocationRepository(db, 1*time.Second)
+
+	err = repo.Revoke(context.Background(), revokedJTI, expiresAt)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
+
+func TestRevocationRepositoryIsRevoked(t *testing.T) {
+	tests := map[string]struct {
+		rows     *sqlmock.Rows
+		err      error
+		expected bool
+	}{
+		"listed":     {rows: sqlmock.NewRows([]string{"?column?"}).AddRow(1), expected: true},
+		"not listed": {err: sql.ErrNoRows, expected: false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			require.NoError(t, err)
+
+			query := sqlMock.ExpectQuery("SELECT 1 FROM revoked_tokens WHERE jti = $1").WithArgs(revokedJTI)
+			if tt.rows != nil {
+				query.WillReturnRows(tt.rows)
+			} else {
+				query.WillReturnError(tt.err)
+			}
+
+			repo := NewRevocationRepository(db, 1*time.Second)
+
+			revoked, err := repo.IsRevoked(context.Background(), revokedJTI)
+			assert.NoError(t, sqlMock.ExpectationsWereMet())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, revoked)
+		})
+	}
+}
+
+func TestRevocationRepositoryPrune(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	now := time.Date(2024, 3, 1, 12, 15, 0, 0, time.UTC)
+	sqlMock.ExpectExec("DELETE FROM revoked_tokens WHERE expires_at <= $1").
+		WithArgs(now).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	repo := NewRevocationRepository(db, 1*time.Second)
+
+	err = repo.Prune(context.Background(), now)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
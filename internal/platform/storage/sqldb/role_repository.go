@@ -0,0 +1,55 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/lib/pq"
+)
+
+type RoleDB struct {
+	Name        string         `db:"name"`
+	Permissions pq.StringArray `db:"permissions"`
+}
+
+var sqlRoleTable = "roles"
+var roleSQLStruct = sqlbuilder.NewStruct(new(RoleDB)).For(defaultFlavor)
+
+// RoleRepository implements the RoleRepository interface for SQL.
+type RoleRepository struct {
+	db        Executor
+	dbTimeout time.Duration
+}
+
+// NewRoleRepository creates a new RoleRepository.
+func NewRoleRepository(db Executor, dbTimeout time.Duration) *RoleRepository {
+	return &RoleRepository{
+		db:        db,
+		dbTimeout: dbTimeout,
+	}
+}
+
+func (r *RoleRepository) Find(ctx context.Context, name domain.RoleName) (domain.Role, error) {
+	sb := roleSQLStruct.SelectFrom(sqlRoleTable)
+	sb.Where(sb.Equal("name", name.String()))
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	var roleDTO RoleDB
+	err := executorFrom(ctxTimeout, r.db).QueryRowContext(ctxTimeout, query, args...).Scan(roleSQLStruct.Addr(&roleDTO)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Role{}, domain.ErrRoleNotFound
+	}
+	if err != nil {
+		return domain.Role{}, fmt.Errorf("failed to find role: %v", err)
+	}
+
+	return domain.NewRole(roleDTO.Name, roleDTO.Permissions)
+}
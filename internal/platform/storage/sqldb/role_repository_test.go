@@ -0,0 +1,55 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const roleSelect = "SELECT roles.name, roles.permissions FROM roles WHERE name = $1"
+
+func TestRoleRepositoryFindSuccess(t *testing.T) {
+	name, err := domain.NewRoleName(domain.RoleEditor)
+	require.NoError(t, err)
+
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery(roleSelect).
+		WithArgs(domain.RoleEditor).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "permissions"}).
+			AddRow(domain.RoleEditor, "{tracks_themes:write,descriptions:write}"))
+
+	repo := NewRoleRepository(db, 1*time.Second)
+
+	role, err := repo.Find(context.Background(), name)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	require.NoError(t, err)
+	assert.Equal(t, name, role.Name())
+	assert.True(t, role.Can(domain.PermissionAnnotate))
+	assert.False(t, role.Can(domain.PermissionManageCatalogue))
+}
+
+func TestRoleRepositoryFindNotFound(t *testing.T) {
+	name, err := domain.NewRoleName("unknown")
+	require.NoError(t, err)
+
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery(roleSelect).
+		WithArgs("unknown").
+		WillReturnError(sql.ErrNoRows)
+
+	repo := NewRoleRepository(db, 1*time.Second)
+
+	_, err = repo.Find(context.Background(), name)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.ErrorIs(t, err, domain.ErrRoleNotFound)
+}
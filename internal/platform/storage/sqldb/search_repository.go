@@ -0,0 +1,104 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+)
+
+// Delimiters ts_headline wraps the matching words in. They are control characters, which
+// the catalogue text does not hold, so that the text can be HTML escaped before they are
+// turned into <mark> tags.
+const (
+	headlineStart = "\x02"
+	headlineStop  = "\x03"
+)
+
+// searchQuery ranks the search_vector columns of every searchable table against the term.
+// Matching words are delimited with headlineStart and headlineStop in the snippet. Like
+// the listings, it leaves out the entries in the trash and those whose parents are: the
+// tracks of a trashed movie, and the themes whose group, category, first heard track or
+// its movie is trashed.
+const searchQuery = `WITH search AS (SELECT websearch_to_tsquery('english', $1) AS query)
+SELECT type, id, name, snippet, rank FROM (
+	SELECT 'theme' AS type, themes.id, themes.name,
+		ts_headline('english', concat_ws(' ', themes.name, themes.description), search.query, 'StartSel=` + headlineStart + `, StopSel=` + headlineStop + `, MaxWords=25, MinWords=10') AS snippet,
+		ts_rank(themes.search_vector, search.query) AS rank
+	FROM search, themes
+		JOIN groups ON groups.id = themes.group_id
+		JOIN tracks AS first_heard_tracks ON first_heard_tracks.id = themes.first_heard
+		JOIN movies AS first_heard_movies ON first_heard_movies.id = first_heard_tracks.movie_id
+		LEFT JOIN categories ON categories.id = themes.category_id
+	WHERE themes.search_vector @@ search.query AND themes.deleted_at IS NULL
+		AND groups.deleted_at IS NULL AND categories.deleted_at IS NULL
+		AND first_heard_tracks.deleted_at IS NULL AND first_heard_movies.deleted_at IS NULL
+	UNION ALL
+	SELECT 'track' AS type, tracks.id, tracks.name,
+		ts_headline('english', tracks.name, search.query, 'StartSel=` + headlineStart + `, StopSel=` + headlineStop + `') AS snippet,
+		ts_rank(tracks.search_vector, search.query) AS rank
+	FROM search, tracks
+		JOIN movies ON movies.id = tracks.movie_id
+	WHERE tracks.search_vector @@ search.query AND tracks.deleted_at IS NULL AND movies.deleted_at IS NULL
+	UNION ALL
+	SELECT 'group' AS type, groups.id, groups.name,
+		ts_headline('english', concat_ws(' ', groups.name, groups.description), search.query, 'StartSel=` + headlineStart + `, StopSel=` + headlineStop + `, MaxWords=25, MinWords=10') AS snippet,
+		ts_rank(groups.search_vector, search.query) AS rank
+	FROM groups, search WHERE groups.search_vector @@ search.query AND groups.deleted_at IS NULL
+	UNION ALL
+	SELECT 'movie' AS type, movies.id, movies.name,
+		ts_headline('english', movies.name, search.query, 'StartSel=` + headlineStart + `, StopSel=` + headlineStop + `') AS snippet,
+		ts_rank(movies.search_vector, search.query) AS rank
+	FROM movies, search WHERE movies.search_vector @@ search.query AND movies.deleted_at IS NULL
+) AS results
+ORDER BY rank DESC, name ASC
+LIMIT $2`
+
+// SearchRepository runs full-text searches over the catalogue.
+type SearchRepository struct {
+	db        Executor
+	dbTimeout time.Duration
+}
+
+// NewSearchRepository creates a new SearchRepository.
+func NewSearchRepository(db Executor, dbTimeout time.Duration) *SearchRepository {
+	return &SearchRepository{
+		db:        db,
+		dbTimeout: dbTimeout,
+	}
+}
+
+func (r *SearchRepository) Search(ctx context.Context, term domain.SearchTerm, limit int) ([]domain.SearchResult, error) {
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	rows, err := executorFrom(ctxTimeout, r.db).QueryContext(ctxTimeout, searchQuery, term.String(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search: %v", err)
+	}
+	defer rows.Close()
+
+	var results []domain.SearchResult
+	for rows.Next() {
+		var resultType, id, name, snippet string
+		var rank float64
+		if err := rows.Scan(&resultType, &id, &name, &snippet, &rank); err != nil {
+			return nil, fmt.Errorf("failed to scan search result: %v", err)
+		}
+		results = append(results, domain.NewSearchResult(resultType, id, name, highlight(snippet), rank))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to search: %v", err)
+	}
+
+	return results, nil
+}
+
+// highlight HTML escapes a snippet of ts_headline, then wraps its matching words in <mark>
+// tags, so that the stored text cannot inject markup into the results.
+func highlight(snippet string) string {
+	return strings.NewReplacer(headlineStart, "<mark>", headlineStop, "</mark>").Replace(html.EscapeString(snippet))
+}
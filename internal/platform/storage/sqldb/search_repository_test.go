@@ -0,0 +1,77 @@
+package sqldb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchRepositorySearchError(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery(searchQuery).
+		WithArgs("Shire", 20).
+		WillReturnError(errors.New("query error"))
+
+	repo := NewSearchRepository(db, 1*time.Second)
+
+	term, err := domain.NewSearchTerm("Shire")
+	require.NoError(t, err)
+
+	_, err = repo.Search(context.Background(), term, 20)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
+
+func TestSearchRepositorySearchSuccess(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery(searchQuery).
+		WithArgs("Shire", 20).
+		WillReturnRows(sqlmock.NewRows([]string{"type", "id", "name", "snippet", "rank"}).
+			AddRow("theme", "123e4567-e89b-12d3-a456-426614174000", "The Shire", "The "+headlineStart+"Shire"+headlineStop+" & <b>theme</b>", 0.6).
+			AddRow("group", "6a4f86e4-4fef-4151-9c60-e467007dd213", "The Hobbits", "Music of the "+headlineStart+"Shire"+headlineStop, 0.2))
+
+	repo := NewSearchRepository(db, 1*time.Second)
+
+	term, err := domain.NewSearchTerm("  Shire ")
+	require.NoError(t, err)
+
+	results, err := repo.Search(context.Background(), term, 20)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "theme", results[0].Type())
+	assert.Equal(t, "The <mark>Shire</mark> &amp; &lt;b&gt;theme&lt;/b&gt;", results[0].Snippet())
+	assert.Equal(t, 0.6, results[0].Rank())
+	assert.Equal(t, "group", results[1].Type())
+}
+
+func TestSearchRepositorySearchRowsError(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery(searchQuery).
+		WithArgs("Shire", 20).
+		WillReturnRows(sqlmock.NewRows([]string{"type", "id", "name", "snippet", "rank"}).
+			AddRow("theme", "123e4567-e89b-12d3-a456-426614174000", "The Shire", "The "+headlineStart+"Shire"+headlineStop, 0.6).
+			AddRow("group", "6a4f86e4-4fef-4151-9c60-e467007dd213", "The Hobbits", "Music of the "+headlineStart+"Shire"+headlineStop, 0.2).
+			RowError(1, errors.New("connection reset")))
+
+	repo := NewSearchRepository(db, 1*time.Second)
+
+	term, err := domain.NewSearchTerm("Shire")
+	require.NoError(t, err)
+
+	_, err = repo.Search(context.Background(), term, 20)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
@@ -0,0 +1,203 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+type SessionDB struct {
+	ID                string     `db:"id"`
+	UserID            string     `db:"user_id"`
+	RefreshTokenHash  string     `db:"refresh_token_hash"`
+	PreviousTokenHash *string    `db:"previous_token_hash"`
+	ExpiresAt         time.Time  `db:"expires_at"`
+	RevokedAt         *time.Time `db:"revoked_at"`
+}
+
+var sqlSessionTable = "sessions"
+var sessionSQLStruct = sqlbuilder.NewStruct(new(SessionDB)).For(defaultFlavor)
+
+// SessionRepository implements the SessionRepository interface for SQL.
+type SessionRepository struct {
+	db        Executor
+	dbTimeout time.Duration
+}
+
+// NewSessionRepository creates a new SessionRepository.
+func NewSessionRepository(db Executor, dbTimeout time.Duration) *SessionRepository {
+	return &SessionRepository{
+		db:        db,
+		dbTimeout: dbTimeout,
+	}
+}
+
+func sessionToDTO(session domain.Session) SessionDB {
+	var revokedAt *time.Time
+	if at := session.RevokedAt(); !at.IsZero() {
+		revokedAt = &at
+	}
+
+	return SessionDB{
+		ID:                session.ID().String(),
+		UserID:            session.UserID().String(),
+		RefreshTokenHash:  session.TokenHash(),
+		PreviousTokenHash: nullString(session.PreviousTokenHash()),
+		ExpiresAt:         session.ExpiresAt(),
+		RevokedAt:         revokedAt,
+	}
+}
+
+func sessionToDomain(dto SessionDB) (domain.Session, error) {
+	var previousTokenHash string
+	if dto.PreviousTokenHash != nil {
+		previousTokenHash = *dto.PreviousTokenHash
+	}
+	var revokedAt time.Time
+	if dto.RevokedAt != nil {
+		revokedAt = *dto.RevokedAt
+	}
+
+	return domain.NewSessionWithID(dto.ID, dto.UserID, dto.RefreshTokenHash, previousTokenHash, dto.ExpiresAt, revokedAt)
+}
+
+func (r *SessionRepository) Save(ctx context.Context, session domain.Session) error {
+	query, args := sessionSQLStruct.InsertInto(sqlSessionTable, sessionToDTO(session)).Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	_, err := executorFrom(ctxTimeout, r.db).ExecContext(ctxTimeout, query, args...)
+	if err != nil {
+		if constraintErr := writeError(err); constraintErr != nil {
+			return constraintErr
+		}
+		return fmt.Errorf("failed to save session: %v", err)
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) FindByTokenHash(ctx context.Context, tokenHash string) (domain.Session, error) {
+	sb := sessionSQLStruct.SelectFrom(sqlSessionTable)
+	sb.Where(sb.Or(
+		sb.Equal("refresh_token_hash", tokenHash),
+		sb.Equal("previous_token_hash", tokenHash),
+	))
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	var sessionDTO SessionDB
+	err := executorFrom(ctxTimeout, r.db).QueryRowContext(ctxTimeout, query, args...).Scan(sessionSQLStruct.Addr(&sessionDTO)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Session{}, domain.ErrSessionNotFound
+	}
+	if err != nil {
+		return domain.Session{}, fmt.Errorf("failed to find session: %v", err)
+	}
+
+	return sessionToDomain(sessionDTO)
+}
+
+func (r *SessionRepository) Rotate(ctx context.Context, session domain.Session) error {
+	ub := defaultFlavor.NewUpdateBuilder()
+	ub.Update(sqlSessionTable)
+	ub.Set(
+		ub.Assign("refresh_token_hash", session.TokenHash()),
+		ub.Assign("previous_token_hash", session.PreviousTokenHash()),
+		ub.Assign("expires_at", session.ExpiresAt()),
+	)
+	ub.Where(
+		ub.Equal("id", session.ID().String()),
+		ub.Equal("refresh_token_hash", session.PreviousTokenHash()),
+		ub.IsNull("revoked_at"),
+	)
+	query, args := ub.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	result, err := executorFrom(ctxTimeout, r.db).ExecContext(ctxTimeout, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to rotate session: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %v", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrInvalidRefreshToken
+	}
+
+	return nil
+}
+
+// Revoke revokes the session. Revoking a session twice keeps the time of the first
+// revocation.
+func (r *SessionRepository) Revoke(ctx context.Context, id domain.SessionID, revokedAt time.Time) error {
+	ub := defaultFlavor.NewUpdateBuilder()
+	ub.Update(sqlSessionTable)
+	ub.Set(ub.Assign("revoked_at", revokedAt))
+	ub.Where(ub.Equal("id", id.String()), ub.IsNull("revoked_at"))
+	query, args := ub.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	_, err := executorFrom(ctxTimeout, r.db).ExecContext(ctxTimeout, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %v", err)
+	}
+
+	return nil
+}
+
+// RevokeByUser revokes the active sessions of the user but except.
+func (r *SessionRepository) RevokeByUser(ctx context.Context, userID domain.UserID, except domain.SessionID, revokedAt time.Time) error {
+	ub := defaultFlavor.NewUpdateBuilder()
+	ub.Update(sqlSessionTable)
+	ub.Set(ub.Assign("revoked_at", revokedAt))
+	ub.Where(ub.Equal("user_id", userID.String()), ub.IsNull("revoked_at"))
+	if except != (domain.SessionID{}) {
+		ub.Where(ub.NotEqual("id", except.String()))
+	}
+	query, args := ub.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	_, err := executorFrom(ctxTimeout, r.db).ExecContext(ctxTimeout, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to revoke sessions: %v", err)
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) IsRevoked(ctx context.Context, id domain.SessionID) (bool, error) {
+	sb := defaultFlavor.NewSelectBuilder()
+	sb.Select("revoked_at IS NOT NULL").From(sqlSessionTable).Where(sb.Equal("id", id.String()))
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	var revoked bool
+	err := executorFrom(ctxTimeout, r.db).QueryRowContext(ctxTimeout, query, args...).Scan(&revoked)
+	if errors.Is(err, sql.ErrNoRows) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check session revocation: %v", err)
+	}
+
+	return revoked, nil
+}
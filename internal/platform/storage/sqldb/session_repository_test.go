@@ -0,0 +1,223 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	sessionID         = "3f2b8c1d-6e4a-4b7f-9d2c-8a1e5f3b7c90"
+	sessionUserID     = "28712a55-04dd-4200-9316-4d6a1e399121"
+	sessionTokenHash  = "4f53cda18c2baa0c0354bb5f9a3ecbe5ed12ab4d8e11ba873c2f11161202b945"
+	sessionRotatedTo  = "b5bb9d8014a0f9b1d61e21e796d78dccdf1352f23cd32812f4850b878ae4944c"
+	sessionInsert     = "INSERT INTO sessions (id, user_id, refresh_token_hash, previous_token_hash, expires_at, revoked_at) VALUES ($1, $2, $3, $4, $5, $6)"
+	sessionRotate     = "UPDATE sessions SET refresh_token_hash = $1, previous_token_hash = $2, expires_at = $3 WHERE id = $4 AND refresh_token_hash = $5 AND revoked_at IS NULL"
+	sessionSelectHash = "SELECT sessions.id, sessions.user_id, sessions.refresh_token_hash, sessions.previous_token_hash, sessions.expires_at, sessions.revoked_at FROM sessions WHERE (refresh_token_hash = $1 OR previous_token_hash = $2)"
+)
+
+var sessionColumns = []string{"id", "user_id", "refresh_token_hash", "previous_token_hash", "expires_at", "revoked_at"}
+
+func TestSessionRepositorySaveSuccess(t *testing.T) {
+	expiresAt := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+	session, err := domain.NewSessionWithID(sessionID, sessionUserID, sessionTokenHash, "", expiresAt, time.Time{})
+	require.NoError(t, err)
+
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec(sessionInsert).
+		WithArgs(sessionID, sessionUserID, sessionTokenHash, nil, expiresAt, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := NewSessionRepository(db, 1*time.Second)
+
+	err = repo.Save(context.Background(), session)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
+
+func TestSessionRepositorySaveUserNotFound(t *testing.T) {
+	session, err := domain.NewSessionWithID(sessionID, sessionUserID, sessionTokenHash, "", time.Now().Add(time.Hour), time.Time{})
+	require.NoError(t, err)
+
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec(sessionInsert).
+		WillReturnError(&pq.Error{Code: "23503", Constraint: "sessions_user_id_fkey"})
+
+	repo := NewSessionRepository(db, 1*time.Second)
+
+	err = repo.Save(context.Background(), session)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func TestSessionRepositoryFindByTokenHashSuccess(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	expiresAt := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+	revokedAt := expiresAt.Add(-time.Hour)
+	sqlMock.ExpectQuery(sessionSelectHash).
+		WithArgs(sessionTokenHash, sessionTokenHash).
+		WillReturnRows(sqlmock.NewRows(sessionColumns).
+			AddRow(sessionID, sessionUserID, sessionRotatedTo, sessionTokenHash, expiresAt, revokedAt))
+
+	repo := NewSessionRepository(db, 1*time.Second)
+
+	session, err := repo.FindByTokenHash(context.Background(), sessionTokenHash)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	require.NoError(t, err)
+	assert.Equal(t, sessionRotatedTo, session.TokenHash())
+	assert.Equal(t, sessionTokenHash, session.PreviousTokenHash())
+	assert.Equal(t, revokedAt, session.RevokedAt())
+	assert.False(t, session.Active(revokedAt.Add(-time.Minute)))
+}
+
+func TestSessionRepositoryFindByTokenHashNotFound(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery(sessionSelectHash).
+		WithArgs(sessionTokenHash, sessionTokenHash).
+		WillReturnError(sql.ErrNoRows)
+
+	repo := NewSessionRepository(db, 1*time.Second)
+
+	_, err = repo.FindByTokenHash(context.Background(), sessionTokenHash)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.ErrorIs(t, err, domain.ErrSessionNotFound)
+}
+
+func TestSessionRepositoryRotate(t *testing.T) {
+	expiresAt := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+	session, err := domain.NewSessionWithID(sessionID, sessionUserID, sessionTokenHash, "", expiresAt, time.Time{})
+	require.NoError(t, err)
+	rotated, err := session.Rotate(sessionRotatedTo, expiresAt.Add(time.Hour))
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		rowsAffected int64
+		expected     error
+	}{
+		"rotates the current token":     {rowsAffected: 1},
+		"rejects a token rotated since": {rowsAffected: 0, expected: domain.ErrInvalidRefreshToken},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			require.NoError(t, err)
+
+			sqlMock.ExpectExec(sessionRotate).
+				WithArgs(sessionRotatedTo, sessionTokenHash, expiresAt.Add(time.Hour), sessionID, sessionTokenHash).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+
+			repo := NewSessionRepository(db, 1*time.Second)
+
+			err = repo.Rotate(context.Background(), rotated)
+			assert.NoError(t, sqlMock.ExpectationsWereMet())
+			if tt.expected != nil {
+				assert.ErrorIs(t, err, tt.expected)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSessionRepositoryRevokeError(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	revokedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	sqlMock.ExpectExec("UPDATE sessions SET revoked_at = $1 WHERE id = $2 AND revoked_at IS NULL").
+		WithArgs(revokedAt, sessionID).
+		WillReturnError(errors.New("update error"))
+
+	repo := NewSessionRepository(db, 1*time.Second)
+
+	id, err := domain.NewSessionIDFromString(sessionID)
+	require.NoError(t, err)
+
+	err = repo.Revoke(context.Background(), id, revokedAt)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
+
+func TestSessionRepositoryRevokeByUser(t *testing.T) {
+	revokedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	userID, err := domain.NewUserIDFromString(sessionUserID)
+	require.NoError(t, err)
+	except, err := domain.NewSessionIDFromString(sessionID)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		except domain.SessionID
+		query  string
+		args   []driver.Value
+	}{
+		"all":         {query: "UPDATE sessions SET revoked_at = $1 WHERE user_id = $2 AND revoked_at IS NULL", args: []driver.Value{revokedAt, sessionUserID}},
+		"all but one": {except: except, query: "UPDATE sessions SET revoked_at = $1 WHERE user_id = $2 AND revoked_at IS NULL AND id <> $3", args: []driver.Value{revokedAt, sessionUserID, sessionID}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			require.NoError(t, err)
+
+			sqlMock.ExpectExec(tt.query).
+				WithArgs(tt.args...).
+				WillReturnResult(sqlmock.NewResult(0, 2))
+
+			repo := NewSessionRepository(db, 1*time.Second)
+
+			err = repo.RevokeByUser(context.Background(), userID, tt.except, revokedAt)
+			assert.NoError(t, sqlMock.ExpectationsWereMet())
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSessionRepositoryIsRevoked(t *testing.T) {
+	tests := map[string]struct {
+		rows     *sqlmock.Rows
+		expected bool
+	}{
+		"active":  {rows: sqlmock.NewRows([]string{"?column?"}).AddRow(false), expected: false},
+		"revoked": {rows: sqlmock.NewRows([]string{"?column?"}).AddRow(true), expected: true},
+		"missing": {rows: sqlmock.NewRows([]string{"?column?"}), expected: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			require.NoError(t, err)
+
+			sqlMock.ExpectQuery("SELECT revoked_at IS NOT NULL FROM sessions WHERE id = $1").
+				WithArgs(sessionID).
+				WillReturnRows(tt.rows)
+
+			repo := NewSessionRepository(db, 1*time.Second)
+
+			id, err := domain.NewSessionIDFromString(sessionID)
+			require.NoError(t, err)
+
+			revoked, err := repo.IsRevoked(context.Background(), id)
+			assert.NoError(t, sqlMock.ExpectationsWereMet())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, revoked)
+		})
+	}
+}
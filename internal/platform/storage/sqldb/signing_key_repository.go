@@ -0,0 +1,109 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+type SigningKeyDB struct {
+	ID          string    `db:"id"`
+	Algorithm   string    `db:"algorithm"`
+	PrivateKey  []byte    `db:"private_key"`
+	ActivatesAt time.Time `db:"activates_at"`
+}
+
+var sqlSigningKeyTable = "signing_keys"
+var signingKeySQLStruct = sqlbuilder.NewStruct(new(SigningKeyDB)).For(defaultFlavor)
+
+// SigningKeyRepository implements the SigningKeyRepository interface for SQL.
+type SigningKeyRepository struct {
+	db        Executor
+	dbTimeout time.Duration
+}
+
+// NewSigningKeyRepository creates a new SigningKeyRepository.
+func NewSigningKeyRepository(db Executor, dbTimeout time.Duration) *SigningKeyRepository {
+	return &SigningKeyRepository{
+		db:        db,
+		dbTimeout: dbTimeout,
+	}
+}
+
+func signingKeyToDTO(key domain.SigningKey) SigningKeyDB {
+	return SigningKeyDB{
+		ID:          key.ID(),
+		Algorithm:   key.Algorithm().String(),
+		PrivateKey:  key.PrivateKey(),
+		ActivatesAt: key.ActivatesAt(),
+	}
+}
+
+func signingKeyToDomain(dto SigningKeyDB) (domain.SigningKey, error) {
+	return domain.NewSigningKey(dto.ID, domain.SigningAlgorithm(dto.Algorithm), dto.PrivateKey, dto.ActivatesAt)
+}
+
+func (r *SigningKeyRepository) Save(ctx context.Context, key domain.SigningKey) error {
+	query, args := signingKeySQLStruct.InsertInto(sqlSigningKeyTable, signingKeyToDTO(key)).Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	_, err := executorFrom(ctxTimeout, r.db).ExecContext(ctxTimeout, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to save signing key: %v", err)
+	}
+
+	return nil
+}
+
+// FindAll returns every key, the one that activates first first.
+func (r *SigningKeyRepository) FindAll(ctx context.Context) ([]domain.SigningKey, error) {
+	sb := signingKeySQLStruct.SelectFrom(sqlSigningKeyTable)
+	sb.OrderBy("activates_at", "id")
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	rows, err := executorFrom(ctxTimeout, r.db).QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find signing keys: %v", err)
+	}
+	defer rows.Close()
+
+	var keys []domain.SigningKey
+	for rows.Next() {
+		var keyDTO SigningKeyDB
+		if err := rows.Scan(signingKeySQLStruct.Addr(&keyDTO)...); err != nil {
+			return nil, fmt.Errorf("failed to scan signing key: %v", err)
+		}
+		key, err := signingKeyToDomain(keyDTO)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert signing key: %v", err)
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// Delete deletes the key with the given ID. Deleting a key that does not exist is a no-op.
+func (r *SigningKeyRepository) Delete(ctx context.Context, id string) error {
+	db := defaultFlavor.NewDeleteBuilder()
+	db.DeleteFrom(sqlSigningKeyTable).Where(db.Equal("id", id))
+	query, args := db.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	_, err := executorFrom(ctxTimeout, r.db).ExecContext(ctxTimeout, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete signing key: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package sqldb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const signingKeyID = "c2b6f0f4-5d0e-4c3a-9a36-1f1d2f0f6f0e"
+
+var signingKeyPrivate = []byte{0x30, 0x2e, 0x02, 0x01, 0x00}
+
+func TestSigningKeyRepositorySave(t *testing.T) {
+	activatesAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	key, err := domain.NewSigningKey(signingKeyID, domain.SigningAlgorithmEdDSA, signingKeyPrivate, activatesAt)
+	require.NoError(t, err)
+
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec("INSERT INTO signing_keys (id, algorithm, private_key, activates_at) VALUES ($1, $2, $3, $4)").
+		WithArgs(signingKeyID, "EdDSA", signingKeyPrivate, activatesAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := NewSigningKeyRepository(db, 1*time.Second)
+
+	err = repo.Save(context.Background(), key)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
+
+func TestSigningKeyRepositoryFindAll(t *testing.T) {
+	activatesAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery("SELECT signing_keys.id, signing_keys.algorithm, signing_keys.private_key, signing_keys.activates_at FROM signing_keys ORDER BY activates_at, id").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "algorithm", "private_key", "activates_at"}).
+			AddRow(signingKeyID, "RS256", signingKeyPrivate, activatesAt))
+
+	repo := NewSigningKeyRepository(db, 1*time.Second)
+
+	keys, err := repo.FindAll(context.Background())
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, signingKeyID, keys[0].ID())
+	assert.Equal(t, domain.SigningAlgorithmRS256, keys[0].Algorithm())
+	assert.Equal(t, activatesAt, keys[0].ActivatesAt())
+}
+
+func TestSigningKeyRepositoryFindAllError(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery("SELECT signing_keys.id, signing_keys.algorithm, signing_keys.private_key, signing_keys.activates_at FROM signing_keys ORDER BY activates_at, id").
+		WillReturnError(errors.New("select error"))
+
+	repo := NewSigningKeyRepository(db, 1*time.Second)
+
+	_, err = repo.FindAll(context.Background())
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
+
+func TestSigningKeyRepositoryDelete(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec("DELETE FROM signing_keys WHERE id = $1").
+		WithArgs(signingKeyID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := NewSigningKeyRepository(db, 1*time.Second)
+
+	err = repo.Delete(context.Background(), signingKeyID)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
@@ -0,0 +1,54 @@
+package sqldb
+
+import (
+	"strings"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// filterThemes adds the criteria of the filter to a select from themes.
+func filterThemes(sb *sqlbuilder.SelectBuilder, filter domain.ThemeFilter) {
+	if groupID := filter.GroupID(); groupID != nil {
+		sb.Where(sb.Equal("themes.group_id", groupID.String()))
+	}
+
+	if categoryID := filter.CategoryID(); categoryID != nil {
+		sb.Where(sb.Equal("themes.category_id", categoryID.String()))
+	}
+
+	if movieID := filter.MovieID(); movieID != nil {
+		tracks := defaultFlavor.NewSelectBuilder()
+		tracks.Select("tracks.id").From(sqlTrackTable)
+		tracks.Where(tracks.Equal("tracks.movie_id", movieID.String()))
+		sb.Where(sb.In("themes.first_heard", tracks))
+	}
+
+	if name := filter.Name(); name != "" {
+		sb.Where(sb.ILike("themes.name", "%"+likeEscaper.Replace(name)+"%"))
+	}
+}
+
+// themeSortColumn returns the keyset column a list of themes is paginated by.
+func themeSortColumn(sort domain.ThemeSort) keysetColumn {
+	switch sort {
+	case domain.ThemeSortName:
+		return keysetColumn{
+			name:     "themes.name",
+			parseKey: func(key string) (any, error) { return key, nil },
+		}
+	case domain.ThemeSortFirstHeardStart:
+		return intColumn("themes.first_heard_start")
+	default:
+		return createdAtColumn(sqlThemeTable)
+	}
+}
+
+// paginateThemes filters a select from themes and restricts it to the requested page,
+// sorted as the filter asks.
+func paginateThemes(sb *sqlbuilder.SelectBuilder, filter domain.ThemeFilter, page domain.PageRequest) error {
+	filterThemes(sb, filter)
+	return paginateBy(sb, sqlThemeTable, themeSortColumn(filter.Sort()), page)
+}
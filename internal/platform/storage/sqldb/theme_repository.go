@@ -22,21 +22,15 @@ type ThemeDB struct {
 	CategoryID      *string `db:"category_id"`
 }
 
-var themeFKMap = map[string]error{
-	"themes_category_id_fkey": domain.ErrCategoryNotFound,
-	"themes_group_id_fkey":    domain.ErrGroupNotFound,
-	"themes_first_heard_fkey": domain.ErrCategoryNotFound,
-}
-
 var sqlThemeTable = "themes"
 var themeSQLStruct = sqlbuilder.NewStruct(new(ThemeDB)).For(defaultFlavor)
 
 type ThemeRepository struct {
-	db        *sql.DB
+	db        Executor
 	dbTimeout time.Duration
 }
 
-func NewThemeRepository(db *sql.DB, timeout time.Duration) *ThemeRepository {
+func NewThemeRepository(db Executor, timeout time.Duration) *ThemeRepository {
 	return &ThemeRepository{
 		db:        db,
 		dbTimeout: timeout,
@@ -82,16 +76,15 @@ func (r *ThemeRepository) Save(ctx context.Context, theme domain.Theme) error {
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	_, err := r.db.ExecContext(ctxTimeout, query, args...)
+	if err := r.requireParents(ctxTimeout, row); err != nil {
+		return err
+	}
+
+	_, err := executorFrom(ctxTimeout, r.db).ExecContext(ctxTimeout, query, args...)
 	if err != nil {
-		constraint := extractConstraintName(err)
-		err = mapSQLError(extractSQLErrorCode(err))
-		if errors.Is(err, ErrForeignKeyViolation) {
-			if fkErr, ok := themeFKMap[constraint]; ok {
-				return fkErr
-			}
+		if constraintErr := writeError(err); constraintErr != nil {
+			return constraintErr
 		}
-
 		return fmt.Errorf("failed to save theme: %v", err)
 	}
 
@@ -99,14 +92,25 @@ func (r *ThemeRepository) Save(ctx context.Context, theme domain.Theme) error {
 }
 
 func (r *ThemeRepository) Find(ctx context.Context, id domain.ThemeID) (domain.Theme, error) {
+	return r.find(ctx, id, notDeleted)
+}
+
+// FindInTrash finds the theme only if it is in the trash.
+func (r *ThemeRepository) FindInTrash(ctx context.Context, id domain.ThemeID) (domain.Theme, error) {
+	return r.find(ctx, id, inTrash)
+}
+
+// find finds the theme, provided it passes the trash filter.
+func (r *ThemeRepository) find(ctx context.Context, id domain.ThemeID, filter func(*sqlbuilder.SelectBuilder, string)) (domain.Theme, error) {
 	sb := themeSQLStruct.SelectFrom(sqlThemeTable)
+	filter(sb, sqlThemeTable)
 	sb.Where(sb.Equal("id", id.String()))
 	query, args := sb.Build()
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
 	var themeDTO ThemeDB
-	err := r.db.QueryRowContext(ctxTimeout, query, args...).Scan(themeSQLStruct.Addr(&themeDTO)...)
+	err := executorFrom(ctxTimeout, r.db).QueryRowContext(ctxTimeout, query, args...).Scan(themeSQLStruct.Addr(&themeDTO)...)
 	if errors.Is(err, sql.ErrNoRows) {
 		return domain.Theme{}, domain.ErrThemeNotFound
 	}
@@ -119,13 +123,14 @@ func (r *ThemeRepository) Find(ctx context.Context, id domain.ThemeID) (domain.T
 
 func (r *ThemeRepository) FindAll(ctx context.Context) ([]domain.Theme, error) {
 	sb := themeSQLStruct.SelectFrom(sqlThemeTable)
+	notDeleted(sb, sqlThemeTable)
 	sb.OrderBy("created_at ASC")
 	query, args := sb.Build()
 
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	rows, err := r.db.QueryContext(ctxTimeout, query, args...)
+	rows, err := executorFrom(ctxTimeout, r.db).QueryContext(ctxTimeout, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find all themes: %v", err)
 	}
@@ -149,18 +154,114 @@ func (r *ThemeRepository) FindAll(ctx context.Context) ([]domain.Theme, error) {
 	return themes, nil
 }
 
+func (r *ThemeRepository) FindPage(ctx context.Context, filter domain.ThemeFilter, page domain.PageRequest) ([]domain.Theme, *domain.Cursor, error) {
+	sb := themeSQLStruct.SelectFrom(sqlThemeTable)
+	notDeleted(sb, sqlThemeTable)
+	if err := paginateThemes(sb, filter, page); err != nil {
+		return nil, nil, err
+	}
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	rows, err := executorFrom(ctxTimeout, r.db).QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find themes page: %v", err)
+	}
+	defer rows.Close()
+
+	var themes []domain.Theme
+	var keys []string
+	for rows.Next() {
+		var themeDTO ThemeDB
+		var key any
+		if err := rows.Scan(append(themeSQLStruct.Addr(&themeDTO), &key)...); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan theme: %v", err)
+		}
+		theme, err := themeToDomain(themeDTO)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to convert theme: %v", err)
+		}
+		themes = append(themes, theme)
+		keys = append(keys, keyOf(key))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to find themes page: %v", err)
+	}
+
+	themes, next := pageOf(themes, keys, page, func(t domain.Theme) string { return t.ID().String() })
+	return themes, next, nil
+}
+
 func (r *ThemeRepository) FindByGroup(ctx context.Context, groupID domain.GroupID) ([]domain.Theme, error) {
 	sb := themeSQLStruct.SelectFrom(sqlThemeTable)
+	notDeleted(sb, sqlThemeTable)
 	sb.Where(sb.Equal("group_id", groupID.String()))
 	sb.OrderBy("created_at ASC")
+
+	themes, err := r.findThemes(ctx, sb)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find themes by group: %v", err)
+	}
+	return themes, nil
+}
+
+func (r *ThemeRepository) FindByCategory(ctx context.Context, categoryID domain.CategoryID) ([]domain.Theme, error) {
+	sb := themeSQLStruct.SelectFrom(sqlThemeTable)
+	notDeleted(sb, sqlThemeTable)
+	sb.Where(sb.Equal("category_id", categoryID.String()))
+	sb.OrderBy("created_at ASC")
+
+	themes, err := r.findThemes(ctx, sb)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find themes by category: %v", err)
+	}
+	return themes, nil
+}
+
+func (r *ThemeRepository) FindByFirstHeard(ctx context.Context, trackID domain.TrackID) ([]domain.Theme, error) {
+	sb := themeSQLStruct.SelectFrom(sqlThemeTable)
+	notDeleted(sb, sqlThemeTable)
+	sb.Where(sb.Equal("first_heard", trackID.String()))
+	sb.OrderBy("created_at ASC")
+
+	themes, err := r.findThemes(ctx, sb)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find themes by first heard track: %v", err)
+	}
+	return themes, nil
+}
+
+// FindByMovie returns the themes first heard in a track of the movie.
+func (r *ThemeRepository) FindByMovie(ctx context.Context, movieID domain.MovieID) ([]domain.Theme, error) {
+	filter, err := domain.NewThemeFilter("", "", movieID.String(), "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	sb := themeSQLStruct.SelectFrom(sqlThemeTable)
+	notDeleted(sb, sqlThemeTable)
+	filterThemes(sb, filter)
+	sb.OrderBy("created_at ASC")
+
+	themes, err := r.findThemes(ctx, sb)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find themes by movie: %v", err)
+	}
+	return themes, nil
+}
+
+// findThemes runs a select of every theme column and converts the rows read.
+func (r *ThemeRepository) findThemes(ctx context.Context, sb *sqlbuilder.SelectBuilder) ([]domain.Theme, error) {
 	query, args := sb.Build()
 
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	rows, err := r.db.QueryContext(ctxTimeout, query, args...)
+	rows, err := executorFrom(ctxTimeout, r.db).QueryContext(ctxTimeout, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find themes by group: %v", err)
+		return nil, err
 	}
 	defer rows.Close()
 
@@ -182,25 +283,57 @@ func (r *ThemeRepository) FindByGroup(ctx context.Context, groupID domain.GroupI
 	return themes, nil
 }
 
+// Delete moves the theme to the trash.
 func (r *ThemeRepository) Delete(ctx context.Context, id domain.ThemeID) error {
-	sb := themeSQLStruct.DeleteFrom(sqlThemeTable)
-	sb.Where(sb.Equal("id", id.String()))
-	query, args := sb.Build()
+	query, args := moveToTrashQuery(sqlThemeTable, id.String())
 
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	result, err := r.db.ExecContext(ctxTimeout, query, args...)
+	found, err := execOnRow(ctxTimeout, r.db, query, args)
 	if err != nil {
 		return fmt.Errorf("failed to delete theme: %v", err)
 	}
+	if !found {
+		return domain.ErrThemeNotFound
+	}
 
-	rowsAffected, err := result.RowsAffected()
+	return nil
+}
+
+// Restore takes the theme out of the trash.
+func (r *ThemeRepository) Restore(ctx context.Context, id domain.ThemeID) error {
+	query, args := restoreQuery(sqlThemeTable, id.String())
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	found, err := execOnRow(ctxTimeout, r.db, query, args)
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %v", err)
+		return fmt.Errorf("failed to restore theme: %v", err)
+	}
+	if !found {
+		return domain.ErrThemeNotFound
 	}
 
-	if rowsAffected == 0 {
+	return nil
+}
+
+// Purge deletes the theme for good, provided it is in the trash.
+func (r *ThemeRepository) Purge(ctx context.Context, id domain.ThemeID) error {
+	query, args := purgeQuery(sqlThemeTable, id.String())
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	found, err := execOnRow(ctxTimeout, r.db, query, args)
+	if err != nil {
+		if constraintErr := deleteError(err); constraintErr != nil {
+			return constraintErr
+		}
+		return fmt.Errorf("failed to purge theme: %v", err)
+	}
+	if !found {
 		return domain.ErrThemeNotFound
 	}
 
@@ -210,14 +343,21 @@ func (r *ThemeRepository) Delete(ctx context.Context, id domain.ThemeID) error {
 func (r *ThemeRepository) Update(ctx context.Context, theme domain.Theme) error {
 	row := themeToDTO(theme)
 	sb := themeSQLStruct.Update(sqlThemeTable, row)
-	sb.Where(sb.Equal("id", row.ID))
+	sb.Where(sb.Equal("id", row.ID), sb.IsNull("deleted_at"))
 	query, args := sb.Build()
 
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	result, err := r.db.ExecContext(ctxTimeout, query, args...)
+	if err := r.requireParents(ctxTimeout, row); err != nil {
+		return err
+	}
+
+	result, err := executorFrom(ctxTimeout, r.db).ExecContext(ctxTimeout, query, args...)
 	if err != nil {
+		if constraintErr := writeError(err); constraintErr != nil {
+			return constraintErr
+		}
 		return fmt.Errorf("failed to update theme: %v", err)
 	}
 
@@ -232,3 +372,17 @@ func (r *ThemeRepository) Update(ctx context.Context, theme domain.Theme) error
 
 	return nil
 }
+
+// requireParents checks that the group, the category and the first heard track the theme
+// points to are not in the trash.
+func (r *ThemeRepository) requireParents(ctx context.Context, row ThemeDB) error {
+	if err := requireLive(ctx, r.db, sqlGroupTable, row.GroupID, domain.ErrGroupNotFound); err != nil {
+		return err
+	}
+	if row.CategoryID != nil {
+		if err := requireLive(ctx, r.db, sqlCategoryTable, *row.CategoryID, domain.ErrCategoryNotFound); err != nil {
+			return err
+		}
+	}
+	return requireLive(ctx, r.db, sqlTrackTable, row.FirstHeard, domain.ErrTrackNotFound)
+}
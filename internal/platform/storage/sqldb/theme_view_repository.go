@@ -0,0 +1,201 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+const (
+	firstHeardTrackAlias = "first_heard_tracks"
+	firstHeardMovieAlias = "first_heard_movies"
+)
+
+// themeView is a theme row joined with its group, category, first heard track and movie.
+type themeView struct {
+	ID               string
+	Name             string
+	Description      string
+	FirstHeardStart  int
+	FirstHeardEnd    int
+	GroupID          string
+	GroupName        string
+	GroupDescription string
+	GroupImageURL    string
+	CategoryID       *string
+	CategoryName     *string
+	FirstHeard       trackView
+}
+
+// themeViewColumns returns the columns scanned by themeView.addr. They must be read from
+// a select built with joinThemeView.
+func themeViewColumns() []string {
+	columns := []string{
+		"themes.id",
+		"themes.name",
+		"themes.description",
+		"themes.first_heard_start",
+		"themes.first_heard_end",
+		"groups.id",
+		"groups.name",
+		"groups.description",
+		"groups.image_url",
+		"categories.id",
+		"categories.name",
+	}
+	return append(columns, trackViewColumns(firstHeardTrackAlias, firstHeardMovieAlias)...)
+}
+
+// joinThemeView joins the tables read by themeViewColumns to a select from themes.
+func joinThemeView(sb *sqlbuilder.SelectBuilder) {
+	sb.Join(sqlGroupTable, "groups.id = themes.group_id")
+	sb.Join(sqlTrackTable+" AS "+firstHeardTrackAlias, firstHeardTrackAlias+".id = themes.first_heard")
+	sb.Join(sqlMovieTable+" AS "+firstHeardMovieAlias, firstHeardMovieAlias+".id = "+firstHeardTrackAlias+".movie_id")
+	sb.JoinWithOption(sqlbuilder.LeftJoin, sqlCategoryTable, "categories.id = themes.category_id")
+}
+
+// notDeletedThemeView restricts a select built with joinThemeView to the themes whose
+// group, category, first heard track and its movie are not in the trash, like the theme
+// itself. A theme without category passes, its left joined columns being null.
+func notDeletedThemeView(sb *sqlbuilder.SelectBuilder) {
+	notDeleted(sb, sqlThemeTable)
+	notDeleted(sb, sqlGroupTable)
+	notDeleted(sb, sqlCategoryTable)
+	notDeleted(sb, firstHeardTrackAlias)
+	notDeleted(sb, firstHeardMovieAlias)
+}
+
+func (v *themeView) addr() []any {
+	addr := []any{
+		&v.ID,
+		&v.Name,
+		&v.Description,
+		&v.FirstHeardStart,
+		&v.FirstHeardEnd,
+		&v.GroupID,
+		&v.GroupName,
+		&v.GroupDescription,
+		&v.GroupImageURL,
+		&v.CategoryID,
+		&v.CategoryName,
+	}
+	return append(addr, v.FirstHeard.addr()...)
+}
+
+func (v themeView) toResponse() dto.ThemeResponse {
+	var category *dto.CategoryResponse
+	if v.CategoryID != nil && v.CategoryName != nil {
+		category = &dto.CategoryResponse{
+			ID:   *v.CategoryID,
+			Name: *v.CategoryName,
+		}
+	}
+
+	return dto.ThemeResponse{
+		ID:         v.ID,
+		Name:       v.Name,
+		FirstHeard: v.FirstHeard.toResponse(),
+		Group: dto.GroupResponse{
+			ID:          v.GroupID,
+			Name:        v.GroupName,
+			Description: v.GroupDescription,
+			ImageURL:    v.GroupImageURL,
+		},
+		Description:     v.Description,
+		FirstHeardStart: v.FirstHeardStart,
+		FirstHeardEnd:   v.FirstHeardEnd,
+		Category:        category,
+	}
+}
+
+// ThemeViewRepository reads themes and their related entities with a single query.
+type ThemeViewRepository struct {
+	db        Executor
+	dbTimeout time.Duration
+}
+
+// NewThemeViewRepository creates a new ThemeViewRepository.
+func NewThemeViewRepository(db Executor, dbTimeout time.Duration) *ThemeViewRepository {
+	return &ThemeViewRepository{
+		db:        db,
+		dbTimeout: dbTimeout,
+	}
+}
+
+func selectThemeView() *sqlbuilder.SelectBuilder {
+	sb := defaultFlavor.NewSelectBuilder()
+	sb.Select(themeViewColumns()...)
+	sb.From(sqlThemeTable)
+	joinThemeView(sb)
+	notDeletedThemeView(sb)
+	return sb
+}
+
+func (r *ThemeViewRepository) FindPage(ctx context.Context, filter domain.ThemeFilter, page domain.PageRequest) ([]dto.ThemeResponse, *domain.Cursor, error) {
+	sb := selectThemeView()
+	if err := paginateThemes(sb, filter, page); err != nil {
+		return nil, nil, err
+	}
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	rows, err := executorFrom(ctxTimeout, r.db).QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find themes page: %v", err)
+	}
+	defer rows.Close()
+
+	var themes []dto.ThemeResponse
+	var keys []string
+	for rows.Next() {
+		var view themeView
+		var key any
+		if err := rows.Scan(append(view.addr(), &key)...); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan theme: %v", err)
+		}
+		themes = append(themes, view.toResponse())
+		keys = append(keys, keyOf(key))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to find themes page: %v", err)
+	}
+
+	themes, next := pageOf(themes, keys, page, func(t dto.ThemeResponse) string { return t.ID })
+	return themes, next, nil
+}
+
+func (r *ThemeViewRepository) FindByGroup(ctx context.Context, groupID domain.GroupID) ([]dto.ThemeResponse, error) {
+	sb := selectThemeView()
+	sb.Where(sb.Equal("themes.group_id", groupID.String()))
+	sb.OrderBy("themes.created_at ASC")
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	rows, err := executorFrom(ctxTimeout, r.db).QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find themes by group: %v", err)
+	}
+	defer rows.Close()
+
+	var themes []dto.ThemeResponse
+	for rows.Next() {
+		var view themeView
+		if err := rows.Scan(view.addr()...); err != nil {
+			return nil, fmt.Errorf("failed to scan theme: %v", err)
+		}
+		themes = append(themes, view.toResponse())
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find themes by group: %v", err)
+	}
+
+	return themes, nil
+}
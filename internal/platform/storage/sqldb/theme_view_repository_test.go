@@ -0,0 +1,181 @@
+package sqldb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	themeViewGroupID    = "6a4f86e4-4fef-4151-9c60-e467007dd213"
+	themeViewCategoryID = "40929ca6-ed89-4548-a1d9-54b604ea50b2"
+	themeViewTrackID    = "481c98f7-373f-4c6d-b0ec-3ba0719a46a0"
+	themeViewMovieID    = "223e4567-e89b-12d3-a456-426614174001"
+
+	querySelectThemeView = "SELECT themes.id, themes.name, themes.description, themes.first_heard_start, themes.first_heard_end, " +
+		"groups.id, groups.name, groups.description, groups.image_url, categories.id, categories.name, " +
+		"first_heard_tracks.id, first_heard_tracks.name, first_heard_tracks.spotify_url, first_heard_tracks.track_number, first_heard_tracks.disc_number, " +
+		"first_heard_tracks.edition, first_heard_tracks.duration_seconds, first_heard_movies.id, first_heard_movies.name, " +
+		"first_heard_movies.release_year, first_heard_movies.sequence, first_heard_movies.series, first_heard_movies.runtime_minutes"
+	queryFromThemeView = " FROM themes JOIN groups ON groups.id = themes.group_id " +
+		"JOIN tracks AS first_heard_tracks ON first_heard_tracks.id = themes.first_heard " +
+		"JOIN movies AS first_heard_movies ON first_heard_movies.id = first_heard_tracks.movie_id " +
+		"LEFT JOIN categories ON categories.id = themes.category_id"
+	// The theme and the parents joined to it are all left out when in the trash
+	queryThemeViewNotDeleted = "themes.deleted_at IS NULL AND groups.deleted_at IS NULL AND categories.deleted_at IS NULL AND " +
+		"first_heard_tracks.deleted_at IS NULL AND first_heard_movies.deleted_at IS NULL"
+)
+
+var themeViewColumnNames = []string{
+	"id", "name", "description", "first_heard_start", "first_heard_end",
+	"group_id", "group_name", "group_description", "group_image_url", "category_id", "category_name",
+	"track_id", "track_name", "track_spotify_url", "track_number", "track_disc_number", "track_edition", "track_duration_seconds",
+	"movie_id", "movie_name",
+	"movie_release_year", "movie_sequence", "movie_series", "movie_runtime_minutes",
+}
+
+func TestThemeViewRepositoryFindByGroupError(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery(querySelectThemeView + queryFromThemeView + " WHERE " + queryThemeViewNotDeleted + " AND themes.group_id = $1 ORDER BY themes.created_at ASC").
+		WithArgs(themeViewGroupID).
+		WillReturnError(errors.New("query error"))
+
+	repo := NewThemeViewRepository(db, 1*time.Second)
+
+	groupID, err := domain.NewGroupIDFromString(themeViewGroupID)
+	require.NoError(t, err)
+
+	_, err = repo.FindByGroup(context.Background(), groupID)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
+
+func TestThemeViewRepositoryFindByGroupSuccess(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery(querySelectThemeView + queryFromThemeView + " WHERE " + queryThemeViewNotDeleted + " AND themes.group_id = $1 ORDER BY themes.created_at ASC").
+		WithArgs(themeViewGroupID).
+		WillReturnRows(sqlmock.NewRows(themeViewColumnNames).
+			AddRow("123e4567-e89b-12d3-a456-426614174000", "The Shire", "Description", 10, 20,
+				themeViewGroupID, "The Hobbits", "Group description", "http://example.com/hobbits.jpg", themeViewCategoryID, "Main",
+				themeViewTrackID, "Concerning Hobbits", nil, 2, 1, "theatrical", 242, themeViewMovieID, "The Fellowship of the Ring", 2001, 1, "lotr", nil).
+			AddRow("123e4567-e89b-12d3-a456-426614174001", "Hobbit Outline", "Description", 0, 5,
+				themeViewGroupID, "The Hobbits", "Group description", "http://example.com/hobbits.jpg", nil, nil,
+				themeViewTrackID, "Concerning Hobbits", nil, 2, 1, "theatrical", 242, themeViewMovieID, "The Fellowship of the Ring", 2001, 1, "lotr", nil))
+
+	repo := NewThemeViewRepository(db, 1*time.Second)
+
+	groupID, err := domain.NewGroupIDFromString(themeViewGroupID)
+	require.NoError(t, err)
+
+	themes, err := repo.FindByGroup(context.Background(), groupID)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+	require.Len(t, themes, 2)
+	assert.Equal(t, "The Shire", themes[0].Name)
+	assert.Equal(t, "The Hobbits", themes[0].Group.Name)
+	assert.Equal(t, "Concerning Hobbits", themes[0].FirstHeard.Name)
+	assert.Equal(t, "The Fellowship of the Ring", themes[0].FirstHeard.Movie.Name)
+	require.NotNil(t, themes[0].Category)
+	assert.Equal(t, "Main", themes[0].Category.Name)
+	assert.Nil(t, themes[1].Category)
+}
+
+func TestThemeViewRepositoryFindByGroupRowsError(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery(querySelectThemeView + queryFromThemeView + " WHERE " + queryThemeViewNotDeleted + " AND themes.group_id = $1 ORDER BY themes.created_at ASC").
+		WithArgs(themeViewGroupID).
+		WillReturnRows(sqlmock.NewRows(themeViewColumnNames).
+			AddRow("123e4567-e89b-12d3-a456-426614174000", "The Shire", "Description", 10, 20,
+				themeViewGroupID, "The Hobbits", "Group description", "http://example.com/hobbits.jpg", nil, nil,
+				themeViewTrackID, "Concerning Hobbits", nil, 2, 1, "theatrical", 242, themeViewMovieID, "The Fellowship of the Ring", 2001, 1, "lotr", nil).
+			AddRow("123e4567-e89b-12d3-a456-426614174001", "Hobbit Outline", "Description", 0, 5,
+				themeViewGroupID, "The Hobbits", "Group description", "http://example.com/hobbits.jpg", nil, nil,
+				themeViewTrackID, "Concerning Hobbits", nil, 2, 1, "theatrical", 242, themeViewMovieID, "The Fellowship of the Ring", 2001, 1, "lotr", nil).
+			RowError(1, errors.New("connection reset")))
+
+	repo := NewThemeViewRepository(db, 1*time.Second)
+
+	groupID, err := domain.NewGroupIDFromString(themeViewGroupID)
+	require.NoError(t, err)
+
+	_, err = repo.FindByGroup(context.Background(), groupID)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
+
+func TestThemeViewRepositoryFindPageSuccess(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	createdAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	sqlMock.ExpectQuery(querySelectThemeView + ", themes.created_at" + queryFromThemeView + " WHERE " + queryThemeViewNotDeleted + " ORDER BY themes.created_at ASC, themes.id ASC LIMIT $1").
+		WithArgs(domain.DefaultPageLimit + 1).
+		WillReturnRows(sqlmock.NewRows(append(themeViewColumnNames, "created_at")).
+			AddRow("123e4567-e89b-12d3-a456-426614174000", "The Shire", "Description", 10, 20,
+				themeViewGroupID, "The Hobbits", "Group description", "http://example.com/hobbits.jpg", nil, nil,
+				themeViewTrackID, "Concerning Hobbits", nil, 2, 1, "theatrical", 242, themeViewMovieID, "The Fellowship of the Ring", 2001, 1, "lotr", nil, createdAt))
+
+	repo := NewThemeViewRepository(db, 1*time.Second)
+
+	page, err := domain.NewPageRequest(0, "")
+	require.NoError(t, err)
+
+	themes, next, err := repo.FindPage(context.Background(), domain.ThemeFilter{}, page)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+	assert.Len(t, themes, 1)
+	assert.Nil(t, next)
+}
+
+func TestThemeViewRepositoryFindPageFiltered(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery(querySelectThemeView+", themes.name"+queryFromThemeView+
+		" WHERE "+queryThemeViewNotDeleted+" AND themes.group_id = $1 AND themes.first_heard IN (SELECT tracks.id FROM tracks WHERE tracks.movie_id = $2)"+
+		" AND themes.name ILIKE $3 AND (themes.name, themes.id) > ($4, $5)"+
+		" ORDER BY themes.name ASC, themes.id ASC LIMIT $6").
+		WithArgs(themeViewGroupID, themeViewMovieID, `%100\% Ring%`, "The Shire", "123e4567-e89b-12d3-a456-426614174000", domain.DefaultPageLimit+1).
+		WillReturnRows(sqlmock.NewRows(append(themeViewColumnNames, "name")))
+
+	repo := NewThemeViewRepository(db, 1*time.Second)
+
+	filter, err := domain.NewThemeFilter(themeViewGroupID, "", themeViewMovieID, "100% Ring", "name")
+	require.NoError(t, err)
+	page, err := domain.NewPageRequest(0, domain.NewCursor("The Shire", "123e4567-e89b-12d3-a456-426614174000").String())
+	require.NoError(t, err)
+
+	themes, next, err := repo.FindPage(context.Background(), filter, page)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+	assert.Empty(t, themes)
+	assert.Nil(t, next)
+}
+
+func TestThemeViewRepositoryFindPageInvalidSortCursor(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	repo := NewThemeViewRepository(db, 1*time.Second)
+
+	filter, err := domain.NewThemeFilter("", "", "", "", "first_heard_start")
+	require.NoError(t, err)
+	page, err := domain.NewPageRequest(0, domain.NewCursor("not a number", "123e4567-e89b-12d3-a456-426614174000").String())
+	require.NoError(t, err)
+
+	_, _, err = repo.FindPage(context.Background(), filter, page)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+}
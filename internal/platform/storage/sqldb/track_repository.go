@@ -12,21 +12,25 @@ import (
 )
 
 type TrackDB struct {
-	ID         string  `db:"id"`
-	Name       string  `db:"name"`
-	MovieID    string  `db:"movie_id"`
-	SpotifyURL *string `db:"spotify_url"`
+	ID              string  `db:"id"`
+	Name            string  `db:"name"`
+	MovieID         string  `db:"movie_id"`
+	SpotifyURL      *string `db:"spotify_url"`
+	TrackNumber     int     `db:"track_number"`
+	DiscNumber      int     `db:"disc_number"`
+	Edition         string  `db:"edition"`
+	DurationSeconds int     `db:"duration_seconds"`
 }
 
 var sqlTrackTable = "tracks"
 var trackSQLStruct = sqlbuilder.NewStruct(new(TrackDB)).For(defaultFlavor)
 
 type TrackRepository struct {
-	db        *sql.DB
+	db        Executor
 	dbTimeout time.Duration
 }
 
-func NewTrackRepository(db *sql.DB, timeout time.Duration) *TrackRepository {
+func NewTrackRepository(db Executor, timeout time.Duration) *TrackRepository {
 	return &TrackRepository{
 		db:        db,
 		dbTimeout: timeout,
@@ -35,15 +39,29 @@ func NewTrackRepository(db *sql.DB, timeout time.Duration) *TrackRepository {
 
 func trackToDTO(track domain.Track) TrackDB {
 	return TrackDB{
-		ID:         track.ID().String(),
-		Name:       track.Name().String(),
-		MovieID:    track.MovieID().String(),
-		SpotifyURL: track.SpotifyURL().AsStringPtr(),
+		ID:              track.ID().String(),
+		Name:            track.Name().String(),
+		MovieID:         track.MovieID().String(),
+		SpotifyURL:      track.SpotifyURL().AsStringPtr(),
+		TrackNumber:     track.TrackNumber().Int(),
+		DiscNumber:      track.DiscNumber().Int(),
+		Edition:         track.Edition().String(),
+		DurationSeconds: track.Duration().Seconds(),
 	}
 }
 
 func trackToDomain(dto TrackDB) (domain.Track, error) {
-	return domain.NewTrackWithID(dto.ID, dto.Name, dto.MovieID, dto.SpotifyURL)
+	return domain.NewTrackWithID(dto.ID, dto.Name, dto.MovieID, dto.SpotifyURL, dto.TrackNumber, dto.DiscNumber, dto.Edition, dto.DurationSeconds)
+}
+
+// trackEditionRank ranks the edition of a track in album order, rather than leaving it to
+// how the edition names happen to sort.
+const trackEditionRank = "CASE tracks.edition WHEN '" + domain.TrackEditionTheatrical + "' THEN 1 WHEN '" + domain.TrackEditionCompleteRecordings + "' THEN 2 END"
+
+// orderByAlbum sorts tracks as they appear on their albums: the theatrical soundtrack
+// before the Complete Recordings, then by disc and track number.
+func orderByAlbum(sb *sqlbuilder.SelectBuilder) {
+	sb.OrderBy(trackEditionRank+" ASC", "tracks.disc_number ASC", "tracks.track_number ASC")
 }
 
 func (r *TrackRepository) Save(ctx context.Context, track domain.Track) error {
@@ -54,14 +72,15 @@ func (r *TrackRepository) Save(ctx context.Context, track domain.Track) error {
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	_, err := r.db.ExecContext(ctxTimeout, query, args...)
-	if err != nil {
-		err = mapSQLError(extractSQLErrorCode(err))
+	if err := requireLive(ctxTimeout, r.db, sqlMovieTable, row.MovieID, domain.ErrMovieNotFound); err != nil {
+		return err
+	}
 
-		if errors.Is(err, ErrForeignKeyViolation) {
-			return domain.ErrMovieNotFound
+	_, err := executorFrom(ctxTimeout, r.db).ExecContext(ctxTimeout, query, args...)
+	if err != nil {
+		if constraintErr := writeError(err); constraintErr != nil {
+			return constraintErr
 		}
-
 		return fmt.Errorf("failed to save track: %v", err)
 	}
 
@@ -69,14 +88,31 @@ func (r *TrackRepository) Save(ctx context.Context, track domain.Track) error {
 }
 
 func (r *TrackRepository) Find(ctx context.Context, id domain.TrackID) (domain.Track, error) {
+	return r.find(ctx, id, notDeleted, false)
+}
+
+// FindInTrash finds the track only if it is in the trash.
+func (r *TrackRepository) FindInTrash(ctx context.Context, id domain.TrackID) (domain.Track, error) {
+	return r.find(ctx, id, inTrash, false)
+}
+
+func (r *TrackRepository) FindForUpdate(ctx context.Context, id domain.TrackID) (domain.Track, error) {
+	return r.find(ctx, id, notDeleted, true)
+}
+
+func (r *TrackRepository) find(ctx context.Context, id domain.TrackID, filter func(*sqlbuilder.SelectBuilder, string), forUpdate bool) (domain.Track, error) {
 	sb := trackSQLStruct.SelectFrom(sqlTrackTable)
+	filter(sb, sqlTrackTable)
 	sb.Where(sb.Equal("id", id.String()))
+	if forUpdate {
+		sb.ForUpdate()
+	}
 	query, args := sb.Build()
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
 	var trackDTO TrackDB
-	err := r.db.QueryRowContext(ctxTimeout, query, args...).Scan(trackSQLStruct.Addr(&trackDTO)...)
+	err := executorFrom(ctxTimeout, r.db).QueryRowContext(ctxTimeout, query, args...).Scan(trackSQLStruct.Addr(&trackDTO)...)
 	if errors.Is(err, sql.ErrNoRows) {
 		return domain.Track{}, domain.ErrTrackNotFound
 	}
@@ -89,12 +125,13 @@ func (r *TrackRepository) Find(ctx context.Context, id domain.TrackID) (domain.T
 
 func (r *TrackRepository) FindAll(ctx context.Context) ([]domain.Track, error) {
 	sb := trackSQLStruct.SelectFrom(sqlTrackTable)
+	notDeleted(sb, sqlTrackTable)
 	query, args := sb.Build()
 
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	rows, err := r.db.QueryContext(ctxTimeout, query, args...)
+	rows, err := executorFrom(ctxTimeout, r.db).QueryContext(ctxTimeout, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find tracks: %v", err)
 	}
@@ -117,16 +154,57 @@ func (r *TrackRepository) FindAll(ctx context.Context) ([]domain.Track, error) {
 	return tracks, nil
 }
 
+func (r *TrackRepository) FindPage(ctx context.Context, page domain.PageRequest) ([]domain.Track, *domain.Cursor, error) {
+	sb := trackSQLStruct.SelectFrom(sqlTrackTable)
+	notDeleted(sb, sqlTrackTable)
+	if err := paginateByCreatedAt(sb, sqlTrackTable, page); err != nil {
+		return nil, nil, err
+	}
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	rows, err := executorFrom(ctxTimeout, r.db).QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find tracks page: %v", err)
+	}
+	defer rows.Close()
+
+	var tracks []domain.Track
+	var keys []string
+	for rows.Next() {
+		var trackDTO TrackDB
+		var createdAt time.Time
+		if err := rows.Scan(append(trackSQLStruct.Addr(&trackDTO), &createdAt)...); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan track: %v", err)
+		}
+		track, err := trackToDomain(trackDTO)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to convert track: %v", err)
+		}
+		tracks = append(tracks, track)
+		keys = append(keys, createdAtKey(createdAt))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to find tracks page: %v", err)
+	}
+
+	tracks, next := pageOf(tracks, keys, page, func(t domain.Track) string { return t.ID().String() })
+	return tracks, next, nil
+}
+
 func (r *TrackRepository) FindByMovie(ctx context.Context, movieID domain.MovieID) ([]domain.Track, error) {
 	sb := trackSQLStruct.SelectFrom(sqlTrackTable)
+	notDeleted(sb, sqlTrackTable)
 	sb.Where(sb.Equal("movie_id", movieID.String()))
-	sb.OrderBy("created_at ASC")
+	orderByAlbum(sb)
 	query, args := sb.Build()
 
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	rows, err := r.db.QueryContext(ctxTimeout, query, args...)
+	rows, err := executorFrom(ctxTimeout, r.db).QueryContext(ctxTimeout, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find tracks by movie: %v", err)
 	}
@@ -149,24 +227,61 @@ func (r *TrackRepository) FindByMovie(ctx context.Context, movieID domain.MovieI
 	return tracks, nil
 }
 
+// Delete moves the track to the trash.
 func (r *TrackRepository) Delete(ctx context.Context, id domain.TrackID) error {
-	sb := trackSQLStruct.DeleteFrom(sqlTrackTable)
-	sb.Where(sb.Equal("id", id.String()))
-	query, args := sb.Build()
+	query, args := moveToTrashQuery(sqlTrackTable, id.String())
 
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	result, err := r.db.ExecContext(ctxTimeout, query, args...)
+	found, err := execOnRow(ctxTimeout, r.db, query, args)
 	if err != nil {
 		return fmt.Errorf("failed to delete track: %v", err)
 	}
+	if !found {
+		return domain.ErrTrackNotFound
+	}
 
-	rowsAffected, err := result.RowsAffected()
+	return nil
+}
+
+// Restore takes the track out of the trash, unless a live track took its album position.
+func (r *TrackRepository) Restore(ctx context.Context, id domain.TrackID) error {
+	query, args := restoreQuery(sqlTrackTable, id.String())
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	found, err := execOnRow(ctxTimeout, r.db, query, args)
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %v", err)
+		// A live track took its album position while it was in the trash
+		if constraintErr := writeError(err); constraintErr != nil {
+			return constraintErr
+		}
+		return fmt.Errorf("failed to restore track: %v", err)
 	}
-	if rowsAffected == 0 {
+	if !found {
+		return domain.ErrTrackNotFound
+	}
+
+	return nil
+}
+
+// Purge deletes the track for good, provided it is in the trash.
+func (r *TrackRepository) Purge(ctx context.Context, id domain.TrackID) error {
+	query, args := purgeQuery(sqlTrackTable, id.String())
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	found, err := execOnRow(ctxTimeout, r.db, query, args)
+	if err != nil {
+		if constraintErr := deleteError(err); constraintErr != nil {
+			return constraintErr
+		}
+		return fmt.Errorf("failed to purge track: %v", err)
+	}
+	if !found {
 		return domain.ErrTrackNotFound
 	}
 
@@ -176,14 +291,21 @@ func (r *TrackRepository) Delete(ctx context.Context, id domain.TrackID) error {
 func (r *TrackRepository) Update(ctx context.Context, track domain.Track) error {
 	row := trackToDTO(track)
 	sb := trackSQLStruct.Update(sqlTrackTable, row)
-	sb.Where(sb.Equal("id", row.ID))
+	sb.Where(sb.Equal("id", row.ID), sb.IsNull("deleted_at"))
 	query, args := sb.Build()
 
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	result, err := r.db.ExecContext(ctxTimeout, query, args...)
+	if err := requireLive(ctxTimeout, r.db, sqlMovieTable, row.MovieID, domain.ErrMovieNotFound); err != nil {
+		return err
+	}
+
+	result, err := executorFrom(ctxTimeout, r.db).ExecContext(ctxTimeout, query, args...)
 	if err != nil {
+		if constraintErr := writeError(err); constraintErr != nil {
+			return constraintErr
+		}
 		return fmt.Errorf("failed to update track: %v", err)
 	}
 
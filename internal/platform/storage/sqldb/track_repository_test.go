@@ -8,6 +8,7 @@ import (
 
 	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -16,21 +17,36 @@ const (
 	trackID            = "123e4567-e89b-12d3-a456-426614174000"
 	trackName          = "The Shire"
 	trackMovieID       = "456e7890-e89b-12d3-a456-426614174111"
+	trackNumber        = 1
+	trackDiscNumber    = 1
+	trackEdition       = domain.TrackEditionTheatrical
+	trackDuration      = 300
 	connectionErrorMsg = "connection error"
-	selectQuery        = "SELECT tracks.id, tracks.name, tracks.movie_id, tracks.spotify_url FROM tracks WHERE id = $1"
-	deleteQuery        = "DELETE FROM tracks WHERE id = $1"
-	selectAllQuery     = "SELECT tracks.id, tracks.name, tracks.movie_id, tracks.spotify_url FROM tracks"
+	selectQuery        = "SELECT tracks.id, tracks.name, tracks.movie_id, tracks.spotify_url, tracks.track_number, tracks.disc_number, tracks.edition, tracks.duration_seconds FROM tracks WHERE tracks.deleted_at IS NULL AND id = $1"
+	deleteQuery        = "UPDATE tracks SET deleted_at = CURRENT_TIMESTAMP(0) WHERE id = $1 AND deleted_at IS NULL"
+	hardDeleteQuery    = "DELETE FROM tracks WHERE id = $1 AND deleted_at IS NOT NULL"
+	selectAllQuery     = "SELECT tracks.id, tracks.name, tracks.movie_id, tracks.spotify_url, tracks.track_number, tracks.disc_number, tracks.edition, tracks.duration_seconds FROM tracks WHERE tracks.deleted_at IS NULL"
+	liveMovieQuery     = "SELECT 1 FROM movies WHERE id = $1 AND deleted_at IS NULL FOR SHARE"
 )
 
+var trackColumns = []string{"id", "name", "movie_id", "spotify_url", "track_number", "disc_number", "edition", "duration_seconds"}
+
+func expectLiveMovie(sqlMock sqlmock.Sqlmock) {
+	sqlMock.ExpectQuery(liveMovieQuery).
+		WithArgs(trackMovieID).
+		WillReturnRows(sqlmock.NewRows([]string{"?column?"}).AddRow(1))
+}
+
 func TestTrackRepositorySaveError(t *testing.T) {
-	track, err := domain.NewTrackWithID(trackID, trackName, trackMovieID, nil)
+	track, err := domain.NewTrackWithID(trackID, trackName, trackMovieID, nil, trackNumber, trackDiscNumber, trackEdition, trackDuration)
 	require.NoError(t, err)
 
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
 
-	sqlMock.ExpectExec("INSERT INTO tracks (id, name, movie_id, spotify_url) VALUES ($1, $2, $3, $4)").
-		WithArgs(trackID, trackName, trackMovieID, nil).
+	expectLiveMovie(sqlMock)
+	sqlMock.ExpectExec("INSERT INTO tracks (id, name, movie_id, spotify_url, track_number, disc_number, edition, duration_seconds) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)").
+		WithArgs(trackID, trackName, trackMovieID, nil, trackNumber, trackDiscNumber, trackEdition, trackDuration).
 		WillReturnError(errors.New(connectionErrorMsg))
 
 	repo := NewTrackRepository(db, 1*time.Second)
@@ -42,14 +58,15 @@ func TestTrackRepositorySaveError(t *testing.T) {
 }
 
 func TestTrackRepositorySaveSuccess(t *testing.T) {
-	track, err := domain.NewTrackWithID(trackID, trackName, trackMovieID, nil)
+	track, err := domain.NewTrackWithID(trackID, trackName, trackMovieID, nil, trackNumber, trackDiscNumber, trackEdition, trackDuration)
 	require.NoError(t, err)
 
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
 
-	sqlMock.ExpectExec("INSERT INTO tracks (id, name, movie_id, spotify_url) VALUES ($1, $2, $3, $4)").
-		WithArgs(trackID, trackName, trackMovieID, nil).
+	expectLiveMovie(sqlMock)
+	sqlMock.ExpectExec("INSERT INTO tracks (id, name, movie_id, spotify_url, track_number, disc_number, edition, duration_seconds) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)").
+		WithArgs(trackID, trackName, trackMovieID, nil, trackNumber, trackDiscNumber, trackEdition, trackDuration).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	repo := NewTrackRepository(db, 1*time.Second)
@@ -84,7 +101,7 @@ func TestTrackRepositoryFindNotFound(t *testing.T) {
 
 	sqlMock.ExpectQuery(selectQuery).
 		WithArgs(trackID).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "movie_id", "spotify_url"}))
+		WillReturnRows(sqlmock.NewRows(trackColumns))
 
 	repo := NewTrackRepository(db, 1*time.Second)
 
@@ -102,8 +119,8 @@ func TestTrackRepositoryFindSuccess(t *testing.T) {
 
 	sqlMock.ExpectQuery(selectQuery).
 		WithArgs(trackID).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "movie_id", "spotify_url"}).
-			AddRow(trackID, trackName, trackMovieID, nil))
+		WillReturnRows(sqlmock.NewRows(trackColumns).
+			AddRow(trackID, trackName, trackMovieID, nil, trackNumber, trackDiscNumber, trackEdition, trackDuration))
 
 	repo := NewTrackRepository(db, 1*time.Second)
 
@@ -118,6 +135,26 @@ func TestTrackRepositoryFindSuccess(t *testing.T) {
 	assert.Equal(t, trackMovieID, track.MovieID().String())
 }
 
+func TestTrackRepositoryFindForUpdateSuccess(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery(selectQuery + " FOR UPDATE").
+		WithArgs(trackID).
+		WillReturnRows(sqlmock.NewRows(trackColumns).
+			AddRow(trackID, trackName, trackMovieID, nil, trackNumber, trackDiscNumber, trackEdition, trackDuration))
+
+	repo := NewTrackRepository(db, 1*time.Second)
+
+	trackIDVO, err := domain.NewTrackIDFromString(trackID)
+	require.NoError(t, err)
+	track, err := repo.FindForUpdate(context.Background(), trackIDVO)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+	assert.Equal(t, trackID, track.ID().String())
+}
+
 func TestTrackRepositoryFindAllError(t *testing.T) {
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
@@ -138,9 +175,9 @@ func TestTrackRepositoryFindAllSuccess(t *testing.T) {
 	require.NoError(t, err)
 
 	sqlMock.ExpectQuery(selectAllQuery).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "movie_id", "spotify_url"}).
-			AddRow(trackID, trackName, trackMovieID, nil).
-			AddRow("789e1011-e89b-12d3-a456-426614174222", "Concerning Hobbits", trackMovieID, nil))
+		WillReturnRows(sqlmock.NewRows(trackColumns).
+			AddRow(trackID, trackName, trackMovieID, nil, trackNumber, trackDiscNumber, trackEdition, trackDuration).
+			AddRow("789e1011-e89b-12d3-a456-426614174222", "Concerning Hobbits", trackMovieID, nil, 2, trackDiscNumber, trackEdition, 240))
 
 	repo := NewTrackRepository(db, 1*time.Second)
 
@@ -154,13 +191,13 @@ func TestTrackRepositoryFindAllSuccess(t *testing.T) {
 	assert.Equal(t, trackMovieID, tracks[0].MovieID().String())
 }
 
-func TestTrackRepositoryDeleteError(t *testing.T) {
+func TestTrackRepositoryDeleteSuccess(t *testing.T) {
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
 
 	sqlMock.ExpectExec(deleteQuery).
 		WithArgs(trackID).
-		WillReturnError(errors.New(connectionErrorMsg))
+		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	repo := NewTrackRepository(db, 1*time.Second)
 
@@ -169,7 +206,7 @@ func TestTrackRepositoryDeleteError(t *testing.T) {
 	err = repo.Delete(context.Background(), trackIDVO)
 
 	assert.NoError(t, sqlMock.ExpectationsWereMet())
-	assert.Error(t, err)
+	assert.NoError(t, err)
 }
 
 func TestTrackRepositoryDeleteNotFound(t *testing.T) {
@@ -190,11 +227,101 @@ func TestTrackRepositoryDeleteNotFound(t *testing.T) {
 	assert.ErrorIs(t, err, domain.ErrTrackNotFound)
 }
 
-func TestTrackRepositoryDeleteSuccess(t *testing.T) {
+func TestTrackRepositoryRestoreSuccess(t *testing.T) {
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
 
-	sqlMock.ExpectExec(deleteQuery).
+	sqlMock.ExpectExec("UPDATE tracks SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL").
+		WithArgs(trackID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := NewTrackRepository(db, 1*time.Second)
+
+	trackIDVO, err := domain.NewTrackIDFromString(trackID)
+	require.NoError(t, err)
+	err = repo.Restore(context.Background(), trackIDVO)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
+
+func TestTrackRepositoryRestoreNotInTrash(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec("UPDATE tracks SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL").
+		WithArgs(trackID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := NewTrackRepository(db, 1*time.Second)
+
+	trackIDVO, err := domain.NewTrackIDFromString(trackID)
+	require.NoError(t, err)
+	err = repo.Restore(context.Background(), trackIDVO)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.ErrorIs(t, err, domain.ErrTrackNotFound)
+}
+
+func TestTrackRepositoryRestorePositionTaken(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec("UPDATE tracks SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL").
+		WithArgs(trackID).
+		WillReturnError(&pq.Error{Code: "23505", Constraint: "tracks_position_key"})
+
+	repo := NewTrackRepository(db, 1*time.Second)
+
+	trackIDVO, err := domain.NewTrackIDFromString(trackID)
+	require.NoError(t, err)
+	err = repo.Restore(context.Background(), trackIDVO)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.ErrorIs(t, err, domain.ErrTrackPositionAlreadyExists)
+}
+
+func TestTrackRepositoryPurgeError(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec(hardDeleteQuery).
+		WithArgs(trackID).
+		WillReturnError(errors.New(connectionErrorMsg))
+
+	repo := NewTrackRepository(db, 1*time.Second)
+
+	trackIDVO, err := domain.NewTrackIDFromString(trackID)
+	require.NoError(t, err)
+	err = repo.Purge(context.Background(), trackIDVO)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
+
+func TestTrackRepositoryPurgeNotFound(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec(hardDeleteQuery).
+		WithArgs(trackID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := NewTrackRepository(db, 1*time.Second)
+
+	trackIDVO, err := domain.NewTrackIDFromString(trackID)
+	require.NoError(t, err)
+	err = repo.Purge(context.Background(), trackIDVO)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.ErrorIs(t, err, domain.ErrTrackNotFound)
+}
+
+func TestTrackRepositoryPurgeSuccess(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec(hardDeleteQuery).
 		WithArgs(trackID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
@@ -202,21 +329,41 @@ func TestTrackRepositoryDeleteSuccess(t *testing.T) {
 
 	trackIDVO, err := domain.NewTrackIDFromString(trackID)
 	require.NoError(t, err)
-	err = repo.Delete(context.Background(), trackIDVO)
+	err = repo.Purge(context.Background(), trackIDVO)
 
 	assert.NoError(t, sqlMock.ExpectationsWereMet())
 	assert.NoError(t, err)
 }
 
+func TestTrackRepositorySaveTrashedMovie(t *testing.T) {
+	track, err := domain.NewTrackWithID(trackID, trackName, trackMovieID, nil, trackNumber, trackDiscNumber, trackEdition, trackDuration)
+	require.NoError(t, err)
+
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery(liveMovieQuery).
+		WithArgs(trackMovieID).
+		WillReturnRows(sqlmock.NewRows([]string{"?column?"}))
+
+	repo := NewTrackRepository(db, 1*time.Second)
+
+	err = repo.Save(context.Background(), track)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+}
+
 func TestTrackRepositoryUpdateError(t *testing.T) {
-	track, err := domain.NewTrackWithID(trackID, trackName, trackMovieID, nil)
+	track, err := domain.NewTrackWithID(trackID, trackName, trackMovieID, nil, trackNumber, trackDiscNumber, trackEdition, trackDuration)
 	require.NoError(t, err)
 
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
 
-	sqlMock.ExpectExec("UPDATE tracks SET id = $1, name = $2, movie_id = $3, spotify_url = $4 WHERE id = $5").
-		WithArgs(trackID, trackName, trackMovieID, nil, trackID).
+	expectLiveMovie(sqlMock)
+	sqlMock.ExpectExec("UPDATE tracks SET id = $1, name = $2, movie_id = $3, spotify_url = $4, track_number = $5, disc_number = $6, edition = $7, duration_seconds = $8 WHERE id = $9 AND deleted_at IS NULL").
+		WithArgs(trackID, trackName, trackMovieID, nil, trackNumber, trackDiscNumber, trackEdition, trackDuration, trackID).
 		WillReturnError(errors.New("update error"))
 
 	repo := NewTrackRepository(db, 1*time.Second)
@@ -227,14 +374,15 @@ func TestTrackRepositoryUpdateError(t *testing.T) {
 }
 
 func TestTrackRepositoryUpdateSuccess(t *testing.T) {
-	track, err := domain.NewTrackWithID(trackID, trackName, trackMovieID, nil)
+	track, err := domain.NewTrackWithID(trackID, trackName, trackMovieID, nil, trackNumber, trackDiscNumber, trackEdition, trackDuration)
 	require.NoError(t, err)
 
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
 
-	sqlMock.ExpectExec("UPDATE tracks SET id = $1, name = $2, movie_id = $3, spotify_url = $4 WHERE id = $5").
-		WithArgs(trackID, trackName, trackMovieID, nil, trackID).
+	expectLiveMovie(sqlMock)
+	sqlMock.ExpectExec("UPDATE tracks SET id = $1, name = $2, movie_id = $3, spotify_url = $4, track_number = $5, disc_number = $6, edition = $7, duration_seconds = $8 WHERE id = $9 AND deleted_at IS NULL").
+		WithArgs(trackID, trackName, trackMovieID, nil, trackNumber, trackDiscNumber, trackEdition, trackDuration, trackID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	repo := NewTrackRepository(db, 1*time.Second)
@@ -248,7 +396,7 @@ func TestTrackRepositoryFindByMovieError(t *testing.T) {
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
 
-	sqlMock.ExpectQuery("SELECT tracks.id, tracks.name, tracks.movie_id, tracks.spotify_url FROM tracks WHERE movie_id = $1").
+	sqlMock.ExpectQuery("SELECT tracks.id, tracks.name, tracks.movie_id, tracks.spotify_url, tracks.track_number, tracks.disc_number, tracks.edition, tracks.duration_seconds FROM tracks WHERE tracks.deleted_at IS NULL AND movie_id = $1 ORDER BY CASE tracks.edition WHEN 'theatrical' THEN 1 WHEN 'complete_recordings' THEN 2 END ASC, tracks.disc_number ASC, tracks.track_number ASC").
 		WillReturnError(errors.New(connectionErrorMsg))
 
 	repo := NewTrackRepository(db, 1*time.Second)
@@ -266,11 +414,11 @@ func TestTrackRepositoryFindByMovieSuccess(t *testing.T) {
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
 
-	sqlMock.ExpectQuery("SELECT tracks.id, tracks.name, tracks.movie_id, tracks.spotify_url FROM tracks WHERE movie_id = $1").
+	sqlMock.ExpectQuery("SELECT tracks.id, tracks.name, tracks.movie_id, tracks.spotify_url, tracks.track_number, tracks.disc_number, tracks.edition, tracks.duration_seconds FROM tracks WHERE tracks.deleted_at IS NULL AND movie_id = $1 ORDER BY CASE tracks.edition WHEN 'theatrical' THEN 1 WHEN 'complete_recordings' THEN 2 END ASC, tracks.disc_number ASC, tracks.track_number ASC").
 		WithArgs(trackMovieID).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "movie_id", "spotify_url"}).
-			AddRow(trackID, trackName, trackMovieID, nil).
-			AddRow("789e1011-e89b-12d3-a456-426614174222", "Concerning Hobbits", trackMovieID, nil))
+		WillReturnRows(sqlmock.NewRows(trackColumns).
+			AddRow(trackID, trackName, trackMovieID, nil, trackNumber, trackDiscNumber, trackEdition, trackDuration).
+			AddRow("789e1011-e89b-12d3-a456-426614174222", "Concerning Hobbits", trackMovieID, nil, 2, trackDiscNumber, trackEdition, 240))
 
 	repo := NewTrackRepository(db, 1*time.Second)
 
@@ -282,3 +430,23 @@ func TestTrackRepositoryFindByMovieSuccess(t *testing.T) {
 	assert.NoError(t, sqlMock.ExpectationsWereMet())
 	assert.NoError(t, err)
 }
+
+func TestTrackRepositorySavePositionAlreadyExists(t *testing.T) {
+	track, err := domain.NewTrackWithID(trackID, trackName, trackMovieID, nil, trackNumber, trackDiscNumber, trackEdition, trackDuration)
+	require.NoError(t, err)
+
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	expectLiveMovie(sqlMock)
+	sqlMock.ExpectExec("INSERT INTO tracks (id, name, movie_id, spotify_url, track_number, disc_number, edition, duration_seconds) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)").
+		WithArgs(trackID, trackName, trackMovieID, nil, trackNumber, trackDiscNumber, trackEdition, trackDuration).
+		WillReturnError(&pq.Error{Code: "23505", Constraint: "tracks_position_key"})
+
+	repo := NewTrackRepository(db, 1*time.Second)
+
+	err = repo.Save(context.Background(), track)
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.ErrorIs(t, err, domain.ErrTrackPositionAlreadyExists)
+}
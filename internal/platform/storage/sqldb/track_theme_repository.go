@@ -19,20 +19,15 @@ type TrackThemeDB struct {
 	IsVariant   bool   `db:"is_variant"`
 }
 
-var trackThemeFKMap = map[string]error{
-	"tracks_themes_track_id_fkey": domain.ErrTrackNotFound,
-	"tracks_themes_theme_id_fkey": domain.ErrThemeNotFound,
-}
-
 var sqlTrackThemeTable = "tracks_themes"
 var trackThemeSQLStruct = sqlbuilder.NewStruct(new(TrackThemeDB)).For(defaultFlavor)
 
 type TrackThemeRepository struct {
-	db        *sql.DB
+	db        Executor
 	dbTimeout time.Duration
 }
 
-func NewTrackThemeRepository(db *sql.DB, timeout time.Duration) *TrackThemeRepository {
+func NewTrackThemeRepository(db Executor, timeout time.Duration) *TrackThemeRepository {
 	return &TrackThemeRepository{
 		db:        db,
 		dbTimeout: timeout,
@@ -61,17 +56,18 @@ func (r *TrackThemeRepository) Save(ctx context.Context, trackTheme domain.Track
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	_, err := r.db.ExecContext(ctxTimeout, query, args...)
-	if err != nil {
-		constraint := extractConstraintName(err)
+	if err := requireLive(ctxTimeout, r.db, sqlTrackTable, row.TrackID, domain.ErrTrackNotFound); err != nil {
+		return err
+	}
+	if err := requireLive(ctxTimeout, r.db, sqlThemeTable, row.ThemeID, domain.ErrThemeNotFound); err != nil {
+		return err
+	}
 
-		err = mapSQLError(extractSQLErrorCode(err))
-		if errors.Is(err, ErrForeignKeyViolation) {
-			if fkErr, ok := trackThemeFKMap[constraint]; ok {
-				return fkErr
-			}
+	_, err := executorFrom(ctxTimeout, r.db).ExecContext(ctxTimeout, query, args...)
+	if err != nil {
+		if constraintErr := writeError(err); constraintErr != nil {
+			return constraintErr
 		}
-
 		return fmt.Errorf("failed to save track theme: %v", err)
 	}
 
@@ -90,7 +86,7 @@ func (r *TrackThemeRepository) Find(ctx context.Context, trackID domain.TrackID,
 	defer cancel()
 
 	var trackThemeDTO TrackThemeDB
-	err := r.db.QueryRowContext(ctxTimeout, query, args...).Scan(trackThemeSQLStruct.Addr(&trackThemeDTO)...)
+	err := executorFrom(ctxTimeout, r.db).QueryRowContext(ctxTimeout, query, args...).Scan(trackThemeSQLStruct.Addr(&trackThemeDTO)...)
 	if errors.Is(err, sql.ErrNoRows) {
 		return domain.TrackTheme{}, domain.ErrTrackThemeNotFound
 	}
@@ -110,7 +106,7 @@ func (r *TrackThemeRepository) FindByTrack(ctx context.Context, trackID domain.T
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	rows, err := r.db.QueryContext(ctxTimeout, query, args...)
+	rows, err := executorFrom(ctxTimeout, r.db).QueryContext(ctxTimeout, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find track themes by track ID: %v", err)
 	}
@@ -130,6 +126,49 @@ func (r *TrackThemeRepository) FindByTrack(ctx context.Context, trackID domain.T
 
 		trackThemes = append(trackThemes, trackTheme)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find track themes by track ID: %v", err)
+	}
+
+	return trackThemes, nil
+}
+
+// FindByTheme returns every occurrence of a theme, ordered by movie, then by track and
+// then by the second the theme starts at.
+func (r *TrackThemeRepository) FindByTheme(ctx context.Context, themeID domain.ThemeID) ([]domain.TrackTheme, error) {
+	sb := trackThemeSQLStruct.SelectFrom(sqlTrackThemeTable)
+	sb.Join(sqlTrackTable, "tracks.id = tracks_themes.track_id")
+	sb.Join(sqlMovieTable, "movies.id = tracks.movie_id")
+	sb.Where(sb.Equal("tracks_themes.theme_id", themeID.String()))
+	orderByMovieAndTrack(sb)
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	rows, err := executorFrom(ctxTimeout, r.db).QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find track themes by theme ID: %v", err)
+	}
+	defer rows.Close()
+
+	var trackThemes []domain.TrackTheme
+	for rows.Next() {
+		var trackThemeDTO TrackThemeDB
+		if err := rows.Scan(trackThemeSQLStruct.Addr(&trackThemeDTO)...); err != nil {
+			return nil, fmt.Errorf("failed to scan track theme: %v", err)
+		}
+
+		trackTheme, err := trackThemeToDomain(trackThemeDTO)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert track theme to domain: %v", err)
+		}
+
+		trackThemes = append(trackThemes, trackTheme)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find track themes by theme ID: %v", err)
+	}
 
 	return trackThemes, nil
 }
@@ -146,8 +185,11 @@ func (r *TrackThemeRepository) Delete(ctx context.Context, trackID domain.TrackI
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	result, err := r.db.ExecContext(ctxTimeout, query, args...)
+	result, err := executorFrom(ctxTimeout, r.db).ExecContext(ctxTimeout, query, args...)
 	if err != nil {
+		if constraintErr := deleteError(err); constraintErr != nil {
+			return constraintErr
+		}
 		return fmt.Errorf("failed to delete track theme: %v", err)
 	}
 
@@ -176,8 +218,11 @@ func (r *TrackThemeRepository) Update(ctx context.Context, trackTheme domain.Tra
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	result, err := r.db.ExecContext(ctxTimeout, query, args...)
+	result, err := executorFrom(ctxTimeout, r.db).ExecContext(ctxTimeout, query, args...)
 	if err != nil {
+		if constraintErr := writeError(err); constraintErr != nil {
+			return constraintErr
+		}
 		return fmt.Errorf("failed to update track theme: %v", err)
 	}
 
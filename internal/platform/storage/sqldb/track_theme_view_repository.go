@@ -0,0 +1,138 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+// trackThemeView is a track theme row joined with its track and theme views.
+type trackThemeView struct {
+	Track       trackView
+	Theme       themeView
+	StartSecond int
+	EndSecond   int
+	IsVariant   bool
+}
+
+func (v *trackThemeView) addr() []any {
+	addr := append(v.Track.addr(), v.Theme.addr()...)
+	return append(addr, &v.StartSecond, &v.EndSecond, &v.IsVariant)
+}
+
+func (v trackThemeView) toResponse() dto.TrackThemeResponse {
+	return dto.TrackThemeResponse{
+		Track:       v.Track.toResponse(),
+		Theme:       v.Theme.toResponse(),
+		StartSecond: v.StartSecond,
+		EndSecond:   v.EndSecond,
+		IsVariant:   v.IsVariant,
+	}
+}
+
+// TrackThemeViewRepository reads track themes and their related entities with a single query.
+type TrackThemeViewRepository struct {
+	db        Executor
+	dbTimeout time.Duration
+}
+
+// NewTrackThemeViewRepository creates a new TrackThemeViewRepository.
+func NewTrackThemeViewRepository(db Executor, dbTimeout time.Duration) *TrackThemeViewRepository {
+	return &TrackThemeViewRepository{
+		db:        db,
+		dbTimeout: dbTimeout,
+	}
+}
+
+func selectTrackThemeView() *sqlbuilder.SelectBuilder {
+	sb := defaultFlavor.NewSelectBuilder()
+	sb.Select(trackViewColumns(sqlTrackTable, sqlMovieTable)...)
+	sb.SelectMore(themeViewColumns()...)
+	sb.SelectMore("tracks_themes.start_second", "tracks_themes.end_second", "tracks_themes.is_variant")
+	sb.From(sqlTrackThemeTable)
+	sb.Join(sqlTrackTable, "tracks.id = tracks_themes.track_id")
+	sb.Join(sqlMovieTable, "movies.id = tracks.movie_id")
+	sb.Join(sqlThemeTable, "themes.id = tracks_themes.theme_id")
+	joinThemeView(sb)
+	notDeleted(sb, sqlTrackTable)
+	notDeleted(sb, sqlMovieTable)
+	notDeletedThemeView(sb)
+	return sb
+}
+
+func (r *TrackThemeViewRepository) FindByTrack(ctx context.Context, trackID domain.TrackID) ([]dto.TrackThemeResponse, error) {
+	sb := selectTrackThemeView()
+	sb.Where(sb.Equal("tracks_themes.track_id", trackID.String()))
+	sb.OrderBy("tracks_themes.start_second ASC")
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	rows, err := executorFrom(ctxTimeout, r.db).QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find track themes by track ID: %v", err)
+	}
+	defer rows.Close()
+
+	var trackThemes []dto.TrackThemeResponse
+	for rows.Next() {
+		var view trackThemeView
+		if err := rows.Scan(view.addr()...); err != nil {
+			return nil, fmt.Errorf("failed to scan track theme: %v", err)
+		}
+		trackThemes = append(trackThemes, view.toResponse())
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find track themes by track ID: %v", err)
+	}
+
+	return trackThemes, nil
+}
+
+// orderByMovieAndTrack orders a select from tracks_themes joined with tracks and movies
+// the way the albums are listened to: by movie, then in album order (see orderByAlbum),
+// then by start second.
+func orderByMovieAndTrack(sb *sqlbuilder.SelectBuilder) {
+	sb.OrderBy(
+		"movies.sequence ASC",
+		trackEditionRank+" ASC",
+		"tracks.disc_number ASC",
+		"tracks.track_number ASC",
+		"tracks_themes.start_second ASC",
+	)
+}
+
+func (r *TrackThemeViewRepository) FindByTheme(ctx context.Context, themeID domain.ThemeID) ([]dto.TrackThemeResponse, error) {
+	sb := selectTrackThemeView()
+	sb.Where(sb.Equal("tracks_themes.theme_id", themeID.String()))
+	orderByMovieAndTrack(sb)
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	rows, err := executorFrom(ctxTimeout, r.db).QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find track themes by theme ID: %v", err)
+	}
+	defer rows.Close()
+
+	var trackThemes []dto.TrackThemeResponse
+	for rows.Next() {
+		var view trackThemeView
+		if err := rows.Scan(view.addr()...); err != nil {
+			return nil, fmt.Errorf("failed to scan track theme: %v", err)
+		}
+		trackThemes = append(trackThemes, view.toResponse())
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find track themes by theme ID: %v", err)
+	}
+
+	return trackThemes, nil
+}
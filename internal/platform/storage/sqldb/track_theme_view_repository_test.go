@@ -0,0 +1,120 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	trackThemeViewThemeID = "123e4567-e89b-12d3-a456-426614174000"
+
+	querySelectTrackThemeView = "SELECT tracks.id, tracks.name, tracks.spotify_url, tracks.track_number, tracks.disc_number, tracks.edition, tracks.duration_seconds, " +
+		"movies.id, movies.name, " +
+		"movies.release_year, movies.sequence, movies.series, movies.runtime_minutes, " +
+		"themes.id, themes.name, themes.description, themes.first_heard_start, themes.first_heard_end, " +
+		"groups.id, groups.name, groups.description, groups.image_url, categories.id, categories.name, " +
+		"first_heard_tracks.id, first_heard_tracks.name, first_heard_tracks.spotify_url, first_heard_tracks.track_number, first_heard_tracks.disc_number, " +
+		"first_heard_tracks.edition, first_heard_tracks.duration_seconds, first_heard_movies.id, first_heard_movies.name, " +
+		"first_heard_movies.release_year, first_heard_movies.sequence, first_heard_movies.series, first_heard_movies.runtime_minutes, " +
+		"tracks_themes.start_second, tracks_themes.end_second, tracks_themes.is_variant " +
+		"FROM tracks_themes JOIN tracks ON tracks.id = tracks_themes.track_id JOIN movies ON movies.id = tracks.movie_id " +
+		"JOIN themes ON themes.id = tracks_themes.theme_id JOIN groups ON groups.id = themes.group_id " +
+		"JOIN tracks AS first_heard_tracks ON first_heard_tracks.id = themes.first_heard " +
+		"JOIN movies AS first_heard_movies ON first_heard_movies.id = first_heard_tracks.movie_id " +
+		"LEFT JOIN categories ON categories.id = themes.category_id"
+	queryOrderByMovieAndTrack = " ORDER BY movies.sequence ASC, CASE tracks.edition WHEN 'theatrical' THEN 1 WHEN 'complete_recordings' THEN 2 END ASC, tracks.disc_number ASC, tracks.track_number ASC, tracks_themes.start_second ASC"
+)
+
+func TestTrackThemeViewRepositoryFindByThemeError(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery(querySelectTrackThemeView + " WHERE tracks.deleted_at IS NULL AND movies.deleted_at IS NULL AND " + queryThemeViewNotDeleted + " AND tracks_themes.theme_id = $1" + queryOrderByMovieAndTrack).
+		WithArgs(trackThemeViewThemeID).
+		WillReturnError(errors.New("query error"))
+
+	repo := NewTrackThemeViewRepository(db, 1*time.Second)
+
+	themeID, err := domain.NewThemeIDFromString(trackThemeViewThemeID)
+	require.NoError(t, err)
+
+	_, err = repo.FindByTheme(context.Background(), themeID)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
+
+func TestTrackThemeViewRepositoryFindByThemeSuccess(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	columns := append([]string{"track_id", "track_name", "track_spotify_url", "track_number", "track_disc_number", "track_edition", "track_duration_seconds", "track_movie_id", "track_movie_name",
+		"track_movie_release_year", "track_movie_sequence", "track_movie_series", "track_movie_runtime_minutes"}, themeViewColumnNames...)
+	columns = append(columns, "start_second", "end_second", "is_variant")
+	themeRow := []driver.Value{trackThemeViewThemeID, "The Shire", "Description", 10, 20,
+		themeViewGroupID, "The Hobbits", "Group description", "http://example.com/hobbits.jpg", nil, nil,
+		themeViewTrackID, "Concerning Hobbits", nil, 2, 1, "theatrical", 242, themeViewMovieID, "The Fellowship of the Ring", 2001, 1, "lotr", nil}
+
+	firstRow := append([]driver.Value{themeViewTrackID, "Concerning Hobbits", nil, 2, 1, "theatrical", 242, themeViewMovieID, "The Fellowship of the Ring", 2001, 1, "lotr", nil}, themeRow...)
+	secondRow := append([]driver.Value{"481c98f7-373f-4c6d-b0ec-3ba0719a46a1", "The Grey Havens", nil, 17, 1, "theatrical", 360, "223e4567-e89b-12d3-a456-426614174003", "The Return of the King", 2003, 3, "lotr", nil}, themeRow...)
+
+	sqlMock.ExpectQuery(querySelectTrackThemeView + " WHERE tracks.deleted_at IS NULL AND movies.deleted_at IS NULL AND " + queryThemeViewNotDeleted + " AND tracks_themes.theme_id = $1" + queryOrderByMovieAndTrack).
+		WithArgs(trackThemeViewThemeID).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(append(firstRow, 0, 30, false)...).
+			AddRow(append(secondRow, 120, 150, true)...))
+
+	repo := NewTrackThemeViewRepository(db, 1*time.Second)
+
+	themeID, err := domain.NewThemeIDFromString(trackThemeViewThemeID)
+	require.NoError(t, err)
+
+	trackThemes, err := repo.FindByTheme(context.Background(), themeID)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+	require.Len(t, trackThemes, 2)
+	assert.Equal(t, "Concerning Hobbits", trackThemes[0].Track.Name)
+	assert.Equal(t, "The Fellowship of the Ring", trackThemes[0].Track.Movie.Name)
+	assert.Equal(t, "The Return of the King", trackThemes[1].Track.Movie.Name)
+	assert.Equal(t, 120, trackThemes[1].StartSecond)
+	assert.Equal(t, 150, trackThemes[1].EndSecond)
+	assert.True(t, trackThemes[1].IsVariant)
+	assert.Equal(t, "The Shire", trackThemes[1].Theme.Name)
+}
+
+func TestTrackThemeViewRepositoryFindByThemeRowsError(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	columns := append([]string{"track_id", "track_name", "track_spotify_url", "track_number", "track_disc_number", "track_edition", "track_duration_seconds", "track_movie_id", "track_movie_name",
+		"track_movie_release_year", "track_movie_sequence", "track_movie_series", "track_movie_runtime_minutes"}, themeViewColumnNames...)
+	columns = append(columns, "start_second", "end_second", "is_variant")
+	row := []driver.Value{themeViewTrackID, "Concerning Hobbits", nil, 2, 1, "theatrical", 242, themeViewMovieID, "The Fellowship of the Ring", 2001, 1, "lotr", nil,
+		trackThemeViewThemeID, "The Shire", "Description", 10, 20,
+		themeViewGroupID, "The Hobbits", "Group description", "http://example.com/hobbits.jpg", nil, nil,
+		themeViewTrackID, "Concerning Hobbits", nil, 2, 1, "theatrical", 242, themeViewMovieID, "The Fellowship of the Ring", 2001, 1, "lotr", nil,
+		0, 30, false}
+
+	sqlMock.ExpectQuery(querySelectTrackThemeView + " WHERE tracks.deleted_at IS NULL AND movies.deleted_at IS NULL AND " + queryThemeViewNotDeleted + " AND tracks_themes.theme_id = $1" + queryOrderByMovieAndTrack).
+		WithArgs(trackThemeViewThemeID).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(row...).
+			AddRow(row...).
+			RowError(1, errors.New("connection reset")))
+
+	repo := NewTrackThemeViewRepository(db, 1*time.Second)
+
+	themeID, err := domain.NewThemeIDFromString(trackThemeViewThemeID)
+	require.NoError(t, err)
+
+	_, err = repo.FindByTheme(context.Background(), themeID)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
@@ -0,0 +1,172 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+// trackView is a track row joined with its movie.
+type trackView struct {
+	ID                  string
+	Name                string
+	SpotifyURL          *string
+	TrackNumber         int
+	DiscNumber          int
+	Edition             string
+	DurationSeconds     int
+	MovieID             string
+	MovieName           string
+	MovieReleaseYear    int
+	MovieSequence       int
+	MovieSeries         string
+	MovieRuntimeMinutes *int
+}
+
+// trackViewColumns returns the columns scanned by trackView.addr, read from the given
+// track and movie table aliases.
+func trackViewColumns(trackTable, movieTable string) []string {
+	return []string{
+		trackTable + ".id",
+		trackTable + ".name",
+		trackTable + ".spotify_url",
+		trackTable + ".track_number",
+		trackTable + ".disc_number",
+		trackTable + ".edition",
+		trackTable + ".duration_seconds",
+		movieTable + ".id",
+		movieTable + ".name",
+		movieTable + ".release_year",
+		movieTable + ".sequence",
+		movieTable + ".series",
+		movieTable + ".runtime_minutes",
+	}
+}
+
+func (v *trackView) addr() []any {
+	return []any{
+		&v.ID,
+		&v.Name,
+		&v.SpotifyURL,
+		&v.TrackNumber,
+		&v.DiscNumber,
+		&v.Edition,
+		&v.DurationSeconds,
+		&v.MovieID,
+		&v.MovieName,
+		&v.MovieReleaseYear,
+		&v.MovieSequence,
+		&v.MovieSeries,
+		&v.MovieRuntimeMinutes,
+	}
+}
+
+func (v trackView) toResponse() dto.TrackResponse {
+	return dto.TrackResponse{
+		ID:   v.ID,
+		Name: v.Name,
+		Movie: dto.MovieResponse{
+			ID:             v.MovieID,
+			Name:           v.MovieName,
+			ReleaseYear:    v.MovieReleaseYear,
+			Sequence:       v.MovieSequence,
+			Series:         v.MovieSeries,
+			RuntimeMinutes: v.MovieRuntimeMinutes,
+		},
+		SpotifyURL:      v.SpotifyURL,
+		TrackNumber:     v.TrackNumber,
+		DiscNumber:      v.DiscNumber,
+		Edition:         v.Edition,
+		DurationSeconds: v.DurationSeconds,
+	}
+}
+
+// TrackViewRepository reads tracks and their movie with a single query.
+type TrackViewRepository struct {
+	db        Executor
+	dbTimeout time.Duration
+}
+
+// NewTrackViewRepository creates a new TrackViewRepository.
+func NewTrackViewRepository(db Executor, dbTimeout time.Duration) *TrackViewRepository {
+	return &TrackViewRepository{
+		db:        db,
+		dbTimeout: dbTimeout,
+	}
+}
+
+func selectTrackView() *sqlbuilder.SelectBuilder {
+	sb := defaultFlavor.NewSelectBuilder()
+	sb.Select(trackViewColumns(sqlTrackTable, sqlMovieTable)...)
+	sb.From(sqlTrackTable)
+	sb.Join(sqlMovieTable, "movies.id = tracks.movie_id")
+	notDeleted(sb, sqlTrackTable)
+	notDeleted(sb, sqlMovieTable)
+	return sb
+}
+
+func (r *TrackViewRepository) FindPage(ctx context.Context, page domain.PageRequest) ([]dto.TrackResponse, *domain.Cursor, error) {
+	sb := selectTrackView()
+	if err := paginateByCreatedAt(sb, sqlTrackTable, page); err != nil {
+		return nil, nil, err
+	}
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	rows, err := executorFrom(ctxTimeout, r.db).QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find tracks page: %v", err)
+	}
+	defer rows.Close()
+
+	var tracks []dto.TrackResponse
+	var keys []string
+	for rows.Next() {
+		var view trackView
+		var createdAt time.Time
+		if err := rows.Scan(append(view.addr(), &createdAt)...); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan track: %v", err)
+		}
+		tracks = append(tracks, view.toResponse())
+		keys = append(keys, createdAtKey(createdAt))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to find tracks page: %v", err)
+	}
+
+	tracks, next := pageOf(tracks, keys, page, func(t dto.TrackResponse) string { return t.ID })
+	return tracks, next, nil
+}
+
+func (r *TrackViewRepository) FindByMovie(ctx context.Context, movieID domain.MovieID) ([]dto.TrackResponse, error) {
+	sb := selectTrackView()
+	sb.Where(sb.Equal("tracks.movie_id", movieID.String()))
+	orderByAlbum(sb)
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	rows, err := executorFrom(ctxTimeout, r.db).QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find tracks by movie: %v", err)
+	}
+	defer rows.Close()
+
+	var tracks []dto.TrackResponse
+	for rows.Next() {
+		var view trackView
+		if err := rows.Scan(view.addr()...); err != nil {
+			return nil, fmt.Errorf("failed to scan track: %v", err)
+		}
+		tracks = append(tracks, view.toResponse())
+	}
+
+	return tracks, nil
+}
@@ -0,0 +1,84 @@
+package sqldb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const querySelectTrackView = "SELECT tracks.id, tracks.name, tracks.spotify_url, tracks.track_number, tracks.disc_number, tracks.edition, tracks.duration_seconds, movies.id, movies.name, movies.release_year, movies.sequence, movies.series, movies.runtime_minutes FROM tracks JOIN movies ON movies.id = tracks.movie_id"
+
+func TestTrackViewRepositoryFindByMovieError(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery(querySelectTrackView + " WHERE tracks.deleted_at IS NULL AND movies.deleted_at IS NULL AND tracks.movie_id = $1 ORDER BY CASE tracks.edition WHEN 'theatrical' THEN 1 WHEN 'complete_recordings' THEN 2 END ASC, tracks.disc_number ASC, tracks.track_number ASC").
+		WithArgs(trackMovieID).
+		WillReturnError(errors.New(connectionErrorMsg))
+
+	repo := NewTrackViewRepository(db, 1*time.Second)
+
+	movieIDVO, err := domain.NewMovieIDFromString(trackMovieID)
+	require.NoError(t, err)
+
+	_, err = repo.FindByMovie(context.Background(), movieIDVO)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
+
+func TestTrackViewRepositoryFindByMovieSuccess(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	spotifyURL := "https://open.spotify.com/track/1"
+	sqlMock.ExpectQuery(querySelectTrackView + " WHERE tracks.deleted_at IS NULL AND movies.deleted_at IS NULL AND tracks.movie_id = $1 ORDER BY CASE tracks.edition WHEN 'theatrical' THEN 1 WHEN 'complete_recordings' THEN 2 END ASC, tracks.disc_number ASC, tracks.track_number ASC").
+		WithArgs(trackMovieID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "spotify_url", "track_number", "disc_number", "edition", "duration_seconds", "movie_id", "movie_name", "movie_release_year", "movie_sequence", "movie_series", "movie_runtime_minutes"}).
+			AddRow(trackID, trackName, spotifyURL, trackNumber, trackDiscNumber, trackEdition, trackDuration, trackMovieID, "The Fellowship of the Ring", 2001, 1, "lotr", nil).
+			AddRow("789e1011-e89b-12d3-a456-426614174222", "Concerning Hobbits", nil, 2, trackDiscNumber, trackEdition, 240, trackMovieID, "The Fellowship of the Ring", 2001, 1, "lotr", nil))
+
+	repo := NewTrackViewRepository(db, 1*time.Second)
+
+	movieIDVO, err := domain.NewMovieIDFromString(trackMovieID)
+	require.NoError(t, err)
+
+	tracks, err := repo.FindByMovie(context.Background(), movieIDVO)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+	require.Len(t, tracks, 2)
+	assert.Equal(t, trackName, tracks[0].Name)
+	assert.Equal(t, "The Fellowship of the Ring", tracks[0].Movie.Name)
+	require.NotNil(t, tracks[0].SpotifyURL)
+	assert.Equal(t, spotifyURL, *tracks[0].SpotifyURL)
+	assert.Nil(t, tracks[1].SpotifyURL)
+}
+
+func TestTrackViewRepositoryFindPageNextCursor(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	createdAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	sqlMock.ExpectQuery("SELECT tracks.id, tracks.name, tracks.spotify_url, tracks.track_number, tracks.disc_number, tracks.edition, tracks.duration_seconds, movies.id, movies.name, movies.release_year, movies.sequence, movies.series, movies.runtime_minutes, tracks.created_at FROM tracks JOIN movies ON movies.id = tracks.movie_id WHERE tracks.deleted_at IS NULL AND movies.deleted_at IS NULL ORDER BY tracks.created_at ASC, tracks.id ASC LIMIT $1").
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "spotify_url", "track_number", "disc_number", "edition", "duration_seconds", "movie_id", "movie_name", "movie_release_year", "movie_sequence", "movie_series", "movie_runtime_minutes", "created_at"}).
+			AddRow(trackID, trackName, nil, trackNumber, trackDiscNumber, trackEdition, trackDuration, trackMovieID, "The Fellowship of the Ring", 2001, 1, "lotr", nil, createdAt).
+			AddRow("789e1011-e89b-12d3-a456-426614174222", "Concerning Hobbits", nil, 2, trackDiscNumber, trackEdition, 240, trackMovieID, "The Fellowship of the Ring", 2001, 1, "lotr", nil, createdAt))
+
+	repo := NewTrackViewRepository(db, 1*time.Second)
+
+	page, err := domain.NewPageRequest(1, "")
+	require.NoError(t, err)
+
+	tracks, next, err := repo.FindPage(context.Background(), page)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+	assert.Len(t, tracks, 1)
+	require.NotNil(t, next)
+	assert.Equal(t, trackID, next.ID())
+}
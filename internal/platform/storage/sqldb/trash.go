@@ -0,0 +1,91 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/huandu/go-sqlbuilder"
+)
+
+// Movies, groups, categories, tracks and themes are soft deleted: Delete sets their
+// deleted_at column, which moves them to the trash, and every query but the trash listing
+// leaves them out. The rows stay, so foreign keys and the annotations in tracks_themes
+// remain intact until Purge deletes them for good.
+
+// notDeleted restricts a select to the rows of the table that are not in the trash.
+func notDeleted(sb *sqlbuilder.SelectBuilder, table string) {
+	sb.Where(sb.IsNull(table + ".deleted_at"))
+}
+
+// inTrash restricts a select to the rows of the table that are in the trash.
+func inTrash(sb *sqlbuilder.SelectBuilder, table string) {
+	sb.Where(sb.IsNotNull(table + ".deleted_at"))
+}
+
+// moveToTrashQuery builds the update that moves the row with the given id to the trash.
+// It affects no row when there is no such row or it is already in the trash.
+func moveToTrashQuery(table, id string) (string, []any) {
+	ub := defaultFlavor.NewUpdateBuilder()
+	ub.Update(table)
+	ub.Set("deleted_at = CURRENT_TIMESTAMP(0)")
+	ub.Where(ub.Equal("id", id), ub.IsNull("deleted_at"))
+	return ub.Build()
+}
+
+// restoreQuery builds the update that takes the row with the given id out of the trash.
+// It affects no row when the row is not in the trash.
+func restoreQuery(table, id string) (string, []any) {
+	ub := defaultFlavor.NewUpdateBuilder()
+	ub.Update(table)
+	ub.Set("deleted_at = NULL")
+	ub.Where(ub.Equal("id", id), ub.IsNotNull("deleted_at"))
+	return ub.Build()
+}
+
+// purgeQuery builds the delete of the row with the given id, provided it is in the trash.
+func purgeQuery(table, id string) (string, []any) {
+	db := defaultFlavor.NewDeleteBuilder()
+	db.DeleteFrom(table)
+	db.Where(db.Equal("id", id), db.IsNotNull("deleted_at"))
+	return db.Build()
+}
+
+// execOnRow runs a statement meant to change a single row and tells whether it did.
+func execOnRow(ctx context.Context, db Executor, query string, args []any) (bool, error) {
+	result, err := executorFrom(ctx, db).ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %v", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// requireLive checks that the row with the given id exists and is not in the trash, and
+// answers notFound otherwise. The foreign keys alone cannot tell, as trashed rows are kept.
+// The row is locked for share so it cannot be moved to the trash before the caller's write.
+func requireLive(ctx context.Context, db Executor, table, id string, notFound error) error {
+	sb := defaultFlavor.NewSelectBuilder()
+	sb.Select("1")
+	sb.From(table)
+	sb.Where(sb.Equal("id", id), sb.IsNull("deleted_at"))
+	sb.ForShare()
+	query, args := sb.Build()
+
+	var one int
+	err := executorFrom(ctx, db).QueryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return notFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check %s: %v", table, err)
+	}
+
+	return nil
+}
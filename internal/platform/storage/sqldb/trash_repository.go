@@ -0,0 +1,74 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
+)
+
+// trashTable unites the soft deleted rows of every table that has a trash.
+const trashTable = `(
+	SELECT 'movie' AS type, movies.id, movies.name, movies.deleted_at FROM movies WHERE movies.deleted_at IS NOT NULL
+	UNION ALL
+	SELECT 'group' AS type, groups.id, groups.name, groups.deleted_at FROM groups WHERE groups.deleted_at IS NOT NULL
+	UNION ALL
+	SELECT 'category' AS type, categories.id, categories.name, categories.deleted_at FROM categories WHERE categories.deleted_at IS NOT NULL
+	UNION ALL
+	SELECT 'track' AS type, tracks.id, tracks.name, tracks.deleted_at FROM tracks WHERE tracks.deleted_at IS NOT NULL
+	UNION ALL
+	SELECT 'theme' AS type, themes.id, themes.name, themes.deleted_at FROM themes WHERE themes.deleted_at IS NOT NULL
+) AS trash`
+
+// TrashRepository lists the catalogue entries in the trash.
+type TrashRepository struct {
+	db        Executor
+	dbTimeout time.Duration
+}
+
+// NewTrashRepository creates a new TrashRepository.
+func NewTrashRepository(db Executor, dbTimeout time.Duration) *TrashRepository {
+	return &TrashRepository{
+		db:        db,
+		dbTimeout: dbTimeout,
+	}
+}
+
+// FindPage returns a page of the entries in the trash, most recently deleted first.
+func (r *TrashRepository) FindPage(ctx context.Context, page domain.PageRequest) ([]dto.TrashItem, *domain.Cursor, error) {
+	sb := defaultFlavor.NewSelectBuilder()
+	sb.Select("trash.type", "trash.id", "trash.name")
+	sb.From(trashTable)
+	if err := paginateByDesc(sb, "trash", timeColumn("trash.deleted_at"), page); err != nil {
+		return nil, nil, err
+	}
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	rows, err := executorFrom(ctxTimeout, r.db).QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find trash: %v", err)
+	}
+	defer rows.Close()
+
+	var items []dto.TrashItem
+	var keys []string
+	for rows.Next() {
+		var item dto.TrashItem
+		if err := rows.Scan(&item.Type, &item.ID, &item.Name, &item.DeletedAt); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan trash item: %v", err)
+		}
+		items = append(items, item)
+		keys = append(keys, createdAtKey(item.DeletedAt))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to find trash: %v", err)
+	}
+
+	items, next := pageOf(items, keys, page, func(item dto.TrashItem) string { return item.ID })
+	return items, next, nil
+}
@@ -0,0 +1,86 @@
+package sqldb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const trashSelect = "SELECT trash.type, trash.id, trash.name, trash.deleted_at FROM " + trashTable
+
+var trashColumns = []string{"type", "id", "name", "deleted_at"}
+
+func TestTrashRepositoryFindPageError(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	page, err := domain.NewPageRequest(0, "")
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery(trashSelect + " ORDER BY trash.deleted_at DESC, trash.id DESC LIMIT $1").
+		WithArgs(domain.DefaultPageLimit + 1).
+		WillReturnError(errors.New("query error"))
+
+	repo := NewTrashRepository(db, 1*time.Second)
+
+	_, _, err = repo.FindPage(context.Background(), page)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
+
+func TestTrashRepositoryFindPageSuccess(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	page, err := domain.NewPageRequest(1, "")
+	require.NoError(t, err)
+
+	deletedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	sqlMock.ExpectQuery(trashSelect + " ORDER BY trash.deleted_at DESC, trash.id DESC LIMIT $1").
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows(trashColumns).
+			AddRow("theme", "123e4567-e89b-12d3-a456-426614174000", "The Shire", deletedAt).
+			AddRow("track", "6a4f86e4-4fef-4151-9c60-e467007dd213", "Concerning Hobbits", deletedAt.Add(-time.Hour)))
+
+	repo := NewTrashRepository(db, 1*time.Second)
+
+	items, next, err := repo.FindPage(context.Background(), page)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "theme", items[0].Type)
+	assert.Equal(t, "The Shire", items[0].Name)
+	assert.Equal(t, deletedAt, items[0].DeletedAt)
+	require.NotNil(t, next)
+	assert.Equal(t, "123e4567-e89b-12d3-a456-426614174000", next.ID())
+	assert.Equal(t, "2024-03-01T12:00:00Z", next.Key())
+}
+
+func TestTrashRepositoryFindPageAfterCursor(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	deletedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	page, err := domain.NewPageRequest(1, domain.NewCursor("2024-03-01T12:00:00Z", "123e4567-e89b-12d3-a456-426614174000").String())
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery(trashSelect+" WHERE (trash.deleted_at, trash.id) < ($1, $2) ORDER BY trash.deleted_at DESC, trash.id DESC LIMIT $3").
+		WithArgs(deletedAt, "123e4567-e89b-12d3-a456-426614174000", 2).
+		WillReturnRows(sqlmock.NewRows(trashColumns).
+			AddRow("track", "6a4f86e4-4fef-4151-9c60-e467007dd213", "Concerning Hobbits", deletedAt.Add(-time.Hour)))
+
+	repo := NewTrashRepository(db, 1*time.Second)
+
+	items, next, err := repo.FindPage(context.Background(), page)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "track", items[0].Type)
+	assert.Nil(t, next)
+}
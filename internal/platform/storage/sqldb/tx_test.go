@@ -0,0 +1,107 @@
+package sqldb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxManagerWithinTxCommit(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(deleteQuery).
+		WithArgs(trackID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectCommit()
+
+	repo := NewTrackRepository(db, 1*time.Second)
+	txManager := NewTxManager(db)
+
+	trackIDVO, err := domain.NewTrackIDFromString(trackID)
+	require.NoError(t, err)
+
+	err = txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+		return repo.Delete(ctx, trackIDVO)
+	})
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
+
+func TestTxManagerWithinTxRollback(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(deleteQuery).
+		WithArgs(trackID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectRollback()
+
+	repo := NewTrackRepository(db, 1*time.Second)
+	txManager := NewTxManager(db)
+
+	trackIDVO, err := domain.NewTrackIDFromString(trackID)
+	require.NoError(t, err)
+
+	err = txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+		return repo.Delete(ctx, trackIDVO)
+	})
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.ErrorIs(t, err, domain.ErrTrackNotFound)
+}
+
+func TestTxManagerWithinTxNested(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(deleteQuery).
+		WithArgs(trackID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectCommit()
+
+	repo := NewTrackRepository(db, 1*time.Second)
+	txManager := NewTxManager(db)
+
+	trackIDVO, err := domain.NewTrackIDFromString(trackID)
+	require.NoError(t, err)
+
+	// The inner call joins the outer transaction instead of beginning a second one
+	err = txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+		return txManager.WithinTx(ctx, func(ctx context.Context) error {
+			return repo.Delete(ctx, trackIDVO)
+		})
+	})
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
+
+func TestTxManagerWithinTxBeginError(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectBegin().WillReturnError(errors.New(connectionErrorMsg))
+
+	txManager := NewTxManager(db)
+
+	called := false
+	err = txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+	assert.False(t, called)
+}
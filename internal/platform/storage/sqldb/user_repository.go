@@ -16,20 +16,29 @@ type UserDB struct {
 	Name     string `db:"name"`
 	Email    string `db:"email"`
 	Password string `db:"password"`
-	IsAdmin  bool   `db:"is_admin"`
+	Role     string `db:"role"`
+	Verified bool   `db:"verified"`
 }
 
 var sqlUserTable = "users"
 var userSQLStruct = sqlbuilder.NewStruct(new(UserDB)).For(defaultFlavor)
 
+// countByPermissionQuery counts the users whose role grants a permission, locking their
+// rows so that concurrent transactions cannot take it away from all of them at once.
+const countByPermissionQuery = `SELECT COUNT(*) FROM (
+	SELECT users.id FROM users JOIN roles ON roles.name = users.role
+	WHERE $1 = ANY(roles.permissions)
+	FOR UPDATE OF users
+) AS holders`
+
 // UserRepository implements the UserRepository interface for SQL.
 type UserRepository struct {
-	db        *sql.DB
+	db        Executor
 	dbTimeout time.Duration
 }
 
 // NewUserRepository creates a new UserRepository instance.
-func NewUserRepository(db *sql.DB, dbTimeout time.Duration) *UserRepository {
+func NewUserRepository(db Executor, dbTimeout time.Duration) *UserRepository {
 	return &UserRepository{
 		db:        db,
 		dbTimeout: dbTimeout,
@@ -42,7 +51,8 @@ func userToDTO(user domain.User) UserDB {
 		Name:     user.Name().String(),
 		Email:    user.Email().String(),
 		Password: user.Password().String(),
-		IsAdmin:  user.IsAdmin().Bool(),
+		Role:     user.Role().String(),
+		Verified: user.Verified(),
 	}
 }
 
@@ -52,7 +62,8 @@ func userToDomain(dto UserDB) (domain.User, error) {
 		dto.Name,
 		dto.Email,
 		dto.Password,
-		dto.IsAdmin,
+		dto.Role,
+		dto.Verified,
 	)
 }
 
@@ -75,8 +86,11 @@ func (r *UserRepository) Save(ctx context.Context, user domain.User) error {
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	_, err = r.db.ExecContext(ctxTimeout, query, args...)
+	_, err = executorFrom(ctxTimeout, r.db).ExecContext(ctxTimeout, query, args...)
 	if err != nil {
+		if constraintErr := writeError(err); constraintErr != nil {
+			return constraintErr
+		}
 		return fmt.Errorf("failed to save user: %v", err)
 	}
 
@@ -92,7 +106,7 @@ func (r *UserRepository) Find(ctx context.Context, id domain.UserID) (domain.Use
 	defer cancel()
 
 	var userDTO UserDB
-	err := r.db.QueryRowContext(ctxTimeout, query, args...).Scan(userSQLStruct.Addr(&userDTO)...)
+	err := executorFrom(ctxTimeout, r.db).QueryRowContext(ctxTimeout, query, args...).Scan(userSQLStruct.Addr(&userDTO)...)
 	if errors.Is(err, sql.ErrNoRows) {
 		return domain.User{}, domain.ErrUserNotFound
 	}
@@ -113,7 +127,7 @@ func (r *UserRepository) FindByEmail(ctx context.Context, email domain.UserEmail
 	defer cancel()
 
 	var userDTO UserDB
-	err := r.db.QueryRowContext(ctxTimeout, query, args...).Scan(userSQLStruct.Addr(&userDTO)...)
+	err := executorFrom(ctxTimeout, r.db).QueryRowContext(ctxTimeout, query, args...).Scan(userSQLStruct.Addr(&userDTO)...)
 	if errors.Is(err, sql.ErrNoRows) {
 		return domain.User{}, domain.ErrUserNotFound
 	}
@@ -131,7 +145,7 @@ func (r *UserRepository) FindAll(ctx context.Context) ([]domain.User, error) {
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	rows, err := r.db.QueryContext(ctxTimeout, query, args...)
+	rows, err := executorFrom(ctxTimeout, r.db).QueryContext(ctxTimeout, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find users: %v", err)
 	}
@@ -152,3 +166,101 @@ func (r *UserRepository) FindAll(ctx context.Context) ([]domain.User, error) {
 
 	return users, nil
 }
+
+func (r *UserRepository) FindPage(ctx context.Context, page domain.PageRequest) ([]domain.User, *domain.Cursor, error) {
+	sb := userSQLStruct.SelectFrom(sqlUserTable)
+	if err := paginateByCreatedAt(sb, sqlUserTable, page); err != nil {
+		return nil, nil, err
+	}
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	rows, err := executorFrom(ctxTimeout, r.db).QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find users page: %v", err)
+	}
+	defer rows.Close()
+
+	var users []domain.User
+	var keys []string
+	for rows.Next() {
+		var userDTO UserDB
+		var createdAt time.Time
+		if err := rows.Scan(append(userSQLStruct.Addr(&userDTO), &createdAt)...); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan user: %v", err)
+		}
+		user, err := userToDomain(userDTO)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to convert user: %v", err)
+		}
+		users = append(users, user)
+		keys = append(keys, createdAtKey(createdAt))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to find users page: %v", err)
+	}
+
+	users, next := pageOf(users, keys, page, func(u domain.User) string { return u.ID().String() })
+	return users, next, nil
+}
+
+// Update replaces the name, email, password, role and verification of a user.
+func (r *UserRepository) Update(ctx context.Context, user domain.User) error {
+	row := userToDTO(user)
+	sb := userSQLStruct.Update(sqlUserTable, row)
+	sb.Where(sb.Equal("id", row.ID))
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	found, err := execOnRow(ctxTimeout, r.db, query, args)
+	if err != nil {
+		if constraintErr := writeError(err); constraintErr != nil {
+			return constraintErr
+		}
+		return fmt.Errorf("failed to update user: %v", err)
+	}
+	if !found {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a user. Their sessions are removed by the ON DELETE CASCADE of
+// sessions.user_id.
+func (r *UserRepository) Delete(ctx context.Context, id domain.UserID) error {
+	db := defaultFlavor.NewDeleteBuilder()
+	db.DeleteFrom(sqlUserTable)
+	db.Where(db.Equal("id", id.String()))
+	query, args := db.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	found, err := execOnRow(ctxTimeout, r.db, query, args)
+	if err != nil {
+		return fmt.Errorf("failed to delete user: %v", err)
+	}
+	if !found {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRepository) CountByPermission(ctx context.Context, permission domain.Permission) (int, error) {
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	var count int
+	err := executorFrom(ctxTimeout, r.db).QueryRowContext(ctxTimeout, countByPermissionQuery, permission.String()).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count users by permission: %v", err)
+	}
+
+	return count, nil
+}
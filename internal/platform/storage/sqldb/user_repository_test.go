@@ -17,11 +17,11 @@ const name = "Test User"
 const userEmail = "test@example.com"
 const userPassword = "password123"
 
-const querySelectUserByEmail = "SELECT users.id, users.name, users.email, users.password, users.is_admin FROM users WHERE email = $1"
-const querySelectAllUsers = "SELECT users.id, users.name, users.email, users.password, users.is_admin FROM users"
+const querySelectUserByEmail = "SELECT users.id, users.name, users.email, users.password, users.role, users.verified FROM users WHERE email = $1"
+const querySelectAllUsers = "SELECT users.id, users.name, users.email, users.password, users.role, users.verified FROM users"
 
 func TestUserRepositorySaveRepositoryError(t *testing.T) {
-	user, err := domain.NewUserWithID(userID, name, userEmail, userPassword, false)
+	user, err := domain.NewUserWithID(userID, name, userEmail, userPassword, domain.RoleViewer, true)
 	require.NoError(t, err)
 
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
@@ -29,11 +29,11 @@ func TestUserRepositorySaveRepositoryError(t *testing.T) {
 
 	sqlMock.ExpectQuery(querySelectUserByEmail).
 		WithArgs(userEmail).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "password", "is_admin"}))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "password", "role", "verified"}))
 
 	sqlMock.ExpectExec(
-		"INSERT INTO users (id, name, email, password, is_admin) VALUES ($1, $2, $3, $4, $5)").
-		WithArgs(userID, name, userEmail, userPassword, false).
+		"INSERT INTO users (id, name, email, password, role, verified) VALUES ($1, $2, $3, $4, $5, $6)").
+		WithArgs(userID, name, userEmail, userPassword, domain.RoleViewer, true).
 		WillReturnError(errors.New("database error"))
 
 	repo := NewUserRepository(db, 1*time.Second)
@@ -44,7 +44,7 @@ func TestUserRepositorySaveRepositoryError(t *testing.T) {
 }
 
 func TestUserRepositorySaveSuccess(t *testing.T) {
-	user, err := domain.NewUserWithID(userID, name, userEmail, userPassword, false)
+	user, err := domain.NewUserWithID(userID, name, userEmail, userPassword, domain.RoleViewer, true)
 	require.NoError(t, err)
 
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
@@ -52,11 +52,11 @@ func TestUserRepositorySaveSuccess(t *testing.T) {
 
 	sqlMock.ExpectQuery(querySelectUserByEmail).
 		WithArgs(userEmail).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "password", "is_admin"}))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "password", "role", "verified"}))
 
 	sqlMock.ExpectExec(
-		"INSERT INTO users (id, name, email, password, is_admin) VALUES ($1, $2, $3, $4, $5)").
-		WithArgs(userID, name, userEmail, userPassword, false).
+		"INSERT INTO users (id, name, email, password, role, verified) VALUES ($1, $2, $3, $4, $5, $6)").
+		WithArgs(userID, name, userEmail, userPassword, domain.RoleViewer, true).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	repo := NewUserRepository(db, 1*time.Second)
@@ -71,9 +71,9 @@ func TestUserRepositoryFindUserNotFound(t *testing.T) {
 	require.NoError(t, err)
 
 	sqlMock.ExpectQuery(
-		"SELECT users.id, users.name, users.email, users.password, users.is_admin FROM users WHERE id = $1").
+		"SELECT users.id, users.name, users.email, users.password, users.role, users.verified FROM users WHERE id = $1").
 		WithArgs(userID).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "password", "is_admin"}))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "password", "role", "verified"}))
 
 	repo := NewUserRepository(db, 1*time.Second)
 
@@ -87,7 +87,7 @@ func TestUserRepositoryFindUserNotFound(t *testing.T) {
 }
 
 func TestUserRepositorySaveUserExistsError(t *testing.T) {
-	user, err := domain.NewUserWithID(userID, name, userEmail, userPassword, false)
+	user, err := domain.NewUserWithID(userID, name, userEmail, userPassword, domain.RoleViewer, true)
 	require.NoError(t, err)
 
 	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
@@ -95,8 +95,8 @@ func TestUserRepositorySaveUserExistsError(t *testing.T) {
 
 	sqlMock.ExpectQuery(querySelectUserByEmail).
 		WithArgs(userEmail).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "password", "is_admin"}).
-			AddRow(userID, name, userEmail, userPassword, false))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "password", "role", "verified"}).
+			AddRow(userID, name, userEmail, userPassword, domain.RoleViewer, true))
 
 	repo := NewUserRepository(db, 1*time.Second)
 
@@ -113,7 +113,7 @@ func TestUserRepositoryFindByEmailNotFound(t *testing.T) {
 
 	sqlMock.ExpectQuery(querySelectUserByEmail).
 		WithArgs(userEmail).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "password", "is_admin"}))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "password", "role", "verified"}))
 
 	repo := NewUserRepository(db, 1*time.Second)
 
@@ -132,8 +132,8 @@ func TestUserRepositoryFindByEmailSuccess(t *testing.T) {
 
 	sqlMock.ExpectQuery(querySelectUserByEmail).
 		WithArgs(userEmail).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "password", "is_admin"}).
-			AddRow(userID, name, userEmail, userPassword, false))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "password", "role", "verified"}).
+			AddRow(userID, name, userEmail, userPassword, domain.RoleViewer, true))
 
 	repo := NewUserRepository(db, 1*time.Second)
 
@@ -151,9 +151,9 @@ func TestUserRepositoryFindAllSuccess(t *testing.T) {
 
 	sqlMock.ExpectQuery(
 		querySelectAllUsers).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "password", "is_admin"}).
-			AddRow(userID, name, userEmail, userPassword, false).
-			AddRow("223e4567-e89b-12d3-a456-426614174001", "Another User", "another.user@example.com", "password123", false))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "password", "role", "verified"}).
+			AddRow(userID, name, userEmail, userPassword, domain.RoleViewer, true).
+			AddRow("223e4567-e89b-12d3-a456-426614174001", "Another User", "another.user@example.com", "password123", domain.RoleViewer, true))
 
 	repo := NewUserRepository(db, 1*time.Second)
 
@@ -169,7 +169,7 @@ func TestUserRepositoryFindAllEmpty(t *testing.T) {
 
 	sqlMock.ExpectQuery(
 		querySelectAllUsers).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "password", "is_admin"}))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "password", "role", "verified"}))
 
 	repo := NewUserRepository(db, 1*time.Second)
 
@@ -194,3 +194,91 @@ func TestUserRepositoryFindAllQueryError(t *testing.T) {
 	assert.Error(t, err)
 	assert.Len(t, users, 0)
 }
+
+func TestUserRepositoryUpdateSuccess(t *testing.T) {
+	user, err := domain.NewUserWithID(userID, name, userEmail, userPassword, domain.RoleEditor, true)
+	require.NoError(t, err)
+
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec("UPDATE users SET id = $1, name = $2, email = $3, password = $4, role = $5, verified = $6 WHERE id = $7").
+		WithArgs(userID, name, userEmail, userPassword, domain.RoleEditor, true, userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := NewUserRepository(db, 1*time.Second)
+
+	err = repo.Update(context.Background(), user)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
+
+func TestUserRepositoryUpdateUserNotFound(t *testing.T) {
+	user, err := domain.NewUserWithID(userID, name, userEmail, userPassword, domain.RoleViewer, true)
+	require.NoError(t, err)
+
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec("UPDATE users SET id = $1, name = $2, email = $3, password = $4, role = $5, verified = $6 WHERE id = $7").
+		WithArgs(userID, name, userEmail, userPassword, domain.RoleViewer, true, userID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := NewUserRepository(db, 1*time.Second)
+
+	err = repo.Update(context.Background(), user)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func TestUserRepositoryDeleteSuccess(t *testing.T) {
+	id, err := domain.NewUserIDFromString(userID)
+	require.NoError(t, err)
+
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec("DELETE FROM users WHERE id = $1").
+		WithArgs(userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := NewUserRepository(db, 1*time.Second)
+
+	err = repo.Delete(context.Background(), id)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
+
+func TestUserRepositoryDeleteUserNotFound(t *testing.T) {
+	id, err := domain.NewUserIDFromString(userID)
+	require.NoError(t, err)
+
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec("DELETE FROM users WHERE id = $1").
+		WithArgs(userID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := NewUserRepository(db, 1*time.Second)
+
+	err = repo.Delete(context.Background(), id)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func TestUserRepositoryCountByPermission(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery(countByPermissionQuery).
+		WithArgs("users:manage").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	repo := NewUserRepository(db, 1*time.Second)
+
+	count, err := repo.CountByPermission(context.Background(), domain.PermissionManageUsers)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
@@ -0,0 +1,124 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+type VerificationTokenDB struct {
+	TokenHash string    `db:"token_hash"`
+	UserID    string    `db:"user_id"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
+
+var sqlVerificationTokenTable = "verification_tokens"
+var verificationTokenSQLStruct = sqlbuilder.NewStruct(new(VerificationTokenDB)).For(defaultFlavor)
+
+// VerificationTokenRepository implements the VerificationTokenRepository interface for SQL.
+type VerificationTokenRepository struct {
+	db        Executor
+	dbTimeout time.Duration
+}
+
+// NewVerificationTokenRepository creates a new VerificationTokenRepository.
+func NewVerificationTokenRepository(db Executor, dbTimeout time.Duration) *VerificationTokenRepository {
+	return &VerificationTokenRepository{
+		db:        db,
+		dbTimeout: dbTimeout,
+	}
+}
+
+func verificationTokenToDTO(token domain.VerificationToken) VerificationTokenDB {
+	return VerificationTokenDB{
+		TokenHash: token.TokenHash(),
+		UserID:    token.UserID().String(),
+		ExpiresAt: token.ExpiresAt(),
+	}
+}
+
+func verificationTokenToDomain(dto VerificationTokenDB) (domain.VerificationToken, error) {
+	return domain.NewVerificationToken(dto.TokenHash, dto.UserID, dto.ExpiresAt)
+}
+
+func (r *VerificationTokenRepository) Save(ctx context.Context, token domain.VerificationToken) error {
+	query, args := verificationTokenSQLStruct.InsertInto(sqlVerificationTokenTable, verificationTokenToDTO(token)).Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	_, err := executorFrom(ctxTimeout, r.db).ExecContext(ctxTimeout, query, args...)
+	if err != nil {
+		if constraintErr := writeError(err); constraintErr != nil {
+			return constraintErr
+		}
+		return fmt.Errorf("failed to save verification token: %v", err)
+	}
+
+	return nil
+}
+
+func (r *VerificationTokenRepository) FindByTokenHash(ctx context.Context, tokenHash string) (domain.VerificationToken, error) {
+	sb := verificationTokenSQLStruct.SelectFrom(sqlVerificationTokenTable)
+	sb.Where(sb.Equal("token_hash", tokenHash))
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	var tokenDTO VerificationTokenDB
+	err := executorFrom(ctxTimeout, r.db).QueryRowContext(ctxTimeout, query, args...).Scan(verificationTokenSQLStruct.Addr(&tokenDTO)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.VerificationToken{}, domain.ErrInvalidVerificationToken
+	}
+	if err != nil {
+		return domain.VerificationToken{}, fmt.Errorf("failed to find verification token: %v", err)
+	}
+
+	return verificationTokenToDomain(tokenDTO)
+}
+
+func (r *VerificationTokenRepository) Delete(ctx context.Context, tokenHash string) error {
+	db := defaultFlavor.NewDeleteBuilder()
+	db.DeleteFrom(sqlVerificationTokenTable)
+	db.Where(db.Equal("token_hash", tokenHash))
+	query, args := db.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	_, err := executorFrom(ctxTimeout, r.db).ExecContext(ctxTimeout, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete verification token: %v", err)
+	}
+
+	return nil
+}
+
+func (r *VerificationTokenRepository) ValidAt(ctx context.Context, userID domain.UserID, t time.Time) (bool, error) {
+	sb := defaultFlavor.NewSelectBuilder()
+	sb.Select("1")
+	sb.From(sqlVerificationTokenTable)
+	sb.Where(sb.Equal("user_id", userID.String()), sb.GreaterThan("expires_at", t))
+	sb.Limit(1)
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	var one int
+	err := executorFrom(ctxTimeout, r.db).QueryRowContext(ctxTimeout, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find valid verification tokens: %v", err)
+	}
+
+	return true, nil
+}
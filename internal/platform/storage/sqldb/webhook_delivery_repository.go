@@ -0,0 +1,252 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+// WebhookDeliveryDB is a row of the delivery log of the webhooks, an attempt that was made.
+type WebhookDeliveryDB struct {
+	ID          string    `db:"id"`
+	WebhookID   string    `db:"webhook_id"`
+	EventID     string    `db:"event_id"`
+	EventType   string    `db:"event_type"`
+	Attempt     int       `db:"attempt"`
+	StatusCode  *int      `db:"status_code"`
+	Error       *string   `db:"error"`
+	DeliveredAt time.Time `db:"delivered_at"`
+}
+
+// PendingWebhookDeliveryDB is a row of an attempt still to be made, along with the event
+// it posts. The claimed_at column and the outcome of the attempt are left to the workers.
+// The payload is written as text, which PostgreSQL casts to JSONB.
+type PendingWebhookDeliveryDB struct {
+	ID            string    `db:"id"`
+	WebhookID     string    `db:"webhook_id"`
+	EventID       string    `db:"event_id"`
+	EventType     string    `db:"event_type"`
+	AggregateID   string    `db:"aggregate_id"`
+	OccurredOn    time.Time `db:"occurred_on"`
+	Payload       string    `db:"payload"`
+	Attempt       int       `db:"attempt"`
+	NextAttemptAt time.Time `db:"next_attempt_at"`
+}
+
+var sqlWebhookDeliveryTable = "webhook_deliveries"
+var webhookDeliverySQLStruct = sqlbuilder.NewStruct(new(WebhookDeliveryDB)).For(defaultFlavor)
+var pendingWebhookDeliverySQLStruct = sqlbuilder.NewStruct(new(PendingWebhookDeliveryDB)).For(defaultFlavor)
+
+// claimWebhookDeliveriesQuery claims the due attempts that have not been made and whose
+// lease, if any, has run out. The rows other workers hold locks on are skipped rather
+// than waited for. The builder has no SKIP LOCKED, hence the raw SQL.
+const claimWebhookDeliveriesQuery = `WITH claimed AS (
+	UPDATE webhook_deliveries SET claimed_at = $1
+	WHERE id IN (
+		SELECT id FROM webhook_deliveries
+		WHERE delivered_at IS NULL AND next_attempt_at <= $1 AND (claimed_at IS NULL OR claimed_at < $2)
+		ORDER BY next_attempt_at
+		LIMIT $3
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING id, webhook_id, event_id, event_type, aggregate_id, occurred_on, payload, attempt, next_attempt_at
+)
+SELECT id, webhook_id, event_id, event_type, aggregate_id, occurred_on, payload, attempt, next_attempt_at FROM claimed ORDER BY next_attempt_at`
+
+// WebhookDeliveryRepository implements the WebhookDeliveryRepository interface for SQL.
+// The events are encoded with codec, which has to know every event posted to a webhook.
+type WebhookDeliveryRepository struct {
+	db        Executor
+	dbTimeout time.Duration
+	codec     event.Codec
+}
+
+// NewWebhookDeliveryRepository creates a new WebhookDeliveryRepository.
+func NewWebhookDeliveryRepository(db Executor, dbTimeout time.Duration, codec event.Codec) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{
+		db:        db,
+		dbTimeout: dbTimeout,
+		codec:     codec,
+	}
+}
+
+func webhookDeliveryToDomain(dto WebhookDeliveryDB) (domain.WebhookDelivery, error) {
+	var statusCode int
+	if dto.StatusCode != nil {
+		statusCode = *dto.StatusCode
+	}
+	var deliveryErr string
+	if dto.Error != nil {
+		deliveryErr = *dto.Error
+	}
+
+	return domain.NewWebhookDeliveryWithID(dto.ID, dto.WebhookID, dto.EventID, dto.EventType, dto.Attempt, statusCode, deliveryErr, dto.DeliveredAt)
+}
+
+// Schedule inserts the attempt, skipping it when the same attempt at posting the event to
+// the webhook is already there.
+func (r *WebhookDeliveryRepository) Schedule(ctx context.Context, delivery domain.PendingWebhookDelivery) error {
+	e := delivery.Event()
+	payload, err := r.codec.Encode(e)
+	if err != nil {
+		return fmt.Errorf("failed to schedule webhook delivery: %v", err)
+	}
+
+	ib := pendingWebhookDeliverySQLStruct.InsertInto(sqlWebhookDeliveryTable, PendingWebhookDeliveryDB{
+		ID:            delivery.ID(),
+		WebhookID:     delivery.WebhookID().String(),
+		EventID:       e.ID(),
+		EventType:     string(e.Type()),
+		AggregateID:   e.AggregateID(),
+		OccurredOn:    e.OccurredOn(),
+		Payload:       string(payload),
+		Attempt:       delivery.Attempt(),
+		NextAttemptAt: delivery.DueAt(),
+	})
+	ib.SQL("ON CONFLICT (webhook_id, event_id, attempt) DO NOTHING")
+	query, args := ib.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	_, err = executorFrom(ctxTimeout, r.db).ExecContext(ctxTimeout, query, args...)
+	if err != nil {
+		if constraintErr := writeError(err); constraintErr != nil {
+			return constraintErr
+		}
+		return fmt.Errorf("failed to schedule webhook delivery: %v", err)
+	}
+
+	return nil
+}
+
+// Claim returns the claimed attempts. The rows the codec cannot decode, say of an event
+// type that no longer exists, are recorded as failed attempts instead, so that they are
+// not claimed again.
+func (r *WebhookDeliveryRepository) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.PendingWebhookDelivery, error) {
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	rows, err := executorFrom(ctxTimeout, r.db).QueryContext(ctxTimeout, claimWebhookDeliveriesQuery, now, now.Add(-lease), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %v", err)
+	}
+	defer rows.Close()
+
+	var deliveries []domain.PendingWebhookDelivery
+	var undecodable []domain.WebhookDelivery
+	for rows.Next() {
+		var pendingDTO PendingWebhookDeliveryDB
+		if err := rows.Scan(pendingWebhookDeliverySQLStruct.Addr(&pendingDTO)...); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %v", err)
+		}
+
+		base := event.RestoreBaseEvent(pendingDTO.EventID, pendingDTO.AggregateID, pendingDTO.OccurredOn)
+		e, err := r.codec.Decode(base, event.Type(pendingDTO.EventType), []byte(pendingDTO.Payload))
+		if err != nil {
+			failed, recordErr := domain.NewWebhookDeliveryWithID(pendingDTO.ID, pendingDTO.WebhookID, pendingDTO.EventID, pendingDTO.EventType, pendingDTO.Attempt, 0, err.Error(), now)
+			if recordErr != nil {
+				return nil, fmt.Errorf("failed to convert webhook delivery: %v", recordErr)
+			}
+			undecodable = append(undecodable, failed)
+			continue
+		}
+
+		delivery, err := domain.NewPendingWebhookDeliveryWithID(pendingDTO.ID, pendingDTO.WebhookID, e, pendingDTO.Attempt, pendingDTO.NextAttemptAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert webhook delivery: %v", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %v", err)
+	}
+	// A transaction runs one statement at a time, so the rows are done with first
+	rows.Close()
+
+	for _, failed := range undecodable {
+		if err := r.Record(ctxTimeout, failed); err != nil {
+			return nil, err
+		}
+	}
+
+	return deliveries, nil
+}
+
+// Record fills in the outcome of the attempt. An attempt that is no longer there went
+// with its webhook, hence domain.ErrWebhookNotFound.
+func (r *WebhookDeliveryRepository) Record(ctx context.Context, delivery domain.WebhookDelivery) error {
+	var statusCode *int
+	if code := delivery.StatusCode(); code != 0 {
+		statusCode = &code
+	}
+
+	ub := defaultFlavor.NewUpdateBuilder()
+	ub.Update(sqlWebhookDeliveryTable)
+	ub.Set(
+		ub.Assign("status_code", statusCode),
+		ub.Assign("error", nullString(delivery.Err())),
+		ub.Assign("delivered_at", delivery.DeliveredAt()),
+	)
+	ub.Where(ub.Equal("id", delivery.ID()))
+	query, args := ub.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	found, err := execOnRow(ctxTimeout, r.db, query, args)
+	if err != nil {
+		return fmt.Errorf("failed to record webhook delivery: %v", err)
+	}
+	if !found {
+		return domain.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// FindPage returns a page of the attempts made at posting events to the webhook, newest
+// first.
+func (r *WebhookDeliveryRepository) FindPage(ctx context.Context, webhookID domain.WebhookID, page domain.PageRequest) ([]domain.WebhookDelivery, *domain.Cursor, error) {
+	sb := webhookDeliverySQLStruct.SelectFrom(sqlWebhookDeliveryTable)
+	sb.Where(sb.Equal("webhook_id", webhookID.String()), sb.IsNotNull("delivered_at"))
+	if err := paginateByDesc(sb, sqlWebhookDeliveryTable, timeColumn(sqlWebhookDeliveryTable+".delivered_at"), page); err != nil {
+		return nil, nil, err
+	}
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	rows, err := executorFrom(ctxTimeout, r.db).QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find webhook deliveries: %v", err)
+	}
+	defer rows.Close()
+
+	var deliveries []domain.WebhookDelivery
+	var keys []string
+	for rows.Next() {
+		var deliveryDTO WebhookDeliveryDB
+		var deliveredAt time.Time
+		if err := rows.Scan(append(webhookDeliverySQLStruct.Addr(&deliveryDTO), &deliveredAt)...); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan webhook delivery: %v", err)
+		}
+		delivery, err := webhookDeliveryToDomain(deliveryDTO)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to convert webhook delivery: %v", err)
+		}
+		deliveries = append(deliveries, delivery)
+		keys = append(keys, createdAtKey(deliveredAt))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to find webhook deliveries: %v", err)
+	}
+
+	deliveries, next := pageOf(deliveries, keys, page, func(d domain.WebhookDelivery) string { return d.ID() })
+	return deliveries, next, nil
+}
@@ -0,0 +1,244 @@
+package sqldb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	webhookDeliveryID      = "5b0e2c1a-7d3f-4c8e-b1a2-9f6d4e3c2b10"
+	webhookDeliveryEventID = "9e8d7c6b-5a4f-4e3d-8c2b-1a0f9e8d7c6b"
+	webhookDeliveryInsert  = "INSERT INTO webhook_deliveries (id, webhook_id, event_id, event_type, aggregate_id, occurred_on, payload, attempt, next_attempt_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) ON CONFLICT (webhook_id, event_id, attempt) DO NOTHING"
+	webhookDeliveryRecord  = "UPDATE webhook_deliveries SET status_code = $1, error = $2, delivered_at = $3 WHERE id = $4"
+	webhookDeliverySelect  = "SELECT webhook_deliveries.id, webhook_deliveries.webhook_id, webhook_deliveries.event_id, webhook_deliveries.event_type, webhook_deliveries.attempt, webhook_deliveries.status_code, webhook_deliveries.error, webhook_deliveries.delivered_at, webhook_deliveries.delivered_at FROM webhook_deliveries WHERE webhook_id = $1 AND delivered_at IS NOT NULL"
+)
+
+var (
+	webhookDeliveryColumns        = []string{"id", "webhook_id", "event_id", "event_type", "attempt", "status_code", "error", "delivered_at"}
+	pendingWebhookDeliveryColumns = []string{"id", "webhook_id", "event_id", "event_type", "aggregate_id", "occurred_on", "payload", "attempt", "next_attempt_at"}
+)
+
+func newPendingWebhookDelivery(t *testing.T, e event.Event, dueAt time.Time) domain.PendingWebhookDelivery {
+	t.Helper()
+
+	delivery, err := domain.NewPendingWebhookDeliveryWithID(webhookDeliveryID, webhookID, e, 2, dueAt)
+	require.NoError(t, err)
+	return delivery
+}
+
+func TestWebhookDeliveryRepositoryScheduleSuccess(t *testing.T) {
+	dueAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	e := domain.NewMovieCreatedEvent(auditMovieID, "The Two Towers")
+
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec(webhookDeliveryInsert).
+		WithArgs(webhookDeliveryID, webhookID, e.ID(), "events.movie.created", auditMovieID, e.OccurredOn(), `{"name":"The Two Towers"}`, 2, dueAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := NewWebhookDeliveryRepository(db, 1*time.Second, domain.EventCodec{})
+
+	err = repo.Schedule(context.Background(), newPendingWebhookDelivery(t, e, dueAt))
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
+
+func TestWebhookDeliveryRepositoryScheduleWebhookNotFound(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec(webhookDeliveryInsert).
+		WillReturnError(&pq.Error{Code: "23503", Constraint: "webhook_deliveries_webhook_id_fkey"})
+
+	repo := NewWebhookDeliveryRepository(db, 1*time.Second, domain.EventCodec{})
+
+	err = repo.Schedule(context.Background(), newPendingWebhookDelivery(t, domain.NewMovieCreatedEvent(auditMovieID, "The Two Towers"), time.Now()))
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+}
+
+func TestWebhookDeliveryRepositoryClaimSuccess(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	occurredOn := now.Add(-time.Hour)
+
+	sqlMock.ExpectQuery(claimWebhookDeliveriesQuery).
+		WithArgs(now, now.Add(-time.Minute), 10).
+		WillReturnRows(sqlmock.NewRows(pendingWebhookDeliveryColumns).
+			AddRow(webhookDeliveryID, webhookID, webhookDeliveryEventID, "events.movie.updated", auditMovieID, occurredOn, `{"name":"The Two Towers"}`, 2, now))
+
+	repo := NewWebhookDeliveryRepository(db, 1*time.Second, domain.EventCodec{})
+
+	deliveries, err := repo.Claim(context.Background(), now, time.Minute, 10)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, webhookDeliveryID, deliveries[0].ID())
+	assert.Equal(t, 2, deliveries[0].Attempt())
+	assert.Equal(t, now, deliveries[0].DueAt())
+
+	movie, ok := deliveries[0].Event().(domain.MovieUpdatedEvent)
+	require.True(t, ok, "claimed a %T", deliveries[0].Event())
+	assert.Equal(t, webhookDeliveryEventID, movie.ID())
+	assert.Equal(t, auditMovieID, movie.AggregateID())
+	assert.Equal(t, occurredOn, movie.OccurredOn())
+}
+
+func TestWebhookDeliveryRepositoryClaimRecordsUndecodable(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	sqlMock.ExpectQuery(claimWebhookDeliveriesQuery).
+		WithArgs(now, now.Add(-time.Minute), 10).
+		WillReturnRows(sqlmock.NewRows(pendingWebhookDeliveryColumns).
+			AddRow(webhookDeliveryID, webhookID, webhookDeliveryEventID, "events.movie.renamed", auditMovieID, now, `{}`, 1, now))
+	sqlMock.ExpectExec(webhookDeliveryRecord).
+		WithArgs(nil, sqlmock.AnyArg(), now, webhookDeliveryID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := NewWebhookDeliveryRepository(db, 1*time.Second, domain.EventCodec{})
+
+	deliveries, err := repo.Claim(context.Background(), now, time.Minute, 10)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	require.NoError(t, err)
+	assert.Empty(t, deliveries)
+}
+
+func TestWebhookDeliveryRepositoryClaimError(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery(claimWebhookDeliveriesQuery).
+		WillReturnError(errors.New("query error"))
+
+	repo := NewWebhookDeliveryRepository(db, 1*time.Second, domain.EventCodec{})
+
+	_, err = repo.Claim(context.Background(), time.Now(), time.Minute, 10)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
+
+func TestWebhookDeliveryRepositoryRecordSuccess(t *testing.T) {
+	deliveredAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	delivery, err := domain.NewWebhookDeliveryWithID(webhookDeliveryID, webhookID, webhookDeliveryEventID, "events.movie.created", 2, 503, "unexpected status 503", deliveredAt)
+	require.NoError(t, err)
+
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec(webhookDeliveryRecord).
+		WithArgs(503, "unexpected status 503", deliveredAt, webhookDeliveryID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := NewWebhookDeliveryRepository(db, 1*time.Second, domain.EventCodec{})
+
+	err = repo.Record(context.Background(), delivery)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
+
+func TestWebhookDeliveryRepositoryRecordWebhookNotFound(t *testing.T) {
+	delivery, err := domain.NewWebhookDeliveryWithID(webhookDeliveryID, webhookID, webhookDeliveryEventID, "events.movie.created", 1, 204, "", time.Now())
+	require.NoError(t, err)
+
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec(webhookDeliveryRecord).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := NewWebhookDeliveryRepository(db, 1*time.Second, domain.EventCodec{})
+
+	err = repo.Record(context.Background(), delivery)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+}
+
+func TestWebhookDeliveryRepositoryFindPage(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	page, err := domain.NewPageRequest(1, "")
+	require.NoError(t, err)
+
+	deliveredAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	sqlMock.ExpectQuery(webhookDeliverySelect+" ORDER BY webhook_deliveries.delivered_at DESC, webhook_deliveries.id DESC LIMIT $2").
+		WithArgs(webhookID, 2).
+		WillReturnRows(sqlmock.NewRows(append(webhookDeliveryColumns, "delivered_at")).
+			AddRow(webhookDeliveryID, webhookID, webhookDeliveryEventID, "events.movie.created", 2, 204, nil, deliveredAt, deliveredAt).
+			AddRow(webhookID, webhookID, webhookDeliveryEventID, "events.movie.created", 1, 503, "unexpected status 503", deliveredAt.Add(-time.Second), deliveredAt.Add(-time.Second)))
+
+	repo := NewWebhookDeliveryRepository(db, 1*time.Second, domain.EventCodec{})
+
+	id, err := domain.NewWebhookIDFromString(webhookID)
+	require.NoError(t, err)
+
+	deliveries, next, err := repo.FindPage(context.Background(), id, page)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, 204, deliveries[0].StatusCode())
+	assert.True(t, deliveries[0].Succeeded())
+	assert.Equal(t, deliveredAt, deliveries[0].DeliveredAt())
+	require.NotNil(t, next)
+	assert.Equal(t, webhookDeliveryID, next.ID())
+	assert.Equal(t, "2024-03-01T12:00:00Z", next.Key())
+}
+
+func TestWebhookDeliveryRepositoryFindPageAfterCursor(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	deliveredAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	page, err := domain.NewPageRequest(10, domain.NewCursor("2024-03-01T12:00:00Z", webhookDeliveryID).String())
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery(webhookDeliverySelect+" AND (webhook_deliveries.delivered_at, webhook_deliveries.id) < ($2, $3) ORDER BY webhook_deliveries.delivered_at DESC, webhook_deliveries.id DESC LIMIT $4").
+		WithArgs(webhookID, deliveredAt, webhookDeliveryID, 11).
+		WillReturnRows(sqlmock.NewRows(append(webhookDeliveryColumns, "delivered_at")))
+
+	repo := NewWebhookDeliveryRepository(db, 1*time.Second, domain.EventCodec{})
+
+	id, err := domain.NewWebhookIDFromString(webhookID)
+	require.NoError(t, err)
+
+	deliveries, next, err := repo.FindPage(context.Background(), id, page)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	require.NoError(t, err)
+	assert.Empty(t, deliveries)
+	assert.Nil(t, next)
+}
+
+func TestWebhookDeliveryRepositoryFindPageError(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	page, err := domain.NewPageRequest(0, "")
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery(webhookDeliverySelect + " ORDER BY webhook_deliveries.delivered_at DESC, webhook_deliveries.id DESC LIMIT $2").
+		WillReturnError(errors.New("query error"))
+
+	repo := NewWebhookDeliveryRepository(db, 1*time.Second, domain.EventCodec{})
+
+	id, err := domain.NewWebhookIDFromString(webhookID)
+	require.NoError(t, err)
+
+	_, _, err = repo.FindPage(context.Background(), id, page)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
@@ -0,0 +1,182 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/lib/pq"
+)
+
+type WebhookDB struct {
+	ID         string         `db:"id"`
+	URL        string         `db:"url"`
+	Secret     string         `db:"secret"`
+	EventTypes pq.StringArray `db:"event_types"`
+}
+
+var sqlWebhookTable = "webhooks"
+var webhookSQLStruct = sqlbuilder.NewStruct(new(WebhookDB)).For(defaultFlavor)
+
+// WebhookRepository implements the WebhookRepository interface for SQL.
+type WebhookRepository struct {
+	db        Executor
+	dbTimeout time.Duration
+}
+
+// NewWebhookRepository creates a new WebhookRepository.
+func NewWebhookRepository(db Executor, dbTimeout time.Duration) *WebhookRepository {
+	return &WebhookRepository{
+		db:        db,
+		dbTimeout: dbTimeout,
+	}
+}
+
+func webhookToDTO(webhook domain.Webhook) WebhookDB {
+	var eventTypes pq.StringArray
+	for _, eventType := range webhook.EventTypes() {
+		eventTypes = append(eventTypes, string(eventType))
+	}
+
+	return WebhookDB{
+		ID:         webhook.ID().String(),
+		URL:        webhook.URL().String(),
+		Secret:     webhook.Secret().String(),
+		EventTypes: eventTypes,
+	}
+}
+
+func webhookToDomain(dto WebhookDB) (domain.Webhook, error) {
+	return domain.NewWebhookWithID(
+		dto.ID,
+		dto.URL,
+		dto.Secret,
+		dto.EventTypes,
+	)
+}
+
+func (r *WebhookRepository) Save(ctx context.Context, webhook domain.Webhook) error {
+	query, args := webhookSQLStruct.InsertInto(sqlWebhookTable, webhookToDTO(webhook)).Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	_, err := executorFrom(ctxTimeout, r.db).ExecContext(ctxTimeout, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to save webhook: %v", err)
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) Find(ctx context.Context, id domain.WebhookID) (domain.Webhook, error) {
+	sb := webhookSQLStruct.SelectFrom(sqlWebhookTable)
+	sb.Where(sb.Equal("id", id.String()))
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	var webhookDTO WebhookDB
+	err := executorFrom(ctxTimeout, r.db).QueryRowContext(ctxTimeout, query, args...).Scan(webhookSQLStruct.Addr(&webhookDTO)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Webhook{}, domain.ErrWebhookNotFound
+	}
+	if err != nil {
+		return domain.Webhook{}, fmt.Errorf("failed to find webhook: %v", err)
+	}
+
+	return webhookToDomain(webhookDTO)
+}
+
+// FindAll returns every webhook, in the order they were saved.
+func (r *WebhookRepository) FindAll(ctx context.Context) ([]domain.Webhook, error) {
+	sb := webhookSQLStruct.SelectFrom(sqlWebhookTable)
+	sb.OrderBy("created_at", "id")
+
+	return r.findWebhooks(ctx, sb)
+}
+
+// FindByEventType returns the webhooks subscribed to the event type, in the order they
+// were saved.
+func (r *WebhookRepository) FindByEventType(ctx context.Context, eventType event.Type) ([]domain.Webhook, error) {
+	sb := webhookSQLStruct.SelectFrom(sqlWebhookTable)
+	sb.Where(fmt.Sprintf("%s = ANY(event_types)", sb.Var(string(eventType))))
+	sb.OrderBy("created_at", "id")
+
+	return r.findWebhooks(ctx, sb)
+}
+
+func (r *WebhookRepository) Update(ctx context.Context, webhook domain.Webhook) error {
+	row := webhookToDTO(webhook)
+	ub := webhookSQLStruct.Update(sqlWebhookTable, row)
+	ub.Where(ub.Equal("id", row.ID))
+	query, args := ub.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	found, err := execOnRow(ctxTimeout, r.db, query, args)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook: %v", err)
+	}
+	if !found {
+		return domain.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// Delete removes the webhook for good. Its deliveries are removed by the foreign key.
+func (r *WebhookRepository) Delete(ctx context.Context, id domain.WebhookID) error {
+	db := defaultFlavor.NewDeleteBuilder()
+	db.DeleteFrom(sqlWebhookTable)
+	db.Where(db.Equal("id", id.String()))
+	query, args := db.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	found, err := execOnRow(ctxTimeout, r.db, query, args)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %v", err)
+	}
+	if !found {
+		return domain.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) findWebhooks(ctx context.Context, sb *sqlbuilder.SelectBuilder) ([]domain.Webhook, error) {
+	query, args := sb.Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	rows, err := executorFrom(ctxTimeout, r.db).QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhooks: %v", err)
+	}
+	defer rows.Close()
+
+	var webhooks []domain.Webhook
+	for rows.Next() {
+		var webhookDTO WebhookDB
+		if err := rows.Scan(webhookSQLStruct.Addr(&webhookDTO)...); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook: %v", err)
+		}
+		webhook, err := webhookToDomain(webhookDTO)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert webhook: %v", err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, nil
+}
@@ -0,0 +1,201 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	webhookID     = "0c1d7b4e-2f1a-4a5e-9a77-3c9b4f0e6a11"
+	webhookURL    = "https://hooks.example.com/middle-earth"
+	webhookSecret = "a-very-secret-signing-key"
+	webhookSelect = "SELECT webhooks.id, webhooks.url, webhooks.secret, webhooks.event_types FROM webhooks"
+)
+
+var webhookColumns = []string{"id", "url", "secret", "event_types"}
+
+func TestWebhookRepositorySaveSuccess(t *testing.T) {
+	webhook, err := domain.NewWebhookWithID(webhookID, webhookURL, webhookSecret, []string{"events.movie.created", "events.movie.deleted"})
+	require.NoError(t, err)
+
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec("INSERT INTO webhooks (id, url, secret, event_types) VALUES ($1, $2, $3, $4)").
+		WithArgs(webhookID, webhookURL, webhookSecret, `{"events.movie.created","events.movie.deleted"}`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := NewWebhookRepository(db, 1*time.Second)
+
+	err = repo.Save(context.Background(), webhook)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
+
+func TestWebhookRepositorySaveError(t *testing.T) {
+	webhook, err := domain.NewWebhookWithID(webhookID, webhookURL, webhookSecret, []string{"events.movie.created"})
+	require.NoError(t, err)
+
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec("INSERT INTO webhooks (id, url, secret, event_types) VALUES ($1, $2, $3, $4)").
+		WillReturnError(errors.New("insert error"))
+
+	repo := NewWebhookRepository(db, 1*time.Second)
+
+	err = repo.Save(context.Background(), webhook)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
+
+func TestWebhookRepositoryFindSuccess(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery(webhookSelect + " WHERE id = $1").
+		WithArgs(webhookID).
+		WillReturnRows(sqlmock.NewRows(webhookColumns).
+			AddRow(webhookID, webhookURL, webhookSecret, `{events.theme.created,events.theme.updated}`))
+
+	repo := NewWebhookRepository(db, 1*time.Second)
+
+	id, err := domain.NewWebhookIDFromString(webhookID)
+	require.NoError(t, err)
+
+	webhook, err := repo.Find(context.Background(), id)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	require.NoError(t, err)
+	assert.Equal(t, webhookURL, webhook.URL().String())
+	assert.True(t, webhook.Subscribes(domain.ThemeUpdatedEventType))
+	assert.False(t, webhook.Subscribes(domain.ThemeDeletedEventType))
+}
+
+func TestWebhookRepositoryFindNotFound(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery(webhookSelect + " WHERE id = $1").
+		WithArgs(webhookID).
+		WillReturnError(sql.ErrNoRows)
+
+	repo := NewWebhookRepository(db, 1*time.Second)
+
+	id, err := domain.NewWebhookIDFromString(webhookID)
+	require.NoError(t, err)
+
+	_, err = repo.Find(context.Background(), id)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+}
+
+func TestWebhookRepositoryFindByEventType(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery(webhookSelect + " WHERE $1 = ANY(event_types) ORDER BY created_at, id").
+		WithArgs("events.movie.created").
+		WillReturnRows(sqlmock.NewRows(webhookColumns).
+			AddRow(webhookID, webhookURL, webhookSecret, `{events.movie.created}`))
+
+	repo := NewWebhookRepository(db, 1*time.Second)
+
+	webhooks, err := repo.FindByEventType(context.Background(), domain.MovieCreatedEventType)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	require.NoError(t, err)
+	require.Len(t, webhooks, 1)
+	assert.Equal(t, webhookID, webhooks[0].ID().String())
+}
+
+func TestWebhookRepositoryFindAllError(t *testing.T) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectQuery(webhookSelect + " ORDER BY created_at, id").
+		WillReturnError(errors.New("query error"))
+
+	repo := NewWebhookRepository(db, 1*time.Second)
+
+	_, err = repo.FindAll(context.Background())
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.Error(t, err)
+}
+
+func TestWebhookRepositoryUpdateSuccess(t *testing.T) {
+	webhook, err := domain.NewWebhookWithID(webhookID, webhookURL, webhookSecret, []string{"events.movie.created"})
+	require.NoError(t, err)
+
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec("UPDATE webhooks SET id = $1, url = $2, secret = $3, event_types = $4 WHERE id = $5").
+		WithArgs(webhookID, webhookURL, webhookSecret, `{"events.movie.created"}`, webhookID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := NewWebhookRepository(db, 1*time.Second)
+
+	err = repo.Update(context.Background(), webhook)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
+
+func TestWebhookRepositoryUpdateNotFound(t *testing.T) {
+	webhook, err := domain.NewWebhookWithID(webhookID, webhookURL, webhookSecret, []string{"events.movie.created"})
+	require.NoError(t, err)
+
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec("UPDATE webhooks SET id = $1, url = $2, secret = $3, event_types = $4 WHERE id = $5").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := NewWebhookRepository(db, 1*time.Second)
+
+	err = repo.Update(context.Background(), webhook)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+}
+
+func TestWebhookRepositoryDeleteSuccess(t *testing.T) {
+	id, err := domain.NewWebhookIDFromString(webhookID)
+	require.NoError(t, err)
+
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec("DELETE FROM webhooks WHERE id = $1").
+		WithArgs(webhookID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := NewWebhookRepository(db, 1*time.Second)
+
+	err = repo.Delete(context.Background(), id)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.NoError(t, err)
+}
+
+func TestWebhookRepositoryDeleteNotFound(t *testing.T) {
+	id, err := domain.NewWebhookIDFromString(webhookID)
+	require.NoError(t, err)
+
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlMock.ExpectExec("DELETE FROM webhooks WHERE id = $1").
+		WithArgs(webhookID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := NewWebhookRepository(db, 1*time.Second)
+
+	err = repo.Delete(context.Background(), id)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+}
@@ -90,6 +90,109 @@ func (_m *GroupRepository) FindAll(ctx context.Context) ([]domain.Group, error)
 	return r0, r1
 }
 
+// FindInTrash provides a mock function with given fields: ctx, id
+func (_m *GroupRepository) FindInTrash(ctx context.Context, id domain.GroupID) (domain.Group, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindInTrash")
+	}
+
+	var r0 domain.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.GroupID) (domain.Group, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.GroupID) domain.Group); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.Group)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.GroupID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindPage provides a mock function with given fields: ctx, page
+func (_m *GroupRepository) FindPage(ctx context.Context, page domain.PageRequest) ([]domain.Group, *domain.Cursor, error) {
+	ret := _m.Called(ctx, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPage")
+	}
+
+	var r0 []domain.Group
+	var r1 *domain.Cursor
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PageRequest) ([]domain.Group, *domain.Cursor, error)); ok {
+		return rf(ctx, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PageRequest) []domain.Group); ok {
+		r0 = rf(ctx, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.PageRequest) *domain.Cursor); ok {
+		r1 = rf(ctx, page)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Cursor)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, domain.PageRequest) error); ok {
+		r2 = rf(ctx, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Purge provides a mock function with given fields: ctx, id
+func (_m *GroupRepository) Purge(ctx context.Context, id domain.GroupID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.GroupID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Restore provides a mock function with given fields: ctx, id
+func (_m *GroupRepository) Restore(ctx context.Context, id domain.GroupID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.GroupID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Save provides a mock function with given fields: ctx, group
 func (_m *GroupRepository) Save(ctx context.Context, group domain.Group) error {
 	ret := _m.Called(ctx, group)
@@ -90,6 +90,109 @@ func (_m *MovieRepository) FindAll(ctx context.Context) ([]domain.Movie, error)
 	return r0, r1
 }
 
+// FindInTrash provides a mock function with given fields: ctx, id
+func (_m *MovieRepository) FindInTrash(ctx context.Context, id domain.MovieID) (domain.Movie, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindInTrash")
+	}
+
+	var r0 domain.Movie
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.MovieID) (domain.Movie, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.MovieID) domain.Movie); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.Movie)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.MovieID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindPage provides a mock function with given fields: ctx, filter, page
+func (_m *MovieRepository) FindPage(ctx context.Context, filter domain.MovieFilter, page domain.PageRequest) ([]domain.Movie, *domain.Cursor, error) {
+	ret := _m.Called(ctx, filter, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPage")
+	}
+
+	var r0 []domain.Movie
+	var r1 *domain.Cursor
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.MovieFilter, domain.PageRequest) ([]domain.Movie, *domain.Cursor, error)); ok {
+		return rf(ctx, filter, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.MovieFilter, domain.PageRequest) []domain.Movie); ok {
+		r0 = rf(ctx, filter, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Movie)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.MovieFilter, domain.PageRequest) *domain.Cursor); ok {
+		r1 = rf(ctx, filter, page)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Cursor)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, domain.MovieFilter, domain.PageRequest) error); ok {
+		r2 = rf(ctx, filter, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Purge provides a mock function with given fields: ctx, id
+func (_m *MovieRepository) Purge(ctx context.Context, id domain.MovieID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.MovieID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Restore provides a mock function with given fields: ctx, id
+func (_m *MovieRepository) Restore(ctx context.Context, id domain.MovieID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.MovieID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Save provides a mock function with given fields: ctx, movie
 func (_m *MovieRepository) Save(ctx context.Context, movie domain.Movie) error {
 	ret := _m.Called(ctx, movie)
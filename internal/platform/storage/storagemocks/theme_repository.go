@@ -90,6 +90,66 @@ func (_m *ThemeRepository) FindAll(ctx context.Context) ([]domain.Theme, error)
 	return r0, r1
 }
 
+// FindByCategory provides a mock function with given fields: ctx, categoryID
+func (_m *ThemeRepository) FindByCategory(ctx context.Context, categoryID domain.CategoryID) ([]domain.Theme, error) {
+	ret := _m.Called(ctx, categoryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByCategory")
+	}
+
+	var r0 []domain.Theme
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CategoryID) ([]domain.Theme, error)); ok {
+		return rf(ctx, categoryID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CategoryID) []domain.Theme); ok {
+		r0 = rf(ctx, categoryID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Theme)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.CategoryID) error); ok {
+		r1 = rf(ctx, categoryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByFirstHeard provides a mock function with given fields: ctx, trackID
+func (_m *ThemeRepository) FindByFirstHeard(ctx context.Context, trackID domain.TrackID) ([]domain.Theme, error) {
+	ret := _m.Called(ctx, trackID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByFirstHeard")
+	}
+
+	var r0 []domain.Theme
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TrackID) ([]domain.Theme, error)); ok {
+		return rf(ctx, trackID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TrackID) []domain.Theme); ok {
+		r0 = rf(ctx, trackID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Theme)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.TrackID) error); ok {
+		r1 = rf(ctx, trackID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindByGroup provides a mock function with given fields: ctx, groupID
 func (_m *ThemeRepository) FindByGroup(ctx context.Context, groupID domain.GroupID) ([]domain.Theme, error) {
 	ret := _m.Called(ctx, groupID)
@@ -120,6 +180,139 @@ func (_m *ThemeRepository) FindByGroup(ctx context.Context, groupID domain.Group
 	return r0, r1
 }
 
+// FindByMovie provides a mock function with given fields: ctx, movieID
+func (_m *ThemeRepository) FindByMovie(ctx context.Context, movieID domain.MovieID) ([]domain.Theme, error) {
+	ret := _m.Called(ctx, movieID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByMovie")
+	}
+
+	var r0 []domain.Theme
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.MovieID) ([]domain.Theme, error)); ok {
+		return rf(ctx, movieID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.MovieID) []domain.Theme); ok {
+		r0 = rf(ctx, movieID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Theme)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.MovieID) error); ok {
+		r1 = rf(ctx, movieID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindInTrash provides a mock function with given fields: ctx, id
+func (_m *ThemeRepository) FindInTrash(ctx context.Context, id domain.ThemeID) (domain.Theme, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindInTrash")
+	}
+
+	var r0 domain.Theme
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ThemeID) (domain.Theme, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ThemeID) domain.Theme); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.Theme)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ThemeID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindPage provides a mock function with given fields: ctx, filter, page
+func (_m *ThemeRepository) FindPage(ctx context.Context, filter domain.ThemeFilter, page domain.PageRequest) ([]domain.Theme, *domain.Cursor, error) {
+	ret := _m.Called(ctx, filter, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPage")
+	}
+
+	var r0 []domain.Theme
+	var r1 *domain.Cursor
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ThemeFilter, domain.PageRequest) ([]domain.Theme, *domain.Cursor, error)); ok {
+		return rf(ctx, filter, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ThemeFilter, domain.PageRequest) []domain.Theme); ok {
+		r0 = rf(ctx, filter, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Theme)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ThemeFilter, domain.PageRequest) *domain.Cursor); ok {
+		r1 = rf(ctx, filter, page)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Cursor)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, domain.ThemeFilter, domain.PageRequest) error); ok {
+		r2 = rf(ctx, filter, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Purge provides a mock function with given fields: ctx, id
+func (_m *ThemeRepository) Purge(ctx context.Context, id domain.ThemeID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ThemeID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Restore provides a mock function with given fields: ctx, id
+func (_m *ThemeRepository) Restore(ctx context.Context, id domain.ThemeID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ThemeID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Save provides a mock function with given fields: ctx, theme
 func (_m *ThemeRepository) Save(ctx context.Context, theme domain.Theme) error {
 	ret := _m.Called(ctx, theme)
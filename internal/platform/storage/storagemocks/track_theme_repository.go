@@ -60,6 +60,36 @@ func (_m *TrackThemeRepository) Find(ctx context.Context, trackID domain.TrackID
 	return r0, r1
 }
 
+// FindByTheme provides a mock function with given fields: ctx, themeID
+func (_m *TrackThemeRepository) FindByTheme(ctx context.Context, themeID domain.ThemeID) ([]domain.TrackTheme, error) {
+	ret := _m.Called(ctx, themeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByTheme")
+	}
+
+	var r0 []domain.TrackTheme
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ThemeID) ([]domain.TrackTheme, error)); ok {
+		return rf(ctx, themeID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ThemeID) []domain.TrackTheme); ok {
+		r0 = rf(ctx, themeID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TrackTheme)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ThemeID) error); ok {
+		r1 = rf(ctx, themeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindByTrack provides a mock function with given fields: ctx, trackID
 func (_m *TrackThemeRepository) FindByTrack(ctx context.Context, trackID domain.TrackID) ([]domain.TrackTheme, error) {
 	ret := _m.Called(ctx, trackID)
@@ -14,6 +14,52 @@ type UserRepository struct {
 	mock.Mock
 }
 
+// CountByPermission provides a mock function with given fields: ctx, permission
+func (_m *UserRepository) CountByPermission(ctx context.Context, permission domain.Permission) (int, error) {
+	ret := _m.Called(ctx, permission)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByPermission")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Permission) (int, error)); ok {
+		return rf(ctx, permission)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Permission) int); ok {
+		r0 = rf(ctx, permission)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Permission) error); ok {
+		r1 = rf(ctx, permission)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *UserRepository) Delete(ctx context.Context, id domain.UserID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Find provides a mock function with given fields: ctx, id
 func (_m *UserRepository) Find(ctx context.Context, id domain.UserID) (domain.User, error) {
 	ret := _m.Called(ctx, id)
@@ -100,6 +146,45 @@ func (_m *UserRepository) FindByEmail(ctx context.Context, email domain.UserEmai
 	return r0, r1
 }
 
+// FindPage provides a mock function with given fields: ctx, page
+func (_m *UserRepository) FindPage(ctx context.Context, page domain.PageRequest) ([]domain.User, *domain.Cursor, error) {
+	ret := _m.Called(ctx, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPage")
+	}
+
+	var r0 []domain.User
+	var r1 *domain.Cursor
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PageRequest) ([]domain.User, *domain.Cursor, error)); ok {
+		return rf(ctx, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PageRequest) []domain.User); ok {
+		r0 = rf(ctx, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.PageRequest) *domain.Cursor); ok {
+		r1 = rf(ctx, page)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Cursor)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, domain.PageRequest) error); ok {
+		r2 = rf(ctx, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Save provides a mock function with given fields: ctx, user
 func (_m *UserRepository) Save(ctx context.Context, user domain.User) error {
 	ret := _m.Called(ctx, user)
@@ -118,6 +203,24 @@ func (_m *UserRepository) Save(ctx context.Context, user domain.User) error {
 	return r0
 }
 
+// Update provides a mock function with given fields: ctx, user
+func (_m *UserRepository) Update(ctx context.Context, user domain.User) error {
+	ret := _m.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewUserRepository creates a new instance of UserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserRepository(t interface {
@@ -0,0 +1,123 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package storagemocks
+
+import (
+	context "context"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// VerificationTokenRepository is an autogenerated mock type for the VerificationTokenRepository type
+type VerificationTokenRepository struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: ctx, tokenHash
+func (_m *VerificationTokenRepository) Delete(ctx context.Context, tokenHash string) error {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindByTokenHash provides a mock function with given fields: ctx, tokenHash
+func (_m *VerificationTokenRepository) FindByTokenHash(ctx context.Context, tokenHash string) (domain.VerificationToken, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByTokenHash")
+	}
+
+	var r0 domain.VerificationToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.VerificationToken, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.VerificationToken); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(domain.VerificationToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: ctx, token
+func (_m *VerificationTokenRepository) Save(ctx context.Context, token domain.VerificationToken) error {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.VerificationToken) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ValidAt provides a mock function with given fields: ctx, userID, t
+func (_m *VerificationTokenRepository) ValidAt(ctx context.Context, userID domain.UserID, t time.Time) (bool, error) {
+	ret := _m.Called(ctx, userID, t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidAt")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserID, time.Time) (bool, error)); ok {
+		return rf(ctx, userID, t)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserID, time.Time) bool); ok {
+		r0 = rf(ctx, userID, t)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.UserID, time.Time) error); ok {
+		r1 = rf(ctx, userID, t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewVerificationTokenRepository creates a new instance of VerificationTokenRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewVerificationTokenRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *VerificationTokenRepository {
+	mock := &VerificationTokenRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,95 @@
+package storagetest
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAuditRepository checks the contract of domain.AuditRepository.
+func TestAuditRepository(t *testing.T, factory Factory) {
+	t.Run("saves entries and pages through them newest first", func(t *testing.T) {
+		f := newFixture(t, factory)
+		start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+		movieID := newID(t)
+		alice := newID(t)
+		bob := newID(t)
+
+		created := f.auditEntry("command.create.movie", domain.AuditEntityMovie, "", alice, "", `{"name":"The Two Towers"}`, start)
+		updated := f.auditEntry("command.update.movie", domain.AuditEntityMovie, movieID, bob, `{"name":"The Two Towers"}`, `{"name":"The Return of the King"}`, start.Add(time.Hour))
+		grouped := f.auditEntry("command.create.group", domain.AuditEntityGroup, "", alice, "", `{"name":"Hobbits"}`, start.Add(2*time.Hour))
+		deleted := f.auditEntry("command.delete.movie", domain.AuditEntityMovie, movieID, "", `{"name":"The Return of the King"}`, "", start.Add(3*time.Hour))
+
+		tests := map[string]struct {
+			entity, userID, since string
+			expected              []domain.AuditEntry
+		}{
+			"everything":   {expected: []domain.AuditEntry{deleted, grouped, updated, created}},
+			"of an entity": {entity: domain.AuditEntityMovie, expected: []domain.AuditEntry{deleted, updated, created}},
+			"of a user":    {userID: alice, expected: []domain.AuditEntry{grouped, created}},
+			"since a time": {since: start.Add(time.Hour).Format(time.RFC3339), expected: []domain.AuditEntry{deleted, grouped, updated}},
+			"all criteria": {entity: domain.AuditEntityMovie, userID: bob, since: start.Format(time.RFC3339), expected: []domain.AuditEntry{updated}},
+		}
+
+		for name, tt := range tests {
+			t.Run(name, func(t *testing.T) {
+				filter, err := domain.NewAuditFilter(tt.entity, tt.userID, tt.since)
+				require.NoError(t, err)
+
+				entries := readAllPages(t, 1, func(page domain.PageRequest) ([]domain.AuditEntry, *domain.Cursor, error) {
+					return f.repos.Audit.FindPage(f.ctx(), filter, page)
+				})
+				require.Len(t, entries, len(tt.expected))
+				for i, expected := range tt.expected {
+					assertSameAuditEntry(t, expected, entries[i])
+				}
+			})
+		}
+	})
+}
+
+func (f *fixture) auditEntry(commandType, entity, entityID, userID, before, after string, occurredAt time.Time) domain.AuditEntry {
+	f.t.Helper()
+
+	entry, err := domain.NewAuditEntry(commandType, entity, entityID, userID, rawJSON(before), rawJSON(after), occurredAt)
+	require.NoError(f.t, err)
+	require.NoError(f.t, f.repos.Audit.Save(f.ctx(), entry))
+
+	return entry
+}
+
+func rawJSON(s string) json.RawMessage {
+	if s == "" {
+		return nil
+	}
+	return json.RawMessage(s)
+}
+
+// assertSameAuditEntry compares two entries field by field. The JSON states are compared
+// by value, since the storage may reformat them.
+func assertSameAuditEntry(t *testing.T, expected, actual domain.AuditEntry) {
+	t.Helper()
+
+	assert.Equal(t, expected.ID(), actual.ID())
+	assert.Equal(t, expected.CommandType(), actual.CommandType())
+	assert.Equal(t, expected.Entity(), actual.Entity())
+	assert.Equal(t, expected.EntityID(), actual.EntityID())
+	assert.Equal(t, expected.UserID(), actual.UserID())
+	assert.True(t, expected.OccurredAt().Equal(actual.OccurredAt()), "occurred at %s, expected %s", actual.OccurredAt(), expected.OccurredAt())
+	assertSameJSON(t, expected.Before(), actual.Before())
+	assertSameJSON(t, expected.After(), actual.After())
+}
+
+func assertSameJSON(t *testing.T, expected, actual json.RawMessage) {
+	t.Helper()
+
+	if expected == nil {
+		assert.Nil(t, actual)
+		return
+	}
+	assert.JSONEq(t, string(expected), string(actual))
+}
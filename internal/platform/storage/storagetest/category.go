@@ -0,0 +1,110 @@
+package storagetest
+
+import (
+	"testing"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCategoryRepository checks the contract of domain.CategoryRepository.
+func TestCategoryRepository(t *testing.T, factory Factory) {
+	t.Run("saves, finds and updates a category", func(t *testing.T) {
+		f := newFixture(t, factory)
+		category := f.category("Characters")
+
+		found, err := f.repos.Categories.Find(f.ctx(), category.ID())
+		require.NoError(t, err)
+		assert.Equal(t, category, found)
+
+		updated, err := domain.NewCategoryWithID(category.ID().String(), "Places")
+		require.NoError(t, err)
+		require.NoError(t, f.repos.Categories.Update(f.ctx(), updated))
+
+		found, err = f.repos.Categories.Find(f.ctx(), category.ID())
+		require.NoError(t, err)
+		assert.Equal(t, updated, found)
+	})
+
+	t.Run("reports a missing category", func(t *testing.T) {
+		f := newFixture(t, factory)
+		category, err := domain.NewCategory("Characters")
+		require.NoError(t, err)
+
+		_, err = f.repos.Categories.Find(f.ctx(), category.ID())
+		assert.ErrorIs(t, err, domain.ErrCategoryNotFound)
+		assert.ErrorIs(t, f.repos.Categories.Update(f.ctx(), category), domain.ErrCategoryNotFound)
+		assert.ErrorIs(t, f.repos.Categories.Delete(f.ctx(), category.ID()), domain.ErrCategoryNotFound)
+		assert.ErrorIs(t, f.repos.Categories.Restore(f.ctx(), category.ID()), domain.ErrCategoryNotFound)
+		assert.ErrorIs(t, f.repos.Categories.Purge(f.ctx(), category.ID()), domain.ErrCategoryNotFound)
+	})
+
+	t.Run("pages through every category", func(t *testing.T) {
+		f := newFixture(t, factory)
+		var categories []domain.Category
+		for i := range 5 {
+			categories = append(categories, f.category(name("Category", i)))
+		}
+
+		read := readAllPages(t, 2, func(page domain.PageRequest) ([]domain.Category, *domain.Cursor, error) {
+			return f.repos.Categories.FindPage(f.ctx(), page)
+		})
+		assert.ElementsMatch(t, categories, read)
+
+		all, err := f.repos.Categories.FindAll(f.ctx())
+		require.NoError(t, err)
+		assert.ElementsMatch(t, categories, all)
+	})
+
+	t.Run("moves a category to the trash and restores it", func(t *testing.T) {
+		f := newFixture(t, factory)
+		category := f.category("Characters")
+
+		require.NoError(t, f.repos.Categories.Delete(f.ctx(), category.ID()))
+
+		trashed, err := f.repos.Categories.FindInTrash(f.ctx(), category.ID())
+		require.NoError(t, err)
+		assert.Equal(t, category, trashed)
+
+		_, err = f.repos.Categories.Find(f.ctx(), category.ID())
+		assert.ErrorIs(t, err, domain.ErrCategoryNotFound)
+		all, err := f.repos.Categories.FindAll(f.ctx())
+		require.NoError(t, err)
+		assert.NotContains(t, all, category)
+		assert.ErrorIs(t, f.repos.Categories.Delete(f.ctx(), category.ID()), domain.ErrCategoryNotFound)
+		assert.ErrorIs(t, f.repos.Categories.Update(f.ctx(), category), domain.ErrCategoryNotFound)
+
+		require.NoError(t, f.repos.Categories.Restore(f.ctx(), category.ID()))
+
+		_, err = f.repos.Categories.FindInTrash(f.ctx(), category.ID())
+		assert.ErrorIs(t, err, domain.ErrCategoryNotFound)
+
+		found, err := f.repos.Categories.Find(f.ctx(), category.ID())
+		require.NoError(t, err)
+		assert.Equal(t, category, found)
+		assert.ErrorIs(t, f.repos.Categories.Restore(f.ctx(), category.ID()), domain.ErrCategoryNotFound)
+		assert.ErrorIs(t, f.repos.Categories.Purge(f.ctx(), category.ID()), domain.ErrCategoryNotFound)
+	})
+
+	t.Run("purges a category from the trash", func(t *testing.T) {
+		f := newFixture(t, factory)
+		category := f.category("Characters")
+
+		require.NoError(t, f.repos.Categories.Delete(f.ctx(), category.ID()))
+		require.NoError(t, f.repos.Categories.Purge(f.ctx(), category.ID()))
+
+		assert.ErrorIs(t, f.repos.Categories.Restore(f.ctx(), category.ID()), domain.ErrCategoryNotFound)
+	})
+
+	t.Run("refuses to purge a category with themes, even in the trash", func(t *testing.T) {
+		f := newFixture(t, factory)
+		category := f.category("Characters")
+		track := f.track("Concerning Hobbits", f.movie("The Fellowship of the Ring"), 2)
+		theme := f.theme("The Shire", f.group("Hobbits"), &category, track, 0)
+		require.NoError(t, f.repos.Themes.Delete(f.ctx(), theme.ID()))
+		require.NoError(t, f.repos.Categories.Delete(f.ctx(), category.ID()))
+
+		assert.ErrorIs(t, f.repos.Categories.Purge(f.ctx(), category.ID()), domain.ErrInUse)
+	})
+}
@@ -0,0 +1,110 @@
+package storagetest
+
+import (
+	"testing"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGroupRepository checks the contract of domain.GroupRepository.
+func TestGroupRepository(t *testing.T, factory Factory) {
+	t.Run("saves, finds and updates a group", func(t *testing.T) {
+		f := newFixture(t, factory)
+		group := f.group("Hobbits")
+
+		found, err := f.repos.Groups.Find(f.ctx(), group.ID())
+		require.NoError(t, err)
+		assert.Equal(t, group, found)
+
+		updated, err := domain.NewGroupWithID(group.ID().String(), "The Shire", "Hobbits and their land", "https://example.com/shire.jpg")
+		require.NoError(t, err)
+		require.NoError(t, f.repos.Groups.Update(f.ctx(), updated))
+
+		found, err = f.repos.Groups.Find(f.ctx(), group.ID())
+		require.NoError(t, err)
+		assert.Equal(t, updated, found)
+	})
+
+	t.Run("reports a missing group", func(t *testing.T) {
+		f := newFixture(t, factory)
+		group, err := domain.NewGroup("Hobbits", "The hobbits", "https://example.com/hobbits.jpg")
+		require.NoError(t, err)
+
+		_, err = f.repos.Groups.Find(f.ctx(), group.ID())
+		assert.ErrorIs(t, err, domain.ErrGroupNotFound)
+		assert.ErrorIs(t, f.repos.Groups.Update(f.ctx(), group), domain.ErrGroupNotFound)
+		assert.ErrorIs(t, f.repos.Groups.Delete(f.ctx(), group.ID()), domain.ErrGroupNotFound)
+		assert.ErrorIs(t, f.repos.Groups.Restore(f.ctx(), group.ID()), domain.ErrGroupNotFound)
+		assert.ErrorIs(t, f.repos.Groups.Purge(f.ctx(), group.ID()), domain.ErrGroupNotFound)
+	})
+
+	t.Run("pages through every group", func(t *testing.T) {
+		f := newFixture(t, factory)
+		var groups []domain.Group
+		for i := range 5 {
+			groups = append(groups, f.group(name("Group", i)))
+		}
+
+		read := readAllPages(t, 2, func(page domain.PageRequest) ([]domain.Group, *domain.Cursor, error) {
+			return f.repos.Groups.FindPage(f.ctx(), page)
+		})
+		assert.ElementsMatch(t, groups, read)
+
+		all, err := f.repos.Groups.FindAll(f.ctx())
+		require.NoError(t, err)
+		assert.ElementsMatch(t, groups, all)
+	})
+
+	t.Run("moves a group to the trash and restores it", func(t *testing.T) {
+		f := newFixture(t, factory)
+		group := f.group("Hobbits")
+
+		require.NoError(t, f.repos.Groups.Delete(f.ctx(), group.ID()))
+
+		trashed, err := f.repos.Groups.FindInTrash(f.ctx(), group.ID())
+		require.NoError(t, err)
+		assert.Equal(t, group, trashed)
+
+		_, err = f.repos.Groups.Find(f.ctx(), group.ID())
+		assert.ErrorIs(t, err, domain.ErrGroupNotFound)
+		all, err := f.repos.Groups.FindAll(f.ctx())
+		require.NoError(t, err)
+		assert.NotContains(t, all, group)
+		assert.ErrorIs(t, f.repos.Groups.Delete(f.ctx(), group.ID()), domain.ErrGroupNotFound)
+		assert.ErrorIs(t, f.repos.Groups.Update(f.ctx(), group), domain.ErrGroupNotFound)
+
+		require.NoError(t, f.repos.Groups.Restore(f.ctx(), group.ID()))
+
+		_, err = f.repos.Groups.FindInTrash(f.ctx(), group.ID())
+		assert.ErrorIs(t, err, domain.ErrGroupNotFound)
+
+		found, err := f.repos.Groups.Find(f.ctx(), group.ID())
+		require.NoError(t, err)
+		assert.Equal(t, group, found)
+		assert.ErrorIs(t, f.repos.Groups.Restore(f.ctx(), group.ID()), domain.ErrGroupNotFound)
+		assert.ErrorIs(t, f.repos.Groups.Purge(f.ctx(), group.ID()), domain.ErrGroupNotFound)
+	})
+
+	t.Run("purges a group from the trash", func(t *testing.T) {
+		f := newFixture(t, factory)
+		group := f.group("Hobbits")
+
+		require.NoError(t, f.repos.Groups.Delete(f.ctx(), group.ID()))
+		require.NoError(t, f.repos.Groups.Purge(f.ctx(), group.ID()))
+
+		assert.ErrorIs(t, f.repos.Groups.Restore(f.ctx(), group.ID()), domain.ErrGroupNotFound)
+	})
+
+	t.Run("refuses to purge a group with themes, even in the trash", func(t *testing.T) {
+		f := newFixture(t, factory)
+		group := f.group("Hobbits")
+		track := f.track("Concerning Hobbits", f.movie("The Fellowship of the Ring"), 2)
+		theme := f.theme("The Shire", group, nil, track, 0)
+		require.NoError(t, f.repos.Themes.Delete(f.ctx(), theme.ID()))
+		require.NoError(t, f.repos.Groups.Delete(f.ctx(), group.ID()))
+
+		assert.ErrorIs(t, f.repos.Groups.Purge(f.ctx(), group.ID()), domain.ErrInUse)
+	})
+}
@@ -0,0 +1,143 @@
+package storagetest
+
+import (
+	"testing"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMovieRepository checks the contract of domain.MovieRepository.
+func TestMovieRepository(t *testing.T, factory Factory) {
+	t.Run("saves, finds and updates a movie", func(t *testing.T) {
+		f := newFixture(t, factory)
+		movie := f.movie("The Fellowship of the Ring")
+
+		found, err := f.repos.Movies.Find(f.ctx(), movie.ID())
+		require.NoError(t, err)
+		assert.Equal(t, movie, found)
+
+		runtime := 178
+		updated, err := domain.NewMovieWithID(movie.ID().String(), "The Fellowship of the Ring", 2001, movie.Sequence().Int(), domain.MovieSeriesLordOfTheRings, &runtime)
+		require.NoError(t, err)
+		require.NoError(t, f.repos.Movies.Update(f.ctx(), updated))
+
+		found, err = f.repos.Movies.Find(f.ctx(), movie.ID())
+		require.NoError(t, err)
+		assert.Equal(t, updated, found)
+	})
+
+	t.Run("reports a missing movie", func(t *testing.T) {
+		f := newFixture(t, factory)
+		movie, err := domain.NewMovie("The Two Towers", 2002, 2, domain.MovieSeriesLordOfTheRings, nil)
+		require.NoError(t, err)
+
+		_, err = f.repos.Movies.Find(f.ctx(), movie.ID())
+		assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+		assert.ErrorIs(t, f.repos.Movies.Update(f.ctx(), movie), domain.ErrMovieNotFound)
+		assert.ErrorIs(t, f.repos.Movies.Delete(f.ctx(), movie.ID()), domain.ErrMovieNotFound)
+		assert.ErrorIs(t, f.repos.Movies.Restore(f.ctx(), movie.ID()), domain.ErrMovieNotFound)
+		assert.ErrorIs(t, f.repos.Movies.Purge(f.ctx(), movie.ID()), domain.ErrMovieNotFound)
+	})
+
+	t.Run("rejects a duplicate sequence", func(t *testing.T) {
+		f := newFixture(t, factory)
+		first := f.movie("The Fellowship of the Ring")
+		second := f.movie("The Two Towers")
+
+		duplicate, err := domain.NewMovie("The Return of the King", 2003, first.Sequence().Int(), domain.MovieSeriesLordOfTheRings, nil)
+		require.NoError(t, err)
+		assert.ErrorIs(t, f.repos.Movies.Save(f.ctx(), duplicate), domain.ErrMovieSequenceAlreadyExists)
+
+		moved, err := domain.NewMovieWithID(second.ID().String(), "The Two Towers", 2002, first.Sequence().Int(), domain.MovieSeriesLordOfTheRings, nil)
+		require.NoError(t, err)
+		assert.ErrorIs(t, f.repos.Movies.Update(f.ctx(), moved), domain.ErrMovieSequenceAlreadyExists)
+	})
+
+	t.Run("frees the sequence of a movie in the trash", func(t *testing.T) {
+		f := newFixture(t, factory)
+		trashed := f.movie("The Fellowship of the Ring")
+		require.NoError(t, f.repos.Movies.Delete(f.ctx(), trashed.ID()))
+
+		movie, err := domain.NewMovie("The Fellowship of the Ring", 2001, trashed.Sequence().Int(), domain.MovieSeriesLordOfTheRings, nil)
+		require.NoError(t, err)
+		require.NoError(t, f.repos.Movies.Save(f.ctx(), movie))
+
+		assert.ErrorIs(t, f.repos.Movies.Restore(f.ctx(), trashed.ID()), domain.ErrMovieSequenceAlreadyExists)
+		_, err = f.repos.Movies.Find(f.ctx(), trashed.ID())
+		assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+	})
+
+	t.Run("lists movies by sequence", func(t *testing.T) {
+		f := newFixture(t, factory)
+		hobbit, err := domain.NewMovie("An Unexpected Journey", 2012, 4, domain.MovieSeriesHobbit, nil)
+		require.NoError(t, err)
+		require.NoError(t, f.repos.Movies.Save(f.ctx(), hobbit))
+		hobbit.PullEvents()
+		first := f.movie("The Fellowship of the Ring")
+		second := f.movie("The Two Towers")
+
+		movies, err := f.repos.Movies.FindAll(f.ctx())
+		require.NoError(t, err)
+		assert.Equal(t, []domain.Movie{first, second, hobbit}, movies)
+
+		filter, err := domain.NewMovieFilter(domain.MovieSeriesLordOfTheRings)
+		require.NoError(t, err)
+		movies = readAllPages(t, 1, func(page domain.PageRequest) ([]domain.Movie, *domain.Cursor, error) {
+			return f.repos.Movies.FindPage(f.ctx(), filter, page)
+		})
+		assert.Equal(t, []domain.Movie{first, second}, movies)
+	})
+
+	t.Run("moves a movie to the trash and restores it", func(t *testing.T) {
+		f := newFixture(t, factory)
+		movie := f.movie("The Fellowship of the Ring")
+
+		require.NoError(t, f.repos.Movies.Delete(f.ctx(), movie.ID()))
+
+		trashed, err := f.repos.Movies.FindInTrash(f.ctx(), movie.ID())
+		require.NoError(t, err)
+		assert.Equal(t, movie, trashed)
+
+		_, err = f.repos.Movies.Find(f.ctx(), movie.ID())
+		assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+		all, err := f.repos.Movies.FindAll(f.ctx())
+		require.NoError(t, err)
+		assert.NotContains(t, all, movie)
+		assert.ErrorIs(t, f.repos.Movies.Delete(f.ctx(), movie.ID()), domain.ErrMovieNotFound)
+		assert.ErrorIs(t, f.repos.Movies.Update(f.ctx(), movie), domain.ErrMovieNotFound)
+
+		require.NoError(t, f.repos.Movies.Restore(f.ctx(), movie.ID()))
+
+		_, err = f.repos.Movies.FindInTrash(f.ctx(), movie.ID())
+		assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+
+		found, err := f.repos.Movies.Find(f.ctx(), movie.ID())
+		require.NoError(t, err)
+		assert.Equal(t, movie, found)
+		assert.ErrorIs(t, f.repos.Movies.Restore(f.ctx(), movie.ID()), domain.ErrMovieNotFound)
+		assert.ErrorIs(t, f.repos.Movies.Purge(f.ctx(), movie.ID()), domain.ErrMovieNotFound)
+	})
+
+	t.Run("purges a movie from the trash", func(t *testing.T) {
+		f := newFixture(t, factory)
+		movie := f.movie("The Fellowship of the Ring")
+
+		require.NoError(t, f.repos.Movies.Delete(f.ctx(), movie.ID()))
+		require.NoError(t, f.repos.Movies.Purge(f.ctx(), movie.ID()))
+
+		assert.ErrorIs(t, f.repos.Movies.Restore(f.ctx(), movie.ID()), domain.ErrMovieNotFound)
+	})
+
+	t.Run("refuses to purge a movie with tracks, even in the trash", func(t *testing.T) {
+		f := newFixture(t, factory)
+		movie := f.movie("The Fellowship of the Ring")
+		track := f.track("Concerning Hobbits", movie, 2)
+		require.NoError(t, f.repos.Tracks.Delete(f.ctx(), track.ID()))
+		require.NoError(t, f.repos.Movies.Delete(f.ctx(), movie.ID()))
+
+		assert.ErrorIs(t, f.repos.Movies.Purge(f.ctx(), movie.ID()), domain.ErrInUse)
+		assert.NoError(t, f.repos.Movies.Restore(f.ctx(), movie.ID()))
+	})
+}
@@ -0,0 +1,117 @@
+package storagetest
+
+import (
+	"testing"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOutbox checks the contract of event.Outbox.
+func TestOutbox(t *testing.T, factory Factory) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	lease := time.Minute
+
+	t.Run("claims the events oldest first, up to the limit", func(t *testing.T) {
+		f := newFixture(t, factory)
+		events := f.outboxEvents(3)
+
+		claimed, err := f.repos.Outbox.Claim(f.ctx(), now, lease, 2)
+		require.NoError(t, err)
+		assertSameEvents(t, events[:2], claimed)
+
+		claimed, err = f.repos.Outbox.Claim(f.ctx(), now, lease, 2)
+		require.NoError(t, err)
+		assertSameEvents(t, events[2:], claimed)
+	})
+
+	t.Run("keeps the events of their payload", func(t *testing.T) {
+		f := newFixture(t, factory)
+		movieID, trackID := newID(t), newID(t)
+		e := domain.NewTrackUpdatedEvent(trackID, "The Bridge of Khazad-dûm", movieID)
+		require.NoError(t, f.repos.Outbox.Save(f.ctx(), []event.Event{e}))
+
+		claimed, err := f.repos.Outbox.Claim(f.ctx(), now, lease, 10)
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+
+		track, ok := claimed[0].(domain.TrackUpdatedEvent)
+		require.True(t, ok, "claimed a %T", claimed[0])
+		assert.Equal(t, trackID, track.TrackID())
+		assert.Equal(t, "The Bridge of Khazad-dûm", track.Name())
+		assert.Equal(t, movieID, track.MovieID())
+	})
+
+	t.Run("ignores the events already saved", func(t *testing.T) {
+		f := newFixture(t, factory)
+		events := f.outboxEvents(2)
+		require.NoError(t, f.repos.Outbox.Save(f.ctx(), events))
+
+		claimed, err := f.repos.Outbox.Claim(f.ctx(), now, lease, 10)
+		require.NoError(t, err)
+		assertSameEvents(t, events, claimed)
+	})
+
+	t.Run("claims an event again once its lease runs out", func(t *testing.T) {
+		f := newFixture(t, factory)
+		events := f.outboxEvents(1)
+
+		_, err := f.repos.Outbox.Claim(f.ctx(), now, lease, 10)
+		require.NoError(t, err)
+
+		claimed, err := f.repos.Outbox.Claim(f.ctx(), now.Add(lease/2), lease, 10)
+		require.NoError(t, err)
+		assert.Empty(t, claimed)
+
+		claimed, err = f.repos.Outbox.Claim(f.ctx(), now.Add(2*lease), lease, 10)
+		require.NoError(t, err)
+		assertSameEvents(t, events, claimed)
+	})
+
+	t.Run("does not claim the events sent", func(t *testing.T) {
+		f := newFixture(t, factory)
+		events := f.outboxEvents(2)
+
+		require.NoError(t, f.repos.Outbox.MarkSent(f.ctx(), events[0].ID(), now))
+
+		claimed, err := f.repos.Outbox.Claim(f.ctx(), now, lease, 10)
+		require.NoError(t, err)
+		assertSameEvents(t, events[1:], claimed)
+
+		require.NoError(t, f.repos.Outbox.MarkSent(f.ctx(), events[1].ID(), now))
+
+		claimed, err = f.repos.Outbox.Claim(f.ctx(), now.Add(2*lease), lease, 10)
+		require.NoError(t, err)
+		assert.Empty(t, claimed)
+	})
+}
+
+// outboxEvents saves n movie events to the outbox, in that order.
+func (f *fixture) outboxEvents(n int) []event.Event {
+	f.t.Helper()
+
+	events := make([]event.Event, 0, n)
+	for i := range n {
+		events = append(events, domain.NewMovieCreatedEvent(newID(f.t), name("Movie", i)))
+	}
+	require.NoError(f.t, f.repos.Outbox.Save(f.ctx(), events))
+
+	return events
+}
+
+// assertSameEvents compares the events by what they carry. The time they occurred on is
+// compared to the microsecond, the precision of the SQL timestamps.
+func assertSameEvents(t *testing.T, expected, actual []event.Event) {
+	t.Helper()
+
+	require.Len(t, actual, len(expected))
+	for i, e := range expected {
+		assert.Equal(t, e.ID(), actual[i].ID())
+		assert.Equal(t, e.Type(), actual[i].Type())
+		assert.Equal(t, e.AggregateID(), actual[i].AggregateID())
+		assert.WithinDuration(t, e.OccurredOn(), actual[i].OccurredOn(), time.Microsecond)
+	}
+}
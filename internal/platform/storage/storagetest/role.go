@@ -0,0 +1,32 @@
+package storagetest
+
+import (
+	"testing"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRoleRepository checks the contract of domain.RoleRepository. Every storage starts
+// with the default roles.
+func TestRoleRepository(t *testing.T, factory Factory) {
+	t.Run("finds the default roles", func(t *testing.T) {
+		f := newFixture(t, factory)
+
+		for _, role := range domain.DefaultRoles() {
+			found, err := f.repos.Roles.Find(f.ctx(), role.Name())
+			require.NoError(t, err)
+			assert.ElementsMatch(t, role.Permissions(), found.Permissions(), role.Name().String())
+		}
+	})
+
+	t.Run("reports a missing role", func(t *testing.T) {
+		f := newFixture(t, factory)
+		name, err := domain.NewRoleName("wizard")
+		require.NoError(t, err)
+
+		_, err = f.repos.Roles.Find(f.ctx(), name)
+		assert.ErrorIs(t, err, domain.ErrRoleNotFound)
+	})
+}
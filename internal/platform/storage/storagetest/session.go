@@ -0,0 +1,176 @@
+package storagetest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSessionRepository checks the contract of domain.SessionRepository.
+func TestSessionRepository(t *testing.T, factory Factory) {
+	expiresAt := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("finds a session by its current and previous token", func(t *testing.T) {
+		f := newFixture(t, factory)
+		session := f.session(tokenHash("first"), expiresAt)
+
+		found, err := f.repos.Sessions.FindByTokenHash(f.ctx(), tokenHash("first"))
+		require.NoError(t, err)
+		assert.Equal(t, session, found)
+
+		rotated, err := session.Rotate(tokenHash("second"), expiresAt.Add(time.Hour))
+		require.NoError(t, err)
+		require.NoError(t, f.repos.Sessions.Rotate(f.ctx(), rotated))
+
+		for _, hash := range []string{tokenHash("first"), tokenHash("second")} {
+			found, err := f.repos.Sessions.FindByTokenHash(f.ctx(), hash)
+			require.NoError(t, err)
+			assert.Equal(t, rotated, found)
+		}
+
+		_, err = f.repos.Sessions.FindByTokenHash(f.ctx(), tokenHash("unknown"))
+		assert.ErrorIs(t, err, domain.ErrSessionNotFound)
+	})
+
+	t.Run("rotates a token only once", func(t *testing.T) {
+		f := newFixture(t, factory)
+		session := f.session(tokenHash("first"), expiresAt)
+
+		rotated, err := session.Rotate(tokenHash("second"), expiresAt)
+		require.NoError(t, err)
+		require.NoError(t, f.repos.Sessions.Rotate(f.ctx(), rotated))
+
+		concurrent, err := session.Rotate(tokenHash("other"), expiresAt)
+		require.NoError(t, err)
+		err = f.repos.Sessions.Rotate(f.ctx(), concurrent)
+		assert.ErrorIs(t, err, domain.ErrInvalidRefreshToken)
+	})
+
+	t.Run("does not rotate a revoked session", func(t *testing.T) {
+		f := newFixture(t, factory)
+		session := f.session(tokenHash("first"), expiresAt)
+		revokedAt := expiresAt.Add(-time.Hour)
+		require.NoError(t, f.repos.Sessions.Revoke(f.ctx(), session.ID(), revokedAt))
+		require.NoError(t, f.repos.Sessions.Revoke(f.ctx(), session.ID(), expiresAt))
+
+		found, err := f.repos.Sessions.FindByTokenHash(f.ctx(), tokenHash("first"))
+		require.NoError(t, err)
+		assert.Equal(t, revokedAt, found.RevokedAt())
+
+		rotated, err := session.Rotate(tokenHash("second"), expiresAt)
+		require.NoError(t, err)
+		err = f.repos.Sessions.Rotate(f.ctx(), rotated)
+		assert.ErrorIs(t, err, domain.ErrInvalidRefreshToken)
+	})
+
+	t.Run("revokes the sessions of a user but one", func(t *testing.T) {
+		f := newFixture(t, factory)
+		kept := f.session(tokenHash("kept"), expiresAt)
+		other, err := domain.NewSession(kept.UserID(), tokenHash("other"), expiresAt)
+		require.NoError(t, err)
+		require.NoError(t, f.repos.Sessions.Save(f.ctx(), other))
+		stranger := f.session(tokenHash("stranger"), expiresAt)
+
+		require.NoError(t, f.repos.Sessions.RevokeByUser(f.ctx(), kept.UserID(), kept.ID(), expiresAt.Add(-time.Hour)))
+
+		for id, expected := range map[domain.SessionID]bool{kept.ID(): false, other.ID(): true, stranger.ID(): false} {
+			revoked, err := f.repos.Sessions.IsRevoked(f.ctx(), id)
+			require.NoError(t, err)
+			assert.Equal(t, expected, revoked)
+		}
+
+		require.NoError(t, f.repos.Sessions.RevokeByUser(f.ctx(), kept.UserID(), domain.SessionID{}, expiresAt))
+		revoked, err := f.repos.Sessions.IsRevoked(f.ctx(), kept.ID())
+		require.NoError(t, err)
+		assert.True(t, revoked)
+
+		found, err := f.repos.Sessions.FindByTokenHash(f.ctx(), tokenHash("other"))
+		require.NoError(t, err)
+		assert.Equal(t, expiresAt.Add(-time.Hour), found.RevokedAt())
+	})
+
+	t.Run("counts a missing session as revoked", func(t *testing.T) {
+		f := newFixture(t, factory)
+		id, err := domain.NewSessionIDFromString(newID(t))
+		require.NoError(t, err)
+
+		revoked, err := f.repos.Sessions.IsRevoked(f.ctx(), id)
+		require.NoError(t, err)
+		assert.True(t, revoked)
+	})
+
+	t.Run("rejects a session of a missing user", func(t *testing.T) {
+		f := newFixture(t, factory)
+		userID, err := domain.NewUserIDFromString(newID(t))
+		require.NoError(t, err)
+		session, err := domain.NewSession(userID, tokenHash("first"), expiresAt)
+		require.NoError(t, err)
+
+		err = f.repos.Sessions.Save(f.ctx(), session)
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+}
+
+// TestRevocationRepository checks the contract of domain.RevocationRepository.
+func TestRevocationRepository(t *testing.T, factory Factory) {
+	t.Run("lists revoked tokens", func(t *testing.T) {
+		f := newFixture(t, factory)
+		revoked := newID(t)
+		expiresAt := time.Date(2024, 3, 1, 12, 15, 0, 0, time.UTC)
+
+		require.NoError(t, f.repos.Revocations.Revoke(f.ctx(), revoked, expiresAt))
+		require.NoError(t, f.repos.Revocations.Revoke(f.ctx(), revoked, expiresAt))
+
+		isRevoked, err := f.repos.Revocations.IsRevoked(f.ctx(), revoked)
+		require.NoError(t, err)
+		assert.True(t, isRevoked)
+
+		isRevoked, err = f.repos.Revocations.IsRevoked(f.ctx(), newID(t))
+		require.NoError(t, err)
+		assert.False(t, isRevoked)
+	})
+
+	t.Run("prunes expired tokens", func(t *testing.T) {
+		f := newFixture(t, factory)
+		expired := newID(t)
+		live := newID(t)
+		now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+		require.NoError(t, f.repos.Revocations.Revoke(f.ctx(), expired, now.Add(-time.Minute)))
+		require.NoError(t, f.repos.Revocations.Revoke(f.ctx(), live, now.Add(time.Minute)))
+		require.NoError(t, f.repos.Revocations.Prune(f.ctx(), now))
+
+		isRevoked, err := f.repos.Revocations.IsRevoked(f.ctx(), expired)
+		require.NoError(t, err)
+		assert.False(t, isRevoked)
+
+		isRevoked, err = f.repos.Revocations.IsRevoked(f.ctx(), live)
+		require.NoError(t, err)
+		assert.True(t, isRevoked)
+	})
+}
+
+// session saves a session of a new user.
+func (f *fixture) session(tokenHash string, expiresAt time.Time) domain.Session {
+	f.t.Helper()
+
+	user, err := domain.NewUser("Frodo Baggins", newID(f.t)+"@shire.me", "hashed-password", domain.RoleViewer)
+	require.NoError(f.t, err)
+	require.NoError(f.t, f.repos.Users.Save(f.ctx(), user))
+
+	session, err := domain.NewSession(user.ID(), tokenHash, expiresAt)
+	require.NoError(f.t, err)
+	require.NoError(f.t, f.repos.Sessions.Save(f.ctx(), session))
+
+	return session
+}
+
+func tokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
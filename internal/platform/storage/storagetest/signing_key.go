@@ -0,0 +1,58 @@
+package storagetest
+
+import (
+	"testing"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSigningKeyRepository checks the contract of domain.SigningKeyRepository.
+func TestSigningKeyRepository(t *testing.T, factory Factory) {
+	activatesAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("finds the keys in activation order", func(t *testing.T) {
+		f := newFixture(t, factory)
+		later := f.signingKey(domain.SigningAlgorithmEdDSA, activatesAt.Add(time.Hour))
+		earlier := f.signingKey(domain.SigningAlgorithmRS256, activatesAt)
+
+		keys, err := f.repos.SigningKeys.FindAll(f.ctx())
+		require.NoError(t, err)
+		assert.Equal(t, []domain.SigningKey{earlier, later}, keys)
+	})
+
+	t.Run("deletes a key", func(t *testing.T) {
+		f := newFixture(t, factory)
+		deleted := f.signingKey(domain.SigningAlgorithmEdDSA, activatesAt)
+		kept := f.signingKey(domain.SigningAlgorithmEdDSA, activatesAt.Add(time.Hour))
+
+		require.NoError(t, f.repos.SigningKeys.Delete(f.ctx(), deleted.ID()))
+		require.NoError(t, f.repos.SigningKeys.Delete(f.ctx(), deleted.ID()))
+
+		keys, err := f.repos.SigningKeys.FindAll(f.ctx())
+		require.NoError(t, err)
+		assert.Equal(t, []domain.SigningKey{kept}, keys)
+	})
+
+	t.Run("finds no keys in an empty storage", func(t *testing.T) {
+		f := newFixture(t, factory)
+
+		keys, err := f.repos.SigningKeys.FindAll(f.ctx())
+		require.NoError(t, err)
+		assert.Empty(t, keys)
+	})
+}
+
+// signingKey saves a key with a new ID. The private key is not parsed by the
+// repositories, so any bytes do.
+func (f *fixture) signingKey(algorithm domain.SigningAlgorithm, activatesAt time.Time) domain.SigningKey {
+	f.t.Helper()
+
+	key, err := domain.NewSigningKey(newID(f.t), algorithm, []byte("private key of "+algorithm.String()), activatesAt)
+	require.NoError(f.t, err)
+	require.NoError(f.t, f.repos.SigningKeys.Save(f.ctx(), key))
+
+	return key
+}
@@ -0,0 +1,196 @@
+// Package storagetest holds the contract test suites of the domain repositories. Every
+// storage implementation runs them against its own repositories, so they all report
+// missing rows, broken constraints and cascades with the same domain errors.
+package storagetest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/listing"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+	"github.com/stretchr/testify/require"
+)
+
+// Repositories are the repositories of one storage implementation under test.
+type Repositories struct {
+	Users       domain.UserRepository
+	Movies      domain.MovieRepository
+	Groups      domain.GroupRepository
+	Categories  domain.CategoryRepository
+	Tracks      domain.TrackRepository
+	Themes      domain.ThemeRepository
+	TrackThemes domain.TrackThemeRepository
+	Trash       listing.TrashRepository
+	Audit       domain.AuditRepository
+	Outbox      event.Outbox
+	Webhooks    domain.WebhookRepository
+	Deliveries  domain.WebhookDeliveryRepository
+	Sessions    domain.SessionRepository
+	Revocations domain.RevocationRepository
+	SigningKeys domain.SigningKeyRepository
+	Roles       domain.RoleRepository
+
+	VerificationTokens domain.VerificationTokenRepository
+}
+
+// Factory returns the repositories of a fresh, empty storage. It is called once per test.
+type Factory func(t *testing.T) Repositories
+
+// Run runs the contract suite of every repository.
+func Run(t *testing.T, factory Factory) {
+	t.Run("UserRepository", func(t *testing.T) { TestUserRepository(t, factory) })
+	t.Run("MovieRepository", func(t *testing.T) { TestMovieRepository(t, factory) })
+	t.Run("GroupRepository", func(t *testing.T) { TestGroupRepository(t, factory) })
+	t.Run("CategoryRepository", func(t *testing.T) { TestCategoryRepository(t, factory) })
+	t.Run("TrackRepository", func(t *testing.T) { TestTrackRepository(t, factory) })
+	t.Run("ThemeRepository", func(t *testing.T) { TestThemeRepository(t, factory) })
+	t.Run("TrackThemeRepository", func(t *testing.T) { TestTrackThemeRepository(t, factory) })
+	t.Run("TrashRepository", func(t *testing.T) { TestTrashRepository(t, factory) })
+	t.Run("AuditRepository", func(t *testing.T) { TestAuditRepository(t, factory) })
+	t.Run("Outbox", func(t *testing.T) { TestOutbox(t, factory) })
+	t.Run("WebhookRepository", func(t *testing.T) { TestWebhookRepository(t, factory) })
+	t.Run("WebhookDeliveryRepository", func(t *testing.T) { TestWebhookDeliveryRepository(t, factory) })
+	t.Run("SessionRepository", func(t *testing.T) { TestSessionRepository(t, factory) })
+	t.Run("RevocationRepository", func(t *testing.T) { TestRevocationRepository(t, factory) })
+	t.Run("SigningKeyRepository", func(t *testing.T) { TestSigningKeyRepository(t, factory) })
+	t.Run("RoleRepository", func(t *testing.T) { TestRoleRepository(t, factory) })
+	t.Run("VerificationTokenRepository", func(t *testing.T) { TestVerificationTokenRepository(t, factory) })
+}
+
+// fixture saves valid catalogue entries through the repositories under test.
+type fixture struct {
+	t     *testing.T
+	repos Repositories
+
+	sequence int // Sequence of the last movie saved
+}
+
+func newFixture(t *testing.T, factory Factory) *fixture {
+	return &fixture{
+		t:     t,
+		repos: factory(t),
+	}
+}
+
+func (f *fixture) ctx() context.Context {
+	return context.Background()
+}
+
+// movie saves a movie with the next free sequence.
+func (f *fixture) movie(name string) domain.Movie {
+	f.t.Helper()
+
+	f.sequence++
+	movie, err := domain.NewMovie(name, 2000+f.sequence, f.sequence, domain.MovieSeriesLordOfTheRings, nil)
+	require.NoError(f.t, err)
+	require.NoError(f.t, f.repos.Movies.Save(f.ctx(), movie))
+	// Drop the creation event, so that the movie compares equal to the one found
+	movie.PullEvents()
+
+	return movie
+}
+
+// track saves a theatrical track of the movie, five minutes long.
+func (f *fixture) track(name string, movie domain.Movie, trackNumber int) domain.Track {
+	f.t.Helper()
+
+	track, err := domain.NewTrack(name, movie.ID().String(), nil, trackNumber, 1, domain.TrackEditionTheatrical, 300)
+	require.NoError(f.t, err)
+	require.NoError(f.t, f.repos.Tracks.Save(f.ctx(), track))
+	track.PullEvents()
+
+	return track
+}
+
+func (f *fixture) group(name string) domain.Group {
+	f.t.Helper()
+
+	group, err := domain.NewGroup(name, "The themes of "+name, "https://example.com/"+name+".jpg")
+	require.NoError(f.t, err)
+	require.NoError(f.t, f.repos.Groups.Save(f.ctx(), group))
+	group.PullEvents()
+
+	return group
+}
+
+func (f *fixture) category(name string) domain.Category {
+	f.t.Helper()
+
+	category, err := domain.NewCategory(name)
+	require.NoError(f.t, err)
+	require.NoError(f.t, f.repos.Categories.Save(f.ctx(), category))
+	category.PullEvents()
+
+	return category
+}
+
+// theme saves a theme of the group, first heard in the track from firstHeardStart for
+// thirty seconds. The category is optional.
+func (f *fixture) theme(name string, group domain.Group, category *domain.Category, firstHeard domain.Track, firstHeardStart int) domain.Theme {
+	f.t.Helper()
+
+	var categoryID *string
+	if category != nil {
+		id := category.ID().String()
+		categoryID = &id
+	}
+
+	theme, err := domain.NewTheme(name, firstHeard.ID().String(), group.ID().String(), "The "+name+" theme", firstHeardStart, firstHeardStart+30, categoryID)
+	require.NoError(f.t, err)
+	require.NoError(f.t, f.repos.Themes.Save(f.ctx(), theme))
+	theme.PullEvents()
+
+	return theme
+}
+
+func (f *fixture) trackTheme(track domain.Track, theme domain.Theme, startSecond, endSecond int) domain.TrackTheme {
+	f.t.Helper()
+
+	trackTheme, err := domain.NewTrackTheme(track.ID().String(), theme.ID().String(), startSecond, endSecond, false)
+	require.NoError(f.t, err)
+	require.NoError(f.t, f.repos.TrackThemes.Save(f.ctx(), trackTheme))
+
+	return trackTheme
+}
+
+// readAllPages reads every page of a list, limit items at a time, and returns the items
+// in the order they were read.
+func readAllPages[T any](t *testing.T, limit int, findPage func(page domain.PageRequest) ([]T, *domain.Cursor, error)) []T {
+	t.Helper()
+
+	var all []T
+	cursor := ""
+	for range 100 {
+		page, err := domain.NewPageRequest(limit, cursor)
+		require.NoError(t, err)
+
+		items, next, err := findPage(page)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(items), limit)
+		all = append(all, items...)
+
+		if next == nil {
+			return all
+		}
+		cursor = next.String()
+	}
+
+	require.FailNow(t, "pagination did not end")
+	return nil
+}
+
+// newID returns a fresh identifier that no stored row uses.
+func newID(t *testing.T) string {
+	t.Helper()
+
+	id, err := domain.NewMovieID()
+	require.NoError(t, err)
+	return id.String()
+}
+
+func name(prefix string, i int) string {
+	return fmt.Sprintf("%s %d", prefix, i)
+}
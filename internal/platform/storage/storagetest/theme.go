@@ -0,0 +1,241 @@
+package storagetest
+
+import (
+	"testing"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestThemeRepository checks the contract of domain.ThemeRepository.
+func TestThemeRepository(t *testing.T, factory Factory) {
+	t.Run("saves, finds and updates a theme", func(t *testing.T) {
+		f := newFixture(t, factory)
+		track := f.track("Concerning Hobbits", f.movie("The Fellowship of the Ring"), 2)
+		category := f.category("Places")
+		theme := f.theme("The Shire", f.group("Hobbits"), &category, track, 0)
+
+		found, err := f.repos.Themes.Find(f.ctx(), theme.ID())
+		require.NoError(t, err)
+		assert.Equal(t, theme, found)
+
+		group := f.group("The Shire")
+		updated, err := domain.NewThemeWithID(theme.ID().String(), "The Shire", track.ID().String(), group.ID().String(), "Pastoral", 5, 45, nil)
+		require.NoError(t, err)
+		require.NoError(t, f.repos.Themes.Update(f.ctx(), updated))
+
+		found, err = f.repos.Themes.Find(f.ctx(), theme.ID())
+		require.NoError(t, err)
+		assert.Equal(t, updated, found)
+	})
+
+	t.Run("reports a missing theme", func(t *testing.T) {
+		f := newFixture(t, factory)
+		track := f.track("Concerning Hobbits", f.movie("The Fellowship of the Ring"), 2)
+		theme, err := domain.NewTheme("The Shire", track.ID().String(), f.group("Hobbits").ID().String(), "Pastoral", 0, 30, nil)
+		require.NoError(t, err)
+
+		_, err = f.repos.Themes.Find(f.ctx(), theme.ID())
+		assert.ErrorIs(t, err, domain.ErrThemeNotFound)
+		assert.ErrorIs(t, f.repos.Themes.Update(f.ctx(), theme), domain.ErrThemeNotFound)
+		assert.ErrorIs(t, f.repos.Themes.Delete(f.ctx(), theme.ID()), domain.ErrThemeNotFound)
+		assert.ErrorIs(t, f.repos.Themes.Restore(f.ctx(), theme.ID()), domain.ErrThemeNotFound)
+		assert.ErrorIs(t, f.repos.Themes.Purge(f.ctx(), theme.ID()), domain.ErrThemeNotFound)
+	})
+
+	// Each foreign key of themes reports the entity it points to.
+	t.Run("maps foreign key violations", func(t *testing.T) {
+		f := newFixture(t, factory)
+		track := f.track("Concerning Hobbits", f.movie("The Fellowship of the Ring"), 2)
+		group := f.group("Hobbits")
+		missing := newID(t)
+
+		tests := map[string]struct {
+			firstHeard string
+			groupID    string
+			categoryID *string
+			expected   error
+		}{
+			"missing group":       {firstHeard: track.ID().String(), groupID: missing, expected: domain.ErrGroupNotFound},
+			"missing category":    {firstHeard: track.ID().String(), groupID: group.ID().String(), categoryID: &missing, expected: domain.ErrCategoryNotFound},
+			"missing first heard": {firstHeard: missing, groupID: group.ID().String(), expected: domain.ErrTrackNotFound},
+		}
+
+		for name, tt := range tests {
+			t.Run(name, func(t *testing.T) {
+				theme, err := domain.NewTheme("The Shire", tt.firstHeard, tt.groupID, "Pastoral", 0, 30, tt.categoryID)
+				require.NoError(t, err)
+
+				assert.ErrorIs(t, f.repos.Themes.Save(f.ctx(), theme), tt.expected)
+			})
+		}
+
+		theme := f.theme("The Shire", group, nil, track, 0)
+		for name, tt := range tests {
+			t.Run("update with "+name, func(t *testing.T) {
+				updated, err := domain.NewThemeWithID(theme.ID().String(), "The Shire", tt.firstHeard, tt.groupID, "Pastoral", 0, 30, tt.categoryID)
+				require.NoError(t, err)
+
+				assert.ErrorIs(t, f.repos.Themes.Update(f.ctx(), updated), tt.expected)
+			})
+		}
+	})
+
+	// A theme cannot point to a group, a category or a first heard track in the trash.
+	t.Run("rejects parents in the trash", func(t *testing.T) {
+		f := newFixture(t, factory)
+		movie := f.movie("The Fellowship of the Ring")
+		track := f.track("Concerning Hobbits", movie, 2)
+		group := f.group("Hobbits")
+		theme := f.theme("The Shire", group, nil, track, 0)
+
+		trashedTrack := f.track("The Shire", movie, 3)
+		require.NoError(t, f.repos.Tracks.Delete(f.ctx(), trashedTrack.ID()))
+		trashedGroup := f.group("Elves")
+		require.NoError(t, f.repos.Groups.Delete(f.ctx(), trashedGroup.ID()))
+		trashedCategory := f.category("Main")
+		require.NoError(t, f.repos.Categories.Delete(f.ctx(), trashedCategory.ID()))
+		trashedCategoryID := trashedCategory.ID().String()
+
+		tests := map[string]struct {
+			firstHeard string
+			groupID    string
+			categoryID *string
+			expected   error
+		}{
+			"group in the trash":       {firstHeard: track.ID().String(), groupID: trashedGroup.ID().String(), expected: domain.ErrGroupNotFound},
+			"category in the trash":    {firstHeard: track.ID().String(), groupID: group.ID().String(), categoryID: &trashedCategoryID, expected: domain.ErrCategoryNotFound},
+			"first heard in the trash": {firstHeard: trashedTrack.ID().String(), groupID: group.ID().String(), expected: domain.ErrTrackNotFound},
+		}
+
+		for name, tt := range tests {
+			t.Run(name, func(t *testing.T) {
+				created, err := domain.NewTheme("Rivendell", tt.firstHeard, tt.groupID, "Elvish", 0, 30, tt.categoryID)
+				require.NoError(t, err)
+				assert.ErrorIs(t, f.repos.Themes.Save(f.ctx(), created), tt.expected)
+
+				updated, err := domain.NewThemeWithID(theme.ID().String(), "The Shire", tt.firstHeard, tt.groupID, "Pastoral", 0, 30, tt.categoryID)
+				require.NoError(t, err)
+				assert.ErrorIs(t, f.repos.Themes.Update(f.ctx(), updated), tt.expected)
+			})
+		}
+	})
+
+	t.Run("lists themes by group and filter", func(t *testing.T) {
+		f := newFixture(t, factory)
+		fellowship := f.movie("The Fellowship of the Ring")
+		towers := f.movie("The Two Towers")
+		shireTrack := f.track("Concerning Hobbits", fellowship, 2)
+		rohanTrack := f.track("The Riders of Rohan", towers, 3)
+		hobbits := f.group("Hobbits")
+		rohan := f.group("Rohan")
+		places := f.category("Places")
+
+		shire := f.theme("The Shire", hobbits, &places, shireTrack, 60)
+		understanding := f.theme("A Hobbit's Understanding", hobbits, nil, shireTrack, 120)
+		rohanTheme := f.theme("Rohan Fanfare", rohan, &places, rohanTrack, 0)
+
+		themes, err := f.repos.Themes.FindByGroup(f.ctx(), hobbits.ID())
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []domain.Theme{shire, understanding}, themes)
+
+		themes, err = f.repos.Themes.FindByCategory(f.ctx(), places.ID())
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []domain.Theme{shire, rohanTheme}, themes)
+
+		themes, err = f.repos.Themes.FindByFirstHeard(f.ctx(), shireTrack.ID())
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []domain.Theme{shire, understanding}, themes)
+
+		themes, err = f.repos.Themes.FindByMovie(f.ctx(), towers.ID())
+		require.NoError(t, err)
+		assert.Equal(t, []domain.Theme{rohanTheme}, themes)
+
+		tests := map[string]struct {
+			groupID, categoryID, movieID, name, sort string
+			expected                                 []domain.Theme
+		}{
+			"by name":              {sort: "name", expected: []domain.Theme{understanding, rohanTheme, shire}},
+			"by first heard start": {sort: "first_heard_start", expected: []domain.Theme{rohanTheme, shire, understanding}},
+			"of a group":           {groupID: hobbits.ID().String(), sort: "name", expected: []domain.Theme{understanding, shire}},
+			"of a category":        {categoryID: places.ID().String(), sort: "name", expected: []domain.Theme{rohanTheme, shire}},
+			"of a movie":           {movieID: towers.ID().String(), expected: []domain.Theme{rohanTheme}},
+			"by name substring":    {name: "HOBBIT", expected: []domain.Theme{understanding}},
+		}
+
+		for name, tt := range tests {
+			t.Run(name, func(t *testing.T) {
+				filter, err := domain.NewThemeFilter(tt.groupID, tt.categoryID, tt.movieID, tt.name, tt.sort)
+				require.NoError(t, err)
+
+				themes := readAllPages(t, 1, func(page domain.PageRequest) ([]domain.Theme, *domain.Cursor, error) {
+					return f.repos.Themes.FindPage(f.ctx(), filter, page)
+				})
+				assert.Equal(t, tt.expected, themes)
+			})
+		}
+	})
+
+	t.Run("moves a theme to the trash and restores it", func(t *testing.T) {
+		f := newFixture(t, factory)
+		theme := f.theme("The Shire", f.group("Hobbits"), nil, f.track("Concerning Hobbits", f.movie("The Fellowship of the Ring"), 2), 0)
+
+		require.NoError(t, f.repos.Themes.Delete(f.ctx(), theme.ID()))
+
+		trashed, err := f.repos.Themes.FindInTrash(f.ctx(), theme.ID())
+		require.NoError(t, err)
+		assert.Equal(t, theme, trashed)
+
+		_, err = f.repos.Themes.Find(f.ctx(), theme.ID())
+		assert.ErrorIs(t, err, domain.ErrThemeNotFound)
+		all, err := f.repos.Themes.FindAll(f.ctx())
+		require.NoError(t, err)
+		assert.NotContains(t, all, theme)
+		assert.ErrorIs(t, f.repos.Themes.Delete(f.ctx(), theme.ID()), domain.ErrThemeNotFound)
+		assert.ErrorIs(t, f.repos.Themes.Update(f.ctx(), theme), domain.ErrThemeNotFound)
+
+		require.NoError(t, f.repos.Themes.Restore(f.ctx(), theme.ID()))
+
+		_, err = f.repos.Themes.FindInTrash(f.ctx(), theme.ID())
+		assert.ErrorIs(t, err, domain.ErrThemeNotFound)
+
+		found, err := f.repos.Themes.Find(f.ctx(), theme.ID())
+		require.NoError(t, err)
+		assert.Equal(t, theme, found)
+		assert.ErrorIs(t, f.repos.Themes.Restore(f.ctx(), theme.ID()), domain.ErrThemeNotFound)
+		assert.ErrorIs(t, f.repos.Themes.Purge(f.ctx(), theme.ID()), domain.ErrThemeNotFound)
+	})
+
+	t.Run("purges a theme from the trash", func(t *testing.T) {
+		f := newFixture(t, factory)
+		theme := f.theme("The Shire", f.group("Hobbits"), nil, f.track("Concerning Hobbits", f.movie("The Fellowship of the Ring"), 2), 0)
+
+		require.NoError(t, f.repos.Themes.Delete(f.ctx(), theme.ID()))
+		require.NoError(t, f.repos.Themes.Purge(f.ctx(), theme.ID()))
+
+		assert.ErrorIs(t, f.repos.Themes.Restore(f.ctx(), theme.ID()), domain.ErrThemeNotFound)
+	})
+
+	t.Run("keeps the track themes of a theme in the trash until it is purged", func(t *testing.T) {
+		f := newFixture(t, factory)
+		track := f.track("Concerning Hobbits", f.movie("The Fellowship of the Ring"), 2)
+		group := f.group("Hobbits")
+		theme := f.theme("The Shire", group, nil, track, 0)
+		other := f.theme("A Hobbit's Understanding", group, nil, track, 60)
+		annotation := f.trackTheme(track, theme, 0, 30)
+		kept := f.trackTheme(track, other, 60, 90)
+
+		require.NoError(t, f.repos.Themes.Delete(f.ctx(), theme.ID()))
+
+		trackThemes, err := f.repos.TrackThemes.FindByTrack(f.ctx(), track.ID())
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []domain.TrackTheme{annotation, kept}, trackThemes)
+
+		require.NoError(t, f.repos.Themes.Purge(f.ctx(), theme.ID()))
+
+		trackThemes, err = f.repos.TrackThemes.FindByTrack(f.ctx(), track.ID())
+		require.NoError(t, err)
+		assert.Equal(t, []domain.TrackTheme{kept}, trackThemes)
+	})
+}
@@ -0,0 +1,213 @@
+package storagetest
+
+import (
+	"testing"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTrackRepository checks the contract of domain.TrackRepository.
+func TestTrackRepository(t *testing.T, factory Factory) {
+	t.Run("saves, finds and updates a track", func(t *testing.T) {
+		f := newFixture(t, factory)
+		movie := f.movie("The Fellowship of the Ring")
+		track := f.track("Concerning Hobbits", movie, 2)
+
+		found, err := f.repos.Tracks.Find(f.ctx(), track.ID())
+		require.NoError(t, err)
+		assert.Equal(t, track, found)
+
+		found, err = f.repos.Tracks.FindForUpdate(f.ctx(), track.ID())
+		require.NoError(t, err)
+		assert.Equal(t, track, found)
+
+		spotifyURL := "https://open.spotify.com/track/1"
+		updated, err := domain.NewTrackWithID(track.ID().String(), "Concerning Hobbits", movie.ID().String(), &spotifyURL, 3, 1, domain.TrackEditionTheatrical, 172)
+		require.NoError(t, err)
+		require.NoError(t, f.repos.Tracks.Update(f.ctx(), updated))
+
+		found, err = f.repos.Tracks.Find(f.ctx(), track.ID())
+		require.NoError(t, err)
+		assert.Equal(t, updated, found)
+	})
+
+	t.Run("reports a missing track", func(t *testing.T) {
+		f := newFixture(t, factory)
+		movie := f.movie("The Fellowship of the Ring")
+		track, err := domain.NewTrack("Concerning Hobbits", movie.ID().String(), nil, 2, 1, domain.TrackEditionTheatrical, 172)
+		require.NoError(t, err)
+
+		_, err = f.repos.Tracks.Find(f.ctx(), track.ID())
+		assert.ErrorIs(t, err, domain.ErrTrackNotFound)
+		_, err = f.repos.Tracks.FindForUpdate(f.ctx(), track.ID())
+		assert.ErrorIs(t, err, domain.ErrTrackNotFound)
+		assert.ErrorIs(t, f.repos.Tracks.Update(f.ctx(), track), domain.ErrTrackNotFound)
+		assert.ErrorIs(t, f.repos.Tracks.Delete(f.ctx(), track.ID()), domain.ErrTrackNotFound)
+		assert.ErrorIs(t, f.repos.Tracks.Restore(f.ctx(), track.ID()), domain.ErrTrackNotFound)
+		assert.ErrorIs(t, f.repos.Tracks.Purge(f.ctx(), track.ID()), domain.ErrTrackNotFound)
+	})
+
+	t.Run("rejects a missing movie", func(t *testing.T) {
+		f := newFixture(t, factory)
+		track, err := domain.NewTrack("Concerning Hobbits", newID(t), nil, 2, 1, domain.TrackEditionTheatrical, 172)
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, f.repos.Tracks.Save(f.ctx(), track), domain.ErrMovieNotFound)
+
+		saved := f.track("Concerning Hobbits", f.movie("The Fellowship of the Ring"), 2)
+		moved, err := domain.NewTrackWithID(saved.ID().String(), "Concerning Hobbits", newID(t), nil, 2, 1, domain.TrackEditionTheatrical, 172)
+		require.NoError(t, err)
+		assert.ErrorIs(t, f.repos.Tracks.Update(f.ctx(), moved), domain.ErrMovieNotFound)
+	})
+
+	t.Run("rejects a movie in the trash", func(t *testing.T) {
+		f := newFixture(t, factory)
+		saved := f.track("Concerning Hobbits", f.movie("The Fellowship of the Ring"), 2)
+		trashed := f.movie("The Two Towers")
+		require.NoError(t, f.repos.Movies.Delete(f.ctx(), trashed.ID()))
+
+		track, err := domain.NewTrack("The Riders of Rohan", trashed.ID().String(), nil, 1, 1, domain.TrackEditionTheatrical, 245)
+		require.NoError(t, err)
+		assert.ErrorIs(t, f.repos.Tracks.Save(f.ctx(), track), domain.ErrMovieNotFound)
+
+		moved, err := domain.NewTrackWithID(saved.ID().String(), "Concerning Hobbits", trashed.ID().String(), nil, 2, 1, domain.TrackEditionTheatrical, 172)
+		require.NoError(t, err)
+		assert.ErrorIs(t, f.repos.Tracks.Update(f.ctx(), moved), domain.ErrMovieNotFound)
+	})
+
+	t.Run("rejects a duplicate album position", func(t *testing.T) {
+		f := newFixture(t, factory)
+		movie := f.movie("The Fellowship of the Ring")
+		f.track("Concerning Hobbits", movie, 2)
+		other := f.track("The Shire", movie, 3)
+
+		duplicate, err := domain.NewTrack("The Prophecy", movie.ID().String(), nil, 2, 1, domain.TrackEditionTheatrical, 172)
+		require.NoError(t, err)
+		assert.ErrorIs(t, f.repos.Tracks.Save(f.ctx(), duplicate), domain.ErrTrackPositionAlreadyExists)
+
+		moved, err := domain.NewTrackWithID(other.ID().String(), "The Shire", movie.ID().String(), nil, 2, 1, domain.TrackEditionTheatrical, 172)
+		require.NoError(t, err)
+		assert.ErrorIs(t, f.repos.Tracks.Update(f.ctx(), moved), domain.ErrTrackPositionAlreadyExists)
+	})
+
+	t.Run("frees the album position of a track in the trash", func(t *testing.T) {
+		f := newFixture(t, factory)
+		movie := f.movie("The Fellowship of the Ring")
+		trashed := f.track("Concerning Hobbits", movie, 2)
+		require.NoError(t, f.repos.Tracks.Delete(f.ctx(), trashed.ID()))
+
+		f.track("The Shire", movie, 2)
+
+		assert.ErrorIs(t, f.repos.Tracks.Restore(f.ctx(), trashed.ID()), domain.ErrTrackPositionAlreadyExists)
+		_, err := f.repos.Tracks.Find(f.ctx(), trashed.ID())
+		assert.ErrorIs(t, err, domain.ErrTrackNotFound)
+	})
+
+	t.Run("lists the tracks of a movie in album order", func(t *testing.T) {
+		f := newFixture(t, factory)
+		movie := f.movie("The Fellowship of the Ring")
+		complete, err := domain.NewTrack("The Prophecy", movie.ID().String(), nil, 1, 1, domain.TrackEditionCompleteRecordings, 200)
+		require.NoError(t, err)
+		require.NoError(t, f.repos.Tracks.Save(f.ctx(), complete))
+		complete.PullEvents()
+		second := f.track("Concerning Hobbits", movie, 2)
+		first := f.track("The Prophecy", movie, 1)
+		f.track("Foundations of Stone", f.movie("The Two Towers"), 1)
+
+		tracks, err := f.repos.Tracks.FindByMovie(f.ctx(), movie.ID())
+		require.NoError(t, err)
+		assert.Equal(t, []domain.Track{first, second, complete}, tracks)
+	})
+
+	t.Run("pages through every track", func(t *testing.T) {
+		f := newFixture(t, factory)
+		movie := f.movie("The Fellowship of the Ring")
+		var tracks []domain.Track
+		for i := range 5 {
+			tracks = append(tracks, f.track(name("Track", i), movie, i+1))
+		}
+
+		read := readAllPages(t, 2, func(page domain.PageRequest) ([]domain.Track, *domain.Cursor, error) {
+			return f.repos.Tracks.FindPage(f.ctx(), page)
+		})
+		assert.ElementsMatch(t, tracks, read)
+
+		all, err := f.repos.Tracks.FindAll(f.ctx())
+		require.NoError(t, err)
+		assert.ElementsMatch(t, tracks, all)
+	})
+
+	t.Run("moves a track to the trash and restores it", func(t *testing.T) {
+		f := newFixture(t, factory)
+		track := f.track("Concerning Hobbits", f.movie("The Fellowship of the Ring"), 2)
+
+		require.NoError(t, f.repos.Tracks.Delete(f.ctx(), track.ID()))
+
+		trashed, err := f.repos.Tracks.FindInTrash(f.ctx(), track.ID())
+		require.NoError(t, err)
+		assert.Equal(t, track, trashed)
+
+		_, err = f.repos.Tracks.Find(f.ctx(), track.ID())
+		assert.ErrorIs(t, err, domain.ErrTrackNotFound)
+		all, err := f.repos.Tracks.FindAll(f.ctx())
+		require.NoError(t, err)
+		assert.NotContains(t, all, track)
+		assert.ErrorIs(t, f.repos.Tracks.Delete(f.ctx(), track.ID()), domain.ErrTrackNotFound)
+		assert.ErrorIs(t, f.repos.Tracks.Update(f.ctx(), track), domain.ErrTrackNotFound)
+
+		require.NoError(t, f.repos.Tracks.Restore(f.ctx(), track.ID()))
+
+		_, err = f.repos.Tracks.FindInTrash(f.ctx(), track.ID())
+		assert.ErrorIs(t, err, domain.ErrTrackNotFound)
+
+		found, err := f.repos.Tracks.Find(f.ctx(), track.ID())
+		require.NoError(t, err)
+		assert.Equal(t, track, found)
+		assert.ErrorIs(t, f.repos.Tracks.Restore(f.ctx(), track.ID()), domain.ErrTrackNotFound)
+		assert.ErrorIs(t, f.repos.Tracks.Purge(f.ctx(), track.ID()), domain.ErrTrackNotFound)
+	})
+
+	t.Run("purges a track from the trash", func(t *testing.T) {
+		f := newFixture(t, factory)
+		track := f.track("Concerning Hobbits", f.movie("The Fellowship of the Ring"), 2)
+
+		require.NoError(t, f.repos.Tracks.Delete(f.ctx(), track.ID()))
+		require.NoError(t, f.repos.Tracks.Purge(f.ctx(), track.ID()))
+
+		assert.ErrorIs(t, f.repos.Tracks.Restore(f.ctx(), track.ID()), domain.ErrTrackNotFound)
+	})
+
+	t.Run("keeps the track themes of a track in the trash until it is purged", func(t *testing.T) {
+		f := newFixture(t, factory)
+		movie := f.movie("The Fellowship of the Ring")
+		firstHeard := f.track("The Prophecy", movie, 1)
+		track := f.track("Concerning Hobbits", movie, 2)
+		theme := f.theme("The Shire", f.group("Hobbits"), nil, firstHeard, 0)
+		kept := f.trackTheme(firstHeard, theme, 0, 30)
+		annotation := f.trackTheme(track, theme, 10, 40)
+
+		require.NoError(t, f.repos.Tracks.Delete(f.ctx(), track.ID()))
+
+		trackThemes, err := f.repos.TrackThemes.FindByTheme(f.ctx(), theme.ID())
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []domain.TrackTheme{kept, annotation}, trackThemes)
+
+		require.NoError(t, f.repos.Tracks.Purge(f.ctx(), track.ID()))
+
+		trackThemes, err = f.repos.TrackThemes.FindByTheme(f.ctx(), theme.ID())
+		require.NoError(t, err)
+		assert.Equal(t, []domain.TrackTheme{kept}, trackThemes)
+	})
+
+	t.Run("refuses to purge the track a theme is first heard in, even in the trash", func(t *testing.T) {
+		f := newFixture(t, factory)
+		track := f.track("Concerning Hobbits", f.movie("The Fellowship of the Ring"), 2)
+		theme := f.theme("The Shire", f.group("Hobbits"), nil, track, 0)
+		require.NoError(t, f.repos.Themes.Delete(f.ctx(), theme.ID()))
+		require.NoError(t, f.repos.Tracks.Delete(f.ctx(), track.ID()))
+
+		assert.ErrorIs(t, f.repos.Tracks.Purge(f.ctx(), track.ID()), domain.ErrInUse)
+	})
+}
@@ -0,0 +1,136 @@
+package storagetest
+
+import (
+	"testing"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTrackThemeRepository checks the contract of domain.TrackThemeRepository.
+func TestTrackThemeRepository(t *testing.T, factory Factory) {
+	t.Run("saves, finds and updates a track theme", func(t *testing.T) {
+		f := newFixture(t, factory)
+		track := f.track("Concerning Hobbits", f.movie("The Fellowship of the Ring"), 2)
+		theme := f.theme("The Shire", f.group("Hobbits"), nil, track, 0)
+		trackTheme := f.trackTheme(track, theme, 10, 40)
+
+		found, err := f.repos.TrackThemes.Find(f.ctx(), track.ID(), theme.ID(), trackTheme.StartSecond())
+		require.NoError(t, err)
+		assert.Equal(t, trackTheme, found)
+
+		updated, err := domain.NewTrackTheme(track.ID().String(), theme.ID().String(), 10, 55, true)
+		require.NoError(t, err)
+		require.NoError(t, f.repos.TrackThemes.Update(f.ctx(), updated))
+
+		found, err = f.repos.TrackThemes.Find(f.ctx(), track.ID(), theme.ID(), trackTheme.StartSecond())
+		require.NoError(t, err)
+		assert.Equal(t, updated, found)
+	})
+
+	t.Run("reports a missing track theme", func(t *testing.T) {
+		f := newFixture(t, factory)
+		track := f.track("Concerning Hobbits", f.movie("The Fellowship of the Ring"), 2)
+		theme := f.theme("The Shire", f.group("Hobbits"), nil, track, 0)
+		trackTheme, err := domain.NewTrackTheme(track.ID().String(), theme.ID().String(), 10, 40, false)
+		require.NoError(t, err)
+
+		_, err = f.repos.TrackThemes.Find(f.ctx(), track.ID(), theme.ID(), trackTheme.StartSecond())
+		assert.ErrorIs(t, err, domain.ErrTrackThemeNotFound)
+		assert.ErrorIs(t, f.repos.TrackThemes.Update(f.ctx(), trackTheme), domain.ErrTrackThemeNotFound)
+		assert.ErrorIs(t, f.repos.TrackThemes.Delete(f.ctx(), track.ID(), theme.ID(), trackTheme.StartSecond()), domain.ErrTrackThemeNotFound)
+	})
+
+	t.Run("maps foreign key violations", func(t *testing.T) {
+		f := newFixture(t, factory)
+		track := f.track("Concerning Hobbits", f.movie("The Fellowship of the Ring"), 2)
+		theme := f.theme("The Shire", f.group("Hobbits"), nil, track, 0)
+
+		missingTrack, err := domain.NewTrackTheme(newID(t), theme.ID().String(), 10, 40, false)
+		require.NoError(t, err)
+		assert.ErrorIs(t, f.repos.TrackThemes.Save(f.ctx(), missingTrack), domain.ErrTrackNotFound)
+
+		missingTheme, err := domain.NewTrackTheme(track.ID().String(), newID(t), 10, 40, false)
+		require.NoError(t, err)
+		assert.ErrorIs(t, f.repos.TrackThemes.Save(f.ctx(), missingTheme), domain.ErrThemeNotFound)
+	})
+
+	t.Run("rejects a track or a theme in the trash", func(t *testing.T) {
+		f := newFixture(t, factory)
+		movie := f.movie("The Fellowship of the Ring")
+		track := f.track("Concerning Hobbits", movie, 2)
+		group := f.group("Hobbits")
+		theme := f.theme("The Shire", group, nil, track, 0)
+
+		trashedTrack := f.track("The Shire", movie, 3)
+		require.NoError(t, f.repos.Tracks.Delete(f.ctx(), trashedTrack.ID()))
+		trashedTheme := f.theme("Hobbit Outline", group, nil, track, 0)
+		require.NoError(t, f.repos.Themes.Delete(f.ctx(), trashedTheme.ID()))
+
+		onTrashedTrack, err := domain.NewTrackTheme(trashedTrack.ID().String(), theme.ID().String(), 10, 40, false)
+		require.NoError(t, err)
+		assert.ErrorIs(t, f.repos.TrackThemes.Save(f.ctx(), onTrashedTrack), domain.ErrTrackNotFound)
+
+		ofTrashedTheme, err := domain.NewTrackTheme(track.ID().String(), trashedTheme.ID().String(), 10, 40, false)
+		require.NoError(t, err)
+		assert.ErrorIs(t, f.repos.TrackThemes.Save(f.ctx(), ofTrashedTheme), domain.ErrThemeNotFound)
+	})
+
+	t.Run("rejects a duplicate occurrence", func(t *testing.T) {
+		f := newFixture(t, factory)
+		track := f.track("Concerning Hobbits", f.movie("The Fellowship of the Ring"), 2)
+		theme := f.theme("The Shire", f.group("Hobbits"), nil, track, 0)
+		f.trackTheme(track, theme, 10, 40)
+
+		duplicate, err := domain.NewTrackTheme(track.ID().String(), theme.ID().String(), 10, 50, false)
+		require.NoError(t, err)
+		assert.ErrorIs(t, f.repos.TrackThemes.Save(f.ctx(), duplicate), domain.ErrTrackThemeOverlaps)
+	})
+
+	t.Run("lists the track themes of a track by start second", func(t *testing.T) {
+		f := newFixture(t, factory)
+		track := f.track("Concerning Hobbits", f.movie("The Fellowship of the Ring"), 2)
+		group := f.group("Hobbits")
+		shire := f.theme("The Shire", group, nil, track, 0)
+		understanding := f.theme("A Hobbit's Understanding", group, nil, track, 60)
+
+		late := f.trackTheme(track, shire, 120, 150)
+		early := f.trackTheme(track, shire, 0, 30)
+		middle := f.trackTheme(track, understanding, 60, 90)
+
+		trackThemes, err := f.repos.TrackThemes.FindByTrack(f.ctx(), track.ID())
+		require.NoError(t, err)
+		assert.Equal(t, []domain.TrackTheme{early, middle, late}, trackThemes)
+	})
+
+	t.Run("lists the track themes of a theme by movie and track", func(t *testing.T) {
+		f := newFixture(t, factory)
+		fellowship := f.movie("The Fellowship of the Ring")
+		king := f.movie("The Return of the King")
+		havens := f.track("The Grey Havens", king, 17)
+		prophecy := f.track("The Prophecy", fellowship, 1)
+		hobbits := f.track("Concerning Hobbits", fellowship, 2)
+		theme := f.theme("The Shire", f.group("Hobbits"), nil, hobbits, 0)
+
+		last := f.trackTheme(havens, theme, 100, 130)
+		second := f.trackTheme(hobbits, theme, 0, 30)
+		first := f.trackTheme(prophecy, theme, 200, 230)
+
+		trackThemes, err := f.repos.TrackThemes.FindByTheme(f.ctx(), theme.ID())
+		require.NoError(t, err)
+		assert.Equal(t, []domain.TrackTheme{first, second, last}, trackThemes)
+	})
+
+	t.Run("deletes a track theme", func(t *testing.T) {
+		f := newFixture(t, factory)
+		track := f.track("Concerning Hobbits", f.movie("The Fellowship of the Ring"), 2)
+		theme := f.theme("The Shire", f.group("Hobbits"), nil, track, 0)
+		trackTheme := f.trackTheme(track, theme, 10, 40)
+
+		require.NoError(t, f.repos.TrackThemes.Delete(f.ctx(), track.ID(), theme.ID(), trackTheme.StartSecond()))
+
+		_, err := f.repos.TrackThemes.Find(f.ctx(), track.ID(), theme.ID(), trackTheme.StartSecond())
+		assert.ErrorIs(t, err, domain.ErrTrackThemeNotFound)
+	})
+}
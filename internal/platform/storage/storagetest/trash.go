@@ -0,0 +1,82 @@
+package storagetest
+
+import (
+	"testing"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTrashRepository checks the contract of listing.TrashRepository.
+func TestTrashRepository(t *testing.T, factory Factory) {
+	t.Run("lists an empty trash", func(t *testing.T) {
+		f := newFixture(t, factory)
+		f.movie("The Fellowship of the Ring")
+
+		assert.Empty(t, f.readTrash(t))
+	})
+
+	t.Run("lists the entries in the trash until they are restored", func(t *testing.T) {
+		f := newFixture(t, factory)
+		movie := f.movie("The Fellowship of the Ring")
+		track := f.track("Concerning Hobbits", movie, 2)
+		group := f.group("Hobbits")
+		category := f.category("Places")
+		theme := f.theme("The Shire", group, &category, track, 0)
+		f.group("Rohan")
+
+		require.NoError(t, f.repos.Themes.Delete(f.ctx(), theme.ID()))
+		require.NoError(t, f.repos.Groups.Delete(f.ctx(), group.ID()))
+		require.NoError(t, f.repos.Categories.Delete(f.ctx(), category.ID()))
+		require.NoError(t, f.repos.Tracks.Delete(f.ctx(), track.ID()))
+		require.NoError(t, f.repos.Movies.Delete(f.ctx(), movie.ID()))
+
+		items := f.readTrash(t)
+		assert.ElementsMatch(t, []dto.TrashItem{
+			{Type: "movie", ID: movie.ID().String(), Name: "The Fellowship of the Ring"},
+			{Type: "group", ID: group.ID().String(), Name: "Hobbits"},
+			{Type: "category", ID: category.ID().String(), Name: "Places"},
+			{Type: "track", ID: track.ID().String(), Name: "Concerning Hobbits"},
+			{Type: "theme", ID: theme.ID().String(), Name: "The Shire"},
+		}, withoutDeletedAt(t, items))
+
+		require.NoError(t, f.repos.Groups.Restore(f.ctx(), group.ID()))
+		require.NoError(t, f.repos.Themes.Purge(f.ctx(), theme.ID()))
+
+		items = f.readTrash(t)
+		assert.ElementsMatch(t, []dto.TrashItem{
+			{Type: "movie", ID: movie.ID().String(), Name: "The Fellowship of the Ring"},
+			{Type: "category", ID: category.ID().String(), Name: "Places"},
+			{Type: "track", ID: track.ID().String(), Name: "Concerning Hobbits"},
+		}, withoutDeletedAt(t, items))
+	})
+}
+
+// readTrash pages through the trash one entry at a time and checks the entries come most
+// recently deleted first.
+func (f *fixture) readTrash(t *testing.T) []dto.TrashItem {
+	t.Helper()
+
+	items := readAllPages(t, 1, func(page domain.PageRequest) ([]dto.TrashItem, *domain.Cursor, error) {
+		return f.repos.Trash.FindPage(f.ctx(), page)
+	})
+	for i := 1; i < len(items); i++ {
+		assert.False(t, items[i].DeletedAt.After(items[i-1].DeletedAt), "%s %s is listed after an older entry", items[i].Type, items[i].Name)
+	}
+	return items
+}
+
+// withoutDeletedAt checks every item records when it was deleted and clears that time,
+// which the storage sets, so the items can be compared.
+func withoutDeletedAt(t *testing.T, items []dto.TrashItem) []dto.TrashItem {
+	t.Helper()
+
+	cleared := make([]dto.TrashItem, 0, len(items))
+	for _, item := range items {
+		assert.False(t, item.DeletedAt.IsZero(), "%s %s has no deletion time", item.Type, item.Name)
+		cleared = append(cleared, dto.TrashItem{Type: item.Type, ID: item.ID, Name: item.Name})
+	}
+	return cleared
+}
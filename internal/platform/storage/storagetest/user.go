@@ -0,0 +1,187 @@
+package storagetest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestUserRepository checks the contract of domain.UserRepository.
+func TestUserRepository(t *testing.T, factory Factory) {
+	newUser := func(t *testing.T, email string) domain.User {
+		user, err := domain.NewUser("Frodo Baggins", email, "hashed-password", domain.RoleViewer)
+		require.NoError(t, err)
+		return user
+	}
+
+	t.Run("saves and finds a user", func(t *testing.T) {
+		f := newFixture(t, factory)
+		user := newUser(t, "frodo@shire.me")
+		require.NoError(t, f.repos.Users.Save(f.ctx(), user))
+
+		found, err := f.repos.Users.Find(f.ctx(), user.ID())
+		require.NoError(t, err)
+		assert.Equal(t, user.Email(), found.Email())
+		assert.Equal(t, user.Password(), found.Password())
+		assert.Empty(t, found.PullEvents())
+
+		found, err = f.repos.Users.FindByEmail(f.ctx(), user.Email())
+		require.NoError(t, err)
+		assert.Equal(t, user.ID(), found.ID())
+	})
+
+	t.Run("keeps the role of a user", func(t *testing.T) {
+		f := newFixture(t, factory)
+		user, err := domain.NewUser("Gandalf", "gandalf@istari.me", "hashed-password", domain.RoleCurator)
+		require.NoError(t, err)
+		require.NoError(t, f.repos.Users.Save(f.ctx(), user))
+
+		found, err := f.repos.Users.Find(f.ctx(), user.ID())
+		require.NoError(t, err)
+		assert.Equal(t, domain.RoleCurator, found.Role().String())
+	})
+
+	t.Run("counts the users a permission is granted to", func(t *testing.T) {
+		f := newFixture(t, factory)
+		for i, role := range []string{domain.RoleAdmin, domain.RoleAdmin, domain.RoleCurator, domain.RoleViewer} {
+			user, err := domain.NewUser("Gandalf", fmt.Sprintf("gandalf-%d@istari.me", i), "hashed-password", role)
+			require.NoError(t, err)
+			require.NoError(t, f.repos.Users.Save(f.ctx(), user))
+		}
+
+		count, err := f.repos.Users.CountByPermission(f.ctx(), domain.PermissionManageUsers)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		count, err = f.repos.Users.CountByPermission(f.ctx(), domain.PermissionCreateThemes)
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+	})
+
+	t.Run("keeps the verification of a user", func(t *testing.T) {
+		f := newFixture(t, factory)
+		user := newUser(t, "frodo@shire.me")
+		require.NoError(t, f.repos.Users.Save(f.ctx(), user))
+
+		found, err := f.repos.Users.Find(f.ctx(), user.ID())
+		require.NoError(t, err)
+		assert.False(t, found.Verified())
+
+		require.NoError(t, f.repos.Users.Update(f.ctx(), found.Verify()))
+		found, err = f.repos.Users.Find(f.ctx(), user.ID())
+		require.NoError(t, err)
+		assert.True(t, found.Verified())
+	})
+
+	t.Run("rejects a user with an unknown role", func(t *testing.T) {
+		f := newFixture(t, factory)
+		user, err := domain.NewUser("Saruman", "saruman@istari.me", "hashed-password", "wizard")
+		require.NoError(t, err)
+
+		err = f.repos.Users.Save(f.ctx(), user)
+		assert.ErrorIs(t, err, domain.ErrRoleNotFound)
+	})
+
+	t.Run("rejects a duplicate email", func(t *testing.T) {
+		f := newFixture(t, factory)
+		require.NoError(t, f.repos.Users.Save(f.ctx(), newUser(t, "frodo@shire.me")))
+
+		err := f.repos.Users.Save(f.ctx(), newUser(t, "frodo@shire.me"))
+		assert.ErrorIs(t, err, domain.ErrUserAlreadyExists)
+	})
+
+	t.Run("reports a missing user", func(t *testing.T) {
+		f := newFixture(t, factory)
+		user := newUser(t, "frodo@shire.me")
+
+		_, err := f.repos.Users.Find(f.ctx(), user.ID())
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+
+		_, err = f.repos.Users.FindByEmail(f.ctx(), user.Email())
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+
+	t.Run("updates a user", func(t *testing.T) {
+		f := newFixture(t, factory)
+		user := newUser(t, "frodo@shire.me")
+		require.NoError(t, f.repos.Users.Save(f.ctx(), user))
+
+		updated, err := user.WithProfile("Frodo of the Nine Fingers", "frodo@grey-havens.me")
+		require.NoError(t, err)
+		updated, err = updated.WithPassword("new-hashed-password")
+		require.NoError(t, err)
+		updated, err = updated.WithRole(domain.RoleEditor)
+		require.NoError(t, err)
+		require.NoError(t, f.repos.Users.Update(f.ctx(), updated))
+
+		found, err := f.repos.Users.Find(f.ctx(), user.ID())
+		require.NoError(t, err)
+		assert.Equal(t, "Frodo of the Nine Fingers", found.Name().String())
+		assert.Equal(t, "frodo@grey-havens.me", found.Email().String())
+		assert.Equal(t, "new-hashed-password", found.Password().String())
+		assert.Equal(t, domain.RoleEditor, found.Role().String())
+	})
+
+	t.Run("rejects an update to a missing user, a taken email or an unknown role", func(t *testing.T) {
+		f := newFixture(t, factory)
+		err := f.repos.Users.Update(f.ctx(), newUser(t, "frodo@shire.me"))
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+
+		user := newUser(t, "frodo@shire.me")
+		require.NoError(t, f.repos.Users.Save(f.ctx(), user))
+		require.NoError(t, f.repos.Users.Save(f.ctx(), newUser(t, "sam@shire.me")))
+
+		taken, err := user.WithProfile("Frodo Baggins", "sam@shire.me")
+		require.NoError(t, err)
+		err = f.repos.Users.Update(f.ctx(), taken)
+		assert.ErrorIs(t, err, domain.ErrUserAlreadyExists)
+
+		unknown, err := user.WithRole("wizard")
+		require.NoError(t, err)
+		err = f.repos.Users.Update(f.ctx(), unknown)
+		assert.ErrorIs(t, err, domain.ErrRoleNotFound)
+	})
+
+	t.Run("deletes a user along with their sessions", func(t *testing.T) {
+		f := newFixture(t, factory)
+		session := f.session(tokenHash("frodo"), time.Now().Add(time.Hour))
+
+		require.NoError(t, f.repos.Users.Delete(f.ctx(), session.UserID()))
+
+		_, err := f.repos.Users.Find(f.ctx(), session.UserID())
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		_, err = f.repos.Sessions.FindByTokenHash(f.ctx(), tokenHash("frodo"))
+		assert.ErrorIs(t, err, domain.ErrSessionNotFound)
+
+		err = f.repos.Users.Delete(f.ctx(), session.UserID())
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+
+	t.Run("pages through every user", func(t *testing.T) {
+		f := newFixture(t, factory)
+		var ids []domain.UserID
+		for _, email := range []string{"frodo@shire.me", "sam@shire.me", "merry@shire.me", "pippin@shire.me", "bilbo@shire.me"} {
+			user := newUser(t, email)
+			require.NoError(t, f.repos.Users.Save(f.ctx(), user))
+			ids = append(ids, user.ID())
+		}
+
+		users := readAllPages(t, 2, func(page domain.PageRequest) ([]domain.User, *domain.Cursor, error) {
+			return f.repos.Users.FindPage(f.ctx(), page)
+		})
+
+		var read []domain.UserID
+		for _, user := range users {
+			read = append(read, user.ID())
+		}
+		assert.ElementsMatch(t, ids, read)
+
+		all, err := f.repos.Users.FindAll(f.ctx())
+		require.NoError(t, err)
+		assert.Len(t, all, len(ids))
+	})
+}
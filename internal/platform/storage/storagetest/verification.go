@@ -0,0 +1,82 @@
+package storagetest
+
+import (
+	"testing"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestVerificationTokenRepository checks the contract of domain.VerificationTokenRepository.
+func TestVerificationTokenRepository(t *testing.T, factory Factory) {
+	expiresAt := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("saves, finds and deletes a token", func(t *testing.T) {
+		f := newFixture(t, factory)
+		token := f.verificationToken(tokenHash("verify"), expiresAt)
+
+		found, err := f.repos.VerificationTokens.FindByTokenHash(f.ctx(), tokenHash("verify"))
+		require.NoError(t, err)
+		assert.Equal(t, token, found)
+
+		require.NoError(t, f.repos.VerificationTokens.Delete(f.ctx(), tokenHash("verify")))
+		require.NoError(t, f.repos.VerificationTokens.Delete(f.ctx(), tokenHash("verify")))
+
+		_, err = f.repos.VerificationTokens.FindByTokenHash(f.ctx(), tokenHash("verify"))
+		assert.ErrorIs(t, err, domain.ErrInvalidVerificationToken)
+	})
+
+	t.Run("tells whether a user has a token valid at a time", func(t *testing.T) {
+		f := newFixture(t, factory)
+		token := f.verificationToken(tokenHash("verify"), expiresAt)
+
+		valid, err := f.repos.VerificationTokens.ValidAt(f.ctx(), token.UserID(), expiresAt.Add(-time.Second))
+		require.NoError(t, err)
+		assert.True(t, valid)
+
+		valid, err = f.repos.VerificationTokens.ValidAt(f.ctx(), token.UserID(), expiresAt)
+		require.NoError(t, err)
+		assert.False(t, valid)
+
+		other := f.verificationToken(tokenHash("other"), expiresAt.Add(time.Hour))
+		valid, err = f.repos.VerificationTokens.ValidAt(f.ctx(), other.UserID(), expiresAt)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("rejects a token of a missing user", func(t *testing.T) {
+		f := newFixture(t, factory)
+		token, err := domain.NewVerificationToken(tokenHash("verify"), newID(t), expiresAt)
+		require.NoError(t, err)
+
+		err = f.repos.VerificationTokens.Save(f.ctx(), token)
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+
+	t.Run("deletes the tokens of a deleted user", func(t *testing.T) {
+		f := newFixture(t, factory)
+		token := f.verificationToken(tokenHash("verify"), expiresAt)
+
+		require.NoError(t, f.repos.Users.Delete(f.ctx(), token.UserID()))
+
+		_, err := f.repos.VerificationTokens.FindByTokenHash(f.ctx(), tokenHash("verify"))
+		assert.ErrorIs(t, err, domain.ErrInvalidVerificationToken)
+	})
+}
+
+// verificationToken saves a verification token of a new user.
+func (f *fixture) verificationToken(tokenHash string, expiresAt time.Time) domain.VerificationToken {
+	f.t.Helper()
+
+	user, err := domain.NewUser("Frodo Baggins", newID(f.t)+"@shire.me", "hashed-password", domain.RoleViewer)
+	require.NoError(f.t, err)
+	require.NoError(f.t, f.repos.Users.Save(f.ctx(), user))
+
+	token, err := domain.NewVerificationToken(tokenHash, user.ID().String(), expiresAt)
+	require.NoError(f.t, err)
+	require.NoError(f.t, f.repos.VerificationTokens.Save(f.ctx(), token))
+
+	return token
+}
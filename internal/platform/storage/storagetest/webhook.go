@@ -0,0 +1,272 @@
+package storagetest
+
+import (
+	"testing"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWebhookRepository checks the contract of domain.WebhookRepository.
+func TestWebhookRepository(t *testing.T, factory Factory) {
+	t.Run("finds a saved webhook", func(t *testing.T) {
+		f := newFixture(t, factory)
+		webhook := f.webhook(domain.MovieCreatedEventType, domain.MovieDeletedEventType)
+
+		found, err := f.repos.Webhooks.Find(f.ctx(), webhook.ID())
+		require.NoError(t, err)
+		assert.Equal(t, webhook, found)
+	})
+
+	t.Run("reports a missing webhook", func(t *testing.T) {
+		f := newFixture(t, factory)
+		id, err := domain.NewWebhookIDFromString(newID(t))
+		require.NoError(t, err)
+
+		_, err = f.repos.Webhooks.Find(f.ctx(), id)
+		assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+	})
+
+	t.Run("lists webhooks in the order they were saved", func(t *testing.T) {
+		f := newFixture(t, factory)
+		first := f.webhook(domain.MovieCreatedEventType)
+		second := f.webhook(domain.ThemeUpdatedEventType)
+
+		webhooks, err := f.repos.Webhooks.FindAll(f.ctx())
+		require.NoError(t, err)
+		assert.Equal(t, []domain.Webhook{first, second}, webhooks)
+	})
+
+	t.Run("finds the webhooks subscribed to an event type", func(t *testing.T) {
+		f := newFixture(t, factory)
+		movies := f.webhook(domain.MovieCreatedEventType, domain.MovieUpdatedEventType)
+		f.webhook(domain.ThemeUpdatedEventType)
+		everything := f.webhook(domain.WebhookEventTypes()...)
+
+		webhooks, err := f.repos.Webhooks.FindByEventType(f.ctx(), domain.MovieUpdatedEventType)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.Webhook{movies, everything}, webhooks)
+
+		webhooks, err = f.repos.Webhooks.FindByEventType(f.ctx(), domain.UserCreatedEventType)
+		require.NoError(t, err)
+		assert.Empty(t, webhooks)
+	})
+
+	t.Run("updates a webhook", func(t *testing.T) {
+		f := newFixture(t, factory)
+		webhook := f.webhook(domain.MovieCreatedEventType)
+		f.webhook(domain.ThemeUpdatedEventType)
+
+		updated, err := domain.NewWebhookWithID(webhook.ID().String(), "https://hooks.example.com/rotated", "a-rotated-signing-key", []string{string(domain.ThemeUpdatedEventType)})
+		require.NoError(t, err)
+		require.NoError(t, f.repos.Webhooks.Update(f.ctx(), updated))
+
+		found, err := f.repos.Webhooks.Find(f.ctx(), webhook.ID())
+		require.NoError(t, err)
+		assert.Equal(t, updated, found)
+
+		// It keeps its place in the list
+		webhooks, err := f.repos.Webhooks.FindAll(f.ctx())
+		require.NoError(t, err)
+		require.Len(t, webhooks, 2)
+		assert.Equal(t, updated, webhooks[0])
+	})
+
+	t.Run("deletes a webhook with its deliveries", func(t *testing.T) {
+		f := newFixture(t, factory)
+		webhook := f.webhook(domain.MovieCreatedEventType)
+		f.delivery(webhook, domain.NewMovieCreatedEvent(newID(t), "The Two Towers"), 1, 204, "", time.Now())
+
+		require.NoError(t, f.repos.Webhooks.Delete(f.ctx(), webhook.ID()))
+
+		_, err := f.repos.Webhooks.Find(f.ctx(), webhook.ID())
+		assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+		assert.Empty(t, f.readDeliveries(t, webhook))
+	})
+
+	t.Run("reports a missing webhook on update and delete", func(t *testing.T) {
+		f := newFixture(t, factory)
+		webhook, err := domain.NewWebhook("https://hooks.example.com/missing", "a-very-secret-signing-key", []string{string(domain.MovieCreatedEventType)})
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, f.repos.Webhooks.Update(f.ctx(), webhook), domain.ErrWebhookNotFound)
+		assert.ErrorIs(t, f.repos.Webhooks.Delete(f.ctx(), webhook.ID()), domain.ErrWebhookNotFound)
+	})
+}
+
+// TestWebhookDeliveryRepository checks the contract of domain.WebhookDeliveryRepository.
+func TestWebhookDeliveryRepository(t *testing.T, factory Factory) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	lease := time.Minute
+
+	t.Run("claims the due attempts soonest first, up to the limit", func(t *testing.T) {
+		f := newFixture(t, factory)
+		webhook := f.webhook(domain.MovieCreatedEventType)
+		later := f.schedule(webhook, domain.NewMovieCreatedEvent(newID(t), "The Two Towers"), 1, now.Add(-time.Second))
+		sooner := f.schedule(webhook, domain.NewMovieCreatedEvent(newID(t), "The Fellowship of the Ring"), 1, now.Add(-time.Minute))
+		f.schedule(webhook, domain.NewMovieCreatedEvent(newID(t), "The Return of the King"), 1, now.Add(time.Second))
+
+		claimed, err := f.repos.Deliveries.Claim(f.ctx(), now, lease, 1)
+		require.NoError(t, err)
+		assertSamePendingDeliveries(t, []domain.PendingWebhookDelivery{sooner}, claimed)
+
+		claimed, err = f.repos.Deliveries.Claim(f.ctx(), now, lease, 10)
+		require.NoError(t, err)
+		assertSamePendingDeliveries(t, []domain.PendingWebhookDelivery{later}, claimed)
+	})
+
+	t.Run("keeps the event of an attempt", func(t *testing.T) {
+		f := newFixture(t, factory)
+		webhook := f.webhook(domain.TrackUpdatedEventType)
+		movieID, trackID := newID(t), newID(t)
+		f.schedule(webhook, domain.NewTrackUpdatedEvent(trackID, "The Bridge of Khazad-dûm", movieID), 2, now)
+
+		claimed, err := f.repos.Deliveries.Claim(f.ctx(), now, lease, 10)
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+		assert.Equal(t, webhook.ID(), claimed[0].WebhookID())
+		assert.Equal(t, 2, claimed[0].Attempt())
+
+		track, ok := claimed[0].Event().(domain.TrackUpdatedEvent)
+		require.True(t, ok, "claimed a %T", claimed[0].Event())
+		assert.Equal(t, trackID, track.TrackID())
+		assert.Equal(t, "The Bridge of Khazad-dûm", track.Name())
+		assert.Equal(t, movieID, track.MovieID())
+	})
+
+	t.Run("ignores an attempt already scheduled", func(t *testing.T) {
+		f := newFixture(t, factory)
+		webhook := f.webhook(domain.MovieCreatedEventType)
+		e := domain.NewMovieCreatedEvent(newID(t), "The Two Towers")
+		scheduled := f.schedule(webhook, e, 1, now)
+		f.schedule(webhook, e, 1, now)
+
+		claimed, err := f.repos.Deliveries.Claim(f.ctx(), now, lease, 10)
+		require.NoError(t, err)
+		assertSamePendingDeliveries(t, []domain.PendingWebhookDelivery{scheduled}, claimed)
+	})
+
+	t.Run("claims an attempt again once its lease runs out", func(t *testing.T) {
+		f := newFixture(t, factory)
+		webhook := f.webhook(domain.MovieCreatedEventType)
+		scheduled := f.schedule(webhook, domain.NewMovieCreatedEvent(newID(t), "The Two Towers"), 1, now)
+
+		_, err := f.repos.Deliveries.Claim(f.ctx(), now, lease, 10)
+		require.NoError(t, err)
+
+		claimed, err := f.repos.Deliveries.Claim(f.ctx(), now.Add(lease/2), lease, 10)
+		require.NoError(t, err)
+		assert.Empty(t, claimed)
+
+		claimed, err = f.repos.Deliveries.Claim(f.ctx(), now.Add(2*lease), lease, 10)
+		require.NoError(t, err)
+		assertSamePendingDeliveries(t, []domain.PendingWebhookDelivery{scheduled}, claimed)
+	})
+
+	t.Run("lists the attempts made at delivering to a webhook newest first", func(t *testing.T) {
+		f := newFixture(t, factory)
+		webhook := f.webhook(domain.MovieCreatedEventType)
+		other := f.webhook(domain.MovieCreatedEventType)
+		e := domain.NewMovieCreatedEvent(newID(t), "The Two Towers")
+
+		refused := f.delivery(webhook, e, 1, 0, "connection refused", now)
+		failed := f.delivery(webhook, e, 2, 503, "unexpected status 503", now.Add(time.Second))
+		succeeded := f.delivery(webhook, e, 3, 204, "", now.Add(2*time.Second))
+		f.delivery(other, e, 1, 200, "", now)
+		f.schedule(webhook, domain.NewMovieCreatedEvent(newID(t), "The Return of the King"), 1, now)
+
+		assert.Equal(t, []domain.WebhookDelivery{succeeded, failed, refused}, f.readDeliveries(t, webhook))
+	})
+
+	t.Run("does not claim the attempts made", func(t *testing.T) {
+		f := newFixture(t, factory)
+		webhook := f.webhook(domain.MovieCreatedEventType)
+		f.delivery(webhook, domain.NewMovieCreatedEvent(newID(t), "The Two Towers"), 1, 503, "unexpected status 503", now)
+
+		claimed, err := f.repos.Deliveries.Claim(f.ctx(), now.Add(2*lease), lease, 10)
+		require.NoError(t, err)
+		assert.Empty(t, claimed)
+	})
+
+	t.Run("rejects an attempt for a missing webhook", func(t *testing.T) {
+		f := newFixture(t, factory)
+		id, err := domain.NewWebhookIDFromString(newID(t))
+		require.NoError(t, err)
+		delivery, err := domain.NewPendingWebhookDelivery(id, domain.NewMovieCreatedEvent(newID(t), "The Two Towers"), 1, now)
+		require.NoError(t, err)
+
+		err = f.repos.Deliveries.Schedule(f.ctx(), delivery)
+		assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+
+		made, err := delivery.Made(0, "connection refused", now)
+		require.NoError(t, err)
+		err = f.repos.Deliveries.Record(f.ctx(), made)
+		assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+	})
+}
+
+// webhook saves a webhook subscribed to the event types.
+func (f *fixture) webhook(eventTypes ...event.Type) domain.Webhook {
+	f.t.Helper()
+
+	var types []string
+	for _, eventType := range eventTypes {
+		types = append(types, string(eventType))
+	}
+
+	webhook, err := domain.NewWebhook("https://hooks.example.com/"+newID(f.t), "a-very-secret-signing-key", types)
+	require.NoError(f.t, err)
+	require.NoError(f.t, f.repos.Webhooks.Save(f.ctx(), webhook))
+
+	return webhook
+}
+
+// schedule schedules an attempt at posting the event to the webhook.
+func (f *fixture) schedule(webhook domain.Webhook, e event.Event, attempt int, dueAt time.Time) domain.PendingWebhookDelivery {
+	f.t.Helper()
+
+	delivery, err := domain.NewPendingWebhookDelivery(webhook.ID(), e, attempt, dueAt)
+	require.NoError(f.t, err)
+	require.NoError(f.t, f.repos.Deliveries.Schedule(f.ctx(), delivery))
+
+	return delivery
+}
+
+// delivery schedules an attempt at posting the event to the webhook and records it as
+// made at deliveredAt.
+func (f *fixture) delivery(webhook domain.Webhook, e event.Event, attempt, statusCode int, err string, deliveredAt time.Time) domain.WebhookDelivery {
+	f.t.Helper()
+
+	delivery, deliveryErr := f.schedule(webhook, e, attempt, deliveredAt).Made(statusCode, err, deliveredAt)
+	require.NoError(f.t, deliveryErr)
+	require.NoError(f.t, f.repos.Deliveries.Record(f.ctx(), delivery))
+
+	return delivery
+}
+
+// readDeliveries reads the attempts made at delivering to the webhook a page at a time.
+func (f *fixture) readDeliveries(t *testing.T, webhook domain.Webhook) []domain.WebhookDelivery {
+	t.Helper()
+
+	return readAllPages(t, 1, func(page domain.PageRequest) ([]domain.WebhookDelivery, *domain.Cursor, error) {
+		return f.repos.Deliveries.FindPage(f.ctx(), webhook.ID(), page)
+	})
+}
+
+// assertSamePendingDeliveries compares the attempts by their ID and event, as the events
+// claimed are decoded anew.
+func assertSamePendingDeliveries(t *testing.T, expected, actual []domain.PendingWebhookDelivery) {
+	t.Helper()
+
+	require.Len(t, actual, len(expected))
+	for i, delivery := range expected {
+		assert.Equal(t, delivery.ID(), actual[i].ID())
+		assert.Equal(t, delivery.WebhookID(), actual[i].WebhookID())
+		assert.Equal(t, delivery.Attempt(), actual[i].Attempt())
+		assert.WithinDuration(t, delivery.DueAt(), actual[i].DueAt(), time.Microsecond)
+		assertSameEvents(t, []event.Event{delivery.Event()}, []event.Event{actual[i].Event()})
+	}
+}
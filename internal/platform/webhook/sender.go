@@ -0,0 +1,134 @@
+// Package webhook posts domain events to the webhooks subscribed to them over HTTP.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+)
+
+// Headers sent along with every payload. The signature is the hex encoded HMAC-SHA256
+// of the body, keyed with the secret of the webhook and prefixed with "sha256=".
+const (
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery" // ID of the event, the same for every attempt
+	SignatureHeader = "X-Webhook-Signature-256"
+)
+
+// ErrForbiddenAddress is returned when a webhook resolves to an address that is not
+// public, see domain.PublicAddress.
+var ErrForbiddenAddress = errors.New("webhook address is not public")
+
+// payload is the JSON body posted to the webhooks.
+type payload struct {
+	ID          string          `json:"id"`
+	Type        string          `json:"type"`
+	AggregateID string          `json:"aggregate_id"`
+	OccurredOn  time.Time       `json:"occurred_on"`
+	Data        json.RawMessage `json:"data"`
+}
+
+// HTTPSender implements notifying.Sender by posting signed JSON payloads.
+type HTTPSender struct {
+	client *http.Client
+	codec  event.Codec
+}
+
+// NewHTTPSender creates a new HTTPSender whose requests give up after timeout. It only
+// connects to public addresses, checked once the host name is resolved so that a name
+// cannot be pointed at an internal service after the webhook was saved, and does not
+// follow redirects: a redirect is answered like any other status but 2xx.
+func NewHTTPSender(timeout time.Duration, codec event.Codec) *HTTPSender {
+	return newHTTPSender(timeout, codec, domain.PublicAddress)
+}
+
+// newHTTPSender creates a new HTTPSender that only connects to the addresses allowed.
+func newHTTPSender(timeout time.Duration, codec event.Codec, allowed func(netip.Addr) bool) *HTTPSender {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil || !allowed(addrPort.Addr()) {
+				return fmt.Errorf("%w: %s", ErrForbiddenAddress, address)
+			}
+			return nil
+		},
+	}
+
+	// No proxy: it would be dialled instead of the webhook, past the check
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &HTTPSender{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		codec: codec,
+	}
+}
+
+// Sign returns the signature of body for the given secret, as sent in SignatureHeader.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Send posts the event to the webhook. Any status other than 2xx is an error.
+func (s *HTTPSender) Send(ctx context.Context, webhook domain.Webhook, e event.Event) (int, error) {
+	data, err := s.codec.Encode(e)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode event: %v", err)
+	}
+	body, err := json.Marshal(payload{
+		ID:          e.ID(),
+		Type:        string(e.Type()),
+		AggregateID: e.AggregateID(),
+		OccurredOn:  e.OccurredOn().UTC(),
+		Data:        data,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode payload: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL().String(), bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(e.Type()))
+	req.Header.Set(DeliveryHeader, e.ID())
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret().String(), body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Drain the body, so that the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,208 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/notifying"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/storage/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const webhookSecret = "a-very-secret-signing-key"
+
+// received is a request as seen by the test receiver.
+type received struct {
+	header http.Header
+	body   []byte
+}
+
+// newReceiver starts a receiver that answers with the given statuses in turn, the last one
+// for every further request, and sends what it receives on the returned channel.
+func newReceiver(t *testing.T, statuses ...int) (*httptest.Server, <-chan received) {
+	t.Helper()
+
+	requests := make(chan received, 10)
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		requests <- received{header: r.Header, body: body}
+
+		i := min(int(calls.Add(1))-1, len(statuses)-1)
+		w.WriteHeader(statuses[i])
+	}))
+	t.Cleanup(server.Close)
+
+	return server, requests
+}
+
+// receiverHost is the host name the webhooks post to in the tests: the receivers listen
+// on 127.0.0.1, which a webhook cannot be created with.
+const receiverHost = "receiver.example.com"
+
+// newTestSender creates an HTTPSender that connects to the receiver whatever the host of
+// the webhook, as long as the address of the receiver is allowed.
+func newTestSender(server *httptest.Server, allowed func(netip.Addr) bool) *HTTPSender {
+	sender := newHTTPSender(time.Second, domain.EventCodec{}, allowed)
+	transport := sender.client.Transport.(*http.Transport)
+	dial := transport.DialContext
+	transport.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+		return dial(ctx, network, server.Listener.Addr().String())
+	}
+	return sender
+}
+
+// allowAll lets the senders reach the receivers on the loopback address.
+func allowAll(netip.Addr) bool {
+	return true
+}
+
+func newWebhook(t *testing.T, url string) domain.Webhook {
+	t.Helper()
+
+	url = strings.Replace(url, "127.0.0.1", receiverHost, 1)
+	webhook, err := domain.NewWebhook(url, webhookSecret, []string{string(domain.MovieCreatedEventType)})
+	require.NoError(t, err)
+	return webhook
+}
+
+func TestHTTPSenderSendSignsPayload(t *testing.T) {
+	server, requests := newReceiver(t, http.StatusNoContent)
+	webhook := newWebhook(t, server.URL)
+	e := domain.NewMovieCreatedEvent("6a4f86e4-4fef-4151-9c60-e467007dd213", "The Two Towers")
+
+	sender := newTestSender(server, allowAll)
+
+	statusCode, err := sender.Send(context.Background(), webhook, e)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, statusCode)
+
+	req := <-requests
+	assert.Equal(t, "application/json", req.header.Get("Content-Type"))
+	assert.Equal(t, string(domain.MovieCreatedEventType), req.header.Get(EventHeader))
+	assert.Equal(t, e.ID(), req.header.Get(DeliveryHeader))
+	assert.Equal(t, Sign(webhookSecret, req.body), req.header.Get(SignatureHeader))
+	assert.NotEqual(t, Sign("another-secret-signing-key", req.body), req.header.Get(SignatureHeader))
+
+	var body struct {
+		ID          string    `json:"id"`
+		Type        string    `json:"type"`
+		AggregateID string    `json:"aggregate_id"`
+		OccurredOn  time.Time `json:"occurred_on"`
+		Data        struct {
+			Name string `json:"name"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(req.body, &body))
+	assert.Equal(t, e.ID(), body.ID)
+	assert.Equal(t, string(domain.MovieCreatedEventType), body.Type)
+	assert.Equal(t, "6a4f86e4-4fef-4151-9c60-e467007dd213", body.AggregateID)
+	assert.True(t, e.OccurredOn().Equal(body.OccurredOn))
+	assert.Equal(t, "The Two Towers", body.Data.Name)
+}
+
+func TestHTTPSenderSendRejectedStatus(t *testing.T) {
+	server, _ := newReceiver(t, http.StatusServiceUnavailable)
+	webhook := newWebhook(t, server.URL)
+
+	sender := newTestSender(server, allowAll)
+
+	statusCode, err := sender.Send(context.Background(), webhook, domain.NewMovieCreatedEvent("6a4f86e4-4fef-4151-9c60-e467007dd213", "The Two Towers"))
+	assert.EqualError(t, err, "unexpected status 503")
+	assert.Equal(t, http.StatusServiceUnavailable, statusCode)
+}
+
+func TestHTTPSenderSendUnreachable(t *testing.T) {
+	server, _ := newReceiver(t, http.StatusOK)
+	webhook := newWebhook(t, server.URL)
+	server.Close()
+
+	sender := newTestSender(server, allowAll)
+
+	statusCode, err := sender.Send(context.Background(), webhook, domain.NewMovieCreatedEvent("6a4f86e4-4fef-4151-9c60-e467007dd213", "The Two Towers"))
+	assert.Error(t, err)
+	assert.Zero(t, statusCode)
+}
+
+func TestHTTPSenderSendForbiddenAddress(t *testing.T) {
+	server, requests := newReceiver(t, http.StatusOK)
+	webhook := newWebhook(t, server.URL)
+
+	// The host of the webhook resolves to the loopback address of the receiver
+	sender := newTestSender(server, domain.PublicAddress)
+
+	statusCode, err := sender.Send(context.Background(), webhook, domain.NewMovieCreatedEvent("6a4f86e4-4fef-4151-9c60-e467007dd213", "The Two Towers"))
+	assert.ErrorIs(t, err, ErrForbiddenAddress)
+	assert.Zero(t, statusCode)
+	assert.Empty(t, requests)
+}
+
+func TestHTTPSenderSendDoesNotFollowRedirects(t *testing.T) {
+	var followed atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/metadata" {
+			followed.Store(true)
+			return
+		}
+		http.Redirect(w, r, "/metadata", http.StatusTemporaryRedirect)
+	}))
+	t.Cleanup(server.Close)
+	webhook := newWebhook(t, server.URL)
+
+	sender := newTestSender(server, allowAll)
+
+	statusCode, err := sender.Send(context.Background(), webhook, domain.NewMovieCreatedEvent("6a4f86e4-4fef-4151-9c60-e467007dd213", "The Two Towers"))
+	assert.EqualError(t, err, "unexpected status 307")
+	assert.Equal(t, http.StatusTemporaryRedirect, statusCode)
+	assert.False(t, followed.Load())
+}
+
+func TestNotifyRetriesAgainstReceiver(t *testing.T) {
+	server, requests := newReceiver(t, http.StatusInternalServerError, http.StatusBadGateway, http.StatusOK)
+	store := inmemory.NewStore()
+	webhooks := inmemory.NewWebhookRepository(store)
+	deliveries := inmemory.NewWebhookDeliveryRepository(store)
+	webhook := newWebhook(t, server.URL)
+	require.NoError(t, webhooks.Save(context.Background(), webhook))
+	e := domain.NewMovieCreatedEvent("6a4f86e4-4fef-4151-9c60-e467007dd213", "The Two Towers")
+
+	service := notifying.NewWebhookService(webhooks, deliveries, inmemory.NewTxManager(store), newTestSender(server, allowAll), 1, time.Millisecond, time.Minute, 5, time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go service.Run(ctx)
+
+	require.NoError(t, service.Notify(context.Background(), e))
+
+	page, err := domain.NewPageRequest(0, "")
+	require.NoError(t, err)
+	var log []domain.WebhookDelivery
+	require.Eventually(t, func() bool {
+		var err error
+		log, _, err = deliveries.FindPage(context.Background(), webhook.ID(), page)
+		return err == nil && len(log) == 3
+	}, time.Second, 5*time.Millisecond)
+	assert.Len(t, requests, 3)
+
+	// Handling the event again does not post it to the webhook that accepted it
+	require.NoError(t, service.Notify(context.Background(), e))
+	assert.Len(t, requests, 3)
+
+	assert.Equal(t, 3, log[0].Attempt())
+	assert.Equal(t, http.StatusOK, log[0].StatusCode())
+	assert.True(t, log[0].Succeeded())
+	assert.Equal(t, http.StatusBadGateway, log[1].StatusCode())
+	assert.Equal(t, "unexpected status 502", log[1].Err())
+	assert.Equal(t, http.StatusInternalServerError, log[2].StatusCode())
+}
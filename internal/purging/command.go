@@ -0,0 +1,222 @@
+package purging
+
+import (
+	"context"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/auditing"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/command"
+)
+
+const (
+	MovieCommandType    = "command.purge.movie"
+	GroupCommandType    = "command.purge.group"
+	CategoryCommandType = "command.purge.category"
+	TrackCommandType    = "command.purge.track"
+	ThemeCommandType    = "command.purge.theme"
+)
+
+type MovieCommand struct {
+	ID string
+}
+
+func NewMovieCommand(id string) MovieCommand {
+	return MovieCommand{
+		ID: id,
+	}
+}
+
+func (c MovieCommand) Type() command.Type {
+	return MovieCommandType
+}
+
+func (c MovieCommand) AuditTarget() auditing.Target {
+	return auditing.Target{Entity: domain.AuditEntityMovie, ID: c.ID}
+}
+
+type MovieCommandHandler struct {
+	service MovieService
+}
+
+func NewMovieCommandHandler(service MovieService) MovieCommandHandler {
+	return MovieCommandHandler{
+		service: service,
+	}
+}
+
+func (h MovieCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	movieCmd, ok := cmd.(MovieCommand)
+	if !ok {
+		return nil
+	}
+
+	movieID, err := domain.NewMovieIDFromString(movieCmd.ID)
+	if err != nil {
+		return err
+	}
+	return h.service.PurgeMovie(ctx, movieID)
+}
+
+type GroupCommand struct {
+	ID string
+}
+
+func NewGroupCommand(id string) GroupCommand {
+	return GroupCommand{
+		ID: id,
+	}
+}
+
+func (c GroupCommand) Type() command.Type {
+	return GroupCommandType
+}
+
+func (c GroupCommand) AuditTarget() auditing.Target {
+	return auditing.Target{Entity: domain.AuditEntityGroup, ID: c.ID}
+}
+
+type GroupCommandHandler struct {
+	service GroupService
+}
+
+func NewGroupCommandHandler(service GroupService) GroupCommandHandler {
+	return GroupCommandHandler{
+		service: service,
+	}
+}
+
+func (h GroupCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	groupCmd, ok := cmd.(GroupCommand)
+	if !ok {
+		return nil
+	}
+
+	groupID, err := domain.NewGroupIDFromString(groupCmd.ID)
+	if err != nil {
+		return err
+	}
+	return h.service.PurgeGroup(ctx, groupID)
+}
+
+type CategoryCommand struct {
+	ID string
+}
+
+func NewCategoryCommand(id string) CategoryCommand {
+	return CategoryCommand{
+		ID: id,
+	}
+}
+
+func (c CategoryCommand) Type() command.Type {
+	return CategoryCommandType
+}
+
+func (c CategoryCommand) AuditTarget() auditing.Target {
+	return auditing.Target{Entity: domain.AuditEntityCategory, ID: c.ID}
+}
+
+type CategoryCommandHandler struct {
+	service CategoryService
+}
+
+func NewCategoryCommandHandler(service CategoryService) CategoryCommandHandler {
+	return CategoryCommandHandler{
+		service: service,
+	}
+}
+
+func (h CategoryCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	categoryCmd, ok := cmd.(CategoryCommand)
+	if !ok {
+		return nil
+	}
+
+	categoryID, err := domain.NewCategoryIDFromString(categoryCmd.ID)
+	if err != nil {
+		return err
+	}
+	return h.service.PurgeCategory(ctx, categoryID)
+}
+
+type TrackCommand struct {
+	ID string
+}
+
+func NewTrackCommand(id string) TrackCommand {
+	return TrackCommand{
+		ID: id,
+	}
+}
+
+func (c TrackCommand) Type() command.Type {
+	return TrackCommandType
+}
+
+func (c TrackCommand) AuditTarget() auditing.Target {
+	return auditing.Target{Entity: domain.AuditEntityTrack, ID: c.ID}
+}
+
+type TrackCommandHandler struct {
+	service TrackService
+}
+
+func NewTrackCommandHandler(service TrackService) TrackCommandHandler {
+	return TrackCommandHandler{
+		service: service,
+	}
+}
+
+func (h TrackCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	trackCmd, ok := cmd.(TrackCommand)
+	if !ok {
+		return nil
+	}
+
+	trackID, err := domain.NewTrackIDFromString(trackCmd.ID)
+	if err != nil {
+		return err
+	}
+	return h.service.PurgeTrack(ctx, trackID)
+}
+
+type ThemeCommand struct {
+	ID string
+}
+
+func NewThemeCommand(id string) ThemeCommand {
+	return ThemeCommand{
+		ID: id,
+	}
+}
+
+func (c ThemeCommand) Type() command.Type {
+	return ThemeCommandType
+}
+
+func (c ThemeCommand) AuditTarget() auditing.Target {
+	return auditing.Target{Entity: domain.AuditEntityTheme, ID: c.ID}
+}
+
+type ThemeCommandHandler struct {
+	service ThemeService
+}
+
+func NewThemeCommandHandler(service ThemeService) ThemeCommandHandler {
+	return ThemeCommandHandler{
+		service: service,
+	}
+}
+
+func (h ThemeCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	themeCmd, ok := cmd.(ThemeCommand)
+	if !ok {
+		return nil
+	}
+
+	themeID, err := domain.NewThemeIDFromString(themeCmd.ID)
+	if err != nil {
+		return err
+	}
+	return h.service.PurgeTheme(ctx, themeID)
+}
@@ -0,0 +1,185 @@
+package purging
+
+import (
+	"context"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/tx"
+)
+
+// The services of this package delete entities in the trash for good. Purging an entity
+// that others still reference, even from the trash, is refused with domain.ErrInUse.
+
+type MovieService struct {
+	movieRepository domain.MovieRepository
+	txManager       tx.Manager
+	eventBus        event.Bus
+}
+
+func NewMovieService(movieRepository domain.MovieRepository, txManager tx.Manager, eventBus event.Bus) MovieService {
+	return MovieService{
+		movieRepository: movieRepository,
+		txManager:       txManager,
+		eventBus:        eventBus,
+	}
+}
+
+func (s *MovieService) PurgeMovie(ctx context.Context, id domain.MovieID) error {
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		movie, err := s.movieRepository.FindInTrash(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.movieRepository.Purge(ctx, id); err != nil {
+			return err
+		}
+
+		movie.Record(domain.NewMoviePurgedEvent(movie.ID().String(), movie.Name().String()))
+		return s.eventBus.Publish(ctx, movie.PullEvents())
+	})
+}
+
+type GroupService struct {
+	groupRepository domain.GroupRepository
+	txManager       tx.Manager
+	eventBus        event.Bus
+}
+
+func NewGroupService(groupRepository domain.GroupRepository, txManager tx.Manager, eventBus event.Bus) GroupService {
+	return GroupService{
+		groupRepository: groupRepository,
+		txManager:       txManager,
+		eventBus:        eventBus,
+	}
+}
+
+func (s *GroupService) PurgeGroup(ctx context.Context, id domain.GroupID) error {
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		group, err := s.groupRepository.FindInTrash(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.groupRepository.Purge(ctx, id); err != nil {
+			return err
+		}
+
+		group.Record(domain.NewGroupPurgedEvent(group.ID().String(), group.Name().String()))
+		return s.eventBus.Publish(ctx, group.PullEvents())
+	})
+}
+
+type CategoryService struct {
+	categoryRepository domain.CategoryRepository
+	txManager          tx.Manager
+	eventBus           event.Bus
+}
+
+func NewCategoryService(categoryRepository domain.CategoryRepository, txManager tx.Manager, eventBus event.Bus) CategoryService {
+	return CategoryService{
+		categoryRepository: categoryRepository,
+		txManager:          txManager,
+		eventBus:           eventBus,
+	}
+}
+
+func (s *CategoryService) PurgeCategory(ctx context.Context, id domain.CategoryID) error {
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		category, err := s.categoryRepository.FindInTrash(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.categoryRepository.Purge(ctx, id); err != nil {
+			return err
+		}
+
+		category.Record(domain.NewCategoryPurgedEvent(category.ID().String(), category.Name().String()))
+		return s.eventBus.Publish(ctx, category.PullEvents())
+	})
+}
+
+type TrackService struct {
+	trackRepository      domain.TrackRepository
+	trackThemeRepository domain.TrackThemeRepository
+	txManager            tx.Manager
+	eventBus             event.Bus
+}
+
+func NewTrackService(trackRepository domain.TrackRepository, trackThemeRepository domain.TrackThemeRepository, txManager tx.Manager, eventBus event.Bus) TrackService {
+	return TrackService{
+		trackRepository:      trackRepository,
+		trackThemeRepository: trackThemeRepository,
+		txManager:            txManager,
+		eventBus:             eventBus,
+	}
+}
+
+// PurgeTrack deletes the track for good, along with the theme occurrences in it, each of
+// which is published as removed.
+func (s *TrackService) PurgeTrack(ctx context.Context, id domain.TrackID) error {
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		track, err := s.trackRepository.FindInTrash(ctx, id)
+		if err != nil {
+			return err
+		}
+		trackThemes, err := s.trackThemeRepository.FindByTrack(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.trackRepository.Purge(ctx, id); err != nil {
+			return err
+		}
+
+		track.Record(domain.NewTrackPurgedEvent(track.ID().String(), track.Name().String(), track.MovieID().String()))
+		events := append(trackThemeRemovedEvents(trackThemes), track.PullEvents()...)
+		return s.eventBus.Publish(ctx, events)
+	})
+}
+
+type ThemeService struct {
+	themeRepository      domain.ThemeRepository
+	trackThemeRepository domain.TrackThemeRepository
+	txManager            tx.Manager
+	eventBus             event.Bus
+}
+
+func NewThemeService(themeRepository domain.ThemeRepository, trackThemeRepository domain.TrackThemeRepository, txManager tx.Manager, eventBus event.Bus) ThemeService {
+	return ThemeService{
+		themeRepository:      themeRepository,
+		trackThemeRepository: trackThemeRepository,
+		txManager:            txManager,
+		eventBus:             eventBus,
+	}
+}
+
+// PurgeTheme deletes the theme for good, along with its occurrences in tracks, each of
+// which is published as removed.
+func (s *ThemeService) PurgeTheme(ctx context.Context, id domain.ThemeID) error {
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		theme, err := s.themeRepository.FindInTrash(ctx, id)
+		if err != nil {
+			return err
+		}
+		trackThemes, err := s.trackThemeRepository.FindByTheme(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.themeRepository.Purge(ctx, id); err != nil {
+			return err
+		}
+
+		theme.Record(domain.NewThemePurgedEvent(theme.ID().String(), theme.Name().String(), theme.GroupID().String()))
+		events := append(trackThemeRemovedEvents(trackThemes), theme.PullEvents()...)
+		return s.eventBus.Publish(ctx, events)
+	})
+}
+
+// trackThemeRemovedEvents returns the events of the occurrences that go with a purged
+// track or theme.
+func trackThemeRemovedEvents(trackThemes []domain.TrackTheme) []event.Event {
+	events := make([]event.Event, 0, len(trackThemes))
+	for _, trackTheme := range trackThemes {
+		events = append(events, domain.NewTrackThemeRemovedEvent(trackTheme.TrackID().String(), trackTheme.ThemeID().String(), trackTheme.StartSecond().Int()))
+	}
+	return events
+}
@@ -0,0 +1,191 @@
+package purging
+
+import (
+	"context"
+	"testing"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/storage/storagemocks"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event/eventmocks"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/tx/txmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	uuidStr      = "123e4567-e89b-12d3-a456-426614174000"
+	trackUUIDStr = "223e4567-e89b-12d3-a456-426614174001"
+	themeUUIDStr = "323e4567-e89b-12d3-a456-426614174002"
+)
+
+func TestMovieServicePurgeMovieSuccess(t *testing.T) {
+	movieIDObj, err := domain.NewMovieIDFromString(uuidStr)
+	require.NoError(t, err)
+
+	mockRepo := new(storagemocks.MovieRepository)
+	mockRepo.On("FindInTrash", mock.Anything, movieIDObj).Return(domain.Movie{}, nil)
+	mockRepo.On("Purge", mock.Anything, movieIDObj).Return(nil)
+
+	service := NewMovieService(mockRepo, newTxManagerMock(t), newEventBusMock(t, domain.MoviePurgedEventType))
+
+	err = service.PurgeMovie(context.Background(), movieIDObj)
+	assert.NoError(t, err)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMovieServicePurgeMovieInUse(t *testing.T) {
+	movieIDObj, err := domain.NewMovieIDFromString(uuidStr)
+	require.NoError(t, err)
+
+	mockRepo := new(storagemocks.MovieRepository)
+	mockRepo.On("FindInTrash", mock.Anything, movieIDObj).Return(domain.Movie{}, nil)
+	mockRepo.On("Purge", mock.Anything, movieIDObj).Return(domain.ErrInUse)
+
+	service := NewMovieService(mockRepo, newTxManagerMock(t), new(eventmocks.Bus))
+
+	err = service.PurgeMovie(context.Background(), movieIDObj)
+	assert.ErrorIs(t, err, domain.ErrInUse)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGroupServicePurgeGroupSuccess(t *testing.T) {
+	groupIDObj, err := domain.NewGroupIDFromString(uuidStr)
+	require.NoError(t, err)
+
+	mockRepo := new(storagemocks.GroupRepository)
+	mockRepo.On("FindInTrash", mock.Anything, groupIDObj).Return(domain.Group{}, nil)
+	mockRepo.On("Purge", mock.Anything, groupIDObj).Return(nil)
+
+	service := NewGroupService(mockRepo, newTxManagerMock(t), newEventBusMock(t, domain.GroupPurgedEventType))
+
+	err = service.PurgeGroup(context.Background(), groupIDObj)
+	assert.NoError(t, err)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCategoryServicePurgeCategorySuccess(t *testing.T) {
+	categoryIDObj, err := domain.NewCategoryIDFromString(uuidStr)
+	require.NoError(t, err)
+
+	mockRepo := new(storagemocks.CategoryRepository)
+	mockRepo.On("FindInTrash", mock.Anything, categoryIDObj).Return(domain.Category{}, nil)
+	mockRepo.On("Purge", mock.Anything, categoryIDObj).Return(nil)
+
+	service := NewCategoryService(mockRepo, newTxManagerMock(t), newEventBusMock(t, domain.CategoryPurgedEventType))
+
+	err = service.PurgeCategory(context.Background(), categoryIDObj)
+	assert.NoError(t, err)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTrackServicePurgeTrackSuccess(t *testing.T) {
+	track := newTestTrack(t)
+
+	mockRepo := new(storagemocks.TrackRepository)
+	mockRepo.On("FindInTrash", mock.Anything, track.ID()).Return(track, nil)
+	mockRepo.On("Purge", mock.Anything, track.ID()).Return(nil)
+
+	mockTrackThemeRepo := new(storagemocks.TrackThemeRepository)
+	mockTrackThemeRepo.On("FindByTrack", mock.Anything, track.ID()).Return([]domain.TrackTheme{newTestTrackTheme(t)}, nil)
+
+	service := NewTrackService(mockRepo, mockTrackThemeRepo, newTxManagerMock(t), newEventBusMock(t, domain.TrackThemeRemovedEventType, domain.TrackPurgedEventType))
+
+	err := service.PurgeTrack(context.Background(), track.ID())
+	assert.NoError(t, err)
+
+	mockRepo.AssertExpectations(t)
+	mockTrackThemeRepo.AssertExpectations(t)
+}
+
+func TestTrackServicePurgeTrackNotFound(t *testing.T) {
+	trackIDObj, err := domain.NewTrackIDFromString(uuidStr)
+	require.NoError(t, err)
+
+	mockRepo := new(storagemocks.TrackRepository)
+	mockRepo.On("FindInTrash", mock.Anything, trackIDObj).Return(domain.Track{}, domain.ErrTrackNotFound)
+
+	mockTrackThemeRepo := new(storagemocks.TrackThemeRepository)
+
+	service := NewTrackService(mockRepo, mockTrackThemeRepo, newTxManagerMock(t), new(eventmocks.Bus))
+
+	err = service.PurgeTrack(context.Background(), trackIDObj)
+	assert.ErrorIs(t, err, domain.ErrTrackNotFound)
+
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "Purge", mock.Anything, mock.Anything)
+	mockTrackThemeRepo.AssertNotCalled(t, "FindByTrack", mock.Anything, mock.Anything)
+}
+
+func TestThemeServicePurgeThemeSuccess(t *testing.T) {
+	theme := newTestTheme(t)
+
+	mockRepo := new(storagemocks.ThemeRepository)
+	mockRepo.On("FindInTrash", mock.Anything, theme.ID()).Return(theme, nil)
+	mockRepo.On("Purge", mock.Anything, theme.ID()).Return(nil)
+
+	mockTrackThemeRepo := new(storagemocks.TrackThemeRepository)
+	mockTrackThemeRepo.On("FindByTheme", mock.Anything, theme.ID()).Return([]domain.TrackTheme{newTestTrackTheme(t)}, nil)
+
+	service := NewThemeService(mockRepo, mockTrackThemeRepo, newTxManagerMock(t), newEventBusMock(t, domain.TrackThemeRemovedEventType, domain.ThemePurgedEventType))
+
+	err := service.PurgeTheme(context.Background(), theme.ID())
+	assert.NoError(t, err)
+
+	mockRepo.AssertExpectations(t)
+	mockTrackThemeRepo.AssertExpectations(t)
+}
+
+func newTxManagerMock(t *testing.T) *txmocks.Manager {
+	txManagerMock := new(txmocks.Manager)
+	txManagerMock.On("WithinTx", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	t.Cleanup(func() { txManagerMock.AssertExpectations(t) })
+	return txManagerMock
+}
+
+// newTestTrack returns a track of the movie with ID uuidStr.
+func newTestTrack(t *testing.T) domain.Track {
+	track, err := domain.NewTrackWithID(trackUUIDStr, "Test Track", uuidStr, nil, 1, 1, domain.TrackEditionTheatrical, 180)
+	require.NoError(t, err)
+	return track
+}
+
+// newTestTheme returns a theme of the group with ID uuidStr, first heard in the test track.
+func newTestTheme(t *testing.T) domain.Theme {
+	theme, err := domain.NewThemeWithID(themeUUIDStr, "Test Theme", trackUUIDStr, uuidStr, "Test description", 0, 30, nil)
+	require.NoError(t, err)
+	return theme
+}
+
+// newTestTrackTheme returns an occurrence of the test theme in the test track.
+func newTestTrackTheme(t *testing.T) domain.TrackTheme {
+	trackTheme, err := domain.NewTrackTheme(trackUUIDStr, themeUUIDStr, 10, 20, false)
+	require.NoError(t, err)
+	return trackTheme
+}
+
+// newEventBusMock returns an event bus that expects the events of the given types to be
+// published once, in that order.
+func newEventBusMock(t *testing.T, types ...event.Type) *eventmocks.Bus {
+	eventBusMock := new(eventmocks.Bus)
+	eventBusMock.On("Publish", mock.Anything, mock.MatchedBy(func(events []event.Event) bool {
+		if len(events) != len(types) {
+			return false
+		}
+		for i, e := range events {
+			if e.Type() != types[i] {
+				return false
+			}
+		}
+		return true
+	})).Return(nil).Once()
+	t.Cleanup(func() { eventBusMock.AssertExpectations(t) })
+	return eventBusMock
+}
@@ -0,0 +1,115 @@
+package registering
+
+import (
+	"context"
+
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/command"
+)
+
+// The commands of this package are sent by visitors, not by users, so they are not
+// auditable.
+const (
+	RegisterCommandType           command.Type = "command.registering.register"
+	VerifyCommandType             command.Type = "command.registering.verify"
+	ResendVerificationCommandType command.Type = "command.registering.resend_verification"
+)
+
+type RegisterCommand struct {
+	dto dto.RegisterRequest
+}
+
+func NewRegisterCommand(dto dto.RegisterRequest) RegisterCommand {
+	return RegisterCommand{
+		dto: dto,
+	}
+}
+
+func (c RegisterCommand) Type() command.Type {
+	return RegisterCommandType
+}
+
+type RegisterCommandHandler struct {
+	service RegisterService
+}
+
+func NewRegisterCommandHandler(service RegisterService) RegisterCommandHandler {
+	return RegisterCommandHandler{
+		service: service,
+	}
+}
+
+func (h RegisterCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	registerCmd, ok := cmd.(RegisterCommand)
+	if !ok {
+		return nil
+	}
+
+	return h.service.Register(ctx, registerCmd.dto)
+}
+
+type VerifyCommand struct {
+	token string
+}
+
+func NewVerifyCommand(token string) VerifyCommand {
+	return VerifyCommand{
+		token: token,
+	}
+}
+
+func (c VerifyCommand) Type() command.Type {
+	return VerifyCommandType
+}
+
+type VerifyCommandHandler struct {
+	service VerificationService
+}
+
+func NewVerifyCommandHandler(service VerificationService) VerifyCommandHandler {
+	return VerifyCommandHandler{
+		service: service,
+	}
+}
+
+func (h VerifyCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	verifyCmd, ok := cmd.(VerifyCommand)
+	if !ok {
+		return nil
+	}
+
+	return h.service.Verify(ctx, verifyCmd.token)
+}
+
+type ResendVerificationCommand struct {
+	dto dto.ResendVerificationRequest
+}
+
+func NewResendVerificationCommand(dto dto.ResendVerificationRequest) ResendVerificationCommand {
+	return ResendVerificationCommand{
+		dto: dto,
+	}
+}
+
+func (c ResendVerificationCommand) Type() command.Type {
+	return ResendVerificationCommandType
+}
+
+type ResendVerificationCommandHandler struct {
+	service VerificationService
+}
+
+func NewResendVerificationCommandHandler(service VerificationService) ResendVerificationCommandHandler {
+	return ResendVerificationCommandHandler{
+		service: service,
+	}
+}
+
+func (h ResendVerificationCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	resendCmd, ok := cmd.(ResendVerificationCommand)
+	if !ok {
+		return nil
+	}
+
+	return h.service.RequestVerification(ctx, resendCmd.dto.Email)
+}
@@ -0,0 +1,50 @@
+package registering
+
+import (
+	"context"
+	"errors"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+)
+
+// SendVerificationOnUserCreated mails a verification link to the users just created.
+type SendVerificationOnUserCreated struct {
+	verificationService VerificationService
+}
+
+func NewSendVerificationOnUserCreated(verificationService VerificationService) SendVerificationOnUserCreated {
+	return SendVerificationOnUserCreated{
+		verificationService: verificationService,
+	}
+}
+
+func (h SendVerificationOnUserCreated) Handle(ctx context.Context, e event.Event) error {
+	userCreatedEvent, ok := e.(domain.UserCreatedEvent)
+	if !ok {
+		return errors.New("event is not of type UserCreatedEvent")
+	}
+
+	return h.verificationService.SendVerification(ctx, userCreatedEvent.UserID())
+}
+
+// SendVerificationOnVerificationRequested mails a new verification link to the users who
+// asked for one.
+type SendVerificationOnVerificationRequested struct {
+	verificationService VerificationService
+}
+
+func NewSendVerificationOnVerificationRequested(verificationService VerificationService) SendVerificationOnVerificationRequested {
+	return SendVerificationOnVerificationRequested{
+		verificationService: verificationService,
+	}
+}
+
+func (h SendVerificationOnVerificationRequested) Handle(ctx context.Context, e event.Event) error {
+	verificationRequestedEvent, ok := e.(domain.VerificationRequestedEvent)
+	if !ok {
+		return errors.New("event is not of type VerificationRequestedEvent")
+	}
+
+	return h.verificationService.ResendVerification(ctx, verificationRequestedEvent.Email())
+}
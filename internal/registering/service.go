@@ -0,0 +1,236 @@
+package registering
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/auth"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/tx"
+)
+
+// Mailer sends an email of plain text to an address.
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// RegisterService signs up visitors as viewers whose email is not verified yet.
+type RegisterService struct {
+	userRepository domain.UserRepository
+	txManager      tx.Manager
+	eventBus       event.Bus
+}
+
+func NewRegisterService(userRepository domain.UserRepository, txManager tx.Manager, eventBus event.Bus) RegisterService {
+	return RegisterService{
+		userRepository: userRepository,
+		txManager:      txManager,
+		eventBus:       eventBus,
+	}
+}
+
+// Register signs the visitor up, unless the email is registered already. The answer is the
+// same either way, so that it does not tell which emails are registered. A registered email
+// is left untouched, password and all, and only gets a VerificationRequestedEvent: its owner
+// gets a new link if they never verified it, as rate-limited as the ones they ask for.
+func (s RegisterService) Register(ctx context.Context, dto dto.RegisterRequest) error {
+	hashedPassword, err := auth.HashPassword(dto.Password)
+	if err != nil {
+		return err
+	}
+
+	user, err := domain.NewUser(dto.Name, dto.Email, hashedPassword, domain.RoleViewer)
+	if err != nil {
+		return err
+	}
+
+	// The UserCreatedEvent is what gets the verification link mailed
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		_, err := s.userRepository.FindByEmail(ctx, user.Email())
+		switch {
+		case errors.Is(err, domain.ErrUserNotFound):
+		case err != nil:
+			return err
+		default:
+			return s.eventBus.Publish(ctx, []event.Event{domain.NewVerificationRequestedEvent(user.Email().String())})
+		}
+
+		if err := s.userRepository.Save(ctx, user); err != nil {
+			return err
+		}
+		return s.eventBus.Publish(ctx, user.PullEvents())
+	})
+	// Someone signed up with the email at the same time
+	if errors.Is(err, domain.ErrUserAlreadyExists) {
+		return nil
+	}
+	return err
+}
+
+// VerificationService mails the users a link to verify their email, and verifies it when
+// the link is opened.
+type VerificationService struct {
+	userRepository  domain.UserRepository
+	tokenRepository domain.VerificationTokenRepository
+	txManager       tx.Manager
+	eventBus        event.Bus
+	mailer          Mailer
+	verifyURL       string        // URL of the link, to which the token is added as a query parameter
+	exp             time.Duration // Lifetime of the links
+	resendAfter     time.Duration // Wait before a user can get another link
+	now             func() time.Time
+}
+
+func NewVerificationService(userRepository domain.UserRepository, tokenRepository domain.VerificationTokenRepository, txManager tx.Manager, eventBus event.Bus, mailer Mailer, verifyURL string, exp, resendAfter time.Duration) VerificationService {
+	return VerificationService{
+		userRepository:  userRepository,
+		tokenRepository: tokenRepository,
+		txManager:       txManager,
+		eventBus:        eventBus,
+		mailer:          mailer,
+		verifyURL:       verifyURL,
+		exp:             exp,
+		resendAfter:     resendAfter,
+		now:             time.Now,
+	}
+}
+
+// SendVerification mails a verification link to the user. Users already verified, like
+// the ones created by an admin, get no email.
+func (s VerificationService) SendVerification(ctx context.Context, userID string) error {
+	userIDVO, err := domain.NewUserIDFromString(userID)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepository.Find(ctx, userIDVO)
+	if err != nil {
+		return err
+	}
+	return s.send(ctx, user)
+}
+
+// RequestVerification asks for a new verification link to be mailed to the email, for
+// when the first one expired or got lost. It only publishes a VerificationRequestedEvent:
+// whether the email is registered is left to ResendVerification, off the request path, so
+// that the answer and the time it takes do not tell.
+func (s VerificationService) RequestVerification(ctx context.Context, email string) error {
+	emailVO, err := domain.NewUserEmail(email)
+	if err != nil {
+		return err
+	}
+
+	return s.eventBus.Publish(ctx, []event.Event{domain.NewVerificationRequestedEvent(emailVO.String())})
+}
+
+// ResendVerification mails a new verification link to the user with the email, unless
+// there is no such user, they are verified already, or they got a link less than
+// resendAfter ago. The latter keeps anyone from flooding their inbox.
+func (s VerificationService) ResendVerification(ctx context.Context, email string) error {
+	emailVO, err := domain.NewUserEmail(email)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepository.FindByEmail(ctx, emailVO)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if user.Verified() {
+		return nil
+	}
+
+	// A link issued less than resendAfter ago is still valid for longer than exp - resendAfter
+	recent, err := s.tokenRepository.ValidAt(ctx, user.ID(), s.now().Add(s.exp-s.resendAfter))
+	if err != nil {
+		return err
+	}
+	if recent {
+		return nil
+	}
+
+	// The token is only kept if the email is sent, so that a failed attempt, retried by
+	// the event bus, is not taken for a recent link
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		return s.send(ctx, user)
+	})
+}
+
+func (s VerificationService) send(ctx context.Context, user domain.User) error {
+	if user.Verified() {
+		return nil
+	}
+
+	// Only the hash of the token is stored, like the refresh tokens
+	token, err := auth.GenerateToken()
+	if err != nil {
+		return err
+	}
+	expiresAt := s.now().Add(s.exp)
+	verificationToken, err := domain.NewVerificationToken(auth.HashToken(token), user.ID().String(), expiresAt)
+	if err != nil {
+		return err
+	}
+
+	link, err := s.link(token)
+	if err != nil {
+		return err
+	}
+
+	if err := s.tokenRepository.Save(ctx, verificationToken); err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Hello %s,\n\nPlease verify your email by opening the link below before %s:\n\n%s\n",
+		user.Name().String(), expiresAt.UTC().Format(time.RFC1123), link)
+	return s.mailer.Send(ctx, user.Email().String(), "Verify your email", body)
+}
+
+func (s VerificationService) link(token string) (string, error) {
+	link, err := url.Parse(s.verifyURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid verification URL: %v", err)
+	}
+
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String(), nil
+}
+
+// Verify verifies the email of the user the token was mailed to. The token can only be
+// used once.
+func (s VerificationService) Verify(ctx context.Context, token string) error {
+	if token == "" {
+		return domain.ErrInvalidVerificationToken
+	}
+
+	hash := auth.HashToken(token)
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		verificationToken, err := s.tokenRepository.FindByTokenHash(ctx, hash)
+		if err != nil {
+			return err
+		}
+		if verificationToken.Expired(s.now()) {
+			return domain.ErrInvalidVerificationToken
+		}
+
+		user, err := s.userRepository.Find(ctx, verificationToken.UserID())
+		if err != nil {
+			return err
+		}
+		if err := s.userRepository.Update(ctx, user.Verify()); err != nil {
+			return err
+		}
+
+		return s.tokenRepository.Delete(ctx, hash)
+	})
+}
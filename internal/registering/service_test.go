@@ -0,0 +1,350 @@
+package registering
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/auth"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/storage/inmemory"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/storage/storagemocks"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event/eventmocks"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/tx"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/tx/txmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	userID      = "7c0e1a5e-5d6a-4f5e-9a3b-0f2d1c4e8b21"
+	verifyURL   = "https://middle-earth.example.com/verify"
+	exp         = 48 * time.Hour
+	resendAfter = 10 * time.Minute
+)
+
+var now = time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+
+// mailerFunc adapts a function to the Mailer interface.
+type mailerFunc func(ctx context.Context, to, subject, body string) error
+
+func (f mailerFunc) Send(ctx context.Context, to, subject, body string) error {
+	return f(ctx, to, subject, body)
+}
+
+func newTxManagerMock(t *testing.T) *txmocks.Manager {
+	txManagerMock := new(txmocks.Manager)
+	txManagerMock.On("WithinTx", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	t.Cleanup(func() { txManagerMock.AssertExpectations(t) })
+	return txManagerMock
+}
+
+func newStoredUser(t *testing.T, verified bool) domain.User {
+	t.Helper()
+
+	user, err := domain.NewUserWithID(userID, "Pippin Took", "pippin@shire.me", "hashed-password", domain.RoleViewer, verified)
+	require.NoError(t, err)
+	return user
+}
+
+// newVerificationService returns a service on mocks. Only Verify and ResendVerification run
+// in a transaction, so the other tests pass a transaction manager without expectations.
+func newVerificationService(t *testing.T, mailer Mailer, txManager tx.Manager) (VerificationService, *storagemocks.UserRepository, *storagemocks.VerificationTokenRepository) {
+	t.Helper()
+
+	userRepositoryMock := new(storagemocks.UserRepository)
+	tokenRepositoryMock := new(storagemocks.VerificationTokenRepository)
+	t.Cleanup(func() {
+		userRepositoryMock.AssertExpectations(t)
+		tokenRepositoryMock.AssertExpectations(t)
+	})
+
+	service := NewVerificationService(userRepositoryMock, tokenRepositoryMock, txManager, nil, mailer, verifyURL, exp, resendAfter)
+	service.now = func() time.Time { return now }
+	return service, userRepositoryMock, tokenRepositoryMock
+}
+
+// newRegisterService returns a service on mocks along with the mock of its user
+// repository, on which the user with the email is looked up.
+func newRegisterService(t *testing.T, existing domain.User, findErr error, eventBus event.Bus) (RegisterService, *storagemocks.UserRepository) {
+	t.Helper()
+
+	userRepositoryMock := new(storagemocks.UserRepository)
+	userRepositoryMock.On("FindByEmail", mock.Anything, mock.MatchedBy(func(email domain.UserEmail) bool {
+		return email.String() == "pippin@shire.me"
+	})).Return(existing, findErr).Once()
+	t.Cleanup(func() { userRepositoryMock.AssertExpectations(t) })
+
+	return NewRegisterService(userRepositoryMock, newTxManagerMock(t), eventBus), userRepositoryMock
+}
+
+// newUserCreatedBusMock returns an event bus that expects a user to be published as created.
+func newUserCreatedBusMock(t *testing.T) *eventmocks.Bus {
+	eventBusMock := new(eventmocks.Bus)
+	eventBusMock.On("Publish", mock.Anything, mock.MatchedBy(func(events []event.Event) bool {
+		return len(events) == 1 && events[0].Type() == domain.UserCreatedEventType
+	})).Return(nil).Once()
+	t.Cleanup(func() { eventBusMock.AssertExpectations(t) })
+	return eventBusMock
+}
+
+func TestRegisterServiceRegister(t *testing.T) {
+	service, userRepositoryMock := newRegisterService(t, domain.User{}, domain.ErrUserNotFound, newUserCreatedBusMock(t))
+	userRepositoryMock.On("Save", mock.Anything, mock.MatchedBy(func(user domain.User) bool {
+		return !user.Verified() && user.Role().String() == domain.RoleViewer
+	})).Return(nil).Once()
+
+	err := service.Register(context.Background(), dto.RegisterRequest{Name: "Pippin Took", Email: "pippin@shire.me", Password: "second-breakfast"})
+	assert.NoError(t, err)
+}
+
+func TestRegisterServiceRegisterRegisteredEmail(t *testing.T) {
+	for name, verified := range map[string]bool{"verified": true, "unverified": false} {
+		t.Run(name, func(t *testing.T) {
+			eventBusMock := new(eventmocks.Bus)
+			eventBusMock.On("Publish", mock.Anything, mock.MatchedBy(func(events []event.Event) bool {
+				return len(events) == 1 && events[0].Type() == domain.VerificationRequestedEventType &&
+					events[0].(domain.VerificationRequestedEvent).Email() == "pippin@shire.me"
+			})).Return(nil).Once()
+			defer eventBusMock.AssertExpectations(t)
+			service, userRepositoryMock := newRegisterService(t, newStoredUser(t, verified), nil, eventBusMock)
+
+			err := service.Register(context.Background(), dto.RegisterRequest{Name: "Peregrin Took", Email: "pippin@shire.me", Password: "second-breakfast"})
+			assert.NoError(t, err)
+			userRepositoryMock.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+			userRepositoryMock.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+			userRepositoryMock.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestRegisterServiceRegisterKeepsUnverifiedUser(t *testing.T) {
+	store := inmemory.NewStore()
+	userRepository := inmemory.NewUserRepository(store)
+	eventBusMock := new(eventmocks.Bus)
+	eventBusMock.On("Publish", mock.Anything, mock.Anything).Return(nil)
+	service := NewRegisterService(userRepository, inmemory.NewTxManager(store), eventBusMock)
+
+	email, err := domain.NewUserEmail("pippin@shire.me")
+	require.NoError(t, err)
+
+	require.NoError(t, service.Register(context.Background(), dto.RegisterRequest{Name: "Pippin Took", Email: "pippin@shire.me", Password: "second-breakfast"}))
+	first, err := userRepository.FindByEmail(context.Background(), email)
+	require.NoError(t, err)
+
+	// Someone else signs up with the email before its owner verified it
+	require.NoError(t, service.Register(context.Background(), dto.RegisterRequest{Name: "Bill Ferny", Email: "pippin@shire.me", Password: "not-pippin-at-all"}))
+	second, err := userRepository.FindByEmail(context.Background(), email)
+	require.NoError(t, err)
+
+	assert.Equal(t, first.ID(), second.ID())
+	assert.Equal(t, first.Password(), second.Password())
+	assert.Equal(t, "Pippin Took", second.Name().String())
+	assert.NoError(t, auth.CheckPassword(second.Password().String(), "second-breakfast"))
+	assert.Error(t, auth.CheckPassword(second.Password().String(), "not-pippin-at-all"))
+}
+
+func TestRegisterServiceRegisterConcurrentSignUp(t *testing.T) {
+	eventBusMock := new(eventmocks.Bus)
+	service, userRepositoryMock := newRegisterService(t, domain.User{}, domain.ErrUserNotFound, eventBusMock)
+	userRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("domain.User")).Return(domain.ErrUserAlreadyExists).Once()
+
+	err := service.Register(context.Background(), dto.RegisterRequest{Name: "Pippin Took", Email: "pippin@shire.me", Password: "second-breakfast"})
+	assert.NoError(t, err)
+	eventBusMock.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestRegisterServiceRegisterRepositoryError(t *testing.T) {
+	service, _ := newRegisterService(t, domain.User{}, errors.New("query error"), new(eventmocks.Bus))
+
+	err := service.Register(context.Background(), dto.RegisterRequest{Name: "Pippin Took", Email: "pippin@shire.me", Password: "second-breakfast"})
+	assert.Error(t, err)
+}
+
+func TestVerificationServiceSendVerification(t *testing.T) {
+	var to, body string
+	mailer := mailerFunc(func(_ context.Context, mailTo, _, mailBody string) error {
+		to, body = mailTo, mailBody
+		return nil
+	})
+	service, userRepositoryMock, tokenRepositoryMock := newVerificationService(t, mailer, new(txmocks.Manager))
+
+	user := newStoredUser(t, false)
+	userRepositoryMock.On("Find", mock.Anything, user.ID()).Return(user, nil).Once()
+	tokenRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("domain.VerificationToken")).Return(nil).Once()
+
+	require.NoError(t, service.SendVerification(context.Background(), userID))
+	assert.Equal(t, "pippin@shire.me", to)
+
+	// The link carries the token whose hash was saved
+	start := strings.Index(body, verifyURL)
+	require.NotEqual(t, -1, start)
+	link, err := url.Parse(strings.Fields(body[start:])[0])
+	require.NoError(t, err)
+	token := link.Query().Get("token")
+
+	saved := tokenRepositoryMock.Calls[0].Arguments.Get(1).(domain.VerificationToken)
+	assert.Equal(t, auth.HashToken(token), saved.TokenHash())
+	assert.Equal(t, user.ID(), saved.UserID())
+	assert.Equal(t, now.Add(exp), saved.ExpiresAt())
+}
+
+func TestVerificationServiceSendVerificationAlreadyVerified(t *testing.T) {
+	mailer := mailerFunc(func(context.Context, string, string, string) error {
+		t.Fatal("no email should be sent")
+		return nil
+	})
+	service, userRepositoryMock, _ := newVerificationService(t, mailer, new(txmocks.Manager))
+
+	user := newStoredUser(t, true)
+	userRepositoryMock.On("Find", mock.Anything, user.ID()).Return(user, nil).Once()
+
+	assert.NoError(t, service.SendVerification(context.Background(), userID))
+}
+
+func TestVerificationServiceSendVerificationMailerError(t *testing.T) {
+	mailer := mailerFunc(func(context.Context, string, string, string) error {
+		return errors.New("connection refused")
+	})
+	service, userRepositoryMock, tokenRepositoryMock := newVerificationService(t, mailer, new(txmocks.Manager))
+
+	user := newStoredUser(t, false)
+	userRepositoryMock.On("Find", mock.Anything, user.ID()).Return(user, nil).Once()
+	tokenRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("domain.VerificationToken")).Return(nil).Once()
+
+	assert.Error(t, service.SendVerification(context.Background(), userID))
+}
+
+func TestVerificationServiceRequestVerification(t *testing.T) {
+	service, _, _ := newVerificationService(t, nil, new(txmocks.Manager))
+
+	eventBusMock := new(eventmocks.Bus)
+	eventBusMock.On("Publish", mock.Anything, mock.MatchedBy(func(events []event.Event) bool {
+		if len(events) != 1 {
+			return false
+		}
+		e, ok := events[0].(domain.VerificationRequestedEvent)
+		return ok && e.Email() == "pippin@shire.me"
+	})).Return(nil).Once()
+	defer eventBusMock.AssertExpectations(t)
+	service.eventBus = eventBusMock
+
+	// The user is only looked up when the event is handled
+	assert.NoError(t, service.RequestVerification(context.Background(), "pippin@shire.me"))
+}
+
+func TestVerificationServiceRequestVerificationInvalidEmail(t *testing.T) {
+	service, _, _ := newVerificationService(t, nil, new(txmocks.Manager))
+
+	eventBusMock := new(eventmocks.Bus)
+	defer eventBusMock.AssertExpectations(t)
+	service.eventBus = eventBusMock
+
+	assert.Error(t, service.RequestVerification(context.Background(), "not-an-email"))
+}
+
+func TestVerificationServiceResendVerification(t *testing.T) {
+	var to string
+	mailer := mailerFunc(func(_ context.Context, mailTo, _, _ string) error {
+		to = mailTo
+		return nil
+	})
+	service, userRepositoryMock, tokenRepositoryMock := newVerificationService(t, mailer, newTxManagerMock(t))
+
+	user := newStoredUser(t, false)
+	userRepositoryMock.On("FindByEmail", mock.Anything, user.Email()).Return(user, nil).Once()
+	tokenRepositoryMock.On("ValidAt", mock.Anything, user.ID(), now.Add(exp-resendAfter)).Return(false, nil).Once()
+	tokenRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("domain.VerificationToken")).Return(nil).Once()
+
+	require.NoError(t, service.ResendVerification(context.Background(), "pippin@shire.me"))
+	assert.Equal(t, "pippin@shire.me", to)
+}
+
+func TestVerificationServiceResendVerificationUnknownEmail(t *testing.T) {
+	mailer := mailerFunc(func(context.Context, string, string, string) error {
+		t.Fatal("no email should be sent")
+		return nil
+	})
+	service, userRepositoryMock, _ := newVerificationService(t, mailer, new(txmocks.Manager))
+
+	email, err := domain.NewUserEmail("merry@shire.me")
+	require.NoError(t, err)
+	userRepositoryMock.On("FindByEmail", mock.Anything, email).Return(domain.User{}, domain.ErrUserNotFound).Once()
+
+	assert.NoError(t, service.ResendVerification(context.Background(), "merry@shire.me"))
+}
+
+func TestVerificationServiceResendVerificationRecentLink(t *testing.T) {
+	mailer := mailerFunc(func(context.Context, string, string, string) error {
+		t.Fatal("no email should be sent")
+		return nil
+	})
+	service, userRepositoryMock, tokenRepositoryMock := newVerificationService(t, mailer, new(txmocks.Manager))
+
+	user := newStoredUser(t, false)
+	userRepositoryMock.On("FindByEmail", mock.Anything, user.Email()).Return(user, nil).Once()
+	tokenRepositoryMock.On("ValidAt", mock.Anything, user.ID(), now.Add(exp-resendAfter)).Return(true, nil).Once()
+
+	assert.NoError(t, service.ResendVerification(context.Background(), "pippin@shire.me"))
+}
+
+func TestVerificationServiceResendVerificationMailerError(t *testing.T) {
+	mailer := mailerFunc(func(context.Context, string, string, string) error {
+		return errors.New("connection refused")
+	})
+	service, userRepositoryMock, tokenRepositoryMock := newVerificationService(t, mailer, newTxManagerMock(t))
+
+	user := newStoredUser(t, false)
+	userRepositoryMock.On("FindByEmail", mock.Anything, user.Email()).Return(user, nil).Once()
+	tokenRepositoryMock.On("ValidAt", mock.Anything, user.ID(), now.Add(exp-resendAfter)).Return(false, nil).Once()
+	tokenRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("domain.VerificationToken")).Return(nil).Once()
+
+	// Returned so that the event bus retries, the token being rolled back with the transaction
+	assert.Error(t, service.ResendVerification(context.Background(), "pippin@shire.me"))
+}
+
+func TestVerificationServiceVerify(t *testing.T) {
+	service, userRepositoryMock, tokenRepositoryMock := newVerificationService(t, nil, newTxManagerMock(t))
+
+	hash := auth.HashToken("token")
+	token, err := domain.NewVerificationToken(hash, userID, now.Add(time.Hour))
+	require.NoError(t, err)
+	user := newStoredUser(t, false)
+
+	tokenRepositoryMock.On("FindByTokenHash", mock.Anything, hash).Return(token, nil).Once()
+	userRepositoryMock.On("Find", mock.Anything, user.ID()).Return(user, nil).Once()
+	userRepositoryMock.On("Update", mock.Anything, user.Verify()).Return(nil).Once()
+	tokenRepositoryMock.On("Delete", mock.Anything, hash).Return(nil).Once()
+
+	assert.NoError(t, service.Verify(context.Background(), "token"))
+}
+
+func TestVerificationServiceVerifyExpired(t *testing.T) {
+	service, _, tokenRepositoryMock := newVerificationService(t, nil, newTxManagerMock(t))
+
+	hash := auth.HashToken("token")
+	token, err := domain.NewVerificationToken(hash, userID, now)
+	require.NoError(t, err)
+	tokenRepositoryMock.On("FindByTokenHash", mock.Anything, hash).Return(token, nil).Once()
+
+	err = service.Verify(context.Background(), "token")
+	assert.ErrorIs(t, err, domain.ErrInvalidVerificationToken)
+}
+
+func TestVerificationServiceVerifyUnknownToken(t *testing.T) {
+	service, _, tokenRepositoryMock := newVerificationService(t, nil, newTxManagerMock(t))
+
+	hash := auth.HashToken("unknown")
+	tokenRepositoryMock.On("FindByTokenHash", mock.Anything, hash).Return(domain.VerificationToken{}, domain.ErrInvalidVerificationToken).Once()
+
+	err := service.Verify(context.Background(), "unknown")
+	assert.ErrorIs(t, err, domain.ErrInvalidVerificationToken)
+}
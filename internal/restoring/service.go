@@ -0,0 +1,197 @@
+package restoring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/tx"
+)
+
+type MovieService struct {
+	movieRepository domain.MovieRepository
+	txManager       tx.Manager
+	eventBus        event.Bus
+}
+
+func NewMovieService(movieRepository domain.MovieRepository, txManager tx.Manager, eventBus event.Bus) MovieService {
+	return MovieService{
+		movieRepository: movieRepository,
+		txManager:       txManager,
+		eventBus:        eventBus,
+	}
+}
+
+func (s *MovieService) RestoreMovie(ctx context.Context, id domain.MovieID) error {
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.movieRepository.Restore(ctx, id); err != nil {
+			return err
+		}
+		movie, err := s.movieRepository.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		movie.Record(domain.NewMovieRestoredEvent(movie.ID().String(), movie.Name().String()))
+		return s.eventBus.Publish(ctx, movie.PullEvents())
+	})
+}
+
+type GroupService struct {
+	groupRepository domain.GroupRepository
+	txManager       tx.Manager
+	eventBus        event.Bus
+}
+
+func NewGroupService(groupRepository domain.GroupRepository, txManager tx.Manager, eventBus event.Bus) GroupService {
+	return GroupService{
+		groupRepository: groupRepository,
+		txManager:       txManager,
+		eventBus:        eventBus,
+	}
+}
+
+func (s *GroupService) RestoreGroup(ctx context.Context, id domain.GroupID) error {
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.groupRepository.Restore(ctx, id); err != nil {
+			return err
+		}
+		group, err := s.groupRepository.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		group.Record(domain.NewGroupRestoredEvent(group.ID().String(), group.Name().String()))
+		return s.eventBus.Publish(ctx, group.PullEvents())
+	})
+}
+
+type CategoryService struct {
+	categoryRepository domain.CategoryRepository
+	txManager          tx.Manager
+	eventBus           event.Bus
+}
+
+func NewCategoryService(categoryRepository domain.CategoryRepository, txManager tx.Manager, eventBus event.Bus) CategoryService {
+	return CategoryService{
+		categoryRepository: categoryRepository,
+		txManager:          txManager,
+		eventBus:           eventBus,
+	}
+}
+
+func (s *CategoryService) RestoreCategory(ctx context.Context, id domain.CategoryID) error {
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.categoryRepository.Restore(ctx, id); err != nil {
+			return err
+		}
+		category, err := s.categoryRepository.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		category.Record(domain.NewCategoryRestoredEvent(category.ID().String(), category.Name().String()))
+		return s.eventBus.Publish(ctx, category.PullEvents())
+	})
+}
+
+type TrackService struct {
+	trackRepository domain.TrackRepository
+	movieRepository domain.MovieRepository
+	txManager       tx.Manager
+	eventBus        event.Bus
+}
+
+func NewTrackService(trackRepository domain.TrackRepository, movieRepository domain.MovieRepository, txManager tx.Manager, eventBus event.Bus) TrackService {
+	return TrackService{
+		trackRepository: trackRepository,
+		movieRepository: movieRepository,
+		txManager:       txManager,
+		eventBus:        eventBus,
+	}
+}
+
+// RestoreTrack takes the track out of the trash. A track whose movie is still in the
+// trash is refused with domain.ErrDeletedReference: the movie has to be restored first.
+func (s *TrackService) RestoreTrack(ctx context.Context, id domain.TrackID) error {
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.trackRepository.Restore(ctx, id); err != nil {
+			return err
+		}
+		track, err := s.trackRepository.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		_, err = s.movieRepository.Find(ctx, track.MovieID())
+		if err := referenceError(err, domain.ErrMovieNotFound, "movie"); err != nil {
+			return err
+		}
+
+		track.Record(domain.NewTrackRestoredEvent(track.ID().String(), track.Name().String(), track.MovieID().String()))
+		return s.eventBus.Publish(ctx, track.PullEvents())
+	})
+}
+
+type ThemeService struct {
+	themeRepository    domain.ThemeRepository
+	groupRepository    domain.GroupRepository
+	categoryRepository domain.CategoryRepository
+	trackRepository    domain.TrackRepository
+	txManager          tx.Manager
+	eventBus           event.Bus
+}
+
+func NewThemeService(themeRepository domain.ThemeRepository, groupRepository domain.GroupRepository, categoryRepository domain.CategoryRepository, trackRepository domain.TrackRepository, txManager tx.Manager, eventBus event.Bus) ThemeService {
+	return ThemeService{
+		themeRepository:    themeRepository,
+		groupRepository:    groupRepository,
+		categoryRepository: categoryRepository,
+		trackRepository:    trackRepository,
+		txManager:          txManager,
+		eventBus:           eventBus,
+	}
+}
+
+// RestoreTheme takes the theme out of the trash. A theme whose group, category or first
+// heard track is still in the trash is refused with domain.ErrDeletedReference.
+func (s *ThemeService) RestoreTheme(ctx context.Context, id domain.ThemeID) error {
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.themeRepository.Restore(ctx, id); err != nil {
+			return err
+		}
+		theme, err := s.themeRepository.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		_, err = s.groupRepository.Find(ctx, theme.GroupID())
+		if err := referenceError(err, domain.ErrGroupNotFound, "group"); err != nil {
+			return err
+		}
+		if categoryID := theme.CategoryID(); categoryID != nil {
+			_, err = s.categoryRepository.Find(ctx, *categoryID)
+			if err := referenceError(err, domain.ErrCategoryNotFound, "category"); err != nil {
+				return err
+			}
+		}
+		_, err = s.trackRepository.Find(ctx, theme.FirstHeard())
+		if err := referenceError(err, domain.ErrTrackNotFound, "first heard track"); err != nil {
+			return err
+		}
+
+		theme.Record(domain.NewThemeRestoredEvent(theme.ID().String(), theme.Name().String(), theme.GroupID().String()))
+		return s.eventBus.Publish(ctx, theme.PullEvents())
+	})
+}
+
+// referenceError turns the not found error of a referenced entity, which can only be
+// missing because it is in the trash, into domain.ErrDeletedReference.
+func referenceError(err, notFound error, reference string) error {
+	if errors.Is(err, notFound) {
+		return fmt.Errorf("%w: the %s is in the trash", domain.ErrDeletedReference, reference)
+	}
+	return err
+}
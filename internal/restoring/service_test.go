@@ -0,0 +1,233 @@
+package restoring
+
+import (
+	"context"
+	"testing"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/storage/storagemocks"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event/eventmocks"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/tx/txmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	uuidStr         = "123e4567-e89b-12d3-a456-426614174000"
+	trackUUIDStr    = "223e4567-e89b-12d3-a456-426614174001"
+	themeUUIDStr    = "323e4567-e89b-12d3-a456-426614174002"
+	categoryUUIDStr = "423e4567-e89b-12d3-a456-426614174003"
+)
+
+func TestMovieServiceRestoreMovieSuccess(t *testing.T) {
+	movieIDObj, err := domain.NewMovieIDFromString(uuidStr)
+	require.NoError(t, err)
+
+	mockRepo := new(storagemocks.MovieRepository)
+	mockRepo.On("Restore", mock.Anything, movieIDObj).Return(nil)
+	mockRepo.On("Find", mock.Anything, movieIDObj).Return(domain.Movie{}, nil)
+
+	service := NewMovieService(mockRepo, newTxManagerMock(t), newEventBusMock(t, domain.MovieRestoredEventType))
+
+	err = service.RestoreMovie(context.Background(), movieIDObj)
+	assert.NoError(t, err)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMovieServiceRestoreMovieNotFound(t *testing.T) {
+	movieIDObj, err := domain.NewMovieIDFromString(uuidStr)
+	require.NoError(t, err)
+
+	mockRepo := new(storagemocks.MovieRepository)
+	mockRepo.On("Restore", mock.Anything, movieIDObj).Return(domain.ErrMovieNotFound)
+
+	service := NewMovieService(mockRepo, newTxManagerMock(t), new(eventmocks.Bus))
+
+	err = service.RestoreMovie(context.Background(), movieIDObj)
+	assert.ErrorIs(t, err, domain.ErrMovieNotFound)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGroupServiceRestoreGroupSuccess(t *testing.T) {
+	groupIDObj, err := domain.NewGroupIDFromString(uuidStr)
+	require.NoError(t, err)
+
+	mockRepo := new(storagemocks.GroupRepository)
+	mockRepo.On("Restore", mock.Anything, groupIDObj).Return(nil)
+	mockRepo.On("Find", mock.Anything, groupIDObj).Return(domain.Group{}, nil)
+
+	service := NewGroupService(mockRepo, newTxManagerMock(t), newEventBusMock(t, domain.GroupRestoredEventType))
+
+	err = service.RestoreGroup(context.Background(), groupIDObj)
+	assert.NoError(t, err)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCategoryServiceRestoreCategorySuccess(t *testing.T) {
+	categoryIDObj, err := domain.NewCategoryIDFromString(uuidStr)
+	require.NoError(t, err)
+
+	mockRepo := new(storagemocks.CategoryRepository)
+	mockRepo.On("Restore", mock.Anything, categoryIDObj).Return(nil)
+	mockRepo.On("Find", mock.Anything, categoryIDObj).Return(domain.Category{}, nil)
+
+	service := NewCategoryService(mockRepo, newTxManagerMock(t), newEventBusMock(t, domain.CategoryRestoredEventType))
+
+	err = service.RestoreCategory(context.Background(), categoryIDObj)
+	assert.NoError(t, err)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTrackServiceRestoreTrackSuccess(t *testing.T) {
+	track := newTestTrack(t)
+
+	trackRepositoryMock := new(storagemocks.TrackRepository)
+	trackRepositoryMock.On("Restore", mock.Anything, track.ID()).Return(nil)
+	trackRepositoryMock.On("Find", mock.Anything, track.ID()).Return(track, nil)
+
+	movieRepositoryMock := new(storagemocks.MovieRepository)
+	movieRepositoryMock.On("Find", mock.Anything, track.MovieID()).Return(domain.Movie{}, nil)
+
+	service := NewTrackService(trackRepositoryMock, movieRepositoryMock, newTxManagerMock(t), newEventBusMock(t, domain.TrackRestoredEventType))
+
+	err := service.RestoreTrack(context.Background(), track.ID())
+	assert.NoError(t, err)
+
+	trackRepositoryMock.AssertExpectations(t)
+	movieRepositoryMock.AssertExpectations(t)
+}
+
+func TestTrackServiceRestoreTrackOfDeletedMovie(t *testing.T) {
+	track := newTestTrack(t)
+
+	trackRepositoryMock := new(storagemocks.TrackRepository)
+	trackRepositoryMock.On("Restore", mock.Anything, track.ID()).Return(nil)
+	trackRepositoryMock.On("Find", mock.Anything, track.ID()).Return(track, nil)
+
+	movieRepositoryMock := new(storagemocks.MovieRepository)
+	movieRepositoryMock.On("Find", mock.Anything, track.MovieID()).Return(domain.Movie{}, domain.ErrMovieNotFound)
+
+	service := NewTrackService(trackRepositoryMock, movieRepositoryMock, newTxManagerMock(t), new(eventmocks.Bus))
+
+	err := service.RestoreTrack(context.Background(), track.ID())
+	assert.ErrorIs(t, err, domain.ErrDeletedReference)
+
+	trackRepositoryMock.AssertExpectations(t)
+	movieRepositoryMock.AssertExpectations(t)
+}
+
+func TestThemeServiceRestoreThemeSuccess(t *testing.T) {
+	theme := newTestTheme(t)
+
+	themeRepositoryMock := new(storagemocks.ThemeRepository)
+	themeRepositoryMock.On("Restore", mock.Anything, theme.ID()).Return(nil)
+	themeRepositoryMock.On("Find", mock.Anything, theme.ID()).Return(theme, nil)
+
+	groupRepositoryMock := new(storagemocks.GroupRepository)
+	groupRepositoryMock.On("Find", mock.Anything, theme.GroupID()).Return(domain.Group{}, nil)
+
+	categoryRepositoryMock := new(storagemocks.CategoryRepository)
+	categoryRepositoryMock.On("Find", mock.Anything, *theme.CategoryID()).Return(domain.Category{}, nil)
+
+	trackRepositoryMock := new(storagemocks.TrackRepository)
+	trackRepositoryMock.On("Find", mock.Anything, theme.FirstHeard()).Return(domain.Track{}, nil)
+
+	service := NewThemeService(themeRepositoryMock, groupRepositoryMock, categoryRepositoryMock, trackRepositoryMock, newTxManagerMock(t), newEventBusMock(t, domain.ThemeRestoredEventType))
+
+	err := service.RestoreTheme(context.Background(), theme.ID())
+	assert.NoError(t, err)
+
+	themeRepositoryMock.AssertExpectations(t)
+	groupRepositoryMock.AssertExpectations(t)
+	categoryRepositoryMock.AssertExpectations(t)
+	trackRepositoryMock.AssertExpectations(t)
+}
+
+func TestThemeServiceRestoreThemeOfDeletedCategory(t *testing.T) {
+	theme := newTestTheme(t)
+
+	themeRepositoryMock := new(storagemocks.ThemeRepository)
+	themeRepositoryMock.On("Restore", mock.Anything, theme.ID()).Return(nil)
+	themeRepositoryMock.On("Find", mock.Anything, theme.ID()).Return(theme, nil)
+
+	groupRepositoryMock := new(storagemocks.GroupRepository)
+	groupRepositoryMock.On("Find", mock.Anything, theme.GroupID()).Return(domain.Group{}, nil)
+
+	categoryRepositoryMock := new(storagemocks.CategoryRepository)
+	categoryRepositoryMock.On("Find", mock.Anything, *theme.CategoryID()).Return(domain.Category{}, domain.ErrCategoryNotFound)
+
+	trackRepositoryMock := new(storagemocks.TrackRepository)
+
+	service := NewThemeService(themeRepositoryMock, groupRepositoryMock, categoryRepositoryMock, trackRepositoryMock, newTxManagerMock(t), new(eventmocks.Bus))
+
+	err := service.RestoreTheme(context.Background(), theme.ID())
+	assert.ErrorIs(t, err, domain.ErrDeletedReference)
+
+	trackRepositoryMock.AssertNotCalled(t, "Find", mock.Anything, mock.Anything)
+}
+
+func TestThemeServiceRestoreThemeNotInTrash(t *testing.T) {
+	themeIDObj, err := domain.NewThemeIDFromString(themeUUIDStr)
+	require.NoError(t, err)
+
+	themeRepositoryMock := new(storagemocks.ThemeRepository)
+	themeRepositoryMock.On("Restore", mock.Anything, themeIDObj).Return(domain.ErrThemeNotFound)
+
+	service := NewThemeService(themeRepositoryMock, nil, nil, nil, newTxManagerMock(t), new(eventmocks.Bus))
+
+	err = service.RestoreTheme(context.Background(), themeIDObj)
+	assert.ErrorIs(t, err, domain.ErrThemeNotFound)
+	assert.NotErrorIs(t, err, domain.ErrDeletedReference)
+
+	themeRepositoryMock.AssertExpectations(t)
+}
+
+func newTxManagerMock(t *testing.T) *txmocks.Manager {
+	txManagerMock := new(txmocks.Manager)
+	txManagerMock.On("WithinTx", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	t.Cleanup(func() { txManagerMock.AssertExpectations(t) })
+	return txManagerMock
+}
+
+// newTestTrack returns a track of the movie with ID uuidStr.
+func newTestTrack(t *testing.T) domain.Track {
+	track, err := domain.NewTrackWithID(trackUUIDStr, "Test Track", uuidStr, nil, 1, 1, domain.TrackEditionTheatrical, 180)
+	require.NoError(t, err)
+	return track
+}
+
+// newTestTheme returns a theme of the group with ID uuidStr and a category, first heard
+// in the test track.
+func newTestTheme(t *testing.T) domain.Theme {
+	categoryID := categoryUUIDStr
+	theme, err := domain.NewThemeWithID(themeUUIDStr, "Test Theme", trackUUIDStr, uuidStr, "Test description", 0, 30, &categoryID)
+	require.NoError(t, err)
+	return theme
+}
+
+// newEventBusMock returns an event bus that expects the events of the given types to be
+// published once, in that order.
+func newEventBusMock(t *testing.T, types ...event.Type) *eventmocks.Bus {
+	eventBusMock := new(eventmocks.Bus)
+	eventBusMock.On("Publish", mock.Anything, mock.MatchedBy(func(events []event.Event) bool {
+		if len(events) != len(types) {
+			return false
+		}
+		for i, e := range events {
+			if e.Type() != types[i] {
+				return false
+			}
+		}
+		return true
+	})).Return(nil).Once()
+	t.Cleanup(func() { eventBusMock.AssertExpectations(t) })
+	return eventBusMock
+}
@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"slices"
+)
+
+var ErrInvalidRoleName = errors.New("invalid role name")
+var ErrInvalidPermission = errors.New("invalid permission")
+var ErrRoleNotFound = errors.New("role not found")
+
+// Permission is an action a role may be allowed to take. Every protected route requires
+// one.
+type Permission string
+
+const (
+	// PermissionAnnotate allows adding, updating and removing theme occurrences (tracks_themes).
+	PermissionAnnotate Permission = "tracks_themes:write"
+	// PermissionEditDescriptions allows editing the descriptions of themes and groups.
+	PermissionEditDescriptions Permission = "descriptions:write"
+	// PermissionCreateTracks allows creating tracks.
+	PermissionCreateTracks Permission = "tracks:create"
+	// PermissionCreateThemes allows creating themes.
+	PermissionCreateThemes Permission = "themes:create"
+	// PermissionManageCatalogue allows creating, updating, deleting and restoring movies,
+	// groups and categories, updating, deleting and restoring tracks and themes, and
+	// listing the trash. Creating tracks and themes takes the two permissions above.
+	PermissionManageCatalogue Permission = "catalogue:write"
+	// PermissionPurge allows deleting the entries of the trash for good.
+	PermissionPurge Permission = "trash:purge"
+	// PermissionManageUsers allows creating, listing, reading, updating and deleting users,
+	// changing their role and revoking their sessions.
+	PermissionManageUsers Permission = "users:manage"
+	// PermissionReadAudit allows reading the audit log.
+	PermissionReadAudit Permission = "audit:read"
+	// PermissionManageWebhooks allows subscribing, updating and deleting webhooks and
+	// reading their deliveries.
+	PermissionManageWebhooks Permission = "webhooks:manage"
+)
+
+var knownPermissions = []Permission{
+	PermissionAnnotate, PermissionEditDescriptions, PermissionCreateTracks, PermissionCreateThemes,
+	PermissionManageCatalogue, PermissionPurge, PermissionManageUsers, PermissionReadAudit,
+	PermissionManageWebhooks,
+}
+
+func NewPermission(value string) (Permission, error) {
+	permission := Permission(value)
+	if !slices.Contains(knownPermissions, permission) {
+		return "", ErrInvalidPermission
+	}
+	return permission, nil
+}
+
+func (p Permission) String() string {
+	return string(p)
+}
+
+// Names of the roles every storage starts with.
+const (
+	RoleViewer  = "viewer"
+	RoleEditor  = "editor"
+	RoleCurator = "curator"
+	RoleAdmin   = "admin"
+)
+
+// DefaultRoles returns the roles every storage starts with: viewers can only sign in,
+// editors annotate the themes heard in the tracks and edit descriptions, curators also
+// create tracks and themes, and admins also manage the rest of the catalogue, users,
+// webhooks, the audit log and the trash.
+func DefaultRoles() []Role {
+	editor := []Permission{PermissionAnnotate, PermissionEditDescriptions}
+	curator := append(slices.Clone(editor), PermissionCreateTracks, PermissionCreateThemes)
+	admin := append(slices.Clone(curator), PermissionManageCatalogue, PermissionPurge, PermissionManageUsers, PermissionReadAudit, PermissionManageWebhooks)
+
+	return []Role{
+		{name: RoleName{value: RoleViewer}},
+		{name: RoleName{value: RoleEditor}, permissions: editor},
+		{name: RoleName{value: RoleCurator}, permissions: curator},
+		{name: RoleName{value: RoleAdmin}, permissions: admin},
+	}
+}
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z_]*$`)
+
+// RoleName is the name a role is referenced by, in lowercase.
+type RoleName struct {
+	value string
+}
+
+func NewRoleName(value string) (RoleName, error) {
+	if !roleNamePattern.MatchString(value) {
+		return RoleName{}, ErrInvalidRoleName
+	}
+
+	return RoleName{
+		value: value,
+	}, nil
+}
+
+func (name RoleName) String() string {
+	return name.value
+}
+
+// RoleRepository is the interface for the roles users are given.
+type RoleRepository interface {
+	Find(ctx context.Context, name RoleName) (Role, error)
+}
+
+//go:generate mockery --case=snake --outpkg=storagemocks --output=platform/storage/storagemocks --name=RoleRepository
+
+// Role is a named set of permissions. Every user has one.
+type Role struct {
+	name        RoleName
+	permissions []Permission
+}
+
+// NewRole creates a Role. Repeated permissions are only kept once.
+func NewRole(name string, permissions []string) (Role, error) {
+	nameVO, err := NewRoleName(name)
+	if err != nil {
+		return Role{}, err
+	}
+
+	var permissionVOs []Permission
+	for _, value := range permissions {
+		permission, err := NewPermission(value)
+		if err != nil {
+			return Role{}, err
+		}
+		if !slices.Contains(permissionVOs, permission) {
+			permissionVOs = append(permissionVOs, permission)
+		}
+	}
+
+	return Role{
+		name:        nameVO,
+		permissions: permissionVOs,
+	}, nil
+}
+
+func (r Role) Name() RoleName {
+	return r.name
+}
+
+func (r Role) Permissions() []Permission {
+	return slices.Clone(r.permissions)
+}
+
+// Can tells whether the role grants the permission.
+func (r Role) Can(permission Permission) bool {
+	return slices.Contains(r.permissions, permission)
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+var ErrInvalidSearchTerm = errors.New("invalid search term")
+
+// MaxSearchTermLength is the longest search term a client can send.
+const MaxSearchTermLength = 100
+
+// SearchTerm is the free text a user searches the catalogue with.
+type SearchTerm struct {
+	value string
+}
+
+func NewSearchTerm(value string) (SearchTerm, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || len(value) > MaxSearchTermLength {
+		return SearchTerm{}, ErrInvalidSearchTerm
+	}
+	return SearchTerm{value: value}, nil
+}
+
+func (t SearchTerm) String() string {
+	return t.value
+}
+
+// SearchResult is a catalogue entry matching a search term. Its type is one of "theme",
+// "track", "group" or "movie"; its snippet is HTML escaped, with the matching words
+// wrapped in <mark> tags.
+type SearchResult struct {
+	resultType string
+	id         string
+	name       string
+	snippet    string
+	rank       float64
+}
+
+func NewSearchResult(resultType, id, name, snippet string, rank float64) SearchResult {
+	return SearchResult{
+		resultType: resultType,
+		id:         id,
+		name:       name,
+		snippet:    snippet,
+		rank:       rank,
+	}
+}
+
+func (r SearchResult) Type() string {
+	return r.resultType
+}
+
+func (r SearchResult) ID() string {
+	return r.id
+}
+
+func (r SearchResult) Name() string {
+	return r.name
+}
+
+func (r SearchResult) Snippet() string {
+	return r.snippet
+}
+
+// Rank tells how well the entry matches; the higher, the better.
+func (r SearchResult) Rank() float64 {
+	return r.rank
+}
+
+// SearchRepository finds themes, tracks, groups and movies matching a search term,
+// best matches first.
+type SearchRepository interface {
+	Search(ctx context.Context, term SearchTerm, limit int) ([]SearchResult, error)
+}
+
+//go:generate mockery --case=snake --outpkg=storagemocks --output=platform/storage/storagemocks --name=SearchRepository
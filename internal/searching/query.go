@@ -0,0 +1,44 @@
+package searching
+
+import (
+	"context"
+
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/query"
+)
+
+const SearchQueryType = "query.searching.search"
+
+type SearchQuery struct {
+	Q     string
+	Limit int
+}
+
+func NewSearchQuery(q string, limit int) SearchQuery {
+	return SearchQuery{
+		Q:     q,
+		Limit: limit,
+	}
+}
+
+func (q SearchQuery) Type() query.Type {
+	return SearchQueryType
+}
+
+type SearchQueryHandler struct {
+	searchService SearchService
+}
+
+func NewSearchQueryHandler(searchService SearchService) SearchQueryHandler {
+	return SearchQueryHandler{
+		searchService: searchService,
+	}
+}
+
+func (h SearchQueryHandler) Handle(ctx context.Context, query query.Query) (any, error) {
+	q, ok := query.(SearchQuery)
+	if !ok {
+		return nil, nil
+	}
+
+	return h.searchService.Search(ctx, q.Q, q.Limit)
+}
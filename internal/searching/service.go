@@ -0,0 +1,44 @@
+package searching
+
+import (
+	"context"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
+)
+
+type SearchService struct {
+	searchRepository domain.SearchRepository
+}
+
+func NewSearchService(searchRepository domain.SearchRepository) SearchService {
+	return SearchService{
+		searchRepository: searchRepository,
+	}
+}
+
+func (s SearchService) Search(ctx context.Context, q string, limit int) (dto.SearchResponse, error) {
+	term, err := domain.NewSearchTerm(q)
+	if err != nil {
+		return dto.SearchResponse{}, err
+	}
+
+	page, err := domain.NewPageRequest(limit, "")
+	if err != nil {
+		return dto.SearchResponse{}, err
+	}
+
+	results, err := s.searchRepository.Search(ctx, term, page.Limit())
+	if err != nil {
+		return dto.SearchResponse{}, err
+	}
+
+	response := dto.SearchResponse{
+		Query:   term.String(),
+		Results: make([]dto.SearchResult, 0, len(results)),
+	}
+	for _, result := range results {
+		response.Results = append(response.Results, dto.NewSearchResult(result))
+	}
+	return response, nil
+}
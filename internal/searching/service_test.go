@@ -0,0 +1,77 @@
+package searching
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/storage/storagemocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSearchServiceSearchInvalidTerm(t *testing.T) {
+	searchRepositoryMock := new(storagemocks.SearchRepository)
+	defer searchRepositoryMock.AssertExpectations(t)
+
+	searchService := NewSearchService(searchRepositoryMock)
+
+	_, err := searchService.Search(context.Background(), "   ", 0)
+	assert.ErrorIs(t, err, domain.ErrInvalidSearchTerm)
+}
+
+func TestSearchServiceSearchInvalidLimit(t *testing.T) {
+	searchRepositoryMock := new(storagemocks.SearchRepository)
+	defer searchRepositoryMock.AssertExpectations(t)
+
+	searchService := NewSearchService(searchRepositoryMock)
+
+	_, err := searchService.Search(context.Background(), "Shire", domain.MaxPageLimit+1)
+	assert.ErrorIs(t, err, domain.ErrInvalidPageLimit)
+}
+
+func TestSearchServiceSearchRepositoryError(t *testing.T) {
+	searchRepositoryMock := new(storagemocks.SearchRepository)
+	searchRepositoryMock.On("Search", mock.Anything, mock.Anything, domain.DefaultPageLimit).Return(nil, errors.New("repository error")).Once()
+	defer searchRepositoryMock.AssertExpectations(t)
+
+	searchService := NewSearchService(searchRepositoryMock)
+
+	_, err := searchService.Search(context.Background(), "Shire", 0)
+	assert.Error(t, err)
+}
+
+func TestSearchServiceSearchSuccess(t *testing.T) {
+	results := []domain.SearchResult{
+		domain.NewSearchResult("theme", "123e4567-e89b-12d3-a456-426614174000", "The Shire", "The <mark>Shire</mark>", 0.6),
+	}
+	searchRepositoryMock := new(storagemocks.SearchRepository)
+	searchRepositoryMock.On("Search", mock.Anything, mock.MatchedBy(func(term domain.SearchTerm) bool {
+		return term.String() == "Shire"
+	}), 10).Return(results, nil).Once()
+	defer searchRepositoryMock.AssertExpectations(t)
+
+	searchService := NewSearchService(searchRepositoryMock)
+
+	response, err := searchService.Search(context.Background(), " Shire ", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, "Shire", response.Query)
+	assert.Equal(t, []dto.SearchResult{
+		{Type: "theme", ID: "123e4567-e89b-12d3-a456-426614174000", Name: "The Shire", Snippet: "The <mark>Shire</mark>", Rank: 0.6},
+	}, response.Results)
+}
+
+func TestSearchServiceSearchNoResults(t *testing.T) {
+	searchRepositoryMock := new(storagemocks.SearchRepository)
+	searchRepositoryMock.On("Search", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+	defer searchRepositoryMock.AssertExpectations(t)
+
+	searchService := NewSearchService(searchRepositoryMock)
+
+	response, err := searchService.Search(context.Background(), "Mordor", 0)
+	assert.NoError(t, err)
+	assert.NotNil(t, response.Results)
+	assert.Empty(t, response.Results)
+}
@@ -0,0 +1,165 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidSessionID = errors.New("invalid session ID")
+var ErrInvalidSession = errors.New("invalid session")
+var ErrSessionNotFound = errors.New("session not found")
+var ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+
+// SessionID identifies a login. Every access token issued for it carries it as its
+// sid claim.
+type SessionID struct {
+	value string
+}
+
+func NewSessionID() (SessionID, error) {
+	v, err := uuid.NewRandom()
+	if err != nil {
+		return SessionID{}, fmt.Errorf("%w: %w", ErrInvalidSessionID, err)
+	}
+
+	return SessionID{
+		value: v.String(),
+	}, nil
+}
+
+func NewSessionIDFromString(id string) (SessionID, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return SessionID{}, ErrInvalidSessionID
+	}
+
+	return SessionID{
+		value: id,
+	}, nil
+}
+
+func (id SessionID) String() string {
+	return id.value
+}
+
+// SessionRepository is the interface for the sessions of the users.
+type SessionRepository interface {
+	Save(ctx context.Context, session Session) error
+	// FindByTokenHash returns the session whose current or previous refresh token has the
+	// given hash.
+	FindByTokenHash(ctx context.Context, tokenHash string) (Session, error)
+	// Rotate stores a rotated session, provided its previous refresh token is still the
+	// current one. It returns ErrInvalidRefreshToken when another rotation got there first.
+	Rotate(ctx context.Context, session Session) error
+	Revoke(ctx context.Context, id SessionID, revokedAt time.Time) error
+	// RevokeByUser revokes the sessions of the user, except the one with the ID except,
+	// which is left zero to revoke them all.
+	RevokeByUser(ctx context.Context, userID UserID, except SessionID, revokedAt time.Time) error
+	// IsRevoked tells whether the session was revoked. A session that no longer exists,
+	// as its user was deleted, counts as revoked.
+	IsRevoked(ctx context.Context, id SessionID) (bool, error)
+}
+
+//go:generate mockery --case=snake --outpkg=storagemocks --output=platform/storage/storagemocks --name=SessionRepository
+
+// Session is the login of a user, kept alive by a refresh token that is replaced every
+// time it is used. Only the SHA-256 hashes of the tokens are stored.
+type Session struct {
+	id                SessionID
+	userID            UserID
+	tokenHash         string
+	previousTokenHash string // Empty until the first rotation
+	expiresAt         time.Time
+	revokedAt         time.Time // Zero while the session is active
+}
+
+// NewSession creates a new Session for the user, with a fresh ID.
+func NewSession(userID UserID, tokenHash string, expiresAt time.Time) (Session, error) {
+	id, err := NewSessionID()
+	if err != nil {
+		return Session{}, err
+	}
+
+	return NewSessionWithID(id.String(), userID.String(), tokenHash, "", expiresAt, time.Time{})
+}
+
+// NewSessionWithID creates a Session from stored values.
+func NewSessionWithID(id, userID, tokenHash, previousTokenHash string, expiresAt, revokedAt time.Time) (Session, error) {
+	idVO, err := NewSessionIDFromString(id)
+	if err != nil {
+		return Session{}, err
+	}
+
+	userIDVO, err := NewUserIDFromString(userID)
+	if err != nil {
+		return Session{}, err
+	}
+
+	if tokenHash == "" || expiresAt.IsZero() {
+		return Session{}, ErrInvalidSession
+	}
+
+	return Session{
+		id:                idVO,
+		userID:            userIDVO,
+		tokenHash:         tokenHash,
+		previousTokenHash: previousTokenHash,
+		expiresAt:         expiresAt.UTC(),
+		revokedAt:         revokedAt.UTC(),
+	}, nil
+}
+
+func (s Session) ID() SessionID {
+	return s.id
+}
+
+func (s Session) UserID() UserID {
+	return s.userID
+}
+
+func (s Session) TokenHash() string {
+	return s.tokenHash
+}
+
+func (s Session) PreviousTokenHash() string {
+	return s.previousTokenHash
+}
+
+func (s Session) ExpiresAt() time.Time {
+	return s.expiresAt
+}
+
+func (s Session) RevokedAt() time.Time {
+	return s.revokedAt
+}
+
+// Active tells whether the session can still be refreshed at the given time.
+func (s Session) Active(now time.Time) bool {
+	return s.revokedAt.IsZero() && now.Before(s.expiresAt)
+}
+
+// Rotate returns the session with its refresh token replaced by the one with the given
+// hash, which expires at expiresAt.
+func (s Session) Rotate(tokenHash string, expiresAt time.Time) (Session, error) {
+	if tokenHash == "" || expiresAt.IsZero() {
+		return Session{}, ErrInvalidSession
+	}
+
+	s.previousTokenHash = s.tokenHash
+	s.tokenHash = tokenHash
+	s.expiresAt = expiresAt.UTC()
+	return s, nil
+}
+
+// RevocationRepository is the interface for the revocation list of the access tokens,
+// by their jti claim. A token only needs to stay listed until it expires.
+type RevocationRepository interface {
+	Revoke(ctx context.Context, jti string, expiresAt time.Time) error
+	IsRevoked(ctx context.Context, jti string) (bool, error)
+	Prune(ctx context.Context, now time.Time) error // Drops the tokens expired by now
+}
+
+//go:generate mockery --case=snake --outpkg=storagemocks --output=platform/storage/storagemocks --name=RevocationRepository
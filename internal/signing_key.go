@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrInvalidSigningKey = errors.New("invalid signing key")
+var ErrInvalidSigningAlgorithm = errors.New("invalid signing algorithm, expected RS256 or EdDSA")
+
+// SigningAlgorithm is the JWS algorithm a signing key is used with.
+type SigningAlgorithm string
+
+const (
+	SigningAlgorithmRS256 SigningAlgorithm = "RS256"
+	SigningAlgorithmEdDSA SigningAlgorithm = "EdDSA"
+)
+
+func NewSigningAlgorithm(value string) (SigningAlgorithm, error) {
+	switch algorithm := SigningAlgorithm(value); algorithm {
+	case SigningAlgorithmRS256, SigningAlgorithmEdDSA:
+		return algorithm, nil
+	default:
+		return "", ErrInvalidSigningAlgorithm
+	}
+}
+
+func (a SigningAlgorithm) String() string {
+	return string(a)
+}
+
+// SigningKeyRepository is the interface for the keys the access tokens are signed with.
+type SigningKeyRepository interface {
+	Save(ctx context.Context, key SigningKey) error
+	// FindAll returns every key, the one that activates first first.
+	FindAll(ctx context.Context) ([]SigningKey, error)
+	Delete(ctx context.Context, id string) error
+}
+
+//go:generate mockery --case=snake --outpkg=storagemocks --output=platform/storage/storagemocks --name=SigningKeyRepository
+
+// SigningKey is a private key the access tokens are signed with. Its ID is the kid
+// header of the tokens, which tells verifiers what public key to check them with. A key
+// is published ahead of activatesAt, and only signs from then on, until a newer key
+// activates.
+type SigningKey struct {
+	id          string
+	algorithm   SigningAlgorithm
+	privateKey  []byte // PKCS #8, ASN.1 DER form
+	activatesAt time.Time
+}
+
+// NewSigningKey creates a SigningKey from its ID, the kid of the tokens it signs.
+func NewSigningKey(id string, algorithm SigningAlgorithm, privateKey []byte, activatesAt time.Time) (SigningKey, error) {
+	if id == "" || len(privateKey) == 0 || activatesAt.IsZero() {
+		return SigningKey{}, ErrInvalidSigningKey
+	}
+
+	if _, err := NewSigningAlgorithm(string(algorithm)); err != nil {
+		return SigningKey{}, err
+	}
+
+	return SigningKey{
+		id:          id,
+		algorithm:   algorithm,
+		privateKey:  privateKey,
+		activatesAt: activatesAt.UTC(),
+	}, nil
+}
+
+func (k SigningKey) ID() string {
+	return k.id
+}
+
+func (k SigningKey) Algorithm() SigningAlgorithm {
+	return k.algorithm
+}
+
+func (k SigningKey) PrivateKey() []byte {
+	return k.privateKey
+}
+
+func (k SigningKey) ActivatesAt() time.Time {
+	return k.activatesAt
+}
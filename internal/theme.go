@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+
 	"github.com/google/uuid"
 )
 
@@ -107,9 +109,16 @@ func (f FirstHeardEnd) Int() int {
 type ThemeRepository interface {
 	Save(ctx context.Context, theme Theme) error
 	Find(ctx context.Context, id ThemeID) (Theme, error)
+	FindInTrash(ctx context.Context, id ThemeID) (Theme, error) // Finds the theme only if it is in the trash
 	FindAll(ctx context.Context) ([]Theme, error)
+	FindPage(ctx context.Context, filter ThemeFilter, page PageRequest) ([]Theme, *Cursor, error)
 	FindByGroup(ctx context.Context, groupID GroupID) ([]Theme, error)
-	Delete(ctx context.Context, id ThemeID) error
+	FindByCategory(ctx context.Context, categoryID CategoryID) ([]Theme, error)
+	FindByFirstHeard(ctx context.Context, trackID TrackID) ([]Theme, error)
+	FindByMovie(ctx context.Context, movieID MovieID) ([]Theme, error) // Themes first heard in a track of the movie
+	Delete(ctx context.Context, id ThemeID) error                      // Moves the theme to the trash
+	Restore(ctx context.Context, id ThemeID) error                     // Takes the theme out of the trash
+	Purge(ctx context.Context, id ThemeID) error                       // Deletes the theme for good, once in the trash
 	Update(ctx context.Context, theme Theme) error
 }
 
@@ -124,6 +133,8 @@ type Theme struct {
 	firstHeardStart FirstHeardStart
 	firstHeardEnd   FirstHeardEnd
 	categoryID      *CategoryID // Optional
+
+	events []event.Event
 }
 
 func NewTheme(name, firstHeard, groupID, description string, firstHeardStart, firstHeardEnd int, categoryID *string) (Theme, error) {
@@ -172,7 +183,7 @@ func NewTheme(name, firstHeard, groupID, description string, firstHeardStart, fi
 		categoryIDVO = &categoryValue
 	}
 
-	return Theme{
+	theme := Theme{
 		id:              idVO,
 		name:            nameVO,
 		firstHeard:      firstHeardVO,
@@ -181,7 +192,11 @@ func NewTheme(name, firstHeard, groupID, description string, firstHeardStart, fi
 		firstHeardStart: firstHeardStartVO,
 		firstHeardEnd:   firstHeardEndVO,
 		categoryID:      categoryIDVO,
-	}, nil
+	}
+
+	theme.Record(NewThemeCreatedEvent(theme.ID().String(), theme.Name().String(), theme.GroupID().String()))
+
+	return theme, nil
 }
 
 func NewThemeWithID(id, name, firstHeard, groupID, description string, firstHeardStart, firstHeardEnd int, categoryID *string) (Theme, error) {
@@ -262,6 +277,17 @@ func (t Theme) Description() Description {
 	return t.description
 }
 
+// WithDescription returns a copy of the theme with another description.
+func (t Theme) WithDescription(description string) (Theme, error) {
+	descriptionVO, err := NewDescription(description)
+	if err != nil {
+		return Theme{}, err
+	}
+
+	t.description = descriptionVO
+	return t, nil
+}
+
 func (t Theme) CategoryID() *CategoryID {
 	return t.categoryID
 }
@@ -273,3 +299,33 @@ func (t Theme) FirstHeardStart() FirstHeardStart {
 func (t Theme) FirstHeardEnd() FirstHeardEnd {
 	return t.firstHeardEnd
 }
+
+// FirstHeardOccurrence returns the span the theme is first heard in as a track theme.
+// It returns false when the theme has no span, that is, when it does not end after it
+// starts.
+func (t Theme) FirstHeardOccurrence() (TrackTheme, bool) {
+	if t.firstHeardEnd.Int() <= t.firstHeardStart.Int() {
+		return TrackTheme{}, false
+	}
+
+	return TrackTheme{
+		trackID:     t.firstHeard,
+		themeID:     t.id,
+		startSecond: StartSecond{value: t.firstHeardStart.Int()},
+		endSecond:   EndSecond{value: t.firstHeardEnd.Int()},
+		isVariant:   NewIsVariant(false),
+	}, true
+}
+
+// Record adds an event to the theme's event list.
+func (t *Theme) Record(event event.Event) {
+	t.events = append(t.events, event)
+}
+
+// PullEvents returns the events recorded for the theme and clears the event list.
+func (t *Theme) PullEvents() []event.Event {
+	events := t.events
+	t.events = nil
+
+	return events
+}
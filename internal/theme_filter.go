@@ -0,0 +1,104 @@
+package domain
+
+import "errors"
+
+var ErrInvalidThemeSort = errors.New("invalid theme sort")
+
+// ThemeSort is the field a list of themes is ordered by.
+type ThemeSort string
+
+const (
+	ThemeSortCreatedAt       ThemeSort = "created_at"
+	ThemeSortName            ThemeSort = "name"
+	ThemeSortFirstHeardStart ThemeSort = "first_heard_start"
+)
+
+// NewThemeSort validates a sort field. An empty value sorts by creation date.
+func NewThemeSort(value string) (ThemeSort, error) {
+	switch sort := ThemeSort(value); sort {
+	case "":
+		return ThemeSortCreatedAt, nil
+	case ThemeSortCreatedAt, ThemeSortName, ThemeSortFirstHeardStart:
+		return sort, nil
+	default:
+		return "", ErrInvalidThemeSort
+	}
+}
+
+func (s ThemeSort) String() string {
+	return string(s)
+}
+
+// ThemeFilter narrows and orders a list of themes. Every criterion is optional, and
+// the ones that are set must all match.
+type ThemeFilter struct {
+	groupID    *GroupID
+	categoryID *CategoryID
+	movieID    *MovieID // Matches the movie of the track the theme is first heard in
+	name       string   // Case-insensitive substring of the theme name
+	sort       ThemeSort
+}
+
+// NewThemeFilter creates a new ThemeFilter instance. Empty values leave the matching
+// criterion unset.
+func NewThemeFilter(groupID, categoryID, movieID, name, sort string) (ThemeFilter, error) {
+	var filter ThemeFilter
+
+	if groupID != "" {
+		groupIDVO, err := NewGroupIDFromString(groupID)
+		if err != nil {
+			return ThemeFilter{}, err
+		}
+		filter.groupID = &groupIDVO
+	}
+
+	if categoryID != "" {
+		categoryIDVO, err := NewCategoryIDFromString(categoryID)
+		if err != nil {
+			return ThemeFilter{}, err
+		}
+		filter.categoryID = &categoryIDVO
+	}
+
+	if movieID != "" {
+		movieIDVO, err := NewMovieIDFromString(movieID)
+		if err != nil {
+			return ThemeFilter{}, err
+		}
+		filter.movieID = &movieIDVO
+	}
+
+	sortVO, err := NewThemeSort(sort)
+	if err != nil {
+		return ThemeFilter{}, err
+	}
+
+	filter.name = name
+	filter.sort = sortVO
+
+	return filter, nil
+}
+
+func (f ThemeFilter) GroupID() *GroupID {
+	return f.groupID
+}
+
+func (f ThemeFilter) CategoryID() *CategoryID {
+	return f.categoryID
+}
+
+func (f ThemeFilter) MovieID() *MovieID {
+	return f.movieID
+}
+
+func (f ThemeFilter) Name() string {
+	return f.name
+}
+
+// Sort returns the field to order by, which defaults to the creation date.
+func (f ThemeFilter) Sort() ThemeSort {
+	if f.sort == "" {
+		return ThemeSortCreatedAt
+	}
+	return f.sort
+}
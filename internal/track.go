@@ -3,6 +3,9 @@ package domain
 import (
 	"context"
 	"fmt"
+	"slices"
+
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
 
 	"github.com/google/uuid"
 )
@@ -11,6 +14,21 @@ var ErrInvalidTrackID = fmt.Errorf("invalid track ID")
 var ErrInvalidTrackName = fmt.Errorf("invalid track name")
 var ErrInvalidSpotifyURL = fmt.Errorf("invalid Spotify URL")
 var ErrTrackNotFound = fmt.Errorf("track not found")
+var ErrInvalidTrackNumber = fmt.Errorf("invalid track number")
+var ErrInvalidDiscNumber = fmt.Errorf("invalid disc number")
+var ErrInvalidTrackEdition = fmt.Errorf("invalid track edition")
+var ErrInvalidTrackDuration = fmt.Errorf("invalid track duration")
+var ErrTrackPositionAlreadyExists = fmt.Errorf("track position already exists")
+
+// Album editions a track can be released on.
+const (
+	TrackEditionTheatrical         = "theatrical"
+	TrackEditionCompleteRecordings = "complete_recordings"
+)
+
+// trackEditions lists the album editions in the order their tracks are listened to: the
+// theatrical soundtrack before The Complete Recordings.
+var trackEditions = []string{TrackEditionTheatrical, TrackEditionCompleteRecordings}
 
 type TrackID struct {
 	value string
@@ -89,30 +107,147 @@ func (u *SpotifyURL) AsStringPtr() *string {
 	return &u.value
 }
 
+// TrackNumber is the position of a track on its disc.
+type TrackNumber struct {
+	value int
+}
+
+func NewTrackNumber(value int) (TrackNumber, error) {
+	if value < 1 {
+		return TrackNumber{}, ErrInvalidTrackNumber
+	}
+
+	return TrackNumber{
+		value: value,
+	}, nil
+}
+
+func (n TrackNumber) Int() int {
+	return n.value
+}
+
+// DiscNumber is the disc of the album a track is on.
+type DiscNumber struct {
+	value int
+}
+
+func NewDiscNumber(value int) (DiscNumber, error) {
+	if value < 1 {
+		return DiscNumber{}, ErrInvalidDiscNumber
+	}
+
+	return DiscNumber{
+		value: value,
+	}, nil
+}
+
+func (n DiscNumber) Int() int {
+	return n.value
+}
+
+// TrackEdition is the album a track was released on: the theatrical soundtrack or
+// The Complete Recordings.
+type TrackEdition struct {
+	value string
+}
+
+func NewTrackEdition(value string) (TrackEdition, error) {
+	switch value {
+	case TrackEditionTheatrical, TrackEditionCompleteRecordings:
+		return TrackEdition{
+			value: value,
+		}, nil
+	default:
+		return TrackEdition{}, ErrInvalidTrackEdition
+	}
+}
+
+func (e TrackEdition) String() string {
+	return e.value
+}
+
+// Rank returns the position of the edition in album order, so that the tracks can be
+// sorted by it.
+func (e TrackEdition) Rank() int {
+	return slices.Index(trackEditions, e.value)
+}
+
+// TrackDuration is the length of a track in seconds.
+type TrackDuration struct {
+	seconds int
+}
+
+func NewTrackDuration(seconds int) (TrackDuration, error) {
+	if seconds < 1 {
+		return TrackDuration{}, ErrInvalidTrackDuration
+	}
+
+	return TrackDuration{
+		seconds: seconds,
+	}, nil
+}
+
+func (d TrackDuration) Seconds() int {
+	return d.seconds
+}
+
 type TrackRepository interface {
 	Save(ctx context.Context, track Track) error
 	Find(ctx context.Context, id TrackID) (Track, error)
+	FindInTrash(ctx context.Context, id TrackID) (Track, error) // Finds the track only if it is in the trash
+	// FindForUpdate finds a track like Find. Within a transaction, the track stays locked
+	// until it ends, so that the writes to its occurrences run one at a time.
+	FindForUpdate(ctx context.Context, id TrackID) (Track, error)
 	FindAll(ctx context.Context) ([]Track, error)
+	FindPage(ctx context.Context, page PageRequest) ([]Track, *Cursor, error)
 	FindByMovie(ctx context.Context, movieID MovieID) ([]Track, error)
-	Delete(ctx context.Context, id TrackID) error
+	Delete(ctx context.Context, id TrackID) error  // Moves the track to the trash
+	Restore(ctx context.Context, id TrackID) error // Takes the track out of the trash
+	Purge(ctx context.Context, id TrackID) error   // Deletes the track for good, once in the trash
 	Update(ctx context.Context, track Track) error
 }
 
 //go:generate mockery --case=snake --outpkg=storagemocks --output=platform/storage/storagemocks --name=TrackRepository
 
 type Track struct {
-	id         TrackID
-	name       TrackName
-	movieID    MovieID
-	spotifyURL *SpotifyURL
+	id          TrackID
+	name        TrackName
+	movieID     MovieID
+	spotifyURL  *SpotifyURL
+	trackNumber TrackNumber
+	discNumber  DiscNumber
+	edition     TrackEdition
+	duration    TrackDuration
+
+	events []event.Event
 }
 
-func NewTrack(name, movieID string, spotifyURL *string) (Track, error) {
+func NewTrack(name, movieID string, spotifyURL *string, trackNumber, discNumber int, edition string, durationSeconds int) (Track, error) {
 	idVO, err := NewTrackID()
 	if err != nil {
 		return Track{}, err
 	}
 
+	track, err := newTrack(idVO, name, movieID, spotifyURL, trackNumber, discNumber, edition, durationSeconds)
+	if err != nil {
+		return Track{}, err
+	}
+
+	track.Record(NewTrackCreatedEvent(track.ID().String(), track.Name().String(), track.MovieID().String()))
+
+	return track, nil
+}
+
+func NewTrackWithID(id, name, movieID string, spotifyURL *string, trackNumber, discNumber int, edition string, durationSeconds int) (Track, error) {
+	idVO, err := NewTrackIDFromString(id)
+	if err != nil {
+		return Track{}, err
+	}
+
+	return newTrack(idVO, name, movieID, spotifyURL, trackNumber, discNumber, edition, durationSeconds)
+}
+
+func newTrack(id TrackID, name, movieID string, spotifyURL *string, trackNumber, discNumber int, edition string, durationSeconds int) (Track, error) {
 	nameVO, err := NewTrackName(name)
 	if err != nil {
 		return Track{}, err
@@ -132,46 +267,35 @@ func NewTrack(name, movieID string, spotifyURL *string) (Track, error) {
 		spotifyURLVO = &spotifyURLValue
 	}
 
-	track := Track{
-		id:         idVO,
-		name:       nameVO,
-		movieID:    movieIDVO,
-		spotifyURL: spotifyURLVO,
-	}
-
-	return track, nil
-}
-
-func NewTrackWithID(id, name, movieID string, spotifyURL *string) (Track, error) {
-	idVO, err := NewTrackIDFromString(id)
+	trackNumberVO, err := NewTrackNumber(trackNumber)
 	if err != nil {
 		return Track{}, err
 	}
 
-	nameVO, err := NewTrackName(name)
+	discNumberVO, err := NewDiscNumber(discNumber)
 	if err != nil {
 		return Track{}, err
 	}
 
-	movieIDVO, err := NewMovieIDFromString(movieID)
+	editionVO, err := NewTrackEdition(edition)
 	if err != nil {
 		return Track{}, err
 	}
 
-	var spotifyURLVO *SpotifyURL
-	if spotifyURL != nil {
-		spotifyURLValue, err := NewSpotifyURL(*spotifyURL)
-		if err != nil {
-			return Track{}, err
-		}
-		spotifyURLVO = &spotifyURLValue
+	durationVO, err := NewTrackDuration(durationSeconds)
+	if err != nil {
+		return Track{}, err
 	}
 
 	track := Track{
-		id:         idVO,
-		name:       nameVO,
-		movieID:    movieIDVO,
-		spotifyURL: spotifyURLVO,
+		id:          id,
+		name:        nameVO,
+		movieID:     movieIDVO,
+		spotifyURL:  spotifyURLVO,
+		trackNumber: trackNumberVO,
+		discNumber:  discNumberVO,
+		edition:     editionVO,
+		duration:    durationVO,
 	}
 
 	return track, nil
@@ -192,3 +316,32 @@ func (t Track) MovieID() MovieID {
 func (t Track) SpotifyURL() *SpotifyURL {
 	return t.spotifyURL
 }
+
+func (t Track) TrackNumber() TrackNumber {
+	return t.trackNumber
+}
+
+func (t Track) DiscNumber() DiscNumber {
+	return t.discNumber
+}
+
+func (t Track) Edition() TrackEdition {
+	return t.edition
+}
+
+func (t Track) Duration() TrackDuration {
+	return t.duration
+}
+
+// Record adds an event to the track's event list.
+func (t *Track) Record(event event.Event) {
+	t.events = append(t.events, event)
+}
+
+// PullEvents returns the events recorded for the track and clears the event list.
+func (t *Track) PullEvents() []event.Event {
+	events := t.events
+	t.events = nil
+
+	return events
+}
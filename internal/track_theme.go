@@ -3,12 +3,16 @@ package domain
 import (
 	"context"
 	"fmt"
+
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
 )
 
 var ErrInvalidStartSecond = fmt.Errorf("invalid start second")
 var ErrInvalidEndSecond = fmt.Errorf("invalid end second")
 var ErrEndSecondMustBeGreaterThanStartSecond = fmt.Errorf("end second must be greater than start second")
 var ErrTrackThemeNotFound = fmt.Errorf("track theme not found")
+var ErrEndSecondExceedsTrackDuration = fmt.Errorf("end second exceeds track duration")
+var ErrTrackThemeOverlaps = fmt.Errorf("track theme overlaps an existing occurrence")
 
 type StartSecond struct {
 	value int
@@ -58,6 +62,7 @@ type TrackThemeRepository interface {
 	Save(ctx context.Context, trackTheme TrackTheme) error
 	Find(ctx context.Context, trackID TrackID, themeID ThemeID, startSecond StartSecond) (TrackTheme, error)
 	FindByTrack(ctx context.Context, trackID TrackID) ([]TrackTheme, error)
+	FindByTheme(ctx context.Context, themeID ThemeID) ([]TrackTheme, error)
 	Delete(ctx context.Context, trackID TrackID, themeID ThemeID, startSecond StartSecond) error
 	Update(ctx context.Context, trackTheme TrackTheme) error
 }
@@ -70,6 +75,8 @@ type TrackTheme struct {
 	startSecond StartSecond
 	endSecond   EndSecond
 	isVariant   IsVariant
+
+	events []event.Event
 }
 
 func NewTrackTheme(trackID, themeID string, startSecond, endSecond int, isVariant bool) (TrackTheme, error) {
@@ -127,3 +134,42 @@ func (tt TrackTheme) EndSecond() EndSecond {
 func (tt TrackTheme) IsVariant() IsVariant {
 	return tt.isVariant
 }
+
+// FitsIn checks that the occurrence ends before the track it is heard in does.
+func (tt TrackTheme) FitsIn(track Track) error {
+	if tt.endSecond.Int() > track.Duration().Seconds() {
+		return ErrEndSecondExceedsTrackDuration
+	}
+	return nil
+}
+
+// SameOccurrence reports whether both values identify the same row: the same theme
+// starting at the same second of the same track.
+func (tt TrackTheme) SameOccurrence(other TrackTheme) bool {
+	return tt.trackID == other.trackID &&
+		tt.themeID == other.themeID &&
+		tt.startSecond == other.startSecond
+}
+
+// Overlaps reports whether both occurrences are of the same theme in the same track
+// and share at least one second.
+func (tt TrackTheme) Overlaps(other TrackTheme) bool {
+	if tt.trackID != other.trackID || tt.themeID != other.themeID {
+		return false
+	}
+	return tt.startSecond.Int() < other.endSecond.Int() && other.startSecond.Int() < tt.endSecond.Int()
+}
+
+// Record adds an event to the track theme's event list. A track theme has no identity
+// of its own, so the services that add, update or remove it record its events.
+func (t *TrackTheme) Record(event event.Event) {
+	t.events = append(t.events, event)
+}
+
+// PullEvents returns the events recorded for the track theme and clears the event list.
+func (t *TrackTheme) PullEvents() []event.Event {
+	events := t.events
+	t.events = nil
+
+	return events
+}
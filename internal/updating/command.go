@@ -3,19 +3,149 @@ package updating
 import (
 	"context"
 
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/auditing"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/command"
 )
 
 const (
+	UserCommandType         command.Type = "command.update.user"
+	UserPasswordCommandType command.Type = "command.update.user_password"
+	UserRoleCommandType     command.Type = "command.update.user_role"
+
 	MovieCommandType      command.Type = "command.update.movie"
 	GroupCommandType      command.Type = "command.update.group"
 	CategoryCommandType   command.Type = "command.update.category"
 	TrackCommandType      command.Type = "command.update.track"
 	ThemeCommandType      command.Type = "command.update.theme"
 	TrackThemeCommandType command.Type = "command.update.track_theme"
+	WebhookCommandType    command.Type = "command.update.webhook"
+
+	GroupDescriptionCommandType command.Type = "command.update.group_description"
+	ThemeDescriptionCommandType command.Type = "command.update.theme_description"
 )
 
+type UserCommand struct {
+	id  string
+	dto dto.UserUpdateRequest
+}
+
+func NewUserCommand(id string, dto dto.UserUpdateRequest) UserCommand {
+	return UserCommand{
+		id:  id,
+		dto: dto,
+	}
+}
+
+func (c UserCommand) Type() command.Type {
+	return UserCommandType
+}
+
+func (c UserCommand) AuditTarget() auditing.Target {
+	return auditing.Target{Entity: domain.AuditEntityUser, ID: c.id, Request: c.dto}
+}
+
+type UserCommandHandler struct {
+	service UserService
+}
+
+func NewUserCommandHandler(service UserService) UserCommandHandler {
+	return UserCommandHandler{
+		service: service,
+	}
+}
+
+func (h UserCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	userCmd, ok := cmd.(UserCommand)
+	if !ok {
+		return nil
+	}
+
+	return h.service.UpdateUser(ctx, userCmd.id, userCmd.dto)
+}
+
+type UserPasswordCommand struct {
+	id        string
+	sessionID string // Session the password is changed from, the only one kept
+	dto       dto.UserPasswordRequest
+}
+
+func NewUserPasswordCommand(id, sessionID string, dto dto.UserPasswordRequest) UserPasswordCommand {
+	return UserPasswordCommand{
+		id:        id,
+		sessionID: sessionID,
+		dto:       dto,
+	}
+}
+
+func (c UserPasswordCommand) Type() command.Type {
+	return UserPasswordCommandType
+}
+
+func (c UserPasswordCommand) AuditTarget() auditing.Target {
+	// The passwords are left out of the audit log.
+	return auditing.Target{Entity: domain.AuditEntityUser, ID: c.id}
+}
+
+type UserPasswordCommandHandler struct {
+	service UserService
+}
+
+func NewUserPasswordCommandHandler(service UserService) UserPasswordCommandHandler {
+	return UserPasswordCommandHandler{
+		service: service,
+	}
+}
+
+func (h UserPasswordCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	passwordCmd, ok := cmd.(UserPasswordCommand)
+	if !ok {
+		return nil
+	}
+
+	return h.service.ChangePassword(ctx, passwordCmd.id, passwordCmd.sessionID, passwordCmd.dto)
+}
+
+type UserRoleCommand struct {
+	id  string
+	dto dto.UserRoleRequest
+}
+
+func NewUserRoleCommand(id string, dto dto.UserRoleRequest) UserRoleCommand {
+	return UserRoleCommand{
+		id:  id,
+		dto: dto,
+	}
+}
+
+func (c UserRoleCommand) Type() command.Type {
+	return UserRoleCommandType
+}
+
+func (c UserRoleCommand) AuditTarget() auditing.Target {
+	return auditing.Target{Entity: domain.AuditEntityUser, ID: c.id, Request: c.dto}
+}
+
+type UserRoleCommandHandler struct {
+	service UserService
+}
+
+func NewUserRoleCommandHandler(service UserService) UserRoleCommandHandler {
+	return UserRoleCommandHandler{
+		service: service,
+	}
+}
+
+func (h UserRoleCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	roleCmd, ok := cmd.(UserRoleCommand)
+	if !ok {
+		return nil
+	}
+
+	return h.service.ChangeRole(ctx, roleCmd.id, roleCmd.dto)
+}
+
 type MovieCommand struct {
 	id  string
 	dto dto.MovieUpdateRequest
@@ -32,6 +162,10 @@ func (c MovieCommand) Type() command.Type {
 	return MovieCommandType
 }
 
+func (c MovieCommand) AuditTarget() auditing.Target {
+	return auditing.Target{Entity: domain.AuditEntityMovie, ID: c.id, Request: c.dto}
+}
+
 type MovieCommandHandler struct {
 	service MovieService
 }
@@ -67,6 +201,10 @@ func (c GroupCommand) Type() command.Type {
 	return GroupCommandType
 }
 
+func (c GroupCommand) AuditTarget() auditing.Target {
+	return auditing.Target{Entity: domain.AuditEntityGroup, ID: c.id, Request: c.dto}
+}
+
 type GroupCommandHandler struct {
 	service GroupService
 }
@@ -102,6 +240,10 @@ func (c CategoryCommand) Type() command.Type {
 	return CategoryCommandType
 }
 
+func (c CategoryCommand) AuditTarget() auditing.Target {
+	return auditing.Target{Entity: domain.AuditEntityCategory, ID: c.id, Request: c.dto}
+}
+
 type CategoryCommandHandler struct {
 	service CategoryService
 }
@@ -137,6 +279,10 @@ func (c TrackCommand) Type() command.Type {
 	return TrackCommandType
 }
 
+func (c TrackCommand) AuditTarget() auditing.Target {
+	return auditing.Target{Entity: domain.AuditEntityTrack, ID: c.id, Request: c.dto}
+}
+
 type TrackCommandHandler struct {
 	service TrackService
 }
@@ -172,6 +318,10 @@ func (c ThemeCommand) Type() command.Type {
 	return ThemeCommandType
 }
 
+func (c ThemeCommand) AuditTarget() auditing.Target {
+	return auditing.Target{Entity: domain.AuditEntityTheme, ID: c.id, Request: c.dto}
+}
+
 type ThemeCommandHandler struct {
 	service ThemeService
 }
@@ -205,6 +355,10 @@ func (c TrackThemeCommand) Type() command.Type {
 	return TrackThemeCommandType
 }
 
+func (c TrackThemeCommand) AuditTarget() auditing.Target {
+	return auditing.Target{Entity: domain.AuditEntityTrackTheme, Request: c.dto}
+}
+
 type TrackThemeCommandHandler struct {
 	service TrackThemeService
 }
@@ -223,3 +377,120 @@ func (h TrackThemeCommandHandler) Handle(ctx context.Context, cmd command.Comman
 
 	return h.service.UpdateTrackTheme(ctx, trackThemeCmd.dto)
 }
+
+type GroupDescriptionCommand struct {
+	id  string
+	dto dto.DescriptionUpdateRequest
+}
+
+func NewGroupDescriptionCommand(id string, dto dto.DescriptionUpdateRequest) GroupDescriptionCommand {
+	return GroupDescriptionCommand{
+		id:  id,
+		dto: dto,
+	}
+}
+
+func (c GroupDescriptionCommand) Type() command.Type {
+	return GroupDescriptionCommandType
+}
+
+func (c GroupDescriptionCommand) AuditTarget() auditing.Target {
+	return auditing.Target{Entity: domain.AuditEntityGroup, ID: c.id, Request: c.dto}
+}
+
+type GroupDescriptionCommandHandler struct {
+	service GroupService
+}
+
+func NewGroupDescriptionCommandHandler(service GroupService) GroupDescriptionCommandHandler {
+	return GroupDescriptionCommandHandler{
+		service: service,
+	}
+}
+
+func (h GroupDescriptionCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	groupCmd, ok := cmd.(GroupDescriptionCommand)
+	if !ok {
+		return nil
+	}
+
+	return h.service.UpdateGroupDescription(ctx, groupCmd.id, groupCmd.dto)
+}
+
+type ThemeDescriptionCommand struct {
+	id  string
+	dto dto.DescriptionUpdateRequest
+}
+
+func NewThemeDescriptionCommand(id string, dto dto.DescriptionUpdateRequest) ThemeDescriptionCommand {
+	return ThemeDescriptionCommand{
+		id:  id,
+		dto: dto,
+	}
+}
+
+func (c ThemeDescriptionCommand) Type() command.Type {
+	return ThemeDescriptionCommandType
+}
+
+func (c ThemeDescriptionCommand) AuditTarget() auditing.Target {
+	return auditing.Target{Entity: domain.AuditEntityTheme, ID: c.id, Request: c.dto}
+}
+
+type ThemeDescriptionCommandHandler struct {
+	service ThemeService
+}
+
+func NewThemeDescriptionCommandHandler(service ThemeService) ThemeDescriptionCommandHandler {
+	return ThemeDescriptionCommandHandler{
+		service: service,
+	}
+}
+
+func (h ThemeDescriptionCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	themeCmd, ok := cmd.(ThemeDescriptionCommand)
+	if !ok {
+		return nil
+	}
+
+	return h.service.UpdateThemeDescription(ctx, themeCmd.id, themeCmd.dto)
+}
+
+type WebhookCommand struct {
+	id  string
+	dto dto.WebhookUpdateRequest
+}
+
+func NewWebhookCommand(id string, dto dto.WebhookUpdateRequest) WebhookCommand {
+	return WebhookCommand{
+		id:  id,
+		dto: dto,
+	}
+}
+
+func (c WebhookCommand) Type() command.Type {
+	return WebhookCommandType
+}
+
+func (c WebhookCommand) AuditTarget() auditing.Target {
+	return auditing.Target{Entity: domain.AuditEntityWebhook, ID: c.id}
+}
+
+type WebhookCommandHandler struct {
+	service WebhookService
+}
+
+func NewWebhookCommandHandler(service WebhookService) WebhookCommandHandler {
+	return WebhookCommandHandler{
+		service: service,
+	}
+}
+
+func (h WebhookCommandHandler) Handle(ctx context.Context, cmd command.Command) error {
+	webhookCmd, ok := cmd.(WebhookCommand)
+	if !ok {
+		return nil
+	}
+
+	return h.service.UpdateWebhook(ctx, webhookCmd.id, webhookCmd.dto)
+}
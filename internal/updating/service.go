@@ -2,36 +2,178 @@ package updating
 
 import (
 	"context"
+	"time"
 
 	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/auth"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/tx"
 )
 
+type UserService struct {
+	userRepository    domain.UserRepository
+	sessionRepository domain.SessionRepository
+	txManager         tx.Manager
+	eventBus          event.Bus
+}
+
+func NewUserService(userRepository domain.UserRepository, sessionRepository domain.SessionRepository, txManager tx.Manager, eventBus event.Bus) UserService {
+	return UserService{
+		userRepository:    userRepository,
+		sessionRepository: sessionRepository,
+		txManager:         txManager,
+		eventBus:          eventBus,
+	}
+}
+
+// UpdateUser replaces the name and email of a user.
+func (s *UserService) UpdateUser(ctx context.Context, id string, dto dto.UserUpdateRequest) error {
+	return s.update(ctx, id, func(user domain.User) (domain.User, error) {
+		user, err := user.WithProfile(dto.Name, dto.Email)
+		if err != nil {
+			return domain.User{}, err
+		}
+
+		user.Record(domain.NewUserUpdatedEvent(user.ID().String(), user.Name().String(), user.Email().String()))
+		return user, nil
+	})
+}
+
+// ChangePassword replaces the password of a user, provided the current one is given. The
+// other sessions of the user are revoked in the same transaction: only the one with the
+// given ID, which the password is changed from, is kept.
+func (s *UserService) ChangePassword(ctx context.Context, id, sessionID string, dto dto.UserPasswordRequest) error {
+	current, err := domain.NewSessionIDFromString(sessionID)
+	if err != nil {
+		return err
+	}
+
+	return s.update(ctx, id, func(user domain.User) (domain.User, error) {
+		if err := auth.CheckPassword(user.Password().String(), dto.CurrentPassword); err != nil {
+			return domain.User{}, domain.ErrIncorrectPassword
+		}
+
+		hashedPassword, err := auth.HashPassword(dto.NewPassword)
+		if err != nil {
+			return domain.User{}, err
+		}
+		user, err = user.WithPassword(hashedPassword)
+		if err != nil {
+			return domain.User{}, err
+		}
+
+		user.Record(domain.NewUserUpdatedEvent(user.ID().String(), user.Name().String(), user.Email().String()))
+		return user, nil
+	}, func(ctx context.Context, user domain.User) error {
+		return s.sessionRepository.RevokeByUser(ctx, user.ID(), current, time.Now())
+	})
+}
+
+// ChangeRole gives a user another role. Taking the permission to manage users away from
+// the last user who has it is refused with domain.ErrLastAdmin.
+func (s *UserService) ChangeRole(ctx context.Context, id string, dto dto.UserRoleRequest) error {
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		// Counting first locks the users allowed to manage users, so that concurrent
+		// changes cannot demote all of them
+		admins, err := s.userRepository.CountByPermission(ctx, domain.PermissionManageUsers)
+		if err != nil {
+			return err
+		}
+
+		return s.update(ctx, id, func(user domain.User) (domain.User, error) {
+			user, err := user.WithRole(dto.Role)
+			if err != nil {
+				return domain.User{}, err
+			}
+
+			user.Record(domain.NewUserRoleChangedEvent(user.ID().String(), user.Name().String(), user.Email().String(), user.Role().String()))
+			return user, nil
+		}, func(ctx context.Context, _ domain.User) error {
+			left, err := s.userRepository.CountByPermission(ctx, domain.PermissionManageUsers)
+			if err != nil {
+				return err
+			}
+			if admins > 0 && left == 0 {
+				return domain.ErrLastAdmin
+			}
+			return nil
+		})
+	})
+}
+
+// update loads a user, changes it and stores it back in one transaction, running the
+// follow-ups of the change in it once the user is stored. The events the change records
+// are published last, in the same transaction.
+func (s *UserService) update(ctx context.Context, id string, change func(domain.User) (domain.User, error), followUps ...func(context.Context, domain.User) error) error {
+	userID, err := domain.NewUserIDFromString(id)
+	if err != nil {
+		return err
+	}
+
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		user, err := s.userRepository.Find(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		user, err = change(user)
+		if err != nil {
+			return err
+		}
+		if err := s.userRepository.Update(ctx, user); err != nil {
+			return err
+		}
+
+		for _, followUp := range followUps {
+			if err := followUp(ctx, user); err != nil {
+				return err
+			}
+		}
+		return s.eventBus.Publish(ctx, user.PullEvents())
+	})
+}
+
 type MovieService struct {
 	movieRepository domain.MovieRepository
+	txManager       tx.Manager
+	eventBus        event.Bus
 }
 
-func NewMovieService(movieRepository domain.MovieRepository) MovieService {
+func NewMovieService(movieRepository domain.MovieRepository, txManager tx.Manager, eventBus event.Bus) MovieService {
 	return MovieService{
 		movieRepository: movieRepository,
+		txManager:       txManager,
+		eventBus:        eventBus,
 	}
 }
 
 func (s *MovieService) UpdateMovie(ctx context.Context, id string, dto dto.MovieUpdateRequest) error {
-	movie, err := domain.NewMovieWithID(id, dto.Name)
+	movie, err := domain.NewMovieWithID(id, dto.Name, dto.ReleaseYear, dto.Sequence, dto.Series, dto.RuntimeMinutes)
 	if err != nil {
 		return err
 	}
-	return s.movieRepository.Update(ctx, movie)
+
+	movie.Record(domain.NewMovieUpdatedEvent(movie.ID().String(), movie.Name().String()))
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.movieRepository.Update(ctx, movie); err != nil {
+			return err
+		}
+		return s.eventBus.Publish(ctx, movie.PullEvents())
+	})
 }
 
 type GroupService struct {
 	groupRepository domain.GroupRepository
+	txManager       tx.Manager
+	eventBus        event.Bus
 }
 
-func NewGroupService(groupRepository domain.GroupRepository) GroupService {
+func NewGroupService(groupRepository domain.GroupRepository, txManager tx.Manager, eventBus event.Bus) GroupService {
 	return GroupService{
 		groupRepository: groupRepository,
+		txManager:       txManager,
+		eventBus:        eventBus,
 	}
 }
 
@@ -40,16 +182,54 @@ func (s *GroupService) UpdateGroup(ctx context.Context, id string, dto dto.Group
 	if err != nil {
 		return err
 	}
-	return s.groupRepository.Update(ctx, group)
+
+	group.Record(domain.NewGroupUpdatedEvent(group.ID().String(), group.Name().String()))
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.groupRepository.Update(ctx, group); err != nil {
+			return err
+		}
+		return s.eventBus.Publish(ctx, group.PullEvents())
+	})
+}
+
+// UpdateGroupDescription replaces the description of a group, leaving the rest of it
+// as it is.
+func (s *GroupService) UpdateGroupDescription(ctx context.Context, id string, dto dto.DescriptionUpdateRequest) error {
+	groupID, err := domain.NewGroupIDFromString(id)
+	if err != nil {
+		return err
+	}
+
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		group, err := s.groupRepository.Find(ctx, groupID)
+		if err != nil {
+			return err
+		}
+
+		group, err = group.WithDescription(dto.Description)
+		if err != nil {
+			return err
+		}
+
+		group.Record(domain.NewGroupUpdatedEvent(group.ID().String(), group.Name().String()))
+		if err := s.groupRepository.Update(ctx, group); err != nil {
+			return err
+		}
+		return s.eventBus.Publish(ctx, group.PullEvents())
+	})
 }
 
 type CategoryService struct {
 	categoryRepository domain.CategoryRepository
+	txManager          tx.Manager
+	eventBus           event.Bus
 }
 
-func NewCategoryService(categoryRepository domain.CategoryRepository) CategoryService {
+func NewCategoryService(categoryRepository domain.CategoryRepository, txManager tx.Manager, eventBus event.Bus) CategoryService {
 	return CategoryService{
 		categoryRepository: categoryRepository,
+		txManager:          txManager,
+		eventBus:           eventBus,
 	}
 }
 
@@ -58,34 +238,56 @@ func (s *CategoryService) UpdateCategory(ctx context.Context, id string, dto dto
 	if err != nil {
 		return err
 	}
-	return s.categoryRepository.Update(ctx, category)
+
+	category.Record(domain.NewCategoryUpdatedEvent(category.ID().String(), category.Name().String()))
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.categoryRepository.Update(ctx, category); err != nil {
+			return err
+		}
+		return s.eventBus.Publish(ctx, category.PullEvents())
+	})
 }
 
 type TrackService struct {
 	trackRepository domain.TrackRepository
+	txManager       tx.Manager
+	eventBus        event.Bus
 }
 
-func NewTrackService(trackRepository domain.TrackRepository) TrackService {
+func NewTrackService(trackRepository domain.TrackRepository, txManager tx.Manager, eventBus event.Bus) TrackService {
 	return TrackService{
 		trackRepository: trackRepository,
+		txManager:       txManager,
+		eventBus:        eventBus,
 	}
 }
 
 func (s *TrackService) UpdateTrack(ctx context.Context, id string, dto dto.TrackUpdateRequest) error {
-	track, err := domain.NewTrackWithID(id, dto.Name, dto.MovieID, dto.SpotifyURL)
+	track, err := domain.NewTrackWithID(id, dto.Name, dto.MovieID, dto.SpotifyURL, dto.TrackNumber, dto.DiscNumber, dto.Edition, dto.DurationSeconds)
 	if err != nil {
 		return err
 	}
-	return s.trackRepository.Update(ctx, track)
+
+	track.Record(domain.NewTrackUpdatedEvent(track.ID().String(), track.Name().String(), track.MovieID().String()))
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.trackRepository.Update(ctx, track); err != nil {
+			return err
+		}
+		return s.eventBus.Publish(ctx, track.PullEvents())
+	})
 }
 
 type ThemeService struct {
 	themeRepository domain.ThemeRepository
+	txManager       tx.Manager
+	eventBus        event.Bus
 }
 
-func NewThemeService(themeRepository domain.ThemeRepository) ThemeService {
+func NewThemeService(themeRepository domain.ThemeRepository, txManager tx.Manager, eventBus event.Bus) ThemeService {
 	return ThemeService{
 		themeRepository: themeRepository,
+		txManager:       txManager,
+		eventBus:        eventBus,
 	}
 }
 
@@ -94,16 +296,56 @@ func (s *ThemeService) UpdateTheme(ctx context.Context, id string, dto dto.Theme
 	if err != nil {
 		return err
 	}
-	return s.themeRepository.Update(ctx, theme)
+
+	theme.Record(domain.NewThemeUpdatedEvent(theme.ID().String(), theme.Name().String(), theme.GroupID().String()))
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.themeRepository.Update(ctx, theme); err != nil {
+			return err
+		}
+		return s.eventBus.Publish(ctx, theme.PullEvents())
+	})
+}
+
+// UpdateThemeDescription replaces the description of a theme, leaving the rest of it
+// as it is.
+func (s *ThemeService) UpdateThemeDescription(ctx context.Context, id string, dto dto.DescriptionUpdateRequest) error {
+	themeID, err := domain.NewThemeIDFromString(id)
+	if err != nil {
+		return err
+	}
+
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		theme, err := s.themeRepository.Find(ctx, themeID)
+		if err != nil {
+			return err
+		}
+
+		theme, err = theme.WithDescription(dto.Description)
+		if err != nil {
+			return err
+		}
+
+		theme.Record(domain.NewThemeUpdatedEvent(theme.ID().String(), theme.Name().String(), theme.GroupID().String()))
+		if err := s.themeRepository.Update(ctx, theme); err != nil {
+			return err
+		}
+		return s.eventBus.Publish(ctx, theme.PullEvents())
+	})
 }
 
 type TrackThemeService struct {
 	trackThemeRepository domain.TrackThemeRepository
+	trackRepository      domain.TrackRepository
+	txManager            tx.Manager
+	eventBus             event.Bus
 }
 
-func NewTrackThemeService(trackThemeRepository domain.TrackThemeRepository) TrackThemeService {
+func NewTrackThemeService(trackThemeRepository domain.TrackThemeRepository, trackRepository domain.TrackRepository, txManager tx.Manager, eventBus event.Bus) TrackThemeService {
 	return TrackThemeService{
 		trackThemeRepository: trackThemeRepository,
+		trackRepository:      trackRepository,
+		txManager:            txManager,
+		eventBus:             eventBus,
 	}
 }
 
@@ -112,5 +354,56 @@ func (s *TrackThemeService) UpdateTrackTheme(ctx context.Context, dto dto.TrackT
 	if err != nil {
 		return err
 	}
-	return s.trackThemeRepository.Update(ctx, trackTheme)
+
+	trackTheme.Record(domain.NewTrackThemeUpdatedEvent(trackTheme.TrackID().String(), trackTheme.ThemeID().String(), trackTheme.StartSecond().Int()))
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		// The occurrence must fit inside the track it is heard in. Locking the track makes
+		// concurrent writes to its occurrences wait for this one, so they cannot both pass
+		// the overlap check below.
+		track, err := s.trackRepository.FindForUpdate(ctx, trackTheme.TrackID())
+		if err != nil {
+			return err
+		}
+		if err := trackTheme.FitsIn(track); err != nil {
+			return err
+		}
+
+		// Reject overlaps with the theme's other occurrences in the track, skipping the
+		// occurrence being updated
+		existing, err := s.trackThemeRepository.FindByTrack(ctx, trackTheme.TrackID())
+		if err != nil {
+			return err
+		}
+		for _, other := range existing {
+			if !trackTheme.SameOccurrence(other) && trackTheme.Overlaps(other) {
+				return domain.ErrTrackThemeOverlaps
+			}
+		}
+
+		if err := s.trackThemeRepository.Update(ctx, trackTheme); err != nil {
+			return err
+		}
+		return s.eventBus.Publish(ctx, trackTheme.PullEvents())
+	})
+}
+
+type WebhookService struct {
+	webhookRepository domain.WebhookRepository
+}
+
+func NewWebhookService(webhookRepository domain.WebhookRepository) WebhookService {
+	return WebhookService{
+		webhookRepository: webhookRepository,
+	}
+}
+
+// UpdateWebhook replaces the URL, the secret and the event types of a webhook. The events
+// being delivered when it happens may still go out with the old ones.
+func (s *WebhookService) UpdateWebhook(ctx context.Context, id string, dto dto.WebhookUpdateRequest) error {
+	webhook, err := domain.NewWebhookWithID(id, dto.URL, dto.Secret, dto.EventTypes)
+	if err != nil {
+		return err
+	}
+
+	return s.webhookRepository.Update(ctx, webhook)
 }
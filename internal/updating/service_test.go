@@ -5,8 +5,13 @@ import (
 	"errors"
 	"testing"
 
+	domain "github.com/AlexFJ498/middle-earth-leitmotifs-api/internal"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/dto"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/auth"
 	"github.com/AlexFJ498/middle-earth-leitmotifs-api/internal/platform/storage/storagemocks"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event/eventmocks"
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/tx/txmocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -37,14 +42,17 @@ var categoryID = "456e7890-e89b-12d3-a456-426614174114"
 
 func TestMovieServiceUpdateMovieRepositoryError(t *testing.T) {
 	dto := dto.MovieUpdateRequest{
-		Name: movieName,
+		Name:        movieName,
+		ReleaseYear: 2001,
+		Sequence:    1,
+		Series:      domain.MovieSeriesLordOfTheRings,
 	}
 
 	movieRepositoryMock := new(storagemocks.MovieRepository)
 	movieRepositoryMock.On("Update", mock.Anything, mock.AnythingOfType(domainMovieType)).Return(errors.New(repositoryErrorMsg)).Once()
 	defer movieRepositoryMock.AssertExpectations(t)
 
-	service := NewMovieService(movieRepositoryMock)
+	service := NewMovieService(movieRepositoryMock, newTxManagerMock(t), new(eventmocks.Bus))
 
 	err := service.UpdateMovie(context.Background(), testID, dto)
 	assert.Error(t, err)
@@ -52,14 +60,17 @@ func TestMovieServiceUpdateMovieRepositoryError(t *testing.T) {
 
 func TestMovieServiceUpdateMovieSuccess(t *testing.T) {
 	dto := dto.MovieUpdateRequest{
-		Name: movieName,
+		Name:        movieName,
+		ReleaseYear: 2001,
+		Sequence:    1,
+		Series:      domain.MovieSeriesLordOfTheRings,
 	}
 
 	movieRepositoryMock := new(storagemocks.MovieRepository)
 	movieRepositoryMock.On("Update", mock.Anything, mock.AnythingOfType(domainMovieType)).Return(nil).Once()
 	defer movieRepositoryMock.AssertExpectations(t)
 
-	service := NewMovieService(movieRepositoryMock)
+	service := NewMovieService(movieRepositoryMock, newTxManagerMock(t), newEventBusMock(t, domain.MovieUpdatedEventType))
 
 	err := service.UpdateMovie(context.Background(), testID, dto)
 	assert.NoError(t, err)
@@ -67,18 +78,38 @@ func TestMovieServiceUpdateMovieSuccess(t *testing.T) {
 
 func TestMovieServiceUpdateMovieInvalidID(t *testing.T) {
 	dto := dto.MovieUpdateRequest{
-		Name: movieName,
+		Name:        movieName,
+		ReleaseYear: 2001,
+		Sequence:    1,
+		Series:      domain.MovieSeriesLordOfTheRings,
 	}
 
 	movieRepositoryMock := new(storagemocks.MovieRepository)
 	defer movieRepositoryMock.AssertExpectations(t)
 
-	service := NewMovieService(movieRepositoryMock)
+	service := NewMovieService(movieRepositoryMock, new(txmocks.Manager), new(eventmocks.Bus))
 
 	err := service.UpdateMovie(context.Background(), invalidId, dto)
 	assert.Error(t, err)
 }
 
+func TestMovieServiceUpdateMovieInvalidSeries(t *testing.T) {
+	dto := dto.MovieUpdateRequest{
+		Name:        movieName,
+		ReleaseYear: 2001,
+		Sequence:    1,
+		Series:      "silmarillion",
+	}
+
+	movieRepositoryMock := new(storagemocks.MovieRepository)
+	defer movieRepositoryMock.AssertExpectations(t)
+
+	service := NewMovieService(movieRepositoryMock, new(txmocks.Manager), new(eventmocks.Bus))
+
+	err := service.UpdateMovie(context.Background(), testID, dto)
+	assert.ErrorIs(t, err, domain.ErrInvalidMovieSeries)
+}
+
 func TestGroupServiceUpdateGroupRepositoryError(t *testing.T) {
 	dto := dto.GroupUpdateRequest{
 		Name:        groupName,
@@ -90,7 +121,7 @@ func TestGroupServiceUpdateGroupRepositoryError(t *testing.T) {
 	groupRepositoryMock.On("Update", mock.Anything, mock.AnythingOfType(domainGroupType)).Return(errors.New(repositoryErrorMsg)).Once()
 	defer groupRepositoryMock.AssertExpectations(t)
 
-	service := NewGroupService(groupRepositoryMock)
+	service := NewGroupService(groupRepositoryMock, newTxManagerMock(t), new(eventmocks.Bus))
 
 	err := service.UpdateGroup(context.Background(), testID, dto)
 	assert.Error(t, err)
@@ -107,7 +138,7 @@ func TestGroupServiceUpdateGroupSuccess(t *testing.T) {
 	groupRepositoryMock.On("Update", mock.Anything, mock.AnythingOfType(domainGroupType)).Return(nil).Once()
 	defer groupRepositoryMock.AssertExpectations(t)
 
-	service := NewGroupService(groupRepositoryMock)
+	service := NewGroupService(groupRepositoryMock, newTxManagerMock(t), newEventBusMock(t, domain.GroupUpdatedEventType))
 
 	err := service.UpdateGroup(context.Background(), testID, dto)
 	assert.NoError(t, err)
@@ -123,7 +154,7 @@ func TestGroupServiceUpdateGroupInvalidID(t *testing.T) {
 	groupRepositoryMock := new(storagemocks.GroupRepository)
 	defer groupRepositoryMock.AssertExpectations(t)
 
-	service := NewGroupService(groupRepositoryMock)
+	service := NewGroupService(groupRepositoryMock, new(txmocks.Manager), new(eventmocks.Bus))
 
 	err := service.UpdateGroup(context.Background(), invalidId, dto)
 	assert.Error(t, err)
@@ -138,7 +169,7 @@ func TestCategoryServiceUpdateCategoryRepositoryError(t *testing.T) {
 	categoryRepositoryMock.On("Update", mock.Anything, mock.AnythingOfType(domainCategoryType)).Return(errors.New(repositoryErrorMsg)).Once()
 	defer categoryRepositoryMock.AssertExpectations(t)
 
-	service := NewCategoryService(categoryRepositoryMock)
+	service := NewCategoryService(categoryRepositoryMock, newTxManagerMock(t), new(eventmocks.Bus))
 
 	err := service.UpdateCategory(context.Background(), testID, dto)
 	assert.Error(t, err)
@@ -153,7 +184,7 @@ func TestCategoryServiceUpdateCategorySuccess(t *testing.T) {
 	categoryRepositoryMock.On("Update", mock.Anything, mock.AnythingOfType(domainCategoryType)).Return(nil).Once()
 	defer categoryRepositoryMock.AssertExpectations(t)
 
-	service := NewCategoryService(categoryRepositoryMock)
+	service := NewCategoryService(categoryRepositoryMock, newTxManagerMock(t), newEventBusMock(t, domain.CategoryUpdatedEventType))
 
 	err := service.UpdateCategory(context.Background(), testID, dto)
 	assert.NoError(t, err)
@@ -167,7 +198,7 @@ func TestCategoryServiceUpdateCategoryInvalidID(t *testing.T) {
 	categoryRepositoryMock := new(storagemocks.CategoryRepository)
 	defer categoryRepositoryMock.AssertExpectations(t)
 
-	service := NewCategoryService(categoryRepositoryMock)
+	service := NewCategoryService(categoryRepositoryMock, new(txmocks.Manager), new(eventmocks.Bus))
 
 	err := service.UpdateCategory(context.Background(), invalidId, dto)
 	assert.Error(t, err)
@@ -175,15 +206,19 @@ func TestCategoryServiceUpdateCategoryInvalidID(t *testing.T) {
 
 func TestTrackServiceUpdateTrackRepositoryError(t *testing.T) {
 	dto := dto.TrackUpdateRequest{
-		Name:    trackName,
-		MovieID: testID,
+		Name:            trackName,
+		MovieID:         testID,
+		TrackNumber:     1,
+		DiscNumber:      1,
+		Edition:         domain.TrackEditionCompleteRecordings,
+		DurationSeconds: 240,
 	}
 
 	trackRepositoryMock := new(storagemocks.TrackRepository)
 	trackRepositoryMock.On("Update", mock.Anything, mock.AnythingOfType(domainTrackType)).Return(errors.New(repositoryErrorMsg)).Once()
 	defer trackRepositoryMock.AssertExpectations(t)
 
-	service := NewTrackService(trackRepositoryMock)
+	service := NewTrackService(trackRepositoryMock, newTxManagerMock(t), new(eventmocks.Bus))
 
 	err := service.UpdateTrack(context.Background(), testID, dto)
 	assert.Error(t, err)
@@ -191,15 +226,19 @@ func TestTrackServiceUpdateTrackRepositoryError(t *testing.T) {
 
 func TestTrackServiceUpdateTrackSuccess(t *testing.T) {
 	dto := dto.TrackUpdateRequest{
-		Name:    trackName,
-		MovieID: testID,
+		Name:            trackName,
+		MovieID:         testID,
+		TrackNumber:     1,
+		DiscNumber:      1,
+		Edition:         domain.TrackEditionCompleteRecordings,
+		DurationSeconds: 240,
 	}
 
 	trackRepositoryMock := new(storagemocks.TrackRepository)
 	trackRepositoryMock.On("Update", mock.Anything, mock.AnythingOfType(domainTrackType)).Return(nil).Once()
 	defer trackRepositoryMock.AssertExpectations(t)
 
-	service := NewTrackService(trackRepositoryMock)
+	service := NewTrackService(trackRepositoryMock, newTxManagerMock(t), newEventBusMock(t, domain.TrackUpdatedEventType))
 
 	err := service.UpdateTrack(context.Background(), testID, dto)
 	assert.NoError(t, err)
@@ -207,14 +246,18 @@ func TestTrackServiceUpdateTrackSuccess(t *testing.T) {
 
 func TestTrackServiceUpdateTrackInvalidID(t *testing.T) {
 	dto := dto.TrackUpdateRequest{
-		Name:    trackName,
-		MovieID: testID,
+		Name:            trackName,
+		MovieID:         testID,
+		TrackNumber:     1,
+		DiscNumber:      1,
+		Edition:         domain.TrackEditionCompleteRecordings,
+		DurationSeconds: 240,
 	}
 
 	trackRepositoryMock := new(storagemocks.TrackRepository)
 	defer trackRepositoryMock.AssertExpectations(t)
 
-	service := NewTrackService(trackRepositoryMock)
+	service := NewTrackService(trackRepositoryMock, new(txmocks.Manager), new(eventmocks.Bus))
 
 	err := service.UpdateTrack(context.Background(), invalidId, dto)
 	assert.Error(t, err)
@@ -233,7 +276,7 @@ func TestThemeServiceUpdateThemeRepositoryError(t *testing.T) {
 	themeRepositoryMock.On("Update", mock.Anything, mock.AnythingOfType(domainThemeType)).Return(errors.New(repositoryErrorMsg)).Once()
 	defer themeRepositoryMock.AssertExpectations(t)
 
-	service := NewThemeService(themeRepositoryMock)
+	service := NewThemeService(themeRepositoryMock, newTxManagerMock(t), new(eventmocks.Bus))
 
 	err := service.UpdateTheme(context.Background(), testID, dto)
 	assert.Error(t, err)
@@ -252,7 +295,7 @@ func TestThemeServiceUpdateThemeSuccess(t *testing.T) {
 	themeRepositoryMock.On("Update", mock.Anything, mock.AnythingOfType(domainThemeType)).Return(nil).Once()
 	defer themeRepositoryMock.AssertExpectations(t)
 
-	service := NewThemeService(themeRepositoryMock)
+	service := NewThemeService(themeRepositoryMock, newTxManagerMock(t), newEventBusMock(t, domain.ThemeUpdatedEventType))
 
 	err := service.UpdateTheme(context.Background(), testID, dto)
 	assert.NoError(t, err)
@@ -270,7 +313,7 @@ func TestThemeServiceUpdateThemeInvalidID(t *testing.T) {
 	themeRepositoryMock := new(storagemocks.ThemeRepository)
 	defer themeRepositoryMock.AssertExpectations(t)
 
-	service := NewThemeService(themeRepositoryMock)
+	service := NewThemeService(themeRepositoryMock, new(txmocks.Manager), new(eventmocks.Bus))
 
 	err := service.UpdateTheme(context.Background(), invalidId, dto)
 	assert.Error(t, err)
@@ -285,11 +328,16 @@ func TestTrackThemeServiceUpdateTrackThemeRepositoryError(t *testing.T) {
 		IsVariant:   false,
 	})
 
+	trackRepositoryMock := new(storagemocks.TrackRepository)
+	trackRepositoryMock.On("FindForUpdate", mock.Anything, mock.AnythingOfType("domain.TrackID")).Return(newTestTrack(t), nil).Once()
+	defer trackRepositoryMock.AssertExpectations(t)
+
 	trackThemeRepositoryMock := new(storagemocks.TrackThemeRepository)
+	trackThemeRepositoryMock.On("FindByTrack", mock.Anything, mock.AnythingOfType("domain.TrackID")).Return([]domain.TrackTheme{}, nil).Once()
 	trackThemeRepositoryMock.On("Update", mock.Anything, mock.AnythingOfType(domainTrackThemeType)).Return(errors.New(repositoryErrorMsg)).Once()
 	defer trackThemeRepositoryMock.AssertExpectations(t)
 
-	service := NewTrackThemeService(trackThemeRepositoryMock)
+	service := NewTrackThemeService(trackThemeRepositoryMock, trackRepositoryMock, newTxManagerMock(t), new(eventmocks.Bus))
 
 	err := service.UpdateTrackTheme(context.Background(), trackThemeCmd.dto)
 	assert.Error(t, err)
@@ -304,11 +352,16 @@ func TestTrackThemeServiceUpdateTrackThemeSuccess(t *testing.T) {
 		IsVariant:   false,
 	})
 
+	trackRepositoryMock := new(storagemocks.TrackRepository)
+	trackRepositoryMock.On("FindForUpdate", mock.Anything, mock.AnythingOfType("domain.TrackID")).Return(newTestTrack(t), nil).Once()
+	defer trackRepositoryMock.AssertExpectations(t)
+
 	trackThemeRepositoryMock := new(storagemocks.TrackThemeRepository)
+	trackThemeRepositoryMock.On("FindByTrack", mock.Anything, mock.AnythingOfType("domain.TrackID")).Return([]domain.TrackTheme{}, nil).Once()
 	trackThemeRepositoryMock.On("Update", mock.Anything, mock.AnythingOfType(domainTrackThemeType)).Return(nil).Once()
 	defer trackThemeRepositoryMock.AssertExpectations(t)
 
-	service := NewTrackThemeService(trackThemeRepositoryMock)
+	service := NewTrackThemeService(trackThemeRepositoryMock, trackRepositoryMock, newTxManagerMock(t), newEventBusMock(t, domain.TrackThemeUpdatedEventType))
 
 	err := service.UpdateTrackTheme(context.Background(), trackThemeCmd.dto)
 	assert.NoError(t, err)
@@ -326,7 +379,7 @@ func TestTrackThemeServiceUpdateTrackThemeInvalidTrackID(t *testing.T) {
 	trackThemeRepositoryMock := new(storagemocks.TrackThemeRepository)
 	defer trackThemeRepositoryMock.AssertExpectations(t)
 
-	service := NewTrackThemeService(trackThemeRepositoryMock)
+	service := NewTrackThemeService(trackThemeRepositoryMock, new(storagemocks.TrackRepository), new(txmocks.Manager), new(eventmocks.Bus))
 
 	err := service.UpdateTrackTheme(context.Background(), trackThemeCmd.dto)
 	assert.Error(t, err)
@@ -344,8 +397,376 @@ func TestTrackThemeServiceUpdateTrackThemeInvalidThemeID(t *testing.T) {
 	trackThemeRepositoryMock := new(storagemocks.TrackThemeRepository)
 	defer trackThemeRepositoryMock.AssertExpectations(t)
 
-	service := NewTrackThemeService(trackThemeRepositoryMock)
+	service := NewTrackThemeService(trackThemeRepositoryMock, new(storagemocks.TrackRepository), new(txmocks.Manager), new(eventmocks.Bus))
 
 	err := service.UpdateTrackTheme(context.Background(), trackThemeCmd.dto)
 	assert.Error(t, err)
 }
+
+func TestTrackThemeServiceUpdateTrackThemeExceedsTrackDuration(t *testing.T) {
+	trackThemeCmd := NewTrackThemeCommand(dto.TrackThemeUpdateRequest{
+		TrackID:     testID,
+		ThemeID:     testID,
+		StartSecond: 200,
+		EndSecond:   300,
+		IsVariant:   false,
+	})
+
+	trackRepositoryMock := new(storagemocks.TrackRepository)
+	trackRepositoryMock.On("FindForUpdate", mock.Anything, mock.AnythingOfType("domain.TrackID")).Return(newTestTrack(t), nil).Once()
+	defer trackRepositoryMock.AssertExpectations(t)
+
+	trackThemeRepositoryMock := new(storagemocks.TrackThemeRepository)
+	defer trackThemeRepositoryMock.AssertExpectations(t)
+
+	service := NewTrackThemeService(trackThemeRepositoryMock, trackRepositoryMock, newTxManagerMock(t), new(eventmocks.Bus))
+
+	err := service.UpdateTrackTheme(context.Background(), trackThemeCmd.dto)
+	assert.ErrorIs(t, err, domain.ErrEndSecondExceedsTrackDuration)
+}
+
+func TestTrackThemeServiceUpdateTrackThemeOverlaps(t *testing.T) {
+	trackThemeCmd := NewTrackThemeCommand(dto.TrackThemeUpdateRequest{
+		TrackID:     testID,
+		ThemeID:     testID,
+		StartSecond: 0,
+		EndSecond:   60,
+		IsVariant:   false,
+	})
+
+	itself, err := domain.NewTrackTheme(testID, testID, 0, 10, false)
+	assert.NoError(t, err)
+	next, err := domain.NewTrackTheme(testID, testID, 50, 80, false)
+	assert.NoError(t, err)
+
+	trackRepositoryMock := new(storagemocks.TrackRepository)
+	trackRepositoryMock.On("FindForUpdate", mock.Anything, mock.AnythingOfType("domain.TrackID")).Return(newTestTrack(t), nil).Once()
+	defer trackRepositoryMock.AssertExpectations(t)
+
+	trackThemeRepositoryMock := new(storagemocks.TrackThemeRepository)
+	trackThemeRepositoryMock.On("FindByTrack", mock.Anything, itself.TrackID()).Return([]domain.TrackTheme{itself, next}, nil).Once()
+	defer trackThemeRepositoryMock.AssertExpectations(t)
+
+	service := NewTrackThemeService(trackThemeRepositoryMock, trackRepositoryMock, newTxManagerMock(t), new(eventmocks.Bus))
+
+	err = service.UpdateTrackTheme(context.Background(), trackThemeCmd.dto)
+	assert.ErrorIs(t, err, domain.ErrTrackThemeOverlaps)
+}
+
+func TestTrackThemeServiceUpdateTrackThemeIgnoresItself(t *testing.T) {
+	trackThemeCmd := NewTrackThemeCommand(dto.TrackThemeUpdateRequest{
+		TrackID:     testID,
+		ThemeID:     testID,
+		StartSecond: 0,
+		EndSecond:   20,
+		IsVariant:   true,
+	})
+
+	itself, err := domain.NewTrackTheme(testID, testID, 0, 10, false)
+	assert.NoError(t, err)
+
+	trackRepositoryMock := new(storagemocks.TrackRepository)
+	trackRepositoryMock.On("FindForUpdate", mock.Anything, mock.AnythingOfType("domain.TrackID")).Return(newTestTrack(t), nil).Once()
+	defer trackRepositoryMock.AssertExpectations(t)
+
+	trackThemeRepositoryMock := new(storagemocks.TrackThemeRepository)
+	trackThemeRepositoryMock.On("FindByTrack", mock.Anything, itself.TrackID()).Return([]domain.TrackTheme{itself}, nil).Once()
+	trackThemeRepositoryMock.On("Update", mock.Anything, mock.AnythingOfType(domainTrackThemeType)).Return(nil).Once()
+	defer trackThemeRepositoryMock.AssertExpectations(t)
+
+	service := NewTrackThemeService(trackThemeRepositoryMock, trackRepositoryMock, newTxManagerMock(t), newEventBusMock(t, domain.TrackThemeUpdatedEventType))
+
+	err = service.UpdateTrackTheme(context.Background(), trackThemeCmd.dto)
+	assert.NoError(t, err)
+}
+
+func TestTrackThemeServiceUpdateTrackThemeChecksWithinTx(t *testing.T) {
+	trackThemeCmd := NewTrackThemeCommand(dto.TrackThemeUpdateRequest{
+		TrackID:     testID,
+		ThemeID:     testID,
+		StartSecond: 0,
+		EndSecond:   10,
+		IsVariant:   false,
+	})
+
+	// The unit of work marks its context, so that the calls made within it can be told
+	// apart
+	type txKey struct{}
+	txManagerMock := new(txmocks.Manager)
+	txManagerMock.On("WithinTx", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(context.WithValue(ctx, txKey{}, true))
+	}).Once()
+	defer txManagerMock.AssertExpectations(t)
+	inTx := mock.MatchedBy(func(ctx context.Context) bool { return ctx.Value(txKey{}) != nil })
+
+	var calls []string
+	trackRepositoryMock := new(storagemocks.TrackRepository)
+	trackRepositoryMock.On("FindForUpdate", inTx, mock.AnythingOfType("domain.TrackID")).Return(newTestTrack(t), nil).
+		Run(func(mock.Arguments) { calls = append(calls, "FindForUpdate") }).Once()
+	defer trackRepositoryMock.AssertExpectations(t)
+
+	trackThemeRepositoryMock := new(storagemocks.TrackThemeRepository)
+	trackThemeRepositoryMock.On("FindByTrack", inTx, mock.AnythingOfType("domain.TrackID")).Return([]domain.TrackTheme{}, nil).
+		Run(func(mock.Arguments) { calls = append(calls, "FindByTrack") }).Once()
+	trackThemeRepositoryMock.On("Update", inTx, mock.AnythingOfType(domainTrackThemeType)).Return(nil).Once()
+	defer trackThemeRepositoryMock.AssertExpectations(t)
+
+	service := NewTrackThemeService(trackThemeRepositoryMock, trackRepositoryMock, txManagerMock, newEventBusMock(t, domain.TrackThemeUpdatedEventType))
+
+	err := service.UpdateTrackTheme(context.Background(), trackThemeCmd.dto)
+	assert.NoError(t, err)
+	// The track is locked before its occurrences are read
+	assert.Equal(t, []string{"FindForUpdate", "FindByTrack"}, calls)
+}
+
+// newTestTrack returns a four-minute track identified by testID.
+func newTestTrack(t *testing.T) domain.Track {
+	track, err := domain.NewTrackWithID(testID, trackName, testID, nil, 1, 1, domain.TrackEditionTheatrical, 240)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return track
+}
+
+// newStoredUser returns a user whose password is the hash of password.
+func newStoredUser(t *testing.T, password string) domain.User {
+	hashedPassword, err := auth.HashPassword(password)
+	assert.NoError(t, err)
+
+	user, err := domain.NewUserWithID(testID, "Meriadoc Brandybuck", "merry@shire.me", hashedPassword, domain.RoleViewer, true)
+	assert.NoError(t, err)
+	return user
+}
+
+func TestUserServiceUpdateUserSuccess(t *testing.T) {
+	user := newStoredUser(t, "old-password")
+
+	userRepositoryMock := new(storagemocks.UserRepository)
+	userRepositoryMock.On("Find", mock.Anything, user.ID()).Return(user, nil).Once()
+	userRepositoryMock.On("Update", mock.Anything, mock.MatchedBy(func(updated domain.User) bool {
+		return updated.Email().String() == "merry@buckland.me" && updated.Password() == user.Password()
+	})).Return(nil).Once()
+	defer userRepositoryMock.AssertExpectations(t)
+
+	service := NewUserService(userRepositoryMock, new(storagemocks.SessionRepository), newTxManagerMock(t), newEventBusMock(t, domain.UserUpdatedEventType))
+
+	err := service.UpdateUser(context.Background(), testID, dto.UserUpdateRequest{Name: "Merry", Email: "merry@buckland.me"})
+	assert.NoError(t, err)
+}
+
+func TestUserServiceUpdateUserInvalidEmail(t *testing.T) {
+	userRepositoryMock := new(storagemocks.UserRepository)
+	userRepositoryMock.On("Find", mock.Anything, mock.Anything).Return(newStoredUser(t, "old-password"), nil).Once()
+	defer userRepositoryMock.AssertExpectations(t)
+
+	service := NewUserService(userRepositoryMock, new(storagemocks.SessionRepository), newTxManagerMock(t), new(eventmocks.Bus))
+
+	err := service.UpdateUser(context.Background(), testID, dto.UserUpdateRequest{Name: "Merry", Email: "not-an-email"})
+	assert.ErrorIs(t, err, domain.ErrInvalidUserEmail)
+}
+
+func TestUserServiceChangePasswordSuccess(t *testing.T) {
+	user := newStoredUser(t, "old-password")
+	sessionID, err := domain.NewSessionID()
+	assert.NoError(t, err)
+
+	userRepositoryMock := new(storagemocks.UserRepository)
+	userRepositoryMock.On("Find", mock.Anything, user.ID()).Return(user, nil).Once()
+	userRepositoryMock.On("Update", mock.Anything, mock.MatchedBy(func(updated domain.User) bool {
+		return auth.CheckPassword(updated.Password().String(), "new-password") == nil
+	})).Return(nil).Once()
+	defer userRepositoryMock.AssertExpectations(t)
+
+	sessionRepositoryMock := new(storagemocks.SessionRepository)
+	sessionRepositoryMock.On("RevokeByUser", mock.Anything, user.ID(), sessionID, mock.AnythingOfType("time.Time")).Return(nil).Once()
+	defer sessionRepositoryMock.AssertExpectations(t)
+
+	service := NewUserService(userRepositoryMock, sessionRepositoryMock, newTxManagerMock(t), newEventBusMock(t, domain.UserUpdatedEventType))
+
+	err = service.ChangePassword(context.Background(), testID, sessionID.String(), dto.UserPasswordRequest{CurrentPassword: "old-password", NewPassword: "new-password"})
+	assert.NoError(t, err)
+}
+
+func TestUserServiceChangePasswordIncorrectPassword(t *testing.T) {
+	sessionID, err := domain.NewSessionID()
+	assert.NoError(t, err)
+
+	userRepositoryMock := new(storagemocks.UserRepository)
+	userRepositoryMock.On("Find", mock.Anything, mock.Anything).Return(newStoredUser(t, "old-password"), nil).Once()
+	defer userRepositoryMock.AssertExpectations(t)
+
+	sessionRepositoryMock := new(storagemocks.SessionRepository)
+	defer sessionRepositoryMock.AssertExpectations(t)
+
+	service := NewUserService(userRepositoryMock, sessionRepositoryMock, newTxManagerMock(t), new(eventmocks.Bus))
+
+	err = service.ChangePassword(context.Background(), testID, sessionID.String(), dto.UserPasswordRequest{CurrentPassword: "wrong-password", NewPassword: "new-password"})
+	assert.ErrorIs(t, err, domain.ErrIncorrectPassword)
+}
+
+func TestUserServiceChangeRoleSuccess(t *testing.T) {
+	user := newStoredUser(t, "old-password")
+
+	userRepositoryMock := new(storagemocks.UserRepository)
+	userRepositoryMock.On("CountByPermission", mock.Anything, domain.PermissionManageUsers).Return(1, nil).Twice()
+	userRepositoryMock.On("Find", mock.Anything, user.ID()).Return(user, nil).Once()
+	userRepositoryMock.On("Update", mock.Anything, mock.MatchedBy(func(updated domain.User) bool {
+		return updated.Role().String() == domain.RoleCurator
+	})).Return(nil).Once()
+	defer userRepositoryMock.AssertExpectations(t)
+
+	service := NewUserService(userRepositoryMock, new(storagemocks.SessionRepository), newTxManagerMock(t), newEventBusMock(t, domain.UserRoleChangedEventType))
+
+	err := service.ChangeRole(context.Background(), testID, dto.UserRoleRequest{Role: domain.RoleCurator})
+	assert.NoError(t, err)
+}
+
+func TestUserServiceChangeRoleLastAdmin(t *testing.T) {
+	hashedPassword, err := auth.HashPassword("old-password")
+	assert.NoError(t, err)
+	admin, err := domain.NewUserWithID(testID, "Meriadoc Brandybuck", "merry@shire.me", hashedPassword, domain.RoleAdmin, true)
+	assert.NoError(t, err)
+
+	userRepositoryMock := new(storagemocks.UserRepository)
+	userRepositoryMock.On("CountByPermission", mock.Anything, domain.PermissionManageUsers).Return(1, nil).Once()
+	userRepositoryMock.On("Find", mock.Anything, admin.ID()).Return(admin, nil).Once()
+	userRepositoryMock.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+	userRepositoryMock.On("CountByPermission", mock.Anything, domain.PermissionManageUsers).Return(0, nil).Once()
+	defer userRepositoryMock.AssertExpectations(t)
+
+	service := NewUserService(userRepositoryMock, new(storagemocks.SessionRepository), newTxManagerMock(t), new(eventmocks.Bus))
+
+	err = service.ChangeRole(context.Background(), testID, dto.UserRoleRequest{Role: domain.RoleCurator})
+	assert.ErrorIs(t, err, domain.ErrLastAdmin)
+}
+
+func TestUserServiceChangeRoleNotFound(t *testing.T) {
+	userRepositoryMock := new(storagemocks.UserRepository)
+	userRepositoryMock.On("CountByPermission", mock.Anything, domain.PermissionManageUsers).Return(1, nil).Once()
+	userRepositoryMock.On("Find", mock.Anything, mock.Anything).Return(domain.User{}, domain.ErrUserNotFound).Once()
+	defer userRepositoryMock.AssertExpectations(t)
+
+	service := NewUserService(userRepositoryMock, new(storagemocks.SessionRepository), newTxManagerMock(t), new(eventmocks.Bus))
+
+	err := service.ChangeRole(context.Background(), testID, dto.UserRoleRequest{Role: domain.RoleCurator})
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+// newTxManagerMock returns a transaction manager that runs the unit of work it is given.
+func newTxManagerMock(t *testing.T) *txmocks.Manager {
+	txManagerMock := new(txmocks.Manager)
+	txManagerMock.On("WithinTx", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	t.Cleanup(func() { txManagerMock.AssertExpectations(t) })
+	return txManagerMock
+}
+
+// newEventBusMock returns an event bus that expects the events of the given types to be
+// published once, in that order.
+func newEventBusMock(t *testing.T, types ...event.Type) *eventmocks.Bus {
+	eventBusMock := new(eventmocks.Bus)
+	eventBusMock.On("Publish", mock.Anything, mock.MatchedBy(func(events []event.Event) bool {
+		if len(events) != len(types) {
+			return false
+		}
+		for i, e := range events {
+			if e.Type() != types[i] {
+				return false
+			}
+		}
+		return true
+	})).Return(nil).Once()
+	t.Cleanup(func() { eventBusMock.AssertExpectations(t) })
+	return eventBusMock
+}
+
+func TestGroupServiceUpdateGroupDescriptionSuccess(t *testing.T) {
+	group, err := domain.NewGroupWithID(testID, groupName, groupDescription, groupImageURL)
+	assert.NoError(t, err)
+
+	groupRepositoryMock := new(storagemocks.GroupRepository)
+	groupRepositoryMock.On("Find", mock.Anything, group.ID()).Return(group, nil).Once()
+	groupRepositoryMock.On("Update", mock.Anything, mock.MatchedBy(func(updated domain.Group) bool {
+		return updated.Description().String() == "New description" && updated.Name() == group.Name()
+	})).Return(nil).Once()
+	defer groupRepositoryMock.AssertExpectations(t)
+
+	service := NewGroupService(groupRepositoryMock, newTxManagerMock(t), newEventBusMock(t, domain.GroupUpdatedEventType))
+
+	err = service.UpdateGroupDescription(context.Background(), testID, dto.DescriptionUpdateRequest{Description: "New description"})
+	assert.NoError(t, err)
+}
+
+func TestGroupServiceUpdateGroupDescriptionNotFound(t *testing.T) {
+	groupRepositoryMock := new(storagemocks.GroupRepository)
+	groupRepositoryMock.On("Find", mock.Anything, mock.Anything).Return(domain.Group{}, domain.ErrGroupNotFound).Once()
+	defer groupRepositoryMock.AssertExpectations(t)
+
+	service := NewGroupService(groupRepositoryMock, newTxManagerMock(t), new(eventmocks.Bus))
+
+	err := service.UpdateGroupDescription(context.Background(), testID, dto.DescriptionUpdateRequest{Description: "New description"})
+	assert.ErrorIs(t, err, domain.ErrGroupNotFound)
+}
+
+func TestThemeServiceUpdateThemeDescriptionSuccess(t *testing.T) {
+	theme, err := domain.NewThemeWithID(testID, themeName, testID, testID, themeDescription, 0, 0, &categoryID)
+	assert.NoError(t, err)
+
+	themeRepositoryMock := new(storagemocks.ThemeRepository)
+	themeRepositoryMock.On("Find", mock.Anything, theme.ID()).Return(theme, nil).Once()
+	themeRepositoryMock.On("Update", mock.Anything, mock.MatchedBy(func(updated domain.Theme) bool {
+		return updated.Description().String() == "New description" && updated.Name() == theme.Name()
+	})).Return(nil).Once()
+	defer themeRepositoryMock.AssertExpectations(t)
+
+	service := NewThemeService(themeRepositoryMock, newTxManagerMock(t), newEventBusMock(t, domain.ThemeUpdatedEventType))
+
+	err = service.UpdateThemeDescription(context.Background(), testID, dto.DescriptionUpdateRequest{Description: "New description"})
+	assert.NoError(t, err)
+}
+
+func TestThemeServiceUpdateThemeDescriptionInvalidID(t *testing.T) {
+	themeRepositoryMock := new(storagemocks.ThemeRepository)
+	defer themeRepositoryMock.AssertExpectations(t)
+
+	service := NewThemeService(themeRepositoryMock, new(txmocks.Manager), new(eventmocks.Bus))
+
+	err := service.UpdateThemeDescription(context.Background(), invalidId, dto.DescriptionUpdateRequest{Description: "New description"})
+	assert.ErrorIs(t, err, domain.ErrInvalidThemeID)
+}
+
+func TestWebhookServiceUpdateWebhookSuccess(t *testing.T) {
+	dto := dto.WebhookUpdateRequest{
+		URL:        "https://hooks.example.com/rotated",
+		Secret:     "a-rotated-signing-key",
+		EventTypes: []string{string(domain.MovieCreatedEventType)},
+	}
+
+	webhookRepositoryMock := new(storagemocks.WebhookRepository)
+	webhookRepositoryMock.On("Update", mock.Anything, mock.MatchedBy(func(webhook domain.Webhook) bool {
+		return webhook.ID().String() == testID && webhook.Secret().String() == "a-rotated-signing-key"
+	})).Return(nil).Once()
+	defer webhookRepositoryMock.AssertExpectations(t)
+
+	service := NewWebhookService(webhookRepositoryMock)
+
+	err := service.UpdateWebhook(context.Background(), testID, dto)
+	assert.NoError(t, err)
+}
+
+func TestWebhookServiceUpdateWebhookInvalidSecret(t *testing.T) {
+	dto := dto.WebhookUpdateRequest{
+		URL:        "https://hooks.example.com/rotated",
+		Secret:     "short",
+		EventTypes: []string{string(domain.MovieCreatedEventType)},
+	}
+
+	webhookRepositoryMock := new(storagemocks.WebhookRepository)
+	defer webhookRepositoryMock.AssertExpectations(t)
+
+	service := NewWebhookService(webhookRepositoryMock)
+
+	err := service.UpdateWebhook(context.Background(), testID, dto)
+	assert.ErrorIs(t, err, domain.ErrInvalidWebhookSecret)
+}
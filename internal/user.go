@@ -16,6 +16,9 @@ var ErrInvalidUserEmail = errors.New("invalid user email")
 var ErrInvalidUserPassword = errors.New("invalid user password")
 var ErrUserAlreadyExists = errors.New("user already exists")
 var ErrUserNotFound = errors.New("user not found")
+var ErrIncorrectPassword = errors.New("incorrect password")
+var ErrUserNotVerified = errors.New("user email not verified")
+var ErrLastAdmin = errors.New("the last user allowed to manage users cannot be demoted or deleted")
 
 // UserID represents the unique identifier for a user.
 type UserID struct {
@@ -37,11 +40,6 @@ type UserPassword struct {
 	value string
 }
 
-// UserIsAdmin represents the admin status of a user.
-type UserIsAdmin struct {
-	value bool
-}
-
 // NewUserID creates a new UserID instance.
 func NewUserID() (UserID, error) {
 	v, err := uuid.NewRandom()
@@ -127,23 +125,19 @@ func (password UserPassword) String() string {
 	return password.value
 }
 
-// NewUserIsAdmin creates a new UserIsAdmin instance.
-func NewUserIsAdmin(value bool) (UserIsAdmin, error) {
-	return UserIsAdmin{
-		value: value,
-	}, nil
-}
-
-func (isAdmin UserIsAdmin) Bool() bool {
-	return isAdmin.value
-}
-
 // UserRepository defines the interface for user persistence operations.
 type UserRepository interface {
 	Save(ctx context.Context, user User) error
 	Find(ctx context.Context, id UserID) (User, error)
 	FindByEmail(ctx context.Context, email UserEmail) (User, error)
 	FindAll(ctx context.Context) ([]User, error)
+	FindPage(ctx context.Context, page PageRequest) ([]User, *Cursor, error)
+	Update(ctx context.Context, user User) error
+	// Delete removes a user for good, along with their sessions.
+	Delete(ctx context.Context, id UserID) error
+	// CountByPermission counts the users whose role grants the permission. Within a
+	// transaction, they stay locked until it ends.
+	CountByPermission(ctx context.Context, permission Permission) (int, error)
 }
 
 //go:generate mockery --case=snake --outpkg=storagemocks --output=platform/storage/storagemocks --name=UserRepository
@@ -154,13 +148,14 @@ type User struct {
 	name     UserName
 	email    UserEmail
 	password UserPassword
-	isAdmin  UserIsAdmin
+	role     RoleName
+	verified bool // Whether the user proved they own their email
 
 	events []event.Event
 }
 
-// NewUser creates a new User instance.
-func NewUser(name, email, password string, isAdmin bool) (User, error) {
+// NewUser creates a new User instance. Its email is not verified yet.
+func NewUser(name, email, password, role string) (User, error) {
 	idVO, err := NewUserID()
 	if err != nil {
 		return User{}, err
@@ -181,7 +176,7 @@ func NewUser(name, email, password string, isAdmin bool) (User, error) {
 		return User{}, err
 	}
 
-	isAdminVO, err := NewUserIsAdmin(isAdmin)
+	roleVO, err := NewRoleName(role)
 	if err != nil {
 		return User{}, err
 	}
@@ -191,7 +186,7 @@ func NewUser(name, email, password string, isAdmin bool) (User, error) {
 		name:     nameVO,
 		email:    emailVO,
 		password: passwordVO,
-		isAdmin:  isAdminVO,
+		role:     roleVO,
 	}
 
 	user.Record(NewUserCreatedEvent(idVO.String(), nameVO.String(), emailVO.String()))
@@ -200,7 +195,7 @@ func NewUser(name, email, password string, isAdmin bool) (User, error) {
 }
 
 // NewUserWithID creates a new User instance with the given ID.
-func NewUserWithID(id, name, email, password string, isAdmin bool) (User, error) {
+func NewUserWithID(id, name, email, password, role string, verified bool) (User, error) {
 	idVO, err := NewUserIDFromString(id)
 	if err != nil {
 		return User{}, err
@@ -221,7 +216,7 @@ func NewUserWithID(id, name, email, password string, isAdmin bool) (User, error)
 		return User{}, err
 	}
 
-	isAdminVO, err := NewUserIsAdmin(isAdmin)
+	roleVO, err := NewRoleName(role)
 	if err != nil {
 		return User{}, err
 	}
@@ -231,7 +226,8 @@ func NewUserWithID(id, name, email, password string, isAdmin bool) (User, error)
 		name:     nameVO,
 		email:    emailVO,
 		password: passwordVO,
-		isAdmin:  isAdminVO,
+		role:     roleVO,
+		verified: verified,
 	}
 
 	return user, nil
@@ -257,9 +253,60 @@ func (u User) Password() UserPassword {
 	return u.password
 }
 
-// IsAdmin returns the user's admin status.
-func (u User) IsAdmin() UserIsAdmin {
-	return u.isAdmin
+// Role returns the name of the user's role.
+func (u User) Role() RoleName {
+	return u.role
+}
+
+// Verified tells whether the user proved they own their email. Unverified users cannot
+// log in.
+func (u User) Verified() bool {
+	return u.verified
+}
+
+// Verify returns a copy of the user whose email is verified.
+func (u User) Verify() User {
+	u.verified = true
+	return u
+}
+
+// WithProfile returns a copy of the user with another name and email.
+func (u User) WithProfile(name, email string) (User, error) {
+	nameVO, err := NewUserName(name)
+	if err != nil {
+		return User{}, err
+	}
+
+	emailVO, err := NewUserEmail(email)
+	if err != nil {
+		return User{}, err
+	}
+
+	u.name = nameVO
+	u.email = emailVO
+	return u, nil
+}
+
+// WithPassword returns a copy of the user with another hashed password.
+func (u User) WithPassword(password string) (User, error) {
+	passwordVO, err := NewUserPassword(password)
+	if err != nil {
+		return User{}, err
+	}
+
+	u.password = passwordVO
+	return u, nil
+}
+
+// WithRole returns a copy of the user with another role.
+func (u User) WithRole(role string) (User, error) {
+	roleVO, err := NewRoleName(role)
+	if err != nil {
+		return User{}, err
+	}
+
+	u.role = roleVO
+	return u, nil
 }
 
 // Record adds an event to the user's event list.
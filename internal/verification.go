@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrInvalidVerificationToken = errors.New("invalid or expired verification token")
+
+// VerificationTokenRepository is the interface for the tokens of the links that verify
+// the emails of the users.
+type VerificationTokenRepository interface {
+	Save(ctx context.Context, token VerificationToken) error
+	FindByTokenHash(ctx context.Context, tokenHash string) (VerificationToken, error)
+	Delete(ctx context.Context, tokenHash string) error
+	// ValidAt tells whether the user has a token that can still verify their email at t.
+	ValidAt(ctx context.Context, userID UserID, t time.Time) (bool, error)
+}
+
+//go:generate mockery --case=snake --outpkg=storagemocks --output=platform/storage/storagemocks --name=VerificationTokenRepository
+
+// VerificationToken is the token of a link mailed to a user to verify their email. Only
+// its SHA-256 hash is stored, like the refresh tokens of the sessions.
+type VerificationToken struct {
+	tokenHash string
+	userID    UserID
+	expiresAt time.Time
+}
+
+// NewVerificationToken creates a VerificationToken, from new or stored values.
+func NewVerificationToken(tokenHash, userID string, expiresAt time.Time) (VerificationToken, error) {
+	userIDVO, err := NewUserIDFromString(userID)
+	if err != nil {
+		return VerificationToken{}, err
+	}
+
+	if tokenHash == "" || expiresAt.IsZero() {
+		return VerificationToken{}, ErrInvalidVerificationToken
+	}
+
+	return VerificationToken{
+		tokenHash: tokenHash,
+		userID:    userIDVO,
+		expiresAt: expiresAt.UTC(),
+	}, nil
+}
+
+func (t VerificationToken) TokenHash() string {
+	return t.tokenHash
+}
+
+func (t VerificationToken) UserID() UserID {
+	return t.userID
+}
+
+func (t VerificationToken) ExpiresAt() time.Time {
+	return t.expiresAt
+}
+
+// Expired tells whether the token can no longer verify the email at now.
+func (t VerificationToken) Expired(now time.Time) bool {
+	return !now.Before(t.expiresAt)
+}
@@ -0,0 +1,399 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+	"github.com/google/uuid"
+)
+
+var ErrInvalidWebhookID = errors.New("invalid webhook ID")
+var ErrInvalidWebhookURL = errors.New("invalid webhook URL, expected an absolute http or https URL to a public host")
+var ErrInvalidWebhookSecret = errors.New("invalid webhook secret, expected at least 16 characters")
+var ErrInvalidWebhookEventType = errors.New("invalid webhook event type")
+var ErrWebhookNotFound = errors.New("webhook not found")
+var ErrInvalidWebhookDelivery = errors.New("invalid webhook delivery")
+
+// sharedAddressSpace is the range carriers use for NAT (RFC 6598); like the private
+// ranges, it is not reachable from the internet.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// PublicAddress tells whether events may be posted to the address. Loopback, private,
+// shared, link-local (such as the 169.254.169.254 metadata endpoint of the cloud
+// providers), unspecified and multicast addresses are kept out, so that a webhook cannot
+// reach the services next to the API.
+func PublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !sharedAddressSpace.Contains(addr)
+}
+
+// minWebhookSecretLength is the shortest secret a webhook is signed with.
+const minWebhookSecretLength = 16
+
+// webhookEventTypes are the events a webhook can subscribe to: every change to the
+// catalogue. The events about users are left out, as they carry personal data.
+var webhookEventTypes = []event.Type{
+	MovieCreatedEventType, MovieUpdatedEventType, MovieDeletedEventType, MovieRestoredEventType, MoviePurgedEventType,
+	GroupCreatedEventType, GroupUpdatedEventType, GroupDeletedEventType, GroupRestoredEventType, GroupPurgedEventType,
+	CategoryCreatedEventType, CategoryUpdatedEventType, CategoryDeletedEventType, CategoryRestoredEventType, CategoryPurgedEventType,
+	TrackCreatedEventType, TrackUpdatedEventType, TrackDeletedEventType, TrackRestoredEventType, TrackPurgedEventType,
+	ThemeCreatedEventType, ThemeUpdatedEventType, ThemeDeletedEventType, ThemeRestoredEventType, ThemePurgedEventType,
+	TrackThemeAddedEventType, TrackThemeUpdatedEventType, TrackThemeRemovedEventType,
+}
+
+// WebhookEventTypes returns the types of the events a webhook can subscribe to.
+func WebhookEventTypes() []event.Type {
+	return slices.Clone(webhookEventTypes)
+}
+
+type WebhookID struct {
+	value string
+}
+
+// WebhookURL is the absolute http or https URL the events are posted to. Its host
+// cannot be localhost or an address that is not public; the host names are only
+// resolved, and checked, when an event is posted.
+type WebhookURL struct {
+	value string
+}
+
+// WebhookSecret is the key the payloads posted to a webhook are signed with.
+type WebhookSecret struct {
+	value string
+}
+
+func NewWebhookID() (WebhookID, error) {
+	v, err := uuid.NewRandom()
+	if err != nil {
+		return WebhookID{}, fmt.Errorf("%w: %w", ErrInvalidWebhookID, err)
+	}
+
+	return WebhookID{
+		value: v.String(),
+	}, nil
+}
+
+func NewWebhookIDFromString(id string) (WebhookID, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return WebhookID{}, ErrInvalidWebhookID
+	}
+
+	return WebhookID{
+		value: id,
+	}, nil
+}
+
+func (id WebhookID) String() string {
+	return id.value
+}
+
+func NewWebhookURL(value string) (WebhookURL, error) {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return WebhookURL{}, ErrInvalidWebhookURL
+	}
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return WebhookURL{}, ErrInvalidWebhookURL
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !PublicAddress(addr) {
+		return WebhookURL{}, ErrInvalidWebhookURL
+	}
+
+	return WebhookURL{
+		value: value,
+	}, nil
+}
+
+func (u WebhookURL) String() string {
+	return u.value
+}
+
+func NewWebhookSecret(value string) (WebhookSecret, error) {
+	if len(value) < minWebhookSecretLength {
+		return WebhookSecret{}, ErrInvalidWebhookSecret
+	}
+
+	return WebhookSecret{
+		value: value,
+	}, nil
+}
+
+func (s WebhookSecret) String() string {
+	return s.value
+}
+
+// newWebhookEventTypes checks the event types a webhook subscribes to, leaving out
+// duplicates. At least one is required.
+func newWebhookEventTypes(values []string) ([]event.Type, error) {
+	if len(values) == 0 {
+		return nil, ErrInvalidWebhookEventType
+	}
+
+	eventTypes := make([]event.Type, 0, len(values))
+	for _, value := range values {
+		eventType := event.Type(value)
+		if !slices.Contains(webhookEventTypes, eventType) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidWebhookEventType, value)
+		}
+		if !slices.Contains(eventTypes, eventType) {
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+
+	return eventTypes, nil
+}
+
+// WebhookRepository is the interface for the webhooks.
+type WebhookRepository interface {
+	Save(ctx context.Context, webhook Webhook) error
+	Find(ctx context.Context, id WebhookID) (Webhook, error)
+	FindAll(ctx context.Context) ([]Webhook, error)
+	FindByEventType(ctx context.Context, eventType event.Type) ([]Webhook, error) // The webhooks subscribed to the event type
+	Update(ctx context.Context, webhook Webhook) error
+	Delete(ctx context.Context, id WebhookID) error // Its deliveries go with it
+}
+
+//go:generate mockery --case=snake --outpkg=storagemocks --output=platform/storage/storagemocks --name=WebhookRepository
+
+// Webhook is a subscription of an external service to some of the domain events. The
+// events are posted to its URL, signed with its secret.
+type Webhook struct {
+	id         WebhookID
+	url        WebhookURL
+	secret     WebhookSecret
+	eventTypes []event.Type
+}
+
+// NewWebhook creates a new Webhook with a fresh ID.
+func NewWebhook(url, secret string, eventTypes []string) (Webhook, error) {
+	id, err := NewWebhookID()
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	return NewWebhookWithID(id.String(), url, secret, eventTypes)
+}
+
+func NewWebhookWithID(id, url, secret string, eventTypes []string) (Webhook, error) {
+	idVO, err := NewWebhookIDFromString(id)
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	urlVO, err := NewWebhookURL(url)
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	secretVO, err := NewWebhookSecret(secret)
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	eventTypesVO, err := newWebhookEventTypes(eventTypes)
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	return Webhook{
+		id:         idVO,
+		url:        urlVO,
+		secret:     secretVO,
+		eventTypes: eventTypesVO,
+	}, nil
+}
+
+func (w Webhook) ID() WebhookID {
+	return w.id
+}
+
+func (w Webhook) URL() WebhookURL {
+	return w.url
+}
+
+func (w Webhook) Secret() WebhookSecret {
+	return w.secret
+}
+
+func (w Webhook) EventTypes() []event.Type {
+	return slices.Clone(w.eventTypes)
+}
+
+// Subscribes tells whether the webhook is sent the events of the given type.
+func (w Webhook) Subscribes(eventType event.Type) bool {
+	return slices.Contains(w.eventTypes, eventType)
+}
+
+// WebhookDeliveryRepository is the interface for the deliveries of the events to the
+// webhooks. It keeps the attempts still to be made, so that none is lost when the API
+// stops, along with the log of the attempts made.
+type WebhookDeliveryRepository interface {
+	// Schedule adds an attempt to be made, unless the same attempt at posting the event
+	// to the webhook is already scheduled or made.
+	Schedule(ctx context.Context, delivery PendingWebhookDelivery) error
+	// Claim returns up to limit attempts due by now, soonest first, and keeps them from
+	// being claimed again until lease has passed, so that an attempt whose worker stopped
+	// before recording it is eventually made again.
+	Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]PendingWebhookDelivery, error)
+	// Record records how a claimed attempt went.
+	Record(ctx context.Context, delivery WebhookDelivery) error
+	FindPage(ctx context.Context, webhookID WebhookID, page PageRequest) ([]WebhookDelivery, *Cursor, error) // The attempts made, newest first
+}
+
+//go:generate mockery --case=snake --outpkg=storagemocks --output=platform/storage/storagemocks --name=WebhookDeliveryRepository
+
+// PendingWebhookDelivery is an attempt at posting an event to a webhook that is still to
+// be made.
+type PendingWebhookDelivery struct {
+	id        string
+	webhookID WebhookID
+	event     event.Event
+	attempt   int // Starts at 1 for every event
+	dueAt     time.Time
+}
+
+// NewPendingWebhookDelivery creates a new PendingWebhookDelivery with a fresh ID.
+func NewPendingWebhookDelivery(webhookID WebhookID, e event.Event, attempt int, dueAt time.Time) (PendingWebhookDelivery, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return PendingWebhookDelivery{}, fmt.Errorf("%w: %w", ErrInvalidWebhookDelivery, err)
+	}
+
+	return NewPendingWebhookDeliveryWithID(id.String(), webhookID.String(), e, attempt, dueAt)
+}
+
+// NewPendingWebhookDeliveryWithID creates a PendingWebhookDelivery from stored values.
+func NewPendingWebhookDeliveryWithID(id, webhookID string, e event.Event, attempt int, dueAt time.Time) (PendingWebhookDelivery, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return PendingWebhookDelivery{}, fmt.Errorf("%w: %w", ErrInvalidWebhookDelivery, err)
+	}
+	webhookIDVO, err := NewWebhookIDFromString(webhookID)
+	if err != nil {
+		return PendingWebhookDelivery{}, err
+	}
+	if e == nil || attempt < 1 || dueAt.IsZero() {
+		return PendingWebhookDelivery{}, ErrInvalidWebhookDelivery
+	}
+
+	return PendingWebhookDelivery{
+		id:        id,
+		webhookID: webhookIDVO,
+		event:     e,
+		attempt:   attempt,
+		dueAt:     dueAt.UTC(),
+	}, nil
+}
+
+func (d PendingWebhookDelivery) ID() string {
+	return d.id
+}
+
+func (d PendingWebhookDelivery) WebhookID() WebhookID {
+	return d.webhookID
+}
+
+func (d PendingWebhookDelivery) Event() event.Event {
+	return d.event
+}
+
+func (d PendingWebhookDelivery) Attempt() int {
+	return d.attempt
+}
+
+// DueAt returns the time the attempt is to be made at, or right after.
+func (d PendingWebhookDelivery) DueAt() time.Time {
+	return d.dueAt
+}
+
+// Made returns the record of the attempt, made at deliveredAt. It keeps the ID of the
+// pending attempt, which it takes the place of.
+func (d PendingWebhookDelivery) Made(statusCode int, err string, deliveredAt time.Time) (WebhookDelivery, error) {
+	return NewWebhookDeliveryWithID(d.id, d.webhookID.String(), d.event.ID(), string(d.event.Type()), d.attempt, statusCode, err, deliveredAt)
+}
+
+// Retry returns the next attempt at posting the event, due at dueAt.
+func (d PendingWebhookDelivery) Retry(dueAt time.Time) (PendingWebhookDelivery, error) {
+	return NewPendingWebhookDelivery(d.webhookID, d.event, d.attempt+1, dueAt)
+}
+
+// WebhookDelivery records an attempt at posting an event to a webhook.
+type WebhookDelivery struct {
+	id          string
+	webhookID   WebhookID
+	eventID     string
+	eventType   event.Type
+	attempt     int    // Starts at 1 for every event
+	statusCode  int    // Zero when no response was received
+	err         string // Empty when the webhook accepted the event
+	deliveredAt time.Time
+}
+
+// NewWebhookDeliveryWithID creates a WebhookDelivery from stored values.
+func NewWebhookDeliveryWithID(id, webhookID, eventID, eventType string, attempt, statusCode int, err string, deliveredAt time.Time) (WebhookDelivery, error) {
+	if _, uuidErr := uuid.Parse(id); uuidErr != nil {
+		return WebhookDelivery{}, fmt.Errorf("%w: %w", ErrInvalidWebhookDelivery, uuidErr)
+	}
+	webhookIDVO, idErr := NewWebhookIDFromString(webhookID)
+	if idErr != nil {
+		return WebhookDelivery{}, idErr
+	}
+	if eventID == "" || eventType == "" || attempt < 1 || deliveredAt.IsZero() {
+		return WebhookDelivery{}, ErrInvalidWebhookDelivery
+	}
+
+	return WebhookDelivery{
+		id:          id,
+		webhookID:   webhookIDVO,
+		eventID:     eventID,
+		eventType:   event.Type(eventType),
+		attempt:     attempt,
+		statusCode:  statusCode,
+		err:         err,
+		deliveredAt: deliveredAt.UTC(),
+	}, nil
+}
+
+func (d WebhookDelivery) ID() string {
+	return d.id
+}
+
+func (d WebhookDelivery) WebhookID() WebhookID {
+	return d.webhookID
+}
+
+func (d WebhookDelivery) EventID() string {
+	return d.eventID
+}
+
+func (d WebhookDelivery) EventType() event.Type {
+	return d.eventType
+}
+
+func (d WebhookDelivery) Attempt() int {
+	return d.attempt
+}
+
+func (d WebhookDelivery) StatusCode() int {
+	return d.statusCode
+}
+
+func (d WebhookDelivery) Err() string {
+	return d.err
+}
+
+func (d WebhookDelivery) DeliveredAt() time.Time {
+	return d.deliveredAt
+}
+
+// Succeeded tells whether the webhook accepted the event.
+func (d WebhookDelivery) Succeeded() bool {
+	return d.err == ""
+}
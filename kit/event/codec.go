@@ -0,0 +1,10 @@
+package event
+
+import "encoding/json"
+
+// Codec turns events into JSON payloads and back, so that they can be stored. The ID,
+// aggregate ID and time of an event are kept apart from its payload.
+type Codec interface {
+	Encode(Event) (json.RawMessage, error)
+	Decode(base BaseEvent, eventType Type, payload json.RawMessage) (Event, error)
+}
@@ -0,0 +1,23 @@
+package event
+
+import (
+	"context"
+	"time"
+)
+
+// DeadLetter is an event a handler failed to handle on every attempt.
+type DeadLetter struct {
+	Event    Event
+	Handler  string // The type of the handler, e.g. "*creating.IncreaseUsersCounterOnUserCreated"
+	Attempts int
+	Err      string // The error of the last attempt
+	FailedAt time.Time
+}
+
+// DeadLetterStore keeps the events that could not be handled, so that they can be looked
+// into and replayed.
+type DeadLetterStore interface {
+	Save(ctx context.Context, letter DeadLetter) error
+}
+
+//go:generate mockery --name=DeadLetterStore --output=eventmocks --case=snake --outpkg=eventmocks
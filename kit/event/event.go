@@ -47,6 +47,16 @@ func NewBaseEvent(aggregateID string) BaseEvent {
 	}
 }
 
+// RestoreBaseEvent rebuilds the BaseEvent of an event that was stored, keeping its ID so
+// that it can still be told apart from any other.
+func RestoreBaseEvent(eventID, aggregateID string, occurredOn time.Time) BaseEvent {
+	return BaseEvent{
+		eventID:     eventID,
+		aggregateID: aggregateID,
+		occurredOn:  occurredOn,
+	}
+}
+
 func (e BaseEvent) ID() string {
 	return e.eventID
 }
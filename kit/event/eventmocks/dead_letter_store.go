@@ -0,0 +1,47 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package eventmocks
+
+import (
+	context "context"
+
+	event "github.com/AlexFJ498/middle-earth-leitmotifs-api/kit/event"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DeadLetterStore is an autogenerated mock type for the DeadLetterStore type
+type DeadLetterStore struct {
+	mock.Mock
+}
+
+// Save provides a mock function with given fields: ctx, letter
+func (_m *DeadLetterStore) Save(ctx context.Context, letter event.DeadLetter) error {
+	ret := _m.Called(ctx, letter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, event.DeadLetter) error); ok {
+		r0 = rf(ctx, letter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewDeadLetterStore creates a new instance of DeadLetterStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDeadLetterStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DeadLetterStore {
+	mock := &DeadLetterStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
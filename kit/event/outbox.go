@@ -0,0 +1,26 @@
+package event
+
+import (
+	"context"
+	"time"
+)
+
+// Outbox keeps the events to be published alongside the aggregates they are about, so
+// that both are saved in the same transaction and no event is lost between them. A relay
+// claims the events that have not been sent yet, publishes them, and marks them sent.
+//
+// Every event is kept under its ID, which doubles as its idempotency key: saving it
+// twice keeps the first copy, and its handlers can tell it apart from any other when it
+// is delivered more than once.
+type Outbox interface {
+	// Save adds the events to the outbox, in the transaction ctx carries if any.
+	Save(ctx context.Context, events []Event) error
+	// Claim returns up to limit unsent events, oldest first, and keeps them from being
+	// claimed again until lease has passed, so that an event whose relay stopped before
+	// marking it sent is eventually relayed again.
+	Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]Event, error)
+	// MarkSent records that the event was handed to its handlers.
+	MarkSent(ctx context.Context, eventID string, sentAt time.Time) error
+}
+
+//go:generate mockery --name=Outbox --output=eventmocks --case=snake --outpkg=eventmocks
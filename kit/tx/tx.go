@@ -0,0 +1,14 @@
+package tx
+
+import "context"
+
+// Manager runs a unit of work. Every repository call made with the context handed to
+// fn takes part in the same transaction, which is committed when fn returns nil and
+// rolled back otherwise.
+type Manager interface {
+	// WithinTx runs fn inside a transaction. Calls nested in an ongoing unit of work
+	// join it instead of starting a new one.
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+//go:generate mockery --name=Manager --output=txmocks --case=snake --outpkg=txmocks